@@ -0,0 +1,164 @@
+package main
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// A player can delete their account from the start page (POST /account/delete). The player
+// row, their sessions, their bots' tokens, profile image, chat messages and statistics are removed; the games
+// they played stay in the archive with their name replaced by deletedPlayerName. The rows
+// that tie a game to them keep the player's id negated: SQLite hands out a deleted rowid
+// again, so the next player to sign up must not inherit the deleted player's games. An
+// account cannot be deleted during a running game.
+
+const deletedPlayerName = "Deleted player"
+
+// playerRefColumns are the columns that hold a player id in a game's record.
+var playerRefColumns = [][2]string{
+	{"game_player", "player_id"},
+	{"game_action", "actor_player_id"},
+	{"game_action", "target_player_id"},
+	{"game_lovers", "player1_id"},
+	{"game_lovers", "player2_id"},
+	{"game_charmed", "player_id"},
+	{"game_role_model", "child_player_id"},
+	{"game_role_model", "model_player_id"},
+	{"cupid_selection", "cupid_player_id"},
+	{"cupid_selection", "first_player_id"},
+	{"cupid_selection", "second_player_id"},
+	{"game", "host_player_id"},
+}
+
+// inRunningGame reports whether the player is seated in a game that has started and not finished.
+func inRunningGame(db *sqlx.DB, playerID int64) bool {
+	var count int
+	db.Get(&count, `
+SELECT COUNT(*) FROM game_player gp JOIN game g ON g.rowid = gp.game_id
+WHERE gp.player_id = ? AND gp.is_observer = 0 AND g.status NOT IN ('lobby', 'finished')`, playerID)
+	return count > 0
+}
+
+// anonymizeName replaces a name where it stands on its own in a recorded description.
+func anonymizeName(desc, name string) string {
+	re := regexp.MustCompile(`(^|[^\pL\pN_])` + regexp.QuoteMeta(name) + `($|[^\pL\pN_])`)
+	return re.ReplaceAllString(desc, "${1}"+deletedPlayerName+"${2}")
+}
+
+// deleteAccount erases a player. It leaves any lobby they sit in and anonymizes the games
+// they played, all in one transaction.
+func deleteAccount(db *sqlx.DB, playerID int64) error {
+	name := getPlayerName(db, playerID)
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM game_player WHERE player_id = ? AND game_id IN (SELECT rowid FROM game WHERE status = 'lobby')`, playerID); err != nil {
+		return err
+	}
+
+	var actions []struct {
+		ID              int64  `db:"id"`
+		Description     string `db:"description"`
+		DescriptionArgs string `db:"description_args"`
+	}
+	if err := tx.Select(&actions, `
+SELECT rowid as id, description, description_args FROM game_action
+WHERE game_id IN (SELECT game_id FROM game_player WHERE player_id = ?)
+	OR actor_player_id = ? OR target_player_id = ?`, playerID, playerID, playerID); err != nil {
+		return err
+	}
+	for _, a := range actions {
+		args := strings.Split(a.DescriptionArgs, "\t")
+		for i, arg := range args {
+			if arg == name {
+				args[i] = deletedPlayerName
+			}
+		}
+		if _, err := tx.Exec("UPDATE game_action SET description = ?, description_args = ? WHERE rowid = ?",
+			anonymizeName(a.Description, name), strings.Join(args, "\t"), a.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, ref := range playerRefColumns {
+		if _, err := tx.Exec("UPDATE "+ref[0]+" SET "+ref[1]+" = -"+ref[1]+" WHERE "+ref[1]+" = ?", playerID); err != nil {
+			return err
+		}
+	}
+	for _, stmt := range []string{
+		"DELETE FROM session WHERE player_id = ?",
+		"DELETE FROM bot WHERE owner_player_id = ?",
+		"DELETE FROM player_oauth WHERE player_id = ?",
+		"DELETE FROM rejoin_code WHERE player_id = ?",
+		"DELETE FROM game_chat WHERE player_id = ?",
+		"DELETE FROM player_game_result WHERE player_id = ?",
+		"DELETE FROM player_image WHERE rowid = (SELECT profile_image_id FROM player WHERE rowid = ?)",
+		"DELETE FROM player WHERE rowid = ?",
+	} {
+		if _, err := tx.Exec(stmt, playerID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// disconnectPlayer closes the player's connections; the hub unregisters them as usual.
+func (h *Hub) disconnectPlayer(playerID int64) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for conn := range h.playerClients[playerID] {
+		conn.Close()
+	}
+}
+
+func (app *App) handleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	playerID, err := getPlayerIdFromSession(app.db, r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	lang := getLangFromCookie(r)
+	toast := func(key string) {
+		w.Header().Set("HX-Reswap", "none")
+		w.Write([]byte(renderToast(app.templates, app.logf, "error", T(lang, key))))
+	}
+
+	if inRunningGame(app.db, playerID) {
+		toast("err_account_in_game")
+		return
+	}
+	playerName := getPlayerName(app.db, playerID)
+	if err := deleteAccount(app.db, playerID); err != nil {
+		app.logf("ERROR [handleDeleteAccount: deleteAccount]: %v", err)
+		toast("err_something_wrong")
+		return
+	}
+	app.logf("Player deleted their account: name='%s', id=%d", playerName, playerID)
+
+	app.hubsMu.RLock()
+	for _, hub := range app.hubs {
+		hub.disconnectPlayer(playerID)
+		hub.forgetPlayerName(playerID)
+		hub.triggerBroadcast()
+	}
+	app.hubsMu.RUnlock()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+	w.Header().Set("HX-Redirect", "/")
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Account Deletion Tests
+// ============================================================================
+
+// deleteAccountAs posts the account deletion with a fresh session of the player.
+func (ctx *TestContext) deleteAccountAs(playerID int64) *httptest.ResponseRecorder {
+	signin := httptest.NewRecorder()
+	if err := setSessionCookie(ctx.app.db, signin, httptest.NewRequest("POST", "/signin", nil), playerID); err != nil {
+		ctx.t.Fatalf("setSessionCookie: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/account/delete", nil)
+	for _, c := range signin.Result().Cookies() {
+		req.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	ctx.app.handleDeleteAccount(rec, req)
+	return rec
+}
+
+func TestDeletedAccountsAreAnonymized(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "Val", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host, wolf, val := ids[0], ids[1], ids[2]
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(host, WSMessage{Action: "moderator_kill", TargetPlayerID: strconv.FormatInt(val, 10)})
+	if rec := ctx.deleteAccountAs(val); !strings.Contains(rec.Body.String(), T("en", "err_account_in_game")) {
+		t.Fatalf("the account should not be deleted during a running game, got %q", rec.Body.String())
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "moderator_kill", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	if status, _, _ := ctx.gameState(); status != "finished" {
+		t.Fatalf("killing the last wolf should end the game, got %q", status)
+	}
+	rec := ctx.deleteAccountAs(val)
+	if rec.Header().Get("HX-Redirect") != "/" {
+		t.Fatalf("the deletion should send the player home, got %q", rec.Body.String())
+	}
+
+	var count int
+	for _, q := range []string{
+		"SELECT COUNT(*) FROM player WHERE rowid = ?",
+		"SELECT COUNT(*) FROM session WHERE player_id = ?",
+		"SELECT COUNT(*) FROM player_game_result WHERE player_id = ?",
+		"SELECT COUNT(*) FROM game_player WHERE player_id = ?",
+		"SELECT COUNT(*) FROM game_action WHERE actor_player_id = ? OR target_player_id = ?",
+	} {
+		ctx.app.db.Get(&count, q, val, val)
+		if count != 0 {
+			t.Errorf("%s: the deleted player should be gone, got %d rows", q, count)
+		}
+	}
+	for _, e := range ctx.app.archiveTimeline(game.ID, "en") {
+		if strings.Contains(string(e.Description), "Val") {
+			t.Errorf("the archive should not name the deleted player, got %q", e.Description)
+		}
+	}
+	if tl := ctx.app.archiveTimeline(game.ID, "en"); len(tl) == 0 || !strings.Contains(string(tl[0].Description), "The host killed "+deletedPlayerName) {
+		t.Errorf("the host's kill should name a deleted player, got %+v", tl)
+	}
+
+	// the next player may get the freed rowid, but none of the old games
+	res := ctx.app.db.MustExec("INSERT INTO player (name, secret_code) VALUES ('Newcomer', 'x')")
+	newID, _ := res.LastInsertId()
+	if games, _ := getPlayerGames(ctx.app.db, newID); len(games) != 0 {
+		t.Errorf("a new player should not inherit the deleted player's games, got %+v", games)
+	}
+}
+
+func TestDeleteAccountRequiresASession(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	rec := httptest.NewRecorder()
+	ctx.app.handleDeleteAccount(rec, httptest.NewRequest("POST", "/account/delete", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("deleting without a session should be refused, got %d", rec.Code)
+	}
+}
+
+func TestDeleteAccountInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a player deleting their account ===")
+
+	gone := browser.signupPlayer(ctx.baseURL, "Gone")
+	stay := browser.signupPlayer(ctx.baseURL, "Stay")
+
+	gone.navigate(ctx.baseURL + "/")
+	gone.acceptConfirms()
+	gone.followLink("#btn-delete-account")
+	if _, err := gone.p().Element("#auth-name"); err != nil {
+		ctx.logger.LogDB("FAIL: still signed in after deletion")
+		t.Fatalf("the deleted player should be signed out: %v", err)
+	}
+
+	if err := stay.waitUntilCondition(`() => !document.querySelector('#player-list .player-card[player-name="Gone"]')`, "Gone left the lobby"); err != nil {
+		t.Errorf("the deleted player should leave the lobby: %v", err)
+	}
+	again := browser.signupPlayer(ctx.baseURL, "Gone")
+	if again.SecretCode == "" || again.SecretCode == gone.SecretCode {
+		t.Errorf("the name should be free for a new account, got code %q", again.SecretCode)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
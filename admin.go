@@ -0,0 +1,253 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// The admin API (/admin/v1) is for whoever runs the server, apart from the player-facing
+// routes and the JSON API: every call needs "Authorization: Bearer <admin_token>", and
+// without an admin_token in the config the API answers 404. It lists the games and the
+// players, force-finishes a running game (with a winner, or aborted into the next lobby
+// like the host's abort) and deletes stale records.
+
+type AdminGame struct {
+	ID         int64  `json:"id" db:"id"`
+	Name       string `json:"name" db:"name"` // the archived name for an archived game
+	Status     string `json:"status" db:"status"`
+	Round      int    `json:"round" db:"round"`
+	Winner     string `json:"winner,omitempty" db:"winner"`
+	Archived   bool   `json:"archived" db:"archived"`
+	FinishedAt string `json:"finished_at,omitempty" db:"finished_at"`
+	Players    int    `json:"players" db:"players"` // seated, observers not counted
+	Connected  int    `json:"connected"`
+}
+
+type AdminPlayer struct {
+	ID       int64  `json:"id" db:"id"`
+	Name     string `json:"name" db:"name"`
+	Bot      bool   `json:"bot" db:"bot"`
+	Games    int    `json:"games" db:"games"`
+	Sessions int    `json:"sessions" db:"sessions"`
+}
+
+type adminFinish struct {
+	Winner string `json:"winner,omitempty"` // empty aborts the game
+}
+
+type adminCleanup struct {
+	FinishedBeforeDays int `json:"finished_before_days,omitempty"` // 0 keeps the archive
+}
+
+// AdminCleanupResult counts what a cleanup deleted.
+type AdminCleanupResult struct {
+	EmptyLobbies  int64 `json:"empty_lobbies"`
+	ArchivedGames int64 `json:"archived_games"`
+	Sessions      int64 `json:"sessions"`
+}
+
+// adminWinners are the winners endGame records.
+var adminWinners = map[string]bool{
+	"villagers": true, "werewolves": true, "lovers": true, "tanner": true,
+	"piper": true, "serial_killer": true, "white_werewolf": true,
+}
+
+const adminGameSelect = `
+SELECT g.rowid AS id, CASE WHEN g.name != '' THEN g.name ELSE g.archived_name END AS name,
+	g.status, g.round, COALESCE(g.winner, '') AS winner, g.name = '' AS archived,
+	COALESCE(g.finished_at, '') AS finished_at,
+	(SELECT COUNT(*) FROM game_player gp WHERE gp.game_id = g.rowid AND gp.is_observer = 0) AS players
+FROM game g`
+
+// adminAuthorized checks the admin token and writes the error when it does not match.
+func (app *App) adminAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	if app.adminToken == "" {
+		http.NotFound(w, r)
+		return false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(app.adminToken)) != 1 {
+		writeAPIError(w, http.StatusUnauthorized, "admin token required")
+		return false
+	}
+	return true
+}
+
+// connectedCount is how many players have the game open; games without a hub have none.
+func (app *App) connectedCount(name string) int {
+	app.hubsMu.RLock()
+	h := app.hubs[name]
+	app.hubsMu.RUnlock()
+	if h == nil || name == "" {
+		return 0
+	}
+	return len(h.connectedPlayerIDs())
+}
+
+func (app *App) adminGame(id int64) (*AdminGame, error) {
+	var g AdminGame
+	if err := app.db.Get(&g, adminGameSelect+" WHERE g.rowid = ?", id); err != nil {
+		return nil, err
+	}
+	if !g.Archived {
+		g.Connected = app.connectedCount(g.Name)
+	}
+	return &g, nil
+}
+
+// handleAdminGames lists every game, newest first; ?status= keeps one status.
+func (app *App) handleAdminGames(w http.ResponseWriter, r *http.Request) {
+	if !app.adminAuthorized(w, r) {
+		return
+	}
+	query, args := adminGameSelect, []any{}
+	if status := r.URL.Query().Get("status"); status != "" {
+		query += " WHERE g.status = ?"
+		args = append(args, status)
+	}
+	games := []AdminGame{}
+	if err := app.db.Select(&games, query+" ORDER BY g.rowid DESC", args...); err != nil {
+		app.logf("ERROR [handleAdminGames: db.Select]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	for i := range games {
+		if !games[i].Archived {
+			games[i].Connected = app.connectedCount(games[i].Name)
+		}
+	}
+	writeJSON(w, http.StatusOK, games)
+}
+
+func (app *App) handleAdminPlayers(w http.ResponseWriter, r *http.Request) {
+	if !app.adminAuthorized(w, r) {
+		return
+	}
+	players := []AdminPlayer{}
+	if err := app.db.Select(&players, `
+SELECT p.rowid AS id, p.name,
+	EXISTS (SELECT 1 FROM bot b WHERE b.player_id = p.rowid) AS bot,
+	(SELECT COUNT(*) FROM game_player gp WHERE gp.player_id = p.rowid) AS games,
+	(SELECT COUNT(*) FROM session s WHERE s.player_id = p.rowid) AS sessions
+FROM player p ORDER BY p.name`); err != nil {
+		app.logf("ERROR [handleAdminPlayers: db.Select]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	writeJSON(w, http.StatusOK, players)
+}
+
+// handleAdminFinishGame ends a running game: endGame with the given winner, or an abort
+// into the next lobby without one.
+func (app *App) handleAdminFinishGame(w http.ResponseWriter, r *http.Request) {
+	if !app.adminAuthorized(w, r) {
+		return
+	}
+	var body adminFinish
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		writeAPIError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	if body.Winner != "" && !adminWinners[body.Winner] {
+		writeAPIError(w, http.StatusBadRequest, "unknown winner")
+		return
+	}
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	var name string
+	if err := app.db.Get(&name, "SELECT name FROM game WHERE rowid = ?", id); err != nil {
+		writeAPIError(w, http.StatusNotFound, "no such game")
+		return
+	}
+	// finishing the game is a move like any player's: it waits for the one in progress, and
+	// the game is read again once it is this move's turn
+	h := app.getOrCreateHub(name)
+	h.moveMu.Lock()
+	defer h.moveMu.Unlock()
+	var game Game
+	if err := app.db.Get(&game, "SELECT rowid as id, name, status, round, ai_enabled, winner, paused FROM game WHERE rowid = ?", id); err != nil {
+		writeAPIError(w, http.StatusNotFound, "no such game")
+		return
+	}
+	if !gameRunning(&game) {
+		writeAPIError(w, http.StatusConflict, "the game is not running")
+		return
+	}
+
+	view, err := app.adminGame(game.ID)
+	if err != nil {
+		app.logf("ERROR [handleAdminFinishGame: adminGame]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	if body.Winner == "" {
+		// the aborted game's row makes way for the next lobby, so the reply is the game as it ended
+		if !h.abortGame(&game, 0, "the admin") {
+			writeAPIError(w, http.StatusInternalServerError, "could not abort the game")
+			return
+		}
+		view.Status = "aborted"
+		writeJSON(w, http.StatusOK, view)
+		return
+	}
+	h.logf("The admin finished game %d, winner: %s", game.ID, body.Winner)
+	h.endGame(&game, body.Winner)
+	if view, err = app.adminGame(game.ID); err != nil {
+		app.logf("ERROR [handleAdminFinishGame: adminGame]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	writeJSON(w, http.StatusOK, view)
+}
+
+// handleAdminCleanup deletes stale records: lobbies nobody sits in or has open, sessions
+// that expired or whose player is gone and, when asked, archived games finished before a number of days.
+// Statistics are kept.
+func (app *App) handleAdminCleanup(w http.ResponseWriter, r *http.Request) {
+	if !app.adminAuthorized(w, r) {
+		return
+	}
+	var body adminCleanup
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		writeAPIError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	if body.FinishedBeforeDays < 0 {
+		writeAPIError(w, http.StatusBadRequest, "finished_before_days must not be negative")
+		return
+	}
+
+	var result AdminCleanupResult
+	var lobbies []struct {
+		ID   int64  `db:"id"`
+		Name string `db:"name"`
+	}
+	app.db.Select(&lobbies, `
+SELECT rowid AS id, name FROM game g
+WHERE status = 'lobby' AND NOT EXISTS (SELECT 1 FROM game_player gp WHERE gp.game_id = g.rowid)`)
+	for _, l := range lobbies {
+		if app.connectedCount(l.Name) == 0 {
+			deleteGame(app.db, l.ID)
+			result.EmptyLobbies++
+		}
+	}
+
+	if body.FinishedBeforeDays > 0 {
+		var ids []int64
+		app.db.Select(&ids, "SELECT rowid FROM game WHERE name = '' AND status = 'finished' AND finished_at < datetime('now', ?)",
+			"-"+strconv.Itoa(body.FinishedBeforeDays)+" days")
+		for _, id := range ids {
+			deleteGame(app.db, id)
+		}
+		result.ArchivedGames = int64(len(ids))
+	}
+
+	if res, err := app.db.Exec("DELETE FROM session WHERE player_id NOT IN (SELECT rowid FROM player) OR expires_at <= datetime('now')"); err == nil {
+		result.Sessions, _ = res.RowsAffected()
+	}
+	app.logf("Admin cleanup: %d empty lobbies, %d archived games, %d sessions deleted", result.EmptyLobbies, result.ArchivedGames, result.Sessions)
+	writeJSON(w, http.StatusOK, result)
+}
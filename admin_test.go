@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// ============================================================================
+// Admin API Tests
+// ============================================================================
+
+func TestAdminAPINeedsTheToken(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	c := ctx.apiClient()
+	if code := ctx.apiCall(c, "GET", "/admin/v1/games", nil, nil); code != http.StatusNotFound {
+		t.Errorf("without an admin token the admin API should be off, got %d", code)
+	}
+	ctx.app.adminToken = "s3cret"
+	if code := ctx.apiCall(&http.Client{Transport: bearer("wrong")}, "GET", "/admin/v1/games", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("a wrong token should be refused, got %d", code)
+	}
+	ctx.apiCall(c, "POST", "/api/v1/session", apiSignin{Name: "Alice"}, nil)
+	if code := ctx.apiCall(c, "GET", "/admin/v1/players", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("a player's session should not open the admin API, got %d", code)
+	}
+}
+
+func TestAdminListsFinishesAndCleansUp(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+	ctx.app.adminToken = "s3cret"
+	ctx.apiClient() // waits for the server
+	admin := &http.Client{Transport: bearer("s3cret")}
+
+	ctx.seedGame("day", 2,
+		[]string{"Host", "Wolf", "V1"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager})
+	ctx.app.db.MustExec("INSERT INTO game (name) VALUES ('stale')")
+	ctx.app.db.MustExec("INSERT INTO session (token, player_id) VALUES (1, 9999)")
+
+	var games []AdminGame
+	if code := ctx.apiCall(admin, "GET", "/admin/v1/games?status=day", nil, &games); code != http.StatusOK || len(games) != 1 || games[0].Name != "test-game" || games[0].Players != 3 || games[0].Round != 2 {
+		t.Fatalf("the running game should be listed, got %d %+v", code, games)
+	}
+	var players []AdminPlayer
+	if ctx.apiCall(admin, "GET", "/admin/v1/players", nil, &players); len(players) != 3 || players[0].Games != 1 {
+		t.Errorf("the players should be listed with their games, got %+v", players)
+	}
+
+	var cleaned AdminCleanupResult
+	if ctx.apiCall(admin, "POST", "/admin/v1/cleanup", nil, &cleaned); cleaned.EmptyLobbies != 1 || cleaned.Sessions != 1 {
+		t.Errorf("the empty lobby and the orphaned session should go, got %+v", cleaned)
+	}
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("the running game should survive the cleanup, got %q", status)
+	}
+
+	finish := "/admin/v1/games/" + strconv.FormatInt(games[0].ID, 10) + "/finish"
+	if code := ctx.apiCall(admin, "POST", finish, adminFinish{Winner: "nobody"}, nil); code != http.StatusBadRequest {
+		t.Errorf("an unknown winner should be refused, got %d", code)
+	}
+	var finished AdminGame
+	if code := ctx.apiCall(admin, "POST", finish, adminFinish{Winner: "villagers"}, &finished); code != http.StatusOK || finished.Status != "finished" {
+		t.Fatalf("the admin should finish the game, got %d %+v", code, finished)
+	}
+	if status, _, winner := ctx.gameState(); status != "finished" || winner != "villagers" {
+		t.Errorf("the game should be over with the villagers winning, got %q/%q", status, winner)
+	}
+	if code := ctx.apiCall(admin, "POST", finish, nil, nil); code != http.StatusConflict {
+		t.Errorf("a finished game cannot be finished again, got %d", code)
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// The JSON API (/api/v1) drives the same game engine as the pages, for other clients.
+// POST /api/v1/session signs in and sets the session cookie every other call needs (bots,
+// bot.go, send their token instead). A game
+// is read as the player sees it on their page, with the same cards masked; actions are the
+// messages the page sends over the WebSocket, flat or in a versioned envelope, posted as
+// JSON to .../actions and handled like a WebSocket message. An action the game refuses is not an HTTP error: the reply is the game
+// as it stands, and the reason goes to the player's open pages as a toast.
+
+type APIPlayer struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Role     string `json:"role,omitempty"` // "Unknown" when the player may not see it, empty in the lobby
+	Team     string `json:"team,omitempty"`
+	Alive    bool   `json:"alive"`
+	Observer bool   `json:"observer,omitempty"`
+	Bot      bool   `json:"bot,omitempty"`
+}
+
+type APIGame struct {
+	Name    string      `json:"name"`
+	Status  string      `json:"status"`
+	Round   int         `json:"round"`
+	Winner  string      `json:"winner,omitempty"`
+	Paused  bool        `json:"paused"`
+	You     APIPlayer   `json:"you"`
+	Players []APIPlayer `json:"players"`
+}
+
+type APIGameSummary struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Round  int    `json:"round"`
+	Winner string `json:"winner,omitempty"`
+	Role   string `json:"role,omitempty"`
+	Team   string `json:"team,omitempty"`
+	Alive  bool   `json:"alive"`
+	Won    bool   `json:"won"`
+}
+
+type APIHistoryEntry struct {
+	ID          int64  `json:"id"`
+	Description string `json:"description"`
+}
+
+type APIVote struct {
+	TargetID int64  `json:"target_id"`
+	Target   string `json:"target"`
+	Votes    int    `json:"votes"` // weighted, like the count on the cards
+}
+
+// APIVotes is the open day vote; with secret votes only the total is given.
+type APIVotes struct {
+	Round  int       `json:"round"`
+	Secret bool      `json:"secret"`
+	Total  int       `json:"total"` // weighted, passes included
+	Votes  []APIVote `json:"votes"`
+}
+
+type apiSignin struct {
+	Name       string `json:"name"`
+	SecretCode string `json:"secret_code"`
+}
+
+type apiSession struct {
+	PlayerID   int64  `json:"player_id"`
+	Name       string `json:"name"`
+	SecretCode string `json:"secret_code,omitempty"` // only when the call signed up: it is not shown again
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func apiPlayer(p Player, showRole bool) APIPlayer {
+	ap := APIPlayer{ID: p.PlayerID, Name: p.Name, Alive: p.IsAlive, Observer: p.IsObserver}
+	if showRole {
+		ap.Role, ap.Team = p.RoleName, p.Team
+	}
+	return ap
+}
+
+// getGameByName looks a game up without opening a lobby for an unknown name.
+func getGameByName(db *sqlx.DB, name string) (*Game, error) {
+	var game Game
+	err := db.Get(&game, "SELECT rowid as id, name, status, round, ai_enabled, winner, paused FROM game WHERE name = ? AND name != ''", name)
+	return &game, err
+}
+
+// apiGameView is the game as the player sees it on their page.
+func apiGameView(db *sqlx.DB, game *Game, playerID int64) (*APIGame, error) {
+	viewer, err := getPlayerInGame(db, game.ID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	players, err := getPlayersByGameId(db, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	dealt := game.Status != "lobby"
+	viewer = drunkView(game, viewer)
+	visible := applyCardVisibility(viewer, selfFirstPlayers(maskDrunkSelf(game, players, playerID), playerID), getSeerInvestigated(db, game.ID, playerID), viewerReveal(db, game.ID, viewer))
+
+	view := &APIGame{Name: game.Name, Status: game.Status, Round: game.Round, Paused: game.Paused, You: apiPlayer(viewer, dealt)}
+	if game.Winner != nil {
+		view.Winner = *game.Winner
+	}
+	bots := botIDs(db, game.ID)
+	view.You.Bot = bots[viewer.PlayerID]
+	for _, p := range visible {
+		ap := apiPlayer(p, dealt)
+		ap.Bot = bots[p.PlayerID]
+		view.Players = append(view.Players, ap)
+	}
+	return view, nil
+}
+
+// apiGameFor resolves the game named in the path for the signed-in player, who must be in
+// it. It writes the error itself and returns nil when the request cannot go on.
+func (app *App) apiGameFor(w http.ResponseWriter, r *http.Request) (*Game, int64) {
+	playerID, err := apiPlayerID(app.db, r)
+	if err != nil {
+		writeAPIError(w, http.StatusUnauthorized, "not signed in")
+		return nil, 0
+	}
+	game, err := getGameByName(app.db, r.PathValue("name"))
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, "no such game")
+		return nil, 0
+	}
+	if !isPlayerInGame(app.db, game.ID, playerID) {
+		writeAPIError(w, http.StatusForbidden, "not in this game")
+		return nil, 0
+	}
+	return game, playerID
+}
+
+func (app *App) writeAPIGame(w http.ResponseWriter, game *Game, playerID int64) {
+	view, err := apiGameView(app.db, game, playerID)
+	if err != nil {
+		app.logf("ERROR [writeAPIGame: apiGameView]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	writeJSON(w, http.StatusOK, view)
+}
+
+// handleAPISession signs in like the sign-in form, from a JSON body.
+func (app *App) handleAPISession(w http.ResponseWriter, r *http.Request) {
+	var body apiSignin
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	playerID, secretCode, errKey := app.signin(body.Name, body.SecretCode)
+	if errKey == "err_invalid_credentials" {
+		app.logf("Failed API sign-in as '%s' from %s", body.Name, clientIP(r))
+	}
+	if errKey != "" {
+		writeAPIError(w, http.StatusUnauthorized, T("en", errKey))
+		return
+	}
+	if err := setSessionCookie(app.db, w, r, playerID); err != nil {
+		app.logf("ERROR [handleAPISession: setSessionCookie]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	writeJSON(w, http.StatusOK, apiSession{PlayerID: playerID, Name: getPlayerName(app.db, playerID), SecretCode: secretCode})
+}
+
+func (app *App) handleAPIGames(w http.ResponseWriter, r *http.Request) {
+	playerID, err := apiPlayerID(app.db, r)
+	if err != nil {
+		writeAPIError(w, http.StatusUnauthorized, "not signed in")
+		return
+	}
+	games, err := getPlayerGames(app.db, playerID)
+	if err != nil {
+		app.logf("ERROR [handleAPIGames: getPlayerGames]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	summaries := make([]APIGameSummary, 0, len(games))
+	for _, g := range games {
+		s := APIGameSummary{Name: g.Name, Status: g.Status, Round: g.Round, Winner: g.Winner, Alive: g.PlayerAlive, Won: g.Won}
+		if g.Status != "lobby" {
+			s.Role, s.Team = g.PlayerRole, g.PlayerTeam
+		}
+		summaries = append(summaries, s)
+	}
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+func (app *App) handleAPIGame(w http.ResponseWriter, r *http.Request) {
+	if game, playerID := app.apiGameFor(w, r); game != nil {
+		app.writeAPIGame(w, game, playerID)
+	}
+}
+
+// handleAPIJoin seats the player like opening the game page does: in the lobby, or as an
+// observer once the game has started.
+func (app *App) handleAPIJoin(w http.ResponseWriter, r *http.Request) {
+	playerID, err := apiPlayerID(app.db, r)
+	if err != nil {
+		writeAPIError(w, http.StatusUnauthorized, "not signed in")
+		return
+	}
+	h := app.getOrCreateHub(r.PathValue("name"))
+	h.addPlayerToLobby(playerID)
+	game, err := h.getGame()
+	if err != nil || !isPlayerInGame(app.db, game.ID, playerID) {
+		writeAPIError(w, http.StatusConflict, "could not join the game")
+		return
+	}
+	app.writeAPIGame(w, game, playerID)
+}
+
+func (app *App) handleAPIHistory(w http.ResponseWriter, r *http.Request) {
+	game, playerID := app.apiGameFor(w, r)
+	if game == nil {
+		return
+	}
+	entries := []APIHistoryEntry{}
+	for _, e := range buildHistoryEntries(app.db, playerID, game, getLangFromCookie(r)) {
+		entries = append(entries, APIHistoryEntry{ID: e.ID, Description: e.Description})
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (app *App) handleAPIVotes(w http.ResponseWriter, r *http.Request) {
+	game, _ := app.apiGameFor(w, r)
+	if game == nil {
+		return
+	}
+	if game.Status != "day" {
+		writeAPIError(w, http.StatusConflict, "no vote is open")
+		return
+	}
+	counts, total, err := getVoteCounts(app.db, game.ID, game.Round, "day", dayVoteAction(app.db, game.ID, game.Round))
+	if err != nil {
+		app.logf("ERROR [handleAPIVotes: getVoteCounts]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	votes := APIVotes{Round: game.Round, Secret: secretVotesEnabled(app.db, game.ID), Total: total, Votes: []APIVote{}}
+	if !votes.Secret {
+		for targetID, n := range counts {
+			votes.Votes = append(votes.Votes, APIVote{TargetID: targetID, Target: getPlayerName(app.db, targetID), Votes: n})
+		}
+		sort.Slice(votes.Votes, func(i, j int) bool {
+			if votes.Votes[i].Votes != votes.Votes[j].Votes {
+				return votes.Votes[i].Votes > votes.Votes[j].Votes
+			}
+			return votes.Votes[i].Target < votes.Votes[j].Target
+		})
+	}
+	writeJSON(w, http.StatusOK, votes)
+}
+
+// handleAPIAction hands a WebSocket message to the game and replies with the game after it.
+// A message the WebSocket would not take is a 400.
+func (app *App) handleAPIAction(w http.ResponseWriter, r *http.Request) {
+	game, playerID := app.apiGameFor(w, r)
+	if game == nil {
+		return
+	}
+	raw, err := io.ReadAll(io.LimitReader(r.Body, 1<<16))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "could not read the body")
+		return
+	}
+	msg, _, decodeErr := decodeWSMessage(raw)
+	if decodeErr != nil {
+		writeAPIError(w, http.StatusBadRequest, T("en", decodeErr.Key))
+		return
+	}
+
+	h := app.getOrCreateHub(game.Name)
+	dispatchWSMessage(&Client{hub: h, playerID: playerID, lang: getLangFromCookie(r)}, msg)
+	game, err = h.getGame()
+	if err != nil {
+		app.logf("ERROR [handleAPIAction: getGame]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	app.writeAPIGame(w, game, playerID)
+}
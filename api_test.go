@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// JSON API Tests
+// ============================================================================
+
+// apiClient is an HTTP client that keeps the session cookie between calls. The test server
+// starts in the background, so it waits until the server takes connections.
+func (ctx *TestContext) apiClient() *http.Client {
+	ctx.t.Helper()
+	addr := strings.TrimPrefix(ctx.baseURL, "http://")
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			ctx.t.Fatalf("the test server did not come up: %v", err)
+		}
+	}
+	jar, _ := cookiejar.New(nil)
+	return &http.Client{Jar: jar}
+}
+
+// apiCall sends a request to the test server and decodes the JSON reply into out.
+func (ctx *TestContext) apiCall(c *http.Client, method, path string, body, out any) int {
+	ctx.t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	req, _ := http.NewRequest(method, ctx.baseURL+path, &buf)
+	resp, err := c.Do(req)
+	if err != nil {
+		ctx.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		json.NewDecoder(resp.Body).Decode(out)
+	}
+	return resp.StatusCode
+}
+
+func TestAPISignsInAndJoins(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	c := ctx.apiClient()
+	if code := ctx.apiCall(c, "GET", "/api/v1/games", nil, nil); code != http.StatusUnauthorized {
+		t.Fatalf("the API should want a session, got %d", code)
+	}
+
+	var session apiSession
+	if code := ctx.apiCall(c, "POST", "/api/v1/session", apiSignin{Name: "Alice"}, &session); code != http.StatusOK || session.SecretCode == "" {
+		t.Fatalf("signing up should return the new secret code, got %d %+v", code, session)
+	}
+	if code := ctx.apiCall(c, "GET", "/api/v1/games/api-game", nil, nil); code != http.StatusNotFound {
+		t.Errorf("an unknown game should be a 404, got %d", code)
+	}
+
+	var game APIGame
+	if code := ctx.apiCall(c, "POST", "/api/v1/games/api-game/join", nil, &game); code != http.StatusOK || game.Status != "lobby" || game.You.Name != "Alice" || game.You.Role != "" {
+		t.Fatalf("joining should seat Alice in the lobby without a role, got %d %+v", code, game)
+	}
+	var games []APIGameSummary
+	if ctx.apiCall(c, "GET", "/api/v1/games", nil, &games); len(games) != 1 || games[0].Name != "api-game" {
+		t.Errorf("Alice's games should list the lobby, got %+v", games)
+	}
+
+	other := ctx.apiClient()
+	ctx.apiCall(other, "POST", "/api/v1/session", apiSignin{Name: "Alice", SecretCode: "wrong"}, nil)
+	if code := ctx.apiCall(other, "GET", "/api/v1/games", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("a wrong secret code should not sign in, got %d", code)
+	}
+}
+
+func TestAPIPlaysTheGameAsThePlayerSeesIt(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	wolf := ids[1]
+
+	c := ctx.apiClient()
+	ctx.apiCall(c, "POST", "/api/v1/session", apiSignin{Name: "V1", SecretCode: "x"}, nil)
+
+	var game APIGame
+	if status := ctx.apiCall(c, "GET", "/api/v1/games/test-game", nil, &game); status != http.StatusOK || game.You.Role != "Villager" || len(game.Players) != 4 {
+		t.Fatalf("V1 should see the game with their own card, got %d %+v", status, game)
+	}
+	for _, p := range game.Players {
+		if p.ID == wolf && p.Role != "Unknown" {
+			t.Errorf("the wolf's card should stay hidden from V1, got %+v", p)
+		}
+	}
+
+	ctx.apiCall(c, "POST", "/api/v1/games/test-game/actions", WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(wolf, 10)}, &game)
+	var votes APIVotes
+	if ctx.apiCall(c, "GET", "/api/v1/games/test-game/votes", nil, &votes); len(votes.Votes) != 1 || votes.Votes[0].Target != "Wolf" || votes.Votes[0].Votes != 1 {
+		t.Errorf("V1's vote should be counted against the wolf, got %+v", votes)
+	}
+	if status := ctx.apiCall(c, "POST", "/api/v1/games/test-game/actions", map[string]string{}, nil); status != http.StatusBadRequest {
+		t.Errorf("a message without an action should be refused, got %d", status)
+	}
+}
+
+func TestOpenAPIDocumentCoversTheAPI(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	var doc struct {
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if code := ctx.apiCall(ctx.apiClient(), "GET", "/api/v1/openapi.json", nil, &doc); code != http.StatusOK {
+		t.Fatalf("the document should be served, got %d", code)
+	}
+	for _, route := range apiRoutes {
+		if _, ok := doc.Paths[route.Path][strings.ToLower(route.Method)]; !ok {
+			t.Errorf("the document should describe %s %s", route.Method, route.Path)
+		}
+	}
+	for schema, prop := range map[string]string{"APIGame": "players", "APIPlayer": "role", "WSMessage": "action", "apiSignin": "secret_code"} {
+		if _, ok := doc.Components.Schemas[schema].Properties[prop]; !ok {
+			t.Errorf("the %s schema should have %q, got %v", schema, prop, doc.Components.Schemas[schema])
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Every finished game is kept: when the next lobby opens under the same name, openNewLobby
+// archives the finished game instead of deleting it, moving its name to archived_name so the
+// name is free again. /history lists the finished games and /history/{id} shows one game's
+// full timeline; the game is over, so nothing in it is hidden any more.
+
+type ArchivedGame struct {
+	ID           int64  `db:"id"`
+	Name         string `db:"name"`
+	Winner       string `db:"winner"`
+	FinishedAt   string `db:"finished_at"`
+	Participants string `db:"participants"` // names, comma-separated
+}
+
+type ArchivePageData struct {
+	Games    []ArchivedGame
+	Game     *ArchivedGame // the game whose timeline is shown, nil on the list
+	Timeline []HistoryEntry
+	StyleTag template.HTML
+	Lang     string
+}
+
+// archivedGamesQuery selects finished games, archived or not, newest first.
+const archivedGamesQuery = `
+SELECT g.rowid as id, CASE WHEN g.archived_name != '' THEN g.archived_name ELSE g.name END as name,
+	IFNULL(g.winner, '') as winner, IFNULL(g.finished_at, '') as finished_at,
+	IFNULL((SELECT GROUP_CONCAT(p.name, ', ') FROM game_player gp JOIN player p ON p.rowid = gp.player_id
+		WHERE gp.game_id = g.rowid AND gp.is_observer = 0), '') as participants
+FROM game g
+WHERE g.status = 'finished'`
+
+// archiveGame frees a finished game's name and keeps the rest of its record. The undo
+// checkpoints are only needed while the game runs.
+func (h *Hub) archiveGame(gameID int64) {
+	h.db.Exec("DELETE FROM game_checkpoint WHERE game_id = ?", gameID)
+	if _, err := h.db.Exec("UPDATE game SET archived_name = name, name = '' WHERE rowid = ?", gameID); err != nil {
+		h.logError("archiveGame: db.Exec", err)
+		deleteGame(h.db, gameID)
+		return
+	}
+	h.logf("Game %d archived", gameID)
+}
+
+// deleteGame removes a game that never finished, with everything recorded for it.
+func deleteGame(db *sqlx.DB, gameID int64) {
+	db.Exec("DELETE FROM game_action WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM game_lovers WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM game_charmed WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM game_spare_role WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM game_role_model WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM game_role_config WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM game_hidden_pack WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM game_chat WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM game_checkpoint WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM rejoin_code WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM game_player WHERE game_id = ?", gameID)
+	db.Exec("DELETE FROM game WHERE rowid = ?", gameID)
+}
+
+// archiveTimeline returns every recorded event of a finished game, translated.
+func (app *App) archiveTimeline(gameID int64, lang string) []HistoryEntry {
+	var rows []struct {
+		ID              int64  `db:"id"`
+		Description     string `db:"description"`
+		DescriptionKey  string `db:"description_key"`
+		DescriptionArgs string `db:"description_args"`
+	}
+	app.db.Select(&rows, `
+		SELECT rowid as id, description, description_key, description_args
+		FROM game_action
+		WHERE game_id = ? AND description != ''
+		ORDER BY rowid ASC`, gameID)
+	entries := make([]HistoryEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, HistoryEntry{ID: row.ID, Description: historyDescription(app.db, RevealFull, row.Description, row.DescriptionKey, row.DescriptionArgs, lang)})
+	}
+	return entries
+}
+
+func (app *App) handleArchive(w http.ResponseWriter, r *http.Request) {
+	data := ArchivePageData{StyleTag: app.pageStyleTag, Lang: getLangFromCookie(r)}
+	if err := app.db.Select(&data.Games, archivedGamesQuery+" ORDER BY g.rowid DESC"); err != nil {
+		app.logf("ERROR [handleArchive: db.Select]: %v", err)
+		http.Error(w, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+	app.templates.ExecuteTemplate(w, "archive.html", data)
+}
+
+func (app *App) handleArchivedGame(w http.ResponseWriter, r *http.Request) {
+	gameID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	var game ArchivedGame
+	if err := app.db.Get(&game, archivedGamesQuery+" AND g.rowid = ?", gameID); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	lang := getLangFromCookie(r)
+	data := ArchivePageData{Game: &game, Timeline: app.archiveTimeline(gameID, lang), StyleTag: app.pageStyleTag, Lang: lang}
+	app.templates.ExecuteTemplate(w, "archive.html", data)
+}
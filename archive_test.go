@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Game Archive Helpers
+// ============================================================================
+
+// finishGameInBrowser plays a short game to its end in the browser: the host kills the
+// werewolf from the moderator panel on the first night and the village wins. It returns the
+// players and the wolf.
+func finishGameInBrowser(ctx *TestContext, browser *TestBrowser) (players []*TestPlayer, wolf *TestPlayer) {
+	players = startGameWithRoles(browser, ctx.baseURL, []string{"Host", "P2", "P3"}, RoleWerewolf, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	werewolves, _ := findPlayersByRole(players)
+	wolf = werewolves[0]
+
+	host := players[0]
+	host.submitFormWithValues("moderator-form", map[string]string{
+		"target_player_id": host.optionValue("#moderator-player", wolf.Name),
+		"action":           "moderator_kill",
+	})
+	if err := host.waitUntilCondition(`() => document.querySelector('#game-content')?.dataset.phase === 'finished'`, "game finished"); err != nil {
+		ctx.logger.LogDB("FAIL: game not finished")
+		ctx.t.Fatalf("killing the only wolf should end the game: %v", err)
+	}
+	return players, wolf
+}
+
+// ============================================================================
+// Game Archive Tests
+// ============================================================================
+
+func TestFinishedGamesAreArchived(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host, wolf := ids[0], ids[1]
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(host, WSMessage{Action: "moderator_kill", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	if status, _, _ := ctx.gameState(); status != "finished" {
+		t.Fatalf("killing the last wolf should end the game, got %q", status)
+	}
+	ctx.sendWS(host, WSMessage{Action: "new_game"})
+	next, _ := ctx.hub().getGame()
+	if next.ID == game.ID || next.Status != "lobby" {
+		t.Fatalf("a new lobby should open under the game's name, got game %d (%s)", next.ID, next.Status)
+	}
+
+	rec := httptest.NewRecorder()
+	ctx.app.handleArchive(rec, httptest.NewRequest("GET", "/history", nil))
+	page := rec.Body.String()
+	gameLink := "/history/" + strconv.FormatInt(game.ID, 10)
+	if rec.Code != http.StatusOK || !strings.Contains(page, gameLink) || !strings.Contains(page, "test-game") || !strings.Contains(page, "Villagers win") || !strings.Contains(page, "Wolf") {
+		t.Fatalf("the finished game should be listed with its winner and players, got %d:\n%s", rec.Code, page)
+	}
+
+	req := httptest.NewRequest("GET", gameLink, nil)
+	req.SetPathValue("id", strconv.FormatInt(game.ID, 10))
+	rec = httptest.NewRecorder()
+	ctx.app.handleArchivedGame(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "The host killed Wolf") {
+		t.Errorf("the archived game should show its full timeline, got %d:\n%s", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/history/"+strconv.FormatInt(next.ID, 10), nil)
+	req.SetPathValue("id", strconv.FormatInt(next.ID, 10))
+	rec = httptest.NewRecorder()
+	ctx.app.handleArchivedGame(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("a game that has not finished should not be in the archive, got %d", rec.Code)
+	}
+}
+
+func TestFinishedGamesExportAsJSON(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host, wolf := ids[0], ids[1]
+	game, _ := ctx.hub().getGame()
+	gameID := strconv.FormatInt(game.ID, 10)
+
+	export := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/history/"+gameID+"/export.json", nil)
+		req.SetPathValue("id", gameID)
+		rec := httptest.NewRecorder()
+		ctx.app.handleGameExport(rec, req)
+		return rec
+	}
+	if rec := export(); rec.Code != http.StatusNotFound {
+		t.Fatalf("a running game should not be exported, got %d", rec.Code)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "moderator_kill", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	rec := export()
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("the finished game should export as JSON, got %d (%s)", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var e GameExport
+	if err := json.NewDecoder(rec.Body).Decode(&e); err != nil {
+		t.Fatalf("the export should decode: %v", err)
+	}
+	if e.ID != game.ID || e.Winner != "villagers" || len(e.Players) != 4 {
+		t.Fatalf("the export should hold the game and its four players, got %+v", e)
+	}
+	for _, p := range e.Players {
+		if p.Name == "Wolf" && (p.Role != "Werewolf" || p.Alive || p.Won) {
+			t.Errorf("the wolf should be exported dead and beaten, got %+v", p)
+		}
+		if p.Name == "V1" && (p.Role != "Villager" || !p.Alive || !p.Won) {
+			t.Errorf("V1 should be exported alive and winning, got %+v", p)
+		}
+	}
+	var kill *ActionExport
+	for i, a := range e.Actions {
+		if a.Type == ActionModeratorKill {
+			kill = &e.Actions[i]
+		}
+	}
+	if kill == nil || kill.Actor != "Wolf" || kill.Visibility != VisibilityModerator || !strings.Contains(kill.Description, "The host killed Wolf") {
+		t.Errorf("the export should hold every action, hidden ones included, got %+v", e.Actions)
+	}
+}
+
+func TestGameArchiveInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the archive of finished games ===")
+
+	players, wolf := finishGameInBrowser(ctx, browser)
+	host := players[0]
+
+	host.navigate(ctx.baseURL + "/")
+	host.followLink("#archive-link")
+	games, err := host.p().Element("#archive-games")
+	if err != nil {
+		t.Fatalf("the archive should list the finished game: %v", err)
+	}
+	if text, _ := games.Text(); !strings.Contains(text, "test-game") || !strings.Contains(text, T("en", "villagers_win_alt")) {
+		t.Errorf("the game should be listed with its winner, got %q", text)
+	}
+
+	host.followLink("#archive-games a[href^='/history/']")
+	timeline, err := host.p().Element("#archive-timeline")
+	if err != nil {
+		t.Fatalf("the archived game should show its timeline: %v", err)
+	}
+	if text, _ := timeline.Text(); !strings.Contains(text, "The host killed "+wolf.Name) {
+		t.Errorf("the timeline should hold the whole game, got %q", text)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -3,7 +3,9 @@ package main
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -13,10 +15,10 @@ import (
 	"image/jpeg"
 	_ "image/png"
 	"io"
-	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -31,43 +33,179 @@ func generateSecretCode() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
-func setSessionCookie(db *sqlx.DB, w http.ResponseWriter, playerID int64) error {
-	tokenBig, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
-	token := tokenBig.Int64()
+// createPlayer makes an account with a fresh secret code, storing only its hash, and
+// returns the raw code for the one time it is shown.
+func createPlayer(db *sqlx.DB, name string) (playerID int64, secretCode string, err error) {
+	if secretCode, err = generateSecretCode(); err != nil {
+		return 0, "", err
+	}
+	hash, err := hashSecretCode(secretCode)
+	if err != nil {
+		return 0, "", err
+	}
+	result, err := db.Exec("INSERT INTO player (name, secret_code) VALUES (?, ?)", name, hash)
+	if err != nil {
+		return 0, "", err
+	}
+	playerID, _ = result.LastInsertId()
+	return playerID, secretCode, nil
+}
+
+// newSecretCodeCookie carries a new account's secret code to the page it lands on, which
+// shows it once and deletes the cookie (takeNewSecretCode).
+const newSecretCodeCookie = "werewolf_new_code"
+
+func setNewSecretCodeCookie(w http.ResponseWriter, r *http.Request, secretCode string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     newSecretCodeCookie,
+		Value:    secretCode,
+		Path:     "/",
+		MaxAge:   300,
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
 
-	_, err := db.Exec("INSERT INTO session (token, player_id) VALUES (?, ?)", token, playerID)
+// takeNewSecretCode returns the secret code waiting to be shown, if any, and clears it.
+func takeNewSecretCode(w http.ResponseWriter, r *http.Request) string {
+	cookie, err := r.Cookie(newSecretCodeCookie)
+	if err != nil {
+		return ""
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     newSecretCodeCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+	return cookie.Value
+}
+
+// sessionLifetime is how long a session lasts without activity (session_days); any
+// request that uses it pushes its end back, at most once per sessionRenewInterval.
+var sessionLifetime = 30 * 24 * time.Hour
+
+const sessionRenewInterval = time.Hour
+
+// sqliteOffset is d as a datetime() modifier, e.g. "+3600 seconds".
+func sqliteOffset(d time.Duration) string {
+	return fmt.Sprintf("%+d seconds", int64(d/time.Second))
+}
+
+// sessionTokenHash is what the session table stores for a token: the token itself stays
+// only in the player's cookie, and looking up its hash keeps the token's bytes out of any
+// comparison whose timing could leak them.
+func sessionTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newSessionToken returns a random 256-bit token, base64url-encoded.
+func newSessionToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// setSessionCookie starts a session for the player in the browser r came from.
+func setSessionCookie(db *sqlx.DB, w http.ResponseWriter, r *http.Request, playerID int64) error {
+	token, err := newSessionToken()
+	if err != nil {
+		return err
+	}
+
+	userAgent := r.UserAgent()
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	_, err = db.Exec(`INSERT INTO session (token_hash, player_id, user_agent, created_at, last_seen_at, expires_at)
+		VALUES (?, ?, ?, datetime('now'), datetime('now'), datetime('now', ?))`,
+		sessionTokenHash(token), playerID, userAgent, sqliteOffset(sessionLifetime))
 	if err != nil {
 		return err
 	}
 
 	http.SetCookie(w, &http.Cookie{
 		Name:     sessionCookieName,
-		Value:    strconv.FormatInt(token, 10),
+		Value:    token,
 		Path:     "/",
 		HttpOnly: true,
+		Secure:   isHTTPS(r),
 		SameSite: http.SameSiteLaxMode,
 	})
 	return nil
 }
 
+// deleteSession ends the request's session, if it has one.
+func deleteSession(db *sqlx.DB, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		db.Exec("DELETE FROM session WHERE token_hash = ?", sessionTokenHash(cookie.Value))
+	}
+}
+
+// hashSessionTokens moves the integer tokens of sessions made before tokens were opaque
+// strings to token_hash; their cookies, the token in decimal, keep working.
+func hashSessionTokens(db *sqlx.DB) error {
+	var tokens []int64
+	if err := db.Select(&tokens, "SELECT token FROM session WHERE token_hash IS NULL AND token IS NOT NULL"); err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if _, err := db.Exec("UPDATE session SET token_hash = ?, token = NULL WHERE token = ?",
+			sessionTokenHash(strconv.FormatInt(token, 10)), token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getPlayerIdFromSession returns the player of the request's session. An expired session
+// counts as none; a live one is renewed.
 func getPlayerIdFromSession(db *sqlx.DB, r *http.Request) (int64, error) {
 	cookie, err := r.Cookie(sessionCookieName)
 	if err != nil {
 		return -1, err
 	}
+	tokenHash := sessionTokenHash(cookie.Value)
 
-	token, err := strconv.ParseInt(cookie.Value, 10, 64)
+	var playerID int64
+	err = db.Get(&playerID, "SELECT player_id FROM session WHERE token_hash = ? AND expires_at > datetime('now')", tokenHash)
 	if err != nil {
 		return -1, err
 	}
 
-	var playerID int64
-	err = db.Get(&playerID, "SELECT player_id FROM session WHERE token = ?", token)
+	renewAfter := min(sessionRenewInterval, sessionLifetime/2)
+	db.Exec("UPDATE session SET expires_at = datetime('now', ?), last_seen_at = datetime('now') WHERE token_hash = ? AND expires_at < datetime('now', ?)",
+		sqliteOffset(sessionLifetime), tokenHash, sqliteOffset(sessionLifetime-renewAfter))
+
+	return playerID, nil
+}
+
+// deleteExpiredSessions removes the sessions that have run out and returns how many.
+func deleteExpiredSessions(db *sqlx.DB) (int64, error) {
+	res, err := db.Exec("DELETE FROM session WHERE expires_at <= datetime('now')")
 	if err != nil {
-		return -1, err
+		return 0, err
 	}
+	return res.RowsAffected()
+}
 
-	return playerID, nil
+// runSessionCleanup deletes expired sessions every interval, for as long as the server runs.
+func (app *App) runSessionCleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		n, err := deleteExpiredSessions(app.db)
+		if err != nil {
+			app.logf("ERROR [runSessionCleanup: deleteExpiredSessions]: %v", err)
+		} else if n > 0 {
+			app.logf("Deleted %d expired sessions", n)
+		}
+	}
 }
 
 // handleCheckName is polled by the sign-in form as the user types their name. It
@@ -92,6 +230,51 @@ func (app *App) handleCheckName(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// signin logs in the player with the name, or signs them up when the name is free, in
+// which case secretCode is the new account's code. A rejoin code (rejoin.go) is taken in
+// place of the secret code. On failure errKey is the translation key of what went wrong.
+func (app *App) signin(name, code string) (playerID int64, secretCode, errKey string) {
+	if name == "" {
+		return 0, "", "err_name_required"
+	}
+
+	existing, lookupErr := getPlayerByName(app.db, name)
+	switch {
+	case lookupErr == sql.ErrNoRows:
+		var err error
+		playerID, secretCode, err = createPlayer(app.db, name)
+		if err != nil {
+			app.logf("ERROR [signin: createPlayer]: %v", err)
+			return 0, "", "err_something_wrong"
+		}
+		app.logf("New player created: name='%s', id=%d", name, playerID)
+		DebugLog("signin", "Player '%s' signed up with ID %d", name, playerID)
+		LogDBState(app.db, "after signup: "+name)
+	case lookupErr != nil:
+		app.logf("ERROR [signin: db.Get player]: %v", lookupErr)
+		return 0, "", "err_something_wrong"
+	default:
+		// Name already taken — require the matching secret code to log in.
+		if code == "" {
+			return 0, "", "err_name_taken"
+		}
+		var stored string
+		app.db.Get(&stored, "SELECT secret_code FROM player WHERE rowid = ?", existing.ID)
+		playerID = existing.ID
+		if !verifySecretCode(stored, code) {
+			// a rejoin code from the host stands in for a lost secret code, once
+			if !redeemRejoinCode(app.db, playerID, code) {
+				return 0, "", "err_invalid_credentials"
+			}
+			app.logf("Player rejoined with a rejoin code: name='%s', id=%d", name, playerID)
+			break
+		}
+		app.logf("Player logged in: name='%s', id=%d", name, playerID)
+		DebugLog("signin", "Player '%s' logged in with ID %d", name, playerID)
+	}
+	return playerID, secretCode, ""
+}
+
 // handleSignin is the single endpoint behind the unified sign-in form: it creates a
 // new account if the name doesn't exist yet, or verifies the secret code if it does.
 // Deciding signup-vs-login here (not on the client) means a race between the live
@@ -108,58 +291,23 @@ func (app *App) handleSignin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	gameName := r.FormValue("game_name")
-	name := r.FormValue("name")
-	if name == "" {
-		toast("err_name_required")
-		return
+	playerID, secretCode, errKey := app.signin(r.FormValue("name"), r.FormValue("secret_code"))
+	if errKey == "err_invalid_credentials" {
+		app.logf("Failed sign-in as '%s' from %s", r.FormValue("name"), clientIP(r))
 	}
-	secretCode := r.FormValue("secret_code")
-
-	existing, lookupErr := getPlayerByName(app.db, name)
-
-	var playerID int64
-	switch {
-	case lookupErr == sql.ErrNoRows:
-		newSecret, err := generateSecretCode()
-		if err != nil {
-			app.logf("ERROR [handleSignin: generateSecretCode]: %v", err)
-			toast("err_something_wrong")
-			return
-		}
-		result, err := app.db.Exec("INSERT INTO player (name, secret_code) VALUES (?, ?)", name, newSecret)
-		if err != nil {
-			app.logf("ERROR [handleSignin: db.Exec insert player]: %v", err)
-			toast("err_something_wrong")
-			return
-		}
-		playerID, _ = result.LastInsertId()
-		app.logf("New player created: name='%s', id=%d", name, playerID)
-		DebugLog("handleSignin", "Player '%s' signed up with ID %d", name, playerID)
-		LogDBState(app.db, "after signup: "+name)
-	case lookupErr != nil:
-		app.logf("ERROR [handleSignin: db.Get player]: %v", lookupErr)
-		toast("err_something_wrong")
+	if errKey != "" {
+		toast(errKey)
 		return
-	default:
-		// Name already taken — require the matching secret code to log in.
-		if secretCode == "" {
-			toast("err_name_taken")
-			return
-		}
-		if secretCode != existing.SecretCode {
-			toast("err_invalid_credentials")
-			return
-		}
-		playerID = existing.ID
-		app.logf("Player logged in: name='%s', id=%d", name, playerID)
-		DebugLog("handleSignin", "Player '%s' logged in with ID %d", name, playerID)
 	}
 
-	if err := setSessionCookie(app.db, w, playerID); err != nil {
+	if err := setSessionCookie(app.db, w, r, playerID); err != nil {
 		app.logf("ERROR [handleSignin: setSessionCookie]: %v", err)
 		toast("err_something_wrong")
 		return
 	}
+	if secretCode != "" {
+		setNewSecretCodeCookie(w, r, secretCode)
+	}
 	redirectTarget := "/"
 	if gameName != "" {
 		redirectTarget = "/game/" + gameName
@@ -259,11 +407,7 @@ func (app *App) handleLogout(w http.ResponseWriter, r *http.Request) {
 	playerID, _ := getPlayerIdFromSession(app.db, r)
 	playerName := getPlayerName(app.db, playerID)
 
-	cookie, err := r.Cookie(sessionCookieName)
-	if err == nil {
-		token, _ := strconv.ParseInt(cookie.Value, 10, 64)
-		app.db.Exec("DELETE FROM session WHERE token = ?", token)
-	}
+	deleteSession(app.db, r)
 
 	app.logf("Player logged out: name='%s', id=%d", playerName, playerID)
 	DebugLog("handleLogout", "Player '%s' (ID: %d) logged out", playerName, playerID)
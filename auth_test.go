@@ -8,6 +8,7 @@ import (
 	"image/png"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -238,7 +239,7 @@ func TestUnknownPathWithSameNameKeepsSession(t *testing.T) {
 
 	playerName := "SameNameUser"
 	player := browser.signupPlayer(ctx.baseURL, playerName)
-	secretCode := player.getSecretCode()
+	playerID := player.getPlayerID()
 
 	// Visit an unknown path with the same name as the logged-in user.
 	wait := player.p().WaitNavigation(proto.PageLifecycleEventNameLoad)
@@ -260,9 +261,9 @@ func TestUnknownPathWithSameNameKeepsSession(t *testing.T) {
 		t.Fatal("Expected session to be intact: game page should load without redirect")
 	}
 
-	// Secret code still visible confirms it's the same session.
-	if code := player.getSecretCode(); code != secretCode {
-		t.Fatalf("Secret code changed — session was incorrectly replaced: before=%q after=%q", secretCode, code)
+	// The same player ID confirms it's the same session.
+	if id := player.getPlayerID(); id != playerID {
+		t.Fatalf("Player changed — session was incorrectly replaced: before=%q after=%q", playerID, id)
 	}
 }
 
@@ -625,9 +626,9 @@ func TestAutoJoinSameUserKeepsSession(t *testing.T) {
 	// Sign up the player normally.
 	player := browser.signupPlayerInGame(ctx.baseURL, playerName, gameName)
 
-	secretCode := player.getSecretCode()
-	if secretCode == "" {
-		t.Fatal("Could not read secret code before auto-join")
+	playerID := player.getPlayerID()
+	if playerID == "" {
+		t.Fatal("Could not read player ID before auto-join")
 	}
 
 	// Navigate to the auto-join link for the same name they're already logged in as.
@@ -641,13 +642,13 @@ func TestAutoJoinSameUserKeepsSession(t *testing.T) {
 		t.Fatalf("Expected to stay on game page, got: %s", info.URL)
 	}
 
-	// Session must still be valid — secret code is still visible.
-	codeAfter := player.getSecretCode()
-	if codeAfter == "" {
-		t.Fatal("Secret code missing after auto-join — session was incorrectly cleared")
+	// Session must still be valid — the same player is still signed in.
+	idAfter := player.getPlayerID()
+	if idAfter == "" {
+		t.Fatal("Player ID missing after auto-join — session was incorrectly cleared")
 	}
-	if codeAfter != secretCode {
-		t.Fatalf("Secret code changed after auto-join: before=%q after=%q", secretCode, codeAfter)
+	if idAfter != playerID {
+		t.Fatalf("Player changed after auto-join: before=%q after=%q", playerID, idAfter)
 	}
 
 	ctx.logger.Debug("=== Test passed ===")
@@ -809,11 +810,10 @@ func TestProfileImageCanBeChanged(t *testing.T) {
 	ctx.logger.Debug("=== TestProfileImageCanBeChanged passed ===")
 }
 
-// TestCannotJoinRunningGame verifies that a logged-in player who is not part of an
-// already-running game cannot enter it: navigating to the game redirects back to the
-// login page, where the Join form shows an error and disables the Join button.
-// Regression test for the "game flashes on screen then redirects to login" bug.
-func TestCannotJoinRunningGame(t *testing.T) {
+// TestLateJoinerWatchesRunningGame verifies that a logged-in player who is not part of
+// an already-running game enters it as an observer: navigating to the game shows the
+// watching view, and the Join form on the login page says they will watch.
+func TestLateJoinerWatchesRunningGame(t *testing.T) {
 	t.Parallel()
 	ctx := newTestContext(t)
 	defer ctx.cleanup()
@@ -827,35 +827,28 @@ func TestCannotJoinRunningGame(t *testing.T) {
 	// A logged-in player who is NOT part of test-game (signed into a different game).
 	outsider := browser.signupPlayerInGame(ctx.baseURL, "Outsider", "other-game")
 
-	// Navigating directly to the running game must NOT show the game — it redirects
-	// back to the login page.
-	outsider.p().MustNavigate(ctx.baseURL + "/game/test-game").MustWaitLoad()
-	if outsider.isOnGamePage() {
-		ctx.logger.LogDB("FAIL: outsider reached running game page")
-		t.Fatalf("Outsider should be redirected away from a running game, not shown the game")
-	}
-
-	// The Join form (game name prefilled) shows an error and disables the Join button.
+	// The Join form (game name prefilled) notes that the game is watched, and still joins.
+	outsider.p().MustNavigate(ctx.baseURL + "/?game=test-game").MustWaitLoad()
 	p := outsider.p().Timeout(10 * time.Second)
-	if _, err := p.Element("#btn-join[disabled]"); err != nil {
-		ctx.logger.LogDB("FAIL: join button not disabled for running game")
-		t.Fatalf("Join button should be disabled for a running game: %v", err)
+	if _, err := p.Element("#join-watch-note"); err != nil {
+		t.Fatalf("The Join form should say a running game is joined as an observer: %v", err)
 	}
-	if _, err := p.Element(".join-error"); err != nil {
-		t.Fatalf("An error message should be shown for a running game: %v", err)
+	if _, err := p.Element("#btn-join:not([disabled])"); err != nil {
+		t.Fatalf("Join button should stay enabled for a running game: %v", err)
 	}
 
-	// Changing the name to a fresh (non-existent) game re-enables joining.
-	outsider.p().MustEval(`() => {
-		const i = document.querySelector('#join-game-name');
-		i.value = 'brand-new-game';
-		i.dispatchEvent(new Event('input', {bubbles: true}));
-	}`)
-	if _, err := p.Element("#btn-join:not([disabled])"); err != nil {
-		t.Fatalf("Join button should re-enable for a fresh game name: %v", err)
+	// Navigating to the running game shows it, as an observer.
+	outsider.p().MustNavigate(ctx.baseURL + "/game/test-game").MustWaitLoad()
+	if !outsider.isOnGamePage() {
+		ctx.logger.LogDB("FAIL: late joiner did not reach the running game")
+		t.Fatalf("A late joiner should be shown the running game")
+	}
+	if _, err := p.Element("#observer-note"); err != nil {
+		ctx.logger.LogDB("FAIL: late joiner not shown the watching view")
+		t.Fatalf("A late joiner should get the watching view: %v", err)
 	}
 
-	ctx.logger.Debug("=== TestCannotJoinRunningGame passed ===")
+	ctx.logger.Debug("=== TestLateJoinerWatchesRunningGame passed ===")
 }
 
 // ============================================================================
@@ -1057,3 +1050,72 @@ func TestLoggedInIndexListsPlayerGames(t *testing.T) {
 
 	ctx.logger.Debug("=== TestLoggedInIndexListsPlayerGames passed ===")
 }
+
+// TestSessionExpiresAndRenews verifies that an expired session no longer signs the
+// player in, that using a live one pushes its end back and that the cleanup deletes
+// the expired rows.
+func TestSessionExpiresAndRenews(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+	db := ctx.app.db
+
+	ids := ctx.seedGame("lobby", 0, []string{"Alice"}, []string{RoleVillager})
+	db.MustExec(`INSERT INTO session (token_hash, player_id, created_at, expires_at) VALUES
+		(?, ?, datetime('now', '-40 days'), datetime('now', '-1 day')),
+		(?, ?, datetime('now', '-20 days'), datetime('now', '+2 days'))`,
+		sessionTokenHash("old"), ids[0], sessionTokenHash("live"), ids[0])
+
+	withToken := func(token string) *http.Request {
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: token})
+		return req
+	}
+	if _, err := getPlayerIdFromSession(db, withToken("old")); err == nil {
+		t.Errorf("an expired session should not sign the player in")
+	}
+	if id, err := getPlayerIdFromSession(db, withToken("live")); err != nil || id != ids[0] {
+		t.Fatalf("a live session should sign Alice in, got %d (%v)", id, err)
+	}
+	var renewed bool
+	db.Get(&renewed, "SELECT expires_at > datetime('now', '+29 days') FROM session WHERE token_hash = ?", sessionTokenHash("live"))
+	if !renewed {
+		t.Errorf("using the session should push its end back to a full lifetime")
+	}
+
+	if n, err := deleteExpiredSessions(db); err != nil || n != 1 {
+		t.Errorf("the cleanup should delete the expired session, got %d (%v)", n, err)
+	}
+}
+
+// TestSessionTokensAreOpaqueAndHashed verifies that a new session's cookie is a long random
+// token stored only as its hash, and that a session from before, with an integer token,
+// still signs its player in after the conversion.
+func TestSessionTokensAreOpaqueAndHashed(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+	db := ctx.app.db
+
+	ids := ctx.seedGame("lobby", 0, []string{"Alice", "Bob"}, []string{RoleVillager, RoleVillager})
+	rec := httptest.NewRecorder()
+	if err := setSessionCookie(db, rec, httptest.NewRequest("GET", "/", nil), ids[0]); err != nil {
+		t.Fatalf("setSessionCookie: %v", err)
+	}
+	token := rec.Result().Cookies()[0].Value
+	var stored int
+	db.Get(&stored, "SELECT COUNT(*) FROM session WHERE token_hash = ? AND token IS NULL", sessionTokenHash(token))
+	if len(token) != 43 || stored != 1 {
+		t.Errorf("the cookie should hold a 256-bit token stored only as its hash, got %q (%d rows)", token, stored)
+	}
+
+	db.MustExec("INSERT INTO session (token, player_id, created_at, expires_at) VALUES (4611686018427387903, ?, datetime('now'), datetime('now', '+1 day'))", ids[1])
+	if err := hashSessionTokens(db); err != nil {
+		t.Fatalf("hashSessionTokens: %v", err)
+	}
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "4611686018427387903"})
+	if id, err := getPlayerIdFromSession(db, req); err != nil || id != ids[1] {
+		t.Errorf("the old cookie should still sign Bob in, got %d (%v)", id, err)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	"modernc.org/sqlite"
+)
+
+// -backup <path> snapshots the database with SQLite's online backup API and exits. It can run
+// next to a live server: in WAL mode the copy only reads, so games go on while it runs.
+// -restore <path> copies a backup over the database and exits; stop the server first, its
+// hubs hold games in memory that would no longer match the restored data.
+
+// sqliteBackupConn is the part of the driver's connection the backup API lives on.
+type sqliteBackupConn interface {
+	NewBackup(dstUri string) (*sqlite.Backup, error)
+	NewRestore(srcUri string) (*sqlite.Backup, error)
+}
+
+// copyDB runs one backup (from the database to path) or restore (from path to the database).
+func copyDB(db *sqlx.DB, path string, restore bool) error {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(sqliteBackupConn)
+		if !ok {
+			return fmt.Errorf("the database driver has no backup API")
+		}
+		start := c.NewBackup
+		if restore {
+			start = c.NewRestore
+		}
+		b, err := start(path)
+		if err != nil {
+			return err
+		}
+		for more := true; more; {
+			if more, err = b.Step(-1); err != nil {
+				b.Finish()
+				return err
+			}
+		}
+		return b.Finish()
+	})
+}
+
+// backupDB writes a snapshot of the database to path, replacing any file there.
+func backupDB(db *sqlx.DB, path string) error {
+	return copyDB(db, path, false)
+}
+
+// restoreDB replaces the database with the backup at path.
+func restoreDB(db *sqlx.DB, path string) error {
+	// the driver would create a missing file, and restoring an empty database wipes everything
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	return copyDB(db, path, true)
+}
+
+// runBackupCommand handles -backup and -restore; it reports whether one of them ran.
+func runBackupCommand(dsn, backupPath, restorePath string) (bool, error) {
+	if backupPath == "" && restorePath == "" {
+		return false, nil
+	}
+	if backupPath != "" && restorePath != "" {
+		return true, fmt.Errorf("-backup and -restore cannot be used together")
+	}
+	db, err := sqlx.Connect("sqlite", dsn)
+	if err != nil {
+		return true, err
+	}
+	defer db.Close()
+	if backupPath != "" {
+		return true, backupDB(db, backupPath)
+	}
+	return true, restoreDB(db, restorePath)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ============================================================================
+// Backup Tests
+// ============================================================================
+
+func TestBackupAndRestore(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ctx.seedGame("lobby", 0, []string{"Host", "P1"}, []string{RoleVillager, RoleVillager})
+	path := filepath.Join(t.TempDir(), "backup.db")
+	if err := backupDB(ctx.app.db, path); err != nil {
+		t.Fatalf("backupDB: %v", err)
+	}
+
+	snapshot, err := sqlx.Connect("sqlite", path)
+	if err != nil {
+		t.Fatalf("the backup should open as a database: %v", err)
+	}
+	var players int
+	snapshot.Get(&players, "SELECT COUNT(*) FROM player")
+	snapshot.Close()
+	if players != 2 {
+		t.Fatalf("the backup should hold both players, got %d", players)
+	}
+
+	ctx.app.db.MustExec("DELETE FROM player")
+	if err := restoreDB(ctx.app.db, path); err != nil {
+		t.Fatalf("restoreDB: %v", err)
+	}
+	ctx.app.db.Get(&players, "SELECT COUNT(*) FROM player")
+	if players != 2 {
+		t.Errorf("the restore should bring the players back, got %d", players)
+	}
+
+	if err := restoreDB(ctx.app.db, filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Error("restoring from a missing file should fail instead of wiping the database")
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Bots are players driven by a program through the JSON API: a scripted player for tests,
+// or an LLM filling a seat for a small group. A signed-in player creates one with POST
+// /api/v1/bots and gets its bearer token once. The bot sends it as "Authorization: Bearer
+// <token>" on every call, where a person sends the session cookie, and then plays like
+// anyone using the API: it joins a lobby, reads its role from GET /games/{name} and posts
+// its actions. Only the token's SHA-256 is stored, like a session token's, so the reply that
+// creates the bot is the one place it is ever shown. A bot's player row has no secret code,
+// so nobody can sign in as it, and its token stops working when its owner deletes their
+// account.
+
+type apiNewBot struct {
+	Name string `json:"name"`
+}
+
+type apiBot struct {
+	PlayerID int64  `json:"player_id" db:"player_id"`
+	Name     string `json:"name" db:"name"`
+	Token    string `json:"token,omitempty"` // only in the reply that creates the bot
+}
+
+func generateBotToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// apiPlayerID is who calls the API: a bot by its bearer token, or a person by their session.
+func apiPlayerID(db *sqlx.DB, r *http.Request) (int64, error) {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		var playerID int64
+		err := db.Get(&playerID, "SELECT player_id FROM bot WHERE token_hash = ?", sessionTokenHash(strings.TrimSpace(token)))
+		return playerID, err
+	}
+	return getPlayerIdFromSession(db, r)
+}
+
+// botIDs returns the bots among a game's players.
+func botIDs(db *sqlx.DB, gameID int64) map[int64]bool {
+	var ids []int64
+	db.Select(&ids, "SELECT b.player_id FROM bot b JOIN game_player gp ON gp.player_id = b.player_id WHERE gp.game_id = ?", gameID)
+	bots := map[int64]bool{}
+	for _, id := range ids {
+		bots[id] = true
+	}
+	return bots
+}
+
+// handleAPICreateBot makes a bot for the signed-in player. Bots cannot make bots.
+func (app *App) handleAPICreateBot(w http.ResponseWriter, r *http.Request) {
+	ownerID, err := getPlayerIdFromSession(app.db, r)
+	if err != nil {
+		writeAPIError(w, http.StatusUnauthorized, "not signed in")
+		return
+	}
+	var body apiNewBot
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	name := strings.TrimSpace(body.Name)
+	if name == "" {
+		writeAPIError(w, http.StatusBadRequest, T("en", "err_name_required"))
+		return
+	}
+	if _, err := getPlayerByName(app.db, name); err != sql.ErrNoRows {
+		writeAPIError(w, http.StatusConflict, "name already taken")
+		return
+	}
+	token, err := generateBotToken()
+	if err != nil {
+		app.logf("ERROR [handleAPICreateBot: generateBotToken]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	tx, err := app.db.Beginx()
+	if err != nil {
+		app.logf("ERROR [handleAPICreateBot: db.Beginx]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	defer tx.Rollback()
+	result, err := tx.Exec("INSERT INTO player (name, secret_code) VALUES (?, '')", name)
+	if err != nil {
+		writeAPIError(w, http.StatusConflict, "name already taken")
+		return
+	}
+	botID, _ := result.LastInsertId()
+	if _, err := tx.Exec("INSERT INTO bot (player_id, owner_player_id, token_hash) VALUES (?, ?, ?)", botID, ownerID, sessionTokenHash(token)); err != nil {
+		app.logf("ERROR [handleAPICreateBot: insert bot]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		app.logf("ERROR [handleAPICreateBot: tx.Commit]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	app.logf("Bot '%s' (id %d) created by player %d", name, botID, ownerID)
+	writeJSON(w, http.StatusOK, apiBot{PlayerID: botID, Name: name, Token: token})
+}
+
+// handleAPIBots lists the signed-in player's bots, without their tokens.
+func (app *App) handleAPIBots(w http.ResponseWriter, r *http.Request) {
+	ownerID, err := getPlayerIdFromSession(app.db, r)
+	if err != nil {
+		writeAPIError(w, http.StatusUnauthorized, "not signed in")
+		return
+	}
+	bots := []apiBot{}
+	if err := app.db.Select(&bots, `
+SELECT b.player_id, p.name FROM bot b JOIN player p ON p.rowid = b.player_id
+WHERE b.owner_player_id = ? ORDER BY p.name`, ownerID); err != nil {
+		app.logf("ERROR [handleAPIBots: db.Select]: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	writeJSON(w, http.StatusOK, bots)
+}
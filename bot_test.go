@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// ============================================================================
+// Bot Player Tests
+// ============================================================================
+
+// bearer signs every request with a bot's token.
+type bearer string
+
+func (b bearer) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+string(b))
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestBotJoinsAndPlays(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager})
+	wolf := ids[1]
+
+	owner := ctx.apiClient()
+	ctx.apiCall(owner, "POST", "/api/v1/session", apiSignin{Name: "Alice"}, nil)
+	var created apiBot
+	if code := ctx.apiCall(owner, "POST", "/api/v1/bots", apiNewBot{Name: "Robo"}, &created); code != http.StatusOK || created.Token == "" {
+		t.Fatalf("Alice should get a bot and its token, got %d %+v", code, created)
+	}
+	if code := ctx.apiCall(owner, "POST", "/api/v1/bots", apiNewBot{Name: "Wolf"}, nil); code != http.StatusConflict {
+		t.Errorf("a bot should not take a player's name, got %d", code)
+	}
+	var bots []apiBot
+	if ctx.apiCall(owner, "GET", "/api/v1/bots", nil, &bots); len(bots) != 1 || bots[0].Name != "Robo" || bots[0].Token != "" {
+		t.Errorf("Alice's bots should be listed without their token, got %+v", bots)
+	}
+
+	bot := &http.Client{Transport: bearer(created.Token)}
+	if code := ctx.apiCall(bot, "POST", "/api/v1/bots", apiNewBot{Name: "Robo2"}, nil); code != http.StatusUnauthorized {
+		t.Errorf("a bot should not make bots, got %d", code)
+	}
+	if code := ctx.apiCall(&http.Client{Transport: bearer("nope")}, "GET", "/api/v1/games", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("an unknown token should be refused, got %d", code)
+	}
+	if code := ctx.apiCall(ctx.apiClient(), "POST", "/api/v1/session", apiSignin{Name: "Robo", SecretCode: "x"}, nil); code != http.StatusUnauthorized {
+		t.Errorf("nobody should sign in as the bot, got %d", code)
+	}
+
+	// a seat at the running game, as if it had joined the lobby
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("INSERT INTO game_player (game_id, player_id, role_id) VALUES (?, ?, ?)", game.ID, created.PlayerID, RoleVillager)
+
+	var view APIGame
+	if code := ctx.apiCall(bot, "GET", "/api/v1/games/test-game", nil, &view); code != http.StatusOK || view.You.Role != "Villager" || !view.You.Bot {
+		t.Fatalf("the bot should read its role, got %d %+v", code, view)
+	}
+	envelope := json.RawMessage(fmt.Sprintf(`{"type":"day_vote","version":1,"payload":{"target_player_id":%d}}`, wolf))
+	ctx.apiCall(bot, "POST", "/api/v1/games/test-game/actions", envelope, nil)
+	var votes APIVotes
+	if ctx.apiCall(bot, "GET", "/api/v1/games/test-game/votes", nil, &votes); len(votes.Votes) != 1 || votes.Votes[0].Target != "Wolf" {
+		t.Errorf("the bot's vote should be counted, got %+v", votes)
+	}
+}
+
+func TestBotTokenStoredAsHash(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+	db := ctx.app.db
+
+	owner := ctx.apiClient()
+	ctx.apiCall(owner, "POST", "/api/v1/session", apiSignin{Name: "Alice"}, nil)
+	var created apiBot
+	ctx.apiCall(owner, "POST", "/api/v1/bots", apiNewBot{Name: "Robo"}, &created)
+	var stored int
+	db.Get(&stored, "SELECT COUNT(*) FROM bot WHERE token_hash = ?", sessionTokenHash(created.Token))
+	if created.Token == "" || stored != 1 {
+		t.Fatalf("the token should be stored only as its hash, got %d rows", stored)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Chat channels. A channel is named like the action visibility of the team that may read it;
+// the lobby has a channel of its own for everyone waiting there. Each pair of lovers writes on
+// "lovers" and the message is stored on the pair's own channel (loversChannel).
+const (
+	ChatChannelWerewolf  = VisibilityTeamWerewolf
+	ChatChannelDay       = VisibilityPublic
+	ChatChannelGraveyard = "graveyard"
+	ChatChannelLovers    = "lovers"
+	ChatChannelLobby     = "lobby"
+)
+
+// GraveyardData fills the dead players' chat, day and night; the living never get it.
+type GraveyardData struct {
+	InGraveyard bool
+	Graveyard   []ChatMessage
+}
+
+func buildGraveyardData(db *sqlx.DB, game *Game, player Player) GraveyardData {
+	var d GraveyardData
+	if player.IsAlive {
+		return d
+	}
+	d.InGraveyard = true
+	d.Graveyard = chatMessages(db, game.ID, ChatChannelGraveyard)
+	return d
+}
+
+// LoversChatData fills the lovers' private chat for the rest of the game once Cupid has
+// linked them; everyone else never gets it.
+type LoversChatData struct {
+	InLove     bool
+	LoverName  string
+	LoversChat []ChatMessage
+}
+
+// loversChannel is the stored channel of the pair, the same seen from either lover.
+func loversChannel(playerID, partnerID int64) string {
+	if partnerID < playerID {
+		playerID, partnerID = partnerID, playerID
+	}
+	return fmt.Sprintf("%s:%d:%d", ChatChannelLovers, playerID, partnerID)
+}
+
+func buildLoversChatData(db *sqlx.DB, game *Game, player Player) LoversChatData {
+	var d LoversChatData
+	if !canChat(db, game, player, ChatChannelLovers) {
+		return d
+	}
+	partnerID := getLoverPartner(db, game.ID, player.PlayerID)
+	d.InLove = true
+	d.LoverName = getPlayerName(db, partnerID)
+	d.LoversChat = chatMessages(db, game.ID, loversChannel(player.PlayerID, partnerID))
+	return d
+}
+
+const chatMaxMessage = 280
+
+type ChatMessage struct {
+	Round   int    `db:"round"`
+	Name    string `db:"name"`
+	Message string `db:"message"`
+}
+
+// chatMessages returns everything said on the channel this game, oldest first.
+func chatMessages(db *sqlx.DB, gameID int64, channel string) []ChatMessage {
+	var msgs []ChatMessage
+	db.Select(&msgs, `
+SELECT c.round, p.name, c.message FROM game_chat c
+JOIN player p ON p.rowid = c.player_id
+WHERE c.game_id = ? AND c.channel = ?
+ORDER BY c.rowid`, gameID, channel)
+	return msgs
+}
+
+// graveyardTalkEnabled reports whether dead players may still talk in the day chat; kept for
+// the next game.
+func graveyardTalkEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT graveyard_talk FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// handleWSToggleGraveyardTalk switches whether the dead may talk in the day chat.
+func handleWSToggleGraveyardTalk(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleGraveyardTalk: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET graveyard_talk = NOT graveyard_talk WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleGraveyardTalk: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_graveyard_talk"))
+		return
+	}
+	h.logf("Graveyard talk toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
+
+// canChat reports whether the player may write on the channel. The pack talks only at night,
+// and only its living members (not the Minion or Sorceress, who never meet the wolves); the
+// village talks by day, the dead too when the lobby lets them (graveyardTalkEnabled), but
+// never the observers nor whoever the Spellcaster silenced last night; the graveyard belongs
+// to everyone out of the game, day and night; the lovers talk to each other while they live;
+// the lobby chat is open to everyone until the game starts.
+func canChat(db *sqlx.DB, game *Game, player Player, channel string) bool {
+	switch channel {
+	case ChatChannelWerewolf:
+		return game.Status == "night" && player.IsAlive && inWolfPack(player)
+	case ChatChannelDay:
+		if game.Status != "day" || player.IsObserver {
+			return false
+		}
+		if !player.IsAlive {
+			return graveyardTalkEnabled(db, game.ID)
+		}
+		return !spellbound(db, game.ID, game.Round, player.PlayerID)
+	case ChatChannelGraveyard:
+		return gameRunning(game) && !player.IsAlive
+	case ChatChannelLovers:
+		return gameRunning(game) && player.IsAlive && getLoverPartner(db, game.ID, player.PlayerID) != 0
+	case ChatChannelLobby:
+		return game.Status == "lobby"
+	}
+	return false
+}
+
+func handleWSChatSend(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSChatSend: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	player, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSChatSend: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if !canChat(h.db, game, player, msg.Channel) {
+		h.sendErrorToast(client.playerID, T(lang, "err_chat_not_allowed"))
+		return
+	}
+	if isMuted(h.db, game.ID, client.playerID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_chat_muted"))
+		return
+	}
+
+	text := strings.TrimSpace(msg.Message)
+	if text == "" {
+		return
+	}
+	if len(text) > chatMaxMessage {
+		h.sendErrorToast(client.playerID, T(lang, "err_chat_too_long"))
+		return
+	}
+	if chatFilterEnabled(h.db, game.ID) {
+		filtered, ok := h.chatFilter.Filter(text)
+		if !ok {
+			h.sendErrorToast(client.playerID, T(lang, "err_chat_filtered"))
+			return
+		}
+		text = filtered
+	}
+
+	channel := msg.Channel
+	if channel == ChatChannelLovers {
+		channel = loversChannel(client.playerID, getLoverPartner(h.db, game.ID, client.playerID))
+	}
+	if _, err := h.db.Exec(`INSERT INTO game_chat (game_id, round, channel, player_id, message) VALUES (?, ?, ?, ?, ?)`,
+		game.ID, game.Round, channel, client.playerID, text); err != nil {
+		h.logError("handleWSChatSend: db.Exec insert message", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_chat_failed"))
+		return
+	}
+
+	DebugLog("handleWSChatSend", "'%s' on %s: %s", player.Name, msg.Channel, text)
+	h.triggerBroadcast()
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Every chat message passes the hub's ChatFilter before it is stored, on every channel, when
+// the host has switched the filter on for the game (chatFilterEnabled). The default filter
+// masks the words of defaultFilterWords; another filter can be plugged into Hub.chatFilter.
+// Apart from the filter the host can mute a player, who then cannot chat at all.
+
+// ChatFilter checks a chat message before it is stored. It returns the message to store, or
+// ok=false to refuse the message.
+type ChatFilter interface {
+	Filter(message string) (filtered string, ok bool)
+}
+
+// wordListFilter masks listed words (whole words, any case) with asterisks.
+type wordListFilter struct {
+	re *regexp.Regexp
+}
+
+// defaultFilterWords are masked by the default filter, English and German.
+var defaultFilterWords = []string{
+	"fuck", "fucking", "fucker", "shit", "bitch", "bastard", "asshole", "dick", "cunt", "slut", "whore",
+	"scheiße", "scheisse", "arschloch", "fotze", "hurensohn", "wichser", "schlampe", "fick", "ficken",
+}
+
+func newWordListFilter(words []string) *wordListFilter {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = regexp.QuoteMeta(w)
+	}
+	return &wordListFilter{re: regexp.MustCompile(`(?i)(^|[^\pL])(` + strings.Join(quoted, "|") + `)([^\pL]|$)`)}
+}
+
+func (f *wordListFilter) Filter(message string) (string, bool) {
+	// a separator ends one match and may start the next, so mask until nothing is left
+	for {
+		masked := f.re.ReplaceAllStringFunc(message, func(m string) string {
+			sub := f.re.FindStringSubmatch(m)
+			return sub[1] + strings.Repeat("*", len([]rune(sub[2]))) + sub[3]
+		})
+		if masked == message {
+			return message, true
+		}
+		message = masked
+	}
+}
+
+// chatFilterEnabled reports whether the game's chat passes the filter; kept for the next game.
+func chatFilterEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT chat_filter FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// isMuted reports whether the host has muted the player in this game.
+func isMuted(db *sqlx.DB, gameID, playerID int64) bool {
+	var muted bool
+	db.Get(&muted, "SELECT muted FROM game_player WHERE game_id = ? AND player_id = ?", gameID, playerID)
+	return muted
+}
+
+// handleWSToggleChatFilter switches the chat filter on or off in the lobby.
+func handleWSToggleChatFilter(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleChatFilter: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET chat_filter = NOT chat_filter WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleChatFilter: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_chat_filter"))
+		return
+	}
+	h.logf("Chat filter toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
+
+// handleWSToggleMute mutes or unmutes a player's chat, in the lobby or during the game.
+func handleWSToggleMute(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleMute: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	if _, err := h.db.Exec("UPDATE game_player SET muted = NOT muted WHERE game_id = ? AND player_id = ?", game.ID, targetID); err != nil {
+		h.logError("handleWSToggleMute: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_mute"))
+		return
+	}
+	if isMuted(h.db, game.ID, targetID) {
+		h.logf("'%s' was muted in game %d", target.Name, game.ID)
+		h.sendInfoToast(targetID, T(h.getPlayerLang(targetID), "chat_muted_notice"))
+	} else {
+		h.logf("'%s' was unmuted in game %d", target.Name, game.ID)
+	}
+	h.triggerBroadcast()
+}
@@ -0,0 +1,432 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Chat Tests
+// ============================================================================
+
+func TestWolfChatReachesOnlyThePack(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf1", "Wolf2", "Minion", "V1", "V2"},
+		[]string{RoleWerewolf, RoleWerewolf, RoleMinion, RoleVillager, RoleVillager})
+	wolf1, wolf2, minion, v1 := ids[0], ids[1], ids[2], ids[3]
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(wolf1, WSMessage{Action: "chat_send", Channel: ChatChannelWerewolf, Message: "  take V2 tonight  "})
+	ctx.sendWS(minion, WSMessage{Action: "chat_send", Channel: ChatChannelWerewolf, Message: "I am with you"})
+	ctx.sendWS(v1, WSMessage{Action: "chat_send", Channel: ChatChannelWerewolf, Message: "hello?"})
+
+	msgs := chatMessages(ctx.app.db, game.ID, ChatChannelWerewolf)
+	if len(msgs) != 1 || msgs[0].Name != "Wolf1" || msgs[0].Message != "take V2 tonight" || msgs[0].Round != 1 {
+		t.Fatalf("only the wolf's trimmed message should be stored, got %+v", msgs)
+	}
+
+	buf, err := getGameComponent(ctx.hub(), wolf2, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "take V2 tonight") {
+		t.Errorf("the other wolf should read the pack chat (err: %v)", err)
+	}
+	for _, id := range []int64{minion, v1} {
+		buf, err := getGameComponent(ctx.hub(), id, game, "en")
+		if err != nil || strings.Contains(buf.String(), "take V2 tonight") || strings.Contains(buf.String(), "wolf-chat") {
+			t.Errorf("player %d should not see the pack chat (err: %v)", id, err)
+		}
+	}
+}
+
+func TestWolfChatClosedDuringDay(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(ids[0], WSMessage{Action: "chat_send", Channel: ChatChannelWerewolf, Message: "anyone?"})
+	if msgs := chatMessages(ctx.app.db, game.ID, ChatChannelWerewolf); len(msgs) != 0 {
+		t.Errorf("the pack chat should only be open at night, got %+v", msgs)
+	}
+}
+
+func TestLobbyChat(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"P1", "P2"}, []string{RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(ids[0], WSMessage{Action: "chat_send", Channel: ChatChannelLobby, Message: "two wolves tonight?"})
+	buf, err := getGameComponent(ctx.hub(), ids[1], game, "en")
+	if err != nil || !strings.Contains(buf.String(), "two wolves tonight?") {
+		t.Fatalf("everyone in the lobby should read its chat, also after reconnecting (err: %v)", err)
+	}
+
+	ctx.app.db.MustExec("UPDATE game SET status = 'night', round = 1 WHERE rowid = ?", game.ID)
+	ctx.sendWS(ids[1], WSMessage{Action: "chat_send", Channel: ChatChannelLobby, Message: "too late"})
+	if msgs := chatMessages(ctx.app.db, game.ID, ChatChannelLobby); len(msgs) != 1 {
+		t.Errorf("the lobby chat should close once the game starts, got %+v", msgs)
+	}
+}
+
+func TestDayChat(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 2,
+		[]string{"Wolf", "V1", "V2", "Dead", "Watcher"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf, v1, dead, watcher := ids[0], ids[1], ids[3], ids[4]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, dead)
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0, is_observer = 1 WHERE game_id = ? AND player_id = ?", game.ID, watcher)
+
+	ctx.sendWS(v1, WSMessage{Action: "chat_send", Channel: ChatChannelDay, Message: "it was Wolf"})
+	ctx.sendWS(dead, WSMessage{Action: "chat_send", Channel: ChatChannelDay, Message: "avenge me"})
+	ctx.sendWS(watcher, WSMessage{Action: "chat_send", Channel: ChatChannelDay, Message: "popcorn"})
+	msgs := chatMessages(ctx.app.db, game.ID, ChatChannelDay)
+	if len(msgs) != 1 || msgs[0].Name != "V1" || msgs[0].Round != 2 {
+		t.Fatalf("only the living should talk by default, got %+v", msgs)
+	}
+
+	buf, err := getGameComponent(ctx.hub(), wolf, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "it was Wolf") || !strings.Contains(buf.String(), `id="day-chat-form"`) {
+		t.Errorf("the village should read and write the day chat (err: %v)", err)
+	}
+	buf, err = getGameComponent(ctx.hub(), dead, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "it was Wolf") || strings.Contains(buf.String(), `id="day-chat-form"`) {
+		t.Errorf("the dead should read the day chat without writing (err: %v)", err)
+	}
+
+	ctx.app.db.MustExec("UPDATE game SET graveyard_talk = 1 WHERE rowid = ?", game.ID)
+	ctx.sendWS(dead, WSMessage{Action: "chat_send", Channel: ChatChannelDay, Message: "avenge me"})
+	ctx.sendWS(watcher, WSMessage{Action: "chat_send", Channel: ChatChannelDay, Message: "popcorn"})
+	if msgs := chatMessages(ctx.app.db, game.ID, ChatChannelDay); len(msgs) != 2 || msgs[1].Name != "Dead" {
+		t.Errorf("with graveyard talk the dead, but not the observers, should speak, got %+v", msgs)
+	}
+}
+
+func TestGraveyardChatReachesOnlyTheDead(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 2,
+		[]string{"Wolf", "V1", "V2", "Dead1", "Dead2"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf, v1, dead1, dead2 := ids[0], ids[1], ids[3], ids[4]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id IN (?, ?)", game.ID, dead1, dead2)
+
+	ctx.sendWS(dead1, WSMessage{Action: "chat_send", Channel: ChatChannelGraveyard, Message: "it was the baker"})
+	ctx.sendWS(v1, WSMessage{Action: "chat_send", Channel: ChatChannelGraveyard, Message: "can you hear me?"})
+	msgs := chatMessages(ctx.app.db, game.ID, ChatChannelGraveyard)
+	if len(msgs) != 1 || msgs[0].Name != "Dead1" {
+		t.Fatalf("only the dead should talk in the graveyard, got %+v", msgs)
+	}
+
+	buf, err := getGameComponent(ctx.hub(), dead2, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "it was the baker") {
+		t.Errorf("the other dead player should read the graveyard at night (err: %v)", err)
+	}
+	for _, id := range []int64{wolf, v1} {
+		buf, err := getGameComponent(ctx.hub(), id, game, "en")
+		if err != nil || strings.Contains(buf.String(), "it was the baker") || strings.Contains(buf.String(), "graveyard-chat") {
+			t.Errorf("player %d is alive and should not see the graveyard (err: %v)", id, err)
+		}
+	}
+
+	ctx.app.db.MustExec("UPDATE game SET status = 'day' WHERE rowid = ?", game.ID)
+	game, _ = ctx.hub().getGame()
+	buf, err = getGameComponent(ctx.hub(), dead2, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "it was the baker") {
+		t.Errorf("the graveyard should stay open by day (err: %v)", err)
+	}
+	buf, err = getGameComponent(ctx.hub(), v1, game, "en")
+	if err != nil || strings.Contains(buf.String(), "it was the baker") {
+		t.Errorf("the living should not read the graveyard by day (err: %v)", err)
+	}
+}
+
+func TestLoversChatIsPrivateToThePair(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 2,
+		[]string{"Wolf", "Romeo", "Juliet", "V1"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	wolf, romeo, juliet, v1 := ids[0], ids[1], ids[2], ids[3]
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(romeo, WSMessage{Action: "chat_send", Channel: ChatChannelLovers, Message: "before Cupid"})
+	ctx.app.db.MustExec("INSERT INTO game_lovers (game_id, player1_id, player2_id) VALUES (?, ?, ?), (?, ?, ?)", game.ID, romeo, juliet, game.ID, juliet, romeo)
+
+	ctx.sendWS(romeo, WSMessage{Action: "chat_send", Channel: ChatChannelLovers, Message: "meet me at the well"})
+	ctx.sendWS(v1, WSMessage{Action: "chat_send", Channel: ChatChannelLovers, Message: "me too"})
+	msgs := chatMessages(ctx.app.db, game.ID, loversChannel(juliet, romeo))
+	if len(msgs) != 1 || msgs[0].Name != "Romeo" || msgs[0].Message != "meet me at the well" {
+		t.Fatalf("only the linked lovers should talk on their channel, got %+v", msgs)
+	}
+
+	buf, err := getGameComponent(ctx.hub(), juliet, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "meet me at the well") || !strings.Contains(buf.String(), "Whispers with Romeo") {
+		t.Errorf("Juliet should read her lover's whispers (err: %v)", err)
+	}
+	for _, id := range []int64{wolf, v1} {
+		buf, err := getGameComponent(ctx.hub(), id, game, "en")
+		if err != nil || strings.Contains(buf.String(), "meet me at the well") || strings.Contains(buf.String(), "lovers-chat") {
+			t.Errorf("player %d should not see the lovers' chat (err: %v)", id, err)
+		}
+	}
+}
+
+func TestChatFilterMasksWords(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0,
+		[]string{"Host", "P1"},
+		[]string{RoleVillager, RoleVillager})
+	host, p1 := ids[0], ids[1]
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(p1, WSMessage{Action: "toggle_chat_filter"})
+	if chatFilterEnabled(ctx.app.db, game.ID) {
+		t.Fatal("only the host should switch the chat filter")
+	}
+	ctx.sendWS(host, WSMessage{Action: "toggle_chat_filter"})
+	ctx.sendWS(p1, WSMessage{Action: "chat_send", Channel: ChatChannelLobby, Message: "Shit shit, this shitake is fine"})
+	msgs := chatMessages(ctx.app.db, game.ID, ChatChannelLobby)
+	if len(msgs) != 1 || msgs[0].Message != "**** ****, this shitake is fine" {
+		t.Fatalf("the filter should mask whole words only, got %+v", msgs)
+	}
+}
+
+// refuseAll is a ChatFilter that lets nothing through.
+type refuseAll struct{}
+
+func (refuseAll) Filter(string) (string, bool) { return "", false }
+
+func TestHostMutesAPlayer(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host, wolf, v1 := ids[0], ids[1], ids[2]
+	game, _ := ctx.hub().getGame()
+	v1ID := strconv.FormatInt(v1, 10)
+
+	ctx.sendWS(wolf, WSMessage{Action: "toggle_mute", TargetPlayerID: v1ID})
+	if isMuted(ctx.app.db, game.ID, v1) {
+		t.Fatal("only the host should mute a player")
+	}
+	ctx.sendWS(host, WSMessage{Action: "toggle_mute", TargetPlayerID: v1ID})
+	ctx.sendWS(v1, WSMessage{Action: "chat_send", Channel: ChatChannelDay, Message: "let me speak"})
+	if msgs := chatMessages(ctx.app.db, game.ID, ChatChannelDay); len(msgs) != 0 {
+		t.Fatalf("a muted player should not chat, got %+v", msgs)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "toggle_mute", TargetPlayerID: v1ID})
+	ctx.hub().chatFilter = refuseAll{}
+	ctx.app.db.MustExec("UPDATE game SET chat_filter = 1 WHERE rowid = ?", game.ID)
+	ctx.sendWS(v1, WSMessage{Action: "chat_send", Channel: ChatChannelDay, Message: "let me speak"})
+	if msgs := chatMessages(ctx.app.db, game.ID, ChatChannelDay); len(msgs) != 0 {
+		t.Errorf("a plugged-in filter should be able to refuse messages, got %+v", msgs)
+	}
+}
+
+func TestLobbyChatInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the lobby chat ===")
+
+	p1 := browser.signupPlayer(ctx.baseURL, "P1")
+	p2 := browser.signupPlayer(ctx.baseURL, "P2")
+
+	p1.submitFormWithValues("lobby-chat-form", map[string]string{"message": "two wolves tonight?"})
+	if err := p2.waitUntilCondition(`() => Array.from(document.querySelectorAll('#lobby-chat-messages .lobby-chat-line')).some(l => l.textContent.includes('two wolves tonight?'))`, "lobby chat line"); err != nil {
+		ctx.logger.LogDB("FAIL: lobby chat not delivered")
+		t.Errorf("everyone in the lobby should read its chat: %v", err)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
+
+func TestWolfChatInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the pack chat at night ===")
+
+	// Setup: 2 werewolves + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"WC1", "WC2", "WC3", "WC4"},
+		RoleWerewolf, RoleWerewolf, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Werewolf"]) != 2 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	wolf1, wolf2, villager := byRole["Werewolf"][0], byRole["Werewolf"][1], byRole["Villager"][0]
+
+	wolf1.submitFormWithValues("wolf-chat-form", map[string]string{"message": "take the baker tonight"})
+	if err := wolf2.waitUntilCondition(`() => Array.from(document.querySelectorAll('#wolf-chat-messages .wolf-chat-line')).some(l => l.textContent.includes('take the baker tonight'))`, "wolf chat line"); err != nil {
+		ctx.logger.LogDB("FAIL: wolf chat not delivered")
+		t.Errorf("the other wolf should read the pack chat: %v", err)
+	}
+	if strings.Contains(villager.getGameContent(), "take the baker tonight") {
+		ctx.logger.LogDB("FAIL: villager reads wolf chat")
+		t.Error("a villager should not read the pack chat")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
+
+func TestDayChatInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the village's day chat ===")
+
+	// Setup: 1 werewolf + 3 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"DC1", "DC2", "DC3", "DC4"},
+		RoleWerewolf, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	werewolves, villagers := killFirstVillagerAtNight(ctx, players)
+
+	villagers[1].submitFormWithValues("day-chat-form", map[string]string{"message": "who howled last night?"})
+	if err := werewolves[0].waitUntilCondition(`() => Array.from(document.querySelectorAll('#day-chat-messages .day-chat-line')).some(l => l.textContent.includes('who howled last night?'))`, "day chat line"); err != nil {
+		ctx.logger.LogDB("FAIL: day chat not delivered")
+		t.Errorf("every player should read the day chat: %v", err)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
+
+func TestGraveyardChatInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the graveyard chat between two dead players ===")
+
+	// Setup: 1 werewolf + 4 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"GY1", "GY2", "GY3", "GY4", "GY5"},
+		RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	werewolves, villagers := killFirstVillagerAtNight(ctx, players)
+	werewolf, eaten, lynched, v3, v4 := werewolves[0], villagers[0], villagers[1], villagers[2], villagers[3]
+
+	// the village lynches a second villager, so two are dead by night 2
+	lynched.clickAndWait("#day-pass-btn")
+	werewolf.dayVoteForPlayer(lynched.Name)
+	v3.dayVoteForPlayer(lynched.Name)
+	v4.dayVoteForPlayer(lynched.Name)
+	waitForNightPhaseAll(ctx, []*TestPlayer{werewolf, v3, v4})
+
+	eaten.submitFormWithValues("graveyard-chat-form", map[string]string{"message": "it was the baker"})
+	if err := lynched.waitUntilCondition(`() => Array.from(document.querySelectorAll('#graveyard-chat-messages .graveyard-chat-line')).some(l => l.textContent.includes('it was the baker'))`, "graveyard chat line"); err != nil {
+		ctx.logger.LogDB("FAIL: graveyard chat not delivered")
+		t.Errorf("the other dead player should read the graveyard: %v", err)
+	}
+	if content := v3.getGameContent(); strings.Contains(content, "it was the baker") {
+		ctx.logger.LogDB("FAIL: living player reads the graveyard")
+		t.Error("the living should not read the graveyard")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
+
+func TestLoversChatInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the lovers' private chat ===")
+
+	// Setup: 1 werewolf + 1 cupid + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"LC1", "LC2", "LC3", "LC4"},
+		RoleWerewolf, RoleCupid, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	werewolves, villagers, cupids := findPlayersByRoleWithCupid(players)
+	if len(werewolves) == 0 || len(villagers) < 2 || len(cupids) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	lover1, lover2, cupid := villagers[0], villagers[1], cupids[0]
+	cupid.cupidPickLover(lover1.Name)
+	cupid.cupidPickLover(lover2.Name)
+	cupid.cupidLinkLovers()
+
+	lover1.submitFormWithValues("lovers-chat-form", map[string]string{"message": "meet me by the well"})
+	if err := lover2.waitUntilCondition(`() => Array.from(document.querySelectorAll('#lovers-chat-messages .lovers-chat-line')).some(l => l.textContent.includes('meet me by the well'))`, "lovers chat line"); err != nil {
+		ctx.logger.LogDB("FAIL: lovers chat not delivered")
+		t.Errorf("the other lover should read the chat: %v", err)
+	}
+	for _, p := range []*TestPlayer{werewolves[0], cupid} {
+		if strings.Contains(p.getGameContent(), "meet me by the well") {
+			ctx.logger.LogDB("FAIL: lovers chat leaked")
+			t.Errorf("%s is not a lover and should not read the chat", p.Name)
+		}
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
+
+func TestChatFilterInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the chat filter set in the lobby ===")
+
+	host := browser.signupPlayer(ctx.baseURL, "Host")
+	guest := browser.signupPlayer(ctx.baseURL, "Guest")
+
+	host.toggleLobbySetting("chat-filter-toggle")
+	guest.submitFormWithValues("lobby-chat-form", map[string]string{"message": "Shit, this shitake is fine"})
+	if err := host.waitUntilCondition(`() => Array.from(document.querySelectorAll('#lobby-chat-messages .lobby-chat-line')).some(l => l.textContent.includes('****, this shitake is fine'))`, "filtered chat line"); err != nil {
+		ctx.logger.LogDB("FAIL: chat not filtered")
+		t.Errorf("the filter should mask the word and leave the rest: %v", err)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,368 @@
+// werewolf-sim plays whole games against a running server with scripted players, so a change
+// to the hub or the database can be measured under load. Every player signs up through the
+// JSON API, joins its game, keeps the game's WebSocket open like a page does and sends its
+// moves as envelopes; whenever the server pushes something it reads the game again from
+// GET /api/v1/games/{name}. The first player of each game is its host: they set the roles
+// (-wolves werewolves, villagers for the rest) and start it.
+//
+// The scripted players are simple: the wolves kill the first villager they see, everybody
+// fills in the night survey, and by day everybody votes for the first living player other
+// than themselves, so the game ends after a few rounds. At the end the command reports how
+// long the server took from a move to the next update on the mover's WebSocket, how long
+// the API calls took, and everything that went wrong; it exits with 1 when anything did.
+//
+//	go run ./cmd/werewolf-sim -server http://localhost:8080 -games 10 -players 8 -wolves 2
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// the role ids the migrations seed
+const (
+	roleVillager = 1
+	roleWerewolf = 2
+)
+
+// wsProtocolVersion is the envelope version the server takes (ws_protocol.go).
+const wsProtocolVersion = 1
+
+type apiPlayer struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+	Alive bool   `json:"alive"`
+}
+
+type apiGame struct {
+	Status  string      `json:"status"`
+	Round   int         `json:"round"`
+	Winner  string      `json:"winner"`
+	You     apiPlayer   `json:"you"`
+	Players []apiPlayer `json:"players"`
+}
+
+// stats collects what all players measured.
+type stats struct {
+	mu       sync.Mutex
+	updates  []time.Duration // from a move to the next message on the mover's WebSocket
+	calls    []time.Duration // API round trips
+	errors   map[string]int
+	finished int
+	timedOut int
+	winners  map[string]int
+}
+
+func (s *stats) fail(format string, args ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[fmt.Sprintf(format, args...)]++
+}
+
+func (s *stats) add(list *[]time.Duration, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*list = append(*list, d)
+}
+
+type simPlayer struct {
+	name   string
+	game   string
+	server *url.URL
+	http   *http.Client
+	conn   *websocket.Conn
+	stats  *stats
+
+	mu     sync.Mutex
+	sentAt time.Time // the move still waiting for its update; zero when none is
+	done   map[string]bool
+	pushed chan struct{} // the server sent something
+}
+
+func newSimPlayer(server *url.URL, name, game string, st *stats) *simPlayer {
+	jar, _ := cookiejar.New(nil)
+	return &simPlayer{
+		name:   name,
+		game:   game,
+		server: server,
+		http:   &http.Client{Jar: jar, Timeout: 30 * time.Second},
+		stats:  st,
+		done:   map[string]bool{},
+		pushed: make(chan struct{}, 1),
+	}
+}
+
+// call sends an API request and decodes the reply into out.
+func (p *simPlayer) call(method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		b, _ := json.Marshal(body)
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, p.server.JoinPath("/api/v1", path).String(), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	start := time.Now()
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	p.stats.add(&p.stats.calls, time.Since(start))
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("%s %s: %d %s", method, path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+// join signs the player up, seats them in the lobby and opens the game's WebSocket.
+func (p *simPlayer) join() error {
+	if err := p.call("POST", "/session", map[string]string{"name": p.name}, nil); err != nil {
+		return err
+	}
+	if err := p.call("POST", "/games/"+p.game+"/join", nil, nil); err != nil {
+		return err
+	}
+	ws := *p.server
+	ws.Scheme = strings.Replace(ws.Scheme, "http", "ws", 1)
+	dialer := websocket.Dialer{Jar: p.http.Jar, HandshakeTimeout: 10 * time.Second}
+	conn, _, err := dialer.Dial(ws.JoinPath("/ws", p.game).String(), nil)
+	if err != nil {
+		return fmt.Errorf("websocket: %w", err)
+	}
+	p.conn = conn
+	go p.read()
+	return nil
+}
+
+// read takes what the server pushes: the first message after a move is its update.
+func (p *simPlayer) read() {
+	for {
+		_, msg, err := p.conn.ReadMessage()
+		if err != nil {
+			close(p.pushed)
+			return
+		}
+		p.mu.Lock()
+		if !p.sentAt.IsZero() {
+			p.stats.add(&p.stats.updates, time.Since(p.sentAt))
+			p.sentAt = time.Time{}
+		}
+		p.mu.Unlock()
+		if bytes.HasPrefix(msg, []byte(`{"type":"error"`)) {
+			var env struct {
+				Payload struct {
+					Code string `json:"code"`
+				} `json:"payload"`
+			}
+			json.Unmarshal(msg, &env)
+			p.stats.fail("error envelope: %s", env.Payload.Code)
+		}
+		select {
+		case p.pushed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// send makes a move once per key.
+func (p *simPlayer) send(key, action string, payload any) {
+	p.mu.Lock()
+	if p.done[key] {
+		p.mu.Unlock()
+		return
+	}
+	p.done[key] = true
+	if p.sentAt.IsZero() {
+		p.sentAt = time.Now()
+	}
+	p.mu.Unlock()
+	raw, _ := json.Marshal(payload)
+	env, _ := json.Marshal(map[string]any{"type": action, "version": wsProtocolVersion, "payload": json.RawMessage(raw)})
+	if err := p.conn.WriteMessage(websocket.TextMessage, env); err != nil {
+		p.stats.fail("websocket write: %v", err)
+	}
+}
+
+// play makes the player's moves until the game is over; it returns the winner.
+func (p *simPlayer) play(deadline time.Time) (string, error) {
+	tick := time.NewTicker(time.Second)
+	defer tick.Stop()
+	for time.Now().Before(deadline) {
+		var g apiGame
+		if err := p.call("GET", "/games/"+p.game, nil, &g); err != nil {
+			return "", err
+		}
+		if g.Status == "finished" {
+			return g.Winner, nil
+		}
+		p.move(g)
+		select {
+		case _, open := <-p.pushed:
+			if !open {
+				return "", fmt.Errorf("websocket closed in %s %d", g.Status, g.Round)
+			}
+		case <-tick.C:
+		}
+	}
+	return "", errTimeout
+}
+
+var errTimeout = fmt.Errorf("game not over in time")
+
+func (p *simPlayer) move(g apiGame) {
+	if !g.You.Alive {
+		return
+	}
+	living := []apiPlayer{}
+	for _, o := range g.Players {
+		if o.Alive && o.ID != g.You.ID {
+			living = append(living, o)
+		}
+	}
+	if len(living) == 0 {
+		return
+	}
+	slices.SortFunc(living, func(a, b apiPlayer) int { return int(a.ID - b.ID) })
+	round := fmt.Sprintf("%s-%d-", g.Status, g.Round)
+	switch g.Status {
+	case "night":
+		if g.You.Role == "Werewolf" {
+			for _, o := range living {
+				if o.Role != "Werewolf" {
+					p.send(round+"vote", "werewolf_vote", map[string]int64{"target_player_id": o.ID})
+					break
+				}
+			}
+			// the last wolf to vote closes the pack's vote; the others' tries are refused
+			p.send(round+"end", "werewolf_end_vote", nil)
+		}
+		p.send(round+"survey", "night_survey", nil)
+	case "day":
+		p.send(round+"vote", "day_vote", map[string]int64{"target_player_id": living[0].ID})
+		p.send(round+"end", "day_end_vote", nil)
+	}
+}
+
+// runGame sets up one game, plays it to the end and records how it went.
+func runGame(server *url.URL, name string, players, wolves int, timeout time.Duration, st *stats) {
+	sims := make([]*simPlayer, players)
+	for i := range sims {
+		sims[i] = newSimPlayer(server, fmt.Sprintf("%s-p%d", name, i+1), name, st)
+		// one after the other: the first to join is the host
+		if err := sims[i].join(); err != nil {
+			st.fail("join: %v", err)
+			return
+		}
+		defer sims[i].conn.Close()
+	}
+	host := sims[0]
+	for i := range players {
+		role := roleVillager
+		if i < wolves {
+			role = roleWerewolf
+		}
+		host.send(fmt.Sprintf("role-%d", i), "update_role", map[string]int{"role_id": role, "delta": 1})
+	}
+	host.send("start", "start_game", nil)
+
+	deadline := time.Now().Add(timeout)
+	var wg sync.WaitGroup
+	winners := make([]string, players)
+	for i, p := range sims {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			winner, err := p.play(deadline)
+			if err != nil && err != errTimeout {
+				st.fail("%v", err)
+			}
+			winners[i] = winner
+		}()
+	}
+	wg.Wait()
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if winners[0] == "" {
+		st.timedOut++
+		return
+	}
+	st.finished++
+	st.winners[winners[0]]++
+}
+
+// percentiles formats p50, p95, p99 and the maximum of the durations.
+func percentiles(ds []time.Duration) string {
+	if len(ds) == 0 {
+		return "none"
+	}
+	slices.Sort(ds)
+	at := func(q float64) time.Duration { return ds[int(q*float64(len(ds)-1))] }
+	return fmt.Sprintf("%d, p50 %v, p95 %v, p99 %v, max %v", len(ds), at(0.5).Round(time.Microsecond),
+		at(0.95).Round(time.Microsecond), at(0.99).Round(time.Microsecond), ds[len(ds)-1].Round(time.Microsecond))
+}
+
+func main() {
+	serverURL := flag.String("server", "http://localhost:8080", "base URL of the running server")
+	games := flag.Int("games", 1, "games played at the same time")
+	players := flag.Int("players", 6, "players per game")
+	wolves := flag.Int("wolves", 1, "werewolves per game")
+	timeout := flag.Duration("timeout", 5*time.Minute, "how long a game may take")
+	prefix := flag.String("prefix", "sim-"+time.Now().Format("150405"), "prefix of the game and player names, unique per run")
+	flag.Parse()
+
+	server, err := url.Parse(*serverURL)
+	if err != nil || server.Host == "" {
+		log.Fatalf("invalid -server %q", *serverURL)
+	}
+	if *wolves < 1 || *wolves >= *players {
+		log.Fatal("-wolves must be at least 1 and fewer than -players")
+	}
+
+	st := &stats{errors: map[string]int{}, winners: map[string]int{}}
+	start := time.Now()
+	var wg sync.WaitGroup
+	for g := range *games {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runGame(server, fmt.Sprintf("%s-g%d", *prefix, g+1), *players, *wolves, *timeout, st)
+		}()
+	}
+	wg.Wait()
+
+	fmt.Printf("games:   %d finished, %d timed out in %v (winners: %v)\n", st.finished, st.timedOut, time.Since(start).Round(time.Millisecond), st.winners)
+	fmt.Printf("updates: %s\n", percentiles(st.updates))
+	fmt.Printf("api:     %s\n", percentiles(st.calls))
+	if len(st.errors) == 0 && st.timedOut == 0 {
+		fmt.Println("errors:  none")
+		return
+	}
+	fmt.Println("errors:")
+	for msg, n := range st.errors {
+		fmt.Printf("  %dx %s\n", n, msg)
+	}
+	os.Exit(1)
+}
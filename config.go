@@ -6,19 +6,28 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 )
 
 // Priority (lowest → highest): defaults < env vars < JSON config file < CLI flags.
 type AppConfig struct {
 	DB                     string `json:"db"`
 	Dev                    bool   `json:"dev"` // verbose logging, db dumps on errors
-	Addr                   string `json:"addr"`
+	Addr                   string `json:"listen"`
+	TLSCert                string `json:"tls_cert"` // HTTPS with this certificate and TLSKey; reloaded when renewed
+	TLSKey                 string `json:"tls_key"`
+	TrustedProxies         string `json:"trusted_proxies"` // comma-separated addresses and CIDR ranges whose X-Forwarded-* headers count
+	LogFile                string `json:"log_file"`        // "" or "off" logs to stdout only
+	LogMaxSizeMB           int    `json:"log_max_size_mb"`
+	LogMaxAgeHours         int    `json:"log_max_age_hours"`
+	LogKeep                int    `json:"log_keep"` // rotated log files kept
 	LogOutputDir           string `json:"log_output_dir"`
 	LogRequests            bool   `json:"log_requests"`
 	LogHTML                bool   `json:"log_html"`
 	LogDB                  bool   `json:"log_db"`
 	LogWS                  bool   `json:"log_ws"`
 	LogDebug               bool   `json:"log_debug"`
+	LogFormat              string `json:"log_format"` // "text" (default) or "json"
 	Storyteller            bool   `json:"storyteller"`
 	OpenAIModel            string `json:"openai_model"`
 	OpenAIAPIBase          string `json:"openai_api_base"` // default: https://api.openai.com/v1
@@ -31,9 +40,16 @@ type AppConfig struct {
 	NarratorModel          string `json:"narrator_model"`
 	NarratorVoice          string `json:"narrator_voice"`
 	NarratorAPIKey         string `json:"narrator_api_key"`
-	NarratorURL            string `json:"narrator_url"`         // base URL for openai-compatible
-	NarratorSampleRate     int    `json:"narrator_sample_rate"` // Hz, default 24000
-	MinifyAssets           bool   `json:"minify_assets"`        // serve minified htmx/pico/idiomorph builds instead of full source
+	NarratorURL            string `json:"narrator_url"`          // base URL for openai-compatible
+	NarratorSampleRate     int    `json:"narrator_sample_rate"`  // Hz, default 24000
+	MinifyAssets           bool   `json:"minify_assets"`         // serve minified htmx/pico/idiomorph builds instead of full source
+	AdminToken             string `json:"admin_token"`           // bearer token for /admin/v1; empty = admin API off
+	SessionDays            int    `json:"session_days"`          // days a session lasts without activity, default 30
+	PublicURL              string `json:"public_url"`            // base URL for OAuth redirects; empty = the request's host
+	GoogleClientID         string `json:"google_client_id"`      // Google sign-in is offered with id and secret
+	GoogleClientSecret     string `json:"google_client_secret"`  // censored in the log
+	DiscordClientID        string `json:"discord_client_id"`     // Discord sign-in is offered with id and secret
+	DiscordClientSecret    string `json:"discord_client_secret"` // censored in the log
 }
 
 func (cfg AppConfig) toLogConfig() LogConfig {
@@ -49,9 +65,16 @@ func (cfg AppConfig) toLogConfig() LogConfig {
 
 func defaultConfig() AppConfig {
 	return AppConfig{
-		DB:           "file::memory:?cache=shared",
-		Addr:         ":8080",
-		MinifyAssets: true,
+		DB:             "file::memory:?cache=shared",
+		Addr:           ":8080",
+		TrustedProxies: defaultTrustedProxies,
+		LogFile:        "werewolf.log",
+		LogMaxSizeMB:   10,
+		LogMaxAgeHours: 24,
+		LogKeep:        5,
+		LogFormat:      "text",
+		MinifyAssets:   true,
+		SessionDays:    30,
 	}
 }
 
@@ -79,6 +102,30 @@ func loadConfig(configPath string) AppConfig {
 	if v := envStr("ADDR"); v != "" {
 		cfg.Addr = v
 	}
+	if v := envStr("LISTEN"); v != "" {
+		cfg.Addr = v
+	}
+	if v := envStr("TLS_CERT"); v != "" {
+		cfg.TLSCert = v
+	}
+	if v := envStr("TLS_KEY"); v != "" {
+		cfg.TLSKey = v
+	}
+	if v := envStr("TRUSTED_PROXIES"); v != "" {
+		cfg.TrustedProxies = v
+	}
+	if v := envStr("LOG_FILE"); v != "" {
+		cfg.LogFile = v
+	}
+	if v, ok := parseCount(envStr("LOG_MAX_SIZE_MB")); ok {
+		cfg.LogMaxSizeMB = v
+	}
+	if v, ok := parseCount(envStr("LOG_MAX_AGE_HOURS")); ok {
+		cfg.LogMaxAgeHours = v
+	}
+	if v, ok := parseCount(envStr("LOG_KEEP")); ok {
+		cfg.LogKeep = v
+	}
 	if v := envStr("LOG_OUTPUT_DIR"); v != "" {
 		cfg.LogOutputDir = v
 	}
@@ -97,6 +144,9 @@ func loadConfig(configPath string) AppConfig {
 	if v, ok := envBool("LOG_DEBUG"); ok {
 		cfg.LogDebug = v
 	}
+	if v := envStr("LOG_FORMAT"); v != "" {
+		cfg.LogFormat = v
+	}
 	if v, ok := envBool("STORYTELLER"); ok {
 		cfg.Storyteller = v
 	}
@@ -150,6 +200,31 @@ func loadConfig(configPath string) AppConfig {
 	if v, ok := envBool("MINIFY_ASSETS"); ok {
 		cfg.MinifyAssets = v
 	}
+	if v := envStr("ADMIN_TOKEN"); v != "" {
+		cfg.AdminToken = v
+	}
+	if v := envStr("SESSION_DAYS"); v != "" {
+		var n int
+		fmt.Sscanf(v, "%d", &n)
+		if n > 0 {
+			cfg.SessionDays = n
+		}
+	}
+	if v := envStr("PUBLIC_URL"); v != "" {
+		cfg.PublicURL = v
+	}
+	if v := envStr("GOOGLE_CLIENT_ID"); v != "" {
+		cfg.GoogleClientID = v
+	}
+	if v := envStr("GOOGLE_CLIENT_SECRET"); v != "" {
+		cfg.GoogleClientSecret = v
+	}
+	if v := envStr("DISCORD_CLIENT_ID"); v != "" {
+		cfg.DiscordClientID = v
+	}
+	if v := envStr("DISCORD_CLIENT_SECRET"); v != "" {
+		cfg.DiscordClientSecret = v
+	}
 
 	// Layer 2: JSON config file — only fields present in the file override env vars
 	if data, err := os.ReadFile(configPath); err == nil {
@@ -167,6 +242,26 @@ func loadConfig(configPath string) AppConfig {
 	return cfg
 }
 
+// parseCount reads a log limit from the environment or the JSON file. 0 is a value like any
+// other (no size or age limit, no rotated files kept), the same as on the command line, so
+// only text that is not a non-negative number leaves the setting alone.
+func parseCount(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// listenAddr turns the configured listen address into one for net.Listen: a bare port
+// listens on every interface, like ":port".
+func listenAddr(addr string) string {
+	if _, err := strconv.Atoi(addr); err == nil {
+		return ":" + addr
+	}
+	return addr
+}
+
 func censor(s string) string {
 	if s == "" {
 		return ""
@@ -178,13 +273,21 @@ func (cfg AppConfig) logConfig() {
 	log.Println("=== Configuration ===")
 	log.Printf("  db:                            %s", cfg.DB)
 	log.Printf("  dev:                           %v", cfg.Dev)
-	log.Printf("  addr:                          %s", cfg.Addr)
+	log.Printf("  listen:                        %s", cfg.Addr)
+	log.Printf("  tls_cert:                      %s", cfg.TLSCert)
+	log.Printf("  tls_key:                       %s", cfg.TLSKey)
+	log.Printf("  trusted_proxies:               %s", cfg.TrustedProxies)
+	log.Printf("  log_file:                      %s", cfg.LogFile)
+	log.Printf("  log_max_size_mb:               %d", cfg.LogMaxSizeMB)
+	log.Printf("  log_max_age_hours:             %d", cfg.LogMaxAgeHours)
+	log.Printf("  log_keep:                      %d", cfg.LogKeep)
 	log.Printf("  log_output_dir:                %s", cfg.LogOutputDir)
 	log.Printf("  log_requests:                  %v", cfg.LogRequests)
 	log.Printf("  log_html:                      %v", cfg.LogHTML)
 	log.Printf("  log_db:                        %v", cfg.LogDB)
 	log.Printf("  log_ws:                        %v", cfg.LogWS)
 	log.Printf("  log_debug:                     %v", cfg.LogDebug)
+	log.Printf("  log_format:                    %s", cfg.LogFormat)
 	log.Printf("  storyteller:                   %v", cfg.Storyteller)
 	log.Printf("  openai_model:                  %s", cfg.OpenAIModel)
 	log.Printf("  openai_api_base:               %s", cfg.OpenAIAPIBase)
@@ -200,6 +303,13 @@ func (cfg AppConfig) logConfig() {
 	log.Printf("  narrator_url:                  %s", cfg.NarratorURL)
 	log.Printf("  narrator_sample_rate:          %d", cfg.NarratorSampleRate)
 	log.Printf("  minify_assets:                 %v", cfg.MinifyAssets)
+	log.Printf("  admin_token:                   %s", censor(cfg.AdminToken))
+	log.Printf("  session_days:                  %d", cfg.SessionDays)
+	log.Printf("  public_url:                    %s", cfg.PublicURL)
+	log.Printf("  google_client_id:              %s", cfg.GoogleClientID)
+	log.Printf("  google_client_secret:          %s", censor(cfg.GoogleClientSecret))
+	log.Printf("  discord_client_id:             %s", cfg.DiscordClientID)
+	log.Printf("  discord_client_secret:         %s", censor(cfg.DiscordClientSecret))
 	log.Println("=====================")
 }
 
@@ -214,15 +324,29 @@ func applyJSONOverlay(cfg *AppConfig, m map[string]json.RawMessage) {
 			json.Unmarshal(v, dst)
 		}
 	}
+	count := func(key string, dst *int) {
+		if v, ok := parseCount(string(m[key])); ok {
+			*dst = v
+		}
+	}
 	str("db", &cfg.DB)
 	boolean("dev", &cfg.Dev)
 	str("addr", &cfg.Addr)
+	str("listen", &cfg.Addr)
+	str("tls_cert", &cfg.TLSCert)
+	str("tls_key", &cfg.TLSKey)
+	str("trusted_proxies", &cfg.TrustedProxies)
+	str("log_file", &cfg.LogFile)
+	count("log_max_size_mb", &cfg.LogMaxSizeMB)
+	count("log_max_age_hours", &cfg.LogMaxAgeHours)
+	count("log_keep", &cfg.LogKeep)
 	str("log_output_dir", &cfg.LogOutputDir)
 	boolean("log_requests", &cfg.LogRequests)
 	boolean("log_html", &cfg.LogHTML)
 	boolean("log_db", &cfg.LogDB)
 	boolean("log_ws", &cfg.LogWS)
 	boolean("log_debug", &cfg.LogDebug)
+	str("log_format", &cfg.LogFormat)
 	boolean("storyteller", &cfg.Storyteller)
 	str("openai_model", &cfg.OpenAIModel)
 	str("openai_api_base", &cfg.OpenAIAPIBase)
@@ -242,6 +366,15 @@ func applyJSONOverlay(cfg *AppConfig, m map[string]json.RawMessage) {
 		json.Unmarshal(v, &cfg.NarratorSampleRate)
 	}
 	boolean("minify_assets", &cfg.MinifyAssets)
+	str("admin_token", &cfg.AdminToken)
+	if v, ok := m["session_days"]; ok {
+		json.Unmarshal(v, &cfg.SessionDays)
+	}
+	str("public_url", &cfg.PublicURL)
+	str("google_client_id", &cfg.GoogleClientID)
+	str("google_client_secret", &cfg.GoogleClientSecret)
+	str("discord_client_id", &cfg.DiscordClientID)
+	str("discord_client_secret", &cfg.DiscordClientSecret)
 }
 
 type flagValues struct {
@@ -249,12 +382,21 @@ type flagValues struct {
 	db                     *string
 	dev                    *bool
 	addr                   *string
+	listen                 *string
+	tlsCert                *string
+	tlsKey                 *string
+	trustedProxies         *string
+	logFile                *string
+	logMaxSizeMB           *int
+	logMaxAgeHours         *int
+	logKeep                *int
 	logOutputDir           *string
 	logRequests            *bool
 	logHTML                *bool
 	logDB                  *bool
 	logWS                  *bool
 	logDebug               *bool
+	logFormat              *string
 	storyteller            *bool
 	openaiModel            *string
 	openaiAPIBase          *string
@@ -270,6 +412,15 @@ type flagValues struct {
 	narratorURL            *string
 	narratorSampleRate     *int
 	minifyAssets           *bool
+	adminToken             *string
+	sessionDays            *int
+	publicURL              *string
+	googleClientID         *string
+	googleClientSecret     *string
+	discordClientID        *string
+	discordClientSecret    *string
+	backup                 *string
+	restore                *string
 }
 
 func registerFlags() flagValues {
@@ -277,13 +428,22 @@ func registerFlags() flagValues {
 		configPath:             flag.String("config", "/etc/werewolf/config.json", "path to JSON config file"),
 		db:                     flag.String("db", "", "database connection string"),
 		dev:                    flag.Bool("dev", false, "enable development mode (verbose logging, db dumps on error)"),
-		addr:                   flag.String("addr", "", "HTTP listen address (e.g. :8080)"),
+		addr:                   flag.String("addr", "", "older name of -listen"),
+		listen:                 flag.String("listen", "", `address and port to listen on: "host:port", ":port" or a port (default :8080)`),
+		tlsCert:                flag.String("tls-cert", "", "serve HTTPS with this certificate file (PEM, full chain); reloaded when it changes"),
+		tlsKey:                 flag.String("tls-key", "", "private key file (PEM) for -tls-cert"),
+		trustedProxies:         flag.String("trusted-proxies", defaultTrustedProxies, "comma-separated addresses and CIDR ranges of reverse proxies whose X-Forwarded-* headers are trusted; empty trusts none"),
+		logFile:                flag.String("log-file", "werewolf.log", `server log file, rotated; "" or "off" logs to stdout only`),
+		logMaxSizeMB:           flag.Int("log-max-size-mb", 10, "rotate the log file at this size in MB, 0 = no size limit"),
+		logMaxAgeHours:         flag.Int("log-max-age-hours", 24, "rotate the log file after this many hours, 0 = no age limit"),
+		logKeep:                flag.Int("log-keep", 5, "rotated log files kept next to the current one, 0 = none"),
 		logOutputDir:           flag.String("log-output-dir", "", "directory for extended log files"),
 		logRequests:            flag.Bool("log-requests", false, "log HTTP requests and responses"),
 		logHTML:                flag.Bool("log-html", false, "log HTML states"),
 		logDB:                  flag.Bool("log-db", false, "log database dumps"),
 		logWS:                  flag.Bool("log-ws", false, "log WebSocket messages"),
 		logDebug:               flag.Bool("log-debug", false, "enable debug logging"),
+		logFormat:              flag.String("log-format", "", `log line format: "text" (default) or "json" (structured, via log/slog)`),
 		storyteller:            flag.Bool("storyteller", false, "enable AI storyteller"),
 		openaiModel:            flag.String("openai-model", "", "OpenAI model name"),
 		openaiAPIBase:          flag.String("openai-api-base", "", "OpenAI API base URL (default: https://api.openai.com/v1)"),
@@ -299,6 +459,15 @@ func registerFlags() flagValues {
 		narratorURL:            flag.String("narrator-url", "", "base URL for openai-compatible TTS provider"),
 		narratorSampleRate:     flag.Int("narrator-sample-rate", 0, "PCM sample rate in Hz (default 24000)"),
 		minifyAssets:           flag.Bool("minify-assets", true, "serve minified htmx/pico/idiomorph builds (disable for readable source in devtools)"),
+		adminToken:             flag.String("admin-token", "", "bearer token for the admin API under /admin/v1 (off when empty)"),
+		sessionDays:            flag.Int("session-days", 0, "days a session lasts without activity (default 30)"),
+		publicURL:              flag.String("public-url", "", "base URL players reach the server under (e.g. https://werewolf.example), used for OAuth redirects"),
+		googleClientID:         flag.String("google-client-id", "", "Google OAuth client id (Google sign-in needs the id and the secret)"),
+		googleClientSecret:     flag.String("google-client-secret", "", "Google OAuth client secret"),
+		discordClientID:        flag.String("discord-client-id", "", "Discord OAuth client id (Discord sign-in needs the id and the secret)"),
+		discordClientSecret:    flag.String("discord-client-secret", "", "Discord OAuth client secret"),
+		backup:                 flag.String("backup", "", "write a snapshot of the database to this path and exit (safe while the server runs)"),
+		restore:                flag.String("restore", "", "replace the database with the backup at this path and exit (stop the server first)"),
 	}
 }
 
@@ -313,6 +482,22 @@ func (fv flagValues) applyTo(cfg *AppConfig) {
 			cfg.Dev = *fv.dev
 		case "addr":
 			cfg.Addr = *fv.addr
+		case "listen":
+			cfg.Addr = *fv.listen
+		case "tls-cert":
+			cfg.TLSCert = *fv.tlsCert
+		case "tls-key":
+			cfg.TLSKey = *fv.tlsKey
+		case "trusted-proxies":
+			cfg.TrustedProxies = *fv.trustedProxies
+		case "log-file":
+			cfg.LogFile = *fv.logFile
+		case "log-max-size-mb":
+			cfg.LogMaxSizeMB = *fv.logMaxSizeMB
+		case "log-max-age-hours":
+			cfg.LogMaxAgeHours = *fv.logMaxAgeHours
+		case "log-keep":
+			cfg.LogKeep = *fv.logKeep
 		case "log-output-dir":
 			cfg.LogOutputDir = *fv.logOutputDir
 		case "log-requests":
@@ -325,6 +510,8 @@ func (fv flagValues) applyTo(cfg *AppConfig) {
 			cfg.LogWS = *fv.logWS
 		case "log-debug":
 			cfg.LogDebug = *fv.logDebug
+		case "log-format":
+			cfg.LogFormat = *fv.logFormat
 		case "storyteller":
 			cfg.Storyteller = *fv.storyteller
 		case "openai-model":
@@ -355,6 +542,20 @@ func (fv flagValues) applyTo(cfg *AppConfig) {
 			cfg.NarratorSampleRate = *fv.narratorSampleRate
 		case "minify-assets":
 			cfg.MinifyAssets = *fv.minifyAssets
+		case "admin-token":
+			cfg.AdminToken = *fv.adminToken
+		case "session-days":
+			cfg.SessionDays = *fv.sessionDays
+		case "public-url":
+			cfg.PublicURL = *fv.publicURL
+		case "google-client-id":
+			cfg.GoogleClientID = *fv.googleClientID
+		case "google-client-secret":
+			cfg.GoogleClientSecret = *fv.googleClientSecret
+		case "discord-client-id":
+			cfg.DiscordClientID = *fv.discordClientID
+		case "discord-client-secret":
+			cfg.DiscordClientSecret = *fv.discordClientSecret
 		}
 	})
 }
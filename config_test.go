@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+// ============================================================================
+// Configuration Tests
+// ============================================================================
+
+func TestListenAddr(t *testing.T) {
+	t.Parallel()
+	for addr, want := range map[string]string{
+		"9090":           ":9090",
+		":9090":          ":9090",
+		"127.0.0.1:9090": "127.0.0.1:9090",
+		"[::1]:9090":     "[::1]:9090",
+	} {
+		if got := listenAddr(addr); got != want {
+			t.Errorf("%q: got %q, want %q", addr, got, want)
+		}
+	}
+}
+
+func TestLogLimitsZeroMeansNone(t *testing.T) {
+	t.Setenv("LOG_MAX_SIZE_MB", "0")
+	t.Setenv("LOG_MAX_AGE_HOURS", "0")
+	t.Setenv("LOG_KEEP", "-1")
+	dir := t.TempDir()
+	cfg := loadConfig(dir + "/missing.json")
+	if cfg.LogMaxSizeMB != 0 || cfg.LogMaxAgeHours != 0 {
+		t.Errorf("0 in the environment should turn the limits off, got %d MB and %d hours", cfg.LogMaxSizeMB, cfg.LogMaxAgeHours)
+	}
+	if cfg.LogKeep != 5 {
+		t.Errorf("a negative count should keep the default, got %d", cfg.LogKeep)
+	}
+
+	path := dir + "/config.json"
+	if err := os.WriteFile(path, []byte(`{"log_max_size_mb": 20, "log_keep": 0}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg = loadConfig(path)
+	if cfg.LogMaxSizeMB != 20 || cfg.LogKeep != 0 {
+		t.Errorf("the config file should set the limits, 0 included: got %d MB, keep %d", cfg.LogMaxSizeMB, cfg.LogKeep)
+	}
+}
@@ -14,6 +14,7 @@ type Game struct {
 	Round     int     `db:"round"`
 	AIEnabled bool    `db:"ai_enabled"` // default true = AI storyteller + narrator active
 	Winner    *string `db:"winner"`
+	Paused    bool    `db:"paused"` // the host has paused the running game
 }
 
 type GameRoleConfig struct {
@@ -28,7 +29,6 @@ type Player struct {
 	GameID          int64  `db:"game_id"`
 	PlayerID        int64  `db:"player_id"`
 	Name            string `db:"name"`
-	SecretCode      string `db:"secret_code"`
 	RoleId          string `db:"role_id"`
 	RoleName        string `db:"role_name"`
 	RoleDescription string `db:"role_description"`
@@ -47,7 +47,6 @@ func getPlayerInGame(db *sqlx.DB, gameID, playerID int64) (Player, error) {
 			g.rowid as game_id,
 			p.rowid as player_id,
 			p.name as name,
-			p.secret_code as secret_code,
 			r.rowid as role_id,
 			r.name as role_name,
 			r.description as role_description,
@@ -74,7 +73,7 @@ func getPlayerName(db *sqlx.DB, playerID int64) string {
 
 func getPlayerByName(db *sqlx.DB, name string) (Player, error) {
 	var player Player
-	err := db.Get(&player, "SELECT rowid as id, name, secret_code FROM player WHERE name = ?", name)
+	err := db.Get(&player, "SELECT rowid as id, name FROM player WHERE name = ?", name)
 	return player, err
 }
 
@@ -84,6 +83,8 @@ func getRoleName(db *sqlx.DB, gameID, playerID int64) string {
 	return name
 }
 
+// getPlayersByGameId returns the game's players; observers have no seat and are left out
+// (see getObserversByGameId).
 func getPlayersByGameId(db *sqlx.DB, id int64) ([]Player, error) {
 	var players []Player
 	err := db.Select(&players, `
@@ -91,7 +92,6 @@ func getPlayersByGameId(db *sqlx.DB, id int64) ([]Player, error) {
 			g.rowid as game_id,
 			p.rowid as player_id,
 			p.name as name,
-			p.secret_code as secret_code,
 			r.rowid as role_id,
 			r.name as role_name,
 			r.description as role_description,
@@ -106,7 +106,7 @@ func getPlayersByGameId(db *sqlx.DB, id int64) ([]Player, error) {
 			JOIN game g on gp.game_id = g.rowid
 			JOIN role r on gp.role_id = r.rowid
 			LEFT JOIN game_lovers l on l.player1_id = p.rowid
-		WHERE g.rowid = ?`, id)
+		WHERE g.rowid = ? AND gp.is_observer = 0`, id)
 	return players, err
 }
 
@@ -115,6 +115,8 @@ type Role struct {
 	Name        string `db:"name"`
 	Team        string `db:"team"`
 	Description string `db:"description"`
+	Ability     string `db:"ability"`
+	Pack        string `db:"pack"`
 }
 
 func getRoles(db *sqlx.DB) ([]Role, error) {
@@ -123,7 +125,9 @@ func getRoles(db *sqlx.DB) ([]Role, error) {
 		SELECT rowid as id,
 			name,
 			description,
-			team
+			team,
+			ability,
+			pack
 		FROM role
 		`)
 	return roles, err
@@ -156,7 +160,8 @@ const (
 	ActionWitchApplyKill           = "witch_apply_kill"
 	ActionWitchApply               = "witch_apply"
 	ActionDoppelgangerSelectCopy   = "doppelganger_select_copy"
-	ActionDoppelgangerApplyCopy    = "doppelganger_apply_copy"
+	ActionDoppelgangerApplyCopy    = "doppelganger_apply_copy" // the role model is chosen; it lives in game_role_model
+	ActionDoppelgangerInherit      = "doppelganger_inherit"    // the role model died and the Doppelganger took their role
 	ActionWerewolfSelectKill       = "werewolf_select_kill"
 	ActionWerewolfApplyKill        = "werewolf_apply_kill"
 	ActionWerewolfSelectKill2      = "werewolf_select_kill_2"
@@ -167,35 +172,184 @@ const (
 	ActionDayApplyKill             = "day_apply_kill"
 	ActionNightApplyKill           = "night_apply_kill"
 
+	ActionSorceressSelectInvestigate = "sorceress_select_investigate"
+	ActionSorceressApplyInvestigate  = "sorceress_apply_investigate"
+
+	// the apply row stays pending (description '') until dawn, like night kills
+	ActionAlphaSelectBite = "alpha_select_bite"
+	ActionAlphaApplyBite  = "alpha_apply_bite"
+	ActionAlphaBitten     = "alpha_bitten"
+
+	ActionBodyguardSelectGuard = "bodyguard_select_guard"
+	ActionBodyguardApplyGuard  = "bodyguard_apply_guard"
+
+	ActionApprenticeSeerPromoted = "apprentice_seer_promoted"
+
+	ActionAuraSeerSelectInvestigate = "aura_seer_select_investigate"
+	ActionAuraSeerApplyInvestigate  = "aura_seer_apply_investigate"
+
+	ActionPrinceRevealed = "prince_revealed"
+
+	// a revealed, living Village Idiot holds no vote for the rest of the game
+	ActionVillageIdiotRevealed = "village_idiot_revealed"
+
+	ActionPriestSelectWater = "priest_select_water"
+	ActionPriestApplyWater  = "priest_apply_water"
+
+	ActionSpellcasterSelectSilence = "spellcaster_select_silence"
+	ActionSpellcasterApplySilence  = "spellcaster_apply_silence"
+
+	ActionDrunkSobered = "drunk_sobered"
+
+	// the wound stays pending (description '') until dawn, like night kills
+	ActionToughGuyWounded = "tough_guy_wounded"
+	ActionToughGuyDied    = "tough_guy_died"
+
+	// pending (description '') until dawn, like night kills
+	ActionCursedTurned = "cursed_turned"
+
+	ActionSerialKillerSelectKill = "serial_killer_select_kill"
+	ActionSerialKillerApplyKill  = "serial_killer_apply_kill"
+
+	// the charmed player's notice stays pending (description '') until dawn
+	ActionPiperSelectCharm1 = "piper_select_charm_1"
+	ActionPiperSelectCharm2 = "piper_select_charm_2"
+	ActionPiperApplyCharm   = "piper_apply_charm"
+	ActionPiperCharmed      = "piper_charmed"
+
+	// target_player_id is NULL when the White Werewolf spares the pack
+	ActionWhiteWolfSelectKill = "white_wolf_select_kill"
+	ActionWhiteWolfApplyKill  = "white_wolf_apply_kill"
+
+	// fox_lost_power is a marker row (description '') left by a sniff that found no wolf
+	ActionFoxSelectSniff = "fox_select_sniff"
+	ActionFoxApplySniff  = "fox_apply_sniff"
+	ActionFoxLostPower   = "fox_lost_power"
+
+	// the survival stays pending (description '') until dawn, like night kills
+	ActionElderSurvived = "elder_survived"
+	ActionElderLynched  = "elder_lynched"
+
+	// allow rows use the chosen voter as actor; the choice stays pending (description '') until confirmed
+	ActionScapegoatAllowVote   = "scapegoat_allow_vote"
+	ActionScapegoatApplyChoice = "scapegoat_apply_choice"
+
+	// recorded in the 'setup' phase (round 0); target_player_id is always NULL
+	ActionThiefApplyChoice = "thief_apply_choice"
+
+	// one row at the start of night 1, seen by every Mason (team:mason); the actor is the first Mason
+	ActionMasonsRevealed = "masons_revealed"
+
+	// the role model itself lives in game_role_model; the apply row is the history entry
+	ActionWildChildSelectModel = "wild_child_select_model"
+	ActionWildChildApplyModel  = "wild_child_apply_model"
+	ActionWildChildTurned      = "wild_child_turned"
+
+	// roles created in the lobby share one select stage; the apply type follows their ability
+	ActionCustomSelectTarget     = "custom_select_target"
+	ActionCustomApplyInvestigate = "custom_apply_investigate"
+	ActionCustomApplyProtect     = "custom_apply_protect"
+
+	// left for each living player who had not finished when the night timer ran out
+	ActionNightTimedOut = "night_timed_out"
+	// one public row when the day timer closed the vote; the actor is any living player
+	ActionDayTimedOut = "day_timed_out"
+
+	// nominations-mode day; a nominee is on the ballot once seconded, and one open row ends the nominations
+	ActionDayNominate = "day_nominate"
+	ActionDaySecond   = "day_second"
+	ActionDayOpenVote = "day_open_vote"
+
+	// trials: the accused is the actor of the trial, defense and acquittal rows; a verdict
+	// row's target is the accused for guilty and NULL for innocent
+	ActionDayTrial     = "day_trial"
+	ActionDayDefense   = "day_defense"
+	ActionDayVerdict   = "day_verdict"
+	ActionDayAcquitted = "day_acquitted"
+
+	// runoff candidates are the actors of day_runoff rows; the runoff's votes are cast afresh
+	ActionDayRunoff     = "day_runoff"
+	ActionDayRunoffVote = "day_runoff_vote"
+
+	// last words: the speaker is the actor; a day_dusk row marks a day that is over and only
+	// waits for them
+	ActionDayLastWords = "day_last_words"
+	ActionDayDusk      = "day_dusk"
+
+	// the opening Mayor election; the elected Mayor is the actor of the mayor_elected row
+	ActionMayorElectionVote = "mayor_election_vote"
+	ActionMayorElected      = "mayor_elected"
+
 	// Cupid has no apply stage
 	ActionCupidSelectLink1 = "cupid_select_link_1"
 	ActionCupidSelectLink2 = "cupid_select_link_2"
 
 	ActionLoverHeartbreak = "lover_heartbreak"
 	ActionStory           = "story"
+
+	// the host handed a dropped player's seat to an observer: actor = the new player, target = the old
+	ActionPlayerSubstituted = "player_substituted"
+
+	// the host took the game back to before its last resolution
+	ActionResolutionUndone = "resolution_undone"
+
+	// the host overrode the game state (moderator.go): actor = the player concerned, or the
+	// host for a forced phase
+	ActionModeratorKill       = "moderator_kill"
+	ActionModeratorRevive     = "moderator_revive"
+	ActionModeratorSetRole    = "moderator_set_role"
+	ActionModeratorForcePhase = "moderator_force_phase"
+
+	// a private day message (day_whisper.go): actor = the sender, target = the recipient
+	ActionDayWhisper = "day_whisper"
 )
 
 const (
 	VisibilityPublic       = "public"
 	VisibilityTeamWerewolf = "team:werewolf"
 	VisibilityTeamVillager = "team:villager"
+	VisibilityTeamMason    = "team:mason"
 	VisibilityActor        = "actor"
 	VisibilityResolved     = "resolved"
+	VisibilityModerator    = "moderator" // the host's overrides; see canSeeOverride
+	VisibilityPair         = "pair"      // the actor and the target; the host audits it, see canSeeOverride
 )
 
+// wolfPackHelpers are werewolf-team roles that do not hunt with the pack: they win with
+// the wolves but neither vote on the night kill nor appear to them.
+var wolfPackHelpers = map[string]bool{
+	"Sorceress": true,
+	"Minion":    true,
+}
+
+// wolfPackSQL is the SQL counterpart of inWolfPack for queries joining role as r.
+// Keep the name list in sync with wolfPackHelpers.
+const wolfPackSQL = "r.team = 'werewolf' AND r.name NOT IN ('Sorceress', 'Minion')"
+
+// inWolfPack reports whether p is one of the werewolves that vote on the night kill.
+func inWolfPack(p Player) bool {
+	return p.Team == "werewolf" && !wolfPackHelpers[p.RoleName]
+}
+
 func canSeeAction(action GameAction, viewer Player, currentRound int, currentPhase string) bool {
 	switch action.Visibility {
 	case VisibilityPublic:
 		return true
 	case VisibilityTeamWerewolf:
-		return viewer.Team == "werewolf"
+		return inWolfPack(viewer)
 	case VisibilityTeamVillager:
 		return viewer.Team == "villager"
+	case VisibilityTeamMason:
+		// Masons are villagers by team, so they need their own rule to share
+		// information only among themselves.
+		return viewer.RoleName == "Mason"
 	case VisibilityActor:
 		return viewer.PlayerID == action.ActorPlayerID
+	case VisibilityPair:
+		return viewer.PlayerID == action.ActorPlayerID || action.TargetPlayerID != nil && viewer.PlayerID == *action.TargetPlayerID
 	case VisibilityResolved:
 		// Visible once we're past the phase when action was created
-		if action.Round < currentRound {
+		if action.Round < currentRound || currentPhase == "finished" {
 			return true
 		}
 		if action.Round == currentRound && action.Phase == "night" && currentPhase == "day" {
@@ -207,24 +361,34 @@ func canSeeAction(action GameAction, viewer Player, currentRound int, currentPha
 	}
 }
 
+// getVoteCounts returns the weighted votes per target and the weighted total including passes.
+// Each vote counts voteWeight(voter's role), so a Mayor's vote counts twice while village powers last.
 func getVoteCounts(db *sqlx.DB, gameID int64, round int, phase string, actionType string) (map[int64]int, int, error) {
-	var actions []GameAction
-	err := db.Select(&actions, `
-		SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
-		FROM game_action
-		WHERE game_id = ? AND round = ? AND phase = ? AND action_type = ?`,
+	var votes []struct {
+		TargetPlayerID *int64 `db:"target_player_id"`
+		RoleName       string `db:"role_name"`
+	}
+	err := db.Select(&votes, `
+		SELECT ga.target_player_id, IFNULL(r.name, '') as role_name
+		FROM game_action ga
+		LEFT JOIN game_player gp ON gp.game_id = ga.game_id AND gp.player_id = ga.actor_player_id
+		LEFT JOIN role r ON gp.role_id = r.rowid
+		WHERE ga.game_id = ? AND ga.round = ? AND ga.phase = ? AND ga.action_type = ?`,
 		gameID, round, phase, actionType)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	voteCounts := make(map[int64]int)
-	for _, action := range actions {
-		if action.TargetPlayerID != nil {
-			voteCounts[*action.TargetPlayerID]++
+	total := 0
+	for _, v := range votes {
+		w := voteWeight(db, gameID, v.RoleName)
+		total += w
+		if v.TargetPlayerID != nil {
+			voteCounts[*v.TargetPlayerID] += w
 		}
 	}
-	return voteCounts, len(actions), nil
+	return voteCounts, total, nil
 }
 
 func getLoverPartner(db *sqlx.DB, gameID, playerID int64) int64 {
@@ -293,6 +457,51 @@ func initDB(db *sqlx.DB, logfn func(string, ...any)) error {
 		FOREIGN KEY (game_id) REFERENCES game(rowid),
 		UNIQUE(game_id, player1_id)
 	);
+	CREATE TABLE IF NOT EXISTS game_charmed (
+		game_id INTEGER NOT NULL,
+		player_id INTEGER NOT NULL,
+		round INTEGER NOT NULL,
+		FOREIGN KEY (game_id) REFERENCES game(rowid),
+		FOREIGN KEY (player_id) REFERENCES player(rowid),
+		UNIQUE(game_id, player_id)
+	);
+	CREATE TABLE IF NOT EXISTS game_role_model (
+		game_id INTEGER NOT NULL,
+		child_player_id INTEGER NOT NULL,
+		model_player_id INTEGER NOT NULL,
+		FOREIGN KEY (game_id) REFERENCES game(rowid),
+		FOREIGN KEY (child_player_id) REFERENCES player(rowid),
+		FOREIGN KEY (model_player_id) REFERENCES player(rowid),
+		UNIQUE(game_id, child_player_id)
+	);
+	CREATE TABLE IF NOT EXISTS game_hidden_pack (
+		game_id INTEGER NOT NULL,
+		pack TEXT NOT NULL,
+		FOREIGN KEY (game_id) REFERENCES game(rowid),
+		UNIQUE(game_id, pack)
+	);
+	CREATE TABLE IF NOT EXISTS role_preset (
+		name TEXT NOT NULL,
+		role_id INTEGER NOT NULL,
+		count INTEGER NOT NULL,
+		FOREIGN KEY (role_id) REFERENCES role(rowid),
+		UNIQUE(name, role_id)
+	);
+	CREATE TABLE IF NOT EXISTS game_chat (
+		game_id INTEGER NOT NULL,
+		round INTEGER NOT NULL,
+		channel TEXT NOT NULL,
+		player_id INTEGER NOT NULL,
+		message TEXT NOT NULL,
+		FOREIGN KEY (game_id) REFERENCES game(rowid),
+		FOREIGN KEY (player_id) REFERENCES player(rowid)
+	);
+	CREATE TABLE IF NOT EXISTS game_spare_role (
+		game_id INTEGER NOT NULL,
+		role_id INTEGER NOT NULL,
+		FOREIGN KEY (game_id) REFERENCES game(rowid),
+		FOREIGN KEY (role_id) REFERENCES role(rowid)
+	);
 	CREATE TABLE IF NOT EXISTS game_action (
 		game_id INTEGER NOT NULL,
 		round INTEGER NOT NULL,
@@ -320,6 +529,16 @@ func initDB(db *sqlx.DB, logfn func(string, ...any)) error {
 		FOREIGN KEY (second_player_id) REFERENCES player(rowid),
 		UNIQUE(game_id, cupid_player_id)
 	);
+	CREATE TABLE IF NOT EXISTS game_checkpoint (
+		game_id INTEGER NOT NULL,
+		kind TEXT NOT NULL,
+		round INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		winner TEXT,
+		last_action_id INTEGER NOT NULL,
+		state TEXT NOT NULL,
+		FOREIGN KEY (game_id) REFERENCES game(rowid)
+	);
 	CREATE TABLE IF NOT EXISTS player_image (
 		image_data BLOB NOT NULL,
 		mime_type TEXT NOT NULL
@@ -339,7 +558,32 @@ func initDB(db *sqlx.DB, logfn func(string, ...any)) error {
 	  ('Mason', 'Knows other masons, providing confirmed villagers.', 'villager'),
 	  ('Wolf Cub', 'If eliminated, werewolves kill two victims the next night.', 'werewolf'),
 	  ('Doppelganger', 'On night 1, secretly copies another player''s role and becomes that role for the rest of the game.', 'villager'),
-	  ('Joker', 'Gets assigned a random other role at the start of the game.', 'villager')
+	  ('Joker', 'Gets assigned a random other role at the start of the game.', 'villager'),
+	  ('Sorceress', 'On the werewolf team but unknown to the wolves; searches for the Seer each night.', 'werewolf'),
+	  ('Alpha Werewolf', 'Hunts with the pack; once per game can turn the night''s victim into a werewolf instead of killing them.', 'werewolf'),
+	  ('Minion', 'Knows who the werewolves are and wins with them, but the wolves do not know the Minion.', 'werewolf'),
+	  ('Tanner', 'Hates their life and wins alone if the village lynches them.', 'tanner'),
+	  ('Bodyguard', 'Guards one player per night and dies in their place if the werewolves attack them.', 'villager'),
+	  ('Apprentice Seer', 'Takes over as the Seer once the Seer dies, investigating from the next night on.', 'villager'),
+	  ('Aura Seer', 'Each night, learns whether one player has a special power (any role other than Villager).', 'villager'),
+	  ('Lycan', 'A villager with a wolf curse: the Seer sees them as a werewolf.', 'villager'),
+	  ('Prince', 'If the village votes them out, their role is revealed and they survive — but only once.', 'villager'),
+	  ('Mayor', 'Their vote counts twice during the day elimination.', 'villager'),
+	  ('Priest', 'Once per game during the day, throws holy water: a werewolf hit dies, otherwise the Priest dies.', 'villager'),
+	  ('Spellcaster', 'Each night, silences one player, who cannot vote the following day.', 'villager'),
+	  ('Drunk', 'Believes they are a Villager until night 3, when they learn their real role.', 'villager'),
+	  ('Tough Guy', 'Survives a werewolf attack, but dies at the end of the following day.', 'villager'),
+	  ('Diseased', 'If the werewolves kill them, the wolves cannot kill on the following night.', 'villager'),
+	  ('Cursed', 'A villager who turns into a werewolf instead of dying when the werewolves attack.', 'villager'),
+	  ('Serial Killer', 'Plays alone: kills one player every night, apart from the werewolves, and wins as the last one standing.', 'serial_killer'),
+	  ('Piper', 'Plays alone: charms two players each night and wins once every living player is charmed.', 'piper'),
+	  ('White Werewolf', 'Hunts with the pack, but every second night may kill a fellow werewolf; wins only as the sole survivor.', 'werewolf'),
+	  ('Fox', 'Each night, sniffs a player and their two neighbors to learn whether a werewolf is among them; loses the power after a miss.', 'villager'),
+	  ('Elder', 'Survives the first werewolf attack. If the village lynches the Elder, every villager loses their powers.', 'villager'),
+	  ('Scapegoat', 'Dies in place of anyone when the day vote ties, then chooses who may vote the next day.', 'villager'),
+	  ('Thief', 'Before the first night, may swap their card for one of two spare cards; must take a werewolf if both are werewolves.', 'villager'),
+	  ('Wild Child', 'On the first night, picks a role model; if the role model dies, the Wild Child becomes a werewolf.', 'villager'),
+	  ('Village Idiot', 'If the village votes them out, their role is revealed and they survive, but they cannot vote for the rest of the game.', 'villager')
 	`
 	_, err := db.Exec(schema)
 	if err != nil {
@@ -357,11 +601,29 @@ func initDB(db *sqlx.DB, logfn func(string, ...any)) error {
 		return err
 	}
 
+	// the Drunk's hidden role, swapped into role_id when they sober up
+	if err := addColumnIfNotExists(db, "game_player", "drunk_role_id", "INTEGER REFERENCES role(rowid)"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
 	if err := addColumnIfNotExists(db, "game", "ai_enabled", "INTEGER NOT NULL DEFAULT 1"); err != nil {
 		logfn("initDB migration error: %v", err)
 		return err
 	}
 
+	// the night the pack cannot hunt after killing the Diseased (0 = none)
+	if err := addColumnIfNotExists(db, "game", "wolves_skip_round", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// set once the village lynches its Elder: villager powers stop working
+	if err := addColumnIfNotExists(db, "game", "powers_disabled", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
 	if err := addColumnIfNotExists(db, "game_action", "description_key", "TEXT NOT NULL DEFAULT ''"); err != nil {
 		logfn("initDB migration error: %v", err)
 		return err
@@ -376,6 +638,136 @@ func initDB(db *sqlx.DB, logfn func(string, ...any)) error {
 		return err
 	}
 
+	// JSON ability spec of roles created in the lobby; empty for the built-in roles
+	if err := addColumnIfNotExists(db, "role", "ability", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	if err := addColumnIfNotExists(db, "role", "pack", "TEXT NOT NULL DEFAULT 'base'"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+	if err := assignRolePacks(db); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// seconds a night may last before it resolves on its own; 0 = no timer
+	if err := addColumnIfNotExists(db, "game", "night_timer", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// day votes go through nominations and seconds instead of a free vote
+	if err := addColumnIfNotExists(db, "game", "nominations", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// the day vote's front-runner is put on trial instead of being eliminated outright
+	if err := addColumnIfNotExists(db, "game", "trials", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// day votes stay hidden (resolved visibility) until the day is over
+	if err := addColumnIfNotExists(db, "game", "secret_votes", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// a tied day vote goes to a runoff between the tied players
+	if err := addColumnIfNotExists(db, "game", "runoffs", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// the game opens with a Mayor election whose winner breaks day-vote ties
+	if err := addColumnIfNotExists(db, "game", "mayor_election", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// lynched and shot players may leave last words before the night
+	if err := addColumnIfNotExists(db, "game", "last_words", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// how much of a dead player's role is revealed while the game runs: full, team or none
+	if err := addColumnIfNotExists(db, "game", "role_reveal", "TEXT NOT NULL DEFAULT 'full'"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// day length in seconds; 0 means the day has no timer
+	if err := addColumnIfNotExists(db, "game", "day_timer", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// the player running the lobby; 0 (or a player who left) means the first to join
+	if err := addColumnIfNotExists(db, "game", "host_player_id", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// observers and dead players see every role
+	if err := addColumnIfNotExists(db, "game", "observers_see_all", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// the host has paused the running game
+	if err := addColumnIfNotExists(db, "game", "paused", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// the host narrates the game instead of playing
+	if err := addColumnIfNotExists(db, "game", "narrator_mode", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// chat messages pass the chat filter; the host muted the player's chat
+	if err := addColumnIfNotExists(db, "game", "chat_filter", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+	if err := addColumnIfNotExists(db, "game_player", "muted", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// players may whisper once a day
+	if err := addColumnIfNotExists(db, "game", "whispers", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	// dead players may still talk in the day chat
+	if err := addColumnIfNotExists(db, "game", "graveyard_talk", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	if err := migrate(db, migrationFS, logfn); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	if err := hashPlaintextSecretCodes(db); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
+	if err := hashSessionTokens(db); err != nil {
+		logfn("initDB migration error: %v", err)
+		return err
+	}
+
 	logfn("Database initialized successfully")
 	return nil
 }
@@ -438,7 +830,7 @@ func getOrCreateGameByName(db *sqlx.DB, name string) (*Game, error) {
 	db.Exec("INSERT OR IGNORE INTO game (name, status, round) VALUES (?, 'lobby', 0)", name)
 
 	var game Game
-	err := db.Get(&game, "SELECT rowid as id, name, status, round, ai_enabled, winner FROM game WHERE name = ?", name)
+	err := db.Get(&game, "SELECT rowid as id, name, status, round, ai_enabled, winner, paused FROM game WHERE name = ?", name)
 
 	return &game, err
 }
@@ -449,6 +841,7 @@ type PlayerGame struct {
 	Round       int    `db:"round"`
 	Winner      string `db:"winner"`
 	PlayerTeam  string `db:"player_team"`
+	PlayerRole  string `db:"player_role"`
 	PlayerAlive bool   `db:"player_alive"`
 	Won         bool
 }
@@ -457,18 +850,18 @@ func getPlayerGames(db *sqlx.DB, playerID int64) ([]PlayerGame, error) {
 	var games []PlayerGame
 	err := db.Select(&games, `
 		SELECT g.name as name, g.status as status, g.round as round,
-			IFNULL(pr.team, '') as player_team, gp.is_alive as player_alive,
+			IFNULL(pr.team, '') as player_team, IFNULL(pr.name, '') as player_role, gp.is_alive as player_alive,
 			IFNULL(g.winner, '') as winner
 		FROM game_player gp
 		JOIN game g ON gp.game_id = g.rowid
 		LEFT JOIN role pr ON gp.role_id = pr.rowid
-		WHERE gp.player_id = ?
+		WHERE gp.player_id = ? AND g.name != ''
 		ORDER BY g.rowid DESC`, playerID)
 	if err != nil {
 		return nil, err
 	}
 	for i := range games {
-		games[i].Won = playerWon(games[i].Winner, games[i].PlayerTeam, games[i].PlayerAlive)
+		games[i].Won = playerWon(games[i].Winner, winTeam(games[i].PlayerRole, games[i].PlayerTeam), games[i].PlayerAlive)
 	}
 	return games, err
 }
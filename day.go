@@ -1,9 +1,14 @@
 package main
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
 	"strconv"
+
+	"github.com/jmoiron/sqlx"
+
+	"werewolf/engine"
 )
 
 type DayData struct {
@@ -12,7 +17,7 @@ type DayData struct {
 	NightNumber          int
 	HasHistory           bool
 	NightVictims         []Player
-	PassVoters           []string
+	PassVoters           []VoterChip
 	CurrentVotePlayer    *Player
 	IsAlive              bool
 	HunterRevengeNeeded  bool
@@ -23,11 +28,34 @@ type DayData struct {
 	HunterTargets        []Player // alive targets for the Hunter; visibility pre-applied
 	AllActed             bool
 	HasVoted             bool
+	SecretVotes          bool   // only the player's own vote is shown until the day is over
+	Runoff               bool   // today's vote tied and the village votes again on the tied players
+	IsMayor              bool   // this player's day vote counts twice
+	ElectedMayor         string // the living elected Mayor, whose vote breaks ties; "" when there is none
+	TimeLeft             string // day countdown ("m:ss"); "" when the game has no day timer
+	NarratorMode         bool   // the narrator closes the vote, so there is no End Vote button
+	DayChat              []ChatMessage
+	CanDayChat           bool // the player may write in the day chat
+	IsSilenced           bool // silenced by the Spellcaster last night, barred by the Scapegoat or a revealed Village Idiot; cannot vote today
+	IsBarred             bool // left out by yesterday's Scapegoat
+	IsIdiot              bool // a revealed Village Idiot, who has lost their vote for good
+	ToughGuyWounded      bool // this Tough Guy was attacked last night and dies as the day ends
+	SilencedPlayers      []Player
 	Lang                 string
+	VoteUpdate           bool // the vote section is sent on its own, as an out-of-band swap (sendDayVoteSection)
 
 	NightVictimCards  []PlayerCardData
 	HunterTargetCards []PlayerCardData
 	VoteTargetCards   []PlayerCardData
+
+	PriestDayData
+	ScapegoatDayData
+	NominationDayData
+	TrialDayData
+	LastWordsDayData
+	WhisperDayData
+	LoversChatData
+	GraveyardData
 }
 
 // applyHeartbreaks recurses so chained heartbreaks resolve (multiple Cupids can link
@@ -53,8 +81,8 @@ func (h *Hub) applyHeartbreaks(game *Game, phase string, killedIDs []int64) []in
 				continue
 			}
 			// actor = the player whose death triggered this, target = the heartbreak victim
-			killedName := getPlayerName(h.db, killed)
-			partnerName := getPlayerName(h.db, partnerID)
+			killedName := h.playerName(killed)
+			partnerName := h.playerName(partnerID)
 			heartbreakKey := "hist_heartbreak_night"
 			phaseLabel := "Night"
 			if phase == "day" {
@@ -101,6 +129,16 @@ func handleWSDayVote(client *Client, msg WSMessage) {
 		return
 	}
 
+	if revealedIdiots(h.db, game.ID)[client.playerID] {
+		h.sendErrorToast(client.playerID, T(lang, "err_idiot_cannot_vote"))
+		return
+	}
+
+	if silencedPlayers(h.db, game.ID, game.Round)[client.playerID] {
+		h.sendErrorToast(client.playerID, T(lang, "err_silenced_cannot_vote"))
+		return
+	}
+
 	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
 	if err != nil {
 		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
@@ -118,31 +156,63 @@ func handleWSDayVote(client *Client, msg WSMessage) {
 		return
 	}
 
-	var existingTarget sql.NullInt64
-	h.db.Get(&existingTarget, `SELECT target_player_id FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND actor_player_id = ? AND action_type = ?`,
-		game.ID, game.Round, client.playerID, ActionDaySelectKill)
+	switch dayStage(h.db, game) {
+	case DayStageLastWords:
+		h.sendErrorToast(client.playerID, T(lang, "err_vote_closed_last_words"))
+		return
+	case DayStageDefense, DayStageVerdict:
+		h.sendErrorToast(client.playerID, T(lang, "err_vote_closed_trial"))
+		return
+	case DayStageNominate:
+		h.sendErrorToast(client.playerID, T(lang, "err_vote_not_open"))
+		return
+	case DayStageVote:
+		if !ballot(h.db, game)[targetID] {
+			h.sendErrorToast(client.playerID, T(lang, "err_not_on_ballot"))
+			return
+		}
+	}
+
+	voteType := dayVoteAction(h.db, game.ID, game.Round)
+	if voteType == ActionDayRunoffVote && !runoffCandidates(h.db, game.ID, game.Round)[targetID] {
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_runoff"))
+		return
+	}
+
+	var existing struct {
+		ID     int64         `db:"id"`
+		Target sql.NullInt64 `db:"target_player_id"`
+	}
+	h.db.Get(&existing, `SELECT rowid AS id, target_player_id FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, voteType)
 	// voting the same target again retracts the vote
-	if existingTarget.Valid && existingTarget.Int64 == targetID {
+	if existing.Target.Valid && existing.Target.Int64 == targetID {
 		_, err = h.db.Exec(`DELETE FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND actor_player_id = ? AND action_type = ?`,
-			game.ID, game.Round, client.playerID, ActionDaySelectKill)
+			game.ID, game.Round, client.playerID, voteType)
 		if err != nil {
 			h.logError("handleWSDayVote: db.Exec delete vote", err)
 			h.sendErrorToast(client.playerID, T(lang, "err_failed_clear_vote"))
 			return
 		}
 		h.logf("Player %d (%s) unselected day vote for player %d (%s)", client.playerID, voter.Name, targetID, target.Name)
-		h.triggerBroadcast()
+		h.noteVoteEntry(existing.ID, voteEntryRemoved)
+		h.sendDayVoteSection(game, client.playerID)
+		h.triggerVoteUpdate()
 		return
 	}
 
 	dayVoteDesc := fmt.Sprintf("Day %d: %s voted to eliminate %s", game.Round, voter.Name, target.Name)
 	dvKey, dvArgs := "hist_day_vote", histArgs(game.Round, voter.Name, target.Name)
-	_, err = h.db.Exec(`
+	if voteType == ActionDayRunoffVote {
+		dayVoteDesc = fmt.Sprintf("Day %d: %s voted to eliminate %s in the runoff", game.Round, voter.Name, target.Name)
+		dvKey = "hist_day_runoff_vote"
+	}
+	res, err := h.db.Exec(`
 		INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
 		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(game_id, round, phase, actor_player_id, action_type)
 		DO UPDATE SET target_player_id = ?, description = ?, description_key = ?, description_args = ?`,
-		game.ID, game.Round, client.playerID, ActionDaySelectKill, targetID, VisibilityPublic, dayVoteDesc, dvKey, dvArgs, targetID, dayVoteDesc, dvKey, dvArgs)
+		game.ID, game.Round, client.playerID, voteType, targetID, dayVoteVisibility(h.db, game.ID), dayVoteDesc, dvKey, dvArgs, targetID, dayVoteDesc, dvKey, dvArgs)
 	if err != nil {
 		h.logError("handleWSDayVote: db.Exec insert vote", err)
 		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_vote"))
@@ -153,7 +223,9 @@ func handleWSDayVote(client *Client, msg WSMessage) {
 	DebugLog("handleWSDayVote", "Player '%s' voted to eliminate '%s'", voter.Name, target.Name)
 	LogDBState(h.db, "after day vote")
 
-	h.triggerBroadcast()
+	h.noteVoteEntry(upsertedEntry(res, existing.ID))
+	h.sendDayVoteSection(game, client.playerID)
+	h.triggerVoteUpdate()
 }
 
 func handleWSDayPass(client *Client, msg WSMessage) {
@@ -183,15 +255,33 @@ func handleWSDayPass(client *Client, msg WSMessage) {
 		return
 	}
 
+	if silencedPlayers(h.db, game.ID, game.Round)[client.playerID] {
+		h.sendErrorToast(client.playerID, T(lang, "err_silenced_cannot_vote"))
+		return
+	}
+
+	if lastWordsHeld(h.db, game) {
+		h.sendErrorToast(client.playerID, T(lang, "err_vote_closed_last_words"))
+		return
+	}
+	if trialStage(h.db, game) != "" {
+		h.sendErrorToast(client.playerID, T(lang, "err_vote_closed_trial"))
+		return
+	}
+
 	// Record pass as a day_vote with NULL target
+	voteType := dayVoteAction(h.db, game.ID, game.Round)
+	var existingID int64
+	h.db.Get(&existingID, `SELECT rowid FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, voteType)
 	passDesc := fmt.Sprintf("Day %d: %s passed", game.Round, voter.Name)
 	dpKey, dpArgs := "hist_day_pass", histArgs(game.Round, voter.Name)
-	_, err = h.db.Exec(`
+	res, err := h.db.Exec(`
 		INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
 		VALUES (?, ?, 'day', ?, ?, NULL, ?, ?, ?, ?)
 		ON CONFLICT(game_id, round, phase, actor_player_id, action_type)
 		DO UPDATE SET target_player_id = NULL, description = ?, description_key = ?, description_args = ?`,
-		game.ID, game.Round, client.playerID, ActionDaySelectKill, VisibilityPublic, passDesc, dpKey, dpArgs, passDesc, dpKey, dpArgs)
+		game.ID, game.Round, client.playerID, voteType, dayVoteVisibility(h.db, game.ID), passDesc, dpKey, dpArgs, passDesc, dpKey, dpArgs)
 	if err != nil {
 		h.logError("handleWSDayPass: db.Exec", err)
 		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_pass"))
@@ -199,7 +289,9 @@ func handleWSDayPass(client *Client, msg WSMessage) {
 	}
 
 	h.logf("Player %d (%s) passed the day vote", client.playerID, voter.Name)
-	h.triggerBroadcast()
+	h.noteVoteEntry(upsertedEntry(res, existingID))
+	h.sendDayVoteSection(game, client.playerID)
+	h.triggerVoteUpdate()
 }
 
 func handleWSDayEndVote(client *Client, msg WSMessage) {
@@ -216,6 +308,10 @@ func handleWSDayEndVote(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_day_vote_only"))
 		return
 	}
+	if narratorModeEnabled(h.db, game.ID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_narrator_closes_vote"))
+		return
+	}
 
 	voter, err := getPlayerInGame(h.db, game.ID, client.playerID)
 	if err != nil {
@@ -229,6 +325,21 @@ func handleWSDayEndVote(client *Client, msg WSMessage) {
 		return
 	}
 
+	silenced := silencedPlayers(h.db, game.ID, game.Round)
+	if silenced[client.playerID] {
+		h.sendErrorToast(client.playerID, T(lang, "err_silenced_cannot_vote"))
+		return
+	}
+
+	if lastWordsHeld(h.db, game) {
+		h.sendErrorToast(client.playerID, T(lang, "err_vote_closed_last_words"))
+		return
+	}
+	if trialStage(h.db, game) != "" {
+		h.sendErrorToast(client.playerID, T(lang, "err_vote_closed_trial"))
+		return
+	}
+
 	var alivePlayers []Player
 	h.db.Select(&alivePlayers, `
 		SELECT g.rowid as id, g.player_id as player_id, p.name as name
@@ -238,10 +349,12 @@ func handleWSDayEndVote(client *Client, msg WSMessage) {
 
 	var totalActed int
 	h.db.Get(&totalActed, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
-		game.ID, game.Round, ActionDaySelectKill)
+		game.ID, game.Round, dayVoteAction(h.db, game.ID, game.Round))
 
-	if totalActed < len(alivePlayers) {
-		h.sendErrorToast(client.playerID, T(lang, "err_players_not_done", totalActed, len(alivePlayers)))
+	// silenced players cannot vote, so the day does not wait for them
+	expected := len(alivePlayers) - len(silenced)
+	if totalActed < expected {
+		h.sendErrorToast(client.playerID, T(lang, "err_players_not_done", totalActed, expected))
 		return
 	}
 
@@ -249,83 +362,189 @@ func handleWSDayEndVote(client *Client, msg WSMessage) {
 	h.resolveDayVotes(game)
 }
 
+// voteWeight is how many votes a player's day vote counts for.
+func voteWeight(db *sqlx.DB, gameID int64, roleName string) int {
+	if roleName == "Mayor" && !powerDisabled(db, gameID, roleName) {
+		return 2
+	}
+	return 1
+}
+
 func (h *Hub) resolveDayVotes(game *Game) {
+	if !h.phaseUnchanged(game) {
+		return
+	}
+	h.saveCheckpoint(game.ID, "vote")
 	var alivePlayers []Player
 	err := h.db.Select(&alivePlayers, `
-		SELECT g.rowid as id, g.player_id as player_id, p.name as name
+		SELECT g.rowid as id, g.player_id as player_id, p.name as name, IFNULL(r.name, '') as role_name
 		FROM game_player g
 		JOIN player p ON g.player_id = p.rowid
+		LEFT JOIN role r ON g.role_id = r.rowid
 		WHERE g.game_id = ? AND g.is_alive = 1`, game.ID)
 	if err != nil {
 		h.logError("resolveDayVotes: get alive players", err)
 		return
 	}
+	// majorities are measured in vote weight, not heads, so the Mayor's extra vote counts;
+	// silenced players hold no vote today
+	silenced := silencedPlayers(h.db, game.ID, game.Round)
+	aliveWeight := 0
+	for _, p := range alivePlayers {
+		if !silenced[p.PlayerID] {
+			aliveWeight += voteWeight(h.db, game.ID, p.RoleName)
+		}
+	}
 
-	voteCounts, totalVotes, err := getVoteCounts(h.db, game.ID, game.Round, "day", ActionDaySelectKill)
+	voteType := dayVoteAction(h.db, game.ID, game.Round)
+	voteCounts, totalVotes, err := getVoteCounts(h.db, game.ID, game.Round, "day", voteType)
 	if err != nil {
 		h.logError("resolveDayVotes: getVoteCounts", err)
 		return
 	}
 
-	h.logf("Day vote check: %d alive players, %d votes", len(alivePlayers), totalVotes)
+	h.logf("Day vote check: %d alive players (vote weight %d), %d votes", len(alivePlayers), aliveWeight, totalVotes)
 
 	realVoteCount := 0
 	for _, c := range voteCounts {
 		realVoteCount += c
 	}
-	passCount := totalVotes - realVoteCount
-	if passCount > len(alivePlayers)/2 {
-		h.logf("Majority passed (%d/%d) — no elimination this day", passCount, len(alivePlayers))
-		h.transitionToNight(game)
-		return
+	vote := engine.DayVote{
+		AliveWeight: aliveWeight,
+		Passes:      totalVotes - realVoteCount,
+		Counts:      voteCounts,
+		MayorVote:   mayorVote(h.db, game, voteType),
+		Scapegoat:   blamedScapegoat(h.db, game.ID) != 0,
+		Runoff:      voteType == ActionDaySelectKill && runoffsEnabled(h.db, game.ID),
+		Trials:      trialsEnabled(h.db, game.ID),
 	}
+	outcome := engine.ResolveDayVote(vote)
 
-	var maxVotes int
-	var eliminatedID int64
-	var isTie bool
-	for targetID, count := range voteCounts {
-		if count > maxVotes {
-			maxVotes = count
-			eliminatedID = targetID
-			isTie = false
-		} else if count == maxVotes {
-			isTie = true
-		}
+	if outcome.Tied != nil && outcome.Target != 0 {
+		h.logf("Mayor broke the tie in favour of player %d", outcome.Target)
 	}
 
-	majority := len(alivePlayers)/2 + 1
-	if maxVotes < majority || isTie {
-		h.logf("No majority reached (need %d, max is %d, tie: %v) - no elimination", majority, maxVotes, isTie)
-		h.transitionToNight(game)
+	// the vote's outcome is written in one transaction, and only while the day is still on
+	tx, ok := h.beginResolution(game)
+	if !ok {
 		return
 	}
-
-	_, err = h.db.Exec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, eliminatedID)
+	defer tx.Rollback()
+	var scapegoatID int64
+	if outcome.Result == engine.DayScapegoat {
+		if scapegoatID, err = h.blameScapegoat(tx, game); err != nil {
+			h.logError("resolveDayVotes: blame scapegoat", err)
+			return
+		}
+		// nobody could take the blame after all: the tie is settled as if there were no Scapegoat
+		if scapegoatID == 0 {
+			vote.Scapegoat = false
+			outcome = engine.ResolveDayVote(vote)
+		}
+	}
+	var died bool
+	switch outcome.Result {
+	case engine.DayRunoff:
+		err = h.openRunoff(tx, game, outcome.Tied)
+	case engine.DayTrial:
+		err = h.openTrial(tx, game, outcome.Target)
+	case engine.DayEliminate:
+		died, err = h.lynch(tx, game, outcome.Target)
+	}
 	if err != nil {
-		h.logError("resolveDayVotes: eliminate player", err)
+		h.logError("resolveDayVotes: record outcome", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		h.logError("resolveDayVotes: commit", err)
 		return
 	}
 
-	eliminatedName := getPlayerName(h.db, eliminatedID)
-	eliminatedRole := getRoleName(h.db, game.ID, eliminatedID)
+	switch outcome.Result {
+	case engine.DayPassed:
+		h.logf("Majority passed (%d/%d) — no elimination this day", totalVotes-realVoteCount, aliveWeight)
+		h.transitionToNight(game)
+	case engine.DayNoMajority:
+		h.logf("No majority reached (max is %d, tied: %v) - no elimination", outcome.MaxVotes, outcome.Tied)
+		h.transitionToNight(game)
+	case engine.DayScapegoat:
+		h.afterScapegoat(game, scapegoatID)
+	case engine.DayRunoff:
+		h.logf("Day %d vote tied between %d players - runoff opened", game.Round, len(outcome.Tied))
+		h.triggerBroadcast()
+	case engine.DayTrial:
+		h.logf("'%s' was put on trial", h.playerName(outcome.Target))
+		h.triggerBroadcast()
+	case engine.DayEliminate:
+		if !died {
+			h.transitionToNight(game)
+			return
+		}
+		h.afterLynch(game, outcome.Target)
+	}
+}
+
+// lynch writes the village's elimination of the player it voted out, in the resolution's
+// transaction, unless a Prince or Village Idiot reveal cancels it; it reports whether the
+// player died. afterLynch moves the game on once the transaction has committed.
+func (h *Hub) lynch(tx *sqlx.Tx, game *Game, eliminatedID int64) (bool, error) {
+	if spared, err := h.princeSurvivesLynch(tx, game, eliminatedID); err != nil || spared {
+		return false, err
+	}
+	if spared, err := h.idiotSurvivesLynch(tx, game, eliminatedID); err != nil || spared {
+		return false, err
+	}
 
+	eliminatedName := h.playerName(eliminatedID)
+	eliminatedRole := getRoleName(h.db, game.ID, eliminatedID)
+	res, err := tx.Exec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ? AND is_alive = 1", game.ID, eliminatedID)
+	if err != nil {
+		return false, fmt.Errorf("eliminate player: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, fmt.Errorf("%s (player ID %d) is already dead", eliminatedName, eliminatedID)
+	}
 	eliminationDesc := fmt.Sprintf("Day %d: %s (%s) was eliminated by the village", game.Round, eliminatedName, eliminatedRole)
-	_, err = h.db.Exec(`
+	_, err = tx.Exec(`
 		INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
 		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
 		game.ID, game.Round, eliminatedID, ActionDayApplyKill, eliminatedID, VisibilityPublic, eliminationDesc, "hist_eliminated", histArgs(game.Round, eliminatedName, eliminatedRole))
 	if err != nil {
-		h.logError("resolveDayVotes: record elimination", err)
+		return false, fmt.Errorf("record elimination: %w", err)
+	}
+	if eliminatedRole == "Elder" {
+		if err := h.disableVillagePowers(tx, game, eliminatedID); err != nil {
+			return false, err
+		}
 	}
+	return true, nil
+}
+
+// afterLynch moves on from a committed elimination: to the Hunter's shot, the end of the game
+// or the night.
+func (h *Hub) afterLynch(game *Game, eliminatedID int64) {
+	eliminatedName := h.playerName(eliminatedID)
+	eliminatedRole := getRoleName(h.db, game.ID, eliminatedID)
 	h.logf("Village eliminated %s (player ID %d)", eliminatedName, eliminatedID)
-	DebugLog("resolveDayVotes", "Village eliminated '%s'", eliminatedName)
+	h.openLastWords(game, eliminatedID)
+	DebugLog("lynch", "Village eliminated '%s'", eliminatedName)
 	h.maybeGenerateStory(game.ID, game.Round, "day", eliminatedID)
 
+	// the Tanner wanted this: being lynched ends the game at once, before heartbreaks or a Hunter shot
+	if eliminatedRole == "Tanner" {
+		h.logf("TANNER WINS - '%s' was lynched by the village", eliminatedName)
+		h.endGame(game, string(engine.TannerWins))
+		return
+	}
+
 	heartbroken := h.applyHeartbreaks(game, "day", []int64{eliminatedID})
+	h.inheritDoppelgangerRoles(game, "day")
+	h.promoteApprenticeSeer(game, "day")
+	h.awakenWildChildren(game, "day")
 
 	for _, deadID := range append([]int64{eliminatedID}, heartbroken...) {
-		if getRoleName(h.db, game.ID, deadID) == "Hunter" {
-			deadName := getPlayerName(h.db, deadID)
+		if getRoleName(h.db, game.ID, deadID) == "Hunter" && !powerDisabled(h.db, game.ID, "Hunter") {
+			deadName := h.playerName(deadID)
 			h.logf("Hunter '%s' was eliminated — waiting for revenge shot before transitioning", deadName)
 			LogDBState(h.db, "after hunter elimination - waiting for revenge")
 			h.triggerBroadcast()
@@ -340,6 +559,33 @@ func (h *Hub) resolveDayVotes(game *Game) {
 	h.transitionToNight(game)
 }
 
+// princeSurvivesLynch cancels the village's elimination of a Prince the first time it happens:
+// the role is revealed to everyone in a public history entry and the Prince stays alive.
+func (h *Hub) princeSurvivesLynch(tx *sqlx.Tx, game *Game, playerID int64) (bool, error) {
+	if getRoleName(h.db, game.ID, playerID) != "Prince" || powerDisabled(h.db, game.ID, "Prince") {
+		return false, nil
+	}
+	var revealed int
+	h.db.Get(&revealed, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND actor_player_id = ? AND action_type = ?`,
+		game.ID, playerID, ActionPrinceRevealed)
+	if revealed > 0 {
+		return false, nil
+	}
+
+	name := h.playerName(playerID)
+	desc := fmt.Sprintf("Day %d: %s was voted out but revealed themselves as the Prince and was spared", game.Round, name)
+	_, err := tx.Exec(`
+		INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, playerID, ActionPrinceRevealed, playerID, VisibilityPublic, desc, "hist_prince_revealed", histArgs(game.Round, name))
+	if err != nil {
+		return false, fmt.Errorf("record prince reveal: %w", err)
+	}
+	h.logf("Prince '%s' was voted out and revealed — elimination cancelled", name)
+	h.maybeSpeakStory(game.ID, T(h.storytellerLang, "tts_prince_revealed", name))
+	return true, nil
+}
+
 func handleWSHunterSelect(client *Client, msg WSMessage) {
 	h := client.hub
 	lang := h.getPlayerLang(client.playerID)
@@ -367,6 +613,10 @@ func handleWSHunterSelect(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_hunter_revenge_only_dead"))
 		return
 	}
+	if powerDisabled(h.db, game.ID, hunter.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
 	var revengeCount int
 	h.db.Get(&revengeCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND actor_player_id=? AND action_type=?`,
 		game.ID, game.Round, client.playerID, ActionHunterApplyKill)
@@ -438,6 +688,11 @@ func handleWSHunterRevenge(client *Client, msg WSMessage) {
 		return
 	}
 
+	if powerDisabled(h.db, game.ID, hunter.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+
 	var revengeCount int
 	h.db.Get(&revengeCount, `
 		SELECT COUNT(*) FROM game_action
@@ -470,6 +725,8 @@ func handleWSHunterRevenge(client *Client, msg WSMessage) {
 		return
 	}
 
+	h.saveCheckpoint(game.ID, "hunter")
+
 	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND actor_player_id=? AND action_type=?`,
 		game.ID, game.Round, client.playerID, ActionHunterSelectKill)
 
@@ -490,15 +747,19 @@ func handleWSHunterRevenge(client *Client, msg WSMessage) {
 	}
 
 	h.logf("Hunter '%s' took revenge on '%s'", hunter.Name, target.Name)
+	h.openLastWords(game, targetID)
 	DebugLog("handleWSHunterRevenge", "Hunter '%s' shot '%s'", hunter.Name, target.Name)
 	LogDBState(h.db, "after hunter revenge")
 	h.maybeGenerateStory(game.ID, game.Round, "day", targetID)
 
 	heartbroken := h.applyHeartbreaks(game, "day", []int64{targetID})
+	h.inheritDoppelgangerRoles(game, "day")
+	h.promoteApprenticeSeer(game, "day")
+	h.awakenWildChildren(game, "day")
 
 	for _, deadID := range append([]int64{targetID}, heartbroken...) {
-		if getRoleName(h.db, game.ID, deadID) == "Hunter" {
-			deadName := getPlayerName(h.db, deadID)
+		if getRoleName(h.db, game.ID, deadID) == "Hunter" && !powerDisabled(h.db, game.ID, "Hunter") {
+			deadName := h.playerName(deadID)
 			h.logf("Hunter '%s' was killed — entering chained revenge", deadName)
 			h.triggerBroadcast()
 			return
@@ -523,3 +784,404 @@ func handleWSHunterRevenge(client *Client, msg WSMessage) {
 		h.triggerBroadcast()
 	}
 }
+
+// votingOpen reports whether the day page shows the vote section (day-vote-section).
+func (d DayData) votingOpen() bool {
+	return !d.ScapegoatPending && !d.Dusk && d.Accused == nil && (!d.HunterRevengeNeeded || d.HunterRevengeDone)
+}
+
+// What a day vote did to its history entry, kept for broadcastDayVotes (noteVoteEntry).
+const (
+	voteEntryAdded   = "added"
+	voteEntryChanged = "changed"
+	voteEntryRemoved = "removed"
+)
+
+// upsertedEntry tells what a vote's insert-or-update did: existingID is the vote's row from
+// before, 0 when there was none.
+func upsertedEntry(res sql.Result, existingID int64) (int64, string) {
+	if existingID != 0 {
+		return existingID, voteEntryChanged
+	}
+	id, _ := res.LastInsertId()
+	return id, voteEntryAdded
+}
+
+// noteVoteEntry remembers what a vote did to history row id until the next broadcast sends
+// it. Several votes of one burst on the same row add up to what the pages have to do.
+func (h *Hub) noteVoteEntry(id int64, change string) {
+	h.voteMu.Lock()
+	defer h.voteMu.Unlock()
+	switch prev := h.voteEntries[id]; {
+	case prev == voteEntryAdded && change == voteEntryChanged:
+		return // still new to the pages
+	case prev == voteEntryAdded && change == voteEntryRemoved:
+		delete(h.voteEntries, id) // the pages never had it
+		return
+	case prev == voteEntryRemoved && change == voteEntryAdded:
+		// SQLite handed the removed row's rowid to the new one, and the pages still show it
+		change = voteEntryChanged
+	}
+	h.voteEntries[id] = change
+}
+
+// takeVoteEntries returns the noted history changes and starts over.
+func (h *Hub) takeVoteEntries() map[int64]string {
+	h.voteMu.Lock()
+	defer h.voteMu.Unlock()
+	taken := h.voteEntries
+	h.voteEntries = make(map[int64]string)
+	return taken
+}
+
+// broadcastDayVotes sends each connected player only what a day vote or pass changes on
+// their page: the tally (day-vote-tally) and the history entries of the votes since the last
+// update (history-update), a few hundred bytes instead of the whole page. The narrator also
+// gets their panel, which lists everyone's vote. The voter's own selection comes with
+// sendDayVoteSection. When the vote is no longer open for someone the page changed more
+// than that, and everybody gets the full broadcast instead.
+func (h *Hub) broadcastDayVotes() {
+	changes := h.takeVoteEntries()
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("broadcastDayVotes: getGame", err)
+		return
+	}
+	if game.Status != "day" {
+		h.broadcastGameUpdate()
+		return
+	}
+	rs, err := loadRenderState(h, game)
+	if err != nil {
+		h.logError("broadcastDayVotes: loadRenderState", err)
+		return
+	}
+	// secret votes are in nobody's history yet, so a retracted one has nothing to remove
+	publicVotes := dayVoteVisibility(h.db, game.ID) == VisibilityPublic
+	connected := map[int64]bool{}
+	for _, id := range h.connectedPlayerIDs() {
+		connected[id] = true
+	}
+	messages := map[int64][]byte{}
+	for _, p := range append(append([]Player{}, rs.Players...), rs.Observers...) {
+		if !connected[p.PlayerID] {
+			continue
+		}
+		lang := h.getPlayerLang(p.PlayerID)
+		data, err := buildDayData(h, rs, p.PlayerID, lang)
+		if err != nil {
+			continue
+		}
+		if !data.votingOpen() {
+			h.broadcastGameUpdate()
+			return
+		}
+		var buf bytes.Buffer
+		if err := h.templates.ExecuteTemplate(&buf, "day-vote-tally", data); err != nil {
+			h.logError("broadcastDayVotes: ExecuteTemplate day-vote-tally", err)
+			continue
+		}
+		h.writeVoteEntries(&buf, changes, publicVotes, p.PlayerID, game, lang)
+		if panel := buildNarratorPanel(h.db, game, p.PlayerID, lang); panel.Show {
+			h.templates.ExecuteTemplate(&buf, "narrator_panel.html", panel)
+		}
+		messages[p.PlayerID] = buf.Bytes()
+	}
+	for id, msg := range messages {
+		h.sendToPlayer(id, msg)
+	}
+}
+
+// writeVoteEntries writes the history entries of changes that playerID can see. A history
+// that held none of their entries before is sent whole, as the empty history bar is hidden.
+func (h *Hub) writeVoteEntries(buf *bytes.Buffer, changes map[int64]string, publicVotes bool, playerID int64, game *Game, lang string) {
+	if len(changes) == 0 {
+		return
+	}
+	entries := buildHistoryEntries(h.db, playerID, game, lang)
+	var update HistoryUpdate
+	for _, e := range entries {
+		switch changes[e.ID] {
+		case voteEntryAdded:
+			update.Added = append(update.Added, e)
+		case voteEntryChanged:
+			update.Changed = append(update.Changed, e)
+		}
+	}
+	if len(update.Added) > 0 && len(update.Added) == len(entries) {
+		h.templates.ExecuteTemplate(buf, "history.html", HistoryData{Lang: lang, Entries: entries})
+		return
+	}
+	if publicVotes {
+		for id, change := range changes {
+			if change == voteEntryRemoved {
+				update.Removed = append(update.Removed, id)
+			}
+		}
+	}
+	if err := h.templates.ExecuteTemplate(buf, "history-update", update); err != nil {
+		h.logError("writeVoteEntries: ExecuteTemplate history-update", err)
+	}
+}
+
+// sendDayVoteSection sends the voter their vote section again, with their new choice
+// selected; the rest of the page stays as it is.
+func (h *Hub) sendDayVoteSection(game *Game, playerID int64) {
+	rs, err := loadRenderState(h, game)
+	if err != nil {
+		h.logError("sendDayVoteSection: loadRenderState", err)
+		return
+	}
+	data, err := buildDayData(h, rs, playerID, h.getPlayerLang(playerID))
+	if err != nil || !data.votingOpen() {
+		return
+	}
+	data.VoteUpdate = true
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, "day-vote-section", data); err != nil {
+		h.logError("sendDayVoteSection: ExecuteTemplate day-vote-section", err)
+		return
+	}
+	h.sendToPlayer(playerID, buf.Bytes())
+}
+
+// buildDayData gathers what playerID's day page shows; the full page (renderGameComponent)
+// and the vote update (broadcastDayVotes) both render from it.
+func buildDayData(h *Hub, rs *renderState, playerID int64, lang string) (DayData, error) {
+	db := h.db
+	game := rs.Game
+	players := rs.Players
+	player, err := getPlayerInGame(db, game.ID, playerID)
+	if err != nil {
+		h.logError("buildDayData: getPlayerInGame", err)
+		return DayData{}, err
+	}
+	player = drunkView(game, player)
+	players = maskDrunkSelf(game, players, playerID)
+
+	// is_alive=0 excludes players who were targeted but survived a protection.
+	var nightVictims []Player
+	db.Select(&nightVictims, `
+		SELECT DISTINCT gp.rowid as id,
+			g.rowid as game_id,
+			p.rowid as player_id,
+			p.name as name,
+			r.rowid as role_id,
+			r.name as role_name,
+			r.description as role_description,
+			r.team as team,
+			gp.is_alive as is_alive,
+			gp.is_observer as is_observer,
+			IFNULL(l.player2_id, 0) as lover
+		FROM game_player gp
+			JOIN player p on gp.player_id = p.rowid
+		    JOIN game_action ga ON ga.target_player_id = p.rowid
+			JOIN game g on gp.game_id = g.rowid
+			JOIN role r on gp.role_id = r.rowid
+			LEFT JOIN game_lovers l on l.player1_id = p.rowid
+		WHERE ga.game_id = ? AND ga.round = ? AND ga.phase = 'night'
+		    AND ga.action_type IN (?, ?, ?, ?)
+		    AND gp.is_alive = 0`,
+		game.ID, game.Round, ActionWerewolfSelectKill, ActionWerewolfSelectKill2, ActionWitchApplyKill, ActionLoverHeartbreak)
+
+	seerInvestigated := getSeerInvestigated(db, game.ID, playerID)
+	nightVictims = applyCardVisibility(player, nightVictims, seerInvestigated, viewerReveal(db, game.ID, player))
+	visiblePlayers := applyCardVisibility(player, players, seerInvestigated, viewerReveal(db, game.ID, player))
+
+	// Get alive players as targets (visibility pre-applied)
+	var aliveTargets []Player
+	for _, p := range visiblePlayers {
+		if p.IsAlive {
+			aliveTargets = append(aliveTargets, p)
+		}
+	}
+
+	// Populate Hunter revenge data — check for any dead Hunter who hasn't taken their revenge shot yet
+	var hunterRevengeNeeded, hunterRevengeDone bool
+	var isTheHunter bool
+	var hunterVictimPlayer, hunterSelectedPlayer *Player
+	var hunterTargets []Player
+
+	// Step 1: Find a dead Hunter who hasn't taken revenge yet (pending — takes priority)
+	for _, p := range players {
+		if p.IsAlive || p.RoleName != "Hunter" || powerDisabled(db, game.ID, p.RoleName) {
+			continue
+		}
+		var revengeCount int
+		db.Get(&revengeCount, `
+			SELECT COUNT(*) FROM game_action
+			WHERE game_id = ? AND actor_player_id = ? AND action_type = ?`,
+			game.ID, p.PlayerID, ActionHunterApplyKill)
+		if revengeCount == 0 {
+			hunterRevengeNeeded = true
+			isTheHunter = (p.PlayerID == playerID)
+			hunterTargets = aliveTargets
+			if isTheHunter {
+				var selectAction GameAction
+				if db.Get(&selectAction, `
+					SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+					FROM game_action
+					WHERE game_id=? AND round=? AND actor_player_id=? AND action_type=?`,
+					game.ID, game.Round, playerID, ActionHunterSelectKill) == nil && selectAction.TargetPlayerID != nil {
+					hunterSelectedPlayer = getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated)
+				}
+			}
+			break
+		}
+	}
+
+	// Step 2: If no pending Hunter, check if any revenge happened this round (show result)
+	if !hunterRevengeNeeded {
+		var revengeAction GameAction
+		err = db.Get(&revengeAction, `
+			SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+			FROM game_action
+			WHERE game_id = ? AND round = ? AND action_type = ?
+			ORDER BY rowid DESC LIMIT 1`,
+			game.ID, game.Round, ActionHunterApplyKill)
+		if err == nil && revengeAction.TargetPlayerID != nil {
+			hunterRevengeNeeded = true
+			hunterRevengeDone = true
+			// HunterVictimPlayer: dead player, full role visible (dead rule in applyCardVisibility)
+			hunterVictimPlayer = getVisiblePlayer(db, game.ID, *revengeAction.TargetPlayerID, player, seerInvestigated)
+			isTheHunter = (revengeAction.ActorPlayerID == playerID)
+		}
+	}
+
+	voteType := dayVoteAction(db, game.ID, game.Round)
+	runoff := runoffCandidates(db, game.ID, game.Round)
+	dayVoteCounts, _, _ := getVoteCounts(db, game.ID, game.Round, "day", voteType)
+	votersByTarget := map[int64][]VoterChip{}
+	var passVoters []VoterChip
+	var currentVotePlayer *Player
+
+	var actions []GameAction
+	db.Select(&actions, `
+		SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+		FROM game_action
+		WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, voteType)
+
+	secretVotes := secretVotesEnabled(db, game.ID)
+	for _, action := range actions {
+		// secret votes: the player sees only their own vote until the day is over
+		if secretVotes && action.ActorPlayerID != playerID {
+			continue
+		}
+		var voterName string
+		db.Get(&voterName, "SELECT name FROM player WHERE rowid = ?", action.ActorPlayerID)
+		chip := VoterChip{Name: voterName, PlayerUID: action.ActorPlayerID, Weight: voteWeight(db, game.ID, getRoleName(db, game.ID, action.ActorPlayerID))}
+		if action.TargetPlayerID != nil {
+			votersByTarget[*action.TargetPlayerID] = append(votersByTarget[*action.TargetPlayerID], chip)
+			if action.ActorPlayerID == playerID {
+				currentVotePlayer = getVisiblePlayer(db, game.ID, *action.TargetPlayerID, player, seerInvestigated)
+			}
+		} else {
+			passVoters = append(passVoters, chip)
+		}
+	}
+
+	silenced := silencedPlayers(db, game.ID, game.Round)
+	var silencedList []Player
+	for _, t := range aliveTargets {
+		if silenced[t.PlayerID] {
+			silencedList = append(silencedList, t)
+		}
+	}
+
+	// All-acted and has-voted checks for End Vote button
+	var totalDayActed int
+	db.Get(&totalDayActed, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, voteType)
+	var playerActed int
+	db.Get(&playerActed, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ? AND actor_player_id = ?`,
+		game.ID, game.Round, voteType, playerID)
+
+	var nightVictimCards []PlayerCardData
+	for _, v := range nightVictims {
+		card := makePlayerCard(v, lang)
+		card.ShowRoleSeal = true
+		card.Collapsed = true
+		card.Lover = v.Lover != 0
+		nightVictimCards = append(nightVictimCards, card)
+	}
+
+	var hunterTargetCards []PlayerCardData
+	for _, t := range hunterTargets {
+		card := makePlayerCard(t, lang)
+		card.Selectable = true
+		card.Lover = isViewerLover(t, player)
+		if hunterSelectedPlayer != nil && hunterSelectedPlayer.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		hunterTargetCards = append(hunterTargetCards, card)
+	}
+
+	nominations := buildNominationDayData(db, game, player, aliveTargets, silenced)
+	onBallot := ballot(db, game)
+
+	var voteTargetCards []PlayerCardData
+	for _, t := range aliveTargets {
+		// with nominations only the ballot can be voted on, once the vote is open
+		if nominations.Nominations && (nominations.DayStage != DayStageVote || !onBallot[t.PlayerID]) {
+			continue
+		}
+		// a runoff is between the tied players only
+		if len(runoff) > 0 && !runoff[t.PlayerID] {
+			continue
+		}
+		card := makePlayerCard(t, lang)
+		card.Selectable = !silenced[playerID]
+		card.ShowVoteCount = !secretVotes
+		card.VoteCount = dayVoteCounts[t.PlayerID]
+		card.Voters = votersByTarget[t.PlayerID]
+		card.Lover = isViewerLover(t, player)
+		if currentVotePlayer != nil && currentVotePlayer.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		voteTargetCards = append(voteTargetCards, card)
+	}
+
+	data := DayData{
+		Player:               &player,
+		AliveTargets:         aliveTargets,
+		NightNumber:          game.Round,
+		NightVictims:         nightVictims,
+		PassVoters:           passVoters,
+		SecretVotes:          secretVotes,
+		Runoff:               len(runoff) > 0,
+		ElectedMayor:         electedMayorName(db, game.ID),
+		TimeLeft:             h.dayTimeLeft(game),
+		NarratorMode:         narratorModeEnabled(db, game.ID),
+		DayChat:              chatMessages(db, game.ID, ChatChannelDay),
+		CanDayChat:           canChat(db, game, player, ChatChannelDay),
+		LoversChatData:       buildLoversChatData(db, game, player),
+		GraveyardData:        buildGraveyardData(db, game, player),
+		CurrentVotePlayer:    currentVotePlayer,
+		HunterRevengeNeeded:  hunterRevengeNeeded,
+		HunterRevengeDone:    hunterRevengeDone,
+		HunterVictimPlayer:   hunterVictimPlayer,
+		IsTheHunter:          isTheHunter,
+		HunterSelectedPlayer: hunterSelectedPlayer,
+		HunterTargets:        hunterTargets,
+		AllActed:             totalDayActed >= len(aliveTargets)-len(silencedList),
+		HasVoted:             playerActed > 0,
+		IsMayor:              player.RoleName == "Mayor",
+		IsSilenced:           silenced[playerID],
+		IsBarred:             scapegoatBarred(db, game.ID, game.Round)[playerID],
+		IsIdiot:              revealedIdiots(db, game.ID)[playerID],
+		ToughGuyWounded:      player.IsAlive && isToughGuyWounded(db, game.ID, game.Round, playerID),
+		SilencedPlayers:      silencedList,
+		PriestDayData:        buildPriestDayData(db, game, player, seerInvestigated, aliveTargets, lang),
+		ScapegoatDayData:     buildScapegoatDayData(db, game, player, aliveTargets, lang),
+		NominationDayData:    nominations,
+		TrialDayData:         buildTrialDayData(db, game, player, seerInvestigated),
+		LastWordsDayData:     buildLastWordsDayData(db, game, playerID),
+		WhisperDayData:       buildWhisperDayData(db, game, player, aliveTargets),
+		Lang:                 lang,
+		NightVictimCards:     nightVictimCards,
+		HunterTargetCards:    hunterTargetCards,
+		VoteTargetCards:      voteTargetCards,
+	}
+	return data, nil
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// With last words a player the village lynched or a Hunter shot may leave one public
+// message. The night waits for it: transitionToNight leaves a day_dusk row instead of ending
+// the day while a message is owed, and the last message (or the window running out) ends it.
+
+// DayStageLastWords is the day's stage once the vote is settled and the night is waiting
+// for last words.
+const DayStageLastWords = "last_words"
+
+// defaultLastWordsWindow is how long an eliminated player has for their last words.
+const defaultLastWordsWindow = 60 * time.Second
+
+// lastWordsMax caps a last message, like a trial defense.
+const lastWordsMax = 500
+
+type LastWord struct {
+	Name  string
+	Words string // "" when they left without a word
+}
+
+type LastWordsDayData struct {
+	LastWordsOwed []string // players who still have their last words to say
+	CanSpeak      bool     // this player owes last words
+	LastWords     []LastWord
+	Dusk          bool // the vote is settled and the night only waits for last words
+}
+
+// lastWordsEnabled reports whether lynched and shot players get last words.
+func lastWordsEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT last_words FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// lastWordsOwed returns the players lynched or shot today who have not had their last words.
+func lastWordsOwed(db *sqlx.DB, game *Game) []int64 {
+	if !lastWordsEnabled(db, game.ID) {
+		return nil
+	}
+	var ids []int64
+	db.Select(&ids, `
+SELECT DISTINCT a.target_player_id FROM game_action a
+JOIN game_player g ON g.game_id = a.game_id AND g.player_id = a.target_player_id
+WHERE a.game_id = ? AND a.round = ? AND a.phase = 'day' AND a.action_type IN (?, ?) AND g.is_alive = 0
+AND a.target_player_id NOT IN (SELECT actor_player_id FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?)
+ORDER BY a.rowid`,
+		game.ID, game.Round, ActionDayApplyKill, ActionHunterApplyKill, game.ID, game.Round, ActionDayLastWords)
+	return ids
+}
+
+// lastWordsHeld reports whether the day is over and the night only waits for last words.
+func lastWordsHeld(db *sqlx.DB, game *Game) bool {
+	var held int
+	db.Get(&held, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, ActionDayDusk)
+	return held > 0
+}
+
+func buildLastWordsDayData(db *sqlx.DB, game *Game, playerID int64) LastWordsDayData {
+	d := LastWordsDayData{Dusk: lastWordsHeld(db, game)}
+	for _, id := range lastWordsOwed(db, game) {
+		d.LastWordsOwed = append(d.LastWordsOwed, getPlayerName(db, id))
+		if id == playerID {
+			d.CanSpeak = true
+		}
+	}
+	var rows []string
+	db.Select(&rows, `SELECT description_args FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ? ORDER BY rowid`,
+		game.ID, game.Round, ActionDayLastWords)
+	for _, args := range rows {
+		parts := strings.Split(args, "\t")
+		w := LastWord{Name: parts[len(parts)-1]}
+		if len(parts) == 3 {
+			w = LastWord{Name: parts[1], Words: parts[2]}
+		}
+		d.LastWords = append(d.LastWords, w)
+	}
+	return d
+}
+
+// holdForLastWords keeps the day open while last words are owed, marking it as over so the
+// last message ends it. Called by transitionToNight.
+func (h *Hub) holdForLastWords(game *Game) bool {
+	owed := lastWordsOwed(h.db, game)
+	if len(owed) == 0 {
+		return false
+	}
+	h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility) VALUES (?, ?, 'day', ?, ?, ?)`,
+		game.ID, game.Round, owed[0], ActionDayDusk, VisibilityActor)
+	h.logf("Day %d is over - waiting for the last words of %d player(s)", game.Round, len(owed))
+	h.triggerBroadcast()
+	return true
+}
+
+// openLastWords gives a player who was just lynched or shot their window for last words;
+// once it runs out they are recorded as silent.
+func (h *Hub) openLastWords(game *Game, playerID int64) {
+	if !lastWordsEnabled(h.db, game.ID) {
+		return
+	}
+	gameID, round := game.ID, game.Round
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		for {
+			select {
+			case <-h.done:
+				return
+			case <-time.After(h.lastWordsWindow):
+			}
+			// the window only closes once the game is not paused
+			if !gamePaused(h.db, gameID) {
+				h.moveMu.Lock()
+				h.recordLastWords(gameID, round, playerID, "")
+				h.moveMu.Unlock()
+				return
+			}
+		}
+	}()
+}
+
+// recordLastWords stores a player's last words (silence when empty) and ends the day if it
+// was only waiting for them. It does nothing once the player's words are in or the day is gone.
+func (h *Hub) recordLastWords(gameID int64, round int, playerID int64, words string) bool {
+	game, err := h.getGame()
+	if err != nil || game.ID != gameID || game.Status != "day" || game.Round != round {
+		return false
+	}
+	owed := false
+	for _, id := range lastWordsOwed(h.db, game) {
+		if id == playerID {
+			owed = true
+		}
+	}
+	if !owed {
+		return false
+	}
+
+	name := h.playerName(playerID)
+	desc := fmt.Sprintf("Day %d: %s left without last words", game.Round, name)
+	key, args := "hist_last_words_silent", histArgs(game.Round, name)
+	if words != "" {
+		desc = fmt.Sprintf("Day %d: %s's last words: %s", game.Round, name, words)
+		key, args = "hist_last_words", histArgs(game.Round, name, words)
+	}
+	if _, err := h.db.Exec(`
+		INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, playerID, ActionDayLastWords, VisibilityPublic, desc, key, args); err != nil {
+		h.logError("recordLastWords: insert", err)
+		return false
+	}
+	h.logf("'%s' had their last words", name)
+
+	if lastWordsHeld(h.db, game) && len(lastWordsOwed(h.db, game)) == 0 {
+		h.transitionToNight(game)
+		return true
+	}
+	h.triggerBroadcast()
+	return true
+}
+
+// handleWSLastWords records the message of a player who owes last words.
+func handleWSLastWords(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSLastWords: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	// history args are tab-separated, so the message is kept to a single line
+	words := strings.Join(strings.Fields(msg.Message), " ")
+	if len(words) > lastWordsMax {
+		h.sendErrorToast(client.playerID, T(lang, "err_last_words_too_long"))
+		return
+	}
+	if !h.recordLastWords(game.ID, game.Round, client.playerID, words) {
+		h.sendErrorToast(client.playerID, T(lang, "err_no_last_words_due"))
+	}
+}
+
+// handleWSToggleLastWords switches last words on or off in the lobby; kept for the next game.
+func handleWSToggleLastWords(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleLastWords: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET last_words = NOT last_words WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleLastWords: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_last_words"))
+		return
+	}
+	h.logf("Last words toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Last Words Tests
+// ============================================================================
+
+func TestLastWordsHoldTheNight(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Wolf2", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	wolf, v1 := ids[0], ids[2]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET last_words = 1 WHERE rowid = ?", game.ID)
+	ctx.hub().lastWordsWindow = time.Hour
+
+	wolfID := strconv.FormatInt(wolf, 10)
+	for _, id := range ids[2:] {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	}
+	ctx.sendWS(wolf, WSMessage{Action: "day_pass"})
+	ctx.sendWS(ids[1], WSMessage{Action: "day_pass"})
+	ctx.sendWS(v1, WSMessage{Action: "day_end_vote"})
+
+	if ctx.isPlayerAlive(wolf) {
+		t.Fatal("the Wolf should have been lynched")
+	}
+	if status, round, _ := ctx.gameState(); status != "day" || round != 1 {
+		t.Fatalf("the night should wait for the last words, got %q round %d", status, round)
+	}
+	buf, err := getGameComponent(ctx.hub(), wolf, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="last-words-form"`) {
+		t.Fatalf("the lynched player should be offered their last words (err: %v)", err)
+	}
+	ctx.sendWS(v1, WSMessage{Action: "day_end_vote"})
+	ctx.sendWS(v1, WSMessage{Action: "last_words", Message: "I was framed"})
+	if n := ctx.countActions(ActionDayLastWords); n != 0 {
+		t.Fatalf("only the lynched player has last words, got %d rows", n)
+	}
+
+	ctx.sendWS(wolf, WSMessage{Action: "last_words", Message: "  You will\nregret this "})
+	if status, round, _ := ctx.gameState(); status != "night" || round != 2 {
+		t.Fatalf("the last words should end the day, got %q round %d", status, round)
+	}
+	if h := ctx.historyFor(v1); !strings.Contains(h, "Wolf's last words: You will regret this") {
+		t.Errorf("the last words should be public and on one line, got: %q", h)
+	}
+}
+
+func TestLastWordsWindowRunsOut(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET last_words = 1 WHERE rowid = ?", game.ID)
+	ctx.hub().lastWordsWindow = 10 * time.Millisecond
+
+	target := strconv.FormatInt(ids[1], 10)
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: target})
+	}
+	ctx.sendWS(ids[2], WSMessage{Action: "day_end_vote"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for status, _, _ := ctx.gameState(); status == "day" && time.Now().Before(deadline); status, _, _ = ctx.gameState() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if status, round, _ := ctx.gameState(); status != "night" || round != 2 {
+		t.Fatalf("the night should fall once the window runs out, got %q round %d", status, round)
+	}
+	if h := ctx.historyFor(ids[0]); !strings.Contains(h, "V1 left without last words") {
+		t.Errorf("a silent exit should be recorded, got: %q", h)
+	}
+}
+
+func TestLastWordsInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a lynched player's last words ===")
+
+	// Setup: 1 werewolf + 4 villagers = 5 players, last words on
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"LW1", "LW2", "LW3", "LW4", "LW5"},
+		[]string{"last-words-toggle"}, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	werewolves, villagers := killFirstVillagerAtNight(ctx, players)
+	werewolf, lynched, v3, v4 := werewolves[0], villagers[1], villagers[2], villagers[3]
+
+	lynched.clickAndWait("#day-pass-btn")
+	werewolf.dayVoteForPlayer(lynched.Name)
+	v3.dayVoteForPlayer(lynched.Name)
+	v4.dayVoteForPlayer(lynched.Name)
+
+	if _, err := lynched.p().Element("#last-words-form"); err != nil {
+		ctx.logger.LogDB("FAIL: no last words")
+		t.Fatalf("the lynched player should get their last words: %v", err)
+	}
+	if _, err := v3.p().Element("#day-dusk-note"); err != nil {
+		t.Errorf("the night should wait for the last words: %v", err)
+	}
+
+	lynched.submitFormWithValues("last-words-form", map[string]string{"message": "it was the baker"})
+	waitForNightPhaseAll(ctx, []*TestPlayer{werewolf, v3, v4})
+	entry := lynched.Name + "'s last words: it was the baker"
+	if !v3.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: last words not in history")
+		t.Errorf("everyone should read %q in history, got: %s", entry, v3.getHistoryText())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
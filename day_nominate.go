@@ -0,0 +1,326 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// In a game with nominations the day runs in two stages: players first nominate and second
+// candidates, then someone opens the vote and only seconded candidates can be voted out.
+// Passing is possible in either stage, so a village that nominates nobody can still end the day.
+const (
+	DayStageNominate = "nominate"
+	DayStageVote     = "vote"
+)
+
+type Nominee struct {
+	PlayerUID   int64
+	Name        string
+	NominatedBy string
+	Seconds     []string // names of the players who seconded
+	OnBallot    bool     // seconded at least once
+	CanSecond   bool     // the viewer may second this nomination
+}
+
+type NominationDayData struct {
+	Nominations     bool   // the game uses nominations
+	DayStage        string // DayStageNominate or DayStageVote; "" without nominations
+	CanNominate     bool   // the viewer may still nominate today
+	Nominees        []Nominee
+	NominateTargets []Player // players the viewer may nominate
+	BallotSize      int
+}
+
+// nominationsEnabled reports whether the game's day votes go through nominations.
+func nominationsEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT nominations FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// dayStage returns the stage of today's vote, including a trial's stages (day_trial.go).
+// Without nominations or a trial it is "". The vote opens once a player has pressed Open
+// Vote; the open row is public, so everyone sees who closed the nominations.
+func dayStage(db *sqlx.DB, game *Game) string {
+	if lastWordsHeld(db, game) {
+		return DayStageLastWords
+	}
+	if stage := trialStage(db, game); stage != "" {
+		return stage
+	}
+	if !nominationsEnabled(db, game.ID) {
+		return ""
+	}
+	var opened int
+	db.Get(&opened, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, ActionDayOpenVote)
+	if opened > 0 {
+		return DayStageVote
+	}
+	return DayStageNominate
+}
+
+// nominatedBy maps today's nominated players to the player who nominated them.
+func nominatedBy(db *sqlx.DB, game *Game) map[int64]int64 {
+	var rows []struct {
+		Actor  int64 `db:"actor_player_id"`
+		Target int64 `db:"target_player_id"`
+	}
+	db.Select(&rows, `SELECT actor_player_id, target_player_id FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, ActionDayNominate)
+	nominated := make(map[int64]int64, len(rows))
+	for _, r := range rows {
+		nominated[r.Target] = r.Actor
+	}
+	return nominated
+}
+
+// ballot returns today's seconded nominations: the only players the village may vote on.
+func ballot(db *sqlx.DB, game *Game) map[int64]bool {
+	var ids []int64
+	db.Select(&ids, `SELECT DISTINCT target_player_id FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, ActionDaySecond)
+	onBallot := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		onBallot[id] = true
+	}
+	return onBallot
+}
+
+func buildNominationDayData(db *sqlx.DB, game *Game, player Player, aliveTargets []Player, silenced map[int64]bool) NominationDayData {
+	if !nominationsEnabled(db, game.ID) {
+		return NominationDayData{}
+	}
+	stage := dayStage(db, game)
+	d := NominationDayData{Nominations: true, DayStage: stage}
+
+	nominated := nominatedBy(db, game)
+	var seconds []struct {
+		Actor  int64 `db:"actor_player_id"`
+		Target int64 `db:"target_player_id"`
+	}
+	db.Select(&seconds, `SELECT actor_player_id, target_player_id FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ? ORDER BY rowid`,
+		game.ID, game.Round, ActionDaySecond)
+	var hasNominated, hasSeconded bool
+	for _, s := range seconds {
+		hasSeconded = hasSeconded || s.Actor == player.PlayerID
+	}
+	for _, nominator := range nominated {
+		hasNominated = hasNominated || nominator == player.PlayerID
+	}
+	canAct := stage == DayStageNominate && player.IsAlive && !silenced[player.PlayerID]
+	d.CanNominate = canAct && !hasNominated
+
+	for _, t := range aliveTargets {
+		nominator, ok := nominated[t.PlayerID]
+		if !ok {
+			if t.PlayerID != player.PlayerID {
+				d.NominateTargets = append(d.NominateTargets, t)
+			}
+			continue
+		}
+		n := Nominee{PlayerUID: t.PlayerID, Name: t.Name, NominatedBy: getPlayerName(db, nominator)}
+		for _, s := range seconds {
+			if s.Target == t.PlayerID {
+				n.Seconds = append(n.Seconds, getPlayerName(db, s.Actor))
+			}
+		}
+		n.OnBallot = len(n.Seconds) > 0
+		n.CanSecond = canAct && !hasSeconded && nominator != player.PlayerID
+		if n.OnBallot {
+			d.BallotSize++
+		}
+		d.Nominees = append(d.Nominees, n)
+	}
+	return d
+}
+
+// dayNominator loads the player behind a nomination-stage action and checks they may take
+// part: it is day, the game uses nominations, the vote is not open yet, and the player is
+// alive and holds a vote today. It sends the error toast itself and returns false on failure.
+func (h *Hub) dayNominator(client *Client, context string) (*Game, Player, bool) {
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError(context+": getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return nil, Player{}, false
+	}
+	if game.Status != "day" {
+		h.sendErrorToast(client.playerID, T(lang, "err_day_vote_only"))
+		return nil, Player{}, false
+	}
+	switch dayStage(h.db, game) {
+	case "":
+		h.sendErrorToast(client.playerID, T(lang, "err_nominations_off"))
+		return nil, Player{}, false
+	case DayStageVote, DayStageDefense, DayStageVerdict:
+		h.sendErrorToast(client.playerID, T(lang, "err_nominations_closed"))
+		return nil, Player{}, false
+	}
+	player, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError(context+": getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return nil, Player{}, false
+	}
+	if !player.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_vote"))
+		return nil, Player{}, false
+	}
+	if silencedPlayers(h.db, game.ID, game.Round)[client.playerID] {
+		h.sendErrorToast(client.playerID, T(lang, "err_silenced_cannot_vote"))
+		return nil, Player{}, false
+	}
+	return game, player, true
+}
+
+// handleWSDayNominate puts a living player up for the vote. Each player nominates at most
+// once a day, and a player is nominated at most once.
+func handleWSDayNominate(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, player, ok := h.dayNominator(client, "handleWSDayNominate")
+	if !ok {
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	nominated := nominatedBy(h.db, game)
+	if _, ok := nominated[targetID]; ok {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_nominated"))
+		return
+	}
+	for _, nominator := range nominated {
+		if nominator == client.playerID {
+			h.sendErrorToast(client.playerID, T(lang, "err_nominated_today"))
+			return
+		}
+	}
+
+	desc := fmt.Sprintf("Day %d: %s nominated %s", game.Round, player.Name, target.Name)
+	_, err = h.db.Exec(`
+		INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionDayNominate, targetID, VisibilityPublic, desc, "hist_day_nominate", histArgs(game.Round, player.Name, target.Name))
+	if err != nil {
+		h.logError("handleWSDayNominate: db.Exec insert nomination", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_nomination"))
+		return
+	}
+
+	h.logf("Player '%s' nominated '%s'", player.Name, target.Name)
+	h.triggerBroadcast()
+}
+
+// handleWSDaySecond seconds someone else's nomination, putting the nominee on the ballot.
+// Each player seconds at most once a day.
+func handleWSDaySecond(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, player, ok := h.dayNominator(client, "handleWSDaySecond")
+	if !ok {
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	nominator, nominated := nominatedBy(h.db, game)[targetID]
+	if !nominated {
+		h.sendErrorToast(client.playerID, T(lang, "err_not_nominated"))
+		return
+	}
+	if nominator == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_second_own_nomination"))
+		return
+	}
+	var seconded int
+	h.db.Get(&seconded, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, ActionDaySecond)
+	if seconded > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_seconded_today"))
+		return
+	}
+
+	targetName := h.playerName(targetID)
+	desc := fmt.Sprintf("Day %d: %s seconded the nomination of %s", game.Round, player.Name, targetName)
+	_, err = h.db.Exec(`
+		INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionDaySecond, targetID, VisibilityPublic, desc, "hist_day_second", histArgs(game.Round, player.Name, targetName))
+	if err != nil {
+		h.logError("handleWSDaySecond: db.Exec insert second", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_nomination"))
+		return
+	}
+
+	h.logf("Player '%s' seconded the nomination of '%s'", player.Name, targetName)
+	h.triggerBroadcast()
+}
+
+// handleWSDayOpenVote closes the nominations and opens the vote on the ballot. It needs at
+// least one seconded nomination; without one the village can still pass.
+func handleWSDayOpenVote(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, player, ok := h.dayNominator(client, "handleWSDayOpenVote")
+	if !ok {
+		return
+	}
+	if len(ballot(h.db, game)) == 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_ballot_empty"))
+		return
+	}
+
+	desc := fmt.Sprintf("Day %d: %s closed the nominations and opened the vote", game.Round, player.Name)
+	_, err := h.db.Exec(`
+		INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionDayOpenVote, VisibilityPublic, desc, "hist_day_open_vote", histArgs(game.Round, player.Name))
+	if err != nil {
+		h.logError("handleWSDayOpenVote: db.Exec insert", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_nomination"))
+		return
+	}
+
+	h.logf("Player '%s' opened the day vote", player.Name)
+	h.triggerBroadcast()
+}
+
+// handleWSToggleNominations switches the game's day votes between open voting and
+// nominations. Like the night timer it is set in the lobby and kept for the next game.
+func handleWSToggleNominations(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleNominations: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET nominations = NOT nominations WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleNominations: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_nominations"))
+		return
+	}
+	h.logf("Day nominations toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Nomination Helpers
+// ============================================================================
+
+// nominatePlayer picks a player in the nomination form by name and nominates them.
+func (tp *TestPlayer) nominatePlayer(targetName string) {
+	if tp.logger != nil {
+		tp.logger.Debug("[%s] Nominating: %s", tp.Name, targetName)
+	}
+	tp.submitFormWithValues("day-nominate-form", map[string]string{
+		"target_player_id": tp.optionValue("#day-nominate-select", targetName),
+	})
+}
+
+// ============================================================================
+// Nomination Tests
+// ============================================================================
+
+func TestNominationsToggleInLobby(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"P1", "P2"}, []string{RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(ids[0], WSMessage{Action: "toggle_nominations"})
+	if !nominationsEnabled(ctx.app.db, game.ID) {
+		t.Fatal("nominations should be switched on")
+	}
+	ctx.sendWS(ids[0], WSMessage{Action: "toggle_nominations"})
+	if nominationsEnabled(ctx.app.db, game.ID) {
+		t.Error("nominations should be switched off again")
+	}
+}
+
+func TestOnlySecondedNomineesCanBeVotedOut(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "V1", "V2", "V3", "V4"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf, v1, v2, v3, v4 := ids[0], ids[1], ids[2], ids[3], ids[4]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET nominations = 1 WHERE rowid = ?", game.ID)
+	wolfID, v4ID := strconv.FormatInt(wolf, 10), strconv.FormatInt(v4, 10)
+
+	// no voting before the nominations close
+	ctx.sendWS(v1, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	if n := ctx.countActions(ActionDaySelectKill); n != 0 {
+		t.Fatalf("votes should wait for the ballot, got %d", n)
+	}
+
+	ctx.sendWS(v1, WSMessage{Action: "day_nominate", TargetPlayerID: wolfID})
+	ctx.sendWS(v2, WSMessage{Action: "day_nominate", TargetPlayerID: v4ID})
+	ctx.sendWS(v1, WSMessage{Action: "day_second", TargetPlayerID: wolfID})
+	if n := ctx.countActions(ActionDaySecond); n != 0 {
+		t.Fatal("a player should not second their own nomination")
+	}
+	ctx.sendWS(v1, WSMessage{Action: "day_open_vote"})
+	if dayStage(ctx.app.db, game) != DayStageNominate {
+		t.Fatal("the vote should not open without a seconded nomination")
+	}
+
+	ctx.sendWS(v3, WSMessage{Action: "day_second", TargetPlayerID: wolfID})
+	buf, err := getGameComponent(ctx.hub(), v2, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="nominee-`+wolfID+`"`) {
+		t.Fatalf("the day screen should list the nominees (err: %v)", err)
+	}
+	ctx.sendWS(v3, WSMessage{Action: "day_open_vote"})
+	if dayStage(ctx.app.db, game) != DayStageVote {
+		t.Fatal("the vote should open once a nomination is seconded")
+	}
+	ctx.sendWS(v4, WSMessage{Action: "day_nominate", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	if n := ctx.countActions(ActionDayNominate); n != 2 {
+		t.Errorf("nominations should be closed once the vote opens, got %d", n)
+	}
+
+	// V4 was nominated but never seconded, so is not on the ballot
+	ctx.sendWS(wolf, WSMessage{Action: "day_vote", TargetPlayerID: v4ID})
+	if n := ctx.countActions(ActionDaySelectKill); n != 0 {
+		t.Fatalf("an unseconded nominee should not be votable, got %d votes", n)
+	}
+
+	for _, id := range []int64{v1, v2, v3, v4} {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	}
+	ctx.sendWS(wolf, WSMessage{Action: "day_pass"})
+	ctx.sendWS(v1, WSMessage{Action: "day_end_vote"})
+	if ctx.isPlayerAlive(wolf) {
+		t.Error("the seconded nominee should be voted out")
+	}
+	if h := ctx.historyFor(v4); !strings.Contains(h, "seconded the nomination of Wolf") {
+		t.Errorf("seconds should be public, got: %q", h)
+	}
+}
+
+func TestNominateSecondAndVoteInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a nominated and seconded werewolf voted out ===")
+
+	// Setup: 1 werewolf + 3 villagers = 4 players, nominations on
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"NO1", "NO2", "NO3", "NO4"},
+		[]string{"nominations-toggle"}, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	werewolves, villagers := killFirstVillagerAtNight(ctx, players)
+	werewolf, accuser, second := werewolves[0], villagers[1], villagers[2]
+
+	if _, err := accuser.p().Element("#nominations-stage"); err != nil {
+		ctx.logger.LogDB("FAIL: no nomination stage")
+		t.Fatalf("the day should open with nominations: %v", err)
+	}
+	accuser.nominatePlayer(werewolf.Name)
+	second.clickAndWait("[id^='day-second-btn-']")
+	accuser.clickAndWait("#day-open-vote-btn")
+
+	if targets := second.getDayVoteButtons(); len(targets) != 1 || targets[0] != werewolf.Name {
+		ctx.logger.LogDB("FAIL: ballot is not the seconded nominee")
+		t.Fatalf("only the seconded nominee should be on the ballot, got %v", targets)
+	}
+
+	werewolf.clickAndWait("#day-pass-btn")
+	accuser.dayVoteForPlayer(werewolf.Name)
+	second.dayVoteForPlayer(werewolf.Name)
+
+	if !second.isGameFinished() || second.getWinner() != "villagers" {
+		ctx.logger.LogDB("FAIL: werewolf not voted out")
+		t.Errorf("voting out the nominated werewolf should win the game for the village, got: %s", second.getGameContent())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
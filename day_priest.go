@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type PriestDayData struct {
+	PriestCanThrow       bool // alive Priest who still has the holy water
+	PriestSelectedPlayer *Player
+	PriestTargetCards    []PlayerCardData
+}
+
+func buildPriestDayData(db *sqlx.DB, game *Game, player Player, seerInvestigated map[int64]string, aliveTargets []Player, lang string) PriestDayData {
+	if player.RoleName != "Priest" || !player.IsAlive || priestWaterUsed(db, game.ID, player.PlayerID) || powerDisabled(db, game.ID, player.RoleName) {
+		return PriestDayData{}
+	}
+
+	d := PriestDayData{PriestCanThrow: true}
+	var selectAction GameAction
+	if db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='day' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, player.PlayerID, ActionPriestSelectWater) == nil && selectAction.TargetPlayerID != nil {
+		d.PriestSelectedPlayer = getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated)
+	}
+
+	for _, t := range aliveTargets {
+		if t.PlayerID == player.PlayerID {
+			continue
+		}
+		card := makePlayerCard(t, lang)
+		card.Selectable = true
+		card.Lover = isViewerLover(t, player)
+		if d.PriestSelectedPlayer != nil && d.PriestSelectedPlayer.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		d.PriestTargetCards = append(d.PriestTargetCards, card)
+	}
+	return d
+}
+
+// priestWaterUsed reports whether the Priest has already thrown their single flask of holy water.
+func priestWaterUsed(db *sqlx.DB, gameID, priestID int64) bool {
+	var used int
+	db.Get(&used, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND actor_player_id=? AND action_type=?`,
+		gameID, priestID, ActionPriestApplyWater)
+	return used > 0
+}
+
+func handleWSPriestSelect(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSPriestSelect: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "day" {
+		h.sendErrorToast(client.playerID, T(lang, "err_priest_day_only"))
+		return
+	}
+	priest, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSPriestSelect: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if priest.RoleName != "Priest" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_priest"))
+		return
+	}
+	if !priest.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	if powerDisabled(h.db, game.ID, priest.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+	if priestWaterUsed(h.db, game.ID, client.playerID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_priest_water_used"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var existing GameAction
+	selectErr := h.db.Get(&existing, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='day' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionPriestSelectWater)
+	if selectErr == nil && existing.TargetPlayerID != nil && *existing.TargetPlayerID == targetID {
+		// clicking the same target again deselects it
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='day' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionPriestSelectWater)
+		h.logf("Priest '%s' deselected holy water target", priest.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'day', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionPriestSelectWater, targetID, VisibilityActor)
+		h.logf("Priest '%s' selected holy water target %d", priest.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+// handleWSPriestThrow resolves the holy water at once: a werewolf target dies, anyone else
+// is unharmed and the Priest dies instead. Deaths then run the usual day death chain.
+func handleWSPriestThrow(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSPriestThrow: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "day" {
+		h.sendErrorToast(client.playerID, T(lang, "err_priest_day_only"))
+		return
+	}
+	priest, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSPriestThrow: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if priest.RoleName != "Priest" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_priest"))
+		return
+	}
+	if !priest.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	if powerDisabled(h.db, game.ID, priest.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+	if priestWaterUsed(h.db, game.ID, client.playerID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_priest_water_used"))
+		return
+	}
+
+	var selectAction GameAction
+	if err := h.db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='day' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionPriestSelectWater); err != nil || selectAction.TargetPlayerID == nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_priest_select_first"))
+		return
+	}
+	targetID := *selectAction.TargetPlayerID
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='day' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionPriestSelectWater)
+
+	hitWolf := inWolfPack(target)
+	deadID := client.playerID
+	histKey := "hist_priest_backfire"
+	desc := fmt.Sprintf("Day %d: Priest %s threw holy water at %s, who was unharmed — the Priest died", game.Round, priest.Name, target.Name)
+	if hitWolf {
+		deadID = targetID
+		histKey = "hist_priest_wolf"
+		desc = fmt.Sprintf("Day %d: Priest %s threw holy water at %s, who burned — a werewolf", game.Round, priest.Name, target.Name)
+	}
+
+	if _, err := h.db.Exec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, deadID); err != nil {
+		h.logError("handleWSPriestThrow: kill player", err)
+		return
+	}
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionPriestApplyWater, targetID, VisibilityPublic, desc, histKey, histArgs(game.Round, priest.Name, target.Name))
+	if err != nil {
+		h.logError("handleWSPriestThrow: record holy water", err)
+	}
+	h.clearDayVotes(game, deadID)
+
+	h.logf("Priest '%s' threw holy water at '%s' (werewolf: %v)", priest.Name, target.Name, hitWolf)
+	DebugLog("handleWSPriestThrow", "Priest '%s' threw holy water at '%s' (werewolf: %v)", priest.Name, target.Name, hitWolf)
+	LogDBState(h.db, "after priest holy water")
+
+	heartbroken := h.applyHeartbreaks(game, "day", []int64{deadID})
+	h.inheritDoppelgangerRoles(game, "day")
+	h.promoteApprenticeSeer(game, "day")
+	h.awakenWildChildren(game, "day")
+
+	for _, id := range append([]int64{deadID}, heartbroken...) {
+		if getRoleName(h.db, game.ID, id) == "Hunter" && !powerDisabled(h.db, game.ID, "Hunter") {
+			h.logf("Hunter '%s' was killed by holy water fallout — waiting for revenge shot", h.playerName(id))
+			h.triggerBroadcast()
+			return
+		}
+	}
+
+	if h.checkWinConditions(game) {
+		return // Game ended
+	}
+
+	h.triggerBroadcast()
+}
+
+// clearDayVotes drops this day's votes cast by or aimed at a player who died mid-day,
+// so the remaining vote can still reach a majority among the living.
+func (h *Hub) clearDayVotes(game *Game, playerID int64) {
+	h.db.Exec(`DELETE FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type IN (?, ?) AND (actor_player_id = ? OR target_player_id = ?)`,
+		game.ID, game.Round, ActionDaySelectKill, ActionDayRunoffVote, playerID, playerID)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Priest Helpers
+// ============================================================================
+
+// priestThrowAt selects a target for the Priest's holy water and clicks the Throw button.
+func (tp *TestPlayer) priestThrowAt(targetName string) {
+	tp.selectAndConfirm("priest-select-form-", targetName, "#priest-throw-button")
+}
+
+// ============================================================================
+// Priest Tests
+// ============================================================================
+
+func TestPriestHolyWaterKillsWerewolf(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Priest", "V1", "V2"},
+		[]string{RoleWerewolf, RolePriest, RoleVillager, RoleVillager})
+	wolf, priest := ids[0], ids[1]
+
+	game, _ := ctx.hub().getGame()
+	buf, err := getGameComponent(ctx.hub(), priest, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "priest-throw-button") {
+		t.Fatalf("Priest should be offered the holy water (err: %v)", err)
+	}
+
+	ctx.sendWS(priest, WSMessage{Action: "priest_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(priest, WSMessage{Action: "priest_throw"})
+
+	if ctx.isPlayerAlive(wolf) {
+		t.Error("holy water should kill a werewolf")
+	}
+	if !ctx.isPlayerAlive(priest) {
+		t.Error("the Priest should survive hitting a werewolf")
+	}
+	if _, _, winner := ctx.gameState(); winner != "villagers" {
+		t.Errorf("killing the last wolf should end the game for the village, got %q", winner)
+	}
+	if h := ctx.historyFor(ids[2]); !strings.Contains(h, "who burned") {
+		t.Errorf("the holy water should be public, got: %q", h)
+	}
+}
+
+func TestPriestHolyWaterBackfires(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Priest", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RolePriest, RoleVillager, RoleVillager, RoleVillager})
+	priest, vil := ids[1], ids[2]
+
+	// a vote already cast by the Priest must not linger after their death
+	ctx.sendWS(priest, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(ids[0], 10)})
+	ctx.sendWS(priest, WSMessage{Action: "priest_select", TargetPlayerID: strconv.FormatInt(vil, 10)})
+	ctx.sendWS(priest, WSMessage{Action: "priest_throw"})
+
+	if !ctx.isPlayerAlive(vil) {
+		t.Error("holy water should not harm a villager")
+	}
+	if ctx.isPlayerAlive(priest) {
+		t.Error("the Priest should die when the holy water hits a non-werewolf")
+	}
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Errorf("the day should continue after the throw, got %q", status)
+	}
+	if n := ctx.countActions(ActionDaySelectKill); n != 0 {
+		t.Errorf("the dead Priest's day vote should be cleared, got %d", n)
+	}
+	if n := ctx.countActions(ActionPriestApplyWater); n != 1 {
+		t.Fatalf("expected one holy water record, got %d", n)
+	}
+}
+
+func TestPriestBurnsWerewolfInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Priest's holy water kills a werewolf ===")
+
+	// Setup: 1 werewolf + 1 priest + 3 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"PT1", "PT2", "PT3", "PT4", "PT5"},
+		RoleWerewolf, RolePriest, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Priest"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) < 2 {
+		t.Fatal("Missing required roles")
+	}
+	priest, werewolf := byRole["Priest"][0], byRole["Werewolf"][0]
+	victim, villager := byRole["Villager"][0], byRole["Villager"][1]
+
+	werewolf.voteForPlayer(victim.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if found, _, _ := villager.p().Has("#day-priest-section"); found {
+		ctx.logger.LogDB("FAIL: villager sees the priest's holy water")
+		t.Errorf("Only the Priest should see the holy water")
+	}
+	priest.priestThrowAt(werewolf.Name)
+
+	// The last werewolf burns and the village wins
+	if !villager.isGameFinished() {
+		ctx.logger.LogDB("FAIL: game not finished after the werewolf burned")
+		t.Fatalf("Game should end once the last werewolf burned. Content: %s", villager.getGameContent())
+	}
+	if winner := villager.getWinner(); winner != "villagers" {
+		ctx.logger.LogDB("FAIL: wrong winner")
+		t.Errorf("Villagers should win, got: %s", winner)
+	}
+	entry := "Priest " + priest.Name + " threw holy water at " + werewolf.Name + ", who burned"
+	if !villager.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: holy water missing from history")
+		t.Errorf("Everyone should see %q in history, got: %s", entry, villager.getHistoryText())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// With runoffs a tied day vote is not the end of the day: the village votes again, on the
+// tied players only. The runoff is a vote of its own type, so every player votes afresh; a
+// second tie ends the day without an elimination.
+
+// runoffsEnabled reports whether the game settles a tied day vote with a runoff.
+func runoffsEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT runoffs FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// runoffCandidates returns the players in the given day's runoff; empty when there was none.
+func runoffCandidates(db *sqlx.DB, gameID int64, round int) map[int64]bool {
+	var ids []int64
+	db.Select(&ids, `SELECT actor_player_id FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		gameID, round, ActionDayRunoff)
+	candidates := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		candidates[id] = true
+	}
+	return candidates
+}
+
+// dayVoteAction is the action type the given day's votes are cast with: the runoff's once
+// one has been opened.
+func dayVoteAction(db *sqlx.DB, gameID int64, round int) string {
+	if len(runoffCandidates(db, gameID, round)) > 0 {
+		return ActionDayRunoffVote
+	}
+	return ActionDaySelectKill
+}
+
+// openRunoff puts the tied players into a runoff, in the resolution's transaction; each gets
+// a public history entry.
+func (h *Hub) openRunoff(tx *sqlx.Tx, game *Game, tied []int64) error {
+	for _, id := range tied {
+		name := h.playerName(id)
+		desc := fmt.Sprintf("Day %d: %s is tied for the most votes and goes into a runoff", game.Round, name)
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+			VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+			game.ID, game.Round, id, ActionDayRunoff, id, VisibilityPublic, desc, "hist_day_runoff", histArgs(game.Round, name)); err != nil {
+			return fmt.Errorf("record runoff candidate: %w", err)
+		}
+	}
+	return nil
+}
+
+// handleWSToggleRunoffs switches runoffs on or off in the lobby; kept for the next game.
+func handleWSToggleRunoffs(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleRunoffs: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET runoffs = NOT runoffs WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleRunoffs: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_runoffs"))
+		return
+	}
+	h.logf("Day runoffs toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Runoff Tests
+// ============================================================================
+
+func TestTiedDayVoteGoesToRunoff(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf1", "Wolf2", "V1", "V2", "V3", "V4"},
+		[]string{RoleWerewolf, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf1, wolf2, v1, v2, v3, v4 := ids[0], ids[1], ids[2], ids[3], ids[4], ids[5]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET runoffs = 1 WHERE rowid = ?", game.ID)
+	wolfID, v1ID := strconv.FormatInt(wolf1, 10), strconv.FormatInt(v1, 10)
+
+	// 2 votes for Wolf1, 2 for V1, 1 for V2 and a pass: a tie at the top
+	ctx.sendWS(v2, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	ctx.sendWS(v3, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	ctx.sendWS(wolf1, WSMessage{Action: "day_vote", TargetPlayerID: v1ID})
+	ctx.sendWS(wolf2, WSMessage{Action: "day_vote", TargetPlayerID: v1ID})
+	ctx.sendWS(v1, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	ctx.sendWS(v4, WSMessage{Action: "day_pass"})
+	ctx.sendWS(v4, WSMessage{Action: "day_end_vote"})
+
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("a tie should go to a runoff instead of ending the day, got %q", status)
+	}
+	if c := runoffCandidates(ctx.app.db, game.ID, 1); len(c) != 2 || !c[wolf1] || !c[v1] {
+		t.Fatalf("the runoff should be between the two tied players, got %v", c)
+	}
+	buf, err := getGameComponent(ctx.hub(), v4, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="runoff-note"`) || strings.Contains(buf.String(), `id="day-vote-form-`+strconv.FormatInt(v2, 10)+`"`) {
+		t.Fatalf("the day screen should offer only the tied players (err: %v)", err)
+	}
+
+	ctx.sendWS(v1, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	if n := ctx.countActions(ActionDayRunoffVote); n != 0 {
+		t.Fatalf("only tied players can be voted on in the runoff, got %d votes", n)
+	}
+	for _, id := range []int64{v1, v2, v3, v4} {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	}
+	ctx.sendWS(wolf1, WSMessage{Action: "day_vote", TargetPlayerID: v1ID})
+	ctx.sendWS(wolf2, WSMessage{Action: "day_vote", TargetPlayerID: v1ID})
+	ctx.sendWS(v4, WSMessage{Action: "day_end_vote"})
+
+	if ctx.isPlayerAlive(wolf1) {
+		t.Error("the runoff's majority should eliminate")
+	}
+	if h := ctx.historyFor(wolf2); !strings.Contains(h, "goes into a runoff") || !strings.Contains(h, "in the runoff") {
+		t.Errorf("the runoff should be public, got: %q", h)
+	}
+}
+
+func TestSecondTieEndsTheDay(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf1", "Wolf2", "V1", "V2", "V3", "V4"},
+		[]string{RoleWerewolf, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf1, v1 := ids[0], ids[2]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET runoffs = 1 WHERE rowid = ?", game.ID)
+
+	for round := 0; round < 2; round++ {
+		for i, id := range ids {
+			target := wolf1
+			if i%2 == 0 {
+				target = v1
+			}
+			ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(target, 10)})
+		}
+		ctx.sendWS(ids[3], WSMessage{Action: "day_end_vote"})
+	}
+
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("a tied runoff should end the day, got %q", status)
+	}
+	if !ctx.isPlayerAlive(wolf1) || !ctx.isPlayerAlive(v1) {
+		t.Error("nobody should be eliminated after a tied runoff")
+	}
+}
+
+func TestRunoffInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a tied day vote settled by a runoff ===")
+
+	// Setup: 1 werewolf + 4 villagers = 5 players, runoffs on
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"RO1", "RO2", "RO3", "RO4", "RO5"},
+		[]string{"runoffs-toggle"}, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	werewolves, villagers := killFirstVillagerAtNight(ctx, players)
+	werewolf, suspect, v3, v4 := werewolves[0], villagers[1], villagers[2], villagers[3]
+
+	// two votes each: a tie between the werewolf and the suspect
+	suspect.dayVoteForPlayer(werewolf.Name)
+	v3.dayVoteForPlayer(werewolf.Name)
+	werewolf.dayVoteForPlayer(suspect.Name)
+	v4.dayVoteForPlayer(suspect.Name)
+
+	if _, err := v3.p().Element("#runoff-note"); err != nil {
+		ctx.logger.LogDB("FAIL: no runoff")
+		t.Fatalf("the tie should go to a runoff: %v", err)
+	}
+	targets := v3.getDayVoteButtons()
+	if len(targets) != 2 || !slices.Contains(targets, werewolf.Name) || !slices.Contains(targets, suspect.Name) {
+		t.Fatalf("the runoff should be between the tied players only, got %v", targets)
+	}
+
+	werewolf.dayVoteForPlayer(suspect.Name)
+	suspect.dayVoteForPlayer(werewolf.Name)
+	v3.dayVoteForPlayer(werewolf.Name)
+	v4.dayVoteForPlayer(werewolf.Name)
+
+	if !v4.isGameFinished() || v4.getWinner() != "villagers" {
+		ctx.logger.LogDB("FAIL: runoff did not eliminate")
+		t.Errorf("the runoff's majority should eliminate the werewolf, got: %s", v4.getGameContent())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
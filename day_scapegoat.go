@@ -0,0 +1,269 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type ScapegoatDayData struct {
+	ScapegoatPending     bool // a blamed Scapegoat still has to choose tomorrow's voters
+	IsTheScapegoat       bool
+	ScapegoatAllowed     int
+	ScapegoatTargetCards []PlayerCardData
+}
+
+func buildScapegoatDayData(db *sqlx.DB, game *Game, player Player, aliveTargets []Player, lang string) ScapegoatDayData {
+	if !scapegoatChoicePending(db, game.ID, game.Round) {
+		return ScapegoatDayData{}
+	}
+	d := ScapegoatDayData{ScapegoatPending: true}
+	if player.RoleName != "Scapegoat" || player.IsAlive {
+		return d
+	}
+	d.IsTheScapegoat = true
+	allowed := scapegoatAllowed(db, game.ID, game.Round)
+	d.ScapegoatAllowed = len(allowed)
+	for _, t := range aliveTargets {
+		card := makePlayerCard(t, lang)
+		card.Selectable = true
+		card.Lover = isViewerLover(t, player)
+		card.Selected = allowed[t.PlayerID]
+		d.ScapegoatTargetCards = append(d.ScapegoatTargetCards, card)
+	}
+	return d
+}
+
+// scapegoatChoicePending reports whether a Scapegoat blamed today has not yet chosen who may
+// vote tomorrow; the choice row stays pending (description ”) until then.
+func scapegoatChoicePending(db *sqlx.DB, gameID int64, round int) bool {
+	var n int
+	db.Get(&n, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='day' AND action_type=? AND description=''`,
+		gameID, round, ActionScapegoatApplyChoice)
+	return n > 0
+}
+
+// scapegoatAllowed returns the players the Scapegoat picked on the given day. Each pick is a
+// row with the chosen voter as actor, so the per-actor unique index allows any number of them.
+func scapegoatAllowed(db *sqlx.DB, gameID int64, round int) map[int64]bool {
+	var ids []int64
+	db.Select(&ids, `SELECT actor_player_id FROM game_action WHERE game_id=? AND round=? AND phase='day' AND action_type=?`,
+		gameID, round, ActionScapegoatAllowVote)
+	allowed := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		allowed[id] = true
+	}
+	return allowed
+}
+
+// scapegoatBarred returns the living players who may not vote on the given day because the
+// Scapegoat blamed the day before left them out.
+func scapegoatBarred(db *sqlx.DB, gameID int64, round int) map[int64]bool {
+	barred := make(map[int64]bool)
+	var chosen int
+	db.Get(&chosen, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='day' AND action_type=? AND description!=''`,
+		gameID, round-1, ActionScapegoatApplyChoice)
+	if chosen == 0 {
+		return barred
+	}
+	allowed := scapegoatAllowed(db, gameID, round-1)
+	var aliveIDs []int64
+	db.Select(&aliveIDs, `SELECT player_id FROM game_player WHERE game_id = ? AND is_alive = 1`, gameID)
+	for _, id := range aliveIDs {
+		if !allowed[id] {
+			barred[id] = true
+		}
+	}
+	return barred
+}
+
+// blamedScapegoat returns the living Scapegoat a tied day vote would be blamed on, 0 when
+// there is none or their power is gone.
+func blamedScapegoat(db *sqlx.DB, gameID int64) int64 {
+	var scapegoatID int64
+	db.Get(&scapegoatID, `
+SELECT g.player_id FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Scapegoat'
+LIMIT 1`, gameID)
+	if scapegoatID == 0 || powerDisabled(db, gameID, "Scapegoat") {
+		return 0
+	}
+	return scapegoatID
+}
+
+// blameScapegoat takes the fall for a tied day vote, in the resolution's transaction: the
+// living Scapegoat dies instead of nobody, and the day stays open until they choose who may
+// vote tomorrow. It returns the Scapegoat, or 0 when there is none to blame.
+func (h *Hub) blameScapegoat(tx *sqlx.Tx, game *Game) (int64, error) {
+	scapegoatID := blamedScapegoat(h.db, game.ID)
+	if scapegoatID == 0 {
+		return 0, nil
+	}
+
+	if _, err := tx.Exec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, scapegoatID); err != nil {
+		return 0, fmt.Errorf("eliminate scapegoat: %w", err)
+	}
+	name := h.playerName(scapegoatID)
+	desc := fmt.Sprintf("Day %d: The vote was tied — the Scapegoat %s took the blame and was eliminated", game.Round, name)
+	_, err := tx.Exec(`
+		INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, scapegoatID, ActionDayApplyKill, scapegoatID, VisibilityPublic, desc, "hist_scapegoat_blamed", histArgs(game.Round, name))
+	if err != nil {
+		return 0, fmt.Errorf("record scapegoat elimination: %w", err)
+	}
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description) VALUES (?, ?, 'day', ?, ?, ?, '')`,
+		game.ID, game.Round, scapegoatID, ActionScapegoatApplyChoice, VisibilityActor); err != nil {
+		return 0, fmt.Errorf("open scapegoat choice: %w", err)
+	}
+	return scapegoatID, nil
+}
+
+// afterScapegoat moves on from a committed blame: heartbreaks, the Hunter's shot or the end
+// of the game, otherwise the Scapegoat's choice.
+func (h *Hub) afterScapegoat(game *Game, scapegoatID int64) {
+	h.logf("Tied vote — Scapegoat '%s' was eliminated", h.playerName(scapegoatID))
+	h.maybeGenerateStory(game.ID, game.Round, "day", scapegoatID)
+
+	heartbroken := h.applyHeartbreaks(game, "day", []int64{scapegoatID})
+	h.inheritDoppelgangerRoles(game, "day")
+	h.promoteApprenticeSeer(game, "day")
+	h.awakenWildChildren(game, "day")
+
+	for _, deadID := range heartbroken {
+		if getRoleName(h.db, game.ID, deadID) == "Hunter" && !powerDisabled(h.db, game.ID, "Hunter") {
+			h.logf("Hunter '%s' died of heartbreak — waiting for revenge shot", h.playerName(deadID))
+			h.triggerBroadcast()
+			return
+		}
+	}
+
+	if h.checkWinConditions(game) {
+		return // Game ended
+	}
+
+	h.logf("Waiting for the Scapegoat to choose tomorrow's voters")
+	h.triggerBroadcast()
+}
+
+// hunterShotPending reports whether a dead Hunter still owes their revenge shot.
+func hunterShotPending(db *sqlx.DB, gameID int64) bool {
+	var n int
+	db.Get(&n, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 0 AND r.name = 'Hunter'
+AND NOT EXISTS (SELECT 1 FROM game_action a WHERE a.game_id = g.game_id AND a.actor_player_id = g.player_id AND a.action_type = ?)`,
+		gameID, ActionHunterApplyKill)
+	return n > 0 && !powerDisabled(db, gameID, "Hunter")
+}
+
+func handleWSScapegoatToggle(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSScapegoatToggle: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "day" || !scapegoatChoicePending(h.db, game.ID, game.Round) {
+		h.sendErrorToast(client.playerID, T(lang, "err_scapegoat_inactive"))
+		return
+	}
+	scapegoat, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSScapegoatToggle: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if scapegoat.RoleName != "Scapegoat" || scapegoat.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_scapegoat"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	if scapegoatAllowed(h.db, game.ID, game.Round)[targetID] {
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='day' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, targetID, ActionScapegoatAllowVote)
+		h.logf("Scapegoat '%s' took the vote from %d", scapegoat.Name, targetID)
+	} else {
+		h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'day', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, targetID, ActionScapegoatAllowVote, client.playerID, VisibilityActor)
+		h.logf("Scapegoat '%s' gave the vote to %d", scapegoat.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+func handleWSScapegoatConfirm(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSScapegoatConfirm: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "day" || !scapegoatChoicePending(h.db, game.ID, game.Round) {
+		h.sendErrorToast(client.playerID, T(lang, "err_scapegoat_inactive"))
+		return
+	}
+	scapegoat, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSScapegoatConfirm: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if scapegoat.RoleName != "Scapegoat" || scapegoat.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_scapegoat"))
+		return
+	}
+
+	var names []string
+	h.db.Select(&names, `
+SELECT p.name FROM game_action a
+JOIN player p ON p.rowid = a.actor_player_id
+JOIN game_player g ON g.game_id = a.game_id AND g.player_id = a.actor_player_id
+WHERE a.game_id = ? AND a.round = ? AND a.phase = 'day' AND a.action_type = ? AND g.is_alive = 1
+ORDER BY p.name`,
+		game.ID, game.Round, ActionScapegoatAllowVote)
+	if len(names) == 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_scapegoat_choose_first"))
+		return
+	}
+	voters := strings.Join(names, ", ")
+
+	desc := fmt.Sprintf("Day %d: You ruled that only %s may vote tomorrow", game.Round, voters)
+	_, err = h.db.Exec(`UPDATE game_action SET description=?, description_key=?, description_args=? WHERE game_id=? AND round=? AND phase='day' AND actor_player_id=? AND action_type=?`,
+		desc, "hist_scapegoat_choice", histArgs(game.Round, voters), game.ID, game.Round, client.playerID, ActionScapegoatApplyChoice)
+	if err != nil {
+		h.logError("handleWSScapegoatConfirm: record choice", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_scapegoat"))
+		return
+	}
+
+	h.logf("Scapegoat '%s' ruled that only %s may vote tomorrow", scapegoat.Name, voters)
+	DebugLog("handleWSScapegoatConfirm", "Scapegoat '%s' chose voters: %s", scapegoat.Name, voters)
+	LogDBState(h.db, "after scapegoat choice")
+
+	// a Hunter who died of heartbreak still shoots first, and ends the day themselves
+	if hunterShotPending(h.db, game.ID) {
+		h.triggerBroadcast()
+		return
+	}
+	h.transitionToNight(game)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Scapegoat Helpers
+// ============================================================================
+
+// scapegoatAllowVoter toggles a player the Scapegoat lets vote tomorrow.
+func (tp *TestPlayer) scapegoatAllowVoter(name string) {
+	tp.clickAndWait("[id^='scapegoat-toggle-form-'] .player-card[player-name='" + name + "']")
+	tp.logHTML("after scapegoat allowed " + name)
+}
+
+// ============================================================================
+// Scapegoat Tests
+// ============================================================================
+
+func TestScapegoatDiesOnTieAndChoosesVoters(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Goat", "V1", "V2", "V3", "V4"},
+		[]string{RoleWerewolf, RoleScapegoat, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf, goat, v1, v2, v3, v4 := ids[0], ids[1], ids[2], ids[3], ids[4], ids[5]
+
+	for _, id := range []int64{wolf, v1} {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	}
+	for _, id := range []int64{v2, v3} {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	}
+	ctx.sendWS(goat, WSMessage{Action: "day_pass"})
+	ctx.sendWS(v4, WSMessage{Action: "day_pass"})
+	ctx.sendWS(v3, WSMessage{Action: "day_end_vote"})
+
+	if ctx.isPlayerAlive(goat) {
+		t.Fatal("the Scapegoat should die on a tied vote")
+	}
+	if !ctx.isPlayerAlive(v1) || !ctx.isPlayerAlive(v2) {
+		t.Error("the tied players should survive")
+	}
+	if h := ctx.historyFor(wolf); !strings.Contains(h, "took the blame") {
+		t.Errorf("the blame should be public, got: %q", h)
+	}
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("the day should wait for the Scapegoat's choice, got %q", status)
+	}
+
+	// only the dead Scapegoat chooses
+	ctx.sendWS(wolf, WSMessage{Action: "scapegoat_toggle", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(goat, WSMessage{Action: "scapegoat_confirm"})
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatal("confirming without any voter should be rejected")
+	}
+	for _, id := range []int64{v1, v2, v3} {
+		ctx.sendWS(goat, WSMessage{Action: "scapegoat_toggle", TargetPlayerID: strconv.FormatInt(id, 10)})
+	}
+	ctx.sendWS(goat, WSMessage{Action: "scapegoat_toggle", TargetPlayerID: strconv.FormatInt(v3, 10)})
+	ctx.sendWS(goat, WSMessage{Action: "scapegoat_confirm"})
+
+	status, round, _ := ctx.gameState()
+	if status != "night" {
+		t.Fatalf("the choice should end the day, got %q", status)
+	}
+	if h := ctx.historyFor(goat); !strings.Contains(h, "only V1, V2 may vote tomorrow") {
+		t.Errorf("the Scapegoat should see the choice in history, got: %q", h)
+	}
+	if h := ctx.historyFor(v1); strings.Contains(h, "may vote tomorrow") {
+		t.Errorf("the choice row is actor-only, got: %q", h)
+	}
+
+	game, _ := ctx.hub().getGame()
+	silenced := silencedPlayers(ctx.app.db, game.ID, round)
+	for _, id := range []int64{wolf, v3, v4} {
+		if !silenced[id] {
+			t.Errorf("player %d was left out and should not vote tomorrow", id)
+		}
+	}
+	if silenced[v1] || silenced[v2] {
+		t.Error("the chosen players should keep their vote")
+	}
+}
+
+func TestTieWithoutScapegoatEliminatesNobody(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	wolf, v1, v2, v3 := ids[0], ids[1], ids[2], ids[3]
+
+	ctx.sendWS(wolf, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(v1, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(v2, WSMessage{Action: "day_pass"})
+	ctx.sendWS(v3, WSMessage{Action: "day_pass"})
+	ctx.sendWS(v3, WSMessage{Action: "day_end_vote"})
+
+	for _, id := range ids {
+		if !ctx.isPlayerAlive(id) {
+			t.Errorf("player %d should survive a tie with no Scapegoat", id)
+		}
+	}
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Errorf("the tie should end the day, got %q", status)
+	}
+}
+
+func TestScapegoatTakesBlameInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Scapegoat dies for a tied vote and picks tomorrow's voters ===")
+
+	// Setup: 1 werewolf + 1 scapegoat + 3 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"SG1", "SG2", "SG3", "SG4", "SG5"},
+		RoleWerewolf, RoleScapegoat, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Scapegoat"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) < 3 {
+		t.Fatal("Missing required roles")
+	}
+	scapegoat, werewolf, villagers := byRole["Scapegoat"][0], byRole["Werewolf"][0], byRole["Villager"]
+
+	werewolf.voteForPlayer(villagers[0].Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	// A 2-2 tie between the two remaining villagers
+	werewolf.dayVoteForPlayer(villagers[2].Name)
+	villagers[1].dayVoteForPlayer(villagers[2].Name)
+	villagers[2].dayVoteForPlayer(villagers[1].Name)
+	scapegoat.dayVoteForPlayer(villagers[1].Name)
+
+	entry := "the Scapegoat " + scapegoat.Name + " took the blame and was eliminated"
+	if !villagers[1].historyContains(entry) {
+		ctx.logger.LogDB("FAIL: scapegoat not blamed for the tie")
+		t.Fatalf("Everyone should see %q in history, got: %s", entry, villagers[1].getHistoryText())
+	}
+	if found, _, _ := villagers[1].p().Has("#scapegoat-waiting"); !found {
+		ctx.logger.LogDB("FAIL: village not waiting for the scapegoat")
+		t.Errorf("The village should wait for the Scapegoat's choice")
+	}
+
+	scapegoat.scapegoatAllowVoter(villagers[1].Name)
+	scapegoat.clickAndWait("#scapegoat-confirm-button")
+	waitForNightPhaseAll(ctx, []*TestPlayer{werewolf, villagers[1], villagers[2]})
+
+	choice := "You ruled that only " + villagers[1].Name + " may vote tomorrow"
+	if !scapegoat.historyContains(choice) {
+		ctx.logger.LogDB("FAIL: scapegoat choice missing from history")
+		t.Errorf("Scapegoat should see %q in history, got: %s", choice, scapegoat.getHistoryText())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
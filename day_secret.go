@@ -0,0 +1,110 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// With secret votes, day votes and passes are recorded with resolved visibility: nobody sees
+// who voted for whom until the day is over. The next night opens with a recap of the vote.
+
+// VoteRecapLine is one target of a finished day vote; Target is nil for the passes.
+type VoteRecapLine struct {
+	Target *Player
+	Votes  int // weighted, like the live count
+	Voters []VoterChip
+}
+
+// secretVotesEnabled reports whether the game hides day votes until the day resolves.
+func secretVotesEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT secret_votes FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// dayVoteVisibility is the visibility day votes and passes are recorded with.
+func dayVoteVisibility(db *sqlx.DB, gameID int64) string {
+	if secretVotesEnabled(db, gameID) {
+		return VisibilityResolved
+	}
+	return VisibilityPublic
+}
+
+// dayVoteRecap breaks down the given day's final vote (the runoff, if there was one), targets first in order of weighted
+// votes, passes last. It is nil when nobody voted.
+func dayVoteRecap(db *sqlx.DB, game *Game, round int, viewer Player, seerInvestigated map[int64]string) []VoteRecapLine {
+	var votes []GameAction
+	db.Select(&votes, `
+		SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+		FROM game_action
+		WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?
+		ORDER BY rowid`,
+		game.ID, round, dayVoteAction(db, game.ID, round))
+	if len(votes) == 0 {
+		return nil
+	}
+
+	var lines []VoteRecapLine
+	byTarget := map[int64]int{}
+	var passes *VoteRecapLine
+	for _, v := range votes {
+		weight := voteWeight(db, game.ID, getRoleName(db, game.ID, v.ActorPlayerID))
+		chip := VoterChip{Name: getPlayerName(db, v.ActorPlayerID), PlayerUID: v.ActorPlayerID, Weight: weight}
+		if v.TargetPlayerID == nil {
+			if passes == nil {
+				passes = &VoteRecapLine{}
+			}
+			passes.Votes += weight
+			passes.Voters = append(passes.Voters, chip)
+			continue
+		}
+		i, ok := byTarget[*v.TargetPlayerID]
+		if !ok {
+			i = len(lines)
+			byTarget[*v.TargetPlayerID] = i
+			lines = append(lines, VoteRecapLine{Target: getVisiblePlayer(db, game.ID, *v.TargetPlayerID, viewer, seerInvestigated)})
+		}
+		lines[i].Votes += weight
+		lines[i].Voters = append(lines[i].Voters, chip)
+	}
+	// equal counts keep the order they were first voted in
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].Votes > lines[j].Votes })
+	if passes != nil {
+		lines = append(lines, *passes)
+	}
+	return lines
+}
+
+// handleWSToggleSecretVotes switches secret day votes on or off in the lobby; kept for the
+// next game.
+func handleWSToggleSecretVotes(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleSecretVotes: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET secret_votes = NOT secret_votes WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleSecretVotes: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_secret_votes"))
+		return
+	}
+	h.logf("Secret day votes toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
+
+// nightVoteRecap is the recap of yesterday's vote shown at night, only for secret votes;
+// open votes were seen as they came in.
+func nightVoteRecap(db *sqlx.DB, game *Game, viewer Player, seerInvestigated map[int64]string) []VoteRecapLine {
+	if game.Round < 2 || !secretVotesEnabled(db, game.ID) {
+		return nil
+	}
+	return dayVoteRecap(db, game, game.Round-1, viewer, seerInvestigated)
+}
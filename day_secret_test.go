@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Secret Vote Tests
+// ============================================================================
+
+func TestSecretVotesRevealedAfterTheDay(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf1", "Wolf2", "V1", "V2", "V3", "V4"},
+		[]string{RoleWerewolf, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf1, v1, v2 := ids[0], ids[2], ids[3]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET secret_votes = 1 WHERE rowid = ?", game.ID)
+	wolfID := strconv.FormatInt(wolf1, 10)
+
+	ctx.sendWS(v1, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	if h := ctx.historyFor(v2); strings.Contains(h, "voted to eliminate") {
+		t.Errorf("a secret vote should not show up in the history during the day, got: %q", h)
+	}
+	buf, err := getGameComponent(ctx.hub(), v2, game, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	voterChip := `id="pc-voter-` + wolfID + `-` + strconv.FormatInt(v1, 10) + `"`
+	if strings.Contains(buf.String(), voterChip) {
+		t.Error("other players' votes should be hidden on the day screen")
+	}
+	buf, _ = getGameComponent(ctx.hub(), v1, game, "en")
+	if !strings.Contains(buf.String(), voterChip) {
+		t.Error("players should still see their own vote")
+	}
+
+	for _, id := range ids[1:] {
+		if id != v1 {
+			ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+		}
+	}
+	ctx.sendWS(wolf1, WSMessage{Action: "day_pass"})
+	ctx.sendWS(v1, WSMessage{Action: "day_end_vote"})
+	if ctx.isPlayerAlive(wolf1) {
+		t.Fatal("the secret vote should still eliminate")
+	}
+
+	if h := ctx.historyFor(v2); !strings.Contains(h, "V1 voted to eliminate Wolf1") {
+		t.Errorf("the votes should be revealed once the day is over, got: %q", h)
+	}
+	game, _ = ctx.hub().getGame()
+	buf, err = getGameComponent(ctx.hub(), v2, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="day-vote-recap"`) {
+		t.Errorf("the night should open with a recap of the vote (err: %v)", err)
+	}
+}
+
+func TestSecretVotesInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing day votes hidden until the night's recap ===")
+
+	// Setup: 1 werewolf + 4 villagers = 5 players, secret votes on
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"SV1", "SV2", "SV3", "SV4", "SV5"},
+		[]string{"secret-votes-toggle"}, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	werewolves, villagers := killFirstVillagerAtNight(ctx, players)
+	werewolf, voter, other1, other2 := werewolves[0], villagers[1], villagers[2], villagers[3]
+
+	if _, err := other1.p().Element("#secret-votes-note"); err != nil {
+		t.Errorf("the village should be told the votes are secret: %v", err)
+	}
+	voter.dayVoteForPlayer(werewolf.Name)
+	if c := other1.getDayVoteCount(werewolf.Name); c != "0" {
+		ctx.logger.LogDB("FAIL: secret vote counted on the card")
+		t.Errorf("another player should not see the vote on the card, got %s", c)
+	}
+	if strings.Contains(other1.dumpElement("#day-vote-section"), "pc-voter-chip") {
+		t.Error("another player should not see who voted")
+	}
+
+	// the rest pass, so the day ends without an elimination
+	passDayForAll([]*TestPlayer{other1, other2, werewolf})
+	waitForNightPhaseAll(ctx, []*TestPlayer{other1})
+
+	recap, err := other1.p().Element("#day-vote-recap")
+	if err != nil {
+		ctx.logger.LogDB("FAIL: no vote recap")
+		t.Fatalf("the night should open with the recap of the secret vote: %v", err)
+	}
+	if text, _ := recap.Text(); !strings.Contains(text, werewolf.Name) || !strings.Contains(text, voter.Name) {
+		t.Errorf("the recap should show who voted for whom, got %q", text)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
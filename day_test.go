@@ -1,8 +1,13 @@
 package main
 
 import (
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
 )
 
 // ============================================================================
@@ -82,23 +87,35 @@ func (tp *TestPlayer) isGameFinished() bool {
 
 // getWinner returns the winner if game is finished
 func (tp *TestPlayer) getWinner() string {
-	found, _, _ := tp.p().Has(".win-seal-villagers")
-	if found {
-		return "villagers"
+	for _, winner := range []string{"villagers", "werewolves", "lovers", "tanner", "serial_killer", "white_werewolf", "piper"} {
+		if found, _, _ := tp.p().Has(".win-seal-" + winner); found {
+			return winner
+		}
 	}
+	return ""
+}
 
-	found, _, _ = tp.p().Has(".win-seal-werewolves")
-	if found {
-		return "werewolves"
+// passDayForAll has every listed player pass the day vote, then ends the vote.
+func passDayForAll(players []*TestPlayer) {
+	for _, p := range players {
+		p.clickAndWait("#day-pass-btn")
+	}
+	if has, endVoteBtn, _ := players[0].p().Has("#day-end-vote-btn:not([disabled])"); has {
+		players[0].clickElementAndWait(endVoteBtn)
 	}
-
-	return ""
 }
 
 // setupDayPhaseGame creates a game, starts night, werewolves kill someone, transitions to day
 func setupDayPhaseGame(ctx *TestContext, browser *TestBrowser, numVillagers, numWerewolves int) ([]*TestPlayer, []*TestPlayer, []*TestPlayer) {
 	players := setupNightPhaseGame(ctx, browser, numVillagers, numWerewolves)
-	werewolves, villagers := findPlayersByRole(players)
+	werewolves, villagers := killFirstVillagerAtNight(ctx, players)
+	return players, werewolves, villagers
+}
+
+// killFirstVillagerAtNight has the pack eat the first villager and waits for the day. It
+// returns the players grouped like findPlayersByRole; the victim is villagers[0].
+func killFirstVillagerAtNight(ctx *TestContext, players []*TestPlayer) (werewolves []*TestPlayer, villagers []*TestPlayer) {
+	werewolves, villagers = findPlayersByRole(players)
 
 	// All werewolves vote for the first villager to transition to day
 	targetName := villagers[0].Name
@@ -118,7 +135,7 @@ func setupDayPhaseGame(ctx *TestContext, browser *TestBrowser, numVillagers, num
 
 	ctx.logger.LogDB("after night kill, should be in day phase")
 
-	return players, werewolves, villagers
+	return werewolves, villagers
 }
 
 // ============================================================================
@@ -617,3 +634,177 @@ func TestCannotVoteForDeadPlayer(t *testing.T) {
 
 	ctx.logger.Debug("=== Test passed ===")
 }
+
+func TestDayVoteResolvesOnce(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+	for _, voter := range []int64{ids[0], ids[2], ids[3]} {
+		ctx.app.db.MustExec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility)
+			VALUES (?, 1, 'day', ?, ?, ?, ?)`, game.ID, voter, ActionDaySelectKill, ids[1], VisibilityPublic)
+	}
+
+	// two moves that both read the game before either resolved the vote, as two end-vote
+	// messages arriving together do
+	var wg sync.WaitGroup
+	for range 2 {
+		stale := *game
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h := ctx.hub()
+			h.moveMu.Lock()
+			defer h.moveMu.Unlock()
+			h.resolveDayVotes(&stale)
+		}()
+	}
+	wg.Wait()
+
+	var eliminations int
+	ctx.app.db.Get(&eliminations, "SELECT COUNT(*) FROM game_action WHERE game_id = ? AND action_type = ?", game.ID, ActionDayApplyKill)
+	if eliminations != 1 {
+		t.Errorf("V1 should be eliminated once, got %d eliminations", eliminations)
+	}
+	if after, _ := ctx.hub().getGame(); after.Status != "night" || after.Round != 2 {
+		t.Errorf("the game should move on to night 2 once, got %s %d", after.Status, after.Round)
+	}
+}
+
+func TestDayVoteSendsOnlyTheTally(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "V1", "V2"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager})
+	h := ctx.hub()
+	v1 := &Client{hub: h, conn: &websocket.Conn{}, playerID: ids[1], send: make(chan hubMsg, 4)}
+	v2 := &Client{hub: h, conn: &websocket.Conn{}, playerID: ids[2], send: make(chan hubMsg, 4)}
+	h.mu.Lock()
+	h.addClient(v1)
+	h.addClient(v2)
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		h.removeClient(v1)
+		h.removeClient(v2)
+		h.mu.Unlock()
+	}()
+
+	h.broadcastGameUpdate()
+	<-v1.send
+	page := (<-v2.send).data
+	handleWSDayVote(v1, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(ids[0], 10)})
+	if own := string((<-v1.send).data); !strings.Contains(own, `<section id="day-vote-section" hx-swap-oob="morph">`) || !strings.Contains(own, "pc-selected") {
+		t.Errorf("the voter should get their vote section with the choice selected")
+	}
+
+	var tally string
+	select {
+	case msg := <-v2.send:
+		tally = string(msg.data)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the vote should reach the other player")
+	}
+	for _, part := range []string{
+		`id="pc-count-` + strconv.FormatInt(ids[0], 10) + `" hx-swap-oob="true"><span class="pc-count">1</span>`,
+		`hx-swap-oob="innerHTML:#pc-voters-` + strconv.FormatInt(ids[0], 10) + `"><span class="pc-voter-chip"`,
+		`id="day-end-vote-btn" hx-swap-oob="true"`,
+	} {
+		if !strings.Contains(tally, part) {
+			t.Errorf("the tally should contain %s", part)
+		}
+	}
+	for _, part := range []string{`id="game-content"`, `id="day-vote-section"`, `id="sidebar"`, "pc-seal"} {
+		if strings.Contains(tally, part) {
+			t.Errorf("the tally should leave %s alone", part)
+		}
+	}
+	if len(tally) >= len(page)/4 {
+		t.Errorf("the tally should be much smaller than the page: %d vs %d bytes", len(tally), len(page))
+	}
+
+	// once the vote is over the page changes more than the votes: the full page goes out
+	ctx.app.db.MustExec("UPDATE game SET status = 'night', round = 2 WHERE rowid = (SELECT MAX(rowid) FROM game)")
+	h.broadcastDayVotes()
+	if update := string((<-v2.send).data); !strings.Contains(update, `id="game-content"`) {
+		t.Errorf("after the day the whole page should be sent")
+	}
+}
+
+func TestDayVoteSendsOnlyItsHistoryEntry(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "V1", "V2"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager})
+	h := ctx.hub()
+	v1 := &Client{hub: h, conn: &websocket.Conn{}, playerID: ids[1], send: make(chan hubMsg, 4)}
+	v2 := &Client{hub: h, conn: &websocket.Conn{}, playerID: ids[2], send: make(chan hubMsg, 4)}
+	h.mu.Lock()
+	h.addClient(v1)
+	h.addClient(v2)
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		h.removeClient(v1)
+		h.removeClient(v2)
+		h.mu.Unlock()
+	}()
+	next := func(c *Client) string {
+		t.Helper()
+		select {
+		case msg := <-c.send:
+			return string(msg.data)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no message for player %d", c.playerID)
+			return ""
+		}
+	}
+	vote := func(c *Client, target int64) {
+		handleWSDayVote(c, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(target, 10)})
+		next(c) // their own vote section
+	}
+
+	// the first entry comes with the whole history, which was hidden while empty
+	vote(v1, ids[0])
+	next(v1)
+	if update := next(v2); !strings.Contains(update, `id="history-bar"`) || !strings.Contains(update, "V1 voted to eliminate Wolf") {
+		t.Errorf("the first vote should send the history with its entry")
+	}
+
+	vote(v2, ids[0])
+	next(v2)
+	update := next(v1)
+	if !strings.Contains(update, `hx-swap-oob="beforeend:#history-bar"`) || !strings.Contains(update, "V2 voted to eliminate Wolf") {
+		t.Errorf("the vote should append its history entry")
+	}
+	for _, part := range []string{`id="history-bar"`, "V1 voted to eliminate Wolf", `id="narrator-panel"`} {
+		if strings.Contains(update, part) {
+			t.Errorf("the vote update should leave %s alone", part)
+		}
+	}
+
+	var entryID int64
+	ctx.app.db.Get(&entryID, "SELECT rowid FROM game_action WHERE actor_player_id = ? AND action_type = ?", ids[2], ActionDaySelectKill)
+	entry := `id="history-entry-` + strconv.FormatInt(entryID, 10) + `"`
+	vote(v2, ids[1])
+	next(v2)
+	if update := next(v1); !strings.Contains(update, entry+` hx-swap-oob="true"`) || !strings.Contains(update, "V2 voted to eliminate V1") {
+		t.Errorf("a changed vote should replace its history entry")
+	}
+
+	vote(v2, ids[1])
+	next(v2)
+	if update := next(v1); !strings.Contains(update, entry+` hx-swap-oob="delete"`) {
+		t.Errorf("a retracted vote should remove its history entry")
+	}
+}
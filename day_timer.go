@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// dayTimerChoices are the day lengths the lobby offers, in seconds; 0 switches the timer off.
+var dayTimerChoices = []int{0, 180, 300, 600}
+
+// dayTimer counts one day's discussion and vote down, keyed by game and round like nightTimer.
+type dayTimer struct {
+	gameID   int64
+	round    int
+	deadline time.Time
+	stop     chan struct{}
+}
+
+type DayTimerChoice struct {
+	Seconds  int
+	Minutes  int // the lobby lists day lengths in minutes
+	Selected bool
+}
+
+// dayTimerSeconds returns the game's day length in seconds, 0 when the timer is off.
+func dayTimerSeconds(db *sqlx.DB, gameID int64) int {
+	var seconds int
+	db.Get(&seconds, "SELECT day_timer FROM game WHERE rowid = ?", gameID)
+	return seconds
+}
+
+func dayTimerOptions(db *sqlx.DB, gameID int64) []DayTimerChoice {
+	current := dayTimerSeconds(db, gameID)
+	options := make([]DayTimerChoice, 0, len(dayTimerChoices))
+	for _, s := range dayTimerChoices {
+		options = append(options, DayTimerChoice{Seconds: s, Minutes: s / 60, Selected: s == current})
+	}
+	return options
+}
+
+// startDayTimer arms the countdown for the game's current day, replacing any earlier one.
+// It does nothing when the game has no day timer, or a narrator who sets the pace.
+func (h *Hub) startDayTimer(gameID int64, round int) {
+	seconds := dayTimerSeconds(h.db, gameID)
+	if seconds <= 0 || narratorModeEnabled(h.db, gameID) {
+		return
+	}
+	h.armDayTimer(gameID, round, time.Duration(seconds)*time.Second)
+}
+
+// armDayTimer starts a day countdown of the given length, like armNightTimer.
+func (h *Hub) armDayTimer(gameID int64, round int, d time.Duration) {
+	t := &dayTimer{gameID: gameID, round: round, deadline: time.Now().Add(d), stop: make(chan struct{})}
+
+	h.timerMu.Lock()
+	if h.dayTimer != nil {
+		close(h.dayTimer.stop)
+	}
+	h.dayTimer = t
+	h.timerMu.Unlock()
+
+	h.logf("Day %d timer started: %s", round, formatCountdown(d))
+	h.wg.Add(1)
+	go h.runDayTimer(t)
+}
+
+// runDayTimer pushes the countdown every second and forces the vote when it runs out.
+// It stops on its own once the day ends some other way.
+func (h *Hub) runDayTimer(t *dayTimer) {
+	defer h.wg.Done()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			game, err := h.getGame()
+			if err != nil || game.ID != t.gameID || game.Status != "day" || game.Round != t.round {
+				return
+			}
+			left := time.Until(t.deadline)
+			if left <= 0 {
+				h.moveMu.Lock()
+				h.expireDay(t.gameID, t.round)
+				h.moveMu.Unlock()
+				return
+			}
+			h.pushDayCountdown(left)
+		}
+	}
+}
+
+// dayTimeLeft returns the countdown shown on the day screen, "" when the day has no timer.
+func (h *Hub) dayTimeLeft(game *Game) string {
+	h.timerMu.Lock()
+	defer h.timerMu.Unlock()
+	t := h.dayTimer
+	if t == nil || t.gameID != game.ID || t.round != game.Round {
+		return ""
+	}
+	return formatCountdown(time.Until(t.deadline))
+}
+
+// pushDayCountdown sends every client the updated countdown as an out-of-band swap.
+func (h *Hub) pushDayCountdown(left time.Duration) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, client := range h.clients {
+		lang := client.lang
+		if l, ok := h.playerLang[client.playerID]; ok {
+			lang = l
+		}
+		msg := fmt.Sprintf(`<span id="day-timer" class="night-timer" hx-swap-oob="true">%s</span>`, T(lang, "day_timer_left", formatCountdown(left)))
+		h.queue(client, hubMsg{data: []byte(msg)})
+	}
+}
+
+// expireDay closes a day whose timer ran out. When the day goes on (a runoff, a trial) the
+// next stage gets a fresh countdown.
+func (h *Hub) expireDay(gameID int64, round int) {
+	h.endDay(gameID, round, false)
+}
+
+// endDay closes the day's vote early, because its timer ran out or the host closed it. The
+// vote resolves with the votes cast so far, as if End Vote had been pressed (no votes means no
+// elimination); a trial is judged on the verdicts in. A Hunter's shot, a Scapegoat's choice or
+// last words are still waited for.
+func (h *Hub) endDay(gameID int64, round int, byHost bool) {
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("endDay: getGame", err)
+		return
+	}
+	if game.ID != gameID || game.Status != "day" || game.Round != round {
+		return
+	}
+	if hunterShotPending(h.db, game.ID) || scapegoatChoicePending(h.db, game.ID, game.Round) || lastWordsHeld(h.db, game) {
+		h.logf("Day %d vote could not close while the day waits on a player", game.Round)
+		return
+	}
+
+	desc, key := "Day %d: Time ran out and the vote was closed", "hist_day_timed_out"
+	if byHost {
+		desc, key = "Day %d: The host closed the vote", "hist_day_closed_by_host"
+	}
+	h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description, description_key, description_args)
+		SELECT ?, ?, 'day', player_id, ?, ?, ?, ?, ? FROM game_player WHERE game_id = ? AND is_alive = 1 LIMIT 1`,
+		game.ID, game.Round, ActionDayTimedOut, VisibilityPublic, fmt.Sprintf(desc, game.Round), key, histArgs(game.Round), game.ID)
+	h.logf("Day %d vote closed early", game.Round)
+
+	if accused := trialAccused(h.db, game); accused != 0 {
+		h.resolveTrial(game, accused, verdictVoters(h.db, game, accused))
+	} else {
+		h.resolveDayVotes(game)
+	}
+
+	if next, err := h.getGame(); err == nil && next.ID == game.ID && next.Status == "day" && next.Round == game.Round {
+		h.startDayTimer(game.ID, game.Round)
+	}
+	h.triggerBroadcast()
+}
+
+// handleWSSetDayTimer picks the day length in the lobby; it applies to every day of the game.
+func handleWSSetDayTimer(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSetDayTimer: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+
+	seconds, err := strconv.Atoi(msg.Seconds)
+	valid := false
+	for _, s := range dayTimerChoices {
+		valid = valid || (err == nil && s == seconds)
+	}
+	if !valid {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_day_timer"))
+		return
+	}
+
+	if _, err := h.db.Exec("UPDATE game SET day_timer = ? WHERE rowid = ?", seconds, game.ID); err != nil {
+		h.logError("handleWSSetDayTimer: db.Exec", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_day_timer"))
+		return
+	}
+	h.logf("Day timer set to %ds", seconds)
+	h.triggerBroadcast()
+}
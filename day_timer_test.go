@@ -0,0 +1,126 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Day Timer Tests
+// ============================================================================
+
+func TestSetDayTimerInLobby(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"P1", "P2"}, []string{RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(ids[0], WSMessage{Action: "set_day_timer", Seconds: "300"})
+	if s := dayTimerSeconds(ctx.app.db, game.ID); s != 300 {
+		t.Fatalf("the day timer should be 300s, got %d", s)
+	}
+	ctx.sendWS(ids[0], WSMessage{Action: "set_day_timer", Seconds: "45"})
+	if s := dayTimerSeconds(ctx.app.db, game.ID); s != 300 {
+		t.Errorf("a length the lobby does not offer should be rejected, got %d", s)
+	}
+}
+
+func TestDayTimerExpiryClosesTheVote(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf1", "Wolf2", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	v1, v2, v3 := ids[2], ids[3], ids[4]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET day_timer = 180 WHERE rowid = ?", game.ID)
+
+	ctx.hub().startDayTimer(game.ID, 1)
+	buf, err := getGameComponent(ctx.hub(), v1, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="day-timer"`) {
+		t.Fatalf("the day screen should show the countdown (err: %v)", err)
+	}
+
+	// three of five villagers agree, but nobody presses End Vote
+	for _, id := range []int64{v1, v2, v3} {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(ids[0], 10)})
+	}
+	ctx.hub().expireDay(game.ID, 1)
+
+	if ctx.isPlayerAlive(ids[0]) {
+		t.Error("the votes cast should still lynch when the timer runs out")
+	}
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("the day should end when the timer runs out, got %q", status)
+	}
+	if !strings.Contains(ctx.historyFor(v1), "Time ran out") {
+		t.Errorf("the history should note the timeout, got %q", ctx.historyFor(v1))
+	}
+
+	// a timer firing for a day that already ended changes nothing
+	ctx.hub().expireDay(game.ID, 1)
+	if status, round, _ := ctx.gameState(); status != "night" || round != 2 {
+		t.Errorf("a stale timer should be ignored, got %s %d", status, round)
+	}
+}
+
+func TestDayTimerExpiryWithoutVotes(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf1", "Wolf2", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET day_timer = 180 WHERE rowid = ?", game.ID)
+
+	ctx.hub().expireDay(game.ID, 1)
+
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("a silent day should still end, got %q", status)
+	}
+	for _, id := range ids {
+		if !ctx.isPlayerAlive(id) {
+			t.Errorf("nobody should be eliminated without votes, but player %d died", id)
+		}
+	}
+}
+
+func TestDayTimerCountsDownInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the day countdown set in the lobby ===")
+
+	// Setup: 1 werewolf + 3 villagers = 4 players, three minute days
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"DT1", "DT2", "DT3", "DT4"},
+		[]string{"day-timer-180"}, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	_, villagers := killFirstVillagerAtNight(ctx, players)
+	watcher := villagers[1]
+
+	timer, err := watcher.p().Element("#day-timer")
+	if err != nil {
+		ctx.logger.LogDB("FAIL: no day countdown")
+		t.Fatalf("the day should show the countdown: %v", err)
+	}
+	first, _ := timer.Text()
+	if !strings.HasSuffix(first, "left") {
+		t.Errorf("the countdown should say how much time is left, got %q", first)
+	}
+	if err := watcher.waitUntilCondition(`() => { const el = document.querySelector('#day-timer'); return el && el.textContent.trim() !== '`+first+`'; }`, "countdown ticks"); err != nil {
+		t.Errorf("the countdown should tick down: %v", err)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
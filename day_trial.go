@@ -0,0 +1,320 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// In a game with trials the day vote does not eliminate anyone by itself: its front-runner is
+// put on trial. The accused gets to speak in their defense, then every other voter answers
+// guilty or innocent and a majority of guilty votes (by vote weight) eliminates them.
+const (
+	DayStageDefense = "defense" // the accused has not spoken yet
+	DayStageVerdict = "verdict" // the village is voting guilty or innocent
+)
+
+const trialMaxDefense = 500
+
+type TrialDayData struct {
+	TrialStage      string // DayStageDefense or DayStageVerdict
+	Accused         *Player
+	IsAccused       bool
+	Defense         string // what the accused said; "" before they spoke or when they kept quiet
+	CanJudge        bool   // the viewer may still cast a verdict vote
+	HasJudged       bool
+	VerdictCount    int // verdict votes cast so far
+	VerdictExpected int
+}
+
+// trialsEnabled reports whether the game's day vote puts its front-runner on trial.
+func trialsEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT trials FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// trialAccused returns the player on trial today, 0 when there is none.
+func trialAccused(db *sqlx.DB, game *Game) int64 {
+	var accused int64
+	db.Get(&accused, `SELECT actor_player_id FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, ActionDayTrial)
+	return accused
+}
+
+// trialStage returns DayStageDefense or DayStageVerdict while a trial is under way, "" otherwise.
+func trialStage(db *sqlx.DB, game *Game) string {
+	if trialAccused(db, game) == 0 {
+		return ""
+	}
+	var spoke int
+	db.Get(&spoke, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, ActionDayDefense)
+	if spoke > 0 {
+		return DayStageVerdict
+	}
+	return DayStageDefense
+}
+
+// verdictVoters returns the players who judge the accused: the living voters of the day
+// except the accused themselves.
+func verdictVoters(db *sqlx.DB, game *Game, accused int64) []Player {
+	var alive []Player
+	db.Select(&alive, `
+		SELECT g.rowid as id, g.player_id as player_id, p.name as name, IFNULL(r.name, '') as role_name
+		FROM game_player g
+		JOIN player p ON g.player_id = p.rowid
+		LEFT JOIN role r ON g.role_id = r.rowid
+		WHERE g.game_id = ? AND g.is_alive = 1`, game.ID)
+	silenced := silencedPlayers(db, game.ID, game.Round)
+	var voters []Player
+	for _, p := range alive {
+		if p.PlayerID != accused && !silenced[p.PlayerID] {
+			voters = append(voters, p)
+		}
+	}
+	return voters
+}
+
+func buildTrialDayData(db *sqlx.DB, game *Game, player Player, seerInvestigated map[int64]string) TrialDayData {
+	accused := trialAccused(db, game)
+	if accused == 0 {
+		return TrialDayData{}
+	}
+	d := TrialDayData{
+		TrialStage: trialStage(db, game),
+		Accused:    getVisiblePlayer(db, game.ID, accused, player, seerInvestigated),
+		IsAccused:  accused == player.PlayerID,
+	}
+	var args string
+	db.Get(&args, `SELECT description_args FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, ActionDayDefense)
+	if parts := strings.Split(args, "\t"); len(parts) == 3 {
+		d.Defense = parts[2]
+	}
+
+	voters := verdictVoters(db, game, accused)
+	d.VerdictExpected = len(voters)
+	db.Get(&d.VerdictCount, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, ActionDayVerdict)
+	var judged int
+	db.Get(&judged, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ? AND actor_player_id = ?`,
+		game.ID, game.Round, ActionDayVerdict, player.PlayerID)
+	d.HasJudged = judged > 0
+	for _, v := range voters {
+		if v.PlayerID == player.PlayerID && !d.HasJudged && d.TrialStage == DayStageVerdict {
+			d.CanJudge = true
+		}
+	}
+	return d
+}
+
+// openTrial puts the day vote's front-runner on trial, in the resolution's transaction.
+func (h *Hub) openTrial(tx *sqlx.Tx, game *Game, accused int64) error {
+	name := h.playerName(accused)
+	desc := fmt.Sprintf("Day %d: %s was put on trial", game.Round, name)
+	_, err := tx.Exec(`
+		INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, accused, ActionDayTrial, accused, VisibilityPublic, desc, "hist_day_trial", histArgs(game.Round, name))
+	if err != nil {
+		return fmt.Errorf("record trial: %w", err)
+	}
+	return nil
+}
+
+// handleWSDayDefense records the accused's defense and opens the verdict vote. The defense
+// may be empty: the accused can rest their case without a word.
+func handleWSDayDefense(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSDayDefense: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "day" || trialStage(h.db, game) != DayStageDefense {
+		h.sendErrorToast(client.playerID, T(lang, "err_no_defense_due"))
+		return
+	}
+	if trialAccused(h.db, game) != client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_accused_defends"))
+		return
+	}
+
+	// history args are tab-separated, so the defense is kept to a single line
+	defense := strings.Join(strings.Fields(msg.Message), " ")
+	if len(defense) > trialMaxDefense {
+		h.sendErrorToast(client.playerID, T(lang, "err_defense_too_long"))
+		return
+	}
+	name := h.playerName(client.playerID)
+	desc := fmt.Sprintf("Day %d: %s rested their case without a word", game.Round, name)
+	key, args := "hist_day_defense_silent", histArgs(game.Round, name)
+	if defense != "" {
+		desc = fmt.Sprintf("Day %d: %s's defense: %s", game.Round, name, defense)
+		key, args = "hist_day_defense", histArgs(game.Round, name, defense)
+	}
+	_, err = h.db.Exec(`
+		INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionDayDefense, VisibilityPublic, desc, key, args)
+	if err != nil {
+		h.logError("handleWSDayDefense: db.Exec insert defense", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_defense"))
+		return
+	}
+
+	h.logf("'%s' gave their defense, the verdict vote is open", name)
+	h.triggerBroadcast()
+}
+
+// handleWSDayVerdict records a guilty (TargetPlayerID = the accused) or innocent (empty)
+// verdict. Verdicts cannot be changed; the last one in settles the trial.
+func handleWSDayVerdict(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSDayVerdict: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "day" || trialStage(h.db, game) != DayStageVerdict {
+		h.sendErrorToast(client.playerID, T(lang, "err_no_verdict_due"))
+		return
+	}
+	accused := trialAccused(h.db, game)
+	var voter *Player
+	voters := verdictVoters(h.db, game, accused)
+	for i := range voters {
+		if voters[i].PlayerID == client.playerID {
+			voter = &voters[i]
+		}
+	}
+	if voter == nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_cannot_judge"))
+		return
+	}
+
+	guilty := msg.TargetPlayerID != ""
+	accusedName := h.playerName(accused)
+	desc := fmt.Sprintf("Day %d: %s found %s innocent", game.Round, voter.Name, accusedName)
+	key := "hist_day_verdict_innocent"
+	var target any
+	if guilty {
+		desc = fmt.Sprintf("Day %d: %s found %s guilty", game.Round, voter.Name, accusedName)
+		key = "hist_day_verdict_guilty"
+		target = accused
+	}
+	result, err := h.db.Exec(`
+		INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionDayVerdict, target, VisibilityPublic, desc, key, histArgs(game.Round, voter.Name, accusedName))
+	if err != nil {
+		h.logError("handleWSDayVerdict: db.Exec insert verdict", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_vote"))
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_judged"))
+		return
+	}
+	h.logf("'%s' judged '%s' (guilty: %v)", voter.Name, accusedName, guilty)
+
+	var cast int
+	h.db.Get(&cast, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
+		game.ID, game.Round, ActionDayVerdict)
+	if cast < len(voters) {
+		h.triggerBroadcast()
+		return
+	}
+	h.resolveTrial(game, accused, voters)
+}
+
+// resolveTrial counts the verdict by vote weight: the accused is eliminated only when the
+// guilty votes are a majority of the judges' weight, otherwise they are acquitted.
+func (h *Hub) resolveTrial(game *Game, accused int64, voters []Player) {
+	guilty := map[int64]bool{}
+	var ids []int64
+	h.db.Select(&ids, `SELECT actor_player_id FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ? AND target_player_id IS NOT NULL`,
+		game.ID, game.Round, ActionDayVerdict)
+	for _, id := range ids {
+		guilty[id] = true
+	}
+	totalWeight, guiltyWeight := 0, 0
+	for _, v := range voters {
+		w := voteWeight(h.db, game.ID, v.RoleName)
+		totalWeight += w
+		if guilty[v.PlayerID] {
+			guiltyWeight += w
+		}
+	}
+
+	// the verdict is written in one transaction, and only while the day is still on
+	tx, ok := h.beginResolution(game)
+	if !ok {
+		return
+	}
+	defer tx.Rollback()
+
+	if guiltyWeight > totalWeight/2 {
+		h.logf("Trial verdict: guilty (%d/%d)", guiltyWeight, totalWeight)
+		died, err := h.lynch(tx, game, accused)
+		if err != nil {
+			h.logError("resolveTrial: lynch", err)
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			h.logError("resolveTrial: commit", err)
+			return
+		}
+		if died {
+			h.afterLynch(game, accused)
+		} else {
+			h.transitionToNight(game)
+		}
+		return
+	}
+
+	name := h.playerName(accused)
+	desc := fmt.Sprintf("Day %d: %s was acquitted", game.Round, name)
+	if _, err := tx.Exec(`
+		INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, accused, ActionDayAcquitted, accused, VisibilityPublic, desc, "hist_day_acquitted", histArgs(game.Round, name)); err != nil {
+		h.logError("resolveTrial: record acquittal", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		h.logError("resolveTrial: commit", err)
+		return
+	}
+	h.logf("Trial verdict: '%s' acquitted (%d/%d guilty)", name, guiltyWeight, totalWeight)
+	h.transitionToNight(game)
+}
+
+// handleWSToggleTrials switches trials on or off in the lobby; kept for the next game.
+func handleWSToggleTrials(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleTrials: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET trials = NOT trials WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleTrials: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_trials"))
+		return
+	}
+	h.logf("Day trials toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
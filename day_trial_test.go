@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Trial Tests
+// ============================================================================
+
+func TestTrialGuiltyVerdictEliminates(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "V1", "V2", "V3", "V4"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf, v1, v2, v3, v4 := ids[0], ids[1], ids[2], ids[3], ids[4]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET trials = 1 WHERE rowid = ?", game.ID)
+	wolfID := strconv.FormatInt(wolf, 10)
+
+	// two votes of five are no majority, but enough to lead and put the Wolf on trial
+	ctx.sendWS(v1, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	ctx.sendWS(v2, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	ctx.sendWS(v3, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(v4, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "day_pass"})
+	ctx.sendWS(v4, WSMessage{Action: "day_pass"})
+	ctx.sendWS(v1, WSMessage{Action: "day_end_vote"})
+	if trialAccused(ctx.app.db, game) != wolf || trialStage(ctx.app.db, game) != DayStageDefense {
+		t.Fatal("the front-runner should be on trial, awaiting their defense")
+	}
+
+	ctx.sendWS(v1, WSMessage{Action: "day_verdict", TargetPlayerID: wolfID})
+	if n := ctx.countActions(ActionDayVerdict); n != 0 {
+		t.Fatal("no verdict before the defense")
+	}
+	ctx.sendWS(v1, WSMessage{Action: "day_defense", Message: "I was asleep"})
+	if trialStage(ctx.app.db, game) != DayStageDefense {
+		t.Fatal("only the accused should be able to give the defense")
+	}
+	ctx.sendWS(wolf, WSMessage{Action: "day_defense", Message: "I was   asleep\tall night"})
+	if trialStage(ctx.app.db, game) != DayStageVerdict {
+		t.Fatal("the defense should open the verdict vote")
+	}
+	buf, err := getGameComponent(ctx.hub(), v3, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "I was asleep all night") {
+		t.Fatalf("the defense should be shown to the judges (err: %v)", err)
+	}
+
+	ctx.sendWS(wolf, WSMessage{Action: "day_verdict"})
+	if n := ctx.countActions(ActionDayVerdict); n != 0 {
+		t.Fatal("the accused should not judge their own trial")
+	}
+	for _, id := range []int64{v1, v2, v3} {
+		ctx.sendWS(id, WSMessage{Action: "day_verdict", TargetPlayerID: wolfID})
+	}
+	if !ctx.isPlayerAlive(wolf) {
+		t.Fatal("the trial should wait for every verdict")
+	}
+	ctx.sendWS(v4, WSMessage{Action: "day_verdict"})
+
+	if ctx.isPlayerAlive(wolf) {
+		t.Error("three guilty verdicts of four should eliminate the accused")
+	}
+}
+
+func TestTrialAcquittal(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "V1", "V2", "V3", "V4"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf, v1 := ids[0], ids[1]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET trials = 1 WHERE rowid = ?", game.ID)
+	v1ID := strconv.FormatInt(v1, 10)
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: v1ID})
+	}
+	ctx.sendWS(v1, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "day_end_vote"})
+	ctx.sendWS(v1, WSMessage{Action: "day_defense"})
+
+	ctx.sendWS(wolf, WSMessage{Action: "day_verdict", TargetPlayerID: v1ID})
+	ctx.sendWS(ids[2], WSMessage{Action: "day_verdict", TargetPlayerID: v1ID})
+	ctx.sendWS(ids[3], WSMessage{Action: "day_verdict"})
+	ctx.sendWS(ids[4], WSMessage{Action: "day_verdict"})
+
+	if !ctx.isPlayerAlive(v1) {
+		t.Fatal("a tied verdict is no majority: the accused goes free")
+	}
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Errorf("the night should follow an acquittal, got %q", status)
+	}
+	if h := ctx.historyFor(wolf); !strings.Contains(h, "V1 was acquitted") || !strings.Contains(h, "without a word") {
+		t.Errorf("the silent defense and the acquittal should be public, got: %q", h)
+	}
+}
+
+func TestTrialInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a werewolf put on trial and found guilty ===")
+
+	// Setup: 1 werewolf + 3 villagers = 4 players, trials on
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"TR1", "TR2", "TR3", "TR4"},
+		[]string{"trials-toggle"}, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	werewolves, villagers := killFirstVillagerAtNight(ctx, players)
+	werewolf, judge1, judge2 := werewolves[0], villagers[1], villagers[2]
+
+	werewolf.clickAndWait("#day-pass-btn")
+	judge1.dayVoteForPlayer(werewolf.Name)
+	judge2.dayVoteForPlayer(werewolf.Name)
+
+	if _, err := werewolf.p().Element("#day-defense-form"); err != nil {
+		ctx.logger.LogDB("FAIL: accused cannot defend")
+		t.Fatalf("the front-runner should be put on trial and asked to defend: %v", err)
+	}
+	werewolf.submitFormWithValues("day-defense-form", map[string]string{"message": "I was asleep all night"})
+	defense, err := judge1.p().Element("#trial-defense")
+	if err != nil {
+		t.Fatalf("the judges should read the defense: %v", err)
+	}
+	if text, _ := defense.Text(); !strings.Contains(text, "I was asleep all night") {
+		t.Errorf("the defense should be shown as given, got %q", text)
+	}
+
+	judge1.clickAndWait("#day-verdict-guilty-btn")
+	judge2.clickAndWait("#day-verdict-guilty-btn")
+
+	if !judge2.isGameFinished() || judge2.getWinner() != "villagers" {
+		ctx.logger.LogDB("FAIL: guilty verdict did not eliminate")
+		t.Errorf("a guilty verdict should eliminate the werewolf, got: %s", judge2.getGameContent())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// revealedIdiots returns the living Village Idiots the village has already voted out once.
+// They keep playing but hold no vote for the rest of the game.
+func revealedIdiots(db *sqlx.DB, gameID int64) map[int64]bool {
+	var ids []int64
+	db.Select(&ids, `
+SELECT a.actor_player_id FROM game_action a
+JOIN game_player g ON g.game_id = a.game_id AND g.player_id = a.actor_player_id
+WHERE a.game_id = ? AND a.action_type = ? AND g.is_alive = 1`,
+		gameID, ActionVillageIdiotRevealed)
+	revealed := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		revealed[id] = true
+	}
+	return revealed
+}
+
+// idiotSurvivesLynch cancels the village's elimination of a Village Idiot the first time it
+// happens: the role is revealed publicly and the Idiot loses their vote for good. Like the
+// Prince's reveal, it works only once.
+func (h *Hub) idiotSurvivesLynch(tx *sqlx.Tx, game *Game, playerID int64) (bool, error) {
+	if getRoleName(h.db, game.ID, playerID) != "Village Idiot" || powerDisabled(h.db, game.ID, "Village Idiot") {
+		return false, nil
+	}
+	if revealedIdiots(h.db, game.ID)[playerID] {
+		return false, nil
+	}
+
+	name := h.playerName(playerID)
+	desc := fmt.Sprintf("Day %d: %s was voted out but turned out to be the Village Idiot — spared, but without a vote from now on", game.Round, name)
+	_, err := tx.Exec(`
+		INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, playerID, ActionVillageIdiotRevealed, playerID, VisibilityPublic, desc, "hist_village_idiot_revealed", histArgs(game.Round, name))
+	if err != nil {
+		return false, fmt.Errorf("record village idiot reveal: %w", err)
+	}
+	h.logf("Village Idiot '%s' was voted out and revealed — elimination cancelled, vote lost", name)
+	h.maybeSpeakStory(game.ID, T(h.storytellerLang, "tts_village_idiot_revealed", name))
+	return true, nil
+}
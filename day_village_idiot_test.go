@@ -0,0 +1,111 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Village Idiot Tests
+// ============================================================================
+
+func TestVillageIdiotSurvivesLynchButLosesVote(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Idiot", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleVillageIdiot, RoleVillager, RoleVillager, RoleVillager})
+	wolf, idiot, v1 := ids[0], ids[1], ids[2]
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(idiot, 10)})
+	}
+	ctx.sendWS(v1, WSMessage{Action: "day_end_vote"})
+
+	if !ctx.isPlayerAlive(idiot) {
+		t.Fatal("the Village Idiot should survive being voted out")
+	}
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("the day should end after the reveal, got %q", status)
+	}
+	if h := ctx.historyFor(wolf); !strings.Contains(h, "turned out to be the Village Idiot") {
+		t.Errorf("the reveal should be public, got: %q", h)
+	}
+
+	// from now on the Idiot holds no vote, and the day does not wait for them
+	ctx.app.db.MustExec("UPDATE game SET status = 'day', round = 2")
+	ctx.sendWS(idiot, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	if n := ctx.countActions(ActionDaySelectKill); n != 5 {
+		t.Fatalf("the revealed Village Idiot must not vote, got %d vote rows", n)
+	}
+	for _, id := range []int64{v1, ids[3], ids[4]} {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	}
+	ctx.sendWS(wolf, WSMessage{Action: "day_pass"})
+	ctx.sendWS(v1, WSMessage{Action: "day_end_vote"})
+	if ctx.isPlayerAlive(wolf) {
+		t.Error("three of the four remaining votes should be a majority")
+	}
+}
+
+func TestVillageIdiotSparedInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Village Idiot survives the lynch but loses their vote ===")
+
+	// Setup: 1 werewolf + 1 village idiot + 3 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"VI1", "VI2", "VI3", "VI4", "VI5"},
+		RoleWerewolf, RoleVillageIdiot, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Village Idiot"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) < 3 {
+		t.Fatal("Missing required roles")
+	}
+	idiot, werewolf, villagers := byRole["Village Idiot"][0], byRole["Werewolf"][0], byRole["Villager"]
+
+	werewolf.voteForPlayer(villagers[0].Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	// Day 1: the village votes the Idiot out
+	alive := []*TestPlayer{werewolf, villagers[1], villagers[2], idiot}
+	for _, p := range alive {
+		if p == idiot {
+			p.dayVoteForPlayer(werewolf.Name)
+		} else {
+			p.dayVoteForPlayer(idiot.Name)
+		}
+	}
+	waitForNightPhaseAll(ctx, alive)
+
+	entry := idiot.Name + " was voted out but turned out to be the Village Idiot"
+	if !villagers[1].historyContains(entry) {
+		ctx.logger.LogDB("FAIL: village idiot reveal missing from history")
+		t.Fatalf("Everyone should see %q in history, got: %s", entry, villagers[1].getHistoryText())
+	}
+
+	// Night 2, then day 2: the Idiot is alive but may not vote
+	werewolf.voteForPlayer(villagers[1].Name)
+	submitNightSurveysForAllPlayers(alive)
+	waitForDayPhaseAll(ctx, alive)
+
+	if found, _, _ := idiot.p().Has("#silenced-note"); !found {
+		ctx.logger.LogDB("FAIL: village idiot can still vote")
+		t.Errorf("The Village Idiot should be told they cannot vote")
+	}
+	if found, _, _ := idiot.p().Has("#day-pass-btn"); found {
+		ctx.logger.LogDB("FAIL: village idiot sees the vote buttons")
+		t.Errorf("The Village Idiot should not be able to pass or vote")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// With whispers on, every living player may send one private message a day to another living
+// player. A whisper is a game_action with VisibilityPair: the sender and the recipient read it
+// in their history, the host audits it, and everyone sees it once the game is over.
+
+// whisperMax caps a whisper, like a chat message.
+const whisperMax = 280
+
+type Whisper struct {
+	From    string
+	To      string
+	Message string
+}
+
+type WhisperDayData struct {
+	WhispersOn     bool
+	CanWhisper     bool // the player has not whispered today
+	WhisperTargets []Player
+	Whispers       []Whisper // today's whispers the player sent or received
+}
+
+// whispersEnabled reports whether players may whisper; kept for the next game.
+func whispersEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT whispers FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// hasWhispered reports whether the player has used today's whisper.
+func hasWhispered(db *sqlx.DB, game *Game, playerID int64) bool {
+	var n int
+	db.Get(&n, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ? AND actor_player_id = ?`,
+		game.ID, game.Round, ActionDayWhisper, playerID)
+	return n > 0
+}
+
+func buildWhisperDayData(db *sqlx.DB, game *Game, player Player, aliveTargets []Player) WhisperDayData {
+	if !whispersEnabled(db, game.ID) || !player.IsAlive {
+		return WhisperDayData{}
+	}
+	d := WhisperDayData{
+		WhispersOn: true,
+		CanWhisper: !hasWhispered(db, game, player.PlayerID) && !spellbound(db, game.ID, game.Round, player.PlayerID),
+	}
+	for _, t := range aliveTargets {
+		if t.PlayerID != player.PlayerID {
+			d.WhisperTargets = append(d.WhisperTargets, t)
+		}
+	}
+	var rows []string
+	db.Select(&rows, `SELECT description_args FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ? AND (actor_player_id = ? OR target_player_id = ?) ORDER BY rowid`,
+		game.ID, game.Round, ActionDayWhisper, player.PlayerID, player.PlayerID)
+	for _, args := range rows {
+		if parts := strings.Split(args, "\t"); len(parts) == 4 {
+			d.Whispers = append(d.Whispers, Whisper{From: parts[1], To: parts[2], Message: parts[3]})
+		}
+	}
+	return d
+}
+
+// handleWSWhisper records a living player's one whisper of the day.
+func handleWSWhisper(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSWhisper: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "day" || !whispersEnabled(h.db, game.ID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_cannot_whisper"))
+		return
+	}
+	player, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil || !player.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_cannot_whisper"))
+		return
+	}
+	if spellbound(h.db, game.ID, game.Round, client.playerID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_silenced_cannot_speak"))
+		return
+	}
+	if hasWhispered(h.db, game, client.playerID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_whispered"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	// history args are tab-separated, so the whisper is kept to a single line
+	text := strings.Join(strings.Fields(msg.Message), " ")
+	if text == "" {
+		return
+	}
+	if len(text) > whisperMax {
+		h.sendErrorToast(client.playerID, T(lang, "err_chat_too_long"))
+		return
+	}
+
+	desc := fmt.Sprintf("Day %d: %s whispered to %s: %s", game.Round, player.Name, target.Name, text)
+	if _, err := h.db.Exec(`
+		INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionDayWhisper, targetID, VisibilityPair, desc, "hist_whisper", histArgs(game.Round, player.Name, target.Name, text)); err != nil {
+		h.logError("handleWSWhisper: insert", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_already_whispered"))
+		return
+	}
+	h.logf("'%s' whispered to '%s'", player.Name, target.Name)
+	h.triggerBroadcast()
+}
+
+// handleWSToggleWhispers switches whispers on or off in the lobby.
+func handleWSToggleWhispers(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleWhispers: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET whispers = NOT whispers WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleWhispers: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_whispers"))
+		return
+	}
+	h.logf("Whispers toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
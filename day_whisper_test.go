@@ -0,0 +1,94 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Whisper Tests
+// ============================================================================
+
+func TestWhisperOncePerDay(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host, wolf, v1, v2 := ids[0], ids[1], ids[2], ids[3]
+	v1ID := strconv.FormatInt(v1, 10)
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(wolf, WSMessage{Action: "whisper", TargetPlayerID: v1ID, Message: "vote Host"})
+	if n := ctx.countActions(ActionDayWhisper); n != 0 {
+		t.Fatalf("whispers are off by default, got %d", n)
+	}
+
+	ctx.app.db.MustExec("UPDATE game SET whispers = 1 WHERE rowid = ?", game.ID)
+	ctx.sendWS(wolf, WSMessage{Action: "whisper", TargetPlayerID: v1ID, Message: "vote  Host"})
+	ctx.sendWS(wolf, WSMessage{Action: "whisper", TargetPlayerID: strconv.FormatInt(v2, 10), Message: "vote Host too"})
+	if n := ctx.countActions(ActionDayWhisper); n != 1 {
+		t.Fatalf("a player should whisper once a day, got %d", n)
+	}
+
+	if h := ctx.historyFor(v1); !strings.Contains(h, "Wolf whispered to V1: vote Host") {
+		t.Errorf("the recipient should read the whisper, got %q", h)
+	}
+	if h := ctx.historyFor(wolf); !strings.Contains(h, "Wolf whispered to V1") {
+		t.Errorf("the sender should read the whisper, got %q", h)
+	}
+	if h := ctx.historyFor(v2); strings.Contains(h, "whispered") {
+		t.Errorf("the other players should not read the whisper, got %q", h)
+	}
+	if h := ctx.historyFor(host); !strings.Contains(h, "Wolf whispered to V1") {
+		t.Errorf("the host should audit the whisper, got %q", h)
+	}
+
+	buf, err := getGameComponent(ctx.hub(), v1, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "vote Host") || !strings.Contains(buf.String(), `id="whisper-form"`) {
+		t.Errorf("V1 should see the whisper and still have their own (err: %v)", err)
+	}
+	buf, err = getGameComponent(ctx.hub(), wolf, game, "en")
+	if err != nil || strings.Contains(buf.String(), `id="whisper-form"`) {
+		t.Errorf("the wolf has used today's whisper (err: %v)", err)
+	}
+}
+
+func TestWhisperInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a private whisper by day ===")
+
+	// Setup: 1 werewolf + 3 villagers = 4 players, whispers on
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"WH1", "WH2", "WH3", "WH4"},
+		[]string{"whispers-toggle"}, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	werewolves, villagers := killFirstVillagerAtNight(ctx, players)
+	werewolf, sender, recipient := werewolves[0], villagers[1], villagers[2]
+
+	sender.submitFormWithValues("whisper-form", map[string]string{
+		"target_player_id": sender.optionValue("#whisper-target", recipient.Name),
+		"message":          "vote the wolf",
+	})
+	if err := recipient.waitUntilCondition(`() => Array.from(document.querySelectorAll('.whisper-line')).some(l => l.textContent.includes('vote the wolf'))`, "whisper line"); err != nil {
+		ctx.logger.LogDB("FAIL: whisper not delivered")
+		t.Errorf("the recipient should read the whisper: %v", err)
+	}
+	if _, err := sender.p().Element("#whisper-used"); err != nil {
+		t.Errorf("the sender should have used today's whisper: %v", err)
+	}
+	if strings.Contains(werewolf.getGameContent(), "vote the wolf") {
+		ctx.logger.LogDB("FAIL: whisper leaked")
+		t.Error("a third player should not read the whisper")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// How much of a dead player's card the living get to see is a game setting: the full role,
+// only the team, or nothing at all. Once the game has finished every card is shown.
+const (
+	RevealFull = "full"
+	RevealTeam = "team"
+	RevealNone = "none"
+	RevealAll  = "all" // every card, living or dead; for observers and the dead (viewerReveal)
+)
+
+var roleRevealChoices = []string{RevealFull, RevealTeam, RevealNone}
+
+type RoleRevealChoice struct {
+	Value    string
+	Selected bool
+}
+
+// deathHistoryKeys are the history entries that name a dead player's role as their third arg.
+var deathHistoryKeys = map[string]bool{
+	"hist_found_dead": true,
+	"hist_eliminated": true,
+}
+
+// roleReveal returns how much of a dead player's role is shown right now: the game's setting
+// while it is being played, RevealFull once it has finished.
+func roleReveal(db *sqlx.DB, gameID int64) string {
+	var g struct {
+		Status string `db:"status"`
+		Reveal string `db:"role_reveal"`
+	}
+	if err := db.Get(&g, "SELECT status, role_reveal FROM game WHERE rowid = ?", gameID); err != nil || g.Status == "finished" {
+		return RevealFull
+	}
+	return g.Reveal
+}
+
+func roleRevealOptions(db *sqlx.DB, gameID int64) []RoleRevealChoice {
+	var current string
+	db.Get(&current, "SELECT role_reveal FROM game WHERE rowid = ?", gameID)
+	options := make([]RoleRevealChoice, 0, len(roleRevealChoices))
+	for _, v := range roleRevealChoices {
+		options = append(options, RoleRevealChoice{Value: v, Selected: v == current})
+	}
+	return options
+}
+
+// teamCardName is the card a team-only reveal shows: the pack's or the village's; a solo role
+// is a team of its own, so it shows as itself.
+func teamCardName(roleName, team string) string {
+	switch team {
+	case "werewolf":
+		return "Werewolf"
+	case "villager":
+		return "Villager"
+	}
+	return roleName
+}
+
+// maskDeathHistory rewrites a death entry's args for the reveal policy: the role becomes the
+// team card, or the entry switches to its "_hidden" variant without a role.
+func maskDeathHistory(db *sqlx.DB, reveal, key string, parts []string) (string, []string) {
+	if !deathHistoryKeys[key] || reveal == RevealFull || len(parts) < 3 {
+		return key, parts
+	}
+	if reveal == RevealNone {
+		return key + "_hidden", parts[:2]
+	}
+	var team string
+	db.Get(&team, "SELECT team FROM role WHERE name = ?", parts[2])
+	masked := append([]string{}, parts...)
+	masked[2] = teamCardName(parts[2], team)
+	return key, masked
+}
+
+// revealedDescription is a history row's English description as the reveal policy allows it;
+// the storyteller reads these while the game runs.
+func revealedDescription(db *sqlx.DB, reveal, desc, key, args string) string {
+	if !deathHistoryKeys[key] || reveal == RevealFull {
+		return desc
+	}
+	key, parts := maskDeathHistory(db, reveal, key, strings.Split(args, "\t"))
+	var a []interface{}
+	for _, p := range parts {
+		a = append(a, p)
+	}
+	return T("en", key, a...)
+}
+
+// handleWSSetRoleReveal picks the game's reveal policy in the lobby; kept for the next game.
+func handleWSSetRoleReveal(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSetRoleReveal: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+
+	valid := false
+	for _, v := range roleRevealChoices {
+		valid = valid || v == msg.Reveal
+	}
+	if !valid {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_role_reveal"))
+		return
+	}
+
+	if _, err := h.db.Exec("UPDATE game SET role_reveal = ? WHERE rowid = ?", msg.Reveal, game.ID); err != nil {
+		h.logError("handleWSSetRoleReveal: db.Exec", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_role_reveal"))
+		return
+	}
+	h.logf("Role reveal on death set to %s", msg.Reveal)
+	h.triggerBroadcast()
+}
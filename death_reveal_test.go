@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Role Reveal On Death Tests
+// ============================================================================
+
+// seedDeadSeer has the wolves kill the Seer (ids[1]) in night 1, with the public death entry.
+func seedDeadSeer(ctx *TestContext, reveal string) []int64 {
+	ctx.t.Helper()
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Seer", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSeer, RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET role_reveal = ? WHERE rowid = ?", reveal, game.ID)
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, ids[1])
+	ctx.app.db.MustExec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, 1, 'night', ?, ?, ?, ?, 'Night 1: Seer (Seer) was found dead', 'hist_found_dead', ?)`,
+		game.ID, ids[1], ActionNightApplyKill, ids[1], VisibilityPublic, histArgs(1, "Seer", "Seer"))
+	ctx.app.db.MustExec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility)
+VALUES (?, 1, 'night', ?, ?, ?, ?)`, game.ID, ids[0], ActionWerewolfSelectKill, ids[1], VisibilityTeamWerewolf)
+	return ids
+}
+
+func TestTeamRevealShowsOnlyTheDeadPlayersTeam(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := seedDeadSeer(ctx, RevealTeam)
+	game, _ := ctx.hub().getGame()
+	viewer, _ := getPlayerInGame(ctx.app.db, game.ID, ids[2])
+
+	if seen := getVisiblePlayer(ctx.app.db, game.ID, ids[1], viewer, nil); seen.RoleName != "Villager" || seen.Team != "villager" {
+		t.Errorf("a dead Seer should show as a villager only, got %q (%s)", seen.RoleName, seen.Team)
+	}
+	if h := ctx.historyFor(ids[2]); !strings.Contains(h, "Seer (Villager) was found dead") {
+		t.Errorf("the history should name the team only, got: %q", h)
+	}
+}
+
+func TestNoRevealHidesTheDeadUntilTheGameEnds(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := seedDeadSeer(ctx, RevealNone)
+	game, _ := ctx.hub().getGame()
+	viewer, _ := getPlayerInGame(ctx.app.db, game.ID, ids[2])
+
+	if seen := getVisiblePlayer(ctx.app.db, game.ID, ids[1], viewer, nil); seen.Team != "unknown" {
+		t.Errorf("a dead player's card should stay hidden, got %q (%s)", seen.RoleName, seen.Team)
+	}
+	if seen := getVisiblePlayer(ctx.app.db, game.ID, ids[1], viewer, map[int64]string{ids[1]: "villager"}); seen.Team != "villager" {
+		t.Errorf("what the Seer found out still shows, got %q (%s)", seen.RoleName, seen.Team)
+	}
+	buf, err := getGameComponent(ctx.hub(), ids[2], game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="death-announcement"`) || strings.Contains(buf.String(), `role-name="Seer"`) {
+		t.Errorf("the morning's victim card should not show the role (err: %v)", err)
+	}
+	if h := ctx.historyFor(ids[2]); !strings.Contains(h, "Night 1: Seer was found dead") {
+		t.Errorf("the history should leave the role out, got: %q", h)
+	}
+
+	ctx.app.db.MustExec("UPDATE game SET status = 'finished' WHERE rowid = ?", game.ID)
+	if seen := getVisiblePlayer(ctx.app.db, game.ID, ids[1], viewer, nil); seen.RoleName != "Seer" {
+		t.Errorf("every role shows once the game is over, got %q", seen.RoleName)
+	}
+}
+
+func TestTeamRevealInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a dead Seer shown by team only ===")
+
+	// Setup: 1 werewolf + 1 seer + 2 villagers = 4 players, team-only reveal
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"RV1", "RV2", "RV3", "RV4"},
+		[]string{"role-reveal-" + RevealTeam}, RoleWerewolf, RoleSeer, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Seer"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	seer, werewolf, villager := byRole["Seer"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+
+	werewolf.voteForPlayer(seer.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	card, err := villager.p().Element("#death-announcement .player-card[player-name='" + seer.Name + "']")
+	if err != nil {
+		ctx.logger.LogDB("FAIL: no victim card")
+		t.Fatalf("the morning should show the victim's card: %v", err)
+	}
+	if role, _ := card.Attribute("role-name"); role == nil || *role != "Villager" {
+		t.Errorf("the dead Seer should show as a villager only, got %v", role)
+	}
+	if entry := seer.Name + " (Villager) was found dead"; !villager.historyContains(entry) {
+		t.Errorf("the history should name the team only (%q), got: %s", entry, villager.getHistoryText())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// With a Mayor election the game opens with a day of its own, before night 1: every player
+// votes for a Mayor, and from then on the Mayor's vote breaks ties in the day vote. The
+// election is a game status of its own ("election", round 0); the Mayor is the actor of the
+// mayor_elected row and loses the office on dying.
+
+type ElectionData struct {
+	Player         *Player
+	CandidateCards []PlayerCardData
+	HasVoted       bool
+	VoteCount      int // players who have voted so far
+	VoterCount     int // living players
+	Lang           string
+}
+
+// mayorElectionEnabled reports whether the game opens with a Mayor election.
+func mayorElectionEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT mayor_election FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// electedMayor returns the living elected Mayor; 0 when there is none (any more).
+func electedMayor(db *sqlx.DB, gameID int64) int64 {
+	var mayorID int64
+	db.Get(&mayorID, `
+SELECT a.actor_player_id FROM game_action a
+JOIN game_player g ON g.game_id = a.game_id AND g.player_id = a.actor_player_id
+WHERE a.game_id = ? AND a.action_type = ? AND g.is_alive = 1`, gameID, ActionMayorElected)
+	return mayorID
+}
+
+// mayorVote returns the target the living elected Mayor voted for today; 0 when there is no
+// Mayor or they did not vote. A tie the Mayor voted in is settled their way.
+func mayorVote(db *sqlx.DB, game *Game, voteType string) int64 {
+	mayorID := electedMayor(db, game.ID)
+	if mayorID == 0 {
+		return 0
+	}
+	var targetID int64
+	db.Get(&targetID, `SELECT IFNULL(target_player_id, 0) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ? AND actor_player_id = ?`,
+		game.ID, game.Round, voteType, mayorID)
+	return targetID
+}
+
+// electedMayorName is the living elected Mayor's name; "" when there is none.
+func electedMayorName(db *sqlx.DB, gameID int64) string {
+	if mayorID := electedMayor(db, gameID); mayorID != 0 {
+		return getPlayerName(db, mayorID)
+	}
+	return ""
+}
+
+func buildElectionData(db *sqlx.DB, game *Game, player Player, players []Player, lang string) ElectionData {
+	d := ElectionData{Player: &player, Lang: lang}
+
+	var votes []GameAction
+	db.Select(&votes, `
+		SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+		FROM game_action WHERE game_id = ? AND phase = 'election' AND action_type = ?`,
+		game.ID, ActionMayorElectionVote)
+	votersByTarget := map[int64][]VoterChip{}
+	var ownVote int64
+	for _, v := range votes {
+		if v.TargetPlayerID == nil {
+			continue
+		}
+		votersByTarget[*v.TargetPlayerID] = append(votersByTarget[*v.TargetPlayerID],
+			VoterChip{Name: getPlayerName(db, v.ActorPlayerID), PlayerUID: v.ActorPlayerID, Weight: 1})
+		if v.ActorPlayerID == player.PlayerID {
+			ownVote = *v.TargetPlayerID
+		}
+	}
+	d.VoteCount = len(votes)
+	d.HasVoted = ownVote != 0
+
+	for _, p := range players {
+		if !p.IsAlive {
+			continue
+		}
+		d.VoterCount++
+		card := makePlayerCard(p, lang)
+		card.Selectable = player.IsAlive
+		card.Selected = p.PlayerID == ownVote
+		card.ShowVoteCount = true
+		card.VoteCount = len(votersByTarget[p.PlayerID])
+		card.Voters = votersByTarget[p.PlayerID]
+		d.CandidateCards = append(d.CandidateCards, card)
+	}
+	return d
+}
+
+// handleWSMayorVote records or changes a player's vote in the Mayor election; once every
+// living player has voted the election is decided and night 1 begins.
+func handleWSMayorVote(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSMayorVote: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "election" {
+		h.sendErrorToast(client.playerID, T(lang, "err_not_election"))
+		return
+	}
+
+	voter, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSMayorVote: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if !voter.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_vote"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	desc := fmt.Sprintf("Before night 1: %s voted for %s as Mayor", voter.Name, target.Name)
+	_, err = h.db.Exec(`
+INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, 0, 'election', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, client.playerID, ActionMayorElectionVote, targetID, VisibilityPublic, desc, "hist_mayor_vote", histArgs(voter.Name, target.Name))
+	if err != nil {
+		h.logError("handleWSMayorVote: db.Exec", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_vote"))
+		return
+	}
+	h.logf("'%s' voted for '%s' as Mayor", voter.Name, target.Name)
+
+	var voted, alive int
+	h.db.Get(&voted, "SELECT COUNT(*) FROM game_action WHERE game_id = ? AND phase = 'election' AND action_type = ?", game.ID, ActionMayorElectionVote)
+	h.db.Get(&alive, "SELECT COUNT(*) FROM game_player WHERE game_id = ? AND is_alive = 1", game.ID)
+	if voted < alive {
+		h.triggerBroadcast()
+		return
+	}
+	h.resolveElection(game)
+}
+
+// resolveElection makes the player with the most votes Mayor, drawing lots between tied
+// players, and starts night 1.
+func (h *Hub) resolveElection(game *Game) {
+	var tally []struct {
+		TargetID int64 `db:"target_player_id"`
+		Votes    int   `db:"votes"`
+	}
+	h.db.Select(&tally, `
+SELECT target_player_id, COUNT(*) as votes FROM game_action
+WHERE game_id = ? AND phase = 'election' AND action_type = ? AND target_player_id IS NOT NULL
+GROUP BY target_player_id ORDER BY votes DESC, target_player_id`, game.ID, ActionMayorElectionVote)
+
+	var leaders []int64
+	for _, t := range tally {
+		if t.Votes == tally[0].Votes {
+			leaders = append(leaders, t.TargetID)
+		}
+	}
+	if len(leaders) > 0 {
+		mayorID := leaders[0]
+		if len(leaders) > 1 {
+			if n, err := rand.Int(rand.Reader, big.NewInt(int64(len(leaders)))); err == nil {
+				mayorID = leaders[n.Int64()]
+			}
+		}
+		name := h.playerName(mayorID)
+		desc := fmt.Sprintf("Before night 1: %s was elected Mayor", name)
+		if _, err := h.db.Exec(`
+INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, 0, 'election', ?, ?, ?, ?, ?, ?, ?)`,
+			game.ID, mayorID, ActionMayorElected, mayorID, VisibilityPublic, desc, "hist_mayor_elected", histArgs(name)); err != nil {
+			h.logError("resolveElection: record Mayor", err)
+		}
+		h.logf("'%s' was elected Mayor (%d tied for the lead)", name, len(leaders))
+	}
+
+	if err := h.beginFirstNight(game.ID); err != nil {
+		h.logError("resolveElection: beginFirstNight", err)
+		return
+	}
+	h.triggerBroadcast()
+}
+
+// handleWSToggleMayorElection switches the opening Mayor election on or off in the lobby;
+// kept for the next game.
+func handleWSToggleMayorElection(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleMayorElection: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET mayor_election = NOT mayor_election WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleMayorElection: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_mayor_election"))
+		return
+	}
+	h.logf("Mayor election toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
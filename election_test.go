@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Mayor Election Tests
+// ============================================================================
+
+func TestMayorElectionBeforeFirstNight(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0,
+		[]string{"P1", "P2", "P3"},
+		[]string{RoleVillager, RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+	for _, rc := range [][2]string{{RoleWerewolf, "1"}, {RoleVillager, "2"}} {
+		ctx.app.db.MustExec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, ?)", game.ID, rc[0], rc[1])
+	}
+	ctx.sendWS(ids[0], WSMessage{Action: "toggle_mayor_election"})
+	ctx.sendWS(ids[0], WSMessage{Action: "start_game"})
+
+	if status, round, _ := ctx.gameState(); status != "election" || round != 0 {
+		t.Fatalf("the game should open with the Mayor election, got %q round %d", status, round)
+	}
+	game, _ = ctx.hub().getGame()
+	buf, err := getGameComponent(ctx.hub(), ids[1], game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="mayor-vote-form-`+strconv.FormatInt(ids[0], 10)+`"`) {
+		t.Fatalf("the election screen should offer every player as a candidate (err: %v)", err)
+	}
+
+	p2 := strconv.FormatInt(ids[1], 10)
+	ctx.sendWS(ids[0], WSMessage{Action: "mayor_vote", TargetPlayerID: strconv.FormatInt(ids[2], 10)})
+	ctx.sendWS(ids[0], WSMessage{Action: "mayor_vote", TargetPlayerID: p2})
+	ctx.sendWS(ids[1], WSMessage{Action: "mayor_vote", TargetPlayerID: p2})
+	if status, _, _ := ctx.gameState(); status != "election" {
+		t.Fatalf("the election should wait for every vote, got %q", status)
+	}
+	ctx.sendWS(ids[2], WSMessage{Action: "mayor_vote", TargetPlayerID: strconv.FormatInt(ids[0], 10)})
+
+	if status, round, _ := ctx.gameState(); status != "night" || round != 1 {
+		t.Fatalf("night 1 should follow the election, got %q round %d", status, round)
+	}
+	if m := electedMayor(ctx.app.db, game.ID); m != ids[1] {
+		t.Errorf("P2 should have been elected Mayor, got player %d", m)
+	}
+	if h := ctx.historyFor(ids[0]); !strings.Contains(h, "P2 was elected Mayor") {
+		t.Errorf("the result should be public, got: %q", h)
+	}
+}
+
+func TestElectedMayorBreaksDayTie(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	wolf, v1, v2, v3 := ids[0], ids[1], ids[2], ids[3]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility)
+VALUES (?, 0, 'election', ?, ?, ?, ?)`, game.ID, v1, ActionMayorElected, v1, VisibilityPublic)
+	wolfID, v3ID := strconv.FormatInt(wolf, 10), strconv.FormatInt(v3, 10)
+
+	// 2 against 2; the Mayor sides with the votes against the Wolf
+	ctx.sendWS(v1, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	ctx.sendWS(v2, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	ctx.sendWS(wolf, WSMessage{Action: "day_vote", TargetPlayerID: v3ID})
+	ctx.sendWS(v3, WSMessage{Action: "day_vote", TargetPlayerID: v3ID})
+	ctx.sendWS(v3, WSMessage{Action: "day_end_vote"})
+
+	if ctx.isPlayerAlive(wolf) {
+		t.Error("the elected Mayor's vote should break the tie")
+	}
+	if !ctx.isPlayerAlive(v3) {
+		t.Error("the other side of the tie should survive")
+	}
+}
+
+func TestMayorElectionInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Mayor election before the first night ===")
+
+	// Setup: 1 werewolf + 3 villagers = 4 players, Mayor election on
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"EL1", "EL2", "EL3", "EL4"},
+		[]string{"mayor-election-toggle"}, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager)
+	for _, p := range players {
+		if err := p.waitUntilCondition(`() => !!document.querySelector('#election-section')`, "election"); err != nil {
+			ctx.logger.LogDB("FAIL: no election")
+			t.Fatalf("[%s] the game should open with the Mayor election: %v", p.Name, err)
+		}
+	}
+
+	// everyone elects the first player; the werewolves then eat a villager who is not Mayor
+	mayor := players[0]
+	for _, p := range players {
+		p.clickAndWait("[id^='mayor-vote-form-'] .player-card[player-name='" + mayor.Name + "']")
+	}
+	waitForNightPhaseAll(ctx, players)
+
+	werewolves, villagers := findPlayersByRole(players)
+	var victim *TestPlayer
+	for _, v := range villagers {
+		if v != mayor {
+			victim = v
+			break
+		}
+	}
+	for _, w := range werewolves {
+		w.voteForPlayer(victim.Name)
+	}
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if !mayor.historyContains(mayor.Name + " was elected Mayor") {
+		ctx.logger.LogDB("FAIL: mayor not elected")
+		t.Errorf("the history should name the elected Mayor, got: %s", mayor.getHistoryText())
+	}
+	if _, err := mayor.p().Element("#mayor-vote-note"); err != nil {
+		t.Errorf("the Mayor should be told their vote counts twice: %v", err)
+	}
+	var other *TestPlayer
+	for _, p := range players {
+		if p != mayor && p != victim {
+			other = p
+			break
+		}
+	}
+	note, err := other.p().Element("#elected-mayor-note")
+	if err != nil {
+		t.Fatalf("the village should be told who is Mayor: %v", err)
+	}
+	if text, _ := note.Text(); !strings.Contains(text, mayor.Name) {
+		t.Errorf("the note should name the Mayor, got %q", text)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,96 @@
+package engine
+
+import "slices"
+
+// DayVote is what the end of the day's vote is settled from. Votes are counted in vote
+// weight, so a Mayor's vote counts twice.
+type DayVote struct {
+	AliveWeight int           // the vote weight of the living players who may vote today
+	Passes      int           // votes to eliminate nobody
+	Counts      map[int64]int // vote weight per target
+	MayorVote   int64         // the target the elected Mayor voted for, 0 when there is none
+	Scapegoat   bool          // a living Scapegoat with their power takes the blame for a tie
+	Runoff      bool          // a tie may still go to a runoff: runoffs are on and this is the first vote
+	Trials      bool          // the front-runner goes on trial instead of being eliminated
+}
+
+// DayResult is what the day's vote comes to.
+type DayResult int
+
+const (
+	DayPassed     DayResult = iota // a majority passed; night falls without an elimination
+	DayNoMajority                  // nobody got enough votes; night falls without an elimination
+	DayEliminate                   // Target is eliminated
+	DayTrial                       // Target goes on trial
+	DayScapegoat                   // the vote tied and the Scapegoat dies instead
+	DayRunoff                      // the vote tied and Tied go into a runoff
+)
+
+// DayOutcome is the settled day vote.
+type DayOutcome struct {
+	Result   DayResult
+	Target   int64   // for DayEliminate and DayTrial
+	Tied     []int64 // the players with the most votes when more than one has them, by id
+	MaxVotes int
+}
+
+// ResolveDayVote settles the day's vote:
+//   - a majority of passes ends the day without an elimination;
+//   - a tie goes to the Mayor's side when the Mayor voted in it, otherwise to the
+//     Scapegoat, otherwise to a runoff;
+//   - with trials a clear front-runner goes on trial, where the verdict needs the majority;
+//   - without them the front-runner is eliminated when they have a majority of the weight.
+func ResolveDayVote(v DayVote) DayOutcome {
+	if v.Passes > v.AliveWeight/2 {
+		return DayOutcome{Result: DayPassed}
+	}
+
+	out := DayOutcome{Result: DayNoMajority}
+	for target, count := range v.Counts {
+		if count > out.MaxVotes {
+			out.MaxVotes, out.Target, out.Tied = count, target, nil
+		}
+		if count == out.MaxVotes {
+			out.Tied = append(out.Tied, target)
+		}
+	}
+	tie := out.MaxVotes > 0 && len(out.Tied) > 1
+	if !tie {
+		out.Tied = nil
+	}
+	slices.Sort(out.Tied)
+
+	// a tie never has a majority, so the Mayor's side goes through (or on trial) without one
+	if tie && v.MayorVote != 0 && v.Counts[v.MayorVote] == out.MaxVotes {
+		out.Target = v.MayorVote
+		if v.Trials {
+			out.Result = DayTrial
+		} else {
+			out.Result = DayEliminate
+		}
+		return out
+	}
+	if tie && v.Scapegoat {
+		out.Result, out.Target = DayScapegoat, 0
+		return out
+	}
+	if tie && v.Runoff {
+		out.Result, out.Target = DayRunoff, 0
+		return out
+	}
+
+	if out.MaxVotes == 0 || tie {
+		out.Target = 0
+		return out
+	}
+	if v.Trials {
+		out.Result = DayTrial
+		return out
+	}
+	if out.MaxVotes < v.AliveWeight/2+1 {
+		out.Target = 0
+		return out
+	}
+	out.Result = DayEliminate
+	return out
+}
@@ -0,0 +1,38 @@
+// Package engine holds the rules of the game that do not depend on how a game is stored or
+// shown: which phase follows which, how the day's vote is settled and when a side has won.
+// Its functions take what the web layer read from the database and return what happens
+// next; the web layer carries that out (writes it, records the history, pushes the pages).
+// Nothing here touches the database, the clock or randomness, so the same input always
+// gives the same result and the rules can be tested on their own.
+//
+// The inputs and outcomes are plain typed values, one call per decision, not a stream of
+// events. The night is not settled here: every role's night step reads and writes state of
+// its own (potions, charms, wounds, curses), so the night pipeline stays with the web layer.
+package engine
+
+// Phase is the status a game is in.
+type Phase string
+
+const (
+	Lobby    Phase = "lobby"
+	Setup    Phase = "setup"    // the Thieves choose their role
+	Election Phase = "election" // the village elects its Mayor
+	Night    Phase = "night"
+	Day      Phase = "day"
+	Finished Phase = "finished"
+)
+
+// Next returns the phase and round that follow the given ones: the first night after the
+// game's setup, the day after a night and the next night, one round later, after a day.
+// A finished game stays finished.
+func Next(phase Phase, round int) (Phase, int) {
+	switch phase {
+	case Lobby, Setup, Election:
+		return Night, 1
+	case Night:
+		return Day, round
+	case Day:
+		return Night, round + 1
+	}
+	return phase, round
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+)
+
+// ============================================================================
+// Engine Tests
+// ============================================================================
+
+func TestNextPhase(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		phase Phase
+		round int
+		want  Phase
+		next  int
+	}{
+		{Lobby, 0, Night, 1},
+		{Setup, 0, Night, 1},
+		{Election, 0, Night, 1},
+		{Night, 2, Day, 2},
+		{Day, 2, Night, 3},
+		{Finished, 4, Finished, 4},
+	} {
+		if phase, round := Next(tc.phase, tc.round); phase != tc.want || round != tc.next {
+			t.Errorf("after %s %d: got %s %d, want %s %d", tc.phase, tc.round, phase, round, tc.want, tc.next)
+		}
+	}
+}
+
+func TestResolveDayVote(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		vote   DayVote
+		result DayResult
+		target int64
+		tied   []int64
+	}{
+		{"majority passes", DayVote{AliveWeight: 5, Passes: 3, Counts: map[int64]int{1: 2}}, DayPassed, 0, nil},
+		{"majority eliminates", DayVote{AliveWeight: 5, Counts: map[int64]int{1: 3, 2: 2}}, DayEliminate, 1, nil},
+		{"front-runner without a majority", DayVote{AliveWeight: 5, Counts: map[int64]int{1: 2, 2: 1}}, DayNoMajority, 0, nil},
+		{"nobody voted", DayVote{AliveWeight: 5}, DayNoMajority, 0, nil},
+		{"tie", DayVote{AliveWeight: 4, Counts: map[int64]int{3: 2, 1: 2}}, DayNoMajority, 0, []int64{1, 3}},
+		{"Mayor settles a tie", DayVote{AliveWeight: 5, Counts: map[int64]int{1: 2, 2: 2}, MayorVote: 2}, DayEliminate, 2, []int64{1, 2}},
+		{"Mayor outside the tie", DayVote{AliveWeight: 6, Counts: map[int64]int{1: 2, 2: 2, 3: 1}, MayorVote: 3, Scapegoat: true}, DayScapegoat, 0, []int64{1, 2}},
+		{"Mayor's side goes on trial", DayVote{AliveWeight: 5, Counts: map[int64]int{1: 2, 2: 2}, MayorVote: 1, Trials: true}, DayTrial, 1, []int64{1, 2}},
+		{"Scapegoat before a runoff", DayVote{AliveWeight: 4, Counts: map[int64]int{1: 2, 2: 2}, Scapegoat: true, Runoff: true}, DayScapegoat, 0, []int64{1, 2}},
+		{"runoff", DayVote{AliveWeight: 4, Counts: map[int64]int{2: 2, 1: 2}, Runoff: true}, DayRunoff, 0, []int64{1, 2}},
+		{"trial without a majority", DayVote{AliveWeight: 5, Counts: map[int64]int{1: 2, 2: 1}, Trials: true}, DayTrial, 1, nil},
+		{"no trial on a tie", DayVote{AliveWeight: 5, Counts: map[int64]int{1: 2, 2: 2}, Trials: true}, DayNoMajority, 0, []int64{1, 2}},
+	} {
+		got := ResolveDayVote(tc.vote)
+		if got.Result != tc.result || got.Target != tc.target || !slices.Equal(got.Tied, tc.tied) {
+			t.Errorf("%s: got %+v, want result %d, target %d, tied %v", tc.name, got, tc.result, tc.target, tc.tied)
+		}
+	}
+}
+
+func TestCheckWin(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name string
+		s    Survivors
+		want Winner
+	}{
+		{"both sides alive", Survivors{Werewolves: 1, Villagers: 2}, NoWinner},
+		{"pack gone", Survivors{Villagers: 2}, VillagersWin},
+		{"village gone", Survivors{Werewolves: 2}, WerewolvesWin},
+		{"Serial Killer blocks the wolves", Survivors{Werewolves: 1, SerialKillers: 1}, NoWinner},
+		{"Serial Killer alone", Survivors{SerialKillers: 1}, SerialKillerWins},
+		{"White Werewolf alone", Survivors{Werewolves: 1, WhiteWolves: 1}, WhiteWerewolfWins},
+		{"White Werewolf in the pack", Survivors{Werewolves: 2, WhiteWolves: 1}, WerewolvesWin},
+		{"lovers", Survivors{Werewolves: 1, Villagers: 1, LastTwoLovers: true}, LoversWin},
+		{"charmed village", Survivors{Werewolves: 1, Villagers: 3, AllCharmed: true}, PiperWins},
+	} {
+		if got := CheckWin(tc.s); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
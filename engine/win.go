@@ -0,0 +1,55 @@
+package engine
+
+// Winner names the side that won a game, as stored in game.winner; "" while nobody has.
+type Winner string
+
+const (
+	NoWinner          Winner = ""
+	VillagersWin      Winner = "villagers"
+	WerewolvesWin     Winner = "werewolves"
+	LoversWin         Winner = "lovers"
+	SerialKillerWins  Winner = "serial_killer"
+	WhiteWerewolfWins Winner = "white_werewolf"
+	PiperWins         Winner = "piper"
+	TannerWins        Winner = "tanner"
+)
+
+// Survivors is who is still alive, counted by side. The werewolves' helpers (Sorceress,
+// Minion) count on neither side: the village wins once the pack is gone, and a surviving
+// helper does not keep the wolves from winning.
+type Survivors struct {
+	Werewolves    int  // the pack, the White Werewolf included
+	Villagers     int  // the village's team
+	SerialKillers int  // living Serial Killers
+	WhiteWolves   int  // living White Werewolves, also counted in Werewolves
+	AllCharmed    bool // a living Piper has charmed every other living player
+	LastTwoLovers bool // exactly two players are alive and they are each other's lover
+}
+
+// CheckWin returns the side that has won with these survivors, NoWinner while the game goes on.
+func CheckWin(s Survivors) Winner {
+	if s.AllCharmed {
+		return PiperWins
+	}
+	if s.LastTwoLovers && s.Werewolves+s.Villagers+s.SerialKillers == 2 {
+		return LoversWin
+	}
+	// the White Werewolf counts with the pack until they are the only one left standing
+	if s.WhiteWolves == 1 && s.Werewolves == 1 && s.Villagers == 0 && s.SerialKillers == 0 {
+		return WhiteWerewolfWins
+	}
+	// a living Serial Killer blocks both sides from winning; they win once nobody else is left
+	if s.SerialKillers > 0 {
+		if s.Werewolves+s.Villagers == 0 {
+			return SerialKillerWins
+		}
+		return NoWinner
+	}
+	if s.Werewolves == 0 {
+		return VillagersWin
+	}
+	if s.Villagers == 0 {
+		return WerewolvesWin
+	}
+	return NoWinner
+}
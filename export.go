@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// A finished game can be downloaded as JSON (/history/{id}/export.json): the game, every
+// player with the role they ended with, and the full game_action log with the English
+// descriptions. Like the archive, the export holds nothing back once the game is over.
+
+type GameExport struct {
+	ID         int64          `json:"id"`
+	Name       string         `json:"name"`
+	Winner     string         `json:"winner"`
+	Rounds     int            `json:"rounds"`
+	FinishedAt string         `json:"finished_at,omitempty"`
+	Players    []PlayerExport `json:"players"`
+	Actions    []ActionExport `json:"actions"`
+}
+
+type PlayerExport struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	Team     string `json:"team"`
+	Alive    bool   `json:"alive"`
+	Won      bool   `json:"won"`
+	Observer bool   `json:"observer,omitempty"`
+	Lover    string `json:"lover,omitempty"` // the partner's name
+}
+
+type ActionExport struct {
+	Round       int    `json:"round"`
+	Phase       string `json:"phase"`
+	Type        string `json:"type"`
+	Actor       string `json:"actor,omitempty"`
+	Target      string `json:"target,omitempty"`
+	Visibility  string `json:"visibility"`
+	Description string `json:"description,omitempty"`
+}
+
+// exportGame builds the export of a finished game.
+func exportGame(db *sqlx.DB, gameID int64) (*GameExport, error) {
+	var game ArchivedGame
+	if err := db.Get(&game, archivedGamesQuery+" AND g.rowid = ?", gameID); err != nil {
+		return nil, err
+	}
+	e := &GameExport{ID: game.ID, Name: game.Name, Winner: game.Winner, FinishedAt: game.FinishedAt}
+	db.Get(&e.Rounds, "SELECT round FROM game WHERE rowid = ?", gameID)
+
+	players, err := getPlayersByGameId(db, gameID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(players))
+	for _, p := range players {
+		names[p.PlayerID] = p.Name
+	}
+	for _, p := range players {
+		team := winTeam(p.RoleName, p.Team)
+		e.Players = append(e.Players, PlayerExport{
+			ID: p.PlayerID, Name: p.Name, Role: p.RoleName, Team: team, Alive: p.IsAlive,
+			Won: !p.IsObserver && playerWon(game.Winner, team, p.IsAlive), Observer: p.IsObserver,
+			Lover: names[getLoverPartner(db, gameID, p.PlayerID)],
+		})
+	}
+
+	var actions []GameAction
+	if err := db.Select(&actions, `
+		SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description
+		FROM game_action WHERE game_id = ? ORDER BY rowid`, gameID); err != nil {
+		return nil, err
+	}
+	for _, a := range actions {
+		ae := ActionExport{Round: a.Round, Phase: a.Phase, Type: a.ActionType, Actor: names[a.ActorPlayerID], Visibility: a.Visibility, Description: a.Description}
+		if a.TargetPlayerID != nil {
+			ae.Target = names[*a.TargetPlayerID]
+		}
+		e.Actions = append(e.Actions, ae)
+	}
+	return e, nil
+}
+
+func (app *App) handleGameExport(w http.ResponseWriter, r *http.Request) {
+	gameID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	e, err := exportGame(app.db, gameID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="werewolf-game-`+strconv.FormatInt(gameID, 10)+`.json"`)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(e); err != nil {
+		app.logf("ERROR [handleGameExport: Encode]: %v", err)
+	}
+}
@@ -1,5 +1,11 @@
 package main
 
+import (
+	"github.com/jmoiron/sqlx"
+
+	"werewolf/engine"
+)
+
 type FinishedData struct {
 	Winners     []Player
 	Losers      []Player
@@ -17,17 +23,86 @@ func playerWon(winner, team string, alive bool) bool {
 		return team == "werewolf"
 	case "lovers":
 		return alive
+	case "tanner":
+		return team == "tanner"
+	case "serial_killer":
+		return team == "serial_killer"
+	case "piper":
+		return team == "piper"
+	case "white_werewolf":
+		return team == "white_werewolf"
 	}
 	return false
 }
 
+// phaseUnchanged reports whether the game is still in the phase and round game was read in.
+// A resolution that finds it moved on was already done by an earlier move.
+func (h *Hub) phaseUnchanged(game *Game) bool {
+	current, err := h.getGame()
+	if err != nil || current.ID != game.ID || current.Status != game.Status || current.Round != game.Round {
+		h.logf("Game %d moved on from %s %d, skipping a stale resolution", game.ID, game.Status, game.Round)
+		return false
+	}
+	return true
+}
+
+// beginResolution opens the transaction a resolution writes its outcome in. Its first
+// statement takes the database's write lock and checks that the game is still in the phase
+// and round it was read in, so the check and the writes after it cannot be split by another
+// move; ok is false when the game moved on and there is nothing left to resolve.
+func (h *Hub) beginResolution(game *Game) (tx *sqlx.Tx, ok bool) {
+	tx, err := h.db.Beginx()
+	if err != nil {
+		h.logError("beginResolution: begin", err)
+		return nil, false
+	}
+	res, err := tx.Exec("UPDATE game SET round = round WHERE rowid = ? AND status = ? AND round = ?", game.ID, game.Status, game.Round)
+	if err != nil {
+		tx.Rollback()
+		h.logError("beginResolution: claim phase", err)
+		return nil, false
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		tx.Rollback()
+		h.logf("Game %d moved on from %s %d, skipping a stale resolution", game.ID, game.Status, game.Round)
+		return nil, false
+	}
+	return tx, true
+}
+
 func (h *Hub) transitionToNight(game *Game) {
-	newRound := game.Round + 1
-	_, err := h.db.Exec("UPDATE game SET status = 'night', round = ? WHERE rowid = ?", newRound, game.ID)
+	// a blamed Scapegoat still has to choose tomorrow's voters; their choice ends the day
+	if scapegoatChoicePending(h.db, game.ID, game.Round) {
+		h.triggerBroadcast()
+		return
+	}
+
+	// the lynched and the shot may still be owed their last words
+	if h.holdForLastWords(game) {
+		return
+	}
+
+	// a Tough Guy wounded last night dies as the day ends; that death may end the game
+	// or leave a Hunter shot pending, and then the day stays open
+	if h.applyToughGuyDeaths(game) {
+		return
+	}
+
+	next, newRound := engine.Next(engine.Day, game.Round)
+	// only the day it was called for moves on: a second resolution of it finds the night begun
+	res, err := h.db.Exec("UPDATE game SET status = ?, round = ? WHERE rowid = ? AND status = 'day' AND round = ?", next, newRound, game.ID, game.Round)
 	if err != nil {
 		h.logError("transitionToNight: update game", err)
 		return
 	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		h.logf("Day %d already ended, not transitioning again", game.Round)
+		return
+	}
+
+	h.soberDrunks(game, newRound)
+	h.saveCheckpoint(game.ID, "night")
+	h.startNightTimer(game.ID, newRound)
 
 	h.logf("Day %d ended, transitioning to night %d", game.Round, newRound)
 	DebugLog("transitionToNight", "Day %d ended, transitioning to night %d", game.Round, newRound)
@@ -39,13 +114,19 @@ func (h *Hub) transitionToNight(game *Game) {
 
 func (h *Hub) checkWinConditions(game *Game) bool {
 	var counts struct {
-		Werewolves int `db:"werewolf_count"`
-		Villagers  int `db:"villager_count"`
+		Werewolves    int `db:"werewolf_count"`
+		Villagers     int `db:"villager_count"`
+		SerialKillers int `db:"serial_killer_count"`
+		WhiteWolves   int `db:"white_wolf_count"`
 	}
+	// werewolf helpers (Sorceress, Minion) count on neither side: the village wins once the
+	// pack is gone, and a surviving helper does not keep the wolves from winning
 	err := h.db.Get(&counts, `
 		SELECT
-			COALESCE(SUM(CASE WHEN r.team='werewolf' THEN 1 ELSE 0 END), 0) as werewolf_count,
-			COALESCE(SUM(CASE WHEN r.team='villager' THEN 1 ELSE 0 END), 0) as villager_count
+			COALESCE(SUM(CASE WHEN `+wolfPackSQL+` THEN 1 ELSE 0 END), 0) as werewolf_count,
+			COALESCE(SUM(CASE WHEN r.team='villager' THEN 1 ELSE 0 END), 0) as villager_count,
+			COALESCE(SUM(CASE WHEN r.team='serial_killer' THEN 1 ELSE 0 END), 0) as serial_killer_count,
+			COALESCE(SUM(CASE WHEN r.name='White Werewolf' THEN 1 ELSE 0 END), 0) as white_wolf_count
 		FROM game_player g
 		JOIN role r ON g.role_id = r.rowid
 		WHERE g.game_id = ? AND g.is_alive = 1`, game.ID)
@@ -53,42 +134,34 @@ func (h *Hub) checkWinConditions(game *Game) bool {
 		h.logError("checkWinConditions: count teams", err)
 		return false
 	}
-	werewolfCount := counts.Werewolves
-	villagerCount := counts.Villagers
-
-	h.logf("Win check: %d werewolves, %d villagers alive", werewolfCount, villagerCount)
+	h.logf("Win check: %d werewolves, %d villagers, %d serial killers alive", counts.Werewolves, counts.Villagers, counts.SerialKillers)
 
-	if werewolfCount+villagerCount == 2 {
+	survivors := engine.Survivors{
+		Werewolves:    counts.Werewolves,
+		Villagers:     counts.Villagers,
+		SerialKillers: counts.SerialKillers,
+		WhiteWolves:   counts.WhiteWolves,
+		AllCharmed:    piperWins(h.db, game.ID),
+	}
+	if counts.Werewolves+counts.Villagers+counts.SerialKillers == 2 {
 		var alivePlayers []Player
 		h.db.Select(&alivePlayers, `
 			SELECT g.player_id as player_id FROM game_player g
 			WHERE g.game_id = ? AND g.is_alive = 1`, game.ID)
-		if len(alivePlayers) == 2 {
-			if getLoverPartner(h.db, game.ID, alivePlayers[0].PlayerID) == alivePlayers[1].PlayerID {
-				h.logf("LOVERS WIN - last two alive are the lovers")
-				h.endGame(game, "lovers")
-				return true
-			}
-		}
+		survivors.LastTwoLovers = len(alivePlayers) == 2 &&
+			getLoverPartner(h.db, game.ID, alivePlayers[0].PlayerID) == alivePlayers[1].PlayerID
 	}
 
-	if werewolfCount == 0 {
-		h.logf("VILLAGERS WIN - all werewolves eliminated")
-		h.endGame(game, "villagers")
-		return true
-	}
-
-	if villagerCount == 0 {
-		h.logf("WEREWOLVES WIN - all villagers eliminated")
-		h.endGame(game, "werewolves")
-		return true
+	winner := engine.CheckWin(survivors)
+	if winner == engine.NoWinner {
+		return false
 	}
-
-	return false
+	h.logf("Win check: %s win", winner)
+	h.endGame(game, string(winner))
+	return true
 }
 
-// handleWSNewGame resets the game: creates a new lobby game with the same role counts,
-// cleans up the finished game, and puts all connected players into the new lobby.
+// handleWSNewGame resets the finished game into a new lobby.
 func (h *Hub) handleWSNewGame(client *Client) {
 	lang := h.getPlayerLang(client.playerID)
 	game, err := h.getGame()
@@ -104,58 +177,132 @@ func (h *Hub) handleWSNewGame(client *Client) {
 		return
 	}
 
-	var roleConfigs []GameRoleConfig
-	err = h.db.Select(&roleConfigs, "SELECT rowid as id, game_id, role_id, count FROM game_role_config WHERE game_id = ?", game.ID)
+	if h.openNewLobby(game, client.playerID) {
+		h.triggerBroadcast()
+	}
+}
+
+// handleWSAbortGame ends a running game without a winner: the game is marked aborted and
+// everyone goes back to a new lobby with the same setup.
+func (h *Hub) handleWSAbortGame(client *Client) {
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
 	if err != nil {
-		h.logError("handleWSNewGame: db.Select roleConfigs", err)
-		h.sendErrorToast(client.playerID, T(lang, "err_failed_role_config"))
+		h.logError("handleWSAbortGame: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
 		return
 	}
+	if !gameRunning(game) {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_not_running"))
+		return
+	}
+	h.abortGame(game, client.playerID, "'"+h.playerName(client.playerID)+"'")
+}
+
+// abortGame marks a running game aborted and opens the next lobby; by names who aborted it
+// for the log, and playerID is told when it fails.
+func (h *Hub) abortGame(game *Game, playerID int64, by string) bool {
+	if _, err := h.db.Exec("UPDATE game SET status = 'aborted', winner = NULL WHERE rowid = ?", game.ID); err != nil {
+		h.logError("abortGame: update game status", err)
+		h.sendErrorToast(playerID, T(h.getPlayerLang(playerID), "err_failed_abort"))
+		return false
+	}
+	h.logf("Game %d aborted by %s in %s %d", game.ID, by, game.Status, game.Round)
+	game.Status = "aborted"
+
+	if !h.openNewLobby(game, playerID) {
+		return false
+	}
+	for _, pid := range h.connectedPlayerIDs() {
+		h.sendInfoToast(pid, T(h.getPlayerLang(pid), "game_aborted_note"))
+	}
+	h.triggerBroadcast()
+	return true
+}
+
+// openNewLobby replaces a finished or aborted game with a new lobby game with the same role
+// counts, packs and settings, cleans up the old game and puts all connected players into the
+// new lobby. On failure it tells the player who asked and reports false.
+func (h *Hub) openNewLobby(game *Game, playerID int64) bool {
+	lang := h.getPlayerLang(playerID)
+	var roleConfigs []GameRoleConfig
+	err := h.db.Select(&roleConfigs, "SELECT rowid as id, game_id, role_id, count FROM game_role_config WHERE game_id = ?", game.ID)
+	if err != nil {
+		h.logError("openNewLobby: db.Select roleConfigs", err)
+		h.sendErrorToast(playerID, T(lang, "err_failed_role_config"))
+		return false
+	}
+	hidden := hiddenPacks(h.db, game.ID)
+	nightTimer := nightTimerSeconds(h.db, game.ID)
+	dayTimer := dayTimerSeconds(h.db, game.ID)
+	nominations := nominationsEnabled(h.db, game.ID)
+	trials := trialsEnabled(h.db, game.ID)
+	secretVotes := secretVotesEnabled(h.db, game.ID)
+	runoffs := runoffsEnabled(h.db, game.ID)
+	mayorElection := mayorElectionEnabled(h.db, game.ID)
+	lastWords := lastWordsEnabled(h.db, game.ID)
+	var reveal string
+	h.db.Get(&reveal, "SELECT role_reveal FROM game WHERE rowid = ?", game.ID)
+	host := gameHost(h.db, game.ID)
+	seeAll := observersSeeAllEnabled(h.db, game.ID)
+	narrator := narratorModeEnabled(h.db, game.ID)
+	graveyardTalk := graveyardTalkEnabled(h.db, game.ID)
+	whispers := whispersEnabled(h.db, game.ID)
+	chatFilter := chatFilterEnabled(h.db, game.ID)
+	webhook := webhookURL(h.db, game.ID)
 
 	// game.name has a unique index, so the old row must go before the new one can claim the name.
+	// A finished game is archived under an empty name and keeps its record for /history.
 	oldGameID := game.ID
-	h.db.Exec("DELETE FROM game_action WHERE game_id = ?", oldGameID)
-	h.db.Exec("DELETE FROM game_lovers WHERE game_id = ?", oldGameID)
-	h.db.Exec("DELETE FROM game_role_config WHERE game_id = ?", oldGameID)
-	h.db.Exec("DELETE FROM game_player WHERE game_id = ?", oldGameID)
-	h.db.Exec("DELETE FROM game WHERE rowid = ?", oldGameID)
+	if game.Status == "finished" {
+		h.archiveGame(oldGameID)
+	} else {
+		deleteGame(h.db, oldGameID)
+	}
 
-	result, err := h.db.Exec("INSERT INTO game (name, status, round) VALUES (?, 'lobby', 0)", h.gameName)
+	result, err := h.db.Exec("INSERT INTO game (name, status, round, night_timer, day_timer, nominations, trials, secret_votes, runoffs, mayor_election, last_words, role_reveal, host_player_id, observers_see_all, narrator_mode, graveyard_talk, whispers, chat_filter, webhook_url) VALUES (?, 'lobby', 0, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", h.gameName, nightTimer, dayTimer, nominations, trials, secretVotes, runoffs, mayorElection, lastWords, reveal, host, seeAll, narrator, graveyardTalk, whispers, chatFilter, webhook)
 	if err != nil {
-		h.logError("handleWSNewGame: create new game", err)
-		h.sendErrorToast(client.playerID, T(lang, "err_failed_create_game"))
-		return
+		h.logError("openNewLobby: create new game", err)
+		h.sendErrorToast(playerID, T(lang, "err_failed_create_game"))
+		return false
 	}
 	newGameID, _ := result.LastInsertId()
 
 	for _, rc := range roleConfigs {
 		_, err = h.db.Exec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, ?)", newGameID, rc.RoleID, rc.Count)
 		if err != nil {
-			h.logError("handleWSNewGame: copy role config", err)
+			h.logError("openNewLobby: copy role config", err)
 		}
 	}
+	for pack := range hidden {
+		h.db.Exec("INSERT INTO game_hidden_pack (game_id, pack) VALUES (?, ?)", newGameID, pack)
+	}
 
 	playerIDs := h.connectedPlayerIDs()
 	for _, pid := range playerIDs {
 		_, err = h.db.Exec("INSERT OR IGNORE INTO game_player (game_id, player_id) VALUES (?, ?)", newGameID, pid)
 		if err != nil {
-			h.logError("handleWSNewGame: add player to new game", err)
+			h.logError("openNewLobby: add player to new game", err)
 		}
 	}
+	// the narrator carries on narrating
+	if narrator {
+		h.db.Exec("UPDATE game_player SET is_observer = 1 WHERE game_id = ? AND player_id = ?", newGameID, host)
+	}
 
 	h.logf("New game %d created (replaced game %d), %d players added to lobby, %d role configs copied",
 		newGameID, oldGameID, len(playerIDs), len(roleConfigs))
 	h.logDBState("after new game created")
-
-	h.triggerBroadcast()
+	return true
 }
 
 func (h *Hub) endGame(game *Game, winner string) {
-	_, err := h.db.Exec("UPDATE game SET status = 'finished', winner = ? WHERE rowid = ?", winner, game.ID)
+	_, err := h.db.Exec("UPDATE game SET status = 'finished', winner = ?, finished_at = CURRENT_TIMESTAMP WHERE rowid = ?", winner, game.ID)
 	if err != nil {
 		h.logError("endGame: update game status", err)
 		return
 	}
+	h.recordResults(game, winner)
 
 	h.logf("Game %d finished, winner: %s", game.ID, winner)
 	DebugLog("endGame", "Game %d finished, winner: %s", game.ID, winner)
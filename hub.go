@@ -3,26 +3,44 @@ package main
 import (
 	"bytes"
 	"html/template"
-	"log"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/jmoiron/sqlx"
 )
 
 type WSMessage struct {
-	Action          string `json:"action"`
-	RoleID          string `json:"role_id,omitempty"`
-	Delta           string `json:"delta,omitempty"`
-	TargetPlayerID  string `json:"target_player_id,omitempty"`
-	SuspectPlayerID string `json:"suspect_player_id,omitempty"`
-	DeathTheory     string `json:"death_theory,omitempty"`
-	Notes           string `json:"notes,omitempty"`
+	Action             string `json:"action"`
+	RoleID             string `json:"role_id,omitempty"`
+	Delta              string `json:"delta,omitempty"`
+	TargetPlayerID     string `json:"target_player_id,omitempty"`
+	SuspectPlayerID    string `json:"suspect_player_id,omitempty"`
+	DeathTheory        string `json:"death_theory,omitempty"`
+	Notes              string `json:"notes,omitempty"`
+	RoleName           string `json:"role_name,omitempty"`
+	RoleDesc           string `json:"role_desc,omitempty"`
+	RoleTeam           string `json:"role_team,omitempty"`
+	NightAbility       string `json:"night_ability,omitempty"`
+	Charges            string `json:"charges,omitempty"`
+	Shield             string `json:"shield,omitempty"`
+	Pack               string `json:"pack,omitempty"`
+	Channel            string `json:"channel,omitempty"`
+	Message            string `json:"message,omitempty"`
+	Seconds            string `json:"seconds,omitempty"`
+	Reveal             string `json:"reveal,omitempty"`
+	Preset             string `json:"preset,omitempty"`
+	SubstitutePlayerID string `json:"substitute_player_id,omitempty"`
+	WebhookURL         string `json:"webhook_url,omitempty"`
 }
 
 const clientSendBuf = 64 // outbound message buffer per client
 
+// clientWriteWait is how long one message may take to reach a client before the connection
+// is given up on.
+const clientWriteWait = 10 * time.Second
+
 type hubMsg struct {
 	binary bool
 	data   []byte
@@ -34,6 +52,7 @@ type Client struct {
 	hub      *Hub
 	send     chan hubMsg // buffered outbound messages; closed on disconnect
 	lang     string
+	kick     sync.Once // closes the connection of a client that stopped reading (queue)
 }
 
 // Runs in its own goroutine so slow clients never block the hub.
@@ -44,8 +63,11 @@ func (c *Client) writer() {
 		if msg.binary {
 			mt = websocket.BinaryMessage
 		}
+		c.conn.SetWriteDeadline(time.Now().Add(clientWriteWait))
 		if err := c.conn.WriteMessage(mt, msg.data); err != nil {
 			c.hub.logf("WebSocket write error to player %d: %v", c.playerID, err)
+			// the reader fails next and unregisters the client
+			c.conn.Close()
 			return
 		}
 	}
@@ -53,43 +75,78 @@ func (c *Client) writer() {
 
 type Hub struct {
 	clients        map[*websocket.Conn]*Client
+	playerClients  map[int64]map[*websocket.Conn]*Client // clients by player, so sending to one skips the others; guarded by mu
 	broadcast      chan []byte
 	register       chan *Client
 	unregister     chan *websocket.Conn
 	broadcastReqCh chan struct{} // coalescing signal for broadcastGameUpdate
+	voteReqCh      chan struct{} // coalescing signal for broadcastDayVotes
+	voteMu         sync.Mutex
+	voteEntries    map[int64]string // history rows the day votes changed since the last broadcast (noteVoteEntry); guarded by voteMu
 	mu             sync.RWMutex
 	done           chan struct{}
 	wg             sync.WaitGroup
 	clientWg       sync.WaitGroup // tracks active WebSocket reader goroutines
 
-	playerLang      map[int64]string // last-known language per player
+	// moveMu lets one change to the game happen at a time: every WebSocket or API action
+	// (dispatchWSMessage), the admin finishing a game and every timer that moves the game on
+	// (night and day timers, last words, away players) holds it, so two messages can never
+	// resolve the same vote twice.
+	moveMu sync.Mutex
+
+	broadcastWindow time.Duration                        // how long a requested broadcast waits for the rest of its burst
+	after           func(time.Duration) <-chan time.Time // time.After; tests close a burst's window themselves
+
+	namesMu sync.Mutex
+	names   map[int64]string // player names this hub has looked up (playerName); names never change
+
+	playerLang      map[int64]string    // last-known language per player
+	awaySince       map[int64]time.Time // when each player lost their last connection; guarded by mu
+	afkTimeout      time.Duration       // how long a player may be away before their night is played for them
+	lastWordsWindow time.Duration       // how long a lynched or shot player has for their last words
 	db              *sqlx.DB
 	templates       *template.Template
 	storyteller     Storyteller
 	narrator        Narrator
-	storytellerLang string // storyteller language ("en"/"de"); empty = "en"
+	chatFilter      ChatFilter    // checks chat messages in games with the chat filter on
+	webhookSeen     *webhookState // the game as the last broadcast saw it; only the broadcast worker uses it
+	webhookClient   *http.Client  // posts the webhook events; only dials public addresses
+	storytellerLang string        // storyteller language ("en"/"de"); empty = "en"
 	gameName        string
+	timerMu         sync.Mutex
+	nightTimer      *nightTimer                      // the running night's countdown, nil when none
+	dayTimer        *dayTimer                        // the running day's countdown, nil when none
+	pausedLeft      time.Duration                    // what the countdown had left when the game was paused
 	logf            func(format string, args ...any) // routes to log.Printf in prod, t.Logf in tests
 }
 
 func newHub(db *sqlx.DB, templates *template.Template, storyteller Storyteller, narrator Narrator, gameName string) *Hub {
 	h := &Hub{
-		clients:        make(map[*websocket.Conn]*Client),
-		broadcast:      make(chan []byte),
-		register:       make(chan *Client),
-		unregister:     make(chan *websocket.Conn, 64),
-		broadcastReqCh: make(chan struct{}, 1),
-		done:           make(chan struct{}),
-		playerLang:     make(map[int64]string),
-		db:             db,
-		templates:      templates,
-		storyteller:    storyteller,
-		narrator:       narrator,
-		gameName:       gameName,
-	}
-	h.logf = func(format string, args ...any) {
-		log.Printf("[game:"+gameName+"] "+format, args...)
+		clients:         make(map[*websocket.Conn]*Client),
+		playerClients:   make(map[int64]map[*websocket.Conn]*Client),
+		broadcast:       make(chan []byte),
+		register:        make(chan *Client),
+		unregister:      make(chan *websocket.Conn, 64),
+		broadcastReqCh:  make(chan struct{}, 1),
+		voteReqCh:       make(chan struct{}, 1),
+		voteEntries:     make(map[int64]string),
+		done:            make(chan struct{}),
+		playerLang:      make(map[int64]string),
+		names:           make(map[int64]string),
+		awaySince:       make(map[int64]time.Time),
+		afkTimeout:      defaultAFKTimeout,
+		lastWordsWindow: defaultLastWordsWindow,
+		broadcastWindow: defaultBroadcastWindow,
+		after:           time.After,
+		db:              db,
+		templates:       templates,
+		storyteller:     storyteller,
+		narrator:        narrator,
+		chatFilter:      newWordListFilter(defaultFilterWords),
+		webhookClient:   webhookClient,
+		gameName:        gameName,
 	}
+	h.logf = hubLogf(gameName)
 	return h
 }
 
@@ -99,6 +156,10 @@ func (h *Hub) getGame() (*Game, error) {
 
 // routed through hub's logf so tests see it via t.Logf.
 func (h *Hub) logError(context string, err error) {
+	if structuredLog != nil {
+		structuredLog.Error(context, "game", h.gameName, "error", err)
+		return
+	}
 	h.logf("ERROR [%s]: %v", context, err)
 }
 
@@ -138,6 +199,9 @@ func (h *Hub) getPlayerLang(playerID int64) string {
 	return "en"
 }
 
+// defaultBroadcastWindow is how long a broadcast waits for more changes of the same burst.
+const defaultBroadcastWindow = 30 * time.Millisecond
+
 // Multiple rapid calls coalesce into a single broadcast.
 func (h *Hub) triggerBroadcast() {
 	select {
@@ -146,6 +210,39 @@ func (h *Hub) triggerBroadcast() {
 	}
 }
 
+// triggerVoteUpdate asks for the day vote to be sent again (broadcastDayVotes); like
+// triggerBroadcast, rapid calls coalesce.
+func (h *Hub) triggerVoteUpdate() {
+	select {
+	case h.voteReqCh <- struct{}{}:
+	default:
+	}
+}
+
+// waitForBurst holds a requested broadcast back for broadcastWindow, so the rest of a burst
+// (a whole village voting at once) goes out with it, and takes the requests that came in
+// meanwhile: full reports whether one of them was for a full broadcast. ok is false when
+// the hub stops while waiting.
+func (h *Hub) waitForBurst() (full, ok bool) {
+	if h.broadcastWindow > 0 {
+		select {
+		case <-h.after(h.broadcastWindow):
+		case <-h.done:
+			return false, false
+		}
+	}
+	select {
+	case <-h.voteReqCh:
+	default:
+	}
+	select {
+	case <-h.broadcastReqCh:
+		full = true
+	default:
+	}
+	return full, true
+}
+
 // Channels are only closed once all senders have stopped, to avoid
 // "send on closed channel" panics.
 func (h *Hub) stop() {
@@ -166,31 +263,64 @@ func (h *Hub) sendToPlayer(playerID int64, message []byte) {
 	// Only pay for the name lookup when WS logging is actually on — this runs
 	// on every outbound message.
 	if WSLoggingEnabled() {
-		LogWSMessage("OUT", getPlayerName(h.db, playerID), string(message))
+		LogWSMessage("OUT", h.playerName(playerID), string(message))
 	}
 
 	h.mu.RLock()
 	defer h.mu.RUnlock()
-	for _, client := range h.clients {
-		if client.playerID == playerID {
-			select {
-			case client.send <- hubMsg{data: message}:
-			default:
-				h.logf("WebSocket send buffer full for player %d, dropping message", playerID)
-			}
+	for _, client := range h.playerClients[playerID] {
+		h.queue(client, hubMsg{data: message})
+	}
+}
+
+// queue puts msg on the client's outbound queue without waiting, so one slow client never
+// holds up the others. A full queue means the client stopped reading: an audio chunk is
+// dropped, but a page update that never arrives would leave the page stale, so the
+// connection is closed instead; the page reloads when it is back (game.html).
+func (h *Hub) queue(client *Client, msg hubMsg) {
+	select {
+	case client.send <- msg:
+	default:
+		if msg.binary {
+			h.logf("WebSocket audio buffer full for player %d, dropping chunk", client.playerID)
+			return
 		}
+		client.kick.Do(func() {
+			h.logf("WebSocket send queue full for player %d, closing the connection", client.playerID)
+			client.conn.Close()
+		})
 	}
 }
 
+// addClient registers a connection with the hub; the caller holds h.mu.
+func (h *Hub) addClient(client *Client) {
+	h.clients[client.conn] = client
+	conns := h.playerClients[client.playerID]
+	if conns == nil {
+		conns = make(map[*websocket.Conn]*Client)
+		h.playerClients[client.playerID] = conns
+	}
+	conns[client.conn] = client
+}
+
+// removeClient drops a connection from the hub and reports whether it was the player's
+// last one; the caller holds h.mu.
+func (h *Hub) removeClient(client *Client) (lastConn bool) {
+	delete(h.clients, client.conn)
+	conns := h.playerClients[client.playerID]
+	delete(conns, client.conn)
+	if len(conns) == 0 {
+		delete(h.playerClients, client.playerID)
+		return true
+	}
+	return false
+}
+
 func (h *Hub) broadcastAudio(data []byte) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 	for _, client := range h.clients {
-		select {
-		case client.send <- hubMsg{binary: true, data: data}:
-		default:
-			h.logf("WebSocket audio buffer full for player %d, dropping chunk", client.playerID)
-		}
+		h.queue(client, hubMsg{binary: true, data: data})
 	}
 }
 
@@ -198,7 +328,8 @@ func (h *Hub) run() {
 	h.wg.Add(1)
 	defer h.wg.Done()
 
-	// Broadcast worker: drains broadcastReqCh and calls broadcastGameUpdate.
+	// Broadcast worker: drains broadcastReqCh and voteReqCh and sends one render per
+	// burst of requests (waitForBurst).
 	// Runs concurrently with run() so the hub goroutine is never blocked by
 	// the heavy DB + template work inside broadcastGameUpdate.
 	// Tracked by h.wg so stop() waits for it before closing client channels.
@@ -208,7 +339,21 @@ func (h *Hub) run() {
 		for {
 			select {
 			case <-h.broadcastReqCh:
+				if _, ok := h.waitForBurst(); !ok {
+					return
+				}
 				h.broadcastGameUpdate()
+			case <-h.voteReqCh:
+				full, ok := h.waitForBurst()
+				if !ok {
+					return
+				}
+				// a full broadcast sends the votes as well
+				if full {
+					h.broadcastGameUpdate()
+				} else {
+					h.broadcastDayVotes()
+				}
 			case <-h.done:
 				return
 			}
@@ -222,14 +367,15 @@ func (h *Hub) run() {
 
 		case client := <-h.register:
 			h.mu.Lock()
-			h.clients[client.conn] = client
+			h.addClient(client)
+			delete(h.awaySince, client.playerID)
 			if client.lang != "" {
 				h.playerLang[client.playerID] = client.lang
 			}
 			h.mu.Unlock()
 			h.clientWg.Add(1)
 			go client.writer()
-			playerName := getPlayerName(h.db, client.playerID)
+			playerName := h.playerName(client.playerID)
 			h.logf("WebSocket client connected (player %d: %s). Total: %d", client.playerID, playerName, len(h.clients))
 			DebugLog("hub.register", "Player '%s' (ID: %d) connected via WebSocket", playerName, client.playerID)
 			h.addPlayerToLobby(client.playerID)
@@ -240,23 +386,16 @@ func (h *Hub) run() {
 			client, ok := h.clients[conn]
 			if ok {
 				playerID := client.playerID
-				playerName := getPlayerName(h.db, playerID)
-				delete(h.clients, conn)
+				playerName := h.playerName(playerID)
+				lastConn := h.removeClient(client)
 				close(client.send) // signal writer goroutine to exit
 				conn.Close()
 
-				hasOtherConn := false
-				for _, c := range h.clients {
-					if c.playerID == playerID {
-						hasOtherConn = true
-						break
-					}
-				}
-
-				if !hasOtherConn {
+				if lastConn {
 					h.logf("Player '%s' (ID: %d) has no more connections, removing from lobby", playerName, playerID)
 					DebugLog("hub.unregister", "Player '%s' (ID: %d) has no more connections, removing from lobby", playerName, playerID)
 					removePlayerID = playerID
+					h.awaySince[playerID] = time.Now()
 				} else {
 					h.logf("Player '%s' (ID: %d) still has other connections", playerName, playerID)
 					DebugLog("hub.unregister", "Player '%s' (ID: %d) still has other connections", playerName, playerID)
@@ -269,16 +408,13 @@ func (h *Hub) run() {
 			if removePlayerID != 0 {
 				h.removePlayerFromLobby(removePlayerID)
 				h.logf("Removed Player: %d", removePlayerID)
+				h.watchAway(removePlayerID)
 			}
 
 		case message := <-h.broadcast:
 			h.mu.RLock()
 			for _, client := range h.clients {
-				select {
-				case client.send <- hubMsg{data: message}:
-				default:
-					h.logf("WebSocket broadcast buffer full for player %d, dropping message", client.playerID)
-				}
+				h.queue(client, hubMsg{data: message})
 			}
 			h.mu.RUnlock()
 		}
@@ -288,50 +424,61 @@ func (h *Hub) run() {
 func (h *Hub) connectedPlayerIDs() []int64 {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
-	seen := make(map[int64]bool)
-	var ids []int64
-	for _, client := range h.clients {
-		if !seen[client.playerID] {
-			seen[client.playerID] = true
-			ids = append(ids, client.playerID)
-		}
+	ids := make([]int64, 0, len(h.playerClients))
+	for id := range h.playerClients {
+		ids = append(ids, id)
 	}
 	return ids
 }
 
 func (h *Hub) broadcastGameUpdate() {
+	h.takeVoteEntries() // the page sends the whole history
 	game, err := h.getGame()
 	if err != nil {
 		h.logError("broadcastGameUpdate: getGame", err)
 		return
 	}
 
-	players, err := getPlayersByGameId(h.db, game.ID)
+	rs, err := loadRenderState(h, game)
 	if err != nil {
-		h.logError("broadcastGameUpdate: getPlayersByGameId", err)
+		h.logError("broadcastGameUpdate: loadRenderState", err)
 		return
 	}
+	players := rs.Players
+	h.notifyWebhook(game, players)
+
+	// observers get the same updates without a seat among the players
+	recipients := append(append([]Player{}, players...), rs.Observers...)
+	connected := map[int64]bool{}
+	for _, id := range h.connectedPlayerIDs() {
+		connected[id] = true
+	}
 
-	DebugLog("broadcastGameUpdate", "Broadcasting to %d players in game %d (status: %s)", len(players), game.ID, game.Status)
+	DebugLog("broadcastGameUpdate", "Broadcasting to %d players in game %d (status: %s)", len(connected), game.ID, game.Status)
 
-	for _, p := range players {
+	for _, p := range recipients {
+		// nobody to send to: their page is rendered in full when they come back
+		if !connected[p.PlayerID] {
+			continue
+		}
 		// Build all three template outputs and combine into a single WebSocket message.
 		// HTMX processes all hx-swap-oob elements found in one message atomically,
 		// which means clients receive a consistent update in one htmx:wsAfterMessage event.
 		lang := h.getPlayerLang(p.PlayerID)
 		var combined bytes.Buffer
 
-		buf, err := getGameComponent(h, p.PlayerID, game, lang)
+		buf, err := renderGameComponent(h, rs, p.PlayerID, lang)
 		if err != nil {
-			h.logError("broadcastGameUpdate: getGameComponent", err)
+			h.logError("broadcastGameUpdate: renderGameComponent", err)
 			continue
 		}
 		combined.Write(buf.Bytes())
 
 		seerInvestigated := getSeerInvestigated(h.db, game.ID, p.PlayerID)
-		visiblePlayers := applyCardVisibility(p, selfFirstPlayers(players, p.PlayerID), seerInvestigated)
-		viewer := p
+		viewer := drunkView(game, p)
+		visiblePlayers := applyCardVisibility(viewer, selfFirstPlayers(maskDrunkSelf(game, players, p.PlayerID), p.PlayerID), seerInvestigated, viewerReveal(h.db, game.ID, viewer))
 		isLobby := game.Status == "lobby"
+		isHost := rs.HostID == p.PlayerID
 		data := SidebarData{
 			Player:         &viewer,
 			Players:        visiblePlayers,
@@ -341,16 +488,21 @@ func (h *Hub) broadcastGameUpdate() {
 			Lang:           lang,
 			AIAvailable:    h.storyteller != nil || h.narrator != nil,
 			PlayerCards:    buildSidebarCards(visiblePlayers, &viewer, isLobby, lang),
+			CanPause:       gameRunning(game) && isHost,
+			CanUndo:        !isLobby && isHost,
+		}
+		if isHost {
+			data.Substitution = h.buildSubstitutionData(game, p.PlayerID)
+			data.Moderator = h.buildModeratorData(game, p.PlayerID)
+			data.RejoinPlayers = h.buildRejoinChoices(game, p.PlayerID)
 		}
 		h.templates.ExecuteTemplate(&combined, "sidebar.html", data)
 
 		historyEntries := buildHistoryEntries(h.db, p.PlayerID, game, lang)
-		historyBuf, err := getGameHistory(h.db, h.templates, p.PlayerID, game, lang)
-		if err != nil {
-			h.logError("broadcastGameHistory: getGameHistory", err)
+		if err := h.templates.ExecuteTemplate(&combined, "history.html", HistoryData{Lang: lang, Entries: historyEntries}); err != nil {
+			h.logError("broadcastGameUpdate: ExecuteTemplate history", err)
 			continue
 		}
-		combined.Write(historyBuf.Bytes())
 
 		var topbarBuf bytes.Buffer
 		h.templates.ExecuteTemplate(&topbarBuf, "topbar.html", TopbarData{Game: game, HasHistory: len(historyEntries) > 0, Lang: lang})
@@ -365,7 +517,7 @@ func (h *Hub) logDBState(context string) {
 }
 
 func (h *Hub) addPlayerToLobby(playerID int64) {
-	playerName := getPlayerName(h.db, playerID)
+	playerName := h.playerName(playerID)
 
 	game, err := h.getGame()
 	if err != nil {
@@ -373,8 +525,17 @@ func (h *Hub) addPlayerToLobby(playerID int64) {
 		return
 	}
 
+	// a late joiner watches the running game instead
 	if game.Status != "lobby" {
-		DebugLog("addPlayerToLobby", "Player '%s' (ID: %d) cannot join - game status is '%s'", playerName, playerID, game.Status)
+		added, err := addObserver(h.db, game.ID, playerID)
+		if err != nil {
+			h.logError("addPlayerToLobby: addObserver", err)
+			return
+		}
+		if added {
+			h.logf("Player %d (%s) joined game %d as an observer (status: %s)", playerID, playerName, game.ID, game.Status)
+			h.triggerBroadcast()
+		}
 		return
 	}
 
@@ -396,7 +557,7 @@ func (h *Hub) addPlayerToLobby(playerID int64) {
 }
 
 func (h *Hub) removePlayerFromLobby(playerID int64) {
-	playerName := getPlayerName(h.db, playerID)
+	playerName := h.playerName(playerID)
 
 	game, err := h.getGame()
 	if err != nil {
@@ -442,20 +603,14 @@ func handleWebSocket(hub *Hub, w http.ResponseWriter, r *http.Request) {
 	}
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		hub.logf("WebSocket upgrade error for player %d (%s): %v", playerID, playerName, err)
+		hub.logf("WebSocket upgrade error for player %d (%s) from %s: %v", playerID, playerName, clientIP(r), err)
 		return
 	}
 
 	DebugLog("handleWebSocket", "WebSocket upgraded successfully for player '%s' (ID: %d)", playerName, playerID)
 
-	// On reconnect after a disconnect, the player may have been removed from the game.
-	game, err := hub.getGame()
-	if err == nil && game.Status != "lobby" && !isPlayerInGame(hub.db, game.ID, playerID) {
-		DebugLog("handleWebSocket", "Player '%s' (ID: %d) not in game %d, redirecting to index", playerName, playerID, game.ID)
-		conn.WriteMessage(websocket.TextMessage, []byte(`<div id="game-content" hx-swap-oob="innerHTML" hx-on::load="window.location.href='/'"></div>`))
-		conn.Close()
-		return
-	}
+	// A player who is not in a running game (e.g. removed from the lobby on a disconnect
+	// before it started) joins it as an observer when the client registers (addPlayerToLobby).
 
 	client := &Client{conn: conn, playerID: playerID, hub: currentHub, send: make(chan hubMsg, clientSendBuf), lang: getLangFromCookie(r)}
 	currentHub.register <- client
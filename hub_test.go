@@ -1,12 +1,19 @@
 package main
 
 import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/launcher"
+	"github.com/gorilla/websocket"
 )
 
 // TestMain launches a single shared Chromium browser for the entire test suite,
@@ -103,3 +110,227 @@ func TestWebSocketSync(t *testing.T) {
 
 	ctx.logger.Debug("=== Test passed ===")
 }
+
+func TestOthersRolesStayOutOfThePage(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Outsider", "Romeo", "Juliet", "Copy"},
+		[]string{RoleVillager, RoleSeer, RoleWitch, RoleWerewolf})
+	outsider, romeo, juliet, copy := ids[0], ids[1], ids[2], ids[3]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("INSERT INTO game_lovers (game_id, player1_id, player2_id) VALUES (?, ?, ?), (?, ?, ?)", game.ID, romeo, juliet, game.ID, juliet, romeo)
+	ctx.app.db.MustExec("UPDATE game_player SET original_role_id = ? WHERE player_id = ?", RoleDoppelganger, copy)
+
+	players, _ := getPlayersByGameId(ctx.app.db, game.ID)
+	viewer, _ := getPlayerInGame(ctx.app.db, game.ID, outsider)
+	for _, p := range applyCardVisibility(viewer, players, nil, RevealNone) {
+		if p.PlayerID != outsider && (p.RoleId != "" || p.Lover != 0 || p.IsDoppelganger || p.Team != "unknown") {
+			t.Errorf("the outsider should see nothing of %s's role, got %+v", p.Name, p)
+		}
+	}
+	viewer, _ = getPlayerInGame(ctx.app.db, game.ID, romeo)
+	for _, p := range applyCardVisibility(viewer, players, nil, RevealNone) {
+		if p.PlayerID == juliet && p.Lover != romeo {
+			t.Errorf("Romeo should still see that Juliet is his lover")
+		}
+	}
+
+	c := ctx.apiClient()
+	ctx.apiCall(c, "POST", "/api/v1/session", apiSignin{Name: "Outsider", SecretCode: "x"}, nil)
+	resp, err := c.Get(ctx.baseURL + "/game/test-game")
+	if err != nil {
+		t.Fatalf("game page: %v", err)
+	}
+	page, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	for _, leak := range []string{`role-name="Seer"`, `role-name="Witch"`, `role-name="Werewolf"`, `class="pc-heart"`, `class="pc-doppelganger-icon"`} {
+		if strings.Contains(string(page), leak) {
+			t.Errorf("the outsider's page should not contain %s", leak)
+		}
+	}
+}
+
+func TestSendToPlayerReachesOnlyTheirConnections(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	h := ctx.hub()
+	phone := &Client{hub: h, conn: &websocket.Conn{}, playerID: 1, send: make(chan hubMsg, 1)}
+	laptop := &Client{hub: h, conn: &websocket.Conn{}, playerID: 1, send: make(chan hubMsg, 1)}
+	other := &Client{hub: h, conn: &websocket.Conn{}, playerID: 2, send: make(chan hubMsg, 1)}
+	h.mu.Lock()
+	for _, c := range []*Client{phone, laptop, other} {
+		h.addClient(c)
+	}
+	h.mu.Unlock()
+
+	h.sendToPlayer(1, []byte("hi"))
+	if len(phone.send) != 1 || len(laptop.send) != 1 || len(other.send) != 0 {
+		t.Errorf("the message should reach both of player 1's connections and nobody else")
+	}
+
+	h.mu.Lock()
+	lastAfterPhone := h.removeClient(phone)
+	lastAfterLaptop := h.removeClient(laptop)
+	h.removeClient(other)
+	h.mu.Unlock()
+	if lastAfterPhone || !lastAfterLaptop {
+		t.Errorf("only the laptop should be player 1's last connection")
+	}
+	if ids := h.connectedPlayerIDs(); len(ids) != 0 {
+		t.Errorf("nobody should be connected any more, got %v", ids)
+	}
+}
+
+func TestBroadcastRendersFromOneRenderState(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Alice", "Bob", "Carol"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager})
+	h := ctx.hub()
+	alice := &Client{hub: h, conn: &websocket.Conn{}, playerID: ids[0], send: make(chan hubMsg, 4)}
+	h.mu.Lock()
+	h.addClient(alice)
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		h.removeClient(alice)
+		h.mu.Unlock()
+	}()
+
+	h.broadcastGameUpdate()
+	if len(alice.send) != 1 {
+		t.Fatalf("the connected player should get one update, got %d", len(alice.send))
+	}
+	page := string((<-alice.send).data)
+	for _, part := range []string{`id="sidebar"`, `id="history-bar"`, "Carol"} {
+		if !strings.Contains(page, part) {
+			t.Errorf("the update should contain %s", part)
+		}
+	}
+
+	if name := h.playerName(ids[1]); name != "Bob" {
+		t.Fatalf("playerName: got %q", name)
+	}
+	ctx.app.db.MustExec("UPDATE player SET name = 'Robert' WHERE rowid = ?", ids[1])
+	if name := h.playerName(ids[1]); name != "Bob" {
+		t.Errorf("the name should come from the hub's cache, got %q", name)
+	}
+	h.forgetPlayerName(ids[1])
+	if name := h.playerName(ids[1]); name != "Robert" {
+		t.Errorf("a forgotten name should be read again, got %q", name)
+	}
+}
+
+func TestBroadcastBurstSendsOnce(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Alice", "Bob", "Carol"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager})
+	h := ctx.hub()
+	alice := &Client{hub: h, conn: &websocket.Conn{}, playerID: ids[0], send: make(chan hubMsg, 8)}
+	h.mu.Lock()
+	h.addClient(alice)
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		h.removeClient(alice)
+		h.mu.Unlock()
+	}()
+
+	// the test closes each burst's window itself
+	windows := make(chan chan time.Time, 4)
+	h.after = func(time.Duration) <-chan time.Time {
+		closeWindow := make(chan time.Time, 1)
+		windows <- closeWindow
+		return closeWindow
+	}
+
+	// the first vote of a village voting at once opens the window ...
+	h.triggerVoteUpdate()
+	var closeWindow chan time.Time
+	select {
+	case closeWindow = <-windows:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a vote should open a broadcast window")
+	}
+	// ... and every move before it closes asks for an update too
+	for range 4 {
+		h.triggerVoteUpdate()
+		h.triggerBroadcast()
+	}
+	closeWindow <- time.Now()
+
+	select {
+	case msg := <-alice.send:
+		if !strings.Contains(string(msg.data), `id="game-content"`) {
+			t.Errorf("with a full broadcast asked for, the one update should be the full page")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the burst should be sent once its window closes")
+	}
+	// the update is queued after the burst's requests are drained: none may be left for a second one
+	if n := len(alice.send) + len(h.voteReqCh) + len(h.broadcastReqCh) + len(windows); n != 0 {
+		t.Errorf("the burst should be sent as exactly one update, %d more pending", n)
+	}
+}
+
+func TestFullSendQueueClosesTheConnection(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	// a real connection whose browser has stopped reading
+	conns := make(chan *websocket.Conn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		conns <- conn
+	}))
+	defer srv.Close()
+	browser, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer browser.Close()
+
+	h := ctx.hub()
+	slow := &Client{hub: h, conn: <-conns, playerID: 1, send: make(chan hubMsg, 1)}
+	h.mu.Lock()
+	h.addClient(slow)
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		h.removeClient(slow)
+		h.mu.Unlock()
+	}()
+
+	browser.SetReadDeadline(time.Now().Add(2 * time.Second))
+	h.broadcastAudio([]byte{1})
+	h.broadcastAudio([]byte{2})
+	slow.conn.WriteMessage(websocket.TextMessage, []byte("still open"))
+	if _, msg, err := browser.ReadMessage(); string(msg) != "still open" {
+		t.Fatalf("a dropped audio chunk should leave the connection open, got %v", err)
+	}
+
+	<-slow.send
+	h.sendToPlayer(1, []byte("one"))
+	h.sendToPlayer(1, []byte("two"))
+	var netErr net.Error
+	if _, _, err := browser.ReadMessage(); err == nil || errors.As(err, &netErr) && netErr.Timeout() {
+		t.Errorf("a page update that does not fit should close the connection, got %v", err)
+	}
+}
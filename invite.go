@@ -0,0 +1,40 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// A game's invite link is simply its page: /game/{name} sends a visitor who is not signed in
+// to the sign-in form with the game filled in, and joins everyone else straight away. The
+// lobby shows the link and a QR code of it, so the table can join from their phones.
+
+// inviteQRSize is the edge length of the invite QR code in pixels.
+const inviteQRSize = 256
+
+// invitePath is the path of the game's invite link.
+func invitePath(gameName string) string {
+	return "/game/" + url.PathEscape(gameName)
+}
+
+// inviteURL is the absolute invite link, on the host and scheme the request came in on.
+func inviteURL(r *http.Request, gameName string) string {
+	scheme := "http"
+	if isHTTPS(r) {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + invitePath(gameName)
+}
+
+// handleInviteQR serves the QR code of a game's invite link as a PNG.
+func (app *App) handleInviteQR(w http.ResponseWriter, r *http.Request) {
+	gameName := r.PathValue("name")
+	png, err := qrPNG(inviteURL(r, gameName), inviteQRSize)
+	if err != nil {
+		app.logf("ERROR [handleInviteQR: qrPNG]: %v", err)
+		http.Error(w, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(png)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Invite Link Tests
+// ============================================================================
+
+func TestLobbyShowsInviteLink(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"P1", "P2"}, []string{RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+
+	buf, err := getGameComponent(ctx.hub(), ids[1], game, "en")
+	if err != nil || !strings.Contains(buf.String(), `href="/game/test-game"`) || !strings.Contains(buf.String(), `src="/game/test-game/qr"`) {
+		t.Fatalf("the lobby should offer the invite link and its QR code (err: %v)", err)
+	}
+
+	req := httptest.NewRequest("GET", "/game/test-game/qr", nil)
+	req.SetPathValue("name", "test-game")
+	rec := httptest.NewRecorder()
+	ctx.app.handleInviteQR(rec, req)
+	if ct := rec.Header().Get("Content-Type"); rec.Code != http.StatusOK || ct != "image/png" {
+		t.Fatalf("the QR code should be served as a PNG, got %d %q", rec.Code, ct)
+	}
+	data := rec.Body.Bytes()
+	if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+		t.Errorf("the QR code should be a valid PNG: %v", err)
+	}
+}
+
+func TestQRCodeGrowsWithText(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		length, size int
+	}{
+		{14, 21},  // version 1 holds 14 bytes at level M
+		{15, 25},  // one more needs version 2
+		{213, 57}, // version 10 is the largest
+	}
+	for _, c := range cases {
+		q, err := encodeQR(strings.Repeat("a", c.length))
+		if err != nil || q.size != c.size {
+			t.Errorf("%d bytes should give a %dx%d symbol, got %+v (err: %v)", c.length, c.size, c.size, q, err)
+			continue
+		}
+		// the top left finder pattern: a dark ring around a light one around a dark centre
+		if !q.dark[0][0] || q.dark[1][1] || !q.dark[3][3] || q.dark[7][7] {
+			t.Errorf("%d bytes: the finder pattern is off", c.length)
+		}
+	}
+	if _, err := encodeQR(strings.Repeat("a", 214)); err != errQRTooLong {
+		t.Errorf("too long a text should be refused, got %v", err)
+	}
+}
+
+func TestInviteLinkInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the lobby's invite link and QR code ===")
+
+	p1 := browser.signupPlayer(ctx.baseURL, "P1")
+
+	link, err := p1.p().Element("#invite-link")
+	if err != nil {
+		t.Fatalf("the lobby should show the invite link: %v", err)
+	}
+	href, err := link.Property("href")
+	if err != nil || !strings.HasSuffix(href.String(), "/game/test-game") {
+		t.Fatalf("the invite link should lead to the game, got %v (err: %v)", href, err)
+	}
+
+	res, err := p1.p().Eval(`() => fetch(document.querySelector('#invite-qr').src).then(r => r.status + ' ' + r.headers.get('Content-Type'))`)
+	if err != nil || res.Value.String() != "200 image/png" {
+		ctx.logger.LogDB("FAIL: invite QR not served")
+		t.Errorf("the QR code should be served as a PNG, got %v (err: %v)", res, err)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// The leaderboards rank players on their player_game_result rows, so they move as soon as
+// endGame records a game. Win rates only rank players with leaderboardMinGames games behind
+// them, so one lucky game does not top the board.
+
+// leaderboardMinGames is how many games a player needs before their win rate is ranked.
+const leaderboardMinGames = 3
+
+// leaderboardSize is how many players each board shows.
+const leaderboardSize = 10
+
+type LeaderboardRow struct {
+	Rank  int
+	Name  string `db:"name"`
+	Games int    `db:"games"`
+	Wins  int    `db:"wins"`
+}
+
+// WinRate is the share of games won, in percent.
+func (r LeaderboardRow) WinRate() int {
+	if r.Games == 0 {
+		return 0
+	}
+	return r.Wins * 100 / r.Games
+}
+
+// Leaderboard is one ranked table; Title is its translation key.
+type Leaderboard struct {
+	Title string
+	Rows  []LeaderboardRow
+}
+
+type LeaderboardData struct {
+	Role     string // the role of the per-role boards, "" for the global boards
+	Roles    []string
+	Boards   []Leaderboard
+	MinGames int
+	StyleTag template.HTML
+	Lang     string
+}
+
+// leaderboard ranks players on the results matching filter (a condition on r, the
+// player_game_result row), by win rate or by games played.
+func leaderboard(db *sqlx.DB, filter string, byWinRate bool, args ...any) []LeaderboardRow {
+	query := `
+		SELECT p.name, COUNT(*) as games, SUM(r.won) as wins
+		FROM player_game_result r JOIN player p ON p.rowid = r.player_id
+		WHERE ` + filter + `
+		GROUP BY r.player_id`
+	if byWinRate {
+		query += ` HAVING COUNT(*) >= ? ORDER BY CAST(SUM(r.won) AS REAL) / COUNT(*) DESC, games DESC, p.name LIMIT ?`
+		args = append(args, leaderboardMinGames)
+	} else {
+		query += ` ORDER BY games DESC, wins DESC, p.name LIMIT ?`
+	}
+	args = append(args, leaderboardSize)
+	var rows []LeaderboardRow
+	db.Select(&rows, query, args...)
+	for i := range rows {
+		rows[i].Rank = i + 1
+	}
+	return rows
+}
+
+// handleLeaderboard shows the global boards, or one role's at /leaderboard?role=Seer.
+func (app *App) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	data := LeaderboardData{MinGames: leaderboardMinGames, StyleTag: app.pageStyleTag, Lang: getLangFromCookie(r)}
+	app.db.Select(&data.Roles, `SELECT DISTINCT role_name FROM player_game_result ORDER BY role_name`)
+
+	if role := r.URL.Query().Get("role"); role != "" {
+		data.Role = role
+		data.Boards = []Leaderboard{
+			{"leaderboard_win_rate_title", leaderboard(app.db, "r.role_name = ?", true, role)},
+			{"leaderboard_most_games_title", leaderboard(app.db, "r.role_name = ?", false, role)},
+		}
+	} else {
+		data.Boards = []Leaderboard{
+			{"leaderboard_win_rate_title", leaderboard(app.db, "1", true)},
+			{"leaderboard_most_games_title", leaderboard(app.db, "1", false)},
+			{"leaderboard_wolf_win_rate_title", leaderboard(app.db, "r.team = 'werewolf'", true)},
+		}
+	}
+	app.templates.ExecuteTemplate(w, "leaderboard.html", data)
+}
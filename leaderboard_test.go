@@ -0,0 +1,106 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// ============================================================================
+// Leaderboard Tests
+// ============================================================================
+
+func TestLeaderboardRanksPlayers(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0,
+		[]string{"Ann", "Ben", "Cat"},
+		[]string{RoleVillager, RoleVillager, RoleVillager})
+	ann, ben, cat := ids[0], ids[1], ids[2]
+	results := []struct {
+		player    int64
+		role      string
+		team      string
+		won       bool
+		gameCount int
+	}{
+		{ann, "Seer", "villager", true, 2}, {ann, "Werewolf", "werewolf", false, 1},
+		{ben, "Werewolf", "werewolf", true, 3}, {ben, "Villager", "villager", false, 2},
+		{cat, "Seer", "villager", true, 1}, // one lucky game is not enough to rank on win rate
+	}
+	gameID := int64(1000)
+	for _, r := range results {
+		for range r.gameCount {
+			gameID++
+			ctx.app.db.MustExec("INSERT INTO player_game_result (game_id, player_id, role_name, team, won) VALUES (?, ?, ?, ?, ?)",
+				gameID, r.player, r.role, r.team, r.won)
+		}
+	}
+
+	winRate := leaderboard(ctx.app.db, "1", true)
+	if len(winRate) != 2 || winRate[0].Name != "Ann" || winRate[0].WinRate() != 66 || winRate[1].Name != "Ben" || winRate[1].Rank != 2 {
+		t.Errorf("Ann (2 of 3) should lead Ben (3 of 5), without Cat, got %+v", winRate)
+	}
+	if most := leaderboard(ctx.app.db, "1", false); len(most) != 3 || most[0].Name != "Ben" || most[0].Games != 5 {
+		t.Errorf("Ben should have played the most games, got %+v", most)
+	}
+	if wolves := leaderboard(ctx.app.db, "r.team = 'werewolf'", true); len(wolves) != 1 || wolves[0].Name != "Ben" || wolves[0].WinRate() != 100 {
+		t.Errorf("Ben should be the only ranked wolf, got %+v", wolves)
+	}
+
+	rec := httptest.NewRecorder()
+	ctx.app.handleLeaderboard(rec, httptest.NewRequest("GET", "/leaderboard?role=Seer", nil))
+	page := rec.Body.String()
+	if !strings.Contains(page, "Leaderboard: Seer") || !strings.Contains(page, `href="/stats/Cat"`) || strings.Contains(page, `href="/stats/Ben"`) {
+		t.Errorf("the Seer's board should list the Seers only:\n%s", page)
+	}
+}
+
+func TestLeaderboardInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the leaderboard and its role filter ===")
+
+	players, wolf := finishGameInBrowser(ctx, browser)
+	host := players[0]
+
+	host.navigate(ctx.baseURL + "/")
+	host.followLink("#leaderboard-link")
+	for _, p := range players {
+		if has, _, _ := host.p().Has(`.leaderboard-table a[href="/stats/` + p.Name + `"]`); !has {
+			t.Errorf("%s should be on the board after one game", p.Name)
+		}
+	}
+
+	wait := host.p().WaitNavigation(proto.PageLifecycleEventNameLoad)
+	if _, err := host.p().Eval(`() => {
+		const select = document.querySelector('#leaderboard-role');
+		select.value = 'Werewolf';
+		select.dispatchEvent(new Event('change'));
+	}`); err != nil {
+		t.Fatalf("picking the role: %v", err)
+	}
+	wait()
+	if heading, err := host.p().Element("#leaderboard-heading"); err != nil {
+		t.Fatalf("the role's board should open: %v", err)
+	} else if text, _ := heading.Text(); !strings.Contains(text, "Werewolf") {
+		t.Errorf("the board should be the Werewolf's, got %q", text)
+	}
+	for _, p := range players {
+		has, _, _ := host.p().Has(`.leaderboard-table a[href="/stats/` + p.Name + `"]`)
+		if want := p == wolf; has != want {
+			t.Errorf("%s on the Werewolf's board: got %v, want %v", p.Name, has, want)
+		}
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -7,12 +7,37 @@ import (
 )
 
 type LobbyData struct {
-	Players     []Player
+	Players     []Player // names and seats only (publicPlayers)
 	RoleConfigs []RoleConfigDisplay
 	RoleCards   []PlayerCardData
 	TotalRoles  int
 	PlayerCount int
+	RoleSlots   int // PlayerCount plus the Thief's spare cards
+	Packs       []PackToggle
+	Presets     []string // saved role presets, by name
+	NightTimers []NightTimerChoice
+	DayTimers   []DayTimerChoice
+	RoleReveals []RoleRevealChoice
+	Nominations bool // day votes go through nominations
+	Trials      bool // the day vote's front-runner is put on trial
+	SecretVotes bool // day votes stay hidden until the day is over
+	Runoffs     bool // a tied day vote goes to a runoff
+	Election    bool // the game opens with a Mayor election
+	LastWords   bool // lynched and shot players get last words
 	CanStart    bool
+	HostID      int64
+	HostName    string
+	IsHost      bool          // this player runs the lobby
+	Observers   []string      // players watching instead of playing
+	Watching    bool          // this player is one of them
+	SeeAll      bool          // observers and the dead see every role
+	Narrator    bool          // the host narrates instead of playing
+	Graveyard   bool          // the dead may talk in the day chat
+	Whispers    bool          // players may whisper once a day
+	ChatFilter  bool          // chat messages pass the chat filter
+	WebhookURL  string        // where the game posts its events; only filled in for the host
+	Chat        []ChatMessage // the lobby chat so far
+	InviteLink  string        // path of the game's invite link, also the base of its QR code
 	GameID      int64
 	GameStatus  string
 	Lang        string
@@ -42,13 +67,12 @@ func handleWSUpdateRole(client *Client, msg WSMessage) {
 	roleID := msg.RoleID
 	delta := msg.Delta
 
-	// reject role additions once slots already cover every player
+	// reject role additions once slots already cover every player (and the Thief's spare cards)
 	if delta == "1" {
 		var totalRoles int
 		h.db.Get(&totalRoles, "SELECT COALESCE(SUM(count), 0) FROM game_role_config WHERE game_id = ?", game.ID)
-		var playerCount int
-		h.db.Get(&playerCount, "SELECT COUNT(*) FROM game_player WHERE game_id = ?", game.ID)
-		if totalRoles >= playerCount {
+		playerCount := seatedPlayerCount(h.db, game.ID)
+		if totalRoles >= playerCount+spareRoleCount(h.db, game.ID, roleID) {
 			h.logf("Rejected role addition: %d roles already cover all %d players", totalRoles, playerCount)
 			return
 		}
@@ -125,8 +149,14 @@ func handleWSStartGame(client *Client) {
 	}
 	h.logf("Role pool size: %d", len(rolePool))
 
-	if len(rolePool) != len(players) {
-		h.logf("Cannot start: role count (%d) != player count (%d)", len(rolePool), len(players))
+	if narratorModeEnabled(h.db, game.ID) && !isObserver(h.db, game.ID, client.playerID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_narrator_must_watch"))
+		return
+	}
+
+	spareCount := spareRoleCount(h.db, game.ID, "")
+	if len(rolePool) != len(players)+spareCount {
+		h.logf("Cannot start: role count (%d) != player count (%d) + spare cards (%d)", len(rolePool), len(players), spareCount)
 		h.sendErrorToast(client.playerID, T(lang, "err_role_count_mismatch"))
 		return
 	}
@@ -134,11 +164,13 @@ func handleWSStartGame(client *Client) {
 	shuffleRoles(rolePool)
 	h.logf("Roles shuffled, assigning to players...")
 
-	// Joker is never seen in-game — replace each Joker slot with a random non-Joker role
+	// Joker is never seen in-game — replace each Joker slot with a random non-Joker role.
+	// A Joker never turns into a Thief, whose spare cards have to be in the pool up front,
+	// nor into a role from a pack the lobby switched off.
 	var jokerRoleID int64
 	h.db.Get(&jokerRoleID, "SELECT rowid FROM role WHERE name = 'Joker'")
 	var allRoleIDs []int64
-	h.db.Select(&allRoleIDs, "SELECT rowid FROM role WHERE name != 'Joker'")
+	h.db.Select(&allRoleIDs, "SELECT rowid FROM role WHERE name NOT IN ('Joker', 'Thief') AND pack NOT IN (SELECT pack FROM game_hidden_pack WHERE game_id = ?)", game.ID)
 	for i, roleID := range rolePool {
 		if roleID == jokerRoleID {
 			jBig, err := rand.Int(rand.Reader, big.NewInt(int64(len(allRoleIDs))))
@@ -160,11 +192,35 @@ func handleWSStartGame(client *Client) {
 			return
 		}
 	}
+	// the cards nobody was dealt stay face down for the Thief
+	for _, roleID := range rolePool[len(players):] {
+		if _, err := h.db.Exec("INSERT INTO game_spare_role (game_id, role_id) VALUES (?, ?)", game.ID, roleID); err != nil {
+			h.logError("handleWSStartGame: db.Exec insert spare role", err)
+			h.sendErrorToast(client.playerID, T(lang, "err_failed_assign_roles"))
+			return
+		}
+	}
+	if err := seatObservers(h.db, game.ID); err != nil {
+		h.logError("handleWSStartGame: seatObservers", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_assign_roles"))
+		return
+	}
 	h.logf("Roles assigned, updating game status...")
 
-	_, err = h.db.Exec("UPDATE game SET status = 'night', round = 1 WHERE rowid = ?", game.ID)
-	if err != nil {
-		h.logError("handleWSStartGame: db.Exec update game status", err)
+	if len(pendingThieves(h.db, game.ID)) > 0 {
+		_, err = h.db.Exec("UPDATE game SET status = 'setup', round = 0 WHERE rowid = ?", game.ID)
+		if err != nil {
+			h.logError("handleWSStartGame: db.Exec update game status", err)
+			h.sendErrorToast(client.playerID, T(lang, "err_failed_start_game"))
+			return
+		}
+		h.logf("Game status updated to 'setup', waiting for the Thief...")
+		h.triggerBroadcast()
+		return
+	}
+
+	if err := h.startFirstNight(game.ID); err != nil {
+		h.logError("handleWSStartGame: startFirstNight", err)
 		h.sendErrorToast(client.playerID, T(lang, "err_failed_start_game"))
 		return
 	}
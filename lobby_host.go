@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Every game has a host who runs the lobby: the role counts, the settings and the start.
+// The host is game.host_player_id while that player is in the game; otherwise (nobody picked,
+// or the host left the lobby) it falls to whoever joined first.
+
+// hostOnlyActions are the WebSocket actions only the host may send.
+var hostOnlyActions = map[string]bool{
+	"update_role":              true,
+	"create_role":              true,
+	"toggle_pack":              true,
+	"start_game":               true,
+	"set_night_timer":          true,
+	"set_day_timer":            true,
+	"set_role_reveal":          true,
+	"toggle_nominations":       true,
+	"toggle_trials":            true,
+	"toggle_secret_votes":      true,
+	"toggle_runoffs":           true,
+	"toggle_mayor_election":    true,
+	"toggle_last_words":        true,
+	"transfer_host":            true,
+	"save_preset":              true,
+	"load_preset":              true,
+	"pause_game":               true,
+	"resume_game":              true,
+	"undo_resolution":          true,
+	"abort_game":               true,
+	"moderator_kill":           true,
+	"moderator_revive":         true,
+	"moderator_set_role":       true,
+	"moderator_force_phase":    true,
+	"suggest_setup":            true,
+	"toggle_observers_see_all": true,
+	"toggle_narrator_mode":     true,
+	"toggle_graveyard_talk":    true,
+	"toggle_whispers":          true,
+	"toggle_chat_filter":       true,
+	"set_webhook":              true,
+	"toggle_mute":              true,
+	"substitute_player":        true,
+	"issue_rejoin_code":        true,
+}
+
+// gameHost returns the game's host; 0 when nobody is in the game.
+func gameHost(db *sqlx.DB, gameID int64) int64 {
+	var hostID int64
+	db.Get(&hostID, `
+SELECT g.host_player_id FROM game g
+JOIN game_player p ON p.game_id = g.rowid AND p.player_id = g.host_player_id
+WHERE g.rowid = ?`, gameID)
+	if hostID != 0 {
+		return hostID
+	}
+	db.Get(&hostID, "SELECT player_id FROM game_player WHERE game_id = ? ORDER BY rowid LIMIT 1", gameID)
+	return hostID
+}
+
+// requireHost reports whether the client is the game's host, telling them off if not.
+func (h *Hub) requireHost(client *Client, game *Game) bool {
+	if gameHost(h.db, game.ID) == client.playerID {
+		return true
+	}
+	h.logf("Player %d is not the host of game %d", client.playerID, game.ID)
+	h.sendErrorToast(client.playerID, T(h.getPlayerLang(client.playerID), "err_host_only"))
+	return false
+}
+
+// handleWSTransferHost hands the lobby over to another player in the game.
+func handleWSTransferHost(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSTransferHost: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	if _, err := getPlayerInGame(h.db, game.ID, targetID); err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	if _, err := h.db.Exec("UPDATE game SET host_player_id = ? WHERE rowid = ?", targetID, game.ID); err != nil {
+		h.logError("handleWSTransferHost: db.Exec", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_transfer_host"))
+		return
+	}
+	h.logf("'%s' is now the host of game %d", h.playerName(targetID), game.ID)
+	h.triggerBroadcast()
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Lobby Host Tests
+// ============================================================================
+
+func TestOnlyHostChangesLobby(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"Host", "Guest"}, []string{RoleVillager, RoleVillager})
+	host, guest := ids[0], ids[1]
+	game, _ := ctx.hub().getGame()
+
+	if h := gameHost(ctx.app.db, game.ID); h != host {
+		t.Fatalf("the first player to join should be host, got %d", h)
+	}
+
+	ctx.sendWS(guest, WSMessage{Action: "set_night_timer", Seconds: "90"})
+	if s := nightTimerSeconds(ctx.app.db, game.ID); s != 0 {
+		t.Errorf("a guest should not change the settings, got a %ds night timer", s)
+	}
+	ctx.sendWS(guest, WSMessage{Action: "start_game"})
+	if status, _, _ := ctx.gameState(); status != "lobby" {
+		t.Fatalf("a guest should not start the game, got %q", status)
+	}
+
+	buf, err := getGameComponent(ctx.hub(), guest, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="host-only-note"`) || strings.Contains(buf.String(), `id="btn-start"`) {
+		t.Errorf("a guest should see who hosts instead of the start button (err: %v)", err)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "set_night_timer", Seconds: "90"})
+	if s := nightTimerSeconds(ctx.app.db, game.ID); s != 90 {
+		t.Errorf("the host should change the settings, got a %ds night timer", s)
+	}
+}
+
+func TestTransferHost(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"Host", "Guest", "Other"}, []string{RoleVillager, RoleVillager, RoleVillager})
+	host, guest := ids[0], ids[1]
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(guest, WSMessage{Action: "transfer_host", TargetPlayerID: strconv.FormatInt(guest, 10)})
+	if h := gameHost(ctx.app.db, game.ID); h != host {
+		t.Fatalf("a guest should not take the lobby, host is %d", h)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "transfer_host", TargetPlayerID: strconv.FormatInt(guest, 10)})
+	if h := gameHost(ctx.app.db, game.ID); h != guest {
+		t.Fatalf("the lobby should be handed to the guest, host is %d", h)
+	}
+	ctx.sendWS(host, WSMessage{Action: "toggle_trials"})
+	if trialsEnabled(ctx.app.db, game.ID) {
+		t.Error("the former host should no longer change the settings")
+	}
+
+	// when the host leaves the lobby it falls back to the first player to join
+	ctx.app.db.MustExec("DELETE FROM game_player WHERE game_id = ? AND player_id = ?", game.ID, guest)
+	if h := gameHost(ctx.app.db, game.ID); h != host {
+		t.Errorf("the lobby should fall back to the first to join, host is %d", h)
+	}
+}
+
+func TestHostAbortsGame(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 2, []string{"Host", "Wolf", "V1"}, []string{RoleVillager, RoleWerewolf, RoleVillager})
+	host := ids[0]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, 2), (?, ?, 1)", game.ID, RoleVillager, game.ID, RoleWerewolf)
+	ctx.app.db.MustExec("UPDATE game SET night_timer = 90 WHERE rowid = ?", game.ID)
+
+	ctx.sendWS(ids[1], WSMessage{Action: "abort_game"})
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("only the host should abort the game, got %q", status)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "abort_game"})
+	status, round, winner := ctx.gameState()
+	if status != "lobby" || round != 0 || winner != "" {
+		t.Fatalf("everyone should be back in a lobby without a winner, got %q round %d winner %q", status, round, winner)
+	}
+	lobby, _ := ctx.hub().getGame()
+	// nobody is connected here, so the new lobby starts empty
+	if n := seatedPlayerCount(ctx.app.db, lobby.ID); n != 0 {
+		t.Errorf("the aborted game's seats should be cleaned up, %d left", n)
+	}
+	var configured int
+	ctx.app.db.Get(&configured, "SELECT IFNULL(SUM(count), 0) FROM game_role_config WHERE game_id = ?", lobby.ID)
+	if configured != 3 {
+		t.Errorf("the role counts should carry over, got %d", configured)
+	}
+	if s := nightTimerSeconds(ctx.app.db, lobby.ID); s != 90 {
+		t.Errorf("the settings should carry over, got a %ds night", s)
+	}
+	var newHost int64
+	ctx.app.db.Get(&newHost, "SELECT host_player_id FROM game WHERE rowid = ?", lobby.ID)
+	if newHost != host {
+		t.Errorf("the host should keep the new lobby, host is %d", newHost)
+	}
+}
+
+func TestTransferHostInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing handing the lobby to another player ===")
+
+	host := browser.signupPlayer(ctx.baseURL, "Host")
+	guest := browser.signupPlayer(ctx.baseURL, "Guest")
+
+	note, err := guest.p().Element("#host-only-note")
+	if err != nil {
+		t.Fatalf("a guest should see who hosts instead of the start button: %v", err)
+	}
+	if text, _ := note.Text(); !strings.Contains(text, "Host") {
+		t.Errorf("the note should name the host, got %q", text)
+	}
+
+	host.submitFormWithValues("transfer-host-form", map[string]string{"target_player_id": guest.getPlayerID()})
+	if err := guest.waitUntilCondition(`() => !!document.querySelector('#btn-start')`, "start button for new host"); err != nil {
+		ctx.logger.LogDB("FAIL: host not transferred")
+		t.Fatalf("the guest should now host the lobby: %v", err)
+	}
+	if err := host.waitUntilCondition(`() => !!document.querySelector('#host-only-note')`, "host-only note for former host"); err != nil {
+		t.Errorf("the former host should now wait for the guest: %v", err)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
+
+func TestAbortGameInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the host aborting the game ===")
+
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"Host", "P2", "P3"}, RoleWerewolf, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	host := players[0]
+
+	host.acceptConfirms()
+	host.clickAndWait("#btn-abort-game")
+	for _, p := range players {
+		if err := p.waitUntilCondition(`() => document.querySelector('#game-content')?.dataset.phase === 'lobby'`, "back in the lobby"); err != nil {
+			ctx.logger.LogDB("FAIL: game not aborted")
+			t.Fatalf("%s should be back in the lobby: %v", p.Name, err)
+		}
+	}
+	if has, _, _ := host.p().Has("#btn-start"); !has {
+		t.Error("the host should keep the new lobby")
+	}
+	if count := host.getRoleCountByID(RoleVillager); count != "2" {
+		t.Errorf("the role counts should carry over, got %s villagers", count)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
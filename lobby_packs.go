@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Role packs group the roles the lobby offers. The base pack is always offered; the others
+// can be switched off per game.
+const (
+	PackBase     = "base"
+	PackDaybreak = "daybreak"
+	PackBonus    = "bonus"
+	PackCustom   = "custom" // roles created in the lobby
+)
+
+var rolePackOrder = []string{PackBase, PackDaybreak, PackBonus, PackCustom}
+
+// rolePackMembers lists the built-in roles outside the base pack. Any role not listed here
+// (and not created in the lobby) stays in the base pack.
+var rolePackMembers = map[string][]string{
+	PackDaybreak: {"Sorceress", "Alpha Werewolf", "Minion", "Bodyguard", "Apprentice Seer", "Aura Seer",
+		"Lycan", "Priest", "Spellcaster", "Drunk", "Tough Guy", "Diseased", "Cursed"},
+	PackBonus: {"Tanner", "Prince", "Mayor", "Serial Killer", "Piper", "White Werewolf", "Fox", "Elder",
+		"Scapegoat", "Thief", "Wild Child", "Village Idiot"},
+}
+
+type PackToggle struct {
+	Pack    string
+	Offered bool
+	Locked  bool // the base pack cannot be switched off
+}
+
+// assignRolePacks files the built-in roles into their packs. It runs on every start, so roles
+// seeded before packs existed move over too.
+func assignRolePacks(db *sqlx.DB) error {
+	for pack, names := range rolePackMembers {
+		query, args, err := sqlx.In("UPDATE role SET pack = ? WHERE name IN (?)", pack, names)
+		if err != nil {
+			return err
+		}
+		if _, err := db.Exec(query, args...); err != nil {
+			return fmt.Errorf("assign %s pack: %w", pack, err)
+		}
+	}
+	return nil
+}
+
+// hiddenPacks returns the packs switched off for the game.
+func hiddenPacks(db *sqlx.DB, gameID int64) map[string]bool {
+	var packs []string
+	db.Select(&packs, "SELECT pack FROM game_hidden_pack WHERE game_id = ?", gameID)
+	hidden := make(map[string]bool, len(packs))
+	for _, p := range packs {
+		hidden[p] = true
+	}
+	return hidden
+}
+
+// packToggles lists every pack in display order with whether the game offers it.
+func packToggles(db *sqlx.DB, gameID int64) []PackToggle {
+	hidden := hiddenPacks(db, gameID)
+	toggles := make([]PackToggle, 0, len(rolePackOrder))
+	for _, p := range rolePackOrder {
+		toggles = append(toggles, PackToggle{Pack: p, Offered: !hidden[p], Locked: p == PackBase})
+	}
+	return toggles
+}
+
+// handleWSTogglePack switches a pack on or off for the lobby. Switching a pack off also drops
+// its roles from the game's role config, so nothing hidden is dealt.
+func handleWSTogglePack(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSTogglePack: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+
+	known := false
+	for _, p := range rolePackOrder {
+		known = known || p == msg.Pack
+	}
+	if !known || msg.Pack == PackBase {
+		h.sendErrorToast(client.playerID, T(lang, "err_unknown_pack"))
+		return
+	}
+
+	if hiddenPacks(h.db, game.ID)[msg.Pack] {
+		h.db.Exec("DELETE FROM game_hidden_pack WHERE game_id = ? AND pack = ?", game.ID, msg.Pack)
+		h.logf("Pack '%s' offered again", msg.Pack)
+	} else {
+		if _, err := h.db.Exec("INSERT OR IGNORE INTO game_hidden_pack (game_id, pack) VALUES (?, ?)", game.ID, msg.Pack); err != nil {
+			h.logError("handleWSTogglePack: db.Exec hide pack", err)
+			h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_pack"))
+			return
+		}
+		h.db.Exec("DELETE FROM game_role_config WHERE game_id = ? AND role_id IN (SELECT rowid FROM role WHERE pack = ?)", game.ID, msg.Pack)
+		h.logf("Pack '%s' hidden, its roles removed from the config", msg.Pack)
+	}
+
+	h.triggerBroadcast()
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Role Pack Tests
+// ============================================================================
+
+func TestTogglePackHidesItsRoles(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"P1", "P2"}, []string{RoleVillager, RoleVillager})
+	p1 := ids[0]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, 1), (?, ?, 1)",
+		game.ID, RoleSeer, game.ID, RoleTanner)
+
+	lobbyHTML := func() string {
+		buf, err := getGameComponent(ctx.hub(), p1, game, "en")
+		if err != nil {
+			t.Fatalf("getGameComponent: %v", err)
+		}
+		return buf.String()
+	}
+	if html := lobbyHTML(); !strings.Contains(html, `id="role-`+RoleTanner+`"`) {
+		t.Fatal("every pack should be offered by default")
+	}
+
+	ctx.sendWS(p1, WSMessage{Action: "toggle_pack", Pack: PackBonus})
+	html := lobbyHTML()
+	if strings.Contains(html, `id="role-`+RoleTanner+`"`) {
+		t.Error("the Tanner belongs to the bonus pack and should no longer be offered")
+	}
+	if !strings.Contains(html, `id="role-`+RoleSeer+`"`) {
+		t.Error("base roles should still be offered")
+	}
+	var count int
+	ctx.app.db.Get(&count, "SELECT COALESCE(SUM(count), 0) FROM game_role_config WHERE game_id = ?", game.ID)
+	if count != 1 {
+		t.Errorf("hiding a pack should drop its roles from the config, %d roles left", count)
+	}
+
+	ctx.sendWS(p1, WSMessage{Action: "toggle_pack", Pack: PackBase})
+	if hiddenPacks(ctx.app.db, game.ID)[PackBase] {
+		t.Error("the base pack cannot be switched off")
+	}
+
+	ctx.sendWS(p1, WSMessage{Action: "toggle_pack", Pack: PackBonus})
+	if html := lobbyHTML(); !strings.Contains(html, `id="role-`+RoleTanner+`"`) {
+		t.Error("toggling the pack again should offer its roles again")
+	}
+}
+
+func TestJokerSkipsHiddenPacks(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"P1", "P2", "P3"}, []string{RoleVillager, RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, 3)", game.ID, RoleJoker)
+	for _, pack := range []string{PackDaybreak, PackBonus, PackCustom} {
+		ctx.sendWS(ids[0], WSMessage{Action: "toggle_pack", Pack: pack})
+	}
+
+	ctx.sendWS(ids[0], WSMessage{Action: "start_game"})
+	var packs []string
+	ctx.app.db.Select(&packs, "SELECT r.pack FROM game_player g JOIN role r ON g.role_id = r.rowid WHERE g.game_id = ?", game.ID)
+	if len(packs) != 3 {
+		t.Fatalf("expected 3 dealt roles, got %d", len(packs))
+	}
+	for _, p := range packs {
+		if p != PackBase {
+			t.Errorf("a Joker must not draw from a hidden pack, got a %s role", p)
+		}
+	}
+}
+
+func TestTogglePackInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the host switching off the bonus pack ===")
+
+	host := browser.signupPlayer(ctx.baseURL, "Host")
+	guest := browser.signupPlayer(ctx.baseURL, "Guest")
+
+	if _, err := guest.p().Element("#role-" + RoleTanner); err != nil {
+		t.Fatalf("every pack should be offered by default: %v", err)
+	}
+
+	host.toggleLobbySetting("pack-" + PackBonus)
+	if err := guest.waitUntilCondition(`() => !document.querySelector('#role-`+RoleTanner+`')`, "Tanner card gone"); err != nil {
+		ctx.logger.LogDB("FAIL: bonus pack still offered")
+		t.Errorf("the Tanner belongs to the bonus pack and should no longer be offered: %v", err)
+	}
+	if _, err := guest.p().Element("#role-" + RoleSeer); err != nil {
+		t.Errorf("base roles should still be offered: %v", err)
+	}
+
+	host.toggleLobbySetting("pack-" + PackBonus)
+	if err := guest.waitUntilCondition(`() => !!document.querySelector('#role-`+RoleTanner+`')`, "Tanner card back"); err != nil {
+		ctx.logger.LogDB("FAIL: bonus pack not offered again")
+		t.Errorf("toggling the pack again should offer its roles again: %v", err)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Role presets are named role configurations ("Classic 8p") the host can save from the lobby
+// and load back into any later game. They are kept in role_preset, one row per role.
+
+// presetNameMax caps a preset's name, like a custom role's.
+const presetNameMax = 32
+
+// rolePresets returns the saved presets' names in alphabetical order.
+func rolePresets(db *sqlx.DB) []string {
+	var names []string
+	db.Select(&names, "SELECT DISTINCT name FROM role_preset ORDER BY name")
+	return names
+}
+
+// handleWSSavePreset stores the lobby's role counts under a name, replacing an older preset
+// of the same name.
+func handleWSSavePreset(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSavePreset: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+
+	name := strings.TrimSpace(msg.Preset)
+	if name == "" || len(name) > presetNameMax {
+		h.sendErrorToast(client.playerID, T(lang, "err_preset_name"))
+		return
+	}
+	var configs []GameRoleConfig
+	h.db.Select(&configs, "SELECT rowid as id, game_id, role_id, count FROM game_role_config WHERE game_id = ? AND count > 0", game.ID)
+	if len(configs) == 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_preset_empty"))
+		return
+	}
+
+	h.db.Exec("DELETE FROM role_preset WHERE name = ?", name)
+	for _, rc := range configs {
+		if _, err := h.db.Exec("INSERT INTO role_preset (name, role_id, count) VALUES (?, ?, ?)", name, rc.RoleID, rc.Count); err != nil {
+			h.logError("handleWSSavePreset: insert", err)
+			h.sendErrorToast(client.playerID, T(lang, "err_failed_save_preset"))
+			return
+		}
+	}
+	h.logf("Role preset '%s' saved (%d roles)", name, len(configs))
+	h.triggerBroadcast()
+}
+
+// handleWSLoadPreset replaces the lobby's role counts with a saved preset. Roles from packs
+// switched off for this game are left out, as the lobby would not offer them.
+func handleWSLoadPreset(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSLoadPreset: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+
+	var rows []struct {
+		RoleID int64  `db:"role_id"`
+		Count  int    `db:"count"`
+		Pack   string `db:"pack"`
+	}
+	h.db.Select(&rows, `SELECT p.role_id, p.count, r.pack FROM role_preset p JOIN role r ON r.rowid = p.role_id WHERE p.name = ?`, msg.Preset)
+	if len(rows) == 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_unknown_preset"))
+		return
+	}
+
+	hidden := hiddenPacks(h.db, game.ID)
+	h.db.Exec("DELETE FROM game_role_config WHERE game_id = ?", game.ID)
+	for _, r := range rows {
+		if hidden[r.Pack] {
+			continue
+		}
+		if _, err := h.db.Exec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, ?)", game.ID, r.RoleID, r.Count); err != nil {
+			h.logError("handleWSLoadPreset: insert", err)
+		}
+	}
+	h.logf("Role preset '%s' loaded into game %d", msg.Preset, game.ID)
+	h.logDBState("after preset load")
+	h.triggerBroadcast()
+}
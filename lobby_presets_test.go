@@ -0,0 +1,117 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Role Preset Tests
+// ============================================================================
+
+func TestSaveAndLoadRolePreset(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"P1", "P2", "P3"}, []string{RoleVillager, RoleVillager, RoleVillager})
+	p1 := ids[0]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, 1), (?, ?, 1), (?, ?, 1)",
+		game.ID, RoleWerewolf, game.ID, RoleSeer, game.ID, RoleTanner)
+
+	roleCount := func() int {
+		var count int
+		ctx.app.db.Get(&count, "SELECT COALESCE(SUM(count), 0) FROM game_role_config WHERE game_id = ?", game.ID)
+		return count
+	}
+
+	ctx.sendWS(p1, WSMessage{Action: "save_preset", Preset: "  Classic 3p  "})
+	if names := rolePresets(ctx.app.db); len(names) != 1 || names[0] != "Classic 3p" {
+		t.Fatalf("the preset should be saved under its trimmed name, got %v", names)
+	}
+	buf, err := getGameComponent(ctx.hub(), p1, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `<option value="Classic 3p">`) {
+		t.Errorf("the host should be offered the saved preset (err: %v)", err)
+	}
+
+	ctx.app.db.MustExec("DELETE FROM game_role_config WHERE game_id = ?", game.ID)
+	ctx.sendWS(p1, WSMessage{Action: "load_preset", Preset: "Classic 3p"})
+	if n := roleCount(); n != 3 {
+		t.Fatalf("loading the preset should restore all 3 roles, got %d", n)
+	}
+
+	// a hidden pack's roles are left out
+	ctx.sendWS(p1, WSMessage{Action: "toggle_pack", Pack: PackBonus})
+	ctx.sendWS(p1, WSMessage{Action: "load_preset", Preset: "Classic 3p"})
+	if n := roleCount(); n != 2 {
+		t.Errorf("the Tanner's pack is off, so only 2 roles should load, got %d", n)
+	}
+}
+
+func TestRolePresetRejections(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"P1", "P2"}, []string{RoleVillager, RoleVillager})
+	p1, p2 := ids[0], ids[1]
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(p1, WSMessage{Action: "save_preset", Preset: "Empty"})
+	if names := rolePresets(ctx.app.db); len(names) != 0 {
+		t.Errorf("an empty role config should not be saved, got %v", names)
+	}
+
+	ctx.app.db.MustExec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, 2)", game.ID, RoleVillager)
+	ctx.sendWS(p2, WSMessage{Action: "save_preset", Preset: "Guest"})
+	if names := rolePresets(ctx.app.db); len(names) != 0 {
+		t.Errorf("only the host should save presets, got %v", names)
+	}
+
+	ctx.sendWS(p1, WSMessage{Action: "load_preset", Preset: "Missing"})
+	var count int
+	ctx.app.db.Get(&count, "SELECT COALESCE(SUM(count), 0) FROM game_role_config WHERE game_id = ?", game.ID)
+	if count != 2 {
+		t.Errorf("an unknown preset should leave the roles alone, got %d", count)
+	}
+}
+
+func TestSaveAndLoadRolePresetInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing saving and loading a role preset ===")
+
+	host := browser.signupPlayer(ctx.baseURL, "Host")
+	browser.signupPlayer(ctx.baseURL, "Guest")
+	host.addRoleByID(RoleWerewolf)
+	host.addRoleByID(RoleSeer)
+
+	host.submitFormWithValues("save-preset-form", map[string]string{"preset": "Duo"})
+	if _, err := host.p().Element("#load-preset-name option[value='Duo']"); err != nil {
+		ctx.logger.LogDB("FAIL: preset not offered")
+		t.Fatalf("the host should be offered the saved preset: %v", err)
+	}
+
+	// the suggestion for two players swaps the Seer for a Villager
+	host.clickAndWait("#btn-suggest-setup")
+	if c := host.getRoleCountByID(RoleSeer); c != "0" {
+		t.Fatalf("the suggested setup should have no Seer, got %s", c)
+	}
+
+	host.submitFormWithValues("load-preset-form", map[string]string{"preset": "Duo"})
+	if c := host.getRoleCountByID(RoleSeer); c != "1" {
+		ctx.logger.LogDB("FAIL: preset not loaded")
+		t.Errorf("loading the preset should bring the Seer back, got %s", c)
+	}
+	if c := host.getRoleCountByID(RoleVillager); c != "0" {
+		t.Errorf("loading the preset should replace the Villager, got %s", c)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
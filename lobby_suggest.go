@@ -0,0 +1,95 @@
+package main
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// roleSuggestion is one row of the balance table: from MinPlayers on, the suggested setup
+// deals one more Role card.
+type roleSuggestion struct {
+	MinPlayers int
+	Role       string
+}
+
+// roleSuggestionTable drives "suggest setup": roughly one werewolf per four players, the
+// village's information and protection roles first, and Villagers for every seat left over.
+// Tune the balance here; rows from a pack switched off for the game are skipped.
+var roleSuggestionTable = []roleSuggestion{
+	{2, "Werewolf"},
+	{4, "Seer"},
+	{5, "Doctor"},
+	{7, "Werewolf"},
+	{7, "Hunter"},
+	{8, "Witch"},
+	{9, "Cupid"},
+	{10, "Guard"},
+	{11, "Werewolf"},
+	{12, "Mason"},
+	{12, "Mason"},
+	{13, "Bodyguard"},
+	{14, "Tanner"},
+	{15, "Werewolf"},
+	{16, "Fox"},
+}
+
+// suggestedSetup returns the role counts the balance table suggests for playerCount players,
+// by role name; the seats no row fills are Villagers.
+func suggestedSetup(db *sqlx.DB, gameID int64, playerCount int) map[string]int {
+	var packs []struct {
+		Name string `db:"name"`
+		Pack string `db:"pack"`
+	}
+	db.Select(&packs, "SELECT name, pack FROM role")
+	packOf := make(map[string]string, len(packs))
+	for _, r := range packs {
+		packOf[r.Name] = r.Pack
+	}
+	hidden := hiddenPacks(db, gameID)
+
+	setup := map[string]int{}
+	dealt := 0
+	for _, s := range roleSuggestionTable {
+		if s.MinPlayers > playerCount || dealt == playerCount || hidden[packOf[s.Role]] {
+			continue
+		}
+		setup[s.Role]++
+		dealt++
+	}
+	if dealt < playerCount {
+		setup["Villager"] += playerCount - dealt
+	}
+	return setup
+}
+
+// handleWSSuggestSetup replaces the lobby's role counts with the balance table's suggestion
+// for the players in the lobby.
+func handleWSSuggestSetup(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSuggestSetup: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+
+	playerCount := seatedPlayerCount(h.db, game.ID)
+	if playerCount < 2 {
+		h.sendErrorToast(client.playerID, T(lang, "err_suggest_needs_players"))
+		return
+	}
+
+	h.db.Exec("DELETE FROM game_role_config WHERE game_id = ?", game.ID)
+	for name, count := range suggestedSetup(h.db, game.ID, playerCount) {
+		if _, err := h.db.Exec("INSERT INTO game_role_config (game_id, role_id, count) SELECT ?, rowid, ? FROM role WHERE name = ?", game.ID, count, name); err != nil {
+			h.logError("handleWSSuggestSetup: insert", err)
+		}
+	}
+	h.logf("Suggested a setup for %d players in game %d", playerCount, game.ID)
+	h.logDBState("after setup suggestion")
+	h.triggerBroadcast()
+}
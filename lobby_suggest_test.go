@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+)
+
+// ============================================================================
+// Setup Suggestion Tests
+// ============================================================================
+
+func TestSuggestedSetupFillsEverySeat(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ctx.seedGame("lobby", 0, []string{"P1"}, []string{RoleVillager})
+	game, _ := ctx.hub().getGame()
+
+	for _, n := range []int{2, 5, 8, 12, 20} {
+		setup := suggestedSetup(ctx.app.db, game.ID, n)
+		total := 0
+		for _, c := range setup {
+			total += c
+		}
+		if total != n {
+			t.Errorf("%d players: the setup should deal %d cards, got %d (%v)", n, n, total, setup)
+		}
+		if setup["Werewolf"] < 1 || setup["Werewolf"] > (n+3)/4 {
+			t.Errorf("%d players: expected about one werewolf per four players, got %d", n, setup["Werewolf"])
+		}
+	}
+	if s := suggestedSetup(ctx.app.db, game.ID, 8); s["Werewolf"] != 2 || s["Seer"] != 1 || s["Witch"] != 1 {
+		t.Errorf("8 players should get 2 werewolves, a Seer and a Witch, got %v", s)
+	}
+}
+
+func TestSuggestSetupFillsTheLobby(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	names := []string{"P1", "P2", "P3", "P4", "P5", "P6", "P7", "P8", "P9", "P10", "P11", "P12", "P13"}
+	roles := make([]string, len(names))
+	for i := range roles {
+		roles[i] = RoleVillager
+	}
+	ids := ctx.seedGame("lobby", 0, names, roles)
+	game, _ := ctx.hub().getGame()
+	ctx.sendWS(ids[0], WSMessage{Action: "toggle_pack", Pack: PackDaybreak})
+
+	ctx.sendWS(ids[1], WSMessage{Action: "suggest_setup"})
+	var total int
+	ctx.app.db.Get(&total, "SELECT COALESCE(SUM(count), 0) FROM game_role_config WHERE game_id = ?", game.ID)
+	if total != 0 {
+		t.Fatalf("only the host should ask for a suggestion, got %d roles", total)
+	}
+
+	ctx.sendWS(ids[0], WSMessage{Action: "suggest_setup"})
+	ctx.app.db.Get(&total, "SELECT COALESCE(SUM(count), 0) FROM game_role_config WHERE game_id = ?", game.ID)
+	if total != len(names) {
+		t.Errorf("the suggestion should cover all %d players, got %d roles", len(names), total)
+	}
+	var bodyguards int
+	ctx.app.db.Get(&bodyguards, "SELECT COUNT(*) FROM game_role_config WHERE game_id = ? AND role_id = ?", game.ID, RoleBodyguard)
+	if bodyguards != 0 {
+		t.Error("the Bodyguard's pack is off, so it should not be suggested")
+	}
+}
+
+func TestSuggestSetupInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the suggested setup for four players ===")
+
+	var players []*TestPlayer
+	for _, name := range []string{"P1", "P2", "P3", "P4"} {
+		players = append(players, browser.signupPlayer(ctx.baseURL, name))
+	}
+	host := players[0]
+
+	host.clickAndWait("#btn-suggest-setup")
+	want := map[string]string{RoleWerewolf: "1", RoleSeer: "1", RoleVillager: "2"}
+	for id, count := range want {
+		if c := host.getRoleCountByID(id); c != count {
+			ctx.logger.LogDB("FAIL: unexpected suggestion")
+			t.Errorf("role %s: expected %s cards, got %s", id, count, c)
+		}
+	}
+	if !host.canStartGame() {
+		t.Error("the suggested setup should fill every seat, so the game can start")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
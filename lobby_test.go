@@ -6,6 +6,19 @@ import (
 	"testing"
 )
 
+// ============================================================================
+// Lobby Setting Helpers
+// ============================================================================
+
+// toggleLobbySetting flips one of the host's lobby switches (a pack, a day rule, a chat
+// option) or picks a timer or reveal choice, by the id of its label.
+func (tp *TestPlayer) toggleLobbySetting(labelID string) {
+	if tp.logger != nil {
+		tp.logger.Debug("[%s] Toggling lobby setting: %s", tp.Name, labelID)
+	}
+	tp.clickAndWait("#" + labelID + " input")
+}
+
 // ============================================================================
 // Lobby Player Count Tests
 // ============================================================================
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// The server log goes to stdout and to log_file (werewolf.log). The file is rotated instead
+// of growing for the whole party: at start, once it reaches log_max_size_mb and once it is
+// older than log_max_age. The current file is renamed to werewolf.log.1, the one before
+// that to .2 and so on; log_keep of them are kept and older ones deleted. A log_file of ""
+// or "off" writes no file at all.
+
+// rotatingFile is an io.Writer over a log file that rotates itself.
+type rotatingFile struct {
+	path    string
+	maxSize int64         // rotate before a write would take the file past this; 0 = no limit
+	maxAge  time.Duration // rotate once the file is this old; 0 = no limit
+	keep    int           // rotated files kept
+
+	mu      sync.Mutex
+	file    *os.File
+	size    int64
+	started time.Time
+	now     func() time.Time
+}
+
+// openRotatingFile rotates the previous run's log away and starts a new one.
+func openRotatingFile(path string, maxSize int64, maxAge time.Duration, keep int) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, maxSize: maxSize, maxAge: maxAge, keep: keep, now: time.Now}
+	if err := f.rotate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	full := f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize
+	old := f.maxAge > 0 && f.now().Sub(f.started) >= f.maxAge
+	if full || old {
+		if err := f.rotate(); err != nil {
+			// keep writing to the old file rather than losing the line
+			fmt.Fprintf(os.Stderr, "log rotation failed: %v\n", err)
+		}
+	}
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the rotated files up by one, dropping the ones past keep, and opens a new
+// file. It is called with mu held, or before the file is shared.
+func (f *rotatingFile) rotate() error {
+	reopen := f.file != nil
+	if reopen {
+		f.file.Close()
+	}
+	if f.keep > 0 {
+		os.Remove(fmt.Sprintf("%s.%d", f.path, f.keep))
+	}
+	for i := f.keep - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+	}
+	if f.keep > 0 {
+		os.Rename(f.path, f.path+".1")
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		if reopen {
+			// go on in the old file where it ended up rather than losing the log; try again later
+			prev := f.path
+			if f.keep > 0 {
+				prev += ".1"
+			}
+			f.file, _ = os.OpenFile(prev, os.O_WRONLY|os.O_APPEND, 0644)
+			f.size, f.started = 0, f.now()
+		}
+		return err
+	}
+	f.file, f.size, f.started = file, 0, f.now()
+	return nil
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Close()
+}
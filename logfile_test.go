@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Log File Rotation Tests
+// ============================================================================
+
+func TestLogFileRotatesAndKeepsAFew(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "werewolf.log")
+	os.WriteFile(path, []byte("last run\n"), 0644)
+	read := func(name string) string {
+		b, _ := os.ReadFile(name)
+		return string(b)
+	}
+
+	f, err := openRotatingFile(path, 20, 0, 2)
+	if err != nil {
+		t.Fatalf("openRotatingFile: %v", err)
+	}
+	defer f.Close()
+	if read(path+".1") != "last run\n" || read(path) != "" {
+		t.Fatalf("the last run's log should be kept aside, got %q / %q", read(path+".1"), read(path))
+	}
+
+	for _, line := range []string{"first line 1\n", "second line\n", "third line\n", "fourth line\n"} {
+		f.Write([]byte(line))
+	}
+	if got := read(path); got != "fourth line\n" {
+		t.Errorf("the current file should hold what came after the last rotation, got %q", got)
+	}
+	if got := read(path + ".1"); got != "third line\n" {
+		t.Errorf("the newest rotated file should come first, got %q", got)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("only two rotated files should be kept")
+	}
+
+	clock := time.Now()
+	f.mu.Lock()
+	f.now = func() time.Time { return clock }
+	f.started, f.maxSize, f.maxAge = clock, 0, time.Hour
+	f.mu.Unlock()
+	f.Write([]byte("still this hour\n"))
+	clock = clock.Add(time.Hour)
+	f.Write([]byte("an hour later\n"))
+	if got := read(path); got != "an hour later\n" || read(path+".1") != "fourth line\nstill this hour\n" {
+		t.Errorf("an hour-old file should be rotated, got %q after %q", got, read(path+".1"))
+	}
+}
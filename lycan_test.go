@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Lycan Tests
+// ============================================================================
+
+func TestSeerSeesLycanAsWerewolf(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Seer", "Lycan", "Vil"},
+		[]string{RoleWerewolf, RoleSeer, RoleLycan, RoleVillager})
+	seer, lycan := ids[1], ids[2]
+
+	ctx.sendWS(seer, WSMessage{Action: "seer_select", TargetPlayerID: strconv.FormatInt(lycan, 10)})
+	ctx.sendWS(seer, WSMessage{Action: "seer_investigate"})
+
+	if h := ctx.historyFor(seer); !strings.Contains(h, "Lycan — they are a werewolf") {
+		t.Errorf("Seer should read the Lycan as a werewolf, got: %q", h)
+	}
+
+	game, _ := ctx.hub().getGame()
+	if team := getSeerInvestigated(ctx.app.db, game.ID, seer)[lycan]; team != "werewolf" {
+		t.Errorf("investigated Lycan should show as werewolf on the Seer's cards, got %q", team)
+	}
+}
+
+func TestLycanWinsWithVillage(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 2,
+		[]string{"Wolf", "Lycan", "V1"},
+		[]string{RoleWerewolf, RoleLycan, RoleVillager})
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE player_id = ?", ids[0])
+
+	game, _ := ctx.hub().getGame()
+	if !ctx.hub().checkWinConditions(game) {
+		t.Fatal("game should end once the last werewolf is dead")
+	}
+	if _, _, winner := ctx.gameState(); winner != "villagers" {
+		t.Errorf("expected villagers to win, got %q", winner)
+	}
+	if !playerWon("villagers", "villager", true) {
+		t.Error("the Lycan is on the village team and should share the village win")
+	}
+}
+
+func TestSeerSeesLycanAsWolfInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Seer sees the Lycan as a werewolf ===")
+
+	// Setup: 1 werewolf + 1 seer + 1 lycan + 1 villager = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"LY1", "LY2", "LY3", "LY4"},
+		RoleWerewolf, RoleSeer, RoleLycan, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Seer"]) == 0 || len(byRole["Lycan"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	seer, lycan := byRole["Seer"][0], byRole["Lycan"][0]
+
+	// The Lycan is a villager and sleeps through the night
+	if lycan.canSeeWerewolfVotes() {
+		ctx.logger.LogDB("FAIL: lycan can vote with the pack")
+		t.Errorf("The Lycan should not hunt with the pack")
+	}
+
+	seer.seerInvestigatePlayer(lycan.Name)
+	if result := seer.getSeerResult(); result != lycan.Name+" is a Werewolf" {
+		ctx.logger.LogDB("FAIL: seer did not see the lycan as a werewolf")
+		t.Errorf("Seer should see the Lycan as a Werewolf, got: %s", result)
+	}
+	entry := "You investigated " + lycan.Name + " — they are a werewolf"
+	if !seer.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: seer history wrong for lycan")
+		t.Errorf("Seer should see %q in history, got: %s", entry, seer.getHistoryText())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
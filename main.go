@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/tls"
 	"database/sql"
 	"embed"
 	"encoding/json"
@@ -16,11 +17,12 @@ import (
 	"io"
 	"log"
 	_ "modernc.org/sqlite"
+	"net"
 	"net/http"
-	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 //go:embed templates/*
@@ -70,6 +72,9 @@ type App struct {
 	pageStyleTag       template.HTML
 	pageGameScriptTag  template.HTML
 	pageIndexScriptTag template.HTML
+	adminToken         string // bearer token for the admin API; empty turns it off
+	oauthProviders     []*oauthProvider
+	publicURL          string // base URL for OAuth redirects; empty = the request's host
 }
 
 func (app *App) getOrCreateHub(gameName string) *Hub {
@@ -100,7 +105,6 @@ func (app *App) getOrCreateHub(gameName string) *Hub {
 
 type GameData struct {
 	Player            *Player
-	Players           []Player
 	Game              *Game
 	GameName          string
 	IsInGame          bool
@@ -113,6 +117,14 @@ type GameData struct {
 	ScriptTag         template.HTML // full bundle; index page uses the lighter indexScriptTag instead
 	SessionCookieName string
 	Lang              string
+	NewSecretCode     string // a new account's code, shown on this one page load
+}
+
+// PauseData fills the overlay that covers the game while the host has it paused.
+type PauseData struct {
+	Paused bool
+	IsHost bool // only the host gets the resume button
+	Lang   string
 }
 
 type TopbarData struct {
@@ -246,7 +258,7 @@ func gameTheme(db *sqlx.DB, game *Game) string {
 		db.Get(&werewolfCount, `
 			SELECT COUNT(*) FROM game_player g
 			JOIN role r ON g.role_id = r.rowid
-			WHERE g.game_id = ? AND g.is_alive = 1 AND r.team = 'werewolf'`, game.ID)
+			WHERE g.game_id = ? AND g.is_alive = 1 AND `+wolfPackSQL, game.ID)
 		if werewolfCount == 0 {
 			return "light"
 		}
@@ -269,11 +281,7 @@ func (app *App) handleIndex(w http.ResponseWriter, r *http.Request) {
 				}
 			}
 			if shouldLogout {
-				if cookie, err := r.Cookie(sessionCookieName); err == nil {
-					if token, err := strconv.ParseInt(cookie.Value, 10, 64); err == nil {
-						app.db.Exec("DELETE FROM session WHERE token = ?", token)
-					}
-				}
+				deleteSession(app.db, r)
 				http.SetCookie(w, &http.Cookie{
 					Name:     sessionCookieName,
 					Value:    "",
@@ -312,18 +320,28 @@ func (app *App) handleIndex(w http.ResponseWriter, r *http.Request) {
 		nameExists = err == nil
 	}
 
+	newSecretCode := ""
+	var sessions []SessionView
+	if loggedIn {
+		newSecretCode = takeNewSecretCode(w, r)
+		sessions = playerSessions(app.db, playerID, r)
+	}
+
 	lang := getLangFromCookie(r)
 	app.templates.ExecuteTemplate(w, "index.html", struct {
-		LoggedIn     bool
-		GameName     string
-		PlayerName   string
-		NameExists   bool
-		Games        []PlayerGame
-		StyleTag     template.HTML
-		ScriptTag    template.HTML
-		Lang         string
-		BuildVersion string
-	}{loggedIn, gameName, playerName, nameExists, games, app.pageStyleTag, app.pageIndexScriptTag, lang, buildVersion})
+		LoggedIn      bool
+		GameName      string
+		PlayerName    string
+		NameExists    bool
+		Games         []PlayerGame
+		StyleTag      template.HTML
+		ScriptTag     template.HTML
+		Lang          string
+		BuildVersion  string
+		NewSecretCode string
+		OAuthButtons  []OAuthButton
+		Sessions      []SessionView
+	}{loggedIn, gameName, playerName, nameExists, games, app.pageStyleTag, app.pageIndexScriptTag, lang, buildVersion, newSecretCode, app.oauthButtons(playerID), sessions})
 }
 
 func (app *App) handleSetLang(w http.ResponseWriter, r *http.Request) {
@@ -336,6 +354,7 @@ func (app *App) handleSetLang(w http.ResponseWriter, r *http.Request) {
 		Value:    lang,
 		Path:     "/",
 		MaxAge:   365 * 24 * 60 * 60,
+		Secure:   isHTTPS(r),
 		SameSite: http.SameSiteLaxMode,
 	})
 	returnURL := r.URL.Query().Get("return")
@@ -347,30 +366,29 @@ func (app *App) handleSetLang(w http.ResponseWriter, r *http.Request) {
 
 // handleCheckGame is polled by the Join form on the login page as the user types a
 // game name. If the named game is already running and the logged-in player is not
-// part of it, it returns the Join button disabled with an inline error (swapped into
-// #join-game-control) so they can't join a game in progress; otherwise it re-enables
-// the button.
+// part of it, it returns the Join button with a note (swapped into #join-game-control)
+// that they will watch the game as an observer.
 func (app *App) handleCheckGame(w http.ResponseWriter, r *http.Request) {
 	lang := getLangFromCookie(r)
 	gameName := strings.TrimSpace(r.URL.Query().Get("game_name"))
 
-	canJoin := true
+	watch := false
 	if gameName != "" {
 		var game Game
 		err := app.db.Get(&game, "SELECT rowid as id, status FROM game WHERE name = ?", gameName)
-		// Only existing, already-running games block joining; a brand-new name or a
-		// game still in the lobby is joinable.
+		// Only existing, already-running games are watched; a brand-new name or a
+		// game still in the lobby is joined as a player.
 		if err == nil && game.Status != "lobby" {
 			playerID, _ := getPlayerIdFromSession(app.db, r)
-			canJoin = isPlayerInGame(app.db, game.ID, playerID)
+			watch = !isPlayerInGame(app.db, game.ID, playerID)
 		}
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	if err := app.templates.ExecuteTemplate(w, "check_game.html", struct {
-		CanJoin bool
-		Lang    string
-	}{canJoin, lang}); err != nil {
+		Watch bool
+		Lang  string
+	}{watch, lang}); err != nil {
 		app.logf("handleCheckGame: ExecuteTemplate: %v", err)
 	}
 }
@@ -391,11 +409,7 @@ func (app *App) handleGame(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		if cookie, err := r.Cookie(sessionCookieName); err == nil {
-			if token, err := strconv.ParseInt(cookie.Value, 10, 64); err == nil {
-				app.db.Exec("DELETE FROM session WHERE token = ?", token)
-			}
-		}
+		deleteSession(app.db, r)
 		http.SetCookie(w, &http.Cookie{
 			Name:     sessionCookieName,
 			Value:    "",
@@ -405,30 +419,23 @@ func (app *App) handleGame(w http.ResponseWriter, r *http.Request) {
 		})
 
 		var existing Player
-		err := app.db.Get(&existing, "SELECT rowid as id, name, secret_code FROM player WHERE name = ?", playerName)
+		err := app.db.Get(&existing, "SELECT rowid as id, name FROM player WHERE name = ?", playerName)
 		if err == sql.ErrNoRows {
-			secretCode, err := generateSecretCode()
+			newPlayerID, secretCode, err := createPlayer(app.db, playerName)
 			if err != nil {
 				hub := app.getOrCreateHub(gameName)
-				hub.logError("handleGame: generateSecretCode", err)
+				hub.logError("handleGame: createPlayer", err)
 				http.Error(w, "Something went wrong", http.StatusInternalServerError)
 				return
 			}
-			result, err := app.db.Exec("INSERT INTO player (name, secret_code) VALUES (?, ?)", playerName, secretCode)
-			if err != nil {
-				hub := app.getOrCreateHub(gameName)
-				hub.logError("handleGame: insert player", err)
-				http.Error(w, "Something went wrong", http.StatusInternalServerError)
-				return
-			}
-			newPlayerID, _ := result.LastInsertId()
 			app.logf("Auto-created player via join link: name='%s', id=%d, game='%s'", playerName, newPlayerID, gameName)
-			if err := setSessionCookie(app.db, w, newPlayerID); err != nil {
+			if err := setSessionCookie(app.db, w, r, newPlayerID); err != nil {
 				hub := app.getOrCreateHub(gameName)
 				hub.logError("handleGame: setSessionCookie", err)
 				http.Error(w, "Something went wrong", http.StatusInternalServerError)
 				return
 			}
+			setNewSecretCodeCookie(w, r, secretCode)
 			// Redirect without ?name= to avoid re-triggering this logic on reload.
 			http.Redirect(w, r, "/game/"+gameName, http.StatusSeeOther)
 			return
@@ -461,7 +468,7 @@ func (app *App) handleGame(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var player Player
-	err = app.db.Get(&player, "SELECT rowid as id, name, secret_code FROM player WHERE rowid = ?", playerID)
+	err = app.db.Get(&player, "SELECT rowid as id, name FROM player WHERE rowid = ?", playerID)
 	if err != nil {
 		hub.logError("handleGame: db.Get player", err)
 		http.Redirect(w, r, "/", http.StatusSeeOther)
@@ -470,15 +477,16 @@ func (app *App) handleGame(w http.ResponseWriter, r *http.Request) {
 	player.PlayerID = playerID
 	DebugLog("handleGame", "Player '%s' (ID: %d) accessing game page, game %d status: '%s'", player.Name, playerID, game.ID, game.Status)
 
-	// A logged-in player who is not part of an already-running game can't view or
-	// play it (roles are assigned). Send them back to the login page with the game
-	// name prefilled, where the Join form shows an inline error and disables the
-	// Join button — instead of rendering the game and letting the WebSocket bounce
-	// them, which looked like the game flashing then redirecting to login.
-	if game.Status != "lobby" && !isPlayerInGame(app.db, game.ID, playerID) {
-		DebugLog("handleGame", "Player '%s' (ID: %d) not in running game %d, redirecting to login", player.Name, playerID, game.ID)
-		http.Redirect(w, r, "/?game="+url.QueryEscape(gameName), http.StatusSeeOther)
-		return
+	// A logged-in player who is not part of an already-running game can't play it (roles
+	// are assigned), so they join it as an observer with the live public view. Add them
+	// now so the page renders the watching view before the WebSocket registers.
+	if game.Status != "lobby" {
+		if added, err := addObserver(app.db, game.ID, playerID); err != nil {
+			hub.logError("handleGame: addObserver", err)
+		} else if added {
+			DebugLog("handleGame", "Player '%s' (ID: %d) joined running game %d as an observer", player.Name, playerID, game.ID)
+			hub.triggerBroadcast()
+		}
 	}
 
 	// In lobby: add this player to the game now so the inline sidebar includes them immediately,
@@ -526,7 +534,8 @@ func (app *App) handleGame(w http.ResponseWriter, r *http.Request) {
 
 	// Build sidebar HTML inline so the page is fully rendered before WebSocket connects.
 	seerInvestigated := getSeerInvestigated(app.db, game.ID, playerID)
-	visiblePlayers := applyCardVisibility(player, selfFirstPlayers(players, playerID), seerInvestigated)
+	player = drunkView(game, player)
+	visiblePlayers := applyCardVisibility(player, selfFirstPlayers(maskDrunkSelf(game, players, playerID), playerID), seerInvestigated, viewerReveal(app.db, game.ID, player))
 	isLobby := game.Status == "lobby"
 	sidebarData := SidebarData{
 		Player:         &player,
@@ -537,6 +546,11 @@ func (app *App) handleGame(w http.ResponseWriter, r *http.Request) {
 		Lang:           lang,
 		AIAvailable:    hub.storyteller != nil || hub.narrator != nil,
 		PlayerCards:    buildSidebarCards(visiblePlayers, &player, isLobby, lang),
+		Substitution:   hub.buildSubstitutionData(game, playerID),
+		CanPause:       gameRunning(game) && gameHost(app.db, game.ID) == playerID,
+		CanUndo:        !isLobby && gameHost(app.db, game.ID) == playerID,
+		Moderator:      hub.buildModeratorData(game, playerID),
+		RejoinPlayers:  hub.buildRejoinChoices(game, playerID),
 	}
 	var sidebarBuf bytes.Buffer
 	app.templates.ExecuteTemplate(&sidebarBuf, "sidebar.html", sidebarData)
@@ -550,7 +564,6 @@ func (app *App) handleGame(w http.ResponseWriter, r *http.Request) {
 
 	data := GameData{
 		Player:            &player,
-		Players:           players,
 		Game:              game,
 		GameName:          gameName,
 		IsInGame:          isInGame,
@@ -563,6 +576,7 @@ func (app *App) handleGame(w http.ResponseWriter, r *http.Request) {
 		ScriptTag:         app.pageGameScriptTag,
 		SessionCookieName: sessionCookieName,
 		Lang:              lang,
+		NewSecretCode:     takeNewSecretCode(w, r),
 	}
 
 	app.templates.ExecuteTemplate(w, "game.html", data)
@@ -577,6 +591,11 @@ type SidebarData struct {
 	Lang           string
 	AIAvailable    bool // true if a storyteller or narrator is configured: show the AI on/off switch
 	PlayerCards    []PlayerCardData
+	Substitution   SubstitutionData // the host's form for handing a dropped player's seat on
+	CanPause       bool             // the viewer is the host of a running game: show the pause button
+	CanUndo        bool             // the viewer is the host and the game is past the lobby: show the undo button
+	Moderator      ModeratorData    // the host's override panel
+	RejoinPlayers  []SeatChoice     // who the host can issue a rejoin code for
 }
 
 func buildSidebarCards(players []Player, viewer *Player, isLobby bool, lang string) []PlayerCardData {
@@ -610,6 +629,7 @@ func buildSidebarCards(players []Player, viewer *Player, isLobby bool, lang stri
 type VoterChip struct {
 	Name      string
 	PlayerUID int64
+	Weight    int // >1 renders a multiplier badge (Mayor's day vote)
 }
 
 // PlayerCardData is everything the "player-card" template needs to render one card.
@@ -673,7 +693,8 @@ func makePlayerCard(p Player, lang string) PlayerCardData {
 }
 
 // makeLobbyCard builds a lobby role card (with ± buttons) from a role config row.
-func makeLobbyCard(rc RoleConfigDisplay, totalRoles, playerCount int, lang string) PlayerCardData {
+// slots is how many cards the pool may hold once this role is added.
+func makeLobbyCard(rc RoleConfigDisplay, totalRoles, playerCount, slots int, lang string) PlayerCardData {
 	roleID := strconv.FormatInt(rc.Role.ID, 10)
 	return PlayerCardData{
 		HTMLID:           "role-" + roleID,
@@ -684,7 +705,7 @@ func makeLobbyCard(rc RoleConfigDisplay, totalRoles, playerCount int, lang strin
 		IsLobby:          true,
 		LobbyCount:       rc.Count,
 		RoleID:           roleID,
-		LobbyAddDisabled: playerCount > 0 && totalRoles >= playerCount,
+		LobbyAddDisabled: playerCount > 0 && totalRoles >= slots,
 		LobbyRemDisabled: rc.Count == 0,
 		Lang:             lang,
 	}
@@ -709,16 +730,18 @@ func selfFirstPlayers(players []Player, selfPlayerID int64) []Player {
 	return out
 }
 
-// getSeerInvestigated returns a map of player_id → team ("werewolf" or "villager") for all
-// players that playerID (as Seer) has investigated. Returns an empty map for non-seers.
+// getSeerInvestigated returns a map of player_id → team as the Seer read it ("werewolf" or
+// "villager"; a Lycan reads as "werewolf") for all players that playerID (as Seer) has
+// investigated. Returns an empty map for non-seers.
 func getSeerInvestigated(db *sqlx.DB, gameID, playerID int64) map[int64]string {
 	type row struct {
 		TargetID int64  `db:"target_player_id"`
 		Team     string `db:"team"`
+		RoleName string `db:"role_name"`
 	}
 	var rows []row
 	db.Select(&rows, `
-		SELECT ga.target_player_id, r.team
+		SELECT ga.target_player_id, r.team, r.name as role_name
 		FROM game_action ga
 		JOIN game_player gp ON gp.game_id = ga.game_id AND gp.player_id = ga.target_player_id
 		JOIN role r ON r.rowid = gp.role_id
@@ -726,7 +749,11 @@ func getSeerInvestigated(db *sqlx.DB, gameID, playerID int64) map[int64]string {
 		gameID, playerID, ActionSeerApplyInvestigate)
 	out := make(map[int64]string, len(rows))
 	for _, r := range rows {
-		out[r.TargetID] = r.Team
+		if seerSeesWerewolf(Player{Team: r.Team, RoleName: r.RoleName}) {
+			out[r.TargetID] = "werewolf"
+		} else {
+			out[r.TargetID] = r.Team
+		}
 	}
 	return out
 }
@@ -738,40 +765,57 @@ func getVisiblePlayer(db *sqlx.DB, gameID, targetPlayerID int64, viewer Player,
 	if err != nil {
 		return nil
 	}
-	result := applyCardVisibility(viewer, []Player{p}, seerInvestigated)
+	result := applyCardVisibility(viewer, []Player{p}, seerInvestigated, viewerReveal(db, gameID, viewer))
 	return &result[0]
 }
 
 // applyCardVisibility returns a copy of targets with role/team fields adjusted to show only
-// what the viewer should see. This is the canonical visibility rule applied in all contexts.
+// what the viewer should see. This is the canonical visibility rule applied in all contexts,
+// and its result is the only player list that may reach another player's templates.
 //
 // Rules (in priority order):
-//  1. Dead → full role + team revealed
+//  1. Viewer sees everything (RevealAll), or dead with the full reveal (roleReveal) → full role + team revealed
 //  2. Self → full role + team visible
 //  3. Viewer is Mason AND target is Mason → full role + team visible (masons know each other)
-//  4. Viewer is werewolf AND target is werewolf → team only ("Werewolf"), no exact role
-//  5. Seer has investigated this target → team only ("Werewolf" or "Villager"), no exact role
-//  6. Otherwise → "Unknown"
-func applyCardVisibility(viewer Player, targets []Player, seerInvestigated map[int64]string) []Player {
+//  4. Viewer is in the pack (or the Minion) AND target is in the pack → team only ("Werewolf"), no exact role
+//  5. Dead with the team reveal → team only (teamCardName), no exact role
+//  6. Seer has investigated this target → team only ("Werewolf" or "Villager"), no exact role
+//  7. Otherwise → "Unknown"
+//
+// Whenever the exact role is hidden, so are the role id and the Doppelganger mark. The lover
+// link stays only on the viewer's own card, their partner's, and the dead's (a heartbreak is
+// announced to everyone).
+func applyCardVisibility(viewer Player, targets []Player, seerInvestigated map[int64]string, reveal string) []Player {
 	out := make([]Player, len(targets))
 	for i, t := range targets {
 		p := t
 		isSelf := viewer.PlayerID == t.PlayerID
 		isMasonPair := viewer.RoleId == "mason" && t.RoleId == "mason"
-		isWolfPair := viewer.Team == "werewolf" && t.Team == "werewolf"
+		isWolfPair := inWolfPack(viewer) && inWolfPack(t)
+		minionSeesWolf := viewer.RoleName == "Minion" && inWolfPack(t)
+		if !isSelf && reveal != RevealAll && t.IsAlive && t.Lover != viewer.PlayerID {
+			p.Lover = 0
+		}
 		switch {
-		case !t.IsAlive, isSelf, isMasonPair:
+		case reveal == RevealAll, !t.IsAlive && reveal == RevealFull, isSelf, isMasonPair:
 			// full role + team — keep as-is
-		case isWolfPair:
+			out[i] = p
+			continue
+		case isWolfPair, minionSeesWolf:
 			p.RoleName = "Werewolf"
 			p.RoleDescription = ""
 			p.Team = "werewolf"
+		case !t.IsAlive && reveal == RevealTeam:
+			p.RoleName = teamCardName(t.RoleName, t.Team)
+			p.RoleDescription = ""
 		default:
 			if team, ok := seerInvestigated[t.PlayerID]; ok {
 				if team == "werewolf" {
 					p.RoleName = "Werewolf"
 				} else {
+					// solo roles like the Tanner read as village to the Seer
 					p.RoleName = "Villager"
+					team = "villager"
 				}
 				p.RoleDescription = ""
 				p.Team = team
@@ -781,11 +825,32 @@ func applyCardVisibility(viewer Player, targets []Player, seerInvestigated map[i
 				p.Team = "unknown"
 			}
 		}
+		p.RoleId = ""
+		p.IsDoppelganger = false
 		out[i] = p
 	}
 	return out
 }
 
+// publicPlayers returns copies of players with only what everyone at the table knows: the
+// seat, the name, whether they are alive and their picture. It is for lists that are not
+// about roles, like the host's player pickers.
+func publicPlayers(players []Player) []Player {
+	out := make([]Player, len(players))
+	for i, p := range players {
+		out[i] = Player{
+			ID:             p.ID,
+			GameID:         p.GameID,
+			PlayerID:       p.PlayerID,
+			Name:           p.Name,
+			IsAlive:        p.IsAlive,
+			IsObserver:     p.IsObserver,
+			ProfileImageID: p.ProfileImageID,
+		}
+	}
+	return out
+}
+
 type HistoryEntry struct {
 	ID          int64
 	Description string
@@ -796,12 +861,25 @@ type HistoryData struct {
 	Entries []HistoryEntry
 }
 
+// HistoryUpdate is what history-update sends: the entries to append, the ones to replace and
+// the IDs of the ones to remove.
+type HistoryUpdate struct {
+	Added   []HistoryEntry
+	Changed []HistoryEntry
+	Removed []int64
+}
+
 // roleNameArgKeys maps translation keys to which arg indices hold role names that need translation.
 var roleNameArgKeys = map[string][]int{
-	"hist_found_dead":      {2}, // args: round, playerName, roleName
-	"hist_eliminated":      {2}, // args: round, playerName, roleName
-	"hist_doppelganger":    {0}, // args: roleName, copiedFromName
-	"hist_witch_confirmed": {},  // no role name args
+	"hist_found_dead":              {2}, // args: round, playerName, roleName
+	"hist_eliminated":              {2}, // args: round, playerName, roleName
+	"hist_doppelganger":            {0}, // args: roleName, copiedFromName
+	"hist_doppelganger_heir_night": {2}, // args: round, modelName, roleName
+	"hist_doppelganger_heir_day":   {2}, // args: round, modelName, roleName
+	"hist_drunk_sobered":           {1}, // args: round, roleName
+	"hist_thief_stole":             {0}, // args: roleName
+	"hist_moderator_set_role":      {2}, // args: round, playerName, roleName
+	"hist_witch_confirmed":         {},  // no role name args
 }
 
 func buildHistoryEntries(db *sqlx.DB, playerID int64, game *Game, lang string) []HistoryEntry {
@@ -817,58 +895,64 @@ func buildHistoryEntries(db *sqlx.DB, playerID int64, game *Game, lang string) [
 		DescriptionArgs string `db:"description_args"`
 		Visibility      string `db:"visibility"`
 		ActorPlayerID   int64  `db:"actor_player_id"`
+		TargetPlayerID  *int64 `db:"target_player_id"`
 		Round           int    `db:"round"`
 		Phase           string `db:"phase"`
 	}
 
 	var rows []historyRow
 	db.Select(&rows, `
-		SELECT rowid as id, description, description_key, description_args, visibility, actor_player_id, round, phase
+		SELECT rowid as id, description, description_key, description_args, visibility, actor_player_id, target_player_id, round, phase
 		FROM game_action
 		WHERE game_id = ? AND description != ''
 		ORDER BY rowid ASC`, game.ID)
 
+	reveal := roleReveal(db, game.ID)
 	var entries []HistoryEntry
 	for _, row := range rows {
 		action := GameAction{
-			ActorPlayerID: row.ActorPlayerID,
-			Visibility:    row.Visibility,
-			Round:         row.Round,
-			Phase:         row.Phase,
+			ActorPlayerID:  row.ActorPlayerID,
+			TargetPlayerID: row.TargetPlayerID,
+			Visibility:     row.Visibility,
+			Round:          row.Round,
+			Phase:          row.Phase,
 		}
-		if !canSeeAction(action, viewer, game.Round, game.Status) {
-			continue
+		visible := canSeeAction(action, viewer, game.Round, game.Status)
+		switch row.Visibility {
+		case VisibilityModerator:
+			visible = canSeeOverride(db, game, playerID)
+		case VisibilityPair:
+			visible = visible || canSeeOverride(db, game, playerID)
 		}
-		desc := row.Description
-		if row.DescriptionKey != "" {
-			var args []interface{}
-			if row.DescriptionArgs != "" {
-				parts := strings.Split(row.DescriptionArgs, "\t")
-				if indices, ok := roleNameArgKeys[row.DescriptionKey]; ok {
-					for _, idx := range indices {
-						if idx < len(parts) {
-							parts[idx] = T(lang, "role_name_"+parts[idx])
-						}
-					}
-				}
-				for _, p := range parts {
-					args = append(args, p)
-				}
-			}
-			desc = T(lang, row.DescriptionKey, args...)
+		if !visible {
+			continue
 		}
-		entries = append(entries, HistoryEntry{ID: row.ID, Description: desc})
+		entries = append(entries, HistoryEntry{ID: row.ID, Description: historyDescription(db, reveal, row.Description, row.DescriptionKey, row.DescriptionArgs, lang)})
 	}
 	return entries
 }
 
-func getGameHistory(db *sqlx.DB, tmpl *template.Template, playerID int64, game *Game, lang string) (*bytes.Buffer, error) {
-	entries := buildHistoryEntries(db, playerID, game, lang)
-	var buf bytes.Buffer
-	if err := tmpl.ExecuteTemplate(&buf, "history.html", HistoryData{Lang: lang, Entries: entries}); err != nil {
-		return nil, err
+// historyDescription translates a history row, masking deaths as the reveal policy asks.
+func historyDescription(db *sqlx.DB, reveal, desc, key, rawArgs, lang string) string {
+	if key == "" {
+		return desc
+	}
+	var args []interface{}
+	if rawArgs != "" {
+		var parts []string
+		key, parts = maskDeathHistory(db, reveal, key, strings.Split(rawArgs, "\t"))
+		if indices, ok := roleNameArgKeys[key]; ok {
+			for _, idx := range indices {
+				if idx < len(parts) {
+					parts[idx] = TOr(lang, "role_name_"+parts[idx], parts[idx])
+				}
+			}
+		}
+		for _, p := range parts {
+			args = append(args, p)
+		}
 	}
-	return &buf, nil
+	return T(lang, key, args...)
 }
 
 func disableCaching(next http.Handler) http.Handler {
@@ -880,19 +964,26 @@ func disableCaching(next http.Handler) http.Handler {
 }
 
 func handleWSMessage(client *Client, message []byte) {
+	msg, enveloped, decodeErr := decodeWSMessage(message)
+	if decodeErr != nil {
+		client.hub.logf("WebSocket message from player %d not taken: %s", client.playerID, decodeErr.Code)
+		client.hub.sendWSError(client.playerID, enveloped, decodeErr)
+		return
+	}
+	dispatchWSMessage(client, msg)
+}
+
+// dispatchWSMessage routes a decoded message to its action's handler.
+func dispatchWSMessage(client *Client, msg WSMessage) {
 	// Log incoming WebSocket message
 	var playerName string
 	client.hub.db.Get(&playerName, "SELECT name FROM player WHERE rowid = ?", client.playerID)
 
-	var msg WSMessage
-	err := json.Unmarshal(message, &msg)
-	if err != nil {
-		client.hub.logf("WebSocket unmarshal error for player %d: %v", client.playerID, err)
-		return
-	}
-
 	LogWSMessage("IN", playerName, msg.Action)
 
+	client.hub.moveMu.Lock()
+	defer client.hub.moveMu.Unlock()
+
 	game, err := client.hub.getGame()
 	if err != nil {
 		client.hub.logError("handleWSMessage: getGame", err)
@@ -900,13 +991,74 @@ func handleWSMessage(client *Client, message []byte) {
 		client.hub.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
 		return
 	}
+	logWSAction(game, client.playerID, msg.Action)
+
+	// the lobby's settings and the start are the host's
+	if hostOnlyActions[msg.Action] && !client.hub.requireHost(client, game) {
+		return
+	}
+
+	// a paused game takes no moves until the host resumes it
+	if game.Paused && !pauseExemptActions[msg.Action] {
+		lang := client.hub.getPlayerLang(client.playerID)
+		client.hub.sendErrorToast(client.playerID, T(lang, "err_game_paused"))
+		return
+	}
 
 	// Route action to the appropriate handler based on action type and game status
 	switch msg.Action {
 	case "update_role":
 		handleWSUpdateRole(client, msg)
+	case "create_role":
+		handleWSCreateRole(client, msg)
+	case "toggle_pack":
+		handleWSTogglePack(client, msg)
 	case "start_game":
 		handleWSStartGame(client)
+	case "transfer_host":
+		handleWSTransferHost(client, msg)
+	case "save_preset":
+		handleWSSavePreset(client, msg)
+	case "load_preset":
+		handleWSLoadPreset(client, msg)
+	case "suggest_setup":
+		handleWSSuggestSetup(client)
+	case "toggle_observer":
+		handleWSToggleObserver(client)
+	case "toggle_observers_see_all":
+		handleWSToggleObserversSeeAll(client)
+	case "toggle_narrator_mode":
+		handleWSToggleNarratorMode(client)
+	case "toggle_graveyard_talk":
+		handleWSToggleGraveyardTalk(client)
+	case "toggle_whispers":
+		handleWSToggleWhispers(client)
+	case "toggle_chat_filter":
+		handleWSToggleChatFilter(client)
+	case "set_webhook":
+		handleWSSetWebhook(client, msg)
+	case "toggle_mute":
+		handleWSToggleMute(client, msg)
+	case "whisper":
+		handleWSWhisper(client, msg)
+	case "advance_phase":
+		handleWSAdvancePhase(client)
+	case "substitute_player":
+		handleWSSubstitutePlayer(client, msg)
+	case "issue_rejoin_code":
+		handleWSIssueRejoinCode(client, msg)
+	case "pause_game":
+		handleWSPauseGame(client)
+	case "resume_game":
+		handleWSResumeGame(client)
+	case "undo_resolution":
+		handleWSUndoResolution(client)
+	case "abort_game":
+		client.hub.handleWSAbortGame(client)
+	case "moderator_kill", "moderator_revive", "moderator_set_role":
+		handleWSModeratorOverride(client, msg)
+	case "moderator_force_phase":
+		handleWSModeratorForcePhase(client)
 	case "werewolf_vote":
 		handleWSWerewolfVote(client, msg)
 	case "werewolf_vote_2":
@@ -917,26 +1069,114 @@ func handleWSMessage(client *Client, message []byte) {
 		handleWSWerewolfPass2(client, msg)
 	case "werewolf_end_vote":
 		handleWSWerewolfEndVote(client, msg)
+	case "chat_send":
+		handleWSChatSend(client, msg)
+	case "set_night_timer":
+		handleWSSetNightTimer(client, msg)
+	case "set_day_timer":
+		handleWSSetDayTimer(client, msg)
+	case "set_role_reveal":
+		handleWSSetRoleReveal(client, msg)
+	case "toggle_nominations":
+		handleWSToggleNominations(client)
+	case "toggle_trials":
+		handleWSToggleTrials(client)
+	case "toggle_secret_votes":
+		handleWSToggleSecretVotes(client)
+	case "toggle_runoffs":
+		handleWSToggleRunoffs(client)
+	case "toggle_mayor_election":
+		handleWSToggleMayorElection(client)
+	case "mayor_vote":
+		handleWSMayorVote(client, msg)
+	case "toggle_last_words":
+		handleWSToggleLastWords(client)
+	case "last_words":
+		handleWSLastWords(client, msg)
 	case "werewolf_end_vote_2":
 		handleWSWerewolfEndVote2(client, msg)
+	case "alpha_bite":
+		handleWSAlphaBite(client, msg)
 	case "seer_select":
 		handleWSSeerSelect(client, msg)
 	case "seer_investigate":
 		handleWSSeerInvestigate(client, msg)
+	case "seer_skip":
+		handleWSNightSkip(client, "Seer")
+	case "aura_seer_select":
+		handleWSAuraSeerSelect(client, msg)
+	case "aura_seer_investigate":
+		handleWSAuraSeerInvestigate(client, msg)
+	case "spellcaster_select":
+		handleWSSpellcasterSelect(client, msg)
+	case "spellcaster_silence":
+		handleWSSpellcasterSilence(client, msg)
+	case "fox_select":
+		handleWSFoxSelect(client, msg)
+	case "fox_sniff":
+		handleWSFoxSniff(client, msg)
+	case "serial_killer_select":
+		handleWSSerialKillerSelect(client, msg)
+	case "serial_killer_kill":
+		handleWSSerialKillerKill(client, msg)
+	case "piper_choose":
+		handleWSPiperChoose(client, msg)
+	case "piper_charm":
+		handleWSPiperCharm(client)
+	case "white_wolf_select":
+		handleWSWhiteWolfSelect(client, msg)
+	case "white_wolf_kill":
+		handleWSWhiteWolfKill(client, false)
+	case "white_wolf_spare":
+		handleWSWhiteWolfKill(client, true)
+	case "sorceress_select":
+		handleWSSorceressSelect(client, msg)
+	case "sorceress_investigate":
+		handleWSSorceressInvestigate(client, msg)
 	case "doctor_select":
 		handleWSDoctorSelect(client, msg)
 	case "doctor_protect":
 		handleWSDoctorProtect(client, msg)
+	case "doctor_skip":
+		handleWSNightSkip(client, "Doctor")
 	case "guard_select":
 		handleWSGuardSelect(client, msg)
 	case "guard_protect":
 		handleWSGuardProtect(client, msg)
+	case "guard_skip":
+		handleWSNightSkip(client, "Guard")
+	case "bodyguard_select":
+		handleWSBodyguardSelect(client, msg)
+	case "bodyguard_guard":
+		handleWSBodyguardGuard(client, msg)
 	case "day_vote":
 		handleWSDayVote(client, msg)
 	case "day_pass":
 		handleWSDayPass(client, msg)
+	case "day_nominate":
+		handleWSDayNominate(client, msg)
+	case "day_second":
+		handleWSDaySecond(client, msg)
+	case "day_open_vote":
+		handleWSDayOpenVote(client, msg)
+	case "day_defense":
+		handleWSDayDefense(client, msg)
+	case "day_verdict":
+		handleWSDayVerdict(client, msg)
+	case "priest_select":
+		handleWSPriestSelect(client, msg)
+	case "priest_throw":
+		handleWSPriestThrow(client, msg)
 	case "day_end_vote":
 		handleWSDayEndVote(client, msg)
+	case "scapegoat_toggle":
+		handleWSScapegoatToggle(client, msg)
+	case "scapegoat_confirm":
+		handleWSScapegoatConfirm(client, msg)
+	case "thief_take":
+		handleWSThiefChoose(client, msg, false)
+	case "thief_keep":
+		handleWSThiefChoose(client, msg, true)
 	case "hunter_select":
 		handleWSHunterSelect(client, msg)
 	case "hunter_revenge":
@@ -955,6 +1195,14 @@ func handleWSMessage(client *Client, message []byte) {
 		handleWSDoppelgangerSelect(client, msg)
 	case "doppelganger_copy":
 		handleWSDoppelgangerCopy(client, msg)
+	case "wild_child_select":
+		handleWSWildChildSelect(client, msg)
+	case "wild_child_choose":
+		handleWSWildChildChoose(client, msg)
+	case "custom_select":
+		handleWSCustomSelect(client, msg)
+	case "custom_act":
+		handleWSCustomAct(client, msg)
 	case "night_survey_suspect":
 		handleWSNightSurveySuspect(client, msg)
 	case "night_survey":
@@ -969,15 +1217,21 @@ func handleWSMessage(client *Client, message []byte) {
 }
 
 func getGameComponent(h *Hub, playerID int64, game *Game, lang string) (*bytes.Buffer, error) {
-	db := h.db
-	tmpl := h.templates
-	var buf bytes.Buffer
-
-	players, err := getPlayersByGameId(db, game.ID)
+	rs, err := loadRenderState(h, game)
 	if err != nil {
-		h.logError("getGameComponent: getPlayersByGameId", err)
+		h.logError("getGameComponent: loadRenderState", err)
 		return nil, err
 	}
+	return renderGameComponent(h, rs, playerID, lang)
+}
+
+// renderGameComponent renders the game's main content for one player from rs.
+func renderGameComponent(h *Hub, rs *renderState, playerID int64, lang string) (*bytes.Buffer, error) {
+	db := h.db
+	tmpl := h.templates
+	game := rs.Game
+	players := rs.Players
+	var buf bytes.Buffer
 
 	if game.Status == "lobby" {
 		var roleConfigs []GameRoleConfig
@@ -992,13 +1246,14 @@ func getGameComponent(h *Hub, playerID int64, game *Game, lang string) (*bytes.B
 
 		var roleConfigDisplay []RoleConfigDisplay
 
-		roles, err := getRoles(db)
-		if err != nil {
-			h.logError("getGameComponent: getRoles", err)
-			return nil, err
-		}
+		roles := rs.Roles
 
+		// roles from packs switched off for this game are not offered
+		hidden := hiddenPacks(db, game.ID)
 		for _, role := range roles {
+			if hidden[role.Pack] {
+				continue
+			}
 			count := roleConfigMap[role.ID]
 			roleConfigDisplay = append(roleConfigDisplay, RoleConfigDisplay{
 				Role:  role,
@@ -1007,38 +1262,90 @@ func getGameComponent(h *Hub, playerID int64, game *Game, lang string) (*bytes.B
 		}
 
 		playerCount := len(players)
+		spareCount := spareRoleCount(db, game.ID, "")
+		hostID := rs.HostID
 		roleCards := make([]PlayerCardData, 0, len(roleConfigDisplay))
 		for _, rc := range roleConfigDisplay {
-			roleCards = append(roleCards, makeLobbyCard(rc, totalRoles, playerCount, lang))
+			slots := playerCount + spareRoleCount(db, game.ID, strconv.FormatInt(rc.Role.ID, 10))
+			roleCards = append(roleCards, makeLobbyCard(rc, totalRoles, playerCount, slots, lang))
 		}
 
 		data := LobbyData{
-			Players:     players,
+			Players:     publicPlayers(players),
 			RoleConfigs: roleConfigDisplay,
 			RoleCards:   roleCards,
 			TotalRoles:  totalRoles,
 			PlayerCount: playerCount,
-			CanStart:    totalRoles > 0 && totalRoles == playerCount,
+			RoleSlots:   playerCount + spareCount,
+			Packs:       packToggles(db, game.ID),
+			Presets:     rolePresets(db),
+			NightTimers: nightTimerOptions(db, game.ID),
+			DayTimers:   dayTimerOptions(db, game.ID),
+			RoleReveals: roleRevealOptions(db, game.ID),
+			Nominations: nominationsEnabled(db, game.ID),
+			Trials:      trialsEnabled(db, game.ID),
+			SecretVotes: secretVotesEnabled(db, game.ID),
+			Runoffs:     runoffsEnabled(db, game.ID),
+			Election:    mayorElectionEnabled(db, game.ID),
+			LastWords:   lastWordsEnabled(db, game.ID),
+			CanStart:    totalRoles > 0 && totalRoles == playerCount+spareCount,
+			HostID:      hostID,
+			HostName:    getPlayerName(db, hostID),
+			IsHost:      hostID == playerID,
+			Observers:   observerNames(db, game.ID),
+			Watching:    isObserver(db, game.ID, playerID),
+			SeeAll:      observersSeeAllEnabled(db, game.ID),
+			Narrator:    narratorModeEnabled(db, game.ID),
+			Graveyard:   graveyardTalkEnabled(db, game.ID),
+			Whispers:    whispersEnabled(db, game.ID),
+			ChatFilter:  chatFilterEnabled(db, game.ID),
+			Chat:        chatMessages(db, game.ID, ChatChannelLobby),
+			InviteLink:  invitePath(game.Name),
 			GameID:      game.ID,
 			GameStatus:  game.Status,
 			Lang:        lang,
 		}
+		if data.IsHost {
+			data.WebhookURL = webhookURL(db, game.ID)
+		}
 
 		if err := tmpl.ExecuteTemplate(&buf, "lobby_content.html", data); err != nil {
 			h.logError("getGameComponent: ExecuteTemplate lobby_content", err)
 			return nil, err
 		}
+	} else if game.Status == "setup" {
+		data := buildSetupData(db, game, playerID, lang)
+		if err := tmpl.ExecuteTemplate(&buf, "setup_content.html", data); err != nil {
+			h.logError("getGameComponent: ExecuteTemplate setup_content", err)
+			return nil, err
+		}
+	} else if game.Status == "election" {
+		player, err := getPlayerInGame(db, game.ID, playerID)
+		if err != nil {
+			h.logError("getGameComponent: getPlayerInGame for election", err)
+			return nil, err
+		}
+		player = drunkView(game, player)
+		players = maskDrunkSelf(game, players, playerID)
+		visiblePlayers := applyCardVisibility(player, players, getSeerInvestigated(db, game.ID, playerID), viewerReveal(db, game.ID, player))
+		data := buildElectionData(db, game, player, visiblePlayers, lang)
+		if err := tmpl.ExecuteTemplate(&buf, "election_content.html", data); err != nil {
+			h.logError("getGameComponent: ExecuteTemplate election_content", err)
+			return nil, err
+		}
 	} else if game.Status == "night" {
 		player, err := getPlayerInGame(db, game.ID, playerID)
 		if err != nil {
 			h.logError("getGameComponent: getPlayerInGame", err)
 			return nil, err
 		}
+		player = drunkView(game, player)
+		players = maskDrunkSelf(game, players, playerID)
 		isAlive := player.IsAlive
 
 		// Apply canonical card visibility rules. All player lists use the result.
 		seerInvestigated := getSeerInvestigated(db, game.ID, playerID)
-		visiblePlayers := applyCardVisibility(player, players, seerInvestigated)
+		visiblePlayers := applyCardVisibility(player, players, seerInvestigated, viewerReveal(db, game.ID, player))
 
 		// Get alive players as targets (visibility pre-applied)
 		var aliveTargets []Player
@@ -1052,15 +1359,33 @@ func getGameComponent(h *Hub, playerID int64, game *Game, lang string) (*bytes.B
 			Player:                &player,
 			AliveTargets:          aliveTargets,
 			NightNumber:           game.Round,
+			PowersDisabled:        powerDisabled(db, game.ID, player.RoleName),
+			TimeLeft:              h.nightTimeLeft(game),
+			NightSkipped:          nightSkipped(db, game, player),
+			DayVoteRecap:          nightVoteRecap(db, game, player, seerInvestigated),
 			Lang:                  lang,
 			WerewolfNightData:     buildWerewolfNightData(db, game, playerID, player, seerInvestigated, aliveTargets),
+			AlphaNightData:        buildAlphaNightData(db, game, player),
+			WhiteWolfNightData:    buildWhiteWolfNightData(db, game, playerID, player, seerInvestigated),
 			SeerNightData:         buildSeerNightData(db, game, playerID, player, seerInvestigated),
+			AuraSeerNightData:     buildAuraSeerNightData(db, game, playerID, player, seerInvestigated),
+			FoxNightData:          buildFoxNightData(db, game, playerID, player, seerInvestigated),
+			SpellcasterNightData:  buildSpellcasterNightData(db, game, playerID, player, seerInvestigated),
+			SerialKillerNightData: buildSerialKillerNightData(db, game, playerID, player, seerInvestigated),
+			PiperNightData:        buildPiperNightData(db, game, playerID, player, seerInvestigated),
+			SorceressNightData:    buildSorceressNightData(db, game, playerID, player, seerInvestigated),
 			DoctorNightData:       buildDoctorNightData(db, game, playerID, player, seerInvestigated),
 			GuardNightData:        buildGuardNightData(db, game, playerID, player, seerInvestigated, aliveTargets),
+			BodyguardNightData:    buildBodyguardNightData(db, game, playerID, player, seerInvestigated),
 			WitchNightData:        buildWitchNightData(db, game, playerID, player, seerInvestigated),
 			MasonNightData:        buildMasonNightData(player, players),
+			MinionNightData:       buildMinionNightData(player, visiblePlayers),
 			CupidNightData:        buildCupidNightData(db, game, playerID, player, seerInvestigated),
 			DoppelgangerNightData: buildDoppelgangerNightData(db, game, playerID, player, seerInvestigated, aliveTargets),
+			WildChildNightData:    buildWildChildNightData(db, game, playerID, player, seerInvestigated),
+			CustomNightData:       buildCustomNightData(db, game, playerID, player, seerInvestigated),
+			LoversChatData:        buildLoversChatData(db, game, player),
+			GraveyardData:         buildGraveyardData(db, game, player),
 		}
 
 		// Survey: show once player has completed their night role action
@@ -1076,7 +1401,7 @@ func getGameComponent(h *Hub, playerID int64, game *Game, lang string) (*bytes.B
 			data.SurveyTargets = aliveTargets
 			var suspectPlayer Player
 			if err := db.Get(&suspectPlayer, `
-				SELECT gp.rowid as id, g.rowid as game_id, p.rowid as player_id, p.name, p.secret_code,
+				SELECT gp.rowid as id, g.rowid as game_id, p.rowid as player_id, p.name,
 				       r.rowid as role_id, r.name as role_name, r.description as role_description, r.team,
 				       gp.is_alive, gp.is_observer, IFNULL(l.player2_id, 0) as lover
 				FROM game_action ga
@@ -1098,203 +1423,10 @@ func getGameComponent(h *Hub, playerID int64, game *Game, lang string) (*bytes.B
 			return nil, err
 		}
 	} else if game.Status == "day" {
-		player, err := getPlayerInGame(db, game.ID, playerID)
+		data, err := buildDayData(h, rs, playerID, lang)
 		if err != nil {
-			h.logError("getGameComponent: getPlayerInGame for day", err)
 			return nil, err
 		}
-
-		// is_alive=0 excludes players who were targeted but survived a protection.
-		var nightVictims []Player
-		db.Select(&nightVictims, `
-			SELECT DISTINCT gp.rowid as id,
-				g.rowid as game_id,
-				p.rowid as player_id,
-				p.name as name,
-				p.secret_code as secret_code,
-				r.rowid as role_id,
-				r.name as role_name,
-				r.description as role_description,
-				r.team as team,
-				gp.is_alive as is_alive,
-				gp.is_observer as is_observer,
-				IFNULL(l.player2_id, 0) as lover
-			FROM game_player gp
-				JOIN player p on gp.player_id = p.rowid
-			    JOIN game_action ga ON ga.target_player_id = p.rowid
-				JOIN game g on gp.game_id = g.rowid
-				JOIN role r on gp.role_id = r.rowid
-				LEFT JOIN game_lovers l on l.player1_id = p.rowid
-			WHERE ga.game_id = ? AND ga.round = ? AND ga.phase = 'night'
-			    AND ga.action_type IN (?, ?, ?, ?)
-			    AND gp.is_alive = 0`,
-			game.ID, game.Round, ActionWerewolfSelectKill, ActionWerewolfSelectKill2, ActionWitchApplyKill, ActionLoverHeartbreak)
-
-		seerInvestigated := getSeerInvestigated(db, game.ID, playerID)
-		visiblePlayers := applyCardVisibility(player, players, seerInvestigated)
-
-		// Get alive players as targets (visibility pre-applied)
-		var aliveTargets []Player
-		for _, p := range visiblePlayers {
-			if p.IsAlive {
-				aliveTargets = append(aliveTargets, p)
-			}
-		}
-
-		// Populate Hunter revenge data — check for any dead Hunter who hasn't taken their revenge shot yet
-		var hunterRevengeNeeded, hunterRevengeDone bool
-		var isTheHunter bool
-		var hunterVictimPlayer, hunterSelectedPlayer *Player
-		var hunterTargets []Player
-
-		// Step 1: Find a dead Hunter who hasn't taken revenge yet (pending — takes priority)
-		for _, p := range players {
-			if p.IsAlive || p.RoleName != "Hunter" {
-				continue
-			}
-			var revengeCount int
-			db.Get(&revengeCount, `
-				SELECT COUNT(*) FROM game_action
-				WHERE game_id = ? AND actor_player_id = ? AND action_type = ?`,
-				game.ID, p.PlayerID, ActionHunterApplyKill)
-			if revengeCount == 0 {
-				hunterRevengeNeeded = true
-				isTheHunter = (p.PlayerID == playerID)
-				hunterTargets = aliveTargets
-				if isTheHunter {
-					var selectAction GameAction
-					if db.Get(&selectAction, `
-						SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
-						FROM game_action
-						WHERE game_id=? AND round=? AND actor_player_id=? AND action_type=?`,
-						game.ID, game.Round, playerID, ActionHunterSelectKill) == nil && selectAction.TargetPlayerID != nil {
-						hunterSelectedPlayer = getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated)
-					}
-				}
-				break
-			}
-		}
-
-		// Step 2: If no pending Hunter, check if any revenge happened this round (show result)
-		if !hunterRevengeNeeded {
-			var revengeAction GameAction
-			err = db.Get(&revengeAction, `
-				SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
-				FROM game_action
-				WHERE game_id = ? AND round = ? AND action_type = ?
-				ORDER BY rowid DESC LIMIT 1`,
-				game.ID, game.Round, ActionHunterApplyKill)
-			if err == nil && revengeAction.TargetPlayerID != nil {
-				hunterRevengeNeeded = true
-				hunterRevengeDone = true
-				// HunterVictimPlayer: dead player, full role visible (dead rule in applyCardVisibility)
-				hunterVictimPlayer = getVisiblePlayer(db, game.ID, *revengeAction.TargetPlayerID, player, seerInvestigated)
-				isTheHunter = (revengeAction.ActorPlayerID == playerID)
-			}
-		}
-
-		dayVoteCounts := map[int64]int{}
-		votersByTarget := map[int64][]VoterChip{}
-		var passVoters []string
-		var currentVotePlayer *Player
-
-		type voteCountRow struct {
-			TargetPlayerID int64 `db:"target_player_id"`
-			Count          int   `db:"count"`
-		}
-		var dayCountRows []voteCountRow
-		db.Select(&dayCountRows, `
-			SELECT target_player_id, COUNT(*) as count FROM game_action
-			WHERE game_id=? AND round=? AND phase='day' AND action_type=? AND target_player_id IS NOT NULL
-			GROUP BY target_player_id`,
-			game.ID, game.Round, ActionDaySelectKill)
-		for _, r := range dayCountRows {
-			dayVoteCounts[r.TargetPlayerID] = r.Count
-		}
-
-		var actions []GameAction
-		db.Select(&actions, `
-			SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
-			FROM game_action
-			WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
-			game.ID, game.Round, ActionDaySelectKill)
-
-		for _, action := range actions {
-			var voterName string
-			db.Get(&voterName, "SELECT name FROM player WHERE rowid = ?", action.ActorPlayerID)
-			if action.TargetPlayerID != nil {
-				votersByTarget[*action.TargetPlayerID] = append(votersByTarget[*action.TargetPlayerID], VoterChip{Name: voterName, PlayerUID: action.ActorPlayerID})
-				if action.ActorPlayerID == playerID {
-					currentVotePlayer = getVisiblePlayer(db, game.ID, *action.TargetPlayerID, player, seerInvestigated)
-				}
-			} else {
-				passVoters = append(passVoters, voterName)
-			}
-		}
-
-		// All-acted and has-voted checks for End Vote button
-		var totalDayActed int
-		db.Get(&totalDayActed, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ?`,
-			game.ID, game.Round, ActionDaySelectKill)
-		var playerActed int
-		db.Get(&playerActed, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND action_type = ? AND actor_player_id = ?`,
-			game.ID, game.Round, ActionDaySelectKill, playerID)
-
-		var nightVictimCards []PlayerCardData
-		for _, v := range nightVictims {
-			card := makePlayerCard(v, lang)
-			card.ShowRoleSeal = true
-			card.Collapsed = true
-			card.Lover = v.Lover != 0
-			nightVictimCards = append(nightVictimCards, card)
-		}
-
-		var hunterTargetCards []PlayerCardData
-		for _, t := range hunterTargets {
-			card := makePlayerCard(t, lang)
-			card.Selectable = true
-			card.Lover = isViewerLover(t, player)
-			if hunterSelectedPlayer != nil && hunterSelectedPlayer.PlayerID == t.PlayerID {
-				card.Selected = true
-			}
-			hunterTargetCards = append(hunterTargetCards, card)
-		}
-
-		var voteTargetCards []PlayerCardData
-		for _, t := range aliveTargets {
-			card := makePlayerCard(t, lang)
-			card.Selectable = true
-			card.ShowVoteCount = true
-			card.VoteCount = dayVoteCounts[t.PlayerID]
-			card.Voters = votersByTarget[t.PlayerID]
-			card.Lover = isViewerLover(t, player)
-			if currentVotePlayer != nil && currentVotePlayer.PlayerID == t.PlayerID {
-				card.Selected = true
-			}
-			voteTargetCards = append(voteTargetCards, card)
-		}
-
-		data := DayData{
-			Player:               &player,
-			AliveTargets:         aliveTargets,
-			NightNumber:          game.Round,
-			NightVictims:         nightVictims,
-			PassVoters:           passVoters,
-			CurrentVotePlayer:    currentVotePlayer,
-			HunterRevengeNeeded:  hunterRevengeNeeded,
-			HunterRevengeDone:    hunterRevengeDone,
-			HunterVictimPlayer:   hunterVictimPlayer,
-			IsTheHunter:          isTheHunter,
-			HunterSelectedPlayer: hunterSelectedPlayer,
-			HunterTargets:        hunterTargets,
-			AllActed:             totalDayActed >= len(aliveTargets),
-			HasVoted:             playerActed > 0,
-			Lang:                 lang,
-			NightVictimCards:     nightVictimCards,
-			HunterTargetCards:    hunterTargetCards,
-			VoteTargetCards:      voteTargetCards,
-		}
-
 		if err := tmpl.ExecuteTemplate(&buf, "day_content.html", data); err != nil {
 			h.logError("getGameComponent: ExecuteTemplate day_content", err)
 			return nil, err
@@ -1306,7 +1438,7 @@ func getGameComponent(h *Hub, playerID int64, game *Game, lang string) (*bytes.B
 
 		var winners, losers []Player
 		for _, p := range players {
-			if playerWon(winner, p.Team, p.IsAlive) {
+			if playerWon(winner, winTeam(p.RoleName, p.Team), p.IsAlive) {
 				winners = append(winners, p)
 			} else {
 				losers = append(losers, p)
@@ -1343,6 +1475,18 @@ func getGameComponent(h *Hub, playerID int64, game *Game, lang string) (*bytes.B
 		}
 	}
 
+	narrator := buildNarratorPanel(db, game, playerID, lang)
+	if err := tmpl.ExecuteTemplate(&buf, "narrator_panel.html", narrator); err != nil {
+		h.logError("getGameComponent: ExecuteTemplate narrator_panel", err)
+		return nil, err
+	}
+
+	pause := PauseData{Paused: game.Paused, IsHost: rs.HostID == playerID, Lang: lang}
+	if err := tmpl.ExecuteTemplate(&buf, "pause_overlay.html", pause); err != nil {
+		h.logError("getGameComponent: ExecuteTemplate pause_overlay", err)
+		return nil, err
+	}
+
 	return &buf, nil
 }
 
@@ -1382,16 +1526,43 @@ func (app *App) registerAppRoutes(wrap func(string, http.HandlerFunc)) {
 	wrap("/", app.handleIndex)
 	wrap("/signin", app.handleSignin)
 	wrap("/logout", app.handleLogout)
+	wrap("POST /logout/everywhere", app.handleLogoutEverywhere)
+	wrap("POST /sessions/{id}/revoke", app.handleRevokeSession)
+	wrap("/account/delete", app.handleDeleteAccount)
 	wrap("/set-lang", app.handleSetLang)
 	wrap("/check-game", app.handleCheckGame)
 	wrap("/check-name", app.handleCheckName)
 	wrap("/game/{name}", app.handleGame)
+	wrap("/game/{name}/qr", app.handleInviteQR)
 	wrap("/ws/{name}", func(w http.ResponseWriter, r *http.Request) {
 		gameName := r.PathValue("name")
 		hub := app.getOrCreateHub(gameName)
 		handleWebSocket(hub, w, r)
 	})
 	wrap("/player/upload-image", app.handleUploadPlayerImage)
+	wrap("/history", app.handleArchive)
+	wrap("/history/{id}", app.handleArchivedGame)
+	wrap("/history/{id}/export.json", app.handleGameExport)
+	wrap("/history/{id}/replay", app.handleReplay)
+	wrap("/stats", app.handleStats)
+	wrap("/stats/{name}", app.handleStats)
+	wrap("/leaderboard", app.handleLeaderboard)
+	wrap("GET /auth/{provider}", app.handleOAuthStart)
+	wrap("GET /auth/{provider}/callback", app.handleOAuthCallback)
+
+	// JSON API for other clients (api.go), described by its OpenAPI document (openapi.go)
+	for _, route := range apiRoutes {
+		wrap(route.Method+" /api/v1"+route.Path, func(w http.ResponseWriter, r *http.Request) {
+			route.Handler(app, w, r)
+		})
+	}
+	wrap("GET /api/v1/openapi.json", app.handleOpenAPI)
+
+	// admin API (admin.go), behind the admin token
+	wrap("GET /admin/v1/games", app.handleAdminGames)
+	wrap("GET /admin/v1/players", app.handleAdminPlayers)
+	wrap("POST /admin/v1/games/{id}/finish", app.handleAdminFinishGame)
+	wrap("POST /admin/v1/cleanup", app.handleAdminCleanup)
 }
 
 func main() {
@@ -1402,15 +1573,32 @@ func main() {
 	cfg := loadConfig(*fv.configPath)
 	fv.applyTo(&cfg)
 
+	// before the log file is opened: a backup next to a running server must not truncate its log
+	if ran, err := runBackupCommand(cfg.DB, *fv.backup, *fv.restore); ran {
+		if err != nil {
+			log.Fatal("Backup/restore failed:", err)
+		}
+		log.Println("Backup/restore done")
+		return
+	}
+
 	devMode = cfg.Dev
-	cfg.logConfig()
+	if cfg.SessionDays > 0 {
+		sessionLifetime = time.Duration(cfg.SessionDays) * 24 * time.Hour
+	}
 
-	logFile, err := os.OpenFile("werewolf.log", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-	if err != nil {
-		log.Fatal("Failed to open log file:", err)
+	logOutput := io.Writer(os.Stdout)
+	if cfg.LogFile != "" && cfg.LogFile != "off" {
+		logFile, err := openRotatingFile(cfg.LogFile, int64(cfg.LogMaxSizeMB)<<20, time.Duration(cfg.LogMaxAgeHours)*time.Hour, cfg.LogKeep)
+		if err != nil {
+			log.Fatal("Failed to open log file:", err)
+		}
+		defer logFile.Close()
+		logOutput = io.MultiWriter(os.Stdout, logFile)
 	}
-	defer logFile.Close()
-	log.SetOutput(io.MultiWriter(os.Stdout, logFile))
+	log.SetOutput(logOutput)
+	setupLogFormat(cfg.LogFormat, logOutput)
+	cfg.logConfig()
 
 	logger, err := NewAppLogger(cfg.toLogConfig())
 	if err != nil {
@@ -1454,6 +1642,7 @@ func main() {
 		},
 		"buildVersion": func() string { return buildVersion },
 		"T":            T,
+		"TOr":          TOr,
 	})
 	tmpl, err := template.New("").Funcs(funcMap).ParseFS(templateFS, "templates/*.html")
 	if err != nil {
@@ -1476,12 +1665,16 @@ func main() {
 		pageStyleTag:       pageStyleTag,
 		pageGameScriptTag:  pageGameScriptTag,
 		pageIndexScriptTag: pageIndexScriptTag,
+		adminToken:         cfg.AdminToken,
+		oauthProviders:     oauthProvidersFromConfig(cfg),
+		publicURL:          cfg.PublicURL,
 	}
 
 	wrapHandler := func(pattern string, handler http.HandlerFunc) {
 		var hh http.Handler = handler
 		hh = withGzip(hh)
 		hh = disableCaching(hh)
+		hh = withRequestID(hh, structuredLog)
 		if appLogger != nil && appLogger.logRequests {
 			http.Handle(pattern, &LoggingHandler{Handler: hh, Logger: appLogger})
 		} else {
@@ -1490,6 +1683,7 @@ func main() {
 	}
 
 	app.registerAppRoutes(wrapHandler)
+	go app.runSessionCleanup(time.Hour)
 	// Image endpoint: register directly (not via wrapHandler) to allow browser caching
 	http.HandleFunc("/player-image/{imageID}", app.handlePlayerImage)
 
@@ -1514,6 +1708,23 @@ func main() {
 	http.Handle("/static/", staticHandler)
 
 	log.Printf("Build version: %s", buildVersion)
-	log.Printf("Server starting on %s", cfg.Addr)
-	log.Fatal(http.ListenAndServe(cfg.Addr, nil))
+	tlsConf, err := tlsConfig(cfg)
+	if err != nil {
+		log.Fatal("Failed to set up TLS:", err)
+	}
+	trustedProxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatal("Invalid trusted_proxies:", err)
+	}
+	// listening before serving reports a taken port at once, and with port 0 the one picked
+	listener, err := net.Listen("tcp", listenAddr(cfg.Addr))
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", cfg.Addr, err)
+	}
+	scheme := "http"
+	if tlsConf != nil {
+		listener, scheme = tls.NewListener(listener, tlsConf), "https"
+	}
+	log.Printf("Server starting on %s://%s", scheme, listener.Addr())
+	log.Fatal(http.Serve(listener, withProxyHeaders(http.DefaultServeMux, trustedProxies)))
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+// ============================================================================
+// Mayor Tests
+// ============================================================================
+
+func TestMayorVoteCountsTwice(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Mayor", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleMayor, RoleVillager, RoleVillager, RoleVillager})
+	wolf, mayor := ids[0], ids[1]
+	wolfID := strconv.FormatInt(wolf, 10)
+
+	// Mayor (2) + V1 + V3 = 4 of 6 vote weight
+	ctx.sendWS(mayor, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	ctx.sendWS(ids[2], WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	ctx.sendWS(wolf, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(ids[3], 10)})
+	ctx.sendWS(ids[3], WSMessage{Action: "day_pass"})
+	ctx.sendWS(ids[4], WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+
+	game, _ := ctx.hub().getGame()
+	counts, total, err := getVoteCounts(ctx.app.db, game.ID, game.Round, "day", ActionDaySelectKill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts[wolf] != 4 || total != 6 {
+		t.Fatalf("expected 4 weighted votes on the wolf out of 6, got %d of %d", counts[wolf], total)
+	}
+
+	ctx.sendWS(mayor, WSMessage{Action: "day_end_vote"})
+	if ctx.isPlayerAlive(wolf) {
+		t.Error("4 of 6 vote weight is a majority; the wolf should be eliminated")
+	}
+}
+
+func TestMayorWeightDecidesMajority(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Mayor", "V1", "V2"},
+		[]string{RoleWerewolf, RoleMayor, RoleVillager, RoleVillager})
+	wolf, mayor := ids[0], ids[1]
+	wolfID := strconv.FormatInt(wolf, 10)
+
+	// 2 of 4 heads would be no majority; 3 of 5 weight is
+	ctx.sendWS(mayor, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	ctx.sendWS(ids[2], WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	ctx.sendWS(wolf, WSMessage{Action: "day_pass"})
+	ctx.sendWS(ids[3], WSMessage{Action: "day_pass"})
+	ctx.sendWS(mayor, WSMessage{Action: "day_end_vote"})
+
+	if ctx.isPlayerAlive(wolf) {
+		t.Error("the Mayor's double vote should carry the majority")
+	}
+}
+
+func TestMayorVoteCountsTwiceInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Mayor's day vote counts twice ===")
+
+	// Setup: 1 werewolf + 1 mayor + 3 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"MA1", "MA2", "MA3", "MA4", "MA5"},
+		RoleWerewolf, RoleMayor, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Mayor"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	mayor, werewolf, victim := byRole["Mayor"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+
+	werewolf.voteForPlayer(victim.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if found, _, _ := mayor.p().Has("#mayor-vote-note"); !found {
+		ctx.logger.LogDB("FAIL: mayor not told the vote counts twice")
+		t.Errorf("Mayor should be told their vote counts twice")
+	}
+
+	mayor.dayVoteForPlayer(werewolf.Name)
+	if count := mayor.getDayVoteCount(werewolf.Name); count != "2" {
+		ctx.logger.LogDB("FAIL: mayor vote did not count twice")
+		t.Errorf("The Mayor's vote should count as 2, got: %s", count)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Schema changes are versioned migrations: SQL files in migrations/ named
+// NNNN_description.sql, embedded in the binary and applied in order on startup by migrate.
+// schema_version keeps one row per applied migration, so each runs once per database. The
+// tables initDB creates are the baseline every migration builds on.
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// loadMigrations returns the embedded migrations sorted by version.
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	var migrations []migration
+	seen := make(map[int]string)
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.TrimPrefix(file, "migrations/"), ".sql")
+		prefix, _, ok := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must start with a version number, like 0001_name.sql", file)
+		}
+		if other, dup := seen[version]; dup {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, name, version)
+		}
+		seen[version] = name
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration{version: version, name: name, sql: string(data)})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
+
+// schemaVersion returns the version of the last migration applied, 0 for none.
+func schemaVersion(db *sqlx.DB) int {
+	var version int
+	db.Get(&version, "SELECT IFNULL(MAX(version), 0) FROM schema_version")
+	return version
+}
+
+// migrate applies the migrations the database has not seen yet, each in a transaction of
+// its own together with its schema_version row.
+func migrate(db *sqlx.DB, fsys fs.FS, logfn func(string, ...any)) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return err
+	}
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		return err
+	}
+
+	current := schemaVersion(db)
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		tx, err := db.Beginx()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(m.sql); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s: %w", m.name, err)
+		}
+		if _, err := tx.Exec("INSERT INTO schema_version (version, name) VALUES (?, ?)", m.version, m.name); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s: %w", m.name, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %s: %w", m.name, err)
+		}
+		logfn("Applied migration %s", m.name)
+	}
+	return nil
+}
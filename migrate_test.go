@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// ============================================================================
+// Migration Tests
+// ============================================================================
+
+func TestMigrationsApplyOnce(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+	db := ctx.app.db
+
+	embedded, err := loadMigrations(migrationFS)
+	if err != nil || len(embedded) == 0 {
+		t.Fatalf("the embedded migrations should load (err: %v)", err)
+	}
+	latest := embedded[len(embedded)-1].version
+	if v := schemaVersion(db); v != latest {
+		t.Fatalf("initDB should bring the schema to version %d, got %d", latest, v)
+	}
+
+	fsys := fstest.MapFS{
+		"migrations/0001_game_chat_channel_index.sql": {Data: []byte("SELECT 1;")},
+		"migrations/9002_second.sql":                  {Data: []byte("ALTER TABLE game ADD COLUMN migrated_b INTEGER NOT NULL DEFAULT 0;")},
+		"migrations/9001_first.sql":                   {Data: []byte("ALTER TABLE game ADD COLUMN migrated_a INTEGER NOT NULL DEFAULT 0;")},
+	}
+	for range 2 {
+		if err := migrate(db, fsys, t.Logf); err != nil {
+			t.Fatalf("the migrations should apply once and then be skipped: %v", err)
+		}
+	}
+	if v := schemaVersion(db); v != 9002 {
+		t.Errorf("the schema should be at version 9002, got %d", v)
+	}
+	var applied []string
+	db.Select(&applied, "SELECT name FROM schema_version WHERE version > 9000 ORDER BY rowid")
+	if strings.Join(applied, ",") != "9001_first,9002_second" {
+		t.Errorf("the migrations should run in version order, got %q", applied)
+	}
+}
+
+func TestFailedMigrationRollsBack(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+	db := ctx.app.db
+
+	fsys := fstest.MapFS{
+		"migrations/9001_broken.sql": {Data: []byte("ALTER TABLE game ADD COLUMN half_done INTEGER; SELECT * FROM no_such_table;")},
+	}
+	if err := migrate(db, fsys, t.Logf); err == nil {
+		t.Fatal("a broken migration should fail")
+	}
+	if v := schemaVersion(db); v >= 9001 {
+		t.Errorf("a failed migration should not be recorded, got version %d", v)
+	}
+	var columns int
+	db.Get(&columns, "SELECT COUNT(*) FROM pragma_table_info('game') WHERE name = 'half_done'")
+	if columns != 0 {
+		t.Error("a failed migration should leave no changes behind")
+	}
+
+	bad := fstest.MapFS{"migrations/first.sql": {Data: []byte("SELECT 1;")}}
+	if _, err := loadMigrations(bad); err == nil {
+		t.Error("a migration without a version number should be refused")
+	}
+}
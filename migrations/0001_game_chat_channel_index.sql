@@ -0,0 +1,2 @@
+-- chatMessages reads one channel of one game on every broadcast
+CREATE INDEX IF NOT EXISTS idx_game_chat_channel ON game_chat(game_id, channel);
@@ -0,0 +1,5 @@
+-- finished games are archived under an empty name (archive.go); archived_name keeps the name
+-- they were played under
+ALTER TABLE game ADD COLUMN archived_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE game ADD COLUMN finished_at DATETIME;
+CREATE INDEX IF NOT EXISTS idx_game_archived ON game(status, finished_at);
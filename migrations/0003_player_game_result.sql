@@ -0,0 +1,15 @@
+-- one row per player and finished game, written by endGame (stats.go)
+CREATE TABLE IF NOT EXISTS player_game_result (
+	game_id INTEGER NOT NULL,
+	player_id INTEGER NOT NULL,
+	role_name TEXT NOT NULL,
+	team TEXT NOT NULL,
+	won INTEGER NOT NULL DEFAULT 0,
+	survived INTEGER NOT NULL DEFAULT 0,
+	lynched_first_day INTEGER NOT NULL DEFAULT 0,
+	finished_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (game_id) REFERENCES game(rowid),
+	FOREIGN KEY (player_id) REFERENCES player(rowid),
+	UNIQUE(game_id, player_id)
+);
+CREATE INDEX IF NOT EXISTS idx_player_game_result_player ON player_game_result(player_id);
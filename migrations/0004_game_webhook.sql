@@ -0,0 +1,2 @@
+-- the URL a game posts its events to (webhook.go); empty when none is set
+ALTER TABLE game ADD COLUMN webhook_url TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,10 @@
+-- bot players driven through the JSON API (bot.go): the bot's player row, who made it and
+-- the SHA-256 of the bearer token it signs its calls with, stored like a session token
+CREATE TABLE IF NOT EXISTS bot (
+	player_id INTEGER PRIMARY KEY,
+	owner_player_id INTEGER NOT NULL,
+	token_hash TEXT UNIQUE NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (player_id) REFERENCES player(rowid)
+);
+CREATE INDEX IF NOT EXISTS idx_bot_owner ON bot(owner_player_id);
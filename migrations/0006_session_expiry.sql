@@ -0,0 +1,6 @@
+-- sessions expire (auth.go): when each was made and when it runs out, pushed back while
+-- the player is active. Sessions from before get a fresh 30 days.
+ALTER TABLE session ADD COLUMN created_at DATETIME;
+ALTER TABLE session ADD COLUMN expires_at DATETIME;
+UPDATE session SET created_at = datetime('now'), expires_at = datetime('now', '+30 days');
+CREATE INDEX IF NOT EXISTS idx_session_expires ON session(expires_at);
@@ -0,0 +1,11 @@
+-- OAuth sign-in (oauth.go): the player behind each external account, e.g. a Google
+-- subject or a Discord user id
+CREATE TABLE IF NOT EXISTS player_oauth (
+	provider TEXT NOT NULL,
+	external_id TEXT NOT NULL,
+	player_id INTEGER NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (provider, external_id),
+	FOREIGN KEY (player_id) REFERENCES player(rowid)
+);
+CREATE INDEX IF NOT EXISTS idx_player_oauth_player ON player_oauth(player_id);
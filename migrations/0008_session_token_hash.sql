@@ -0,0 +1,4 @@
+-- session tokens are opaque strings (auth.go) and only their SHA-256 is stored; initDB
+-- fills token_hash for the sessions made before and clears their integer token
+ALTER TABLE session ADD COLUMN token_hash TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_session_token_hash ON session(token_hash);
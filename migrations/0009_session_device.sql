@@ -0,0 +1,5 @@
+-- the start page lists a player's sessions (sessions.go): the browser each was made in
+-- and when it was last used (updated when the session is renewed)
+ALTER TABLE session ADD COLUMN user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE session ADD COLUMN last_seen_at DATETIME;
+UPDATE session SET last_seen_at = created_at;
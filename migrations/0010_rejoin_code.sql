@@ -0,0 +1,11 @@
+-- host-issued rejoin codes (rejoin.go): a one-time code for a player who lost their secret
+-- code, for their seat in one game, stored as a hash like the secret code and valid for a
+-- short while
+CREATE TABLE IF NOT EXISTS rejoin_code (
+	player_id INTEGER PRIMARY KEY,
+	game_id INTEGER NOT NULL,
+	code_hash TEXT NOT NULL,
+	expires_at DATETIME NOT NULL,
+	FOREIGN KEY (player_id) REFERENCES player(rowid),
+	FOREIGN KEY (game_id) REFERENCES game(rowid)
+);
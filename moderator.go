@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// The host (the narrator, in narrator mode) can override the game state when the table
+// needs it: kill or revive a player, change a player's role, or force the phase on. An
+// override is applied as is, no death triggers (lovers, the Hunter's shot) fire, though a
+// kill or a revival can still end the game. Every override is recorded with
+// VisibilityModerator: the host sees it in the history, everyone else once the game is over.
+
+// ModeratorData fills the host's override panel in the sidebar; Show is false for everyone
+// else and outside a running game.
+type ModeratorData struct {
+	Show    bool
+	Players []SeatChoice
+	Roles   []SeatChoice
+}
+
+// moderatorActions are the host's overrides; each records its own action type.
+var moderatorActions = map[string]string{
+	"moderator_kill":     ActionModeratorKill,
+	"moderator_revive":   ActionModeratorRevive,
+	"moderator_set_role": ActionModeratorSetRole,
+}
+
+func (h *Hub) buildModeratorData(game *Game, viewerID int64) ModeratorData {
+	var d ModeratorData
+	if !gameRunning(game) || gameHost(h.db, game.ID) != viewerID {
+		return d
+	}
+	players, _ := getPlayersByGameId(h.db, game.ID)
+	for _, p := range players {
+		d.Players = append(d.Players, SeatChoice{ID: p.PlayerID, Name: p.Name})
+	}
+	h.db.Select(&d.Roles, "SELECT rowid as id, name FROM role WHERE name != 'Joker' ORDER BY name")
+	d.Show = len(d.Players) > 0
+	return d
+}
+
+// canSeeOverride reports whether the viewer sees an override in the history: the host does,
+// everyone else once the game is over.
+func canSeeOverride(db *sqlx.DB, game *Game, viewerID int64) bool {
+	return game.Status == "finished" || gameHost(db, game.ID) == viewerID
+}
+
+// recordOverride writes an override to the game's actions. The player it concerns is the
+// actor, so overrides on different players in one phase do not collide; a repeated override
+// replaces the earlier one.
+func (h *Hub) recordOverride(game *Game, actionType string, playerID int64, desc, key, args string) {
+	_, err := h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description, description_key, description_args) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, game.Status, playerID, actionType, VisibilityModerator, desc, key, args)
+	if err != nil {
+		h.logError("recordOverride: db.Exec", err)
+	}
+}
+
+// handleWSModeratorOverride kills, revives or recasts a seated player.
+func handleWSModeratorOverride(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSModeratorOverride: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if !gameRunning(game) {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_not_running"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || target.IsObserver {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	actionType := moderatorActions[msg.Action]
+	switch msg.Action {
+	case "moderator_kill", "moderator_revive":
+		alive := msg.Action == "moderator_revive"
+		if target.IsAlive == alive {
+			h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+			return
+		}
+		if _, err := h.db.Exec("UPDATE game_player SET is_alive = ? WHERE game_id = ? AND player_id = ?", alive, game.ID, targetID); err != nil {
+			h.logError("handleWSModeratorOverride: update is_alive", err)
+			h.sendErrorToast(client.playerID, T(lang, "err_failed_override"))
+			return
+		}
+		desc, key := "Round %d: The host killed %s", "hist_moderator_kill"
+		if alive {
+			desc, key = "Round %d: The host revived %s", "hist_moderator_revive"
+		}
+		h.recordOverride(game, actionType, targetID, fmt.Sprintf(desc, game.Round, target.Name), key, histArgs(game.Round, target.Name))
+	case "moderator_set_role":
+		var roleName string
+		if err := h.db.Get(&roleName, "SELECT name FROM role WHERE rowid = ? AND name != 'Joker'", msg.RoleID); err != nil {
+			h.sendErrorToast(client.playerID, T(lang, "err_invalid_role"))
+			return
+		}
+		if _, err := h.db.Exec("UPDATE game_player SET role_id = ? WHERE game_id = ? AND player_id = ?", msg.RoleID, game.ID, targetID); err != nil {
+			h.logError("handleWSModeratorOverride: update role_id", err)
+			h.sendErrorToast(client.playerID, T(lang, "err_failed_override"))
+			return
+		}
+		h.recordOverride(game, actionType, targetID, fmt.Sprintf("Round %d: The host made %s the %s", game.Round, target.Name, roleName),
+			"hist_moderator_set_role", histArgs(game.Round, target.Name, roleName))
+	default:
+		return
+	}
+
+	h.logf("Host override %s on '%s' in game %d", msg.Action, target.Name, game.ID)
+	if !h.checkWinConditions(game) {
+		h.triggerBroadcast()
+	}
+}
+
+// handleWSModeratorForcePhase moves the game on like the narrator's advance, in any game.
+func handleWSModeratorForcePhase(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSModeratorForcePhase: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	switch game.Status {
+	case "night":
+		h.recordOverride(game, ActionModeratorForcePhase, client.playerID, fmt.Sprintf("Night %d: The host forced the day to begin", game.Round),
+			"hist_moderator_force_night", histArgs(game.Round))
+		h.endNight(game.ID, game.Round, true)
+	case "day":
+		if hunterShotPending(h.db, game.ID) || scapegoatChoicePending(h.db, game.ID, game.Round) || lastWordsHeld(h.db, game) {
+			h.sendErrorToast(client.playerID, T(lang, "err_day_waits_on_player"))
+			return
+		}
+		h.recordOverride(game, ActionModeratorForcePhase, client.playerID, fmt.Sprintf("Day %d: The host forced the vote to close", game.Round),
+			"hist_moderator_force_day", histArgs(game.Round))
+		h.endDay(game.ID, game.Round, true)
+	default:
+		h.sendErrorToast(client.playerID, T(lang, "err_nothing_to_advance"))
+		return
+	}
+	h.logf("Host forced the phase on in game %d", game.ID)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Moderator Override Tests
+// ============================================================================
+
+func TestHostOverridesPlayers(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host, wolf, v1, v2 := ids[0], ids[1], ids[2], ids[3]
+	v1ID := strconv.FormatInt(v1, 10)
+
+	ctx.sendWS(wolf, WSMessage{Action: "moderator_kill", TargetPlayerID: v1ID})
+	if !ctx.isPlayerAlive(v1) {
+		t.Fatal("only the host should override the game")
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "moderator_kill", TargetPlayerID: v1ID})
+	if ctx.isPlayerAlive(v1) {
+		t.Fatal("the host should kill V1")
+	}
+	if h := ctx.historyFor(host); !strings.Contains(h, "The host killed V1") {
+		t.Errorf("the host should see the override in the history, got %q", h)
+	}
+	if h := ctx.historyFor(v2); strings.Contains(h, "The host killed") {
+		t.Errorf("players should not see the override while the game runs, got %q", h)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "moderator_revive", TargetPlayerID: v1ID})
+	if !ctx.isPlayerAlive(v1) {
+		t.Fatal("the host should revive V1")
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "moderator_set_role", TargetPlayerID: strconv.FormatInt(v2, 10), RoleID: RoleSeer})
+	game, _ := ctx.hub().getGame()
+	if role := getRoleName(ctx.app.db, game.ID, v2); role != "Seer" {
+		t.Errorf("the host should make V2 the Seer, got %q", role)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "moderator_kill", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	if status, _, winner := ctx.gameState(); status != "finished" || winner != "villagers" {
+		t.Fatalf("killing the last wolf should end the game, got %q/%q", status, winner)
+	}
+	if h := ctx.historyFor(v2); !strings.Contains(h, "The host made V2 the Seer") {
+		t.Errorf("everyone should see the overrides once the game is over, got %q", h)
+	}
+}
+
+func TestHostForcesThePhase(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host, wolf := ids[0], ids[1]
+
+	ctx.sendWS(wolf, WSMessage{Action: "moderator_force_phase"})
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("only the host should force the phase, got %q", status)
+	}
+	ctx.sendWS(host, WSMessage{Action: "moderator_force_phase"})
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("the host should force the day to begin, got %q", status)
+	}
+	if n := ctx.countActions(ActionModeratorForcePhase); n != 1 {
+		t.Errorf("the forced phase should be recorded, got %d", n)
+	}
+}
+
+func TestModeratorPanelInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the host's overrides ===")
+
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"Host", "P2", "P3", "P4", "P5"},
+		RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	host := players[0]
+
+	// a villager other than the host, so the game goes on
+	_, villagers := findPlayersByRole(players[1:])
+	target := villagers[0]
+	host.submitFormWithValues("moderator-form", map[string]string{
+		"target_player_id": host.optionValue("#moderator-player", target.Name),
+		"action":           "moderator_kill",
+	})
+	if err := host.waitUntilCondition(`() => document.querySelector('#history-bar')?.textContent.includes('The host killed `+target.Name+`')`, "override in history"); err != nil {
+		ctx.logger.LogDB("FAIL: moderator kill not applied")
+		t.Fatalf("the host should see the override in the history: %v", err)
+	}
+	if villagers[1].historyContains("The host killed") {
+		t.Error("players should not see the override while the game runs")
+	}
+
+	host.acceptConfirms()
+	host.clickAndWait("#btn-force-phase")
+	waitForDayPhaseAll(ctx, players)
+	if !villagers[1].isInDayPhase() {
+		ctx.logger.LogDB("FAIL: phase not forced")
+		t.Fatal("the host should force the day to begin")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
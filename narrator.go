@@ -0,0 +1,145 @@
+package main
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// Narrator mode is for a village playing around one table: the host runs the game from their
+// own device instead of playing. They watch (an observer, dealt no card), see every role and
+// who still owes tonight's action or today's vote, and move the game on by hand: the night
+// ends and the vote closes only when they say so, and the night and day timers stay off.
+// Players use their devices for their secret actions only.
+
+// NarratorPanel is the narrator's view of the running game, shown under the game content.
+type NarratorPanel struct {
+	Show    bool // the viewer narrates this game
+	Phase   string
+	Round   int
+	Players []NarratorRow
+	Script  []string // tonight's call order, read out loud (narrator_script.go)
+	Lang    string
+}
+
+// NarratorRow is one seated player as the narrator sees them.
+type NarratorRow struct {
+	Name     string
+	RoleName string
+	IsAlive  bool
+	Done     bool   // has acted tonight, or voted today
+	Target   string // who they picked so far; "" when nobody (yet) or a pass
+}
+
+// narratorModeEnabled reports whether the host narrates instead of playing; kept for the next game.
+func narratorModeEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT narrator_mode FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// isNarrator reports whether the player narrates the game: narrator mode is on and they are
+// the host, watching.
+func isNarrator(db *sqlx.DB, gameID, playerID int64) bool {
+	return narratorModeEnabled(db, gameID) && gameHost(db, gameID) == playerID && isObserver(db, gameID, playerID)
+}
+
+// buildNarratorPanel fills the narrator's panel; for anyone else it stays empty and hidden.
+func buildNarratorPanel(db *sqlx.DB, game *Game, playerID int64, lang string) NarratorPanel {
+	panel := NarratorPanel{Phase: game.Status, Round: game.Round, Lang: lang}
+	if !gameRunning(game) || !isNarrator(db, game.ID, playerID) {
+		return panel
+	}
+	panel.Show = true
+	if game.Status == "night" {
+		panel.Script = narratorScript(db, game, lang)
+	}
+
+	players, _ := getPlayersByGameId(db, game.ID)
+	voteType := dayVoteAction(db, game.ID, game.Round)
+	for _, p := range players {
+		row := NarratorRow{Name: p.Name, RoleName: TOr(lang, "role_name_"+p.RoleName, p.RoleName), IsAlive: p.IsAlive}
+		var target string
+		switch {
+		case !p.IsAlive:
+		case game.Status == "night":
+			row.Done = playerDoneWithNightAction(db, game.ID, game.Round, p)
+			db.Get(&target, `
+SELECT t.name FROM game_action ga JOIN player t ON t.rowid = ga.target_player_id
+WHERE ga.game_id = ? AND ga.round = ? AND ga.phase = 'night' AND ga.actor_player_id = ?
+	AND ga.action_type NOT IN (?, ?)
+ORDER BY ga.rowid DESC LIMIT 1`,
+				game.ID, game.Round, p.PlayerID, ActionNightSurveySelectSuspect, ActionNightSurveyApplySuspect)
+		case game.Status == "day":
+			var votes int
+			db.Get(&votes, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'day' AND actor_player_id = ? AND action_type = ?`,
+				game.ID, game.Round, p.PlayerID, voteType)
+			row.Done = votes > 0
+			db.Get(&target, `
+SELECT t.name FROM game_action ga JOIN player t ON t.rowid = ga.target_player_id
+WHERE ga.game_id = ? AND ga.round = ? AND ga.phase = 'day' AND ga.actor_player_id = ? AND ga.action_type = ?`,
+				game.ID, game.Round, p.PlayerID, voteType)
+		}
+		row.Target = target
+		panel.Players = append(panel.Players, row)
+	}
+	return panel
+}
+
+// handleWSToggleNarratorMode switches narrator mode in the lobby. Switching it on seats the
+// host among the observers, since the narrator is dealt no card.
+func handleWSToggleNarratorMode(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleNarratorMode: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET narrator_mode = NOT narrator_mode WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleNarratorMode: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_narrator_mode"))
+		return
+	}
+	if narratorModeEnabled(h.db, game.ID) {
+		h.db.Exec("UPDATE game_player SET is_observer = 1 WHERE game_id = ? AND player_id = ?", game.ID, client.playerID)
+	}
+	h.logf("Narrator mode toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
+
+// handleWSAdvancePhase is the narrator moving the game on: at night every action still owed
+// is skipped, the night resolves and the day dawns; by day the vote (or trial) is closed with
+// what is in. A Hunter's shot, a Scapegoat's choice or last words are still waited for.
+func handleWSAdvancePhase(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSAdvancePhase: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if !isNarrator(h.db, game.ID, client.playerID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_narrator_only"))
+		return
+	}
+
+	switch game.Status {
+	case "night":
+		h.logf("Narrator '%s' ends night %d", h.playerName(client.playerID), game.Round)
+		h.endNight(game.ID, game.Round, true)
+	case "day":
+		if hunterShotPending(h.db, game.ID) || scapegoatChoicePending(h.db, game.ID, game.Round) || lastWordsHeld(h.db, game) {
+			h.sendErrorToast(client.playerID, T(lang, "err_day_waits_on_player"))
+			return
+		}
+		h.logf("Narrator '%s' closes the vote of day %d", h.playerName(client.playerID), game.Round)
+		h.endDay(game.ID, game.Round, true)
+	default:
+		h.sendErrorToast(client.playerID, T(lang, "err_nothing_to_advance"))
+	}
+}
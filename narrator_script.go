@@ -0,0 +1,102 @@
+package main
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// The narrator's script is tonight's call order, read out loud at the table: everyone closes
+// their eyes, each role still in play wakes in the order of nightSteps, and the village wakes.
+// It is built from the roles actually dealt and still alive, so it changes from night to
+// night as players die, the first night's roles are done or the Elder's lynching takes the
+// village's powers away.
+
+// scriptStep is one call in the script: the line to read and whether it is read tonight.
+type scriptStep struct {
+	key   string
+	wakes func(db *sqlx.DB, game *Game) bool
+}
+
+// narratorScriptSteps follow the order of nightSteps.
+var narratorScriptSteps = []scriptStep{
+	{"script_cupid", firstNight(roleInPlay("Cupid"))},
+	{"script_lovers", firstNight(roleInPlay("Cupid"))},
+	{"script_doppelganger", firstNight(roleInPlay("Doppelganger"))},
+	{"script_wild_child", firstNight(roleInPlay("Wild Child"))},
+	{"script_masons", firstNight(roleInPlay("Mason"))},
+	{"script_guard", roleInPlay("Guard")},
+	{"script_bodyguard", roleInPlay("Bodyguard")},
+	{"script_seer", roleInPlay("Seer")},
+	{"script_aura_seer", roleInPlay("Aura Seer")},
+	{"script_fox", roleInPlay("Fox")},
+	{"script_sorceress", roleInPlay("Sorceress")},
+	{"script_spellcaster", roleInPlay("Spellcaster")},
+	{"script_wolves", wolvesHunt},
+	{"script_white_werewolf", whiteWolfHunts},
+	{"script_serial_killer", roleInPlay("Serial Killer")},
+	{"script_piper", roleInPlay("Piper")},
+	{"script_witch", roleInPlay("Witch")},
+	{"script_doctor", roleInPlay("Doctor")},
+}
+
+// roleInPlay wakes a role while a player with it is alive and it still has its power.
+func roleInPlay(role string) func(db *sqlx.DB, game *Game) bool {
+	return func(db *sqlx.DB, game *Game) bool {
+		if powerDisabled(db, game.ID, role) {
+			return false
+		}
+		var alive int
+		db.Get(&alive, `
+SELECT COUNT(*) FROM game_player g JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = ?`, game.ID, role)
+		return alive > 0
+	}
+}
+
+// firstNight limits a call to the first night.
+func firstNight(wakes func(db *sqlx.DB, game *Game) bool) func(db *sqlx.DB, game *Game) bool {
+	return func(db *sqlx.DB, game *Game) bool {
+		return game.Round == 1 && wakes(db, game)
+	}
+}
+
+// wolvesHunt wakes the pack unless it is sick tonight.
+func wolvesHunt(db *sqlx.DB, game *Game) bool {
+	if wolvesSkipNight(db, game.ID, game.Round) {
+		return false
+	}
+	var wolves int
+	db.Get(&wolves, `
+SELECT COUNT(*) FROM game_player g JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND `+wolfPackSQL, game.ID)
+	return wolves > 0
+}
+
+// whiteWolfHunts wakes the White Werewolf on the nights it may turn on the pack.
+func whiteWolfHunts(db *sqlx.DB, game *Game) bool {
+	return whiteWolfNight(game.Round) && roleInPlay("White Werewolf")(db, game)
+}
+
+// narratorScript returns tonight's lines in the order they are read.
+func narratorScript(db *sqlx.DB, game *Game, lang string) []string {
+	lines := []string{T(lang, "script_sleep")}
+	for _, step := range narratorScriptSteps {
+		if step.wakes(db, game) {
+			lines = append(lines, T(lang, step.key))
+		}
+	}
+
+	// custom roles with a night ability wake last, like their step in nightSteps
+	var custom []string
+	db.Select(&custom, `
+SELECT DISTINCT r.name FROM game_player g JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.ability != ''
+ORDER BY r.name`, game.ID)
+	for _, role := range custom {
+		if !powerDisabled(db, game.ID, role) {
+			name := TOr(lang, "role_name_"+role, role)
+			lines = append(lines, T(lang, "script_custom", name, name))
+		}
+	}
+
+	return append(lines, T(lang, "script_wake"))
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Narrator Script Tests
+// ============================================================================
+
+func TestNarratorScriptFollowsTheRolesInPlay(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Host", "Wolf", "Cupid", "Seer", "V1"},
+		[]string{RoleVillager, RoleWerewolf, RoleCupid, RoleSeer, RoleVillager})
+	host, seer := ids[0], ids[3]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET narrator_mode = 1 WHERE rowid = ?", game.ID)
+	ctx.app.db.MustExec("UPDATE game_player SET is_observer = 1, is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, host)
+
+	want := []string{"script_sleep", "script_cupid", "script_lovers", "script_seer", "script_wolves", "script_wake"}
+	script := narratorScript(ctx.app.db, game, "en")
+	if len(script) != len(want) {
+		t.Fatalf("expected %d lines on the first night, got %q", len(want), script)
+	}
+	for i, key := range want {
+		if script[i] != T("en", key) {
+			t.Errorf("line %d: expected %q, got %q", i, T("en", key), script[i])
+		}
+	}
+
+	buf, err := getGameComponent(ctx.hub(), host, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="narrator-script"`) || !strings.Contains(buf.String(), T("en", "script_cupid")) {
+		t.Errorf("the narrator's panel should show tonight's script (err: %v)", err)
+	}
+
+	// the second night: Cupid is done and the Seer is dead
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, seer)
+	ctx.app.db.MustExec("UPDATE game SET round = 2 WHERE rowid = ?", game.ID)
+	game, _ = ctx.hub().getGame()
+	script = narratorScript(ctx.app.db, game, "en")
+	if got := strings.Join(script, "|"); got != strings.Join([]string{T("en", "script_sleep"), T("en", "script_wolves"), T("en", "script_wake")}, "|") {
+		t.Errorf("the second night should only wake the pack, got %q", script)
+	}
+}
+
+func TestNarratorScriptInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the narrator's script for the night ===")
+
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"Host", "P2", "P3", "P4"},
+		[]string{"narrator-mode-toggle"}, RoleWerewolf, RoleSeer, RoleVillager)
+	host := players[0]
+	waitForNightPhaseAll(ctx, players[1:])
+
+	if err := host.waitUntilCondition(`() => document.querySelectorAll('#narrator-script li').length > 0`, "narrator script"); err != nil {
+		ctx.logger.LogDB("FAIL: no narrator script")
+		t.Fatalf("the narrator should get tonight's script: %v", err)
+	}
+	result, err := host.p().Eval(`() => Array.from(document.querySelectorAll('#narrator-script li')).map(li => li.textContent.trim()).join('\n')`)
+	if err != nil {
+		t.Fatalf("reading the script: %v", err)
+	}
+	script := result.Value.String()
+	for _, key := range []string{"script_sleep", "script_seer", "script_wolves", "script_wake"} {
+		if !strings.Contains(script, T("en", key)) {
+			t.Errorf("the script should read %q, got %q", T("en", key), script)
+		}
+	}
+	if strings.Contains(script, T("en", "script_cupid")) {
+		t.Errorf("the script should leave out roles not in play, got %q", script)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Narrator Mode Tests
+// ============================================================================
+
+func TestNarratorModeSeatsTheHostAsObserver(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0,
+		[]string{"Host", "P1", "P2", "P3"},
+		[]string{RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	host := ids[0]
+	game, _ := ctx.hub().getGame()
+	for _, rc := range [][2]string{{RoleWerewolf, "1"}, {RoleVillager, "2"}} {
+		ctx.app.db.MustExec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, ?)", game.ID, rc[0], rc[1])
+	}
+
+	ctx.sendWS(ids[1], WSMessage{Action: "toggle_narrator_mode"})
+	if narratorModeEnabled(ctx.app.db, game.ID) {
+		t.Fatal("only the host should switch narrator mode")
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "toggle_narrator_mode"})
+	if !narratorModeEnabled(ctx.app.db, game.ID) || !isObserver(ctx.app.db, game.ID, host) {
+		t.Fatal("narrator mode should be on, with the host watching")
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "start_game"})
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("the game should start with roles for the three players, got %q", status)
+	}
+	if ctx.isPlayerAlive(host) || !isNarrator(ctx.app.db, game.ID, host) {
+		t.Error("the host should narrate the game without a card")
+	}
+}
+
+func TestNarratorMovesTheGameOn(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Host", "Wolf", "V1", "V2", "V3"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	host, wolf, v1, v2, v3 := ids[0], ids[1], ids[2], ids[3], ids[4]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET narrator_mode = 1, night_timer = 60 WHERE rowid = ?", game.ID)
+	ctx.app.db.MustExec("UPDATE game_player SET is_observer = 1, is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, host)
+
+	ctx.hub().startNightTimer(game.ID, 1)
+	if left := ctx.hub().nightTimeLeft(game); left != "" {
+		t.Errorf("the narrator sets the pace, so the night timer should stay off, got %q", left)
+	}
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	buf, err := getGameComponent(ctx.hub(), host, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="btn-advance-phase"`) || !strings.Contains(buf.String(), "Werewolf") || !strings.Contains(buf.String(), "picked V1") {
+		t.Fatalf("the narrator should see every role, the pack's pick and the advance button (err: %v)", err)
+	}
+	buf, err = getGameComponent(ctx.hub(), v2, game, "en")
+	if err != nil || strings.Contains(buf.String(), `id="btn-advance-phase"`) {
+		t.Errorf("players should not get the narrator's panel (err: %v)", err)
+	}
+
+	ctx.sendWS(wolf, WSMessage{Action: "advance_phase"})
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("only the narrator should move the game on, got %q", status)
+	}
+	ctx.sendWS(host, WSMessage{Action: "advance_phase"})
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("the narrator should wake the village, got %q", status)
+	}
+	if ctx.isPlayerAlive(v1) {
+		t.Error("the pack's victim should be found dead at dawn")
+	}
+
+	wolfID := strconv.FormatInt(wolf, 10)
+	for _, id := range []int64{wolf, v2, v3} {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: wolfID})
+	}
+	ctx.sendWS(v2, WSMessage{Action: "day_end_vote"})
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("with a narrator the players should not close the vote, got %q", status)
+	}
+	ctx.sendWS(host, WSMessage{Action: "advance_phase"})
+	if status, _, winner := ctx.gameState(); status != "finished" || winner != "villagers" {
+		t.Errorf("the narrator should close the vote and the village win, got %q/%q", status, winner)
+	}
+}
+
+func TestNarratorModeInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the host narrating the game ===")
+
+	players := startGameWithSettings(browser, ctx.baseURL, []string{"Host", "P2", "P3", "P4"},
+		[]string{"narrator-mode-toggle"}, RoleWerewolf, RoleVillager, RoleVillager)
+	host, seated := players[0], players[1:]
+	waitForNightPhaseAll(ctx, seated)
+
+	if err := host.waitUntilCondition(`() => document.querySelector('#narrator-panel')?.hidden === false`, "narrator panel"); err != nil {
+		ctx.logger.LogDB("FAIL: no narrator panel")
+		t.Fatalf("the host should get the narrator's panel: %v", err)
+	}
+	if has, _, _ := seated[0].p().Has("#btn-advance-phase"); has {
+		t.Error("players should not get the narrator's panel")
+	}
+
+	werewolves, villagers := findPlayersByRole(seated)
+	victim := villagers[0].Name
+	werewolves[0].voteForPlayer(victim)
+	if err := host.waitUntilCondition(`() => document.querySelector('#narrator-panel')?.textContent.includes('picked `+victim+`')`, "pack's pick on the panel"); err != nil {
+		t.Errorf("the narrator should see the pack's pick: %v", err)
+	}
+	if seated[0].isInDayPhase() {
+		t.Fatal("with a narrator the night should wait for them")
+	}
+
+	submitNightSurveysForAllPlayers(seated)
+	host.clickAndWait("#btn-advance-phase")
+	waitForDayPhaseAll(ctx, seated)
+	if !villagers[1].isInDayPhase() {
+		ctx.logger.LogDB("FAIL: narrator did not wake the village")
+		t.Fatal("the narrator should wake the village")
+	}
+	if death := villagers[1].getDeathAnnouncement(); !strings.Contains(death, victim) {
+		t.Errorf("the pack's victim should be found dead at dawn, got %q", death)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+
+	"werewolf/engine"
 )
 
 // Role-specific data is embedded from per-role structs defined in their own files.
@@ -16,6 +18,11 @@ type NightData struct {
 	HasHistory   bool
 	Lang         string
 
+	PowersDisabled bool            // the village lynched its Elder and this role lost its power
+	TimeLeft       string          // night timer countdown, "" when the game has none
+	NightSkipped   bool            // the Seer, Doctor or Guard chose to sit tonight out
+	DayVoteRecap   []VoteRecapLine // yesterday's secret day vote, revealed now that it is over
+
 	ShowSurvey            bool
 	HasSubmittedSurvey    bool
 	SurveyCount           int
@@ -25,13 +32,27 @@ type NightData struct {
 	SurveyTargetCards     []PlayerCardData
 
 	WerewolfNightData
+	AlphaNightData
+	WhiteWolfNightData
 	SeerNightData
+	AuraSeerNightData
+	FoxNightData
+	SpellcasterNightData
+	SerialKillerNightData
+	PiperNightData
+	SorceressNightData
 	DoctorNightData
 	GuardNightData
+	BodyguardNightData
 	WitchNightData
 	MasonNightData
+	MinionNightData
 	CupidNightData
 	DoppelgangerNightData
+	WildChildNightData
+	CustomNightData
+	LoversChatData
+	GraveyardData
 }
 
 // isNightLover reports whether target is the viewer's lover in night templates,
@@ -99,6 +120,122 @@ func buildNightCards(data *NightData, viewer Player, lang string) {
 		data.SeerTargetCards = append(data.SeerTargetCards, card)
 	}
 
+	// Aura Seer (never themselves)
+	if data.AuraSeerHasInvestigated && data.AuraSeerSelectedPlayer != nil {
+		card := nightResultCard(*data.AuraSeerSelectedPlayer, viewer, lang, false)
+		card.HTMLID = "aura-seer-result"
+		data.AuraSeerResultCard = &card
+	}
+	for _, t := range data.AliveTargets {
+		if t.PlayerID == viewer.PlayerID {
+			continue
+		}
+		card := nightTargetCard(t, viewer, lang)
+		if data.AuraSeerSelectedPlayer != nil && data.AuraSeerSelectedPlayer.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		data.AuraSeerTargetCards = append(data.AuraSeerTargetCards, card)
+	}
+
+	// Fox (never themselves; the result shows the whole sniffed group)
+	for _, p := range data.FoxSniffed {
+		data.FoxResultCards = append(data.FoxResultCards, nightResultCard(p, viewer, lang, false))
+	}
+	if !data.FoxLostPower {
+		for _, t := range data.AliveTargets {
+			if t.PlayerID == viewer.PlayerID {
+				continue
+			}
+			card := nightTargetCard(t, viewer, lang)
+			if data.FoxSelectedPlayer != nil && data.FoxSelectedPlayer.PlayerID == t.PlayerID {
+				card.Selected = true
+			}
+			data.FoxTargetCards = append(data.FoxTargetCards, card)
+		}
+	}
+
+	// Spellcaster (never themselves)
+	if data.SpellcasterHasSilenced && data.SpellcasterSelectedPlayer != nil {
+		card := nightResultCard(*data.SpellcasterSelectedPlayer, viewer, lang, false)
+		card.HTMLID = "spellcaster-result"
+		data.SpellcasterResultCard = &card
+	}
+	for _, t := range data.AliveTargets {
+		if t.PlayerID == viewer.PlayerID {
+			continue
+		}
+		card := nightTargetCard(t, viewer, lang)
+		if data.SpellcasterSelectedPlayer != nil && data.SpellcasterSelectedPlayer.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		data.SpellcasterTargetCards = append(data.SpellcasterTargetCards, card)
+	}
+
+	// Serial Killer (never themselves)
+	if data.SerialKillerHasKilled && data.SerialKillerSelectedPlayer != nil {
+		card := nightResultCard(*data.SerialKillerSelectedPlayer, viewer, lang, false)
+		card.HTMLID = "serial-killer-result"
+		data.SerialKillerResultCard = &card
+	}
+	for _, t := range data.AliveTargets {
+		if t.PlayerID == viewer.PlayerID {
+			continue
+		}
+		card := nightTargetCard(t, viewer, lang)
+		if data.SerialKillerSelectedPlayer != nil && data.SerialKillerSelectedPlayer.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		data.SerialKillerTargetCards = append(data.SerialKillerTargetCards, card)
+	}
+
+	// White Werewolf (fellow pack members only)
+	for _, t := range data.WhiteWolfTargets {
+		card := nightTargetCard(t, viewer, lang)
+		if data.WhiteWolfSelected != nil && data.WhiteWolfSelected.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		data.WhiteWolfTargetCards = append(data.WhiteWolfTargetCards, card)
+	}
+
+	// Piper (never themselves, never someone already charmed)
+	charmed := make(map[int64]bool, len(data.PiperCharmed))
+	for _, p := range data.PiperCharmed {
+		charmed[p.PlayerID] = true
+		data.PiperCharmedCards = append(data.PiperCharmedCards, nightResultCard(p, viewer, lang, false))
+	}
+	for _, t := range data.AliveTargets {
+		if t.PlayerID == viewer.PlayerID || charmed[t.PlayerID] {
+			continue
+		}
+		card := nightTargetCard(t, viewer, lang)
+		if (data.PiperChosen1Player != nil && data.PiperChosen1Player.PlayerID == t.PlayerID) ||
+			(data.PiperChosen2Player != nil && data.PiperChosen2Player.PlayerID == t.PlayerID) {
+			card.Selected = true
+		}
+		data.PiperTargetCards = append(data.PiperTargetCards, card)
+	}
+
+	// Sorceress (never herself)
+	if data.SorceressHasInvestigated && data.SorceressSelectedPlayer != nil {
+		card := nightResultCard(*data.SorceressSelectedPlayer, viewer, lang, data.SorceressFoundSeer)
+		if data.SorceressFoundSeer {
+			card.RoleName = "Seer"
+			card.Team = "villager"
+		}
+		card.HTMLID = "sorceress-result"
+		data.SorceressResultCard = &card
+	}
+	for _, t := range data.AliveTargets {
+		if t.PlayerID == viewer.PlayerID {
+			continue
+		}
+		card := nightTargetCard(t, viewer, lang)
+		if data.SorceressSelectedPlayer != nil && data.SorceressSelectedPlayer.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		data.SorceressTargetCards = append(data.SorceressTargetCards, card)
+	}
+
 	// Doctor
 	if data.HasProtected && data.DoctorProtectingPlayer != nil {
 		card := nightResultCard(*data.DoctorProtectingPlayer, viewer, lang, false)
@@ -125,6 +262,18 @@ func buildNightCards(data *NightData, viewer Player, lang string) {
 		data.GuardTargetCards = append(data.GuardTargetCards, card)
 	}
 
+	// Bodyguard (never themselves)
+	for _, t := range data.AliveTargets {
+		if t.PlayerID == viewer.PlayerID {
+			continue
+		}
+		card := nightTargetCard(t, viewer, lang)
+		if data.BodyguardSelectedPlayer != nil && data.BodyguardSelectedPlayer.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		data.BodyguardTargetCards = append(data.BodyguardTargetCards, card)
+	}
+
 	// Witch heal
 	if data.WerewolfVictimPlayer != nil {
 		card := nightTargetCard(*data.WerewolfVictimPlayer, viewer, lang)
@@ -156,6 +305,13 @@ func buildNightCards(data *NightData, viewer Player, lang string) {
 		data.MasonCards = append(data.MasonCards, card)
 	}
 
+	// Minion's view of the pack (not selectable)
+	for _, t := range data.MinionWolves {
+		card := makePlayerCard(t, lang)
+		card.Lover = isNightLover(t, viewer)
+		data.MinionWolfCards = append(data.MinionWolfCards, card)
+	}
+
 	// Cupid targets
 	for _, t := range data.AliveTargets {
 		card := nightTargetCard(t, viewer, lang)
@@ -177,8 +333,7 @@ func buildNightCards(data *NightData, viewer Player, lang string) {
 
 	// Doppelganger
 	if data.DoppelgangerHasCopied && data.DoppelgangerCopiedPlayer != nil {
-		card := nightResultCard(*data.DoppelgangerCopiedPlayer, viewer, lang, true)
-		card.Lover = false
+		card := nightResultCard(*data.DoppelgangerCopiedPlayer, viewer, lang, false)
 		data.DoppelgangerResultCard = &card
 	}
 	for _, t := range data.DoppelgangerTargets {
@@ -188,6 +343,40 @@ func buildNightCards(data *NightData, viewer Player, lang string) {
 		}
 		data.DoppelgangerTargetCards = append(data.DoppelgangerTargetCards, card)
 	}
+
+	// Wild Child (never themselves)
+	if data.WildChildHasChosen && data.WildChildSelectedPlayer != nil {
+		card := nightResultCard(*data.WildChildSelectedPlayer, viewer, lang, false)
+		card.HTMLID = "wild-child-result"
+		data.WildChildResultCard = &card
+	}
+	for _, t := range data.AliveTargets {
+		if t.PlayerID == viewer.PlayerID {
+			continue
+		}
+		card := nightTargetCard(t, viewer, lang)
+		if data.WildChildSelectedPlayer != nil && data.WildChildSelectedPlayer.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		data.WildChildTargetCards = append(data.WildChildTargetCards, card)
+	}
+
+	// roles created in the lobby (never themselves)
+	if data.CustomHasActed && data.CustomSelectedPlayer != nil {
+		card := nightResultCard(*data.CustomSelectedPlayer, viewer, lang, false)
+		card.HTMLID = "custom-result"
+		data.CustomResultCard = &card
+	}
+	for _, t := range data.AliveTargets {
+		if t.PlayerID == viewer.PlayerID {
+			continue
+		}
+		card := nightTargetCard(t, viewer, lang)
+		if data.CustomSelectedPlayer != nil && data.CustomSelectedPlayer.PlayerID == t.PlayerID {
+			card.Selected = true
+		}
+		data.CustomTargetCards = append(data.CustomTargetCards, card)
+	}
 }
 
 func surveySuspectCard(target, viewer Player, lang string, selected *Player) PlayerCardData {
@@ -204,19 +393,67 @@ func (d *NightData) SeerResultPlayerCard() bool {
 
 // playerDoneWithNightAction gates when the night survey appears for this player.
 func playerDoneWithNightAction(db *sqlx.DB, gameID int64, round int, player Player) bool {
+	if powerDisabled(db, gameID, player.RoleName) {
+		return true // the village lynched its Elder
+	}
+	var timedOut int
+	db.Get(&timedOut, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		gameID, round, player.PlayerID, ActionNightTimedOut)
+	if timedOut > 0 {
+		return true // the night timer ran out; the action was skipped
+	}
 	switch player.RoleName {
-	case "Villager", "Mason", "Hunter":
+	case "Villager", "Mason", "Hunter", "Minion", "Apprentice Seer", "Lycan", "Prince", "Mayor", "Priest", "Drunk", "Tough Guy", "Diseased", "Cursed", "Elder":
 		return true // no night action
 	case "Doppelganger":
-		// Night 1 only (role changes after copying, so this case is hit before copying)
+		// Night 1 only; they keep the role until their role model dies
+		if round != 1 {
+			return true
+		}
+		return roleModelOf(db, gameID, player.PlayerID) != 0
+	case "Wild Child":
+		// Night 1 only; the role model is kept for the rest of the game
+		if round != 1 {
+			return true
+		}
+		return roleModelOf(db, gameID, player.PlayerID) != 0
+	case "Seer":
 		var c int
 		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
-			gameID, round, player.PlayerID, ActionDoppelgangerApplyCopy)
+			gameID, round, player.PlayerID, ActionSeerApplyInvestigate)
 		return c > 0
-	case "Seer":
+	case "Aura Seer":
 		var c int
 		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
-			gameID, round, player.PlayerID, ActionSeerApplyInvestigate)
+			gameID, round, player.PlayerID, ActionAuraSeerApplyInvestigate)
+		return c > 0
+	case "Fox":
+		if foxLostPower(db, gameID, round, player.PlayerID) {
+			return true // the nose has gone cold
+		}
+		var c int
+		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			gameID, round, player.PlayerID, ActionFoxApplySniff)
+		return c > 0
+	case "Spellcaster":
+		var c int
+		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			gameID, round, player.PlayerID, ActionSpellcasterApplySilence)
+		return c > 0
+	case "Serial Killer":
+		var c int
+		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			gameID, round, player.PlayerID, ActionSerialKillerApplyKill)
+		return c > 0
+	case "Piper":
+		var c int
+		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			gameID, round, player.PlayerID, ActionPiperApplyCharm)
+		return c > 0
+	case "Sorceress":
+		var c int
+		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			gameID, round, player.PlayerID, ActionSorceressApplyInvestigate)
 		return c > 0
 	case "Doctor":
 		var c int
@@ -228,12 +465,24 @@ func playerDoneWithNightAction(db *sqlx.DB, gameID int64, round int, player Play
 		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
 			gameID, round, player.PlayerID, ActionGuardApplyProtect)
 		return c > 0
+	case "Bodyguard":
+		var c int
+		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			gameID, round, player.PlayerID, ActionBodyguardApplyGuard)
+		return c > 0
 	case "Witch":
 		var c int
 		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
 			gameID, round, player.PlayerID, ActionWitchApply)
 		return c > 0
-	case "Werewolf", "Wolf Cub":
+	case "Werewolf", "Wolf Cub", "Alpha Werewolf", "White Werewolf":
+		if whiteWolfOwesKill(db, gameID, round, player.PlayerID) {
+			return false
+		}
+		// a sick pack has nothing to vote on tonight
+		if wolvesSkipNight(db, gameID, round) {
+			return true
+		}
 		// Survey available after End Vote is pressed (any wolf)
 		var c int
 		db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=?`,
@@ -242,21 +491,12 @@ func playerDoneWithNightAction(db *sqlx.DB, gameID int64, round int, player Play
 			return false
 		}
 		// If Wolf Cub double kill is active this round, also require End Vote 2
-		if round > 1 {
-			var wolfCubDeathCount int
-			db.Get(&wolfCubDeathCount, `
-SELECT COUNT(*) FROM game_action ga
-JOIN game_player gp ON ga.target_player_id = gp.player_id AND gp.game_id = ga.game_id
-JOIN role r ON gp.role_id = r.rowid
-WHERE ga.game_id = ? AND ga.round = ?
-AND ga.action_type IN (?, ?, ?, ?)
-AND r.name = 'Wolf Cub'`,
-				gameID, round-1, ActionWerewolfSelectKill, ActionDayApplyKill, ActionHunterApplyKill, ActionWitchApplyKill)
-			if wolfCubDeathCount > 0 {
-				var c2 int
-				db.Get(&c2, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=?`,
-					gameID, round, ActionWerewolfApplyKill2)
-				return c2 > 0
+		if round > 1 && wolfCubDiedLastRound(db, gameID, round) {
+			var c2 int
+			db.Get(&c2, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=?`,
+				gameID, round, ActionWerewolfApplyKill2)
+			if c2 == 0 {
+				return false
 			}
 		}
 		return true
@@ -268,7 +508,7 @@ AND r.name = 'Wolf Cub'`,
 		db.Get(&loverCount, `SELECT COUNT(*) FROM game_lovers WHERE game_id=?`, gameID)
 		return loverCount > 0
 	default:
-		return true
+		return customRoleDone(db, gameID, round, player)
 	}
 }
 
@@ -406,6 +646,13 @@ FROM game_action WHERE game_id=? AND round=? AND actor_player_id=? AND action_ty
 
 	h.logf("Survey submitted by '%s' (game %d round %d)", player.Name, game.ID, game.Round)
 
+	h.dawnIfSurveyed(game)
+	h.triggerBroadcast()
+}
+
+// dawnIfSurveyed applies the dawn once every living player has submitted tonight's survey;
+// with a narrator the day dawns when they wake the village.
+func (h *Hub) dawnIfSurveyed(game *Game) {
 	var aliveCount int
 	h.db.Get(&aliveCount, `SELECT COUNT(*) FROM game_player WHERE game_id=? AND is_alive=1`, game.ID)
 	var surveyCount int
@@ -414,430 +661,119 @@ FROM game_action WHERE game_id=? AND round=? AND actor_player_id=? AND action_ty
 
 	h.logf("Night survey progress: %d/%d", surveyCount, aliveCount)
 
-	if surveyCount >= aliveCount {
-		// description="" marks a kill as pending; resolveWerewolfVotes inserted these rows earlier tonight
-		type pendingKill struct {
-			ID             int64 `db:"id"`
-			TargetPlayerID int64 `db:"target_player_id"`
-		}
-		var pendingKills []pendingKill
-		h.db.Select(&pendingKills, `SELECT rowid as id, target_player_id FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND description=''`,
-			game.ID, game.Round, ActionNightApplyKill)
-
-		var nightKills []int64
-		var nightKillNames []string
-		for _, pk := range pendingKills {
-			if _, err = h.db.Exec("UPDATE game_player SET is_alive=0 WHERE game_id=? AND player_id=?", game.ID, pk.TargetPlayerID); err != nil {
-				h.logError("handleWSNightSurvey: apply kill", err)
-				continue
-			}
-			var name, roleName string
-			h.db.Get(&name, "SELECT name FROM player WHERE rowid=?", pk.TargetPlayerID)
-			h.db.Get(&roleName, `SELECT r.name FROM game_player gp JOIN role r ON gp.role_id=r.rowid WHERE gp.game_id=? AND gp.player_id=?`, game.ID, pk.TargetPlayerID)
-			desc := fmt.Sprintf("Night %d: %s (%s) was found dead", game.Round, name, roleName)
-			h.db.Exec(`UPDATE game_action SET description=?, description_key=?, description_args=? WHERE rowid=?`,
-				desc, "hist_found_dead", histArgs(game.Round, name, roleName), pk.ID)
-			nightKills = append(nightKills, pk.TargetPlayerID)
-			nightKillNames = append(nightKillNames, name)
-			h.logf("Applied pending night kill: %s (%s)", name, roleName)
-		}
-
-		// Transition to day, then apply heartbreaks and check win conditions
-		if _, err = h.db.Exec("UPDATE game SET status='day' WHERE rowid=?", game.ID); err != nil {
-			h.logError("handleWSNightSurvey: transition to day", err)
-			return
-		}
-		h.applyHeartbreaks(game, "night", nightKills)
-
-		h.logf("Night %d ended (all surveys submitted), transitioning to day", game.Round)
-		LogDBState(h.db, "after all surveys submitted and kills applied")
-
-		if h.checkWinConditions(game) {
-			return
-		}
-		if len(nightKillNames) == 0 {
-			h.maybeSpeakStory(game.ID, T(h.storytellerLang, "tts_dawn_unscathed"))
-		} else {
-			h.maybeSpeakStory(game.ID, T(h.storytellerLang, "tts_dawn_deaths", strings.Join(nightKillNames, T(h.storytellerLang, "tts_join_and"))))
-		}
-		if len(nightKills) > 0 {
-			h.maybeGenerateStory(game.ID, game.Round, "night", nightKills[0])
-		}
+	if surveyCount >= aliveCount && !narratorModeEnabled(h.db, game.ID) {
+		h.applyDawn(game)
 	}
-
-	h.triggerBroadcast()
 }
 
-func recordPublicDeath(h *Hub, game *Game, playerID int64) {
-	var name string
-	h.db.Get(&name, "SELECT name FROM player WHERE rowid = ?", playerID)
-	var roleName string
-	h.db.Get(&roleName, `SELECT r.name FROM game_player gp JOIN role r ON gp.role_id = r.rowid WHERE gp.game_id = ? AND gp.player_id = ?`, game.ID, playerID)
-	desc := fmt.Sprintf("Night %d: %s (%s) was found dead", game.Round, name, roleName)
-	_, err := h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args) VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
-		game.ID, game.Round, playerID, ActionNightApplyKill, playerID, VisibilityPublic, desc, "hist_found_dead", histArgs(game.Round, name, roleName))
-	if err != nil {
-		h.logError("recordPublicDeath: insert death record", err)
-	} else {
-		h.logf("Recorded public death: %s", desc)
-	}
+// dawn is the end of a night being applied: the kills, conversions and reveals are written
+// through tx, and whatever tells a player about them waits in after until tx has committed.
+type dawn struct {
+	tx    *sqlx.Tx
+	after []func()
 }
 
-func (h *Hub) resolveWerewolfVotes(game *Game) {
-	var werewolves []Player
-	err := h.db.Select(&werewolves, `
-SELECT g.rowid as id, g.player_id as player_id, p.name as name
-FROM game_player g
-JOIN player p ON g.player_id = p.rowid
-JOIN role r ON g.role_id = r.rowid
-WHERE g.game_id = ? AND g.is_alive = 1 AND r.team = 'werewolf'`, game.ID)
-	if err != nil {
-		h.logError("resolveWerewolfVotes: get werewolves", err)
-		return
-	}
-
-	var votes []GameAction
-	err = h.db.Select(&votes, `
-SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
-FROM game_action
-WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
-		game.ID, game.Round, ActionWerewolfSelectKill)
-	if err != nil {
-		h.logError("resolveWerewolfVotes: get votes", err)
-		return
-	}
-
-	h.logf("Werewolf vote check: %d werewolves, %d votes", len(werewolves), len(votes))
-
-	if len(votes) < len(werewolves) {
-		h.logf("Not all werewolves have voted yet (%d/%d)", len(votes), len(werewolves))
-		h.triggerBroadcast()
-		return
-	}
+func (d *dawn) afterCommit(f func()) {
+	d.after = append(d.after, f)
+}
 
-	var endVoteCount int
-	h.db.Get(&endVoteCount, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
-		game.ID, game.Round, ActionWerewolfApplyKill)
-	if endVoteCount == 0 {
-		h.logf("Werewolves have all voted but End Vote not pressed yet")
-		h.triggerBroadcast()
+// applyDawn ends the night once every survey is in (or the night timer ran out): the pending
+// kills and dawn reveals are applied and the day begins.
+func (h *Hub) applyDawn(game *Game) {
+	// the night's kills, conversions and reveals and the move to day are one transaction:
+	// all of them or none, and only while the night is still on
+	tx, ok := h.beginResolution(game)
+	if !ok {
 		return
 	}
+	defer tx.Rollback()
+	d := &dawn{tx: tx}
 
-	// Count votes for each target (NULL = pass, excluded from counts)
-	voteCounts := make(map[int64]int)
-	for _, v := range votes {
-		if v.TargetPlayerID != nil {
-			voteCounts[*v.TargetPlayerID]++
-		}
+	// description="" marks a kill as pending; resolveNight inserted these rows earlier tonight
+	type pendingKill struct {
+		ID             int64 `db:"id"`
+		TargetPlayerID int64 `db:"target_player_id"`
 	}
+	var pendingKills []pendingKill
+	tx.Select(&pendingKills, `SELECT rowid as id, target_player_id FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND description=''`,
+		game.ID, game.Round, ActionNightApplyKill)
 
-	var maxVotes int
-	var victim int64
-	for targetID, count := range voteCounts {
-		if count > maxVotes {
-			maxVotes = count
-			victim = targetID
-		}
-	}
-
-	// victim stays 0 on a non-majority — we still need to check the other night roles before deciding
-	majority := len(werewolves)/2 + 1
-	if maxVotes < majority {
-		h.logf("No majority reached (need %d, max is %d) — no kill this night", majority, maxVotes)
-		victim = 0
-	}
-
-	// Wolf Cub died last round → a second kill is required tonight
-	wolfCubDoubleKill := false
-	var victim2 int64
-	if game.Round > 1 {
-		var wolfCubDeathCount int
-		h.db.Get(&wolfCubDeathCount, `
-SELECT COUNT(*) FROM game_action ga
-JOIN game_player gp ON ga.target_player_id = gp.player_id AND gp.game_id = ga.game_id
-JOIN role r ON gp.role_id = r.rowid
-WHERE ga.game_id = ? AND ga.round = ?
-AND ga.action_type IN (?, ?, ?, ?)
-AND r.name = 'Wolf Cub'`,
-			game.ID, game.Round-1, ActionWerewolfSelectKill, ActionDayApplyKill, ActionHunterApplyKill, ActionWitchApplyKill)
-		wolfCubDoubleKill = wolfCubDeathCount > 0
-	}
-
-	if wolfCubDoubleKill {
-		var votes2 []GameAction
-		h.db.Select(&votes2, `
-SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
-FROM game_action
-WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
-			game.ID, game.Round, ActionWerewolfSelectKill2)
-
-		if len(votes2) < len(werewolves) {
-			h.logf("Wolf Cub double kill: waiting for second votes (%d/%d)", len(votes2), len(werewolves))
-			h.triggerBroadcast()
+	for _, reveal := range []func(*dawn, *Game) error{h.applyAlphaBites, revealToughGuyWounds, revealElderSurvival, h.applyCursedTurns, h.revealPiperCharms} {
+		if err := reveal(d, game); err != nil {
+			h.logError("applyDawn: reveal", err)
 			return
 		}
+	}
 
-		var endVote2Count int
-		h.db.Get(&endVote2Count, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
-			game.ID, game.Round, ActionWerewolfApplyKill2)
-		if endVote2Count == 0 {
-			h.logf("Wolf Cub double kill: End Vote 2 not pressed yet")
-			h.triggerBroadcast()
+	var nightKills []int64
+	var nightKillNames []string
+	for _, pk := range pendingKills {
+		if _, err := tx.Exec("UPDATE game_player SET is_alive=0 WHERE game_id=? AND player_id=?", game.ID, pk.TargetPlayerID); err != nil {
+			h.logError("applyDawn: apply kill", err)
 			return
 		}
-
-		voteCounts2 := make(map[int64]int)
-		for _, v := range votes2 {
-			if v.TargetPlayerID != nil {
-				voteCounts2[*v.TargetPlayerID]++
-			}
-		}
-		var maxVotes2 int
-		for targetID, count := range voteCounts2 {
-			if count > maxVotes2 {
-				maxVotes2 = count
-				victim2 = targetID
-			}
-		}
-		if maxVotes2 < majority {
-			h.logf("Wolf Cub double kill: no majority for second victim (need %d, max is %d) — no second kill", majority, maxVotes2)
-			victim2 = 0
-		}
-	}
-
-	if game.Round == 1 {
-		var aliveCupidCount int
-		h.db.Get(&aliveCupidCount, `
-SELECT COUNT(*) FROM game_player g
-JOIN role r ON g.role_id = r.rowid
-WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Cupid'`, game.ID)
-		if aliveCupidCount > 0 {
-			var loverCount int
-			h.db.Get(&loverCount, `SELECT COUNT(*) FROM game_lovers WHERE game_id = ?`, game.ID)
-			if loverCount == 0 {
-				h.logf("Waiting for Cupid to link lovers")
-				h.triggerBroadcast()
-				return
-			}
-		}
-	}
-
-	// after copying, role_id changes, so this count naturally drops to 0 once a Doppelganger is done
-	if game.Round == 1 {
-		var aliveDoppelgangerCount int
-		h.db.Get(&aliveDoppelgangerCount, `
-SELECT COUNT(*) FROM game_player g
-JOIN role r ON g.role_id = r.rowid
-WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Doppelganger'`, game.ID)
-		if aliveDoppelgangerCount > 0 {
-			h.logf("Waiting for Doppelganger(s) to copy (%d remaining)", aliveDoppelgangerCount)
-			h.triggerBroadcast()
+		var name, roleName string
+		tx.Get(&name, "SELECT name FROM player WHERE rowid=?", pk.TargetPlayerID)
+		tx.Get(&roleName, `SELECT r.name FROM game_player gp JOIN role r ON gp.role_id=r.rowid WHERE gp.game_id=? AND gp.player_id=?`, game.ID, pk.TargetPlayerID)
+		desc := fmt.Sprintf("Night %d: %s (%s) was found dead", game.Round, name, roleName)
+		if _, err := tx.Exec(`UPDATE game_action SET description=?, description_key=?, description_args=? WHERE rowid=?`,
+			desc, "hist_found_dead", histArgs(game.Round, name, roleName), pk.ID); err != nil {
+			h.logError("applyDawn: record kill", err)
 			return
 		}
+		nightKills = append(nightKills, pk.TargetPlayerID)
+		nightKillNames = append(nightKillNames, name)
 	}
 
-	var aliveSeerCount int
-	h.db.Get(&aliveSeerCount, `
-SELECT COUNT(*) FROM game_player g
-JOIN role r ON g.role_id = r.rowid
-WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Seer'`, game.ID)
-
-	var seerInvestigateCount int
-	h.db.Get(&seerInvestigateCount, `
-SELECT COUNT(*) FROM game_action
-WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
-		game.ID, game.Round, ActionSeerApplyInvestigate)
-
-	if seerInvestigateCount < aliveSeerCount {
-		h.logf("Waiting for seers to investigate (%d/%d)", seerInvestigateCount, aliveSeerCount)
-		h.triggerBroadcast()
+	// Transition to day, then apply heartbreaks and check win conditions
+	next, _ := engine.Next(engine.Night, game.Round)
+	if _, err := tx.Exec("UPDATE game SET status=? WHERE rowid=?", next, game.ID); err != nil {
+		h.logError("applyDawn: transition to day", err)
 		return
 	}
-
-	var aliveDoctorCount int
-	h.db.Get(&aliveDoctorCount, `
-SELECT COUNT(*) FROM game_player g
-JOIN role r ON g.role_id = r.rowid
-WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Doctor'`, game.ID)
-
-	var doctorProtectCount int
-	h.db.Get(&doctorProtectCount, `
-SELECT COUNT(*) FROM game_action
-WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
-		game.ID, game.Round, ActionDoctorApplyProtect)
-
-	if doctorProtectCount < aliveDoctorCount {
-		h.logf("Waiting for doctors to protect (%d/%d)", doctorProtectCount, aliveDoctorCount)
-		h.triggerBroadcast()
+	if err := tx.Commit(); err != nil {
+		h.logError("applyDawn: commit", err)
 		return
 	}
+	for _, f := range d.after {
+		f()
+	}
+	for i, name := range nightKillNames {
+		h.logf("Applied pending night kill: %s (player ID %d)", name, nightKills[i])
+	}
+	h.startDayTimer(game.ID, game.Round)
+	h.applyHeartbreaks(game, "night", nightKills)
+	h.inheritDoppelgangerRoles(game, "night")
+	h.promoteApprenticeSeer(game, "night")
+	h.awakenWildChildren(game, "night")
 
-	var aliveGuardCount int
-	h.db.Get(&aliveGuardCount, `
-SELECT COUNT(*) FROM game_player g
-JOIN role r ON g.role_id = r.rowid
-WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Guard'`, game.ID)
-
-	var guardProtectCount int
-	h.db.Get(&guardProtectCount, `
-SELECT COUNT(*) FROM game_action
-WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
-		game.ID, game.Round, ActionGuardApplyProtect)
+	h.logf("Night %d ended, transitioning to day", game.Round)
+	LogDBState(h.db, "after all surveys submitted and kills applied")
 
-	if guardProtectCount < aliveGuardCount {
-		h.logf("Waiting for guards to protect (%d/%d)", guardProtectCount, aliveGuardCount)
-		h.triggerBroadcast()
+	if h.checkWinConditions(game) {
 		return
 	}
-
-	var aliveWitchCount int
-	h.db.Get(&aliveWitchCount, `
-SELECT COUNT(*) FROM game_player g
-JOIN role r ON g.role_id = r.rowid
-WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Witch'`, game.ID)
-
-	if aliveWitchCount > 0 {
-		var witchApplyCount int
-		h.db.Get(&witchApplyCount, `
-SELECT COUNT(*) FROM game_action
-WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
-			game.ID, game.Round, ActionWitchApply)
-
-		if witchApplyCount < aliveWitchCount {
-			h.logf("Waiting for witch to apply (%d/%d)", witchApplyCount, aliveWitchCount)
-			h.triggerBroadcast()
-			return
-		}
+	if len(nightKillNames) == 0 {
+		h.maybeSpeakStory(game.ID, T(h.storytellerLang, "tts_dawn_unscathed"))
+	} else {
+		h.maybeSpeakStory(game.ID, T(h.storytellerLang, "tts_dawn_deaths", strings.Join(nightKillNames, T(h.storytellerLang, "tts_join_and"))))
 	}
-
-	// no wolf kill, but Wolf Cub's and the Witch's kills are independent and still need applying
-	if victim == 0 {
-		h.logf("No werewolf kill this night (wolves passed or no majority)")
-		if wolfCubDoubleKill && victim2 != 0 {
-			var protect2Count int
-			h.db.Get(&protect2Count, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type IN (?, ?, ?) AND target_player_id = ?`,
-				game.ID, game.Round, ActionDoctorApplyProtect, ActionGuardApplyProtect, ActionWitchApplyProtect, victim2)
-			var victim2Name string
-			h.db.Get(&victim2Name, "SELECT name FROM player WHERE rowid = ?", victim2)
-			if protect2Count > 0 {
-				h.logf("Protection saved %s from Wolf Cub double kill", victim2Name)
-			} else {
-				h.logf("Wolf Cub double kill pending: %s", victim2Name)
-				h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
-					game.ID, game.Round, victim2, ActionNightApplyKill, victim2, VisibilityPublic)
-			}
-		}
-		var witchKillActionNoVictim GameAction
-		if wkErr := h.db.Get(&witchKillActionNoVictim, `SELECT * FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`, game.ID, game.Round, ActionWitchApplyKill); wkErr == nil && witchKillActionNoVictim.TargetPlayerID != nil {
-			var poisonName string
-			h.db.Get(&poisonName, "SELECT name FROM player WHERE rowid = ?", *witchKillActionNoVictim.TargetPlayerID)
-			h.logf("Witch poison pending: %s", poisonName)
-			h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
-				game.ID, game.Round, *witchKillActionNoVictim.TargetPlayerID, ActionNightApplyKill, *witchKillActionNoVictim.TargetPlayerID, VisibilityPublic)
-		}
-		h.logf("Night %d: no wolf kill, waiting for surveys", game.Round)
-		h.triggerBroadcast()
-		return
+	if len(nightKills) > 0 {
+		h.maybeGenerateStory(game.ID, game.Round, "night", nightKills[0])
 	}
+}
 
-	var protectionCount int
-	h.db.Get(&protectionCount, `
-SELECT COUNT(*) FROM game_action
-WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ? AND target_player_id = ?`,
-		game.ID, game.Round, ActionDoctorApplyProtect, victim)
-
-	var guardProtectionCount int
-	h.db.Get(&guardProtectionCount, `
-SELECT COUNT(*) FROM game_action
-WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ? AND target_player_id = ?`,
-		game.ID, game.Round, ActionGuardApplyProtect, victim)
-
-	var witchHealCount int
-	h.db.Get(&witchHealCount, `
-SELECT COUNT(*) FROM game_action
-WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ? AND target_player_id = ?`,
-		game.ID, game.Round, ActionWitchApplyProtect, victim)
-
-	if protectionCount > 0 || guardProtectionCount > 0 || witchHealCount > 0 {
-		var victimName string
-		h.db.Get(&victimName, "SELECT name FROM player WHERE rowid = ?", victim)
-		if protectionCount > 0 {
-			h.logf("Doctor saved %s (player ID %d) from werewolf attack", victimName, victim)
-		}
-		if guardProtectionCount > 0 {
-			h.logf("Guard saved %s (player ID %d) from werewolf attack", victimName, victim)
-		}
-		if witchHealCount > 0 {
-			h.logf("Witch saved %s (player ID %d) from werewolf attack", victimName, victim)
-		}
-
-		// Wolf Cub second kill may still land even if main victim is protected
-		if wolfCubDoubleKill && victim2 != 0 {
-			var protect2Count int
-			h.db.Get(&protect2Count, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type IN (?, ?, ?) AND target_player_id = ?`,
-				game.ID, game.Round, ActionDoctorApplyProtect, ActionGuardApplyProtect, ActionWitchApplyProtect, victim2)
-			var victim2Name string
-			h.db.Get(&victim2Name, "SELECT name FROM player WHERE rowid = ?", victim2)
-			if protect2Count > 0 {
-				h.logf("Protection saved %s from Wolf Cub double kill", victim2Name)
-			} else {
-				h.logf("Wolf Cub double kill pending: %s", victim2Name)
-				h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
-					game.ID, game.Round, victim2, ActionNightApplyKill, victim2, VisibilityPublic)
-			}
-		}
-		// Witch poison is separate from the main wolf kill
-		var witchKillActionP2 GameAction
-		if wkErr := h.db.Get(&witchKillActionP2, `SELECT * FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`, game.ID, game.Round, ActionWitchApplyKill); wkErr == nil && witchKillActionP2.TargetPlayerID != nil {
-			var poisonName string
-			h.db.Get(&poisonName, "SELECT name FROM player WHERE rowid = ?", *witchKillActionP2.TargetPlayerID)
-			h.logf("Witch poison pending: %s", poisonName)
-			h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
-				game.ID, game.Round, *witchKillActionP2.TargetPlayerID, ActionNightApplyKill, *witchKillActionP2.TargetPlayerID, VisibilityPublic)
-		}
-
-		h.logf("Night %d: main victim protected, waiting for surveys", game.Round)
-		LogDBState(h.db, "after protection save")
-		h.triggerBroadcast()
-		return
+func recordPublicDeath(h *Hub, game *Game, playerID int64) {
+	var name string
+	h.db.Get(&name, "SELECT name FROM player WHERE rowid = ?", playerID)
+	var roleName string
+	h.db.Get(&roleName, `SELECT r.name FROM game_player gp JOIN role r ON gp.role_id = r.rowid WHERE gp.game_id = ? AND gp.player_id = ?`, game.ID, playerID)
+	desc := fmt.Sprintf("Night %d: %s (%s) was found dead", game.Round, name, roleName)
+	_, err := h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args) VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, playerID, ActionNightApplyKill, playerID, VisibilityPublic, desc, "hist_found_dead", histArgs(game.Round, name, roleName))
+	if err != nil {
+		h.logError("recordPublicDeath: insert death record", err)
+	} else {
+		h.logf("Recorded public death: %s", desc)
 	}
-
-	var victimName string
-	h.db.Get(&victimName, "SELECT name FROM player WHERE rowid = ?", victim)
-	h.logf("Werewolf kill pending: %s (player ID %d)", victimName, victim)
-	DebugLog("resolveWerewolfVotes", "Werewolf kill pending: '%s', waiting for surveys", victimName)
-	h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
-		game.ID, game.Round, victim, ActionNightApplyKill, victim, VisibilityPublic)
-
-	var witchKillAction GameAction
-	if err := h.db.Get(&witchKillAction, `SELECT * FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`, game.ID, game.Round, ActionWitchApplyKill); err == nil && witchKillAction.TargetPlayerID != nil {
-		var poisonVictimName string
-		h.db.Get(&poisonVictimName, "SELECT name FROM player WHERE rowid = ?", *witchKillAction.TargetPlayerID)
-		h.logf("Witch poison pending: %s (player ID %d)", poisonVictimName, *witchKillAction.TargetPlayerID)
-		h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
-			game.ID, game.Round, *witchKillAction.TargetPlayerID, ActionNightApplyKill, *witchKillAction.TargetPlayerID, VisibilityPublic)
-	}
-
-	if wolfCubDoubleKill && victim2 != 0 && victim2 != victim {
-		var protect2Count int
-		h.db.Get(&protect2Count, `
-SELECT COUNT(*) FROM game_action
-WHERE game_id = ? AND round = ? AND phase = 'night'
-AND action_type IN (?, ?, ?) AND target_player_id = ?`,
-			game.ID, game.Round, ActionDoctorApplyProtect, ActionGuardApplyProtect, ActionWitchApplyProtect, victim2)
-		var victim2Name string
-		h.db.Get(&victim2Name, "SELECT name FROM player WHERE rowid = ?", victim2)
-		if protect2Count > 0 {
-			h.logf("Protection saved %s (player ID %d) from Wolf Cub double kill", victim2Name, victim2)
-		} else {
-			h.logf("Wolf Cub double kill pending: %s (player ID %d)", victim2Name, victim2)
-			h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
-				game.ID, game.Round, victim2, ActionNightApplyKill, victim2, VisibilityPublic)
-		}
-	}
-
-	h.logf("Night %d: kills pending, waiting for surveys", game.Round)
-	LogDBState(h.db, "after pending night kills recorded")
-	h.triggerBroadcast()
 }
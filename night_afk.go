@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultAFKTimeout is how long a player may be without an open WebSocket before the night
+// goes on without them.
+const defaultAFKTimeout = 90 * time.Second
+
+// watchAway waits while a player who just lost their last connection stays away. Every
+// afkTimeout without a reconnect, markAFK plays the current night for them, so one closed
+// laptop cannot hold up the village. It stops once they reconnect or drop out of the game.
+func (h *Hub) watchAway(playerID int64) {
+	h.mu.RLock()
+	since, away := h.awaySince[playerID]
+	h.mu.RUnlock()
+	if !away {
+		return
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		ticker := time.NewTicker(h.afkTimeout)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.done:
+				return
+			case <-ticker.C:
+			}
+			h.mu.RLock()
+			current, stillAway := h.awaySince[playerID]
+			h.mu.RUnlock()
+			if !stillAway || !current.Equal(since) {
+				return // reconnected, possibly dropping again later under a new watcher
+			}
+			h.moveMu.Lock()
+			stillPlaying := h.markAFK(playerID)
+			h.moveMu.Unlock()
+			if !stillPlaying {
+				return
+			}
+		}
+	}()
+}
+
+// markAFK skips the night action the away player still owes, like the night timer does, and
+// submits an empty survey for them. The night then resolves and the day begins as soon as
+// everyone else is done. It reports whether the player is still in a running game, i.e.
+// whether it is worth checking on them again.
+func (h *Hub) markAFK(playerID int64) bool {
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("markAFK: getGame", err)
+		return false
+	}
+	if game.Status == "lobby" || game.Status == "finished" {
+		return false
+	}
+	player, err := getPlayerInGame(h.db, game.ID, playerID)
+	if err != nil || !player.IsAlive {
+		return false
+	}
+	if game.Status != "night" || game.Paused {
+		return true
+	}
+
+	// every step ready means the night was already resolved by its last action
+	resolved := nightReady(h, game)
+	if !playerDoneWithNightAction(h.db, game.ID, game.Round, player) {
+		desc := fmt.Sprintf("Night %d: You were away too long — your night action was skipped", game.Round)
+		h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description, description_key, description_args) VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?)`,
+			game.ID, game.Round, playerID, ActionNightTimedOut, VisibilityActor, desc, "hist_night_afk", histArgs(game.Round))
+		h.logf("Night %d: '%s' (%s) is away, skipping their night action", game.Round, player.Name, player.RoleName)
+	}
+	h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, '')`,
+		game.ID, game.Round, playerID, ActionNightSurveyApplySuspect, VisibilityResolved)
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, playerID, ActionNightSurveySelectSuspect)
+
+	if !resolved {
+		h.resolveWerewolfVotes(game)
+	}
+	if nightReady(h, game) {
+		h.dawnIfSurveyed(game)
+	}
+	h.triggerBroadcast()
+	return true
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// AFK Tests
+// ============================================================================
+
+func TestAwaySeerDoesNotBlockNight(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf1", "Wolf2", "Seer", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleWerewolf, RoleSeer, RoleVillager, RoleVillager, RoleVillager})
+	wolf1, wolf2, seer, v1 := ids[0], ids[1], ids[2], ids[3]
+	target := strconv.FormatInt(v1, 10)
+
+	ctx.sendWS(wolf1, WSMessage{Action: "werewolf_vote", TargetPlayerID: target})
+	ctx.sendWS(wolf2, WSMessage{Action: "werewolf_vote", TargetPlayerID: target})
+	ctx.sendWS(wolf2, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		if id != seer {
+			ctx.sendWS(id, WSMessage{Action: "night_survey"})
+		}
+	}
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("the night should wait on the Seer, got %q", status)
+	}
+
+	ctx.hub().markAFK(seer)
+
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("the night should go on without the away Seer, got %q", status)
+	}
+	if ctx.isPlayerAlive(v1) {
+		t.Error("the pack's kill should land")
+	}
+	if !strings.Contains(ctx.historyFor(seer), "away too long") {
+		t.Errorf("the Seer should learn their action was skipped, got %q", ctx.historyFor(seer))
+	}
+}
+
+func TestAwayWolfIsNotWaitedFor(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf1", "Wolf2", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	wolf1, wolf2, v1 := ids[0], ids[1], ids[2]
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(wolf1, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf1, WSMessage{Action: "werewolf_end_vote"})
+	if wolfTargetsLocked(ctx.app.db, game) {
+		t.Fatal("End Vote should wait on the second wolf's vote")
+	}
+
+	ctx.hub().markAFK(wolf2)
+	ctx.sendWS(wolf1, WSMessage{Action: "werewolf_end_vote"})
+	if !nightReady(ctx.hub(), game) {
+		t.Error("an away wolf should not hold up the pack")
+	}
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Errorf("the away wolf counts as a pass, so one vote of two is no majority, got %d kills", n)
+	}
+}
+
+func TestAwayWatcherPlaysNightForDisconnectedPlayer(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Seer", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSeer, RoleVillager, RoleVillager})
+	seer := ids[1]
+
+	h := ctx.hub()
+	h.mu.Lock()
+	h.afkTimeout = 10 * time.Millisecond
+	h.awaySince[seer] = time.Now()
+	h.mu.Unlock()
+	h.watchAway(seer)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for ctx.countActions(ActionNightTimedOut) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := ctx.countActions(ActionNightTimedOut); n != 1 {
+		t.Fatalf("the disconnected Seer should have their action skipped, got %d rows", n)
+	}
+	if strings.Contains(ctx.historyFor(ids[2]), "away too long") {
+		t.Error("players without a night action should not be marked as away")
+	}
+}
+
+func TestAwaySeerInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the night going on without a Seer who closed the page ===")
+
+	h := ctx.hub()
+	h.mu.Lock()
+	h.afkTimeout = 500 * time.Millisecond
+	h.mu.Unlock()
+
+	// Setup: 1 werewolf + 1 seer + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"AF1", "AF2", "AF3", "AF4"},
+		RoleWerewolf, RoleSeer, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Seer"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) < 2 {
+		t.Fatal("Missing required roles")
+	}
+	seer, werewolf := byRole["Seer"][0], byRole["Werewolf"][0]
+	victim, other := byRole["Villager"][0], byRole["Villager"][1]
+
+	seer.disconnect()
+	werewolf.voteForPlayer(victim.Name)
+	submitNightSurveysForAllPlayers([]*TestPlayer{werewolf, victim, other})
+	waitForDayPhaseAll(ctx, []*TestPlayer{werewolf, other})
+
+	if !other.isInDayPhase() {
+		ctx.logger.LogDB("FAIL: night waits for away Seer")
+		t.Fatal("the night should go on without the away Seer")
+	}
+
+	back := browser.loginPlayer(ctx.baseURL, seer.Name, seer.SecretCode)
+	if !back.historyContains("You were away too long") {
+		ctx.logger.LogDB("FAIL: away Seer not told")
+		t.Errorf("the Seer should learn their action was skipped, got: %s", back.getHistoryText())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
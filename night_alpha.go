@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AlphaNightData struct {
+	AlphaCanBite      bool // bite not used yet this game
+	AlphaBiteSelected bool // the pack's victim will be converted tonight instead of killed
+}
+
+func buildAlphaNightData(db *sqlx.DB, game *Game, player Player) AlphaNightData {
+	// nothing to bite while the pack is sick from the Diseased
+	if player.RoleName != "Alpha Werewolf" || wolvesSkipNight(db, game.ID, game.Round) {
+		return AlphaNightData{}
+	}
+
+	var used int
+	db.Get(&used, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND actor_player_id=? AND action_type=?`,
+		game.ID, player.PlayerID, ActionAlphaApplyBite)
+	if used > 0 {
+		return AlphaNightData{}
+	}
+
+	var selected int
+	db.Get(&selected, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, player.PlayerID, ActionAlphaSelectBite)
+	return AlphaNightData{
+		AlphaCanBite:      true,
+		AlphaBiteSelected: selected > 0,
+	}
+}
+
+// handleWSAlphaBite toggles whether tonight's pack victim is bitten (converted) instead of killed.
+func handleWSAlphaBite(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSAlphaBite: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+	alpha, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSAlphaBite: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if alpha.RoleName != "Alpha Werewolf" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_alpha_bite"))
+		return
+	}
+	if !alpha.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	var used int
+	h.db.Get(&used, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND actor_player_id=? AND action_type=?`,
+		game.ID, client.playerID, ActionAlphaApplyBite)
+	if used > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_alpha_bite_used"))
+		return
+	}
+	var endVoteCount int
+	h.db.Get(&endVoteCount, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
+		game.ID, game.Round, ActionWerewolfApplyKill)
+	if endVoteCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_vote_locked"))
+		return
+	}
+
+	var selected int
+	h.db.Get(&selected, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionAlphaSelectBite)
+	if selected > 0 {
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionAlphaSelectBite)
+		h.logf("Alpha Werewolf '%s' withdrew the bite", alpha.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionAlphaSelectBite, VisibilityTeamWerewolf)
+		h.logf("Alpha Werewolf '%s' will bite tonight's victim", alpha.Name)
+	}
+
+	h.triggerBroadcast()
+}
+
+// alphaBiter returns the living Alpha Werewolf who chose to bite tonight's victim, or 0 when
+// the victim should simply die. Werewolf-team victims are never bitten.
+func (h *Hub) alphaBiter(game *Game, victim int64) int64 {
+	var alphaID int64
+	h.db.Get(&alphaID, `
+SELECT ga.actor_player_id FROM game_action ga
+JOIN game_player gp ON gp.game_id = ga.game_id AND gp.player_id = ga.actor_player_id
+WHERE ga.game_id = ? AND ga.round = ? AND ga.phase = 'night' AND ga.action_type = ? AND gp.is_alive = 1`,
+		game.ID, game.Round, ActionAlphaSelectBite)
+	if alphaID == 0 {
+		return 0
+	}
+	target, err := getPlayerInGame(h.db, game.ID, victim)
+	if err != nil || target.Team == "werewolf" {
+		return 0
+	}
+	return alphaID
+}
+
+// applyAlphaBites converts tonight's pending bite victims into werewolves at dawn.
+// The bite row's description stays empty until it is applied, like pending night kills.
+func (h *Hub) applyAlphaBites(d *dawn, game *Game) error {
+	var bites []GameAction
+	d.tx.Select(&bites, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND description=''`,
+		game.ID, game.Round, ActionAlphaApplyBite)
+
+	for _, bite := range bites {
+		if bite.TargetPlayerID == nil {
+			continue
+		}
+		victimID := *bite.TargetPlayerID
+		if err := joinPack(d.tx, game, victimID); err != nil {
+			return fmt.Errorf("convert bitten player: %w", err)
+		}
+		victimName := h.playerName(victimID)
+		desc := fmt.Sprintf("Night %d: The Alpha bit %s, who joins the pack", game.Round, victimName)
+		if _, err := d.tx.Exec(`UPDATE game_action SET description=?, description_key=?, description_args=? WHERE rowid=?`,
+			desc, "hist_alpha_bite", histArgs(game.Round, victimName), bite.ID); err != nil {
+			return err
+		}
+
+		// the bitten player's own record; the pack's entry above is team-only
+		bittenDesc := fmt.Sprintf("Night %d: You were bitten and turned into a werewolf", game.Round)
+		if _, err := d.tx.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args) VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+			game.ID, game.Round, victimID, ActionAlphaBitten, victimID, VisibilityActor, bittenDesc, "hist_alpha_bitten", histArgs(game.Round)); err != nil {
+			return err
+		}
+
+		d.afterCommit(func() {
+			h.warnStaleSeers(game, victimID)
+			toastMsg := T(h.getPlayerLang(victimID), "toast_alpha_bitten")
+			h.sendToPlayer(victimID, []byte(renderToast(h.templates, h.logf, "warning", toastMsg)))
+			h.logf("Alpha bite applied: '%s' is now a Werewolf", victimName)
+		})
+	}
+	return nil
+}
+
+// joinPack turns a player into a plain Werewolf (Alpha bite, Cursed, Wild Child). Pack
+// visibility follows from the new role; warnStaleSeers tells the Seers once it is written.
+func joinPack(db sqlx.Execer, game *Game, playerID int64) error {
+	_, err := db.Exec(`UPDATE game_player SET role_id = (SELECT rowid FROM role WHERE name = 'Werewolf') WHERE game_id = ? AND player_id = ?`,
+		game.ID, playerID)
+	return err
+}
+
+// warnStaleSeers tells any Seer who already read a player who joined the pack that the
+// reading is stale, like a Doppelganger turning wolf.
+func (h *Hub) warnStaleSeers(game *Game, playerID int64) {
+	name := h.playerName(playerID)
+	var seerInvestigations []struct {
+		ActorPlayerID int64 `db:"actor_player_id"`
+	}
+	h.db.Select(&seerInvestigations, `
+SELECT actor_player_id FROM game_action
+WHERE game_id = ? AND action_type = ? AND target_player_id = ?`,
+		game.ID, ActionSeerApplyInvestigate, playerID)
+	for _, inv := range seerInvestigations {
+		notif := T(h.getPlayerLang(inv.ActorPlayerID), "toast_seer_outdated_reading", name)
+		h.sendToPlayer(inv.ActorPlayerID, []byte(renderToast(h.templates, h.logf, "warning", notif)))
+	}
+}
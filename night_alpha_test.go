@@ -0,0 +1,149 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Alpha Werewolf Helpers
+// ============================================================================
+
+// alphaArmBite arms the Alpha's once-per-game bite for tonight's kill.
+func (tp *TestPlayer) alphaArmBite() {
+	if tp.logger != nil {
+		tp.logger.Debug("[%s] Alpha arming the bite", tp.Name)
+	}
+	tp.clickAndWait("#alpha-bite-btn")
+	tp.logHTML("after alpha bite armed")
+}
+
+// ============================================================================
+// Alpha Werewolf Tests
+// ============================================================================
+
+func TestAlphaBiteConvertsVictim(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Alpha", "Wolf", "Bitten", "V2", "V3"},
+		[]string{RoleAlphaWerewolf, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	alpha, wolf, bitten := ids[0], ids[1], ids[2]
+	target := strconv.FormatInt(bitten, 10)
+
+	ctx.sendWS(alpha, WSMessage{Action: "alpha_bite"})
+	ctx.sendWS(alpha, WSMessage{Action: "werewolf_vote", TargetPlayerID: target})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: target})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Errorf("bitten victim should not have a pending kill, got %d", n)
+	}
+	if n := ctx.countActions(ActionAlphaApplyBite); n != 1 {
+		t.Fatalf("expected one pending bite, got %d", n)
+	}
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("expected day after all surveys, got %q", status)
+	}
+	if !ctx.isPlayerAlive(bitten) {
+		t.Fatal("bitten player should survive the night")
+	}
+	game, _ := ctx.hub().getGame()
+	p, err := getPlayerInGame(ctx.app.db, game.ID, bitten)
+	if err != nil || p.RoleName != "Werewolf" {
+		t.Fatalf("bitten player should now be a Werewolf, got %q (%v)", p.RoleName, err)
+	}
+	if h := ctx.historyFor(bitten); !strings.Contains(h, "You were bitten") {
+		t.Errorf("bitten player should see their conversion in history, got: %q", h)
+	}
+	if h := ctx.historyFor(wolf); !strings.Contains(h, "The Alpha bit Bitten") {
+		t.Errorf("pack should see the bite in history, got: %q", h)
+	}
+	if h := ctx.historyFor(ids[3]); strings.Contains(h, "bit") {
+		t.Errorf("villagers must not learn about the bite, got: %q", h)
+	}
+}
+
+func TestAlphaBiteOncePerGame(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 3,
+		[]string{"Alpha", "V1", "V2", "V3"},
+		[]string{RoleAlphaWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	alpha := ids[0]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description)
+		VALUES (?, 1, 'night', ?, ?, ?, ?, 'used')`, game.ID, alpha, ActionAlphaApplyBite, ids[1], VisibilityTeamWerewolf)
+
+	player, _ := getPlayerInGame(ctx.app.db, game.ID, alpha)
+	if buildAlphaNightData(ctx.app.db, game, player).AlphaCanBite {
+		t.Error("Alpha should not be offered a second bite")
+	}
+	ctx.sendWS(alpha, WSMessage{Action: "alpha_bite"})
+	if n := ctx.countActions(ActionAlphaSelectBite); n != 0 {
+		t.Errorf("second bite should be rejected, got %d select rows", n)
+	}
+}
+
+func TestAlphaBiteInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing Alpha Werewolf bites the victim into the pack ===")
+
+	// Setup: 1 alpha werewolf + 3 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"AL1", "AL2", "AL3", "AL4"},
+		RoleAlphaWerewolf, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Alpha Werewolf"]) == 0 || len(byRole["Villager"]) < 2 {
+		t.Fatal("Missing required roles")
+	}
+	alpha, victim, villager := byRole["Alpha Werewolf"][0], byRole["Villager"][0], byRole["Villager"][1]
+	ctx.logger.Debug("Alpha: %s, biting: %s", alpha.Name, victim.Name)
+
+	alpha.alphaArmBite()
+	alpha.voteForPlayer(victim.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	// Nobody dies: the victim wakes up as a werewolf and only the pack learns why
+	if !villager.hasNoDeathMessage() {
+		ctx.logger.LogDB("FAIL: bitten victim died")
+		t.Errorf("The bitten victim should not die, got: %s", villager.getDeathAnnouncement())
+	}
+	if err := victim.waitForRole("Werewolf"); err != nil {
+		ctx.logger.LogDB("FAIL: victim did not join the pack")
+		t.Fatalf("Bitten victim should be a Werewolf, got: %s", victim.getRole())
+	}
+	if !victim.historyContains("You were bitten and turned into a werewolf") {
+		ctx.logger.LogDB("FAIL: victim not told of the bite")
+		t.Errorf("Bitten victim should see the bite in history, got: %s", victim.getHistoryText())
+	}
+	entry := "The Alpha bit " + victim.Name + ", who joins the pack"
+	if !alpha.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: alpha cannot see the bite in history")
+		t.Errorf("Alpha should see %q in history, got: %s", entry, alpha.getHistoryText())
+	}
+	if villager.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: villager can see the bite in history")
+		t.Errorf("The village should not learn of the bite")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,47 @@
+package main
+
+import "fmt"
+
+// promoteApprenticeSeer turns a living Apprentice Seer into the Seer once no Seer is left alive.
+// It runs after every death path (night kills, day elimination, Hunter revenge), so the new
+// Seer investigates from the next night on. Only one apprentice is promoted per Seer lost.
+func (h *Hub) promoteApprenticeSeer(game *Game, phase string) {
+	var aliveSeerCount int
+	h.db.Get(&aliveSeerCount, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Seer'`, game.ID)
+	if aliveSeerCount > 0 {
+		return
+	}
+
+	var apprenticeID int64
+	h.db.Get(&apprenticeID, `
+SELECT g.player_id FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Apprentice Seer'
+ORDER BY g.rowid LIMIT 1`, game.ID)
+	if apprenticeID == 0 {
+		return
+	}
+
+	if _, err := h.db.Exec(`UPDATE game_player SET role_id = (SELECT rowid FROM role WHERE name = 'Seer') WHERE game_id = ? AND player_id = ?`,
+		game.ID, apprenticeID); err != nil {
+		h.logError("promoteApprenticeSeer: promote apprentice", err)
+		return
+	}
+
+	histKey := "hist_apprentice_promoted_night"
+	desc := fmt.Sprintf("Night %d: The Seer is gone — you inherit their sight", game.Round)
+	if phase == "day" {
+		histKey = "hist_apprentice_promoted_day"
+		desc = fmt.Sprintf("Day %d: The Seer is gone — you inherit their sight", game.Round)
+	}
+	h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, phase, apprenticeID, ActionApprenticeSeerPromoted, apprenticeID, VisibilityActor, desc, histKey, histArgs(game.Round))
+
+	toastMsg := T(h.getPlayerLang(apprenticeID), "toast_apprentice_promoted")
+	h.sendToPlayer(apprenticeID, []byte(renderToast(h.templates, h.logf, "info", toastMsg)))
+
+	h.logf("Apprentice Seer '%s' promoted to Seer", h.playerName(apprenticeID))
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Apprentice Seer Tests
+// ============================================================================
+
+func TestApprenticeSeerPromotedAfterNightKill(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Seer", "Apprentice", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSeer, RoleApprentice, RoleVillager, RoleVillager})
+	wolf, seer, apprentice := ids[0], ids[1], ids[2]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(seer, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	ctx.sendWS(seer, WSMessage{Action: "seer_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(seer, WSMessage{Action: "seer_investigate"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if ctx.isPlayerAlive(seer) {
+		t.Fatal("the Seer should have been killed")
+	}
+	game, _ := ctx.hub().getGame()
+	p, err := getPlayerInGame(ctx.app.db, game.ID, apprentice)
+	if err != nil || p.RoleName != "Seer" {
+		t.Fatalf("Apprentice Seer should have become the Seer, got %q (%v)", p.RoleName, err)
+	}
+	if h := ctx.historyFor(apprentice); !strings.Contains(h, "you inherit their sight") {
+		t.Errorf("apprentice should see the promotion in history, got: %q", h)
+	}
+	if h := ctx.historyFor(ids[3]); strings.Contains(h, "inherit") {
+		t.Errorf("the promotion must stay secret, villager sees: %q", h)
+	}
+}
+
+func TestApprenticeSeerPromotedAfterLynch(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Seer", "Apprentice", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSeer, RoleApprentice, RoleVillager, RoleVillager})
+	seer, apprentice := ids[1], ids[2]
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(seer, 10)})
+	}
+	ctx.sendWS(ids[0], WSMessage{Action: "day_end_vote"})
+
+	game, _ := ctx.hub().getGame()
+	if game.Status != "night" {
+		t.Fatalf("expected night after the lynch, got %q", game.Status)
+	}
+	p, _ := getPlayerInGame(ctx.app.db, game.ID, apprentice)
+	if p.RoleName != "Seer" {
+		t.Fatalf("Apprentice Seer should have become the Seer, got %q", p.RoleName)
+	}
+	if playerDoneWithNightAction(ctx.app.db, game.ID, game.Round, p) {
+		t.Error("the promoted Seer must investigate before the night can end")
+	}
+}
+
+func TestApprenticeSeerWaitsWhileSeerLives(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Seer", "Apprentice", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSeer, RoleApprentice, RoleVillager, RoleVillager})
+	apprentice := ids[2]
+
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE player_id = ?", ids[3])
+	game, _ := ctx.hub().getGame()
+	ctx.hub().promoteApprenticeSeer(game, "day")
+
+	p, _ := getPlayerInGame(ctx.app.db, game.ID, apprentice)
+	if p.RoleName != "Apprentice Seer" {
+		t.Errorf("apprentice should stay an apprentice while the Seer lives, got %q", p.RoleName)
+	}
+}
+
+func TestApprenticeSeerPromotedInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Apprentice Seer takes over when the Seer dies ===")
+
+	// Setup: 1 werewolf + 1 seer + 1 apprentice seer + 2 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"AP1", "AP2", "AP3", "AP4", "AP5"},
+		RoleWerewolf, RoleSeer, RoleApprentice, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Seer"]) == 0 || len(byRole["Apprentice Seer"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	seer, apprentice, werewolf, villager := byRole["Seer"][0], byRole["Apprentice Seer"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+
+	// The Apprentice has no power while the Seer lives
+	if apprentice.canSeeSeerButtons() {
+		ctx.logger.LogDB("FAIL: apprentice can investigate while the seer lives")
+		t.Errorf("Apprentice Seer should not investigate while the Seer is alive")
+	}
+
+	seer.seerInvestigatePlayer(villager.Name)
+	werewolf.voteForPlayer(seer.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if err := apprentice.waitForRole("Seer"); err != nil {
+		ctx.logger.LogDB("FAIL: apprentice not promoted")
+		t.Fatalf("Apprentice should be the Seer once the Seer died, got: %s", apprentice.getRole())
+	}
+	entry := "The Seer is gone — you inherit their sight"
+	if !apprentice.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: apprentice not told of the promotion")
+		t.Errorf("Apprentice should see %q in history, got: %s", entry, apprentice.getHistoryText())
+	}
+	if villager.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: promotion visible to the village")
+		t.Errorf("Only the Apprentice should learn of the promotion")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
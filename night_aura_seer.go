@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AuraSeerNightData struct {
+	AuraSeerHasInvestigated bool
+	AuraSeerFoundPower      bool
+	AuraSeerSelectedPlayer  *Player // pending, or confirmed once investigated
+	AuraSeerResultCard      *PlayerCardData
+	AuraSeerTargetCards     []PlayerCardData
+}
+
+func buildAuraSeerNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string) AuraSeerNightData {
+	if player.RoleName != "Aura Seer" {
+		return AuraSeerNightData{}
+	}
+
+	var action GameAction
+	err := db.Get(&action, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, playerID, ActionAuraSeerApplyInvestigate)
+
+	if err == nil && action.TargetPlayerID != nil {
+		d := AuraSeerNightData{
+			AuraSeerHasInvestigated: true,
+			AuraSeerSelectedPlayer:  getVisiblePlayer(db, game.ID, *action.TargetPlayerID, player, seerInvestigated),
+		}
+		if target, err := getPlayerInGame(db, game.ID, *action.TargetPlayerID); err == nil && hasSpecialPower(target) {
+			d.AuraSeerFoundPower = true
+		}
+		return d
+	}
+
+	var selectAction GameAction
+	if db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, playerID, ActionAuraSeerSelectInvestigate) == nil && selectAction.TargetPlayerID != nil {
+		return AuraSeerNightData{
+			AuraSeerSelectedPlayer: getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated),
+		}
+	}
+
+	return AuraSeerNightData{}
+}
+
+// hasSpecialPower is what the Aura Seer learns: any role other than a plain Villager has a power.
+func hasSpecialPower(p Player) bool {
+	return p.RoleName != "Villager"
+}
+
+func handleWSAuraSeerSelect(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSAuraSeerSelect: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+	auraSeer, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSAuraSeerSelect: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if auraSeer.RoleName != "Aura Seer" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_aura_seer_select"))
+		return
+	}
+	if !auraSeer.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	if powerDisabled(h.db, game.ID, auraSeer.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+	var existingCount int
+	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionAuraSeerApplyInvestigate)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_investigated"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var existing GameAction
+	selectErr := h.db.Get(&existing, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionAuraSeerSelectInvestigate)
+	if selectErr == nil && existing.TargetPlayerID != nil && *existing.TargetPlayerID == targetID {
+		// clicking the same target again deselects it
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionAuraSeerSelectInvestigate)
+		h.logf("Aura Seer '%s' deselected investigation target", auraSeer.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionAuraSeerSelectInvestigate, targetID, VisibilityActor)
+		h.logf("Aura Seer '%s' selected investigation target %d", auraSeer.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+func handleWSAuraSeerInvestigate(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSAuraSeerInvestigate: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_investigate"))
+		return
+	}
+
+	auraSeer, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSAuraSeerInvestigate: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	if auraSeer.RoleName != "Aura Seer" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_aura_seer_investigate"))
+		return
+	}
+
+	if !auraSeer.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	if powerDisabled(h.db, game.ID, auraSeer.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+
+	var existingCount int
+	h.db.Get(&existingCount, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, ActionAuraSeerApplyInvestigate)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_investigated"))
+		return
+	}
+
+	var selectAction GameAction
+	if err := h.db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionAuraSeerSelectInvestigate); err != nil || selectAction.TargetPlayerID == nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_select_investigate_first"))
+		return
+	}
+	targetID := *selectAction.TargetPlayerID
+
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_target_not_found"))
+		return
+	}
+
+	if !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_cannot_investigate_dead"))
+		return
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionAuraSeerSelectInvestigate)
+
+	foundPower := hasSpecialPower(target)
+	histKey := "hist_aura_seer_no_power"
+	result := "no special power"
+	if foundPower {
+		histKey = "hist_aura_seer_power"
+		result = "a special power"
+	}
+	desc := fmt.Sprintf("Night %d: You read the aura of %s — they have %s", game.Round, target.Name, result)
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionAuraSeerApplyInvestigate, targetID, VisibilityActor, desc, histKey, histArgs(game.Round, target.Name))
+	if err != nil {
+		h.logError("handleWSAuraSeerInvestigate: db.Exec insert investigation", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_investigation"))
+		return
+	}
+
+	toastMsg := T(lang, "toast_aura_seer_no_power", target.Name)
+	if foundPower {
+		toastMsg = T(lang, "toast_aura_seer_power", target.Name)
+	}
+	h.sendToPlayer(client.playerID, []byte(renderToast(h.templates, h.logf, "info", toastMsg)))
+
+	h.logf("Aura Seer '%s' investigated '%s' (power: %v)", auraSeer.Name, target.Name, foundPower)
+	DebugLog("handleWSAuraSeerInvestigate", "Aura Seer '%s' investigated '%s' (power: %v)", auraSeer.Name, target.Name, foundPower)
+	LogDBState(h.db, "after aura seer investigation")
+
+	h.resolveWerewolfVotes(game)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Aura Seer Helpers
+// ============================================================================
+
+// auraSeerReadPlayer selects a target for the Aura Seer and clicks the Read button.
+func (tp *TestPlayer) auraSeerReadPlayer(targetName string) {
+	tp.selectAndConfirm("aura-seer-select-form-", targetName, "#aura-seer-investigate-button")
+}
+
+// ============================================================================
+// Aura Seer Tests
+// ============================================================================
+
+func TestAuraSeerReadsPowerAndGatesNight(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Aura", "Doc", "Vil"},
+		[]string{RoleWerewolf, RoleAuraSeer, RoleDoctor, RoleVillager})
+	wolf, aura, doc, vil := ids[0], ids[1], ids[2], ids[3]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(vil, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	ctx.sendWS(doc, WSMessage{Action: "doctor_select", TargetPlayerID: strconv.FormatInt(doc, 10)})
+	ctx.sendWS(doc, WSMessage{Action: "doctor_protect"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("night should wait for the Aura Seer, but %d kill(s) are pending", n)
+	}
+
+	ctx.sendWS(aura, WSMessage{Action: "aura_seer_select", TargetPlayerID: strconv.FormatInt(doc, 10)})
+	ctx.sendWS(aura, WSMessage{Action: "aura_seer_investigate"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 1 {
+		t.Errorf("wolf kill should be pending once the Aura Seer has acted, got %d", n)
+	}
+
+	if h := ctx.historyFor(aura); !strings.Contains(h, "they have a special power") {
+		t.Errorf("Aura Seer should learn the Doctor has a power, got: %q", h)
+	}
+	if h := ctx.historyFor(doc); strings.Contains(h, "aura") {
+		t.Errorf("aura readings must be actor-only, target sees: %q", h)
+	}
+
+	// second reading the same night is rejected
+	ctx.sendWS(aura, WSMessage{Action: "aura_seer_select", TargetPlayerID: strconv.FormatInt(vil, 10)})
+	if n := ctx.countActions(ActionAuraSeerApplyInvestigate); n != 1 {
+		t.Errorf("expected exactly one aura reading, got %d", n)
+	}
+}
+
+func TestAuraSeerPlainVillagerHasNoPower(t *testing.T) {
+	t.Parallel()
+	for role, want := range map[string]bool{"Villager": false, "Werewolf": true, "Tanner": true, "Seer": true} {
+		if got := hasSpecialPower(Player{RoleName: role}); got != want {
+			t.Errorf("hasSpecialPower(%s) = %v, want %v", role, got, want)
+		}
+	}
+}
+
+func TestAuraSeerReadsAuraInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing Aura Seer reads a werewolf's aura from the night panel ===")
+
+	// Setup: 1 werewolf + 1 aura seer + 1 villager = 3 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"AS1", "AS2", "AS3"},
+		RoleWerewolf, RoleAuraSeer, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Aura Seer"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	auraSeer, werewolf, villager := byRole["Aura Seer"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+	ctx.logger.Debug("Aura Seer: %s, reading Werewolf: %s", auraSeer.Name, werewolf.Name)
+
+	auraSeer.auraSeerReadPlayer(werewolf.Name)
+
+	// A werewolf has a special power; the reading is actor-only
+	entry := "You read the aura of " + werewolf.Name + " — they have a special power"
+	if !auraSeer.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: aura seer cannot see the reading in history")
+		t.Errorf("Aura Seer should see %q in history, got: %s", entry, auraSeer.getHistoryText())
+	}
+	if werewolf.historyContains(entry) || villager.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: aura reading visible to others")
+		t.Errorf("Only the Aura Seer should see the reading in history")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
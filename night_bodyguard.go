@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type BodyguardNightData struct {
+	BodyguardHasGuarded     bool
+	BodyguardSelectedPlayer *Player // pending, not yet confirmed
+	BodyguardGuardingPlayer *Player // confirmed ward this night
+	BodyguardTargetCards    []PlayerCardData
+}
+
+func buildBodyguardNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string) BodyguardNightData {
+	if player.RoleName != "Bodyguard" {
+		return BodyguardNightData{}
+	}
+
+	var action GameAction
+	err := db.Get(&action, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, playerID, ActionBodyguardApplyGuard)
+	if err == nil && action.TargetPlayerID != nil {
+		return BodyguardNightData{
+			BodyguardHasGuarded:     true,
+			BodyguardGuardingPlayer: getVisiblePlayer(db, game.ID, *action.TargetPlayerID, player, seerInvestigated),
+		}
+	}
+
+	var selectAction GameAction
+	if db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, playerID, ActionBodyguardSelectGuard) == nil && selectAction.TargetPlayerID != nil {
+		return BodyguardNightData{
+			BodyguardSelectedPlayer: getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated),
+		}
+	}
+
+	return BodyguardNightData{}
+}
+
+func handleWSBodyguardSelect(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSBodyguardSelect: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+	bodyguard, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSBodyguardSelect: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if bodyguard.RoleName != "Bodyguard" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_bodyguard_select"))
+		return
+	}
+	if !bodyguard.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	if powerDisabled(h.db, game.ID, bodyguard.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+	var existingCount int
+	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionBodyguardApplyGuard)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_protected"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	if targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_bodyguard_no_self"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var existing GameAction
+	selectErr := h.db.Get(&existing, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionBodyguardSelectGuard)
+	if selectErr == nil && existing.TargetPlayerID != nil && *existing.TargetPlayerID == targetID {
+		// clicking the same target again deselects it
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionBodyguardSelectGuard)
+		h.logf("Bodyguard '%s' deselected ward", bodyguard.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionBodyguardSelectGuard, targetID, VisibilityActor)
+		h.logf("Bodyguard '%s' selected ward %d", bodyguard.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+func handleWSBodyguardGuard(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSBodyguardGuard: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_protect"))
+		return
+	}
+
+	bodyguard, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSBodyguardGuard: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	if bodyguard.RoleName != "Bodyguard" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_bodyguard_guard"))
+		return
+	}
+
+	if !bodyguard.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	if powerDisabled(h.db, game.ID, bodyguard.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+
+	var existingCount int
+	h.db.Get(&existingCount, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, ActionBodyguardApplyGuard)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_protected"))
+		return
+	}
+
+	var selectAction GameAction
+	if err := h.db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionBodyguardSelectGuard); err != nil || selectAction.TargetPlayerID == nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_select_protect_first"))
+		return
+	}
+	targetID := *selectAction.TargetPlayerID
+
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_target_not_found"))
+		return
+	}
+
+	if !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_cannot_protect_dead"))
+		return
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionBodyguardSelectGuard)
+
+	guardDesc := fmt.Sprintf("Night %d: You stood guard over %s", game.Round, target.Name)
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionBodyguardApplyGuard, targetID, VisibilityActor, guardDesc, "hist_bodyguard_guarded", histArgs(game.Round, target.Name))
+	if err != nil {
+		h.logError("handleWSBodyguardGuard: db.Exec insert guard", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_protection"))
+		return
+	}
+
+	h.logf("Bodyguard '%s' is guarding '%s'", bodyguard.Name, target.Name)
+	DebugLog("handleWSBodyguardGuard", "Bodyguard '%s' guarding '%s'", bodyguard.Name, target.Name)
+	LogDBState(h.db, "after bodyguard guard")
+
+	h.resolveWerewolfVotes(game)
+}
+
+// bodyguardFor returns the living Bodyguard standing guard over victim tonight, or 0.
+// Unlike the Doctor or Guard, the Bodyguard does not cancel the kill: they die in the victim's place.
+func (h *Hub) bodyguardFor(game *Game, victim int64) int64 {
+	var bodyguardID int64
+	h.db.Get(&bodyguardID, `
+SELECT ga.actor_player_id FROM game_action ga
+JOIN game_player gp ON gp.game_id = ga.game_id AND gp.player_id = ga.actor_player_id
+WHERE ga.game_id = ? AND ga.round = ? AND ga.phase = 'night' AND ga.action_type = ? AND ga.target_player_id = ? AND gp.is_alive = 1
+LIMIT 1`,
+		game.ID, game.Round, ActionBodyguardApplyGuard, victim)
+	return bodyguardID
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Bodyguard Helpers
+// ============================================================================
+
+// bodyguardGuardPlayer selects a ward for the Bodyguard and clicks the Guard button.
+func (tp *TestPlayer) bodyguardGuardPlayer(targetName string) {
+	tp.selectAndConfirm("bodyguard-select-form-", targetName, "#bodyguard-guard-button")
+}
+
+// ============================================================================
+// Bodyguard Tests
+// ============================================================================
+
+func TestBodyguardDiesInPlaceOfWard(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Bodyguard", "Ward", "V2", "V3"},
+		[]string{RoleWerewolf, RoleBodyguard, RoleVillager, RoleVillager, RoleVillager})
+	wolf, bodyguard, ward := ids[0], ids[1], ids[2]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(ward, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("night should wait for the Bodyguard, but %d kill(s) are pending", n)
+	}
+
+	ctx.sendWS(bodyguard, WSMessage{Action: "bodyguard_select", TargetPlayerID: strconv.FormatInt(ward, 10)})
+	ctx.sendWS(bodyguard, WSMessage{Action: "bodyguard_guard"})
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("expected day after all surveys, got %q", status)
+	}
+	if !ctx.isPlayerAlive(ward) {
+		t.Error("the guarded player should survive the attack")
+	}
+	if ctx.isPlayerAlive(bodyguard) {
+		t.Error("the Bodyguard should die in their ward's place")
+	}
+}
+
+func TestBodyguardNotNeededWhenDoctorSaves(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Bodyguard", "Doctor", "Ward", "V2"},
+		[]string{RoleWerewolf, RoleBodyguard, RoleDoctor, RoleVillager, RoleVillager})
+	wolf, bodyguard, doctor, ward := ids[0], ids[1], ids[2], ids[3]
+	target := strconv.FormatInt(ward, 10)
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: target})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	ctx.sendWS(doctor, WSMessage{Action: "doctor_select", TargetPlayerID: target})
+	ctx.sendWS(doctor, WSMessage{Action: "doctor_protect"})
+	ctx.sendWS(bodyguard, WSMessage{Action: "bodyguard_select", TargetPlayerID: target})
+	ctx.sendWS(bodyguard, WSMessage{Action: "bodyguard_guard"})
+
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Errorf("a saved victim means nobody dies, got %d pending kill(s)", n)
+	}
+}
+
+func TestBodyguardCannotGuardSelf(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Bodyguard", "V1"},
+		[]string{RoleWerewolf, RoleBodyguard, RoleVillager})
+	bodyguard := ids[1]
+
+	ctx.sendWS(bodyguard, WSMessage{Action: "bodyguard_select", TargetPlayerID: strconv.FormatInt(bodyguard, 10)})
+	if n := ctx.countActions(ActionBodyguardSelectGuard); n != 0 {
+		t.Errorf("Bodyguard should not be able to guard themselves, got %d select rows", n)
+	}
+}
+
+func TestBodyguardDiesForWardInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing Bodyguard dies in place of the werewolves' target ===")
+
+	// Setup: 1 werewolf + 1 bodyguard + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"BG1", "BG2", "BG3", "BG4"},
+		RoleWerewolf, RoleBodyguard, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Bodyguard"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	bodyguard, werewolf, ward := byRole["Bodyguard"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+	ctx.logger.Debug("Bodyguard: %s, guarding: %s", bodyguard.Name, ward.Name)
+
+	bodyguard.bodyguardGuardPlayer(ward.Name)
+
+	entry := "You stood guard over " + ward.Name
+	if !bodyguard.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: bodyguard cannot see the guard in history")
+		t.Errorf("Bodyguard should see %q in history, got: %s", entry, bodyguard.getHistoryText())
+	}
+	if ward.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: ward can see the bodyguard in history")
+		t.Errorf("The ward should not see the Bodyguard's guard in history")
+	}
+
+	// The werewolf attacks the ward
+	werewolf.voteForPlayer(ward.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if !werewolf.isInDayPhase() {
+		ctx.logger.LogDB("FAIL: did not transition to day")
+		t.Fatal("Should have transitioned to day phase")
+	}
+	deaths := werewolf.getDeathAnnouncement()
+	if !strings.Contains(deaths, bodyguard.Name) || strings.Contains(deaths, ward.Name) {
+		ctx.logger.LogDB("FAIL: bodyguard did not die in place of the ward")
+		t.Errorf("The Bodyguard %s should have died instead of %s, got: %s", bodyguard.Name, ward.Name, deaths)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// curseVictim absorbs the pack's kill when it lands on the Cursed: instead of a pending kill,
+// a pending turn is recorded (empty description until dawn, like night kills).
+func (h *Hub) curseVictim(tx *sqlx.Tx, game *Game, victim int64) (bool, error) {
+	if getRoleName(h.db, game.ID, victim) != "Cursed" {
+		return false, nil
+	}
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+		game.ID, game.Round, victim, ActionCursedTurned, victim, VisibilityTeamWerewolf); err != nil {
+		return false, err
+	}
+	h.logf("Cursed '%s' was attacked and will turn at dawn", h.playerName(victim))
+	return true, nil
+}
+
+// applyCursedTurns converts tonight's attacked Cursed into werewolves at dawn. The history entry
+// is team-only, so the new wolf and the pack learn of it together; the rest of the village sees
+// a quiet night.
+func (h *Hub) applyCursedTurns(d *dawn, game *Game) error {
+	var turns []GameAction
+	d.tx.Select(&turns, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND description=''`,
+		game.ID, game.Round, ActionCursedTurned)
+
+	for _, turn := range turns {
+		if turn.TargetPlayerID == nil {
+			continue
+		}
+		cursedID := *turn.TargetPlayerID
+		if err := joinPack(d.tx, game, cursedID); err != nil {
+			return fmt.Errorf("convert cursed: %w", err)
+		}
+		name := h.playerName(cursedID)
+		desc := fmt.Sprintf("Night %d: The attack awakened %s's curse — they join the pack", game.Round, name)
+		if _, err := d.tx.Exec(`UPDATE game_action SET description=?, description_key=?, description_args=? WHERE rowid=?`,
+			desc, "hist_cursed_turned", histArgs(game.Round, name), turn.ID); err != nil {
+			return err
+		}
+
+		d.afterCommit(func() {
+			h.warnStaleSeers(game, cursedID)
+			toastMsg := T(h.getPlayerLang(cursedID), "toast_cursed_turned")
+			h.sendToPlayer(cursedID, []byte(renderToast(h.templates, h.logf, "warning", toastMsg)))
+			h.logf("Cursed '%s' is now a Werewolf", name)
+		})
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Cursed Tests
+// ============================================================================
+
+func TestCursedJoinsPackWhenAttacked(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Cursed", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleCursed, RoleVillager, RoleVillager, RoleVillager})
+	wolf, cursed, v1 := ids[0], ids[1], ids[2]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(cursed, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if !ctx.isPlayerAlive(cursed) {
+		t.Fatal("the Cursed should survive the attack")
+	}
+	game, _ := ctx.hub().getGame()
+	p, _ := getPlayerInGame(ctx.app.db, game.ID, cursed)
+	if p.RoleName != "Werewolf" {
+		t.Fatalf("the Cursed should now be a Werewolf, got %q", p.RoleName)
+	}
+
+	for _, id := range []int64{wolf, cursed} {
+		if h := ctx.historyFor(id); !strings.Contains(h, "they join the pack") {
+			t.Errorf("player %d should see the turn in history, got: %q", id, h)
+		}
+	}
+	if h := ctx.historyFor(v1); strings.Contains(h, "curse") {
+		t.Errorf("the turn must stay hidden from the village, villager sees: %q", h)
+	}
+
+	players, _ := getPlayersByGameId(ctx.app.db, game.ID)
+	wolfPlayer, _ := getPlayerInGame(ctx.app.db, game.ID, wolf)
+	for _, vp := range applyCardVisibility(wolfPlayer, players, nil, RevealFull) {
+		if vp.PlayerID == cursed && vp.RoleName != "Werewolf" {
+			t.Errorf("the pack should now see the Cursed as a werewolf, got %q", vp.RoleName)
+		}
+	}
+}
+
+func TestCursedJoinsPackInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Cursed turns werewolf instead of dying ===")
+
+	// Setup: 1 werewolf + 1 cursed + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"CU1", "CU2", "CU3", "CU4"},
+		RoleWerewolf, RoleCursed, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Cursed"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	cursed, werewolf, villager := byRole["Cursed"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+
+	werewolf.voteForPlayer(cursed.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if !villager.hasNoDeathMessage() {
+		ctx.logger.LogDB("FAIL: cursed died")
+		t.Errorf("The Cursed should not die, got: %s", villager.getDeathAnnouncement())
+	}
+	if err := cursed.waitForRole("Werewolf"); err != nil {
+		ctx.logger.LogDB("FAIL: cursed did not join the pack")
+		t.Fatalf("The Cursed should be a Werewolf after the attack, got: %s", cursed.getRole())
+	}
+	// The turn is team-only: the pack sees it, the village does not
+	entry := "The attack awakened " + cursed.Name + "'s curse"
+	if !werewolf.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: pack not told of the turn")
+		t.Errorf("Werewolf should see %q in history, got: %s", entry, werewolf.getHistoryText())
+	}
+	if villager.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: villager can see the turn in history")
+		t.Errorf("The village should not learn the Cursed turned")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type CustomNightData struct {
+	CustomNight          string  // the role's night ability, "" when it has none
+	CustomUsesLeft       int     // -1 = every night
+	CustomHasActed       bool    // confirmed tonight
+	CustomSelectedPlayer *Player // pending, or tonight's target once confirmed
+	CustomSawWolf        bool    // investigate result
+	CustomResultCard     *PlayerCardData
+	CustomTargetCards    []PlayerCardData
+}
+
+// customApplyAction maps a night ability to the action type it records.
+func customApplyAction(night string) string {
+	if night == CustomNightProtect {
+		return ActionCustomApplyProtect
+	}
+	return ActionCustomApplyInvestigate
+}
+
+// customUsesLeft returns how many more nights the player may use their role's night ability,
+// or -1 when it has no charge limit.
+func customUsesLeft(db *sqlx.DB, gameID, playerID int64, ability RoleAbility) int {
+	if ability.Charges == 0 {
+		return -1
+	}
+	var used int
+	db.Get(&used, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND actor_player_id=? AND action_type IN (?, ?)`,
+		gameID, playerID, ActionCustomApplyInvestigate, ActionCustomApplyProtect)
+	if used >= ability.Charges {
+		return 0
+	}
+	return ability.Charges - used
+}
+
+// customRoleDone is playerDoneWithNightAction for roles without a hardcoded case: a custom
+// night ability is owed every night until its charges run out.
+func customRoleDone(db *sqlx.DB, gameID int64, round int, player Player) bool {
+	ability := customAbility(db, player.RoleName)
+	if ability.Night == "" {
+		return true
+	}
+	var c int
+	db.Get(&c, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		gameID, round, player.PlayerID, customApplyAction(ability.Night))
+	if c > 0 {
+		return true
+	}
+	return customUsesLeft(db, gameID, player.PlayerID, ability) == 0
+}
+
+func buildCustomNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string) CustomNightData {
+	ability := customAbility(db, player.RoleName)
+	if ability.Night == "" {
+		return CustomNightData{}
+	}
+
+	d := CustomNightData{CustomNight: ability.Night}
+
+	var action GameAction
+	if db.Get(&action, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, playerID, customApplyAction(ability.Night)) == nil && action.TargetPlayerID != nil {
+		d.CustomHasActed = true
+		d.CustomSelectedPlayer = getVisiblePlayer(db, game.ID, *action.TargetPlayerID, player, seerInvestigated)
+		if ability.Night == CustomNightInvestigate {
+			if target, err := getPlayerInGame(db, game.ID, *action.TargetPlayerID); err == nil {
+				d.CustomSawWolf = seerSeesWerewolf(target)
+			}
+		}
+	} else {
+		var selectAction GameAction
+		if db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, playerID, ActionCustomSelectTarget) == nil && selectAction.TargetPlayerID != nil {
+			d.CustomSelectedPlayer = getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated)
+		}
+	}
+	// counted after tonight's use, so the player sees what is left for later nights
+	d.CustomUsesLeft = customUsesLeft(db, game.ID, playerID, ability)
+
+	return d
+}
+
+func handleWSCustomSelect(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSCustomSelect: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+	player, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSCustomSelect: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	ability := customAbility(h.db, player.RoleName)
+	if ability.Night == "" {
+		h.sendErrorToast(client.playerID, T(lang, "err_no_custom_ability"))
+		return
+	}
+	if !player.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	if powerDisabled(h.db, game.ID, player.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+	if customRoleDone(h.db, game.ID, game.Round, player) {
+		h.sendErrorToast(client.playerID, T(lang, "err_custom_ability_done"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var existing GameAction
+	selectErr := h.db.Get(&existing, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionCustomSelectTarget)
+	if selectErr == nil && existing.TargetPlayerID != nil && *existing.TargetPlayerID == targetID {
+		// clicking the same target again deselects it
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionCustomSelectTarget)
+		h.logf("%s '%s' deselected target", player.RoleName, player.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionCustomSelectTarget, targetID, VisibilityActor)
+		h.logf("%s '%s' selected target %d", player.RoleName, player.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+// handleWSCustomAct confirms the selected target. Protection is picked up by the werewolf
+// kill like the Doctor's; an investigation is answered right away in the actor's history.
+func handleWSCustomAct(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSCustomAct: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+
+	player, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSCustomAct: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	ability := customAbility(h.db, player.RoleName)
+	if ability.Night == "" {
+		h.sendErrorToast(client.playerID, T(lang, "err_no_custom_ability"))
+		return
+	}
+
+	if !player.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	if powerDisabled(h.db, game.ID, player.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+
+	if customRoleDone(h.db, game.ID, game.Round, player) {
+		h.sendErrorToast(client.playerID, T(lang, "err_custom_ability_done"))
+		return
+	}
+
+	var selectAction GameAction
+	if err := h.db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionCustomSelectTarget); err != nil || selectAction.TargetPlayerID == nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_custom_select_first"))
+		return
+	}
+
+	target, err := getPlayerInGame(h.db, game.ID, *selectAction.TargetPlayerID)
+	if err != nil || !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionCustomSelectTarget)
+
+	histKey := "hist_protected"
+	desc := fmt.Sprintf("Night %d: You protected %s", game.Round, target.Name)
+	if ability.Night == CustomNightInvestigate {
+		histKey = "hist_seer_not_wolf"
+		desc = fmt.Sprintf("Night %d: You investigated %s — they are not a werewolf", game.Round, target.Name)
+		if seerSeesWerewolf(target) {
+			histKey = "hist_seer_wolf"
+			desc = fmt.Sprintf("Night %d: You investigated %s — they are a werewolf", game.Round, target.Name)
+		}
+	}
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, customApplyAction(ability.Night), target.PlayerID, VisibilityActor, desc, histKey, histArgs(game.Round, target.Name))
+	if err != nil {
+		h.logError("handleWSCustomAct: db.Exec insert action", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_custom_role_failed"))
+		return
+	}
+
+	h.logf("%s '%s' used %s on '%s'", player.RoleName, player.Name, ability.Night, target.Name)
+	DebugLog("handleWSCustomAct", "%s '%s' used %s on '%s'", player.RoleName, player.Name, ability.Night, target.Name)
+	LogDBState(h.db, "after custom role action")
+
+	h.resolveWerewolfVotes(game)
+}
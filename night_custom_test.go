@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Custom Role Night Tests
+// ============================================================================
+
+func TestCustomProtectorUsesUpCharges(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	watchman := insertCustomRole(ctx, "Watchman", "villager", `{"night":"protect","charges":1}`)
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Watch", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, watchman, RoleVillager, RoleVillager, RoleVillager})
+	wolf, watch, v1 := ids[0], ids[1], ids[2]
+	target := strconv.FormatInt(v1, 10)
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: target})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("the night should wait for the Watchman, got %d pending kills", n)
+	}
+	ctx.sendWS(watch, WSMessage{Action: "custom_act"})
+	if n := ctx.countActions(ActionCustomApplyProtect); n != 0 {
+		t.Fatal("the Watchman must select a target first")
+	}
+	ctx.sendWS(watch, WSMessage{Action: "custom_select", TargetPlayerID: target})
+	ctx.sendWS(watch, WSMessage{Action: "custom_act"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+	if !ctx.isPlayerAlive(v1) {
+		t.Fatal("the Watchman's protection should save V1")
+	}
+
+	// the single charge is spent: the night no longer waits and V1 is unprotected
+	ctx.app.db.MustExec("UPDATE game SET status = 'night', round = 2")
+	ctx.sendWS(watch, WSMessage{Action: "custom_select", TargetPlayerID: target})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: target})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+	if ctx.isPlayerAlive(v1) {
+		t.Error("without charges left the Watchman cannot protect anymore")
+	}
+}
+
+func TestCustomInvestigatorWithShield(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	oracle := insertCustomRole(ctx, "Oracle", "villager", `{"night":"investigate","shield":true}`)
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Oracle", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, oracle, RoleVillager, RoleVillager, RoleVillager})
+	wolf, seer := ids[0], ids[1]
+
+	ctx.sendWS(seer, WSMessage{Action: "custom_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(seer, WSMessage{Action: "custom_act"})
+	if h := ctx.historyFor(seer); !strings.Contains(h, "You investigated Wolf — they are a werewolf") {
+		t.Errorf("the Oracle should learn the result, got: %q", h)
+	}
+	if h := ctx.historyFor(ids[2]); strings.Contains(h, "investigated") {
+		t.Errorf("the result must stay private, villager sees: %q", h)
+	}
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(seer, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+	if !ctx.isPlayerAlive(seer) {
+		t.Error("the shield should absorb the first attack")
+	}
+}
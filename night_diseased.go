@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// markDiseasedKill sets the game's wolves_skip_round flag when a werewolf kill lands on the
+// Diseased: the pack falls ill and cannot hunt on the following night.
+func (h *Hub) markDiseasedKill(tx *sqlx.Tx, game *Game, victim int64) error {
+	if getRoleName(h.db, game.ID, victim) != "Diseased" || powerDisabled(h.db, game.ID, "Diseased") {
+		return nil
+	}
+	if _, err := tx.Exec("UPDATE game SET wolves_skip_round = ? WHERE rowid = ?", game.Round+1, game.ID); err != nil {
+		return fmt.Errorf("set wolves_skip_round: %w", err)
+	}
+	h.logf("Werewolves killed the Diseased '%s' — no hunt on night %d", h.playerName(victim), game.Round+1)
+	return nil
+}
+
+// wolvesSkipNight reports whether the pack is sick tonight after killing the Diseased.
+func wolvesSkipNight(db *sqlx.DB, gameID int64, round int) bool {
+	var skipRound int
+	db.Get(&skipRound, "SELECT wolves_skip_round FROM game WHERE rowid = ?", gameID)
+	return skipRound != 0 && skipRound == round
+}
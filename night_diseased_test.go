@@ -0,0 +1,119 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Diseased Tests
+// ============================================================================
+
+func TestKillingDiseasedSkipsNextHunt(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Sick", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleDiseased, RoleVillager, RoleVillager, RoleVillager})
+	wolf, sick, v1 := ids[0], ids[1], ids[2]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(sick, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+	if ctx.isPlayerAlive(sick) {
+		t.Fatal("the Diseased should have been killed")
+	}
+
+	alive := []int64{wolf, ids[2], ids[3], ids[4]}
+	for _, id := range alive {
+		ctx.sendWS(id, WSMessage{Action: "day_pass"})
+	}
+	ctx.sendWS(wolf, WSMessage{Action: "day_end_vote"})
+
+	game, _ := ctx.hub().getGame()
+	if game.Status != "night" || game.Round != 2 {
+		t.Fatalf("expected night 2, got %s %d", game.Status, game.Round)
+	}
+	buf, err := getGameComponent(ctx.hub(), wolf, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "wolves-sick-msg") {
+		t.Errorf("the wolf UI should say the pack cannot hunt (err: %v)", err)
+	}
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	if n := ctx.countActions(ActionWerewolfSelectKill); n != 1 {
+		t.Errorf("a sick pack must not vote; expected only night 1's vote, got %d", n)
+	}
+	for _, id := range alive {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	game, _ = ctx.hub().getGame()
+	if game.Status != "day" || game.Round != 2 {
+		t.Fatalf("night 2 should end without a hunt, got %s %d", game.Status, game.Round)
+	}
+	for _, id := range alive {
+		if !ctx.isPlayerAlive(id) {
+			t.Errorf("nobody should die on the sick night, but player %d did", id)
+		}
+	}
+	if wolvesSkipNight(ctx.app.db, game.ID, 3) {
+		t.Error("the sickness should last a single night")
+	}
+}
+
+func TestDiseasedSickensPackInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the wolves cannot kill the night after eating the Diseased ===")
+
+	// Setup: 1 werewolf + 1 diseased + 3 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"DI1", "DI2", "DI3", "DI4", "DI5"},
+		RoleWerewolf, RoleDiseased, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Diseased"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	diseased, werewolf, villager := byRole["Diseased"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+
+	werewolf.voteForPlayer(diseased.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if deaths := villager.getDeathAnnouncement(); !strings.Contains(deaths, diseased.Name) {
+		ctx.logger.LogDB("FAIL: diseased did not die")
+		t.Fatalf("The Diseased should have died, got: %s", deaths)
+	}
+
+	var alive []*TestPlayer
+	for _, p := range players {
+		if p != diseased {
+			alive = append(alive, p)
+		}
+	}
+	passDayForAll(alive)
+	waitForNightPhaseAll(ctx, alive)
+
+	// Night 2: the pack is sick and has no vote
+	if found, _, _ := werewolf.p().Has("#wolves-sick-msg"); !found {
+		ctx.logger.LogDB("FAIL: wolves not sick")
+		t.Errorf("Werewolf should be told the pack is sick on night 2")
+	}
+	if found, _, _ := werewolf.p().Has("[id^='vote-form-']"); found {
+		ctx.logger.LogDB("FAIL: sick wolves can vote")
+		t.Errorf("Sick werewolves should not be able to choose a victim")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -74,6 +74,10 @@ func handleWSDoctorSelect(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
 		return
 	}
+	if powerDisabled(h.db, game.ID, doctor.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
 	var existingCount int
 	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
 		game.ID, game.Round, client.playerID, ActionDoctorApplyProtect)
@@ -145,6 +149,11 @@ func handleWSDoctorProtect(client *Client, msg WSMessage) {
 		return
 	}
 
+	if powerDisabled(h.db, game.ID, doctor.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+
 	var existingCount int
 	h.db.Get(&existingCount, `
 SELECT COUNT(*) FROM game_action
@@ -23,17 +23,10 @@ func buildDoppelgangerNightData(db *sqlx.DB, game *Game, playerID int64, player
 
 	d := DoppelgangerNightData{}
 
-	var copyAction GameAction
-	if err := db.Get(&copyAction, `
-SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
-FROM game_action
-WHERE game_id=? AND round=1 AND phase='night' AND actor_player_id=? AND action_type=?`,
-		game.ID, playerID, ActionDoppelgangerApplyCopy); err == nil && copyAction.TargetPlayerID != nil {
+	// the role model's role stays hidden like anyone else's until the Doppelganger inherits it
+	if modelID := roleModelOf(db, game.ID, playerID); modelID != 0 {
 		d.DoppelgangerHasCopied = true
-		target, err := getPlayerInGame(db, game.ID, *copyAction.TargetPlayerID)
-		if err == nil {
-			d.DoppelgangerCopiedPlayer = &target
-		}
+		d.DoppelgangerCopiedPlayer = getVisiblePlayer(db, game.ID, modelID, player, seerInvestigated)
 	} else {
 		var selectAction GameAction
 		if db.Get(&selectAction, `
@@ -77,10 +70,7 @@ func handleWSDoppelgangerSelect(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_doppelganger_only_living"))
 		return
 	}
-	var copiedCount int
-	h.db.Get(&copiedCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=1 AND phase='night' AND actor_player_id=? AND action_type=?`,
-		game.ID, client.playerID, ActionDoppelgangerApplyCopy)
-	if copiedCount > 0 {
+	if roleModelOf(h.db, game.ID, client.playerID) != 0 {
 		h.sendErrorToast(client.playerID, T(lang, "err_doppelganger_already_chosen"))
 		return
 	}
@@ -143,10 +133,7 @@ func handleWSDoppelgangerCopy(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_doppelganger_only_living"))
 		return
 	}
-	var copiedCount int
-	h.db.Get(&copiedCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=1 AND phase='night' AND actor_player_id=? AND action_type=?`,
-		game.ID, client.playerID, ActionDoppelgangerApplyCopy)
-	if copiedCount > 0 {
+	if roleModelOf(h.db, game.ID, client.playerID) != 0 {
 		h.sendErrorToast(client.playerID, T(lang, "err_doppelganger_already_chosen"))
 		return
 	}
@@ -168,50 +155,105 @@ WHERE game_id=? AND round=1 AND phase='night' AND actor_player_id=? AND action_t
 		return
 	}
 
-	var targetRoleID, originalRoleID int64
-	h.db.Get(&targetRoleID, `SELECT role_id FROM game_player WHERE game_id = ? AND player_id = ?`, game.ID, targetID)
-	h.db.Get(&originalRoleID, `SELECT role_id FROM game_player WHERE game_id = ? AND player_id = ?`, game.ID, client.playerID)
-
-	// original_role_id marks them as a former Doppelganger for the end-game reveal
-	if _, err := h.db.Exec(`UPDATE game_player SET role_id = ?, original_role_id = ? WHERE game_id = ? AND player_id = ?`,
-		targetRoleID, originalRoleID, game.ID, client.playerID); err != nil {
-		h.logError("handleWSDoppelgangerCopy: update role", err)
-		h.sendErrorToast(client.playerID, T(lang, "err_failed_apply_role_change"))
+	// the Doppelganger shares the Wild Child's role model table; inheritDoppelgangerRoles hands
+	// them the role once the model dies
+	if _, err := h.db.Exec(`INSERT INTO game_role_model (game_id, child_player_id, model_player_id) VALUES (?, ?, ?)`,
+		game.ID, client.playerID, targetID); err != nil {
+		h.logError("handleWSDoppelgangerCopy: insert role model", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_copy"))
 		return
 	}
 
 	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=1 AND phase='night' AND actor_player_id=? AND action_type=?`,
 		game.ID, client.playerID, ActionDoppelgangerSelectCopy)
-	copyDesc := fmt.Sprintf("Night 1: You secretly became a %s (copied from %s)", target.RoleName, target.Name)
-	_, err = h.db.Exec(`
+	copyDesc := fmt.Sprintf("Night 1: You chose %s — you take their role when they die", target.Name)
+	h.db.Exec(`
 INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
 VALUES (?, 1, 'night', ?, ?, ?, ?, ?, ?, ?)`,
-		game.ID, client.playerID, ActionDoppelgangerApplyCopy, targetID, VisibilityActor, copyDesc, "hist_doppelganger", histArgs(target.RoleName, target.Name))
-	if err != nil {
-		h.logError("handleWSDoppelgangerCopy: insert copy action", err)
-		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_copy"))
-		return
+		game.ID, client.playerID, ActionDoppelgangerApplyCopy, targetID, VisibilityActor, copyDesc, "hist_doppelganger_model", histArgs(target.Name))
+
+	h.logf("Doppelganger '%s' chose '%s' and takes their role when they die", doppelganger.Name, target.Name)
+	LogDBState(h.db, "after doppelganger choice")
+	h.resolveWerewolfVotes(game)
+}
+
+// inheritDoppelgangerRoles hands every living Doppelganger whose role model has died the
+// model's role. Like awakenWildChildren it runs after every death path, before the Apprentice
+// Seer's promotion, so a Doppelganger who inherits the Seer keeps the apprentice waiting.
+// original_role_id marks them for the end-game reveal. A Doppelganger who inherits a Wild
+// Child follows that child's role model; one who inherits a werewolf role joins the pack.
+func (h *Hub) inheritDoppelgangerRoles(game *Game, phase string) {
+	var heirs []struct {
+		HeirID   int64  `db:"child_player_id"`
+		OwnRole  int64  `db:"own_role_id"`
+		ModelID  int64  `db:"model_player_id"`
+		Model    string `db:"model_name"`
+		RoleID   int64  `db:"model_role_id"`
+		RoleName string `db:"model_role_name"`
+		Team     string `db:"model_team"`
 	}
+	h.db.Select(&heirs, `
+SELECT m.child_player_id, heir.role_id as own_role_id, m.model_player_id, p.name as model_name,
+	model.role_id as model_role_id, mr.name as model_role_name, mr.team as model_team
+FROM game_role_model m
+JOIN game_player heir ON heir.game_id = m.game_id AND heir.player_id = m.child_player_id
+JOIN role r ON heir.role_id = r.rowid
+JOIN game_player model ON model.game_id = m.game_id AND model.player_id = m.model_player_id
+JOIN role mr ON model.role_id = mr.rowid
+JOIN player p ON p.rowid = m.model_player_id
+WHERE m.game_id = ? AND heir.is_alive = 1 AND r.name = 'Doppelganger' AND model.is_alive = 0`, game.ID)
 
-	toastMsg := T(lang, "toast_doppelganger_became", T(lang, "role_name_"+target.RoleName))
-	h.sendToPlayer(client.playerID, []byte(renderToast(h.templates, h.logf, "info", toastMsg)))
+	for _, d := range heirs {
+		tx, err := h.db.Beginx()
+		if err != nil {
+			h.logError("inheritDoppelgangerRoles: begin", err)
+			continue
+		}
+		if err := takeOverRole(tx, game, d.HeirID, d.OwnRole, d.ModelID, d.RoleID); err != nil {
+			tx.Rollback()
+			h.logError("inheritDoppelgangerRoles: take over role", err)
+			continue
+		}
+		if err := tx.Commit(); err != nil {
+			h.logError("inheritDoppelgangerRoles: commit", err)
+			continue
+		}
 
-	// the Seer's earlier reading is now stale: it called them a villager before they became a werewolf
-	if target.Team == "werewolf" {
-		var seerInvestigations []struct {
-			ActorPlayerID int64 `db:"actor_player_id"`
+		histKey := "hist_doppelganger_heir_night"
+		desc := fmt.Sprintf("Night %d: %s is dead — you take their role: %s", game.Round, d.Model, d.RoleName)
+		if phase == "day" {
+			histKey = "hist_doppelganger_heir_day"
+			desc = fmt.Sprintf("Day %d: %s is dead — you take their role: %s", game.Round, d.Model, d.RoleName)
 		}
-		h.db.Select(&seerInvestigations, `
-SELECT actor_player_id FROM game_action
-WHERE game_id = ? AND action_type = ? AND target_player_id = ?`,
-			game.ID, ActionSeerApplyInvestigate, doppelganger.PlayerID)
-		for _, inv := range seerInvestigations {
-			notif := T(h.getPlayerLang(inv.ActorPlayerID), "toast_seer_outdated_reading", doppelganger.Name)
-			h.sendToPlayer(inv.ActorPlayerID, []byte(renderToast(h.templates, h.logf, "warning", notif)))
+		h.db.Exec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			game.ID, game.Round, phase, d.HeirID, ActionDoppelgangerInherit, d.ModelID, VisibilityActor, desc, histKey, histArgs(game.Round, d.Model, d.RoleName))
+
+		lang := h.getPlayerLang(d.HeirID)
+		toastMsg := T(lang, "toast_doppelganger_became", TOr(lang, "role_name_"+d.RoleName, d.RoleName))
+		h.sendToPlayer(d.HeirID, []byte(renderToast(h.templates, h.logf, "info", toastMsg)))
+		// the Seer's earlier reading is now stale: it called them a villager before they became a werewolf
+		if d.Team == "werewolf" {
+			h.warnStaleSeers(game, d.HeirID)
 		}
+		h.logf("Doppelganger '%s' lost their role model '%s' and is now a %s", h.playerName(d.HeirID), d.Model, d.RoleName)
 	}
+}
 
-	h.logf("Doppelganger '%s' immediately became a %s (copied from '%s')", doppelganger.Name, target.RoleName, target.Name)
-	LogDBState(h.db, "after doppelganger copy")
-	h.resolveWerewolfVotes(game)
+// takeOverRole gives the heir the dead model's role and, should that be a Wild Child, the
+// model's own role model in place of the model.
+func takeOverRole(tx *sqlx.Tx, game *Game, heirID, ownRoleID, modelID, roleID int64) error {
+	if _, err := tx.Exec(`UPDATE game_player SET role_id = ?, original_role_id = ? WHERE game_id = ? AND player_id = ?`,
+		roleID, ownRoleID, game.ID, heirID); err != nil {
+		return fmt.Errorf("take over role: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM game_role_model WHERE game_id = ? AND child_player_id = ?`, game.ID, heirID); err != nil {
+		return fmt.Errorf("drop role model: %w", err)
+	}
+	if _, err := tx.Exec(`
+INSERT INTO game_role_model (game_id, child_player_id, model_player_id)
+SELECT game_id, ?, model_player_id FROM game_role_model WHERE game_id = ? AND child_player_id = ?`,
+		heirID, game.ID, modelID); err != nil {
+		return fmt.Errorf("follow the model's role model: %w", err)
+	}
+	return nil
 }
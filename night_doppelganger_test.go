@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -89,10 +90,10 @@ func (tp *TestPlayer) doppelgangerCopy() {
 // Doppelganger Tests
 // ============================================================================
 
-// TestDoppelgangerCopiesVillager verifies the full happy path:
-// Night only resolves after the Doppelganger copies, and the Doppelganger's
-// role immediately changes to the copied role.
-func TestDoppelgangerCopiesVillager(t *testing.T) {
+// TestDoppelgangerChoosesRoleModel verifies the night-1 choice: the night only
+// resolves after the Doppelganger has chosen, and they stay a Doppelganger while
+// their role model lives.
+func TestDoppelgangerChoosesRoleModel(t *testing.T) {
 	t.Parallel()
 	ctx := newTestContext(t)
 	defer ctx.cleanup()
@@ -119,64 +120,61 @@ func TestDoppelgangerCopiesVillager(t *testing.T) {
 	dg := doppelgangers[0]
 	wolf := werewolves[0]
 
-	// Doppelganger must see the copy UI on Night 1.
+	// Doppelganger must see the choice UI on Night 1.
 	if !dg.canSeeDoppelgangerUI() {
 		ctx.logger.LogDB("FAIL: doppelganger UI not visible")
-		t.Fatal("Doppelganger should see the copy UI on Night 1")
+		t.Fatal("Doppelganger should see the choice UI on Night 1")
 	}
 
-	// Copy button should be disabled until a target is selected.
+	// Choose button should be disabled until a target is selected.
 	if dg.isDoppelgangerCopyButtonEnabled() {
-		t.Fatal("Doppelganger copy button should be disabled before selecting a target")
+		t.Fatal("Doppelganger choose button should be disabled before selecting a target")
 	}
 
-	// Werewolf votes — night should not resolve yet (Doppelganger hasn't copied).
+	// Werewolf votes — night should not resolve yet (Doppelganger hasn't chosen).
 	wolf.voteForPlayer(villagers[0].Name)
 	if wolf.isInDayPhase() {
-		t.Fatal("Night should not resolve before Doppelganger has copied")
+		t.Fatal("Night should not resolve before Doppelganger has chosen")
 	}
 
-	// Doppelganger selects a villager.
+	// Doppelganger selects the villager the wolves spare.
 	dg.doppelgangerSelectTarget(villagers[1].Name)
 	if !dg.isDoppelgangerCopyButtonEnabled() {
-		t.Fatal("Doppelganger copy button should be enabled after selecting a target")
+		t.Fatal("Doppelganger choose button should be enabled after selecting a target")
 	}
 
-	// Night still should not resolve — copy not yet confirmed.
+	// Night still should not resolve — choice not yet confirmed.
 	if wolf.isInDayPhase() {
-		t.Fatal("Night should not resolve before Doppelganger confirms copy")
+		t.Fatal("Night should not resolve before Doppelganger confirms the choice")
 	}
 
-	// Doppelganger confirms copy.
 	dg.doppelgangerCopy()
 
 	submitNightSurveysForAllPlayers(players)
 
-	// Night should now resolve.
 	if err := wolf.waitForDayPhase(); err != nil {
 		ctx.logger.LogDB("FAIL: day phase did not start")
-		t.Fatalf("Night should resolve after Doppelganger copies: %v", err)
+		t.Fatalf("Night should resolve after Doppelganger chooses: %v", err)
 	}
 
-	// Doppelganger's sidebar role should now show the copied role.
-	newRole := dg.getRole()
-	if newRole == "Doppelganger" {
-		ctx.logger.LogDB("FAIL: doppelganger role not updated")
-		t.Errorf("Doppelganger's role should have changed, still shows 'Doppelganger'")
+	// The role model lives, so the Doppelganger keeps their role.
+	if role := dg.getRole(); role != "Doppelganger" {
+		ctx.logger.LogDB("FAIL: doppelganger role changed early")
+		t.Errorf("Doppelganger should stay a Doppelganger while the role model lives, shows %q", role)
 	}
 
-	// History should contain the copy notification (actor-only visibility).
-	if !dg.historyContains("You secretly became a") {
-		ctx.logger.LogDB("FAIL: copy history missing")
-		t.Errorf("Doppelganger history should contain copy notification, got: %s", dg.getHistoryText())
+	// History should contain the choice (actor-only visibility).
+	if !dg.historyContains("you take their role when they die") {
+		ctx.logger.LogDB("FAIL: choice history missing")
+		t.Errorf("Doppelganger history should contain the choice, got: %s", dg.getHistoryText())
 	}
 
 	ctx.logger.Debug("=== Test passed ===")
 }
 
-// TestDoppelgangerCopiesWerewolf verifies that when the Doppelganger copies a
-// werewolf their role immediately changes to Werewolf.
-func TestDoppelgangerCopiesWerewolf(t *testing.T) {
+// TestDoppelgangerInheritsRoleWhenModelDies verifies that when the wolves kill
+// the Doppelganger's role model, the Doppelganger takes the model's role at dawn.
+func TestDoppelgangerInheritsRoleWhenModelDies(t *testing.T) {
 	t.Parallel()
 	ctx := newTestContext(t)
 	defer ctx.cleanup()
@@ -184,40 +182,47 @@ func TestDoppelgangerCopiesWerewolf(t *testing.T) {
 	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
 	defer browserCleanup()
 
-	// 1 Doppelganger + 3 Villagers + 1 Werewolf = 5 players
+	// 1 Seer + 1 Doppelganger + 2 Villagers + 1 Werewolf = 5 players
 	var players []*TestPlayer
-	for _, name := range []string{"DW1", "DW2", "DW3", "DW4", "DW5"} {
+	for _, name := range []string{"DI1", "DI2", "DI3", "DI4", "DI5"} {
 		players = append(players, browser.signupPlayer(ctx.baseURL, name))
 	}
+	players[0].addRoleByID(RoleSeer)
 	players[0].addRoleByID(RoleDoppelganger)
 	players[0].addRoleByID(RoleVillager)
 	players[0].addRoleByID(RoleVillager)
-	players[0].addRoleByID(RoleVillager)
 	players[0].addRoleByID(RoleWerewolf)
 	players[0].startGame()
 
-	werewolves, villagers, doppelgangers := findPlayersByRoleWithDoppelganger(players)
-	if len(doppelgangers) == 0 || len(werewolves) == 0 || len(villagers) < 3 {
+	werewolves, villagers, seers, doppelgangers := findPlayersByRoleWithDoppelgangerAndSeer(players)
+	if len(seers) == 0 || len(doppelgangers) == 0 || len(werewolves) == 0 || len(villagers) < 2 {
 		t.Skip("Role assignment didn't produce expected roles")
 	}
 
+	seer := seers[0]
 	dg := doppelgangers[0]
 	wolf := werewolves[0]
 
-	// Werewolf votes.
-	wolf.voteForPlayer(villagers[0].Name)
-
-	// Doppelganger copies the werewolf.
-	dg.doppelgangerSelectTarget(wolf.Name)
+	// The Doppelganger chooses the Seer, whom the wolves then kill.
+	dg.doppelgangerSelectTarget(seer.Name)
 	dg.doppelgangerCopy()
+	seer.seerSelectTarget(wolf.Name)
+	seer.seerInvestigate()
+	wolf.voteForPlayer(seer.Name)
+	submitNightSurveysForAllPlayers(players)
 
-	// Doppelganger's role should immediately change to Werewolf.
-	if err := dg.waitUntilCondition(`() => {
-		const card = document.querySelector('#sidebar-role-card');
-		return card && card.getAttribute('role-name') === 'Werewolf';
-	}`, "doppelganger becomes werewolf"); err != nil {
-		ctx.logger.LogDB("FAIL: doppelganger did not become werewolf")
-		t.Fatalf("Doppelganger should show Werewolf role after copying werewolf: %v", err)
+	if err := wolf.waitForDayPhase(); err != nil {
+		ctx.logger.LogDB("FAIL: day phase did not start")
+		t.Fatalf("Day 1 did not start: %v", err)
+	}
+
+	// The Doppelganger's sidebar should now show the Seer's role.
+	if err := dg.waitForRole("Seer"); err != nil {
+		ctx.logger.LogDB("FAIL: doppelganger did not inherit the seer")
+		t.Fatalf("Doppelganger should show the Seer role once the role model died: %v", err)
+	}
+	if !dg.historyContains("you take their role") {
+		t.Errorf("Doppelganger history should record the inheritance, got: %s", dg.getHistoryText())
 	}
 
 	ctx.logger.Debug("=== Test passed ===")
@@ -334,10 +339,11 @@ func TestDoppelgangerUIHiddenOnNight2(t *testing.T) {
 	ctx.logger.Debug("=== Test passed ===")
 }
 
-// TestDoppelgangerSeerNotificationOnWerewolfCopy verifies that a Seer who
-// investigated the Doppelganger before the copy receives a warning toast when
-// the Doppelganger copies a werewolf-team player.
-func TestDoppelgangerSeerNotificationOnWerewolfCopy(t *testing.T) {
+// TestDoppelgangerSeerNotificationOnInheritedWerewolf verifies that a Seer who
+// investigated the Doppelganger receives a warning toast when the Doppelganger
+// later inherits a werewolf role: the village lynches the wolf the Doppelganger
+// chose as role model.
+func TestDoppelgangerSeerNotificationOnInheritedWerewolf(t *testing.T) {
 	t.Parallel()
 	ctx := newTestContext(t)
 	defer ctx.cleanup()
@@ -366,27 +372,34 @@ func TestDoppelgangerSeerNotificationOnWerewolfCopy(t *testing.T) {
 	dg := doppelgangers[0]
 	wolf := werewolves[0]
 
-	// Werewolf votes for a villager.
+	// Night 1: the wolf kills a villager, the Seer reads the Doppelganger and the
+	// Doppelganger chooses the wolf.
 	wolf.voteForPlayer(villagers[0].Name)
-
-	// Seer investigates the Doppelganger — result should be "Not Werewolf".
 	seer.seerSelectTarget(dg.Name)
 	seer.seerInvestigate()
 
-	// Confirm investigation result shows doppelganger as not-werewolf.
 	seerResult, _ := seer.p().Element("#seer-result")
 	if seerResult != nil {
 		team, _ := seerResult.Attribute("team")
 		if team != nil && *team == "werewolf" {
-			t.Errorf("Seer result should show Doppelganger as non-werewolf before copy, got team=%s", *team)
+			t.Errorf("Seer result should show Doppelganger as non-werewolf, got team=%s", *team)
 		}
 	}
 
-	// Doppelganger copies the werewolf — this should trigger a warning toast to the Seer.
 	dg.doppelgangerSelectTarget(wolf.Name)
 	dg.doppelgangerCopy()
+	submitNightSurveysForAllPlayers(players)
+
+	if err := wolf.waitForDayPhase(); err != nil {
+		t.Fatalf("Day 1 did not start: %v", err)
+	}
+
+	// Day 1: the village lynches the wolf; the Doppelganger takes their place.
+	seer.dayVoteForPlayer(wolf.Name)
+	dg.dayVoteForPlayer(wolf.Name)
+	villagers[1].dayVoteForPlayer(wolf.Name)
+	wolf.dayVoteForPlayer(villagers[1].Name)
 
-	// Seer should receive a warning toast about their stale reading.
 	warningText := "has become a werewolf"
 	if err := seer.waitUntilCondition(`() => {
 		const container = document.querySelector('#toast-container');
@@ -401,5 +414,88 @@ func TestDoppelgangerSeerNotificationOnWerewolfCopy(t *testing.T) {
 		t.Errorf("Seer should see warning toast containing %q", warningText)
 	}
 
+	if err := dg.waitForRole("Werewolf"); err != nil {
+		ctx.logger.LogDB("FAIL: doppelganger did not become werewolf")
+		t.Fatalf("Doppelganger should show the Werewolf role after the wolf was lynched: %v", err)
+	}
+
 	ctx.logger.Debug("=== Test passed ===")
 }
+
+// ============================================================================
+// Doppelganger Inheritance Tests
+// ============================================================================
+
+func TestDoppelgangerInheritsOnlyOnceModelDies(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Doppel", "Child", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleDoppelganger, RoleWildChild, RoleVillager, RoleVillager, RoleVillager})
+	doppel, child, v1, v2 := ids[1], ids[2], ids[3], ids[4]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("INSERT INTO game_role_model (game_id, child_player_id, model_player_id) VALUES (?, ?, ?), (?, ?, ?)",
+		game.ID, doppel, child, game.ID, child, v1)
+
+	// someone else dying changes nothing
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	}
+	ctx.sendWS(ids[0], WSMessage{Action: "day_end_vote"})
+	if player, _ := getPlayerInGame(ctx.app.db, game.ID, doppel); player.RoleName != "Doppelganger" {
+		t.Fatalf("the Doppelganger should keep their role while the role model lives, got %q", player.RoleName)
+	}
+
+	// lynching the Wild Child hands the Doppelganger the child's role and role model
+	ctx.app.db.MustExec("UPDATE game SET status = 'day', round = 2 WHERE rowid = ?", game.ID)
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(child, 10)})
+	}
+	ctx.sendWS(ids[0], WSMessage{Action: "day_end_vote"})
+	player, _ := getPlayerInGame(ctx.app.db, game.ID, doppel)
+	if player.RoleName != "Wild Child" || !player.IsDoppelganger {
+		t.Fatalf("the Doppelganger should inherit the lynched Wild Child, got %q (marked %v)", player.RoleName, player.IsDoppelganger)
+	}
+	if model := roleModelOf(ctx.app.db, game.ID, doppel); model != v1 {
+		t.Errorf("the Doppelganger should follow the Wild Child's role model, got %d", model)
+	}
+	if h := ctx.historyFor(doppel); !strings.Contains(h, "Child is dead") {
+		t.Errorf("the Doppelganger should be told, got: %q", h)
+	}
+	if h := ctx.historyFor(v1); strings.Contains(h, "take their role") {
+		t.Errorf("the inheritance must stay private, villager sees: %q", h)
+	}
+}
+
+func TestDoppelgangerInheritsAtDawn(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Doppel", "Mason", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleDoppelganger, RoleMason, RoleVillager, RoleVillager, RoleVillager})
+	wolf, doppel, mason := ids[0], ids[1], ids[2]
+
+	ctx.sendWS(doppel, WSMessage{Action: "doppelganger_select", TargetPlayerID: strconv.FormatInt(mason, 10)})
+	ctx.sendWS(doppel, WSMessage{Action: "doppelganger_copy"})
+	game, _ := ctx.hub().getGame()
+	if player, _ := getPlayerInGame(ctx.app.db, game.ID, doppel); player.RoleName != "Doppelganger" {
+		t.Fatalf("choosing should not change the role yet, got %q", player.RoleName)
+	}
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(mason, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("the night should end, got %q", status)
+	}
+	if player, _ := getPlayerInGame(ctx.app.db, game.ID, doppel); player.RoleName != "Mason" || !player.IsDoppelganger {
+		t.Errorf("the Doppelganger should take the dead Mason's role at dawn, got %q (marked %v)", player.RoleName, player.IsDoppelganger)
+	}
+}
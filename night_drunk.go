@@ -0,0 +1,98 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// drunkSoberNight is the night on which the Drunk learns their real role.
+const drunkSoberNight = 3
+
+// drunkRolePool holds the village roles a Drunk may secretly be. Roles that only act on
+// night 1 (Cupid, Doppelganger) or need a partner (Mason) are left out.
+var drunkRolePool = []string{"Seer", "Doctor", "Witch", "Hunter", "Guard", "Bodyguard", "Aura Seer", "Spellcaster", "Mayor", "Prince", "Priest"}
+
+// assignDrunkRoles draws the hidden role for every Drunk at game start. The role is only
+// stored in drunk_role_id; role_id stays Drunk until soberDrunks swaps it in.
+func (h *Hub) assignDrunkRoles(gameID int64) error {
+	var drunkIDs []int64
+	h.db.Select(&drunkIDs, `
+SELECT g.player_id FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND r.name = 'Drunk'`, gameID)
+
+	for _, id := range drunkIDs {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(drunkRolePool))))
+		if err != nil {
+			return err
+		}
+		roleName := drunkRolePool[n.Int64()]
+		if _, err := h.db.Exec(`UPDATE game_player SET drunk_role_id = (SELECT rowid FROM role WHERE name = ?) WHERE game_id = ? AND player_id = ?`,
+			roleName, gameID, id); err != nil {
+			return err
+		}
+		h.logf("Drunk %d secretly holds role '%s'", id, roleName)
+	}
+	return nil
+}
+
+// soberDrunks gives every living Drunk their real role once night drunkSoberNight begins,
+// so they act with it from that night on. The reveal is private to the Drunk.
+func (h *Hub) soberDrunks(game *Game, round int) {
+	if round < drunkSoberNight {
+		return
+	}
+
+	var drunks []struct {
+		PlayerID int64  `db:"player_id"`
+		RoleName string `db:"role_name"`
+	}
+	h.db.Select(&drunks, `
+SELECT g.player_id, tr.name as role_name FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+JOIN role tr ON g.drunk_role_id = tr.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Drunk'`, game.ID)
+
+	for _, d := range drunks {
+		if _, err := h.db.Exec(`UPDATE game_player SET role_id = drunk_role_id WHERE game_id = ? AND player_id = ?`,
+			game.ID, d.PlayerID); err != nil {
+			h.logError("soberDrunks: swap role", err)
+			continue
+		}
+
+		desc := fmt.Sprintf("Night %d: The fog lifts — you are really the %s", round, d.RoleName)
+		h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args) VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+			game.ID, round, d.PlayerID, ActionDrunkSobered, d.PlayerID, VisibilityActor, desc, "hist_drunk_sobered", histArgs(round, d.RoleName))
+
+		lang := h.getPlayerLang(d.PlayerID)
+		toastMsg := T(lang, "toast_drunk_sobered", TOr(lang, "role_name_"+d.RoleName, d.RoleName))
+		h.sendToPlayer(d.PlayerID, []byte(renderToast(h.templates, h.logf, "info", toastMsg)))
+
+		h.logf("Drunk '%s' sobered up as '%s'", h.playerName(d.PlayerID), d.RoleName)
+	}
+}
+
+// drunkView is what a living Drunk sees of themselves before sobering up: a plain Villager.
+// Everyone else already sees an unknown card; death and the finished game reveal "Drunk".
+func drunkView(game *Game, p Player) Player {
+	if p.RoleName != "Drunk" || !p.IsAlive || game.Status == "finished" {
+		return p
+	}
+	p.RoleId = RoleVillager
+	p.RoleName = "Villager"
+	p.RoleDescription = ""
+	return p
+}
+
+// maskDrunkSelf applies drunkView to the viewer's own entry in a player list.
+func maskDrunkSelf(game *Game, players []Player, viewerID int64) []Player {
+	out := make([]Player, len(players))
+	for i, p := range players {
+		if p.PlayerID == viewerID {
+			p = drunkView(game, p)
+		}
+		out[i] = p
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Drunk Tests
+// ============================================================================
+
+func TestDrunkSeesVillagerUntilNightThree(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 2,
+		[]string{"Wolf", "Drunk", "V1", "V2"},
+		[]string{RoleWerewolf, RoleDrunk, RoleVillager, RoleVillager})
+	drunk := ids[1]
+
+	game, _ := ctx.hub().getGame()
+	if err := ctx.hub().assignDrunkRoles(game.ID); err != nil {
+		t.Fatalf("assignDrunkRoles: %v", err)
+	}
+	var hidden string
+	ctx.app.db.Get(&hidden, `SELECT r.name FROM game_player g JOIN role r ON g.drunk_role_id = r.rowid WHERE g.player_id = ?`, drunk)
+	if !slices.Contains(drunkRolePool, hidden) {
+		t.Fatalf("hidden role %q should come from the Drunk pool", hidden)
+	}
+
+	p, _ := getPlayerInGame(ctx.app.db, game.ID, drunk)
+	if v := drunkView(game, p); v.RoleName != "Villager" {
+		t.Errorf("the Drunk should see themselves as a Villager, got %q", v.RoleName)
+	}
+	if v := maskDrunkSelf(game, []Player{p}, ids[0])[0]; v.RoleName != "Drunk" {
+		t.Errorf("only the Drunk's own view is masked, got %q", v.RoleName)
+	}
+
+	// day 2 ends with nobody eliminated → night 3, the Drunk sobers up
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_pass"})
+	}
+	ctx.sendWS(ids[0], WSMessage{Action: "day_end_vote"})
+
+	game, _ = ctx.hub().getGame()
+	if game.Status != "night" || game.Round != 3 {
+		t.Fatalf("expected night 3, got %s %d", game.Status, game.Round)
+	}
+	p, _ = getPlayerInGame(ctx.app.db, game.ID, drunk)
+	if p.RoleName != hidden {
+		t.Errorf("the Drunk should now be the %s, got %q", hidden, p.RoleName)
+	}
+	if h := ctx.historyFor(drunk); !strings.Contains(h, "you are really the "+hidden) {
+		t.Errorf("the Drunk should learn their role in history, got: %q", h)
+	}
+	if h := ctx.historyFor(ids[2]); strings.Contains(h, "fog lifts") {
+		t.Errorf("the reveal must stay private, villager sees: %q", h)
+	}
+}
+
+func TestDrunkStaysDrunkBeforeNightThree(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Drunk", "V1", "V2"},
+		[]string{RoleWerewolf, RoleDrunk, RoleVillager, RoleVillager})
+	drunk := ids[1]
+
+	game, _ := ctx.hub().getGame()
+	ctx.hub().assignDrunkRoles(game.ID)
+	ctx.hub().transitionToNight(game)
+
+	p, _ := getPlayerInGame(ctx.app.db, game.ID, drunk)
+	if p.RoleName != "Drunk" {
+		t.Errorf("the Drunk should stay Drunk on night 2, got %q", p.RoleName)
+	}
+	if !playerDoneWithNightAction(ctx.app.db, game.ID, 2, p) {
+		t.Error("the Drunk has no night action of their own")
+	}
+}
+
+func TestDrunkSobersOnNightThreeInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Drunk believes they are a Villager until night 3 ===")
+
+	// Setup: 1 werewolf + 1 drunk + 3 masons = 5 players. No real Villager is dealt, so the
+	// only player who sees a Villager card is the Drunk.
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"DR1", "DR2", "DR3", "DR4", "DR5"},
+		RoleWerewolf, RoleDrunk, RoleMason, RoleMason, RoleMason)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Villager"]) != 1 || len(byRole["Werewolf"]) == 0 || len(byRole["Mason"]) < 2 {
+		t.Fatalf("Expected the Drunk to see a Villager card, got roles: %v", byRole)
+	}
+	drunk, werewolf, masons := byRole["Villager"][0], byRole["Werewolf"][0], byRole["Mason"]
+
+	// Nights 1 and 2 each cost a Mason; the village passes in between
+	alive := players
+	for night := 0; night < 2; night++ {
+		victim := masons[night]
+		werewolf.voteForPlayer(victim.Name)
+		submitNightSurveysForAllPlayers(alive)
+		var next []*TestPlayer
+		for _, p := range alive {
+			if p != victim {
+				next = append(next, p)
+			}
+		}
+		alive = next
+		waitForDayPhaseAll(ctx, alive)
+		if drunk.getRole() != "Villager" {
+			ctx.logger.LogDB("FAIL: drunk sobered too early")
+			t.Fatalf("Drunk should still see a Villager card on day %d, got: %s", night+1, drunk.getRole())
+		}
+		passDayForAll(alive)
+		waitForNightPhaseAll(ctx, alive)
+	}
+
+	// Night 3: the fog lifts
+	if err := drunk.waitUntilCondition(`() => document.querySelector('#sidebar-role-card')?.getAttribute('role-name') !== 'Villager'`,
+		"drunk sobers"); err != nil {
+		ctx.logger.LogDB("FAIL: drunk did not sober up")
+		t.Fatalf("Drunk should learn their real role on night 3: %v", err)
+	}
+	if !drunk.historyContains("The fog lifts — you are really the " + drunk.getRole()) {
+		ctx.logger.LogDB("FAIL: drunk not told of the real role")
+		t.Errorf("Drunk should see their real role in history, got: %s", drunk.getHistoryText())
+	}
+	if werewolf.historyContains("The fog lifts") {
+		ctx.logger.LogDB("FAIL: sobering visible to others")
+		t.Errorf("Only the Drunk should learn their real role")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// villagerPowers are the village roles that go quiet once the village lynches its Elder.
+// Masons keep knowing each other, and the Lycan's and Cursed's traits are not powers.
+var villagerPowers = map[string]bool{
+	"Seer": true, "Apprentice Seer": true, "Aura Seer": true, "Fox": true, "Doctor": true,
+	"Guard": true, "Bodyguard": true, "Witch": true, "Hunter": true, "Cupid": true,
+	"Spellcaster": true, "Priest": true, "Mayor": true, "Prince": true, "Tough Guy": true,
+	"Diseased": true, "Scapegoat": true, "Village Idiot": true,
+}
+
+// villagePowersDisabled reports whether the game's powers_disabled flag is set.
+func villagePowersDisabled(db *sqlx.DB, gameID int64) bool {
+	var disabled bool
+	db.Get(&disabled, "SELECT powers_disabled FROM game WHERE rowid = ?", gameID)
+	return disabled
+}
+
+// powerDisabled reports whether a role has lost its power to the Elder's lynching. Custom
+// roles lose their night ability along with the built-in villager powers.
+func powerDisabled(db *sqlx.DB, gameID int64, roleName string) bool {
+	if !villagePowersDisabled(db, gameID) {
+		return false
+	}
+	return villagerPowers[roleName] || customAbility(db, roleName).Night != ""
+}
+
+// shieldElder absorbs the first werewolf attack on the Elder, or on a custom role built with a
+// shield: instead of a pending kill, the survival is recorded with an empty description until
+// dawn, like night kills. Any later attack kills them as usual.
+func (h *Hub) shieldElder(tx *sqlx.Tx, game *Game, victim int64) (bool, error) {
+	if role := getRoleName(h.db, game.ID, victim); role != "Elder" && !customAbility(h.db, role).Shield {
+		return false, nil
+	}
+	var survived int
+	h.db.Get(&survived, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round<? AND action_type=? AND target_player_id=?`,
+		game.ID, game.Round, ActionElderSurvived, victim)
+	if survived > 0 {
+		return false, nil
+	}
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+		game.ID, game.Round, victim, ActionElderSurvived, victim, VisibilityActor); err != nil {
+		return false, err
+	}
+	h.logf("Elder '%s' withstands the werewolf attack", h.playerName(victim))
+	return true, nil
+}
+
+// revealElderSurvival fills in tonight's pending survival at dawn, so only the Elder learns
+// that they were attacked.
+func revealElderSurvival(d *dawn, game *Game) error {
+	var elderIDs []int64
+	d.tx.Select(&elderIDs, `SELECT target_player_id FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND description=''`,
+		game.ID, game.Round, ActionElderSurvived)
+	for _, id := range elderIDs {
+		desc := fmt.Sprintf("Night %d: The werewolves attacked you — you withstood it, but will not survive another attack", game.Round)
+		if _, err := d.tx.Exec(`UPDATE game_action SET description=?, description_key=?, description_args=? WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND target_player_id=?`,
+			desc, "hist_elder_survived", histArgs(game.Round), game.ID, game.Round, ActionElderSurvived, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// disableVillagePowers is the village's punishment for lynching its Elder: the game's
+// powers_disabled flag is set and everyone learns why in a public history entry.
+func (h *Hub) disableVillagePowers(tx *sqlx.Tx, game *Game, elderID int64) error {
+	if _, err := tx.Exec("UPDATE game SET powers_disabled = 1 WHERE rowid = ?", game.ID); err != nil {
+		return fmt.Errorf("set powers_disabled: %w", err)
+	}
+	name := h.playerName(elderID)
+	desc := fmt.Sprintf("Day %d: The village lynched its Elder %s — every villager loses their powers", game.Round, name)
+	_, err := tx.Exec(`
+		INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, elderID, ActionElderLynched, elderID, VisibilityPublic, desc, "hist_elder_lynched", histArgs(game.Round, name))
+	if err != nil {
+		return fmt.Errorf("record lynch: %w", err)
+	}
+	h.logf("Elder '%s' was lynched — village powers are gone", name)
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Elder Tests
+// ============================================================================
+
+func TestElderSurvivesFirstWolfAttackOnly(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Elder", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleElder, RoleVillager, RoleVillager, RoleVillager})
+	wolf, elder := ids[0], ids[1]
+	target := strconv.FormatInt(elder, 10)
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: target})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+	if !ctx.isPlayerAlive(elder) {
+		t.Fatal("the Elder should withstand the first attack")
+	}
+	if h := ctx.historyFor(elder); !strings.Contains(h, "you withstood it") {
+		t.Errorf("the Elder should learn of the attack at dawn, got: %q", h)
+	}
+	if h := ctx.historyFor(ids[2]); strings.Contains(h, "withstood") {
+		t.Errorf("the survival must stay private, got: %q", h)
+	}
+
+	// the second attack kills
+	ctx.app.db.MustExec("UPDATE game SET status = 'night', round = 2")
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: target})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+	if ctx.isPlayerAlive(elder) {
+		t.Error("the Elder only withstands one attack")
+	}
+}
+
+func TestLynchingElderDisablesVillagePowers(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Elder", "Seer", "V1", "V2"},
+		[]string{RoleWerewolf, RoleElder, RoleSeer, RoleVillager, RoleVillager})
+	wolf, elder, seer, v1 := ids[0], ids[1], ids[2], ids[3]
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(elder, 10)})
+	}
+	ctx.sendWS(v1, WSMessage{Action: "day_end_vote"})
+
+	game, _ := ctx.hub().getGame()
+	if !villagePowersDisabled(ctx.app.db, game.ID) {
+		t.Fatal("lynching the Elder should set the powers_disabled flag")
+	}
+	if h := ctx.historyFor(wolf); !strings.Contains(h, "every villager loses their powers") {
+		t.Errorf("everyone should learn why, got: %q", h)
+	}
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("the day should end after the lynch, got %q", status)
+	}
+
+	ctx.sendWS(seer, WSMessage{Action: "seer_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	if n := ctx.countActions(ActionSeerSelectInvestigate); n != 0 {
+		t.Errorf("the Seer should have lost their power, got %d selections", n)
+	}
+
+	// the night no longer waits for the powerless Seer
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 1 {
+		t.Errorf("the night should resolve without the Seer, got %d pending kills", n)
+	}
+}
+
+func TestElderSurvivesAttackInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Elder survives the first werewolf attack ===")
+
+	// Setup: 1 werewolf + 1 elder + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"EL1", "EL2", "EL3", "EL4"},
+		RoleWerewolf, RoleElder, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Elder"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	elder, werewolf, villager := byRole["Elder"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+
+	werewolf.voteForPlayer(elder.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if !villager.hasNoDeathMessage() {
+		ctx.logger.LogDB("FAIL: elder died on the first attack")
+		t.Errorf("The Elder should survive the first attack, got: %s", villager.getDeathAnnouncement())
+	}
+	entry := "The werewolves attacked you — you withstood it"
+	if !elder.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: elder not told of the attack")
+		t.Errorf("Elder should see %q in history, got: %s", entry, elder.getHistoryText())
+	}
+	if villager.historyContains(entry) || werewolf.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: elder's survival visible to others")
+		t.Errorf("Only the Elder should see they withstood the attack")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type FoxNightData struct {
+	FoxLostPower      bool // an earlier sniff came up empty
+	FoxHasSniffed     bool
+	FoxFoundWolf      bool
+	FoxSelectedPlayer *Player // pending, or confirmed once sniffed
+	FoxSniffed        []Player
+	FoxResultCards    []PlayerCardData
+	FoxTargetCards    []PlayerCardData
+}
+
+// foxLostPower reports whether the Fox came up empty on a night before this round.
+func foxLostPower(db *sqlx.DB, gameID int64, round int, playerID int64) bool {
+	var n int
+	db.Get(&n, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round<? AND actor_player_id=? AND action_type=?`,
+		gameID, round, playerID, ActionFoxLostPower)
+	return n > 0
+}
+
+// foxSniffGroup returns the target and its two seat neighbors. Seats follow the order players
+// joined the game; the dead leave their seat, so the neighbors are the nearest living players
+// on either side, wrapping around the table.
+func foxSniffGroup(db *sqlx.DB, gameID, targetID int64) []Player {
+	players, _ := getPlayersByGameId(db, gameID)
+	sort.Slice(players, func(i, j int) bool { return players[i].ID < players[j].ID })
+	var seats []Player
+	idx := -1
+	for _, p := range players {
+		if !p.IsAlive || p.IsObserver {
+			continue
+		}
+		if p.PlayerID == targetID {
+			idx = len(seats)
+		}
+		seats = append(seats, p)
+	}
+	if idx < 0 {
+		return nil
+	}
+	group := []Player{seats[idx]}
+	for _, i := range []int{idx - 1 + len(seats), idx + 1} {
+		n := seats[i%len(seats)]
+		if n.PlayerID != targetID && (len(group) == 1 || group[1].PlayerID != n.PlayerID) {
+			group = append(group, n)
+		}
+	}
+	return group
+}
+
+func buildFoxNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string) FoxNightData {
+	if player.RoleName != "Fox" {
+		return FoxNightData{}
+	}
+	if foxLostPower(db, game.ID, game.Round, playerID) {
+		return FoxNightData{FoxLostPower: true}
+	}
+
+	var action GameAction
+	err := db.Get(&action, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, playerID, ActionFoxApplySniff)
+
+	if err == nil && action.TargetPlayerID != nil {
+		d := FoxNightData{
+			FoxHasSniffed:     true,
+			FoxSelectedPlayer: getVisiblePlayer(db, game.ID, *action.TargetPlayerID, player, seerInvestigated),
+		}
+		var lost int
+		db.Get(&lost, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, playerID, ActionFoxLostPower)
+		d.FoxFoundWolf = lost == 0
+		for _, p := range foxSniffGroup(db, game.ID, *action.TargetPlayerID) {
+			if v := getVisiblePlayer(db, game.ID, p.PlayerID, player, seerInvestigated); v != nil {
+				d.FoxSniffed = append(d.FoxSniffed, *v)
+			}
+		}
+		return d
+	}
+
+	var selectAction GameAction
+	if db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, playerID, ActionFoxSelectSniff) == nil && selectAction.TargetPlayerID != nil {
+		return FoxNightData{
+			FoxSelectedPlayer: getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated),
+		}
+	}
+
+	return FoxNightData{}
+}
+
+func handleWSFoxSelect(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSFoxSelect: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_investigate"))
+		return
+	}
+	fox, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSFoxSelect: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if fox.RoleName != "Fox" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_fox"))
+		return
+	}
+	if !fox.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	if powerDisabled(h.db, game.ID, fox.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+	if foxLostPower(h.db, game.ID, game.Round, client.playerID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_fox_lost_power"))
+		return
+	}
+	var existingCount int
+	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionFoxApplySniff)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_fox_done"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var existing GameAction
+	selectErr := h.db.Get(&existing, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionFoxSelectSniff)
+	if selectErr == nil && existing.TargetPlayerID != nil && *existing.TargetPlayerID == targetID {
+		// clicking the same target again deselects it
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionFoxSelectSniff)
+		h.logf("Fox '%s' deselected sniff target", fox.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionFoxSelectSniff, targetID, VisibilityActor)
+		h.logf("Fox '%s' selected sniff target %d", fox.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+func handleWSFoxSniff(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSFoxSniff: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_investigate"))
+		return
+	}
+
+	fox, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSFoxSniff: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	if fox.RoleName != "Fox" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_fox"))
+		return
+	}
+
+	if !fox.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	if powerDisabled(h.db, game.ID, fox.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+
+	if foxLostPower(h.db, game.ID, game.Round, client.playerID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_fox_lost_power"))
+		return
+	}
+
+	var existingCount int
+	h.db.Get(&existingCount, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, ActionFoxApplySniff)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_fox_done"))
+		return
+	}
+
+	var selectAction GameAction
+	if err := h.db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionFoxSelectSniff); err != nil || selectAction.TargetPlayerID == nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_fox_select_first"))
+		return
+	}
+	targetID := *selectAction.TargetPlayerID
+
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_target_not_found"))
+		return
+	}
+
+	if !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_cannot_investigate_dead"))
+		return
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionFoxSelectSniff)
+
+	foundWolf := false
+	var names []string
+	for _, p := range foxSniffGroup(h.db, game.ID, targetID) {
+		names = append(names, p.Name)
+		if seerSeesWerewolf(p) {
+			foundWolf = true
+		}
+	}
+	group := strings.Join(names, ", ")
+
+	histKey := "hist_fox_no_wolf"
+	result := "no werewolf among them, and your nose has gone cold"
+	if foundWolf {
+		histKey = "hist_fox_wolf"
+		result = "a werewolf is among them"
+	}
+	desc := fmt.Sprintf("Night %d: You sniffed around %s — %s", game.Round, group, result)
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionFoxApplySniff, targetID, VisibilityActor, desc, histKey, histArgs(game.Round, group))
+	if err != nil {
+		h.logError("handleWSFoxSniff: db.Exec insert sniff", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_investigation"))
+		return
+	}
+	if !foundWolf {
+		h.db.Exec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionFoxLostPower, VisibilityActor)
+	}
+
+	toastMsg := T(lang, "toast_fox_no_wolf", group)
+	if foundWolf {
+		toastMsg = T(lang, "toast_fox_wolf", group)
+	}
+	h.sendToPlayer(client.playerID, []byte(renderToast(h.templates, h.logf, "info", toastMsg)))
+
+	h.logf("Fox '%s' sniffed around '%s' (wolf: %v)", fox.Name, group, foundWolf)
+	DebugLog("handleWSFoxSniff", "Fox '%s' sniffed around '%s' (wolf: %v)", fox.Name, group, foundWolf)
+	LogDBState(h.db, "after fox sniff")
+
+	h.resolveWerewolfVotes(game)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Fox Helpers
+// ============================================================================
+
+// foxSniffPlayer selects a target for the Fox and clicks the Sniff button.
+func (tp *TestPlayer) foxSniffPlayer(targetName string) {
+	tp.selectAndConfirm("fox-select-form-", targetName, "#fox-sniff-button")
+}
+
+// getFoxResult returns the text of the Fox's sniff result.
+func (tp *TestPlayer) getFoxResult() string {
+	el, err := tp.p().Element("#fox-result")
+	if err != nil {
+		return ""
+	}
+	text, _ := el.Text()
+	return text
+}
+
+// ============================================================================
+// Fox Tests
+// ============================================================================
+
+func TestFoxSniffsTargetAndNeighbors(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"V1", "Wolf", "V2", "Fox", "V3"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleFox, RoleVillager})
+	v1, wolf, v2, fox := ids[0], ids[1], ids[2], ids[3]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("the night should wait for the Fox, got %d pending kills", n)
+	}
+
+	// V1 sits between V3 (wrapping around the table) and the Wolf
+	ctx.sendWS(fox, WSMessage{Action: "fox_select", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(fox, WSMessage{Action: "fox_sniff"})
+	if h := ctx.historyFor(fox); !strings.Contains(h, "V1, V3, Wolf") || !strings.Contains(h, "a werewolf is among them") {
+		t.Errorf("the Fox should smell the wolf next to V1, got: %q", h)
+	}
+	if h := ctx.historyFor(wolf); strings.Contains(h, "sniffed") {
+		t.Errorf("the sniff must stay private, wolf sees: %q", h)
+	}
+	if n := ctx.countActions(ActionFoxLostPower); n != 0 {
+		t.Errorf("a hit should keep the power, got %d lost-power rows", n)
+	}
+	if n := ctx.countActions(ActionNightApplyKill); n != 1 {
+		t.Errorf("the night should resolve once the Fox has sniffed, got %d pending kills", n)
+	}
+}
+
+func TestFoxLosesPowerOnMiss(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "V1", "V2", "V3", "Fox", "V4"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleFox, RoleVillager})
+	v2, fox := ids[2], ids[4]
+
+	ctx.sendWS(fox, WSMessage{Action: "fox_select", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	ctx.sendWS(fox, WSMessage{Action: "fox_sniff"})
+	if h := ctx.historyFor(fox); !strings.Contains(h, "no werewolf among them") {
+		t.Errorf("the Fox should find no wolf around V2, got: %q", h)
+	}
+
+	game, _ := ctx.hub().getGame()
+	player, _ := getPlayerInGame(ctx.app.db, game.ID, fox)
+	if !playerDoneWithNightAction(ctx.app.db, game.ID, game.Round+1, player) {
+		t.Error("a Fox without power should have nothing to do on later nights")
+	}
+	if !foxLostPower(ctx.app.db, game.ID, game.Round+1, fox) {
+		t.Error("the miss should cost the Fox their power from the next night on")
+	}
+}
+
+func TestFoxSniffsWolfInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing Fox sniffs out a werewolf from the night panel ===")
+
+	// Setup: 1 werewolf + 1 fox + 1 villager = 3 players; the villager's neighbors are the
+	// other two, so the werewolf is always among them
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"FX1", "FX2", "FX3"},
+		RoleWerewolf, RoleFox, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Fox"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	fox, villager := byRole["Fox"][0], byRole["Villager"][0]
+	ctx.logger.Debug("Fox: %s, sniffing around: %s", fox.Name, villager.Name)
+
+	fox.foxSniffPlayer(villager.Name)
+
+	if result := fox.getFoxResult(); !strings.Contains(result, "A werewolf is among them") {
+		ctx.logger.LogDB("FAIL: fox result missing")
+		t.Errorf("Fox should be told a werewolf is among them, got: %s", result)
+	}
+	if !fox.historyContains("a werewolf is among them") {
+		ctx.logger.LogDB("FAIL: fox cannot see the sniff in history")
+		t.Errorf("Fox should see the sniff in history, got: %s", fox.getHistoryText())
+	}
+	if villager.historyContains("You sniffed around") {
+		ctx.logger.LogDB("FAIL: villager can see the fox's sniff in history")
+		t.Errorf("Only the Fox should see the sniff in history")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -95,6 +95,10 @@ func handleWSGuardSelect(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
 		return
 	}
+	if powerDisabled(h.db, game.ID, guard.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
 	var existingCount int
 	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
 		game.ID, game.Round, client.playerID, ActionGuardApplyProtect)
@@ -170,6 +174,11 @@ func handleWSGuardProtect(client *Client, msg WSMessage) {
 		return
 	}
 
+	if powerDisabled(h.db, game.ID, guard.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+
 	var existingCount int
 	h.db.Get(&existingCount, `
 SELECT COUNT(*) FROM game_action
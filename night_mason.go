@@ -1,5 +1,10 @@
 package main
 
+import (
+	"fmt"
+	"strings"
+)
+
 type MasonNightData struct {
 	Masons     []Player // other alive Masons, excluding self
 	MasonCards []PlayerCardData
@@ -18,3 +23,30 @@ func buildMasonNightData(player Player, players []Player) MasonNightData {
 	}
 	return d
 }
+
+// recordMasonReveal writes down who the Masons are when night 1 begins, so the Masons keep
+// it in their history after the night. It is one team:mason action; a lone Mason gets none.
+func (h *Hub) recordMasonReveal(gameID int64) {
+	players, err := getPlayersByGameId(h.db, gameID)
+	if err != nil {
+		h.logError("recordMasonReveal: getPlayersByGameId", err)
+		return
+	}
+	var masons []Player
+	var names []string
+	for _, p := range players {
+		if p.RoleName == "Mason" && p.IsAlive {
+			masons = append(masons, p)
+			names = append(names, p.Name)
+		}
+	}
+	if len(masons) < 2 {
+		return
+	}
+	group := strings.Join(names, ", ")
+	_, err = h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description, description_key, description_args) VALUES (?, 1, 'night', ?, ?, ?, ?, ?, ?)`,
+		gameID, masons[0].PlayerID, ActionMasonsRevealed, VisibilityTeamMason, fmt.Sprintf("Night 1: The Masons are %s", group), "hist_masons_revealed", histArgs(group))
+	if err != nil {
+		h.logError("recordMasonReveal: insert", err)
+	}
+}
@@ -92,11 +92,21 @@ func TestMasonsKnowEachOther(t *testing.T) {
 		t.Errorf("Mason '%s' should see fellow mason '%s' in the list", mason2.Name, mason1.Name)
 	}
 
+	// Both keep it in their history
+	for _, m := range masons {
+		if !m.historyContains("The Masons are") {
+			t.Errorf("Mason '%s' should find the Masons in the history, got %q", m.Name, m.getHistoryText())
+		}
+	}
+
 	// A regular villager should NOT see mason list
 	if len(villagers) > 0 {
 		if villagers[0].canSeeMasonList() {
 			t.Errorf("Villager '%s' should not see mason list", villagers[0].Name)
 		}
+		if villagers[0].historyContains("The Masons are") {
+			t.Errorf("Villager '%s' should not find the Masons in the history", villagers[0].Name)
+		}
 	}
 
 	ctx.logger.Debug("=== Test passed ===")
@@ -148,3 +158,26 @@ func TestSingleMasonSeesNoOthers(t *testing.T) {
 
 	ctx.logger.Debug("=== Test passed ===")
 }
+
+func TestMasonsRevealedInHistory(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"M1", "M2", "V", "W"},
+		[]string{RoleMason, RoleMason, RoleVillager, RoleWerewolf})
+	game, _ := ctx.hub().getGame()
+	ctx.hub().recordMasonReveal(game.ID)
+
+	for _, id := range ids[:2] {
+		if h := ctx.historyFor(id); !strings.Contains(h, "The Masons are M1, M2") {
+			t.Errorf("every Mason should see who the Masons are, got %q", h)
+		}
+	}
+	for _, id := range ids[2:] {
+		if h := ctx.historyFor(id); strings.Contains(h, "Masons") {
+			t.Errorf("only the Masons should see who they are, got %q", h)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+type MinionNightData struct {
+	MinionWolves    []Player // living pack members; the wolves do not know the Minion
+	MinionWolfCards []PlayerCardData
+}
+
+func buildMinionNightData(player Player, players []Player) MinionNightData {
+	if player.RoleName != "Minion" {
+		return MinionNightData{}
+	}
+
+	d := MinionNightData{}
+	for _, p := range players {
+		if inWolfPack(p) && p.IsAlive {
+			d.MinionWolves = append(d.MinionWolves, p)
+		}
+	}
+	return d
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Minion Helpers
+// ============================================================================
+
+// canSeeInMinionList reports whether the Minion's werewolf list shows the named player.
+func (tp *TestPlayer) canSeeInMinionList(name string) bool {
+	found, _, err := tp.p().Has("#minion-card-list .player-card[player-name='" + name + "']")
+	return err == nil && found
+}
+
+// ============================================================================
+// Minion Tests
+// ============================================================================
+
+func TestMinionKnowsWolvesButNotViceVersa(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Minion", "Wolf", "V1", "V2"},
+		[]string{RoleMinion, RoleWerewolf, RoleVillager, RoleVillager})
+	minionID, wolfID := ids[0], ids[1]
+
+	game, _ := ctx.hub().getGame()
+	buf, err := getGameComponent(ctx.hub(), minionID, game, "en")
+	if err != nil {
+		t.Fatalf("render night for minion: %v", err)
+	}
+	if !strings.Contains(buf.String(), `id="minion-card-list"`) || !strings.Contains(buf.String(), `player-name="Wolf"`) {
+		t.Errorf("Minion should see the wolf in the minion list")
+	}
+
+	wolf, _ := getPlayerInGame(ctx.app.db, game.ID, wolfID)
+	minion, _ := getPlayerInGame(ctx.app.db, game.ID, minionID)
+	if seen := applyCardVisibility(wolf, []Player{minion}, nil, RevealFull)[0]; seen.Team != "unknown" {
+		t.Errorf("wolves must not recognise the Minion, saw team %q", seen.Team)
+	}
+
+	ctx.sendWS(minionID, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(ids[2], 10)})
+	if n := ctx.countActions(ActionWerewolfSelectKill); n != 0 {
+		t.Errorf("Minion must not vote on the night kill, got %d votes", n)
+	}
+}
+
+func TestMinionDoesNotCountAsWolf(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 2,
+		[]string{"Minion", "Wolf", "V1", "V2"},
+		[]string{RoleMinion, RoleWerewolf, RoleVillager, RoleVillager})
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE player_id = ?", ids[1])
+
+	game, _ := ctx.hub().getGame()
+	ctx.hub().checkWinConditions(game)
+	if _, _, winner := ctx.gameState(); winner != "villagers" {
+		t.Errorf("villagers should win once the last wolf dies, got %q", winner)
+	}
+	if !playerWon("werewolves", "werewolf", true) {
+		t.Errorf("Minion is on the werewolf team and should win with the wolves")
+	}
+}
+
+func TestMinionSeesWolvesInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing Minion sees the werewolves, who do not see the Minion ===")
+
+	// Setup: 1 werewolf + 1 minion + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"MI1", "MI2", "MI3", "MI4"},
+		RoleWerewolf, RoleMinion, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Minion"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	minion, werewolf, villager := byRole["Minion"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+
+	if !minion.canSeeInMinionList(werewolf.Name) {
+		ctx.logger.LogDB("FAIL: minion cannot see the werewolf")
+		t.Errorf("Minion should see %s among the werewolves", werewolf.Name)
+	}
+	if minion.canSeeInMinionList(villager.Name) {
+		ctx.logger.LogDB("FAIL: minion sees a villager as werewolf")
+		t.Errorf("Minion should not see %s among the werewolves", villager.Name)
+	}
+	if minion.canSeeWerewolfVotes() {
+		ctx.logger.LogDB("FAIL: minion can vote with the pack")
+		t.Errorf("Minion should not hunt with the pack")
+	}
+	if found, _, _ := werewolf.p().Has("#minion-card-list"); found {
+		ctx.logger.LogDB("FAIL: werewolf sees the minion list")
+		t.Errorf("Werewolf should not see the Minion's list")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,440 @@
+package main
+
+import "github.com/jmoiron/sqlx"
+
+// nightStep is one stage the night waits on. ready reports whether everyone the step waits
+// for has acted, logging who is still missing when not.
+type nightStep struct {
+	name  string
+	ready func(h *Hub, game *Game) bool
+}
+
+// nightSteps are checked in order each time a night action comes in; the night only resolves
+// once every step is ready. A new night role slots in here — usually as an everyoneActed or
+// eachPlayerDone step — instead of in resolveWerewolfVotes.
+var nightSteps = []nightStep{
+	{"Cupid", cupidLinked},
+	{"Doppelganger", doppelgangersCopied},
+	{"Wild Child", wildChildrenChose},
+	{"Guard", everyoneActed("Guard", ActionGuardApplyProtect)},
+	{"Bodyguard", everyoneActed("Bodyguard", ActionBodyguardApplyGuard)},
+	{"Seer", everyoneActed("Seer", ActionSeerApplyInvestigate)},
+	{"Aura Seer", everyoneActed("Aura Seer", ActionAuraSeerApplyInvestigate)},
+	{"Fox", eachPlayerDone("r.name = 'Fox'")},
+	{"Sorceress", everyoneActed("Sorceress", ActionSorceressApplyInvestigate)},
+	{"Spellcaster", everyoneActed("Spellcaster", ActionSpellcasterApplySilence)},
+	{"Wolves", wolvesVoted},
+	{"White Werewolf", eachPlayerDone("r.name = 'White Werewolf'")},
+	{"Serial Killer", everyoneActed("Serial Killer", ActionSerialKillerApplyKill)},
+	{"Piper", everyoneActed("Piper", ActionPiperApplyCharm)},
+	{"Witch", everyoneActed("Witch", ActionWitchApply)},
+	{"Doctor", everyoneActed("Doctor", ActionDoctorApplyProtect)},
+	{"Custom roles", eachPlayerDone("r.ability != ''")},
+}
+
+// skippedTonightSQL leaves out players whose night action was skipped, by the night timer or
+// because they were away (night_afk.go), so steps counting a role's players do not wait on
+// them. It is a condition on game_player alias g and binds the round.
+const skippedTonightSQL = `g.player_id NOT IN (
+SELECT actor_player_id FROM game_action
+WHERE game_id = g.game_id AND round = ? AND phase = 'night' AND action_type = '` + ActionNightTimedOut + `')`
+
+// nightOutcome is what the wolves decided, handed from the tally to the resolvers.
+type nightOutcome struct {
+	victim     int64 // 0 = no kill (passed, no majority, or a sick pack)
+	victim2    int64 // the Wolf Cub's revenge kill, 0 = none
+	doubleKill bool
+}
+
+// nightResolvers queue tonight's pending kills, in order, once every step is ready. They write
+// through the night's resolution transaction; the kills themselves are applied at dawn, after
+// the survey.
+var nightResolvers = []func(h *Hub, tx *sqlx.Tx, game *Game, night *nightOutcome) error{
+	resolveIndependentKills,
+	resolveWolfAttack,
+	resolveWolfCubRevenge,
+	resolveWitchPoison,
+}
+
+// resolveWerewolfVotes is called after every night action: it waits until all nightSteps are
+// ready, then tallies the pack's vote and runs the nightResolvers.
+func (h *Hub) resolveWerewolfVotes(game *Game) {
+	if !nightReady(h, game) {
+		h.triggerBroadcast()
+		return
+	}
+	h.resolveNight(game)
+}
+
+// nightReady reports whether every nightStep is ready.
+func nightReady(h *Hub, game *Game) bool {
+	for _, step := range nightSteps {
+		if !step.ready(h, game) {
+			DebugLog("nightReady", "Night %d waits on step %s", game.Round, step.name)
+			return false
+		}
+	}
+	return true
+}
+
+// resolveNight tallies the pack's vote and queues tonight's kills. The night timer calls it
+// directly when time runs out before every step is ready. The kills are queued together or
+// not at all, and only while the night is still on.
+func (h *Hub) resolveNight(game *Game) {
+	night := h.tallyWolfVotes(game)
+	tx, ok := h.beginResolution(game)
+	if !ok {
+		return
+	}
+	defer tx.Rollback()
+	for _, resolve := range nightResolvers {
+		if err := resolve(h, tx, game, &night); err != nil {
+			h.logError("resolveNight: queue kills", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		h.logError("resolveNight: commit", err)
+		return
+	}
+
+	h.logf("Night %d: kills pending, waiting for surveys", game.Round)
+	LogDBState(h.db, "after pending night kills recorded")
+	h.triggerBroadcast()
+}
+
+// everyoneActed is the common step: every living player with the role has recorded actionType
+// tonight. Villager powers are not waited for once the village has lynched its Elder.
+func everyoneActed(role, actionType string) func(h *Hub, game *Game) bool {
+	return func(h *Hub, game *Game) bool {
+		if powerDisabled(h.db, game.ID, role) {
+			return true
+		}
+		var alive int
+		h.db.Get(&alive, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = ? AND `+skippedTonightSQL, game.ID, role, game.Round)
+		if alive == 0 {
+			return true
+		}
+		var acted int
+		h.db.Get(&acted, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
+			game.ID, game.Round, actionType)
+		if acted < alive {
+			h.logf("Waiting for %s to act (%d/%d)", role, acted, alive)
+			return false
+		}
+		return true
+	}
+}
+
+// eachPlayerDone asks playerDoneWithNightAction about every living player whose role matches
+// roleSQL (a condition on role alias r), for roles whose duty varies by player or night.
+func eachPlayerDone(roleSQL string) func(h *Hub, game *Game) bool {
+	return func(h *Hub, game *Game) bool {
+		var players []Player
+		h.db.Select(&players, `
+SELECT g.player_id, r.name as role_name FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND `+roleSQL, game.ID)
+		for _, p := range players {
+			if !playerDoneWithNightAction(h.db, game.ID, game.Round, p) {
+				h.logf("Waiting for the %s (player ID %d) to act", p.RoleName, p.PlayerID)
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func cupidLinked(h *Hub, game *Game) bool {
+	if game.Round != 1 {
+		return true
+	}
+	var aliveCupidCount int
+	h.db.Get(&aliveCupidCount, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Cupid' AND `+skippedTonightSQL, game.ID, game.Round)
+	if aliveCupidCount == 0 {
+		return true
+	}
+	var loverCount int
+	h.db.Get(&loverCount, `SELECT COUNT(*) FROM game_lovers WHERE game_id = ?`, game.ID)
+	if loverCount == 0 {
+		h.logf("Waiting for Cupid to link lovers")
+		return false
+	}
+	return true
+}
+
+// doppelgangersCopied waits for every Doppelganger's role model, like wildChildrenChose.
+func doppelgangersCopied(h *Hub, game *Game) bool {
+	if game.Round != 1 {
+		return true
+	}
+	var aliveDoppelgangerCount int
+	h.db.Get(&aliveDoppelgangerCount, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Doppelganger'
+AND g.player_id NOT IN (SELECT child_player_id FROM game_role_model WHERE game_id = ?)
+AND `+skippedTonightSQL, game.ID, game.ID, game.Round)
+	if aliveDoppelgangerCount > 0 {
+		h.logf("Waiting for Doppelganger(s) to copy (%d remaining)", aliveDoppelgangerCount)
+		return false
+	}
+	return true
+}
+
+func wildChildrenChose(h *Hub, game *Game) bool {
+	if game.Round != 1 {
+		return true
+	}
+	var pendingWildChildCount int
+	h.db.Get(&pendingWildChildCount, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Wild Child'
+AND g.player_id NOT IN (SELECT child_player_id FROM game_role_model WHERE game_id = ?)
+AND `+skippedTonightSQL, game.ID, game.ID, game.Round)
+	if pendingWildChildCount > 0 {
+		h.logf("Waiting for Wild Child(ren) to choose a role model (%d remaining)", pendingWildChildCount)
+		return false
+	}
+	return true
+}
+
+// wolvesVoted waits for every wolf's vote and the End Vote, and for the second round of both
+// on the night after the Wolf Cub died. A sick pack has nothing to vote on; wolves who were
+// skipped tonight are not waited for, and a pack that is entirely skipped has no vote.
+func wolvesVoted(h *Hub, game *Game) bool {
+	if wolvesSkipNight(h.db, game.ID, game.Round) {
+		h.logf("Werewolves are sick tonight — skipping the hunt")
+		return true
+	}
+
+	var wolfCount int
+	h.db.Get(&wolfCount, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND `+wolfPackSQL+` AND `+skippedTonightSQL, game.ID, game.Round)
+	if wolfCount == 0 {
+		return true
+	}
+
+	stages := []struct{ vote, end, label string }{{ActionWerewolfSelectKill, ActionWerewolfApplyKill, "first"}}
+	if game.Round > 1 && wolfCubDiedLastRound(h.db, game.ID, game.Round) {
+		stages = append(stages, struct{ vote, end, label string }{ActionWerewolfSelectKill2, ActionWerewolfApplyKill2, "Wolf Cub double kill"})
+	}
+	for _, s := range stages {
+		var votes, ended int
+		h.db.Get(&votes, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
+			game.ID, game.Round, s.vote)
+		if votes < wolfCount {
+			h.logf("Not all werewolves have voted yet (%s vote, %d/%d)", s.label, votes, wolfCount)
+			return false
+		}
+		h.db.Get(&ended, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
+			game.ID, game.Round, s.end)
+		if ended == 0 {
+			h.logf("Werewolves have all voted but End Vote not pressed yet (%s vote)", s.label)
+			return false
+		}
+	}
+	return true
+}
+
+// majorityTarget returns the target holding a strict majority of the pack's votes of the given
+// type, or 0. Passes (NULL targets) count towards the pack size but not towards any target.
+func majorityTarget(db *sqlx.DB, game *Game, voteType string) int64 {
+	var wolfCount int
+	db.Get(&wolfCount, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND `+wolfPackSQL, game.ID)
+
+	var targets []int64
+	db.Select(&targets, `
+SELECT target_player_id FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ? AND target_player_id IS NOT NULL`,
+		game.ID, game.Round, voteType)
+	voteCounts := make(map[int64]int)
+	for _, t := range targets {
+		voteCounts[t]++
+	}
+
+	var maxVotes int
+	var victim int64
+	for targetID, count := range voteCounts {
+		if count > maxVotes {
+			maxVotes = count
+			victim = targetID
+		}
+	}
+	if maxVotes < wolfCount/2+1 {
+		return 0
+	}
+	return victim
+}
+
+// wolfTargetsLocked reports whether the pack has locked in tonight's targets with End Vote
+// (both rounds of it on the night after the Wolf Cub died). This is the first stage of the
+// night: from here on the targets are published to the roles that may save them, while the
+// night itself resolves only once every step is ready. A sick pack, or one whose every wolf
+// was skipped tonight, has nothing to lock.
+func wolfTargetsLocked(db *sqlx.DB, game *Game) bool {
+	if wolvesSkipNight(db, game.ID, game.Round) {
+		return true
+	}
+	var activeWolves int
+	db.Get(&activeWolves, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND `+wolfPackSQL+` AND `+skippedTonightSQL, game.ID, game.Round)
+	if activeWolves == 0 {
+		return true
+	}
+	endTypes := []string{ActionWerewolfApplyKill}
+	if game.Round > 1 && wolfCubDiedLastRound(db, game.ID, game.Round) {
+		endTypes = append(endTypes, ActionWerewolfApplyKill2)
+	}
+	for _, t := range endTypes {
+		var ended int
+		db.Get(&ended, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
+			game.ID, game.Round, t)
+		if ended == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// lockedWolfTargets returns the pack's locked targets (0 = none) for the saving roles to see.
+func lockedWolfTargets(db *sqlx.DB, game *Game) (victim, victim2 int64) {
+	if wolvesSkipNight(db, game.ID, game.Round) || !wolfTargetsLocked(db, game) {
+		return 0, 0
+	}
+	victim = majorityTarget(db, game, ActionWerewolfSelectKill)
+	if game.Round > 1 && wolfCubDiedLastRound(db, game.ID, game.Round) {
+		victim2 = majorityTarget(db, game, ActionWerewolfSelectKill2)
+	}
+	return victim, victim2
+}
+
+func (h *Hub) tallyWolfVotes(game *Game) nightOutcome {
+	if wolvesSkipNight(h.db, game.ID, game.Round) {
+		return nightOutcome{}
+	}
+	night := nightOutcome{victim: majorityTarget(h.db, game, ActionWerewolfSelectKill)}
+	if game.Round > 1 && wolfCubDiedLastRound(h.db, game.ID, game.Round) {
+		night.doubleKill = true
+		night.victim2 = majorityTarget(h.db, game, ActionWerewolfSelectKill2)
+	}
+	if night.victim == 0 {
+		h.logf("No majority for the werewolf kill tonight")
+	}
+	return night
+}
+
+// protectedTonight reports whether the Doctor, Guard, Witch or a custom protector shields the
+// player from tonight's kills.
+func protectedTonight(h *Hub, game *Game, playerID int64) bool {
+	var protectCount int
+	h.db.Get(&protectCount, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night'
+AND action_type IN (?, ?, ?, ?) AND target_player_id = ?`,
+		game.ID, game.Round, ActionDoctorApplyProtect, ActionGuardApplyProtect, ActionWitchApplyProtect, ActionCustomApplyProtect, playerID)
+	return protectCount > 0
+}
+
+// queuePendingKill records a kill to be announced at dawn.
+func queuePendingKill(tx *sqlx.Tx, game *Game, playerID int64) error {
+	_, err := tx.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+		game.ID, game.Round, playerID, ActionNightApplyKill, playerID, VisibilityPublic)
+	return err
+}
+
+// resolveIndependentKills: the Serial Killer's and White Werewolf's victims die whatever the
+// pack decided.
+func resolveIndependentKills(h *Hub, tx *sqlx.Tx, game *Game, _ *nightOutcome) error {
+	if err := h.queueIndependentKills(tx, game, ActionSerialKillerApplyKill, "Serial Killer"); err != nil {
+		return err
+	}
+	return h.queueIndependentKills(tx, game, ActionWhiteWolfApplyKill, "White Werewolf")
+}
+
+// resolveWolfAttack runs the pack's victim through protection and then the chain of roles that
+// answer a wolf attack: Bodyguard, Alpha bite, Elder, Tough Guy, Cursed. Whatever is left is a
+// pending kill. night.victim ends up as whoever the attack landed on.
+func resolveWolfAttack(h *Hub, tx *sqlx.Tx, game *Game, night *nightOutcome) error {
+	if night.victim == 0 {
+		h.logf("No werewolf kill this night (wolves passed, no majority or sick)")
+		return nil
+	}
+	victimName := h.playerName(night.victim)
+	if protectedTonight(h, game, night.victim) {
+		h.logf("Protection saved %s (player ID %d) from werewolf attack", victimName, night.victim)
+		night.victim = 0
+		return nil
+	}
+
+	// the Bodyguard only steps in when nobody else saved the victim; the attack then lands on them
+	if bodyguardID := h.bodyguardFor(game, night.victim); bodyguardID != 0 {
+		h.logf("Bodyguard (player ID %d) takes the attack meant for %s", bodyguardID, victimName)
+		night.victim = bodyguardID
+		victimName = h.playerName(night.victim)
+	}
+	victim := night.victim
+	if alphaID := h.alphaBiter(game, victim); alphaID != 0 {
+		h.logf("Alpha bite pending: %s (player ID %d) will join the pack", victimName, victim)
+		_, err := tx.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, alphaID, ActionAlphaApplyBite, victim, VisibilityTeamWerewolf)
+		return err
+	}
+	// the Elder, the Tough Guy and the Cursed each answer the attack in their own way
+	for _, absorb := range []func(*sqlx.Tx, *Game, int64) (bool, error){h.shieldElder, h.woundToughGuy, h.curseVictim} {
+		if absorbed, err := absorb(tx, game, victim); err != nil || absorbed {
+			return err
+		}
+	}
+	h.logf("Werewolf kill pending: %s (player ID %d)", victimName, victim)
+	DebugLog("resolveWerewolfVotes", "Werewolf kill pending: '%s', waiting for surveys", victimName)
+	if err := h.markDiseasedKill(tx, game, victim); err != nil {
+		return err
+	}
+	return queuePendingKill(tx, game, victim)
+}
+
+// resolveWolfCubRevenge: the second victim is only checked for protection; Bodyguard, Elder
+// and the like answer the main attack alone.
+func resolveWolfCubRevenge(h *Hub, tx *sqlx.Tx, game *Game, night *nightOutcome) error {
+	if !night.doubleKill || night.victim2 == 0 || night.victim2 == night.victim {
+		return nil
+	}
+	name := h.playerName(night.victim2)
+	if protectedTonight(h, game, night.victim2) {
+		h.logf("Protection saved %s (player ID %d) from Wolf Cub double kill", name, night.victim2)
+		return nil
+	}
+	h.logf("Wolf Cub double kill pending: %s (player ID %d)", name, night.victim2)
+	if err := h.markDiseasedKill(tx, game, night.victim2); err != nil {
+		return err
+	}
+	return queuePendingKill(tx, game, night.victim2)
+}
+
+// resolveWitchPoison: the poison ignores every protection.
+func resolveWitchPoison(h *Hub, tx *sqlx.Tx, game *Game, _ *nightOutcome) error {
+	var poisonID int64
+	if err := h.db.Get(&poisonID, `SELECT target_player_id FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ? AND target_player_id IS NOT NULL`,
+		game.ID, game.Round, ActionWitchApplyKill); err != nil {
+		return nil
+	}
+	h.logf("Witch poison pending: %s (player ID %d)", h.playerName(poisonID), poisonID)
+	return queuePendingKill(tx, game, poisonID)
+}
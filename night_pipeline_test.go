@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// ============================================================================
+// Night Pipeline Tests
+// ============================================================================
+
+func TestNightWaitsForEveryStep(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Seer", "Doc", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSeer, RoleDoctor, RoleVillager, RoleVillager})
+	wolf, seer, doc, v1 := ids[0], ids[1], ids[2], ids[3]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	ctx.sendWS(seer, WSMessage{Action: "seer_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(seer, WSMessage{Action: "seer_investigate"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("the night should still wait for the Doctor, got %d pending kills", n)
+	}
+
+	ctx.sendWS(doc, WSMessage{Action: "doctor_select", TargetPlayerID: strconv.FormatInt(ids[4], 10)})
+	ctx.sendWS(doc, WSMessage{Action: "doctor_protect"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 1 {
+		t.Errorf("once every step is ready the wolf kill should be pending, got %d", n)
+	}
+}
+
+func TestNightResolversKeepProtectionPerVictim(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Witch", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleWitch, RoleVillager, RoleVillager, RoleVillager})
+	wolf, witch, v1, v2 := ids[0], ids[1], ids[2], ids[3]
+	game, _ := ctx.hub().getGame()
+
+	// the Witch heals the wolves' victim and poisons someone else on the same night
+	ctx.app.db.MustExec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, 1, 'night', ?, ?, ?, ?, ''), (?, 1, 'night', ?, ?, ?, ?, '')`,
+		game.ID, witch, ActionWitchApplyProtect, v1, VisibilityActor,
+		game.ID, witch, ActionWitchApplyKill, v2, VisibilityActor)
+	ctx.app.db.MustExec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description) VALUES (?, 1, 'night', ?, ?, ?, '')`,
+		game.ID, witch, ActionWitchApply, VisibilityActor)
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+	if !ctx.isPlayerAlive(v1) {
+		t.Error("the healed victim should survive")
+	}
+	if ctx.isPlayerAlive(v2) {
+		t.Error("the poison should still land when the wolf kill is healed")
+	}
+}
+
+func TestStaleNightResolutionIsSkipped(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	wolf, v1 := ids[0], ids[1]
+	stale, _ := ctx.hub().getGame()
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+
+	// two dawns of the same night, as a last survey and the night timer running out together
+	var wg sync.WaitGroup
+	for range 2 {
+		night := *stale
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h := ctx.hub()
+			h.moveMu.Lock()
+			defer h.moveMu.Unlock()
+			h.applyDawn(&night)
+		}()
+	}
+	wg.Wait()
+	if status, round, _ := ctx.gameState(); status != "day" || round != 1 || ctx.isPlayerAlive(v1) {
+		t.Fatalf("V1 should be found dead on day 1, got %q round %d", status, round)
+	}
+	var found int
+	ctx.app.db.Get(&found, "SELECT COUNT(*) FROM game_action WHERE action_type = ? AND description_key = 'hist_found_dead'", ActionNightApplyKill)
+	if found != 1 {
+		t.Errorf("the kill should be applied once, got %d", found)
+	}
+
+	// a resolution that read the game before the dawn finds the night over and queues nothing
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 1 WHERE player_id = ?", v1)
+	ctx.app.db.MustExec("DELETE FROM game_action WHERE action_type = ?", ActionNightApplyKill)
+	ctx.hub().resolveNight(stale)
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Errorf("a stale night resolution should queue no kills, got %d", n)
+	}
+}
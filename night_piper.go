@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type PiperNightData struct {
+	PiperHasCharmed    bool
+	PiperChosen1Player *Player
+	PiperChosen2Player *Player
+	PiperNeeded        int      // two, or fewer once only a few uncharmed players remain
+	PiperCharmed       []Player // everyone charmed so far, alive or dead
+	PiperTargetCards   []PlayerCardData
+	PiperCharmedCards  []PlayerCardData
+}
+
+func buildPiperNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string) PiperNightData {
+	if player.RoleName != "Piper" {
+		return PiperNightData{}
+	}
+
+	d := PiperNightData{PiperNeeded: piperCharmsNeeded(db, game.ID, playerID)}
+	var charmedIDs []int64
+	db.Select(&charmedIDs, `SELECT player_id FROM game_charmed WHERE game_id = ? ORDER BY rowid`, game.ID)
+	for _, id := range charmedIDs {
+		if p := getVisiblePlayer(db, game.ID, id, player, seerInvestigated); p != nil {
+			d.PiperCharmed = append(d.PiperCharmed, *p)
+		}
+	}
+
+	var applyCount int
+	db.Get(&applyCount, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, playerID, ActionPiperApplyCharm)
+	if applyCount > 0 {
+		d.PiperHasCharmed = true
+		return d
+	}
+
+	var chosen1ID, chosen2ID int64
+	db.Get(&chosen1ID, `SELECT COALESCE(target_player_id, 0) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, playerID, ActionPiperSelectCharm1)
+	db.Get(&chosen2ID, `SELECT COALESCE(target_player_id, 0) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, playerID, ActionPiperSelectCharm2)
+	if chosen1ID != 0 {
+		d.PiperChosen1Player = getVisiblePlayer(db, game.ID, chosen1ID, player, seerInvestigated)
+	}
+	if chosen2ID != 0 {
+		d.PiperChosen2Player = getVisiblePlayer(db, game.ID, chosen2ID, player, seerInvestigated)
+	}
+	return d
+}
+
+// charmedPlayers returns every player the Piper has charmed in this game, alive or dead.
+func charmedPlayers(db *sqlx.DB, gameID int64) map[int64]bool {
+	var ids []int64
+	db.Select(&ids, `SELECT player_id FROM game_charmed WHERE game_id = ?`, gameID)
+	charmed := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		charmed[id] = true
+	}
+	return charmed
+}
+
+// piperCharmsNeeded is how many players the Piper must pick tonight: two, unless fewer
+// living players other than the Piper are still uncharmed.
+func piperCharmsNeeded(db *sqlx.DB, gameID, piperID int64) int {
+	var uncharmed int
+	db.Get(&uncharmed, `
+SELECT COUNT(*) FROM game_player
+WHERE game_id = ? AND is_alive = 1 AND player_id != ?
+AND player_id NOT IN (SELECT player_id FROM game_charmed WHERE game_id = ?)`,
+		gameID, piperID, gameID)
+	return min(uncharmed, 2)
+}
+
+// piperWins reports whether a living Piper has charmed every other living player.
+func piperWins(db *sqlx.DB, gameID int64) bool {
+	var pipers, uncharmed int
+	db.Get(&pipers, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name = 'Piper'`, gameID)
+	if pipers == 0 {
+		return false
+	}
+	db.Get(&uncharmed, `
+SELECT COUNT(*) FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND r.name != 'Piper'
+AND g.player_id NOT IN (SELECT player_id FROM game_charmed WHERE game_id = ?)`, gameID, gameID)
+	return uncharmed == 0
+}
+
+// revealPiperCharms tells tonight's newly charmed players at dawn. The charm itself is
+// recorded when the Piper plays, so the win check at dawn already counts it.
+func (h *Hub) revealPiperCharms(d *dawn, game *Game) error {
+	var charmedIDs []int64
+	d.tx.Select(&charmedIDs, `SELECT actor_player_id FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND description=''`,
+		game.ID, game.Round, ActionPiperCharmed)
+	for _, id := range charmedIDs {
+		desc := fmt.Sprintf("Night %d: The Piper's tune has charmed you", game.Round)
+		if _, err := d.tx.Exec(`UPDATE game_action SET description=?, description_key=?, description_args=? WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND actor_player_id=?`,
+			desc, "hist_piper_charmed", histArgs(game.Round), game.ID, game.Round, ActionPiperCharmed, id); err != nil {
+			return err
+		}
+		d.afterCommit(func() {
+			h.sendToPlayer(id, []byte(renderToast(h.templates, h.logf, "info", T(h.getPlayerLang(id), "toast_piper_charmed"))))
+		})
+	}
+	return nil
+}
+
+func handleWSPiperChoose(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSPiperChoose: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+	piper, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSPiperChoose: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if piper.RoleName != "Piper" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_piper"))
+		return
+	}
+	if !piper.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	var existingCount int
+	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionPiperApplyCharm)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_piper_already_charmed"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive || targetID == client.playerID || charmedPlayers(h.db, game.ID)[targetID] {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var slot1ID, slot2ID int64
+	h.db.Get(&slot1ID, `SELECT COALESCE(target_player_id, 0) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionPiperSelectCharm1)
+	h.db.Get(&slot2ID, `SELECT COALESCE(target_player_id, 0) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionPiperSelectCharm2)
+
+	// clicking a chosen player again deselects them
+	if targetID == slot1ID || targetID == slot2ID {
+		slot := ActionPiperSelectCharm1
+		if targetID == slot2ID {
+			slot = ActionPiperSelectCharm2
+		}
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, slot)
+		h.logf("Piper '%s' unselected '%s'", piper.Name, target.Name)
+		h.triggerBroadcast()
+		return
+	}
+
+	// fill the first free slot; with both taken, the second pick is replaced
+	fillType := ActionPiperSelectCharm2
+	if slot1ID == 0 {
+		fillType = ActionPiperSelectCharm1
+	}
+	_, err = h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+		game.ID, game.Round, client.playerID, fillType, targetID, VisibilityActor)
+	if err != nil {
+		h.logError("handleWSPiperChoose: insert", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_choice"))
+		return
+	}
+
+	h.logf("Piper '%s' chose '%s' (slot: %s)", piper.Name, target.Name, fillType)
+	h.triggerBroadcast()
+}
+
+func handleWSPiperCharm(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSPiperCharm: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+
+	piper, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSPiperCharm: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	if piper.RoleName != "Piper" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_piper"))
+		return
+	}
+
+	if !piper.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	var existingCount int
+	h.db.Get(&existingCount, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, ActionPiperApplyCharm)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_piper_already_charmed"))
+		return
+	}
+
+	var chosenIDs []int64
+	h.db.Select(&chosenIDs, `
+SELECT target_player_id FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type IN (?, ?) AND target_player_id IS NOT NULL
+ORDER BY action_type`,
+		game.ID, game.Round, client.playerID, ActionPiperSelectCharm1, ActionPiperSelectCharm2)
+	if len(chosenIDs) < piperCharmsNeeded(h.db, game.ID, client.playerID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_piper_choose_first"))
+		return
+	}
+
+	charmed := charmedPlayers(h.db, game.ID)
+	var names []string
+	for _, id := range chosenIDs {
+		target, err := getPlayerInGame(h.db, game.ID, id)
+		if err != nil || !target.IsAlive || charmed[id] {
+			h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+			return
+		}
+		names = append(names, target.Name)
+	}
+
+	for _, id := range chosenIDs {
+		if _, err := h.db.Exec(`INSERT OR IGNORE INTO game_charmed (game_id, player_id, round) VALUES (?, ?, ?)`, game.ID, id, game.Round); err != nil {
+			h.logError("handleWSPiperCharm: insert charm", err)
+			h.sendErrorToast(client.playerID, T(lang, "err_failed_record_charm"))
+			return
+		}
+		// the charmed player's own notice stays pending until dawn, like night kills
+		h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, id, ActionPiperCharmed, id, VisibilityActor)
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type IN (?, ?)`,
+		game.ID, game.Round, client.playerID, ActionPiperSelectCharm1, ActionPiperSelectCharm2)
+
+	// with nobody left to charm the Piper just confirms; the empty description keeps it out of history
+	var targetID any
+	desc, key, args := "", "", ""
+	switch len(chosenIDs) {
+	case 1:
+		targetID = chosenIDs[0]
+		desc = fmt.Sprintf("Night %d: You charmed %s", game.Round, names[0])
+		key, args = "hist_piper_charm_one", histArgs(game.Round, names[0])
+	case 2:
+		targetID = chosenIDs[0]
+		desc = fmt.Sprintf("Night %d: You charmed %s and %s", game.Round, names[0], names[1])
+		key, args = "hist_piper_charm", histArgs(game.Round, names[0], names[1])
+	}
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionPiperApplyCharm, targetID, VisibilityActor, desc, key, args)
+	if err != nil {
+		h.logError("handleWSPiperCharm: db.Exec insert charm", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_charm"))
+		return
+	}
+
+	h.logf("Piper '%s' charmed %v", piper.Name, names)
+	DebugLog("handleWSPiperCharm", "Piper '%s' charmed %v", piper.Name, names)
+	LogDBState(h.db, "after piper charm")
+
+	h.resolveWerewolfVotes(game)
+}
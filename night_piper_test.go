@@ -0,0 +1,149 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Piper Helpers
+// ============================================================================
+
+// piperCharmPlayers picks two players for the Piper and clicks the Charm button.
+func (tp *TestPlayer) piperCharmPlayers(first, second string) {
+	if tp.logger != nil {
+		tp.logger.Debug("[%s] Piper charming: %s, %s", tp.Name, first, second)
+	}
+	tp.clickAndWait("[id^='piper-form-'] .player-card[player-name='" + first + "']")
+	tp.clickAndWait("[id^='piper-form-'] .player-card[player-name='" + second + "']")
+	tp.logHTML("after piper picked " + first + " and " + second)
+	tp.clickAndWait("#piper-charm-button")
+	tp.logHTML("after piper charm")
+}
+
+// ============================================================================
+// Piper Tests
+// ============================================================================
+
+func TestPiperCharmsTwoPlayers(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Piper", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RolePiper, RoleVillager, RoleVillager, RoleVillager})
+	wolf, piper, v1, v2, v3 := ids[0], ids[1], ids[2], ids[3], ids[4]
+
+	ctx.sendWS(piper, WSMessage{Action: "piper_choose", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(piper, WSMessage{Action: "piper_charm"})
+	if n := ctx.countActions(ActionPiperApplyCharm); n != 0 {
+		t.Fatal("the Piper must pick two players while enough are uncharmed")
+	}
+	ctx.sendWS(piper, WSMessage{Action: "piper_choose", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	ctx.sendWS(piper, WSMessage{Action: "piper_charm"})
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v3, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	if h := ctx.historyFor(v1); strings.Contains(h, "charmed you") {
+		t.Errorf("the charm should stay hidden until dawn, got: %q", h)
+	}
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	for _, id := range []int64{v1, v2} {
+		if h := ctx.historyFor(id); !strings.Contains(h, "charmed you") {
+			t.Errorf("player %d should learn of the charm at dawn, got: %q", id, h)
+		}
+	}
+	if h := ctx.historyFor(wolf); strings.Contains(h, "charm") {
+		t.Errorf("the charm must stay private, wolf sees: %q", h)
+	}
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Errorf("the game should continue while the wolf is uncharmed, got %q", status)
+	}
+}
+
+func TestPiperWinsWhenAllCharmed(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 2,
+		[]string{"Wolf", "Piper", "V1", "V2"},
+		[]string{RoleWerewolf, RolePiper, RoleVillager, RoleVillager})
+	wolf, piper, v1, v2 := ids[0], ids[1], ids[2], ids[3]
+
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.Exec(`INSERT INTO game_charmed (game_id, player_id, round) VALUES (?, ?, 1)`, game.ID, v1)
+
+	// a charmed player is no longer a valid target
+	ctx.sendWS(piper, WSMessage{Action: "piper_choose", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(piper, WSMessage{Action: "piper_choose", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(piper, WSMessage{Action: "piper_choose", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	ctx.sendWS(piper, WSMessage{Action: "piper_charm"})
+	if n := ctx.countActions(ActionPiperApplyCharm); n != 1 {
+		t.Fatalf("expected the charm to be recorded, got %d", n)
+	}
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if _, _, winner := ctx.gameState(); winner != "piper" {
+		t.Errorf("the Piper should win once every living player is charmed, got %q", winner)
+	}
+}
+
+func TestPiperCharmsInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing Piper charms two players, who learn it at dawn ===")
+
+	// Setup: 1 werewolf + 1 piper + 3 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"PI1", "PI2", "PI3", "PI4", "PI5"},
+		RoleWerewolf, RolePiper, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Piper"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) < 3 {
+		t.Fatal("Missing required roles")
+	}
+	piper, werewolf := byRole["Piper"][0], byRole["Werewolf"][0]
+	first, second, victim := byRole["Villager"][0], byRole["Villager"][1], byRole["Villager"][2]
+
+	piper.piperCharmPlayers(first.Name, second.Name)
+	history := piper.getHistoryText()
+	if !strings.Contains(history, "You charmed") || !strings.Contains(history, first.Name) || !strings.Contains(history, second.Name) {
+		ctx.logger.LogDB("FAIL: piper cannot see the charm in history")
+		t.Errorf("Piper should see charming %s and %s in history, got: %s", first.Name, second.Name, history)
+	}
+
+	werewolf.voteForPlayer(victim.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	// The charmed players are told at dawn, nobody else
+	charmed := "The Piper's tune has charmed you"
+	for _, p := range []*TestPlayer{first, second} {
+		if !p.historyContains(charmed) {
+			ctx.logger.LogDB("FAIL: charmed player not told")
+			t.Errorf("%s should see %q in history, got: %s", p.Name, charmed, p.getHistoryText())
+		}
+	}
+	if werewolf.historyContains(charmed) {
+		ctx.logger.LogDB("FAIL: werewolf told of a charm")
+		t.Errorf("The werewolf was not charmed and should not be told so")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -47,6 +47,12 @@ WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_t
 	return SeerNightData{}
 }
 
+// seerSeesWerewolf is the Seer's reading of target. The Lycan is a villager cursed to
+// read as a werewolf, so the result depends on the role and not just the team.
+func seerSeesWerewolf(target Player) bool {
+	return target.Team == "werewolf" || target.RoleName == "Lycan"
+}
+
 func handleWSSeerSelect(client *Client, msg WSMessage) {
 	h := client.hub
 	lang := h.getPlayerLang(client.playerID)
@@ -74,6 +80,10 @@ func handleWSSeerSelect(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
 		return
 	}
+	if powerDisabled(h.db, game.ID, investigator.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
 	var existingCount int
 	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
 		game.ID, game.Round, client.playerID, ActionSeerApplyInvestigate)
@@ -145,6 +155,11 @@ func handleWSSeerInvestigate(client *Client, msg WSMessage) {
 		return
 	}
 
+	if powerDisabled(h.db, game.ID, investigator.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+
 	var existingCount int
 	h.db.Get(&existingCount, `
 SELECT COUNT(*) FROM game_action
@@ -180,9 +195,10 @@ WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_t
 	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
 		game.ID, game.Round, client.playerID, ActionSeerSelectInvestigate)
 
+	seesWolf := seerSeesWerewolf(target)
 	seerKey := "hist_seer_not_wolf"
 	result := "not a werewolf"
-	if target.Team == "werewolf" {
+	if seesWolf {
 		seerKey = "hist_seer_wolf"
 		result = "a werewolf"
 	}
@@ -198,7 +214,7 @@ VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
 	}
 
 	toastMsg := T(lang, "toast_seer_not_werewolf", target.Name)
-	if target.Team == "werewolf" {
+	if seesWolf {
 		toastMsg = T(lang, "toast_seer_is_werewolf", target.Name)
 	}
 	h.sendToPlayer(client.playerID, []byte(renderToast(h.templates, h.logf, "info", toastMsg)))
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type SerialKillerNightData struct {
+	SerialKillerHasKilled      bool
+	SerialKillerSelectedPlayer *Player // pending, or confirmed once the kill is set
+	SerialKillerResultCard     *PlayerCardData
+	SerialKillerTargetCards    []PlayerCardData
+}
+
+func buildSerialKillerNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string) SerialKillerNightData {
+	if player.RoleName != "Serial Killer" {
+		return SerialKillerNightData{}
+	}
+
+	var action GameAction
+	err := db.Get(&action, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, playerID, ActionSerialKillerApplyKill)
+
+	if err == nil && action.TargetPlayerID != nil {
+		return SerialKillerNightData{
+			SerialKillerHasKilled:      true,
+			SerialKillerSelectedPlayer: getVisiblePlayer(db, game.ID, *action.TargetPlayerID, player, seerInvestigated),
+		}
+	}
+
+	var selectAction GameAction
+	if db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, playerID, ActionSerialKillerSelectKill) == nil && selectAction.TargetPlayerID != nil {
+		return SerialKillerNightData{
+			SerialKillerSelectedPlayer: getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated),
+		}
+	}
+
+	return SerialKillerNightData{}
+}
+
+// queueIndependentKills turns tonight's kills of the given action type (the Serial Killer's,
+// the White Werewolf's) into pending kills next to the pack's. Doctor, Guard and Witch
+// protection still saves the target, but the Bodyguard, Tough Guy and Cursed only answer to
+// the pack's attack.
+func (h *Hub) queueIndependentKills(tx *sqlx.Tx, game *Game, actionType, killer string) error {
+	var targetIDs []int64
+	h.db.Select(&targetIDs, `SELECT target_player_id FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND target_player_id IS NOT NULL`,
+		game.ID, game.Round, actionType)
+	for _, id := range targetIDs {
+		name := h.playerName(id)
+		if protectedTonight(h, game, id) {
+			h.logf("Protection saved %s (player ID %d) from the %s", name, id, killer)
+			continue
+		}
+		h.logf("%s kill pending: %s (player ID %d)", killer, name, id)
+		if err := queuePendingKill(tx, game, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func handleWSSerialKillerSelect(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSerialKillerSelect: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+	killer, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSSerialKillerSelect: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if killer.RoleName != "Serial Killer" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_serial_killer"))
+		return
+	}
+	if !killer.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	var existingCount int
+	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSerialKillerApplyKill)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_serial_killer_done"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var existing GameAction
+	selectErr := h.db.Get(&existing, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSerialKillerSelectKill)
+	if selectErr == nil && existing.TargetPlayerID != nil && *existing.TargetPlayerID == targetID {
+		// clicking the same target again deselects it
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionSerialKillerSelectKill)
+		h.logf("Serial Killer '%s' deselected victim", killer.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionSerialKillerSelectKill, targetID, VisibilityActor)
+		h.logf("Serial Killer '%s' selected victim %d", killer.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+func handleWSSerialKillerKill(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSerialKillerKill: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+
+	killer, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSSerialKillerKill: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	if killer.RoleName != "Serial Killer" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_serial_killer"))
+		return
+	}
+
+	if !killer.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	var existingCount int
+	h.db.Get(&existingCount, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, ActionSerialKillerApplyKill)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_serial_killer_done"))
+		return
+	}
+
+	var selectAction GameAction
+	if err := h.db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSerialKillerSelectKill); err != nil || selectAction.TargetPlayerID == nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_serial_killer_select_first"))
+		return
+	}
+	targetID := *selectAction.TargetPlayerID
+
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_target_not_found"))
+		return
+	}
+
+	if !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSerialKillerSelectKill)
+
+	desc := fmt.Sprintf("Night %d: You chose %s as your victim", game.Round, target.Name)
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionSerialKillerApplyKill, targetID, VisibilityActor, desc, "hist_serial_killer_kill", histArgs(game.Round, target.Name))
+	if err != nil {
+		h.logError("handleWSSerialKillerKill: db.Exec insert kill", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_serial_kill"))
+		return
+	}
+
+	h.logf("Serial Killer '%s' chose '%s' as tonight's victim", killer.Name, target.Name)
+	DebugLog("handleWSSerialKillerKill", "Serial Killer '%s' chose '%s'", killer.Name, target.Name)
+	LogDBState(h.db, "after serial killer kill")
+
+	h.resolveWerewolfVotes(game)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Serial Killer Helpers
+// ============================================================================
+
+// serialKillerKillPlayer selects a victim for the Serial Killer and clicks the Kill button.
+func (tp *TestPlayer) serialKillerKillPlayer(targetName string) {
+	tp.selectAndConfirm("serial-killer-select-form-", targetName, "#serial-killer-kill-button")
+}
+
+// ============================================================================
+// Serial Killer Tests
+// ============================================================================
+
+func TestSerialKillerKillsAlongsideWolves(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Killer", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleSerialKiller, RoleVillager, RoleVillager, RoleVillager})
+	wolf, killer, v1, v2 := ids[0], ids[1], ids[2], ids[3]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("the night should wait for the Serial Killer, got %d pending kills", n)
+	}
+
+	ctx.sendWS(killer, WSMessage{Action: "serial_killer_select", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	ctx.sendWS(killer, WSMessage{Action: "serial_killer_kill"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if ctx.isPlayerAlive(v1) || ctx.isPlayerAlive(v2) {
+		t.Error("both the pack's and the Serial Killer's victims should die at dawn")
+	}
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Errorf("the game should continue into the day, got %q", status)
+	}
+	if h := ctx.historyFor(killer); !strings.Contains(h, "as your victim") {
+		t.Errorf("the Serial Killer should see their pick in history, got: %q", h)
+	}
+	if h := ctx.historyFor(wolf); strings.Contains(h, "as your victim") {
+		t.Errorf("the pick must stay private, wolf sees: %q", h)
+	}
+}
+
+func TestSerialKillerWinsAsLastStanding(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 2,
+		[]string{"Wolf", "Killer", "V1"},
+		[]string{RoleWerewolf, RoleSerialKiller, RoleVillager})
+	wolf, killer, v1 := ids[0], ids[1], ids[2]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	ctx.sendWS(killer, WSMessage{Action: "serial_killer_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(killer, WSMessage{Action: "serial_killer_kill"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if !ctx.isPlayerAlive(killer) {
+		t.Fatal("the Serial Killer should survive the night")
+	}
+	if _, _, winner := ctx.gameState(); winner != "serial_killer" {
+		t.Errorf("the last one standing should win alone, got %q", winner)
+	}
+}
+
+func TestSerialKillerBlocksVillageWin(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Killer", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSerialKiller, RoleVillager, RoleVillager})
+
+	ctx.app.db.Exec("UPDATE game_player SET is_alive = 0 WHERE player_id = ?", ids[0])
+	game, _ := ctx.hub().getGame()
+	if ctx.hub().checkWinConditions(game) {
+		t.Error("the village must not win while the Serial Killer lives")
+	}
+}
+
+func TestSerialKillerKillsInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing Serial Killer kills alongside the werewolves ===")
+
+	// Setup: 1 werewolf + 1 serial killer + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"SK1", "SK2", "SK3", "SK4"},
+		RoleWerewolf, RoleSerialKiller, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Serial Killer"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) < 2 {
+		t.Fatal("Missing required roles")
+	}
+	killer, werewolf := byRole["Serial Killer"][0], byRole["Werewolf"][0]
+	killerVictim, wolfVictim := byRole["Villager"][0], byRole["Villager"][1]
+	ctx.logger.Debug("Serial Killer: %s, victim: %s", killer.Name, killerVictim.Name)
+
+	killer.serialKillerKillPlayer(killerVictim.Name)
+	entry := "You chose " + killerVictim.Name + " as your victim"
+	if !killer.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: serial killer cannot see the kill in history")
+		t.Errorf("Serial Killer should see %q in history, got: %s", entry, killer.getHistoryText())
+	}
+	if werewolf.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: werewolf can see the serial killer's kill in history")
+		t.Errorf("Only the Serial Killer should see the kill in history")
+	}
+
+	werewolf.voteForPlayer(wolfVictim.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	// Both victims die at dawn; the Serial Killer keeps the game going
+	deaths := killer.getDeathAnnouncement()
+	if !strings.Contains(deaths, killerVictim.Name) || !strings.Contains(deaths, wolfVictim.Name) {
+		ctx.logger.LogDB("FAIL: not both victims died")
+		t.Errorf("Both %s and %s should have died, got: %s", killerVictim.Name, wolfVictim.Name, deaths)
+	}
+	if killer.isGameFinished() {
+		ctx.logger.LogDB("FAIL: game ended with the serial killer alive")
+		t.Errorf("The game should go on while the Serial Killer and a werewolf are alive")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
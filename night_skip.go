@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// nightSkip is the select/apply action pair of a role that may sit a night out.
+type nightSkip struct {
+	selectType string
+	applyType  string
+}
+
+// nightSkips lists the roles with an explicit skip. A skip records the role's apply action
+// without a target, so the night's completeness checks pass as if the role had acted.
+var nightSkips = map[string]nightSkip{
+	"Seer":   {ActionSeerSelectInvestigate, ActionSeerApplyInvestigate},
+	"Doctor": {ActionDoctorSelectProtect, ActionDoctorApplyProtect},
+	"Guard":  {ActionGuardSelectProtect, ActionGuardApplyProtect},
+}
+
+// nightSkipped reports whether the player sat tonight out.
+func nightSkipped(db *sqlx.DB, game *Game, player Player) bool {
+	skip, ok := nightSkips[player.RoleName]
+	if !ok {
+		return false
+	}
+	var n int
+	db.Get(&n, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=? AND target_player_id IS NULL`,
+		game.ID, game.Round, player.PlayerID, skip.applyType)
+	return n > 0
+}
+
+// handleWSNightSkip lets a Seer, Doctor or Guard pass on tonight's action instead of blocking
+// the night; role is the role the WS action was sent for.
+func handleWSNightSkip(client *Client, role string) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSNightSkip: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+
+	player, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSNightSkip: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	skip, ok := nightSkips[role]
+	if !ok || player.RoleName != role {
+		h.sendErrorToast(client.playerID, T(lang, "err_cannot_skip"))
+		return
+	}
+
+	if !player.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	if powerDisabled(h.db, game.ID, player.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+
+	var existingCount int
+	h.db.Get(&existingCount, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, skip.applyType)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_submitted_night"))
+		return
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, skip.selectType)
+
+	desc := fmt.Sprintf("Night %d: You skipped your action", game.Round)
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, NULL, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, skip.applyType, VisibilityActor, desc, "hist_night_skipped", histArgs(game.Round))
+	if err != nil {
+		h.logError("handleWSNightSkip: db.Exec insert skip", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_skip"))
+		return
+	}
+
+	h.logf("%s '%s' skipped their night action", player.RoleName, player.Name)
+	DebugLog("handleWSNightSkip", "%s '%s' skipped their night action", player.RoleName, player.Name)
+	LogDBState(h.db, "after night skip")
+
+	h.resolveWerewolfVotes(game)
+}
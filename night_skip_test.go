@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Night Skip Tests
+// ============================================================================
+
+func TestNightSkipLetsTheNightResolve(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Seer", "Doc", "Guard", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSeer, RoleDoctor, RoleGuard, RoleVillager, RoleVillager})
+	wolf, seer, doc, guard, v1 := ids[0], ids[1], ids[2], ids[3], ids[4]
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+
+	// a villager cannot skip on the Seer's behalf
+	ctx.sendWS(v1, WSMessage{Action: "seer_skip"})
+	if n := ctx.countActions(ActionSeerApplyInvestigate); n != 0 {
+		t.Fatalf("only the Seer may skip the Seer's action, got %d rows", n)
+	}
+
+	ctx.sendWS(seer, WSMessage{Action: "seer_skip"})
+	ctx.sendWS(doc, WSMessage{Action: "doctor_skip"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("the night should still wait for the Guard, got %d pending kills", n)
+	}
+	ctx.sendWS(guard, WSMessage{Action: "guard_skip"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 1 {
+		t.Errorf("once everyone acted or skipped the kill should be pending, got %d", n)
+	}
+
+	ctx.sendWS(seer, WSMessage{Action: "seer_skip"})
+	if n := ctx.countActions(ActionSeerApplyInvestigate); n != 1 {
+		t.Errorf("a second skip should be rejected, got %d rows", n)
+	}
+
+	buf, err := getGameComponent(ctx.hub(), seer, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="seer-skipped"`) || !strings.Contains(buf.String(), "night-survey-section") {
+		t.Errorf("the Seer should see the skip and move on to the survey (err: %v)", err)
+	}
+	if !strings.Contains(ctx.historyFor(doc), "You skipped your action") {
+		t.Errorf("the Doctor's history should record the skip, got %q", ctx.historyFor(doc))
+	}
+}
+
+func TestSeerSkipsNightInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Seer sitting the night out ===")
+
+	// Setup: 1 werewolf + 1 seer + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"SK1", "SK2", "SK3", "SK4"},
+		RoleWerewolf, RoleSeer, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Seer"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	seer, werewolf, victim := byRole["Seer"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+
+	seer.clickAndWait("#seer-skip-button")
+	if _, err := seer.p().Element("#seer-skipped"); err != nil {
+		ctx.logger.LogDB("FAIL: skip not shown")
+		t.Fatalf("the Seer should be told they sat the night out: %v", err)
+	}
+
+	// the skip counts as the Seer's action, so the night resolves without an investigation
+	werewolf.voteForPlayer(victim.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if !seer.isInDayPhase() {
+		ctx.logger.LogDB("FAIL: night blocked by skipping Seer")
+		t.Fatal("the night should resolve after the Seer skipped")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type SorceressNightData struct {
+	SorceressHasInvestigated bool
+	SorceressFoundSeer       bool
+	SorceressSelectedPlayer  *Player // pending, or confirmed once investigated
+	SorceressResultCard      *PlayerCardData
+	SorceressTargetCards     []PlayerCardData
+}
+
+func buildSorceressNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string) SorceressNightData {
+	if player.RoleName != "Sorceress" {
+		return SorceressNightData{}
+	}
+
+	var action GameAction
+	err := db.Get(&action, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, playerID, ActionSorceressApplyInvestigate)
+
+	if err == nil && action.TargetPlayerID != nil {
+		d := SorceressNightData{
+			SorceressHasInvestigated: true,
+			SorceressSelectedPlayer:  getVisiblePlayer(db, game.ID, *action.TargetPlayerID, player, seerInvestigated),
+		}
+		if target, err := getPlayerInGame(db, game.ID, *action.TargetPlayerID); err == nil && target.RoleName == "Seer" {
+			d.SorceressFoundSeer = true
+		}
+		return d
+	}
+
+	var selectAction GameAction
+	if db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, playerID, ActionSorceressSelectInvestigate) == nil && selectAction.TargetPlayerID != nil {
+		return SorceressNightData{
+			SorceressSelectedPlayer: getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated),
+		}
+	}
+
+	return SorceressNightData{}
+}
+
+func handleWSSorceressSelect(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSorceressSelect: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+	sorceress, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSSorceressSelect: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if sorceress.RoleName != "Sorceress" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_sorceress_select"))
+		return
+	}
+	if !sorceress.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	var existingCount int
+	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSorceressApplyInvestigate)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_investigated"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var existing GameAction
+	selectErr := h.db.Get(&existing, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSorceressSelectInvestigate)
+	if selectErr == nil && existing.TargetPlayerID != nil && *existing.TargetPlayerID == targetID {
+		// clicking the same target again deselects it
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionSorceressSelectInvestigate)
+		h.logf("Sorceress '%s' deselected investigation target", sorceress.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionSorceressSelectInvestigate, targetID, VisibilityActor)
+		h.logf("Sorceress '%s' selected investigation target %d", sorceress.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+func handleWSSorceressInvestigate(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSorceressInvestigate: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_investigate"))
+		return
+	}
+
+	sorceress, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSSorceressInvestigate: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	if sorceress.RoleName != "Sorceress" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_sorceress_investigate"))
+		return
+	}
+
+	if !sorceress.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	var existingCount int
+	h.db.Get(&existingCount, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, ActionSorceressApplyInvestigate)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_investigated"))
+		return
+	}
+
+	var selectAction GameAction
+	if err := h.db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSorceressSelectInvestigate); err != nil || selectAction.TargetPlayerID == nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_select_investigate_first"))
+		return
+	}
+	targetID := *selectAction.TargetPlayerID
+
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_target_not_found"))
+		return
+	}
+
+	if !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_cannot_investigate_dead"))
+		return
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSorceressSelectInvestigate)
+
+	foundSeer := target.RoleName == "Seer"
+	histKey := "hist_sorceress_not_seer"
+	result := "not the Seer"
+	if foundSeer {
+		histKey = "hist_sorceress_seer"
+		result = "the Seer"
+	}
+	desc := fmt.Sprintf("Night %d: You searched %s — they are %s", game.Round, target.Name, result)
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionSorceressApplyInvestigate, targetID, VisibilityActor, desc, histKey, histArgs(game.Round, target.Name))
+	if err != nil {
+		h.logError("handleWSSorceressInvestigate: db.Exec insert investigation", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_investigation"))
+		return
+	}
+
+	toastMsg := T(lang, "toast_sorceress_not_seer", target.Name)
+	if foundSeer {
+		toastMsg = T(lang, "toast_sorceress_found_seer", target.Name)
+	}
+	h.sendToPlayer(client.playerID, []byte(renderToast(h.templates, h.logf, "info", toastMsg)))
+
+	h.logf("Sorceress '%s' investigated '%s' (seer: %v)", sorceress.Name, target.Name, foundSeer)
+	DebugLog("handleWSSorceressInvestigate", "Sorceress '%s' investigated '%s' (seer: %v)", sorceress.Name, target.Name, foundSeer)
+	LogDBState(h.db, "after sorceress investigation")
+
+	h.resolveWerewolfVotes(game)
+}
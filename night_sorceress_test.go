@@ -0,0 +1,125 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Sorceress Helpers
+// ============================================================================
+
+// sorceressSearchPlayer selects a target for the Sorceress and clicks the Search button.
+func (tp *TestPlayer) sorceressSearchPlayer(targetName string) {
+	tp.selectAndConfirm("sorceress-select-form-", targetName, "#sorceress-investigate-button")
+}
+
+// ============================================================================
+// Sorceress Tests
+// ============================================================================
+
+func TestSorceressFindsSeerAndGatesNight(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Sorc", "Seer", "Vil"},
+		[]string{RoleWerewolf, RoleSorceress, RoleSeer, RoleVillager})
+	wolf, sorc, seer, vil := ids[0], ids[1], ids[2], ids[3]
+
+	// The Sorceress is not part of the pack: the wolf's vote alone is a majority.
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(vil, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	if n := ctx.countActions(ActionWerewolfApplyKill); n != 1 {
+		t.Fatalf("werewolf end vote should be recorded with only the wolf voting, got %d", n)
+	}
+
+	ctx.sendWS(seer, WSMessage{Action: "seer_select", TargetPlayerID: strconv.FormatInt(vil, 10)})
+	ctx.sendWS(seer, WSMessage{Action: "seer_investigate"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("night should wait for the Sorceress, but %d kill(s) are pending", n)
+	}
+
+	// Wolves and the Sorceress never act together.
+	ctx.sendWS(sorc, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(vil, 10)})
+	var sorcVotes int
+	ctx.app.db.Get(&sorcVotes, `SELECT COUNT(*) FROM game_action WHERE actor_player_id = ? AND action_type = ?`, sorc, ActionWerewolfSelectKill)
+	if sorcVotes != 0 {
+		t.Errorf("Sorceress should not be able to vote with the wolves")
+	}
+
+	ctx.sendWS(sorc, WSMessage{Action: "sorceress_select", TargetPlayerID: strconv.FormatInt(seer, 10)})
+	ctx.sendWS(sorc, WSMessage{Action: "sorceress_investigate"})
+	if n := ctx.countActions(ActionSorceressApplyInvestigate); n != 1 {
+		t.Fatalf("expected one sorceress investigation, got %d", n)
+	}
+	if n := ctx.countActions(ActionNightApplyKill); n != 1 {
+		t.Errorf("wolf kill should be pending once the Sorceress has acted, got %d", n)
+	}
+
+	if h := ctx.historyFor(sorc); !strings.Contains(h, "they are the Seer") {
+		t.Errorf("Sorceress history should reveal the Seer, got: %q", h)
+	}
+	if h := ctx.historyFor(wolf); strings.Contains(h, "Seer") {
+		t.Errorf("Sorceress results must be actor-only, wolf sees: %q", h)
+	}
+}
+
+func TestSorceressDoesNotKeepWolvesAlive(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 2,
+		[]string{"Wolf", "Sorc", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSorceress, RoleVillager, RoleVillager})
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE player_id = ?", ids[0])
+
+	game, _ := ctx.hub().getGame()
+	if !ctx.hub().checkWinConditions(game) {
+		t.Fatal("game should end once the last werewolf is dead, even with the Sorceress alive")
+	}
+	if _, _, winner := ctx.gameState(); winner != "villagers" {
+		t.Errorf("expected villagers to win, got %q", winner)
+	}
+}
+
+func TestSorceressSearchesInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing Sorceress finds the Seer from her night panel ===")
+
+	// Setup: 1 werewolf + 1 sorceress + 1 seer + 1 villager = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"SO1", "SO2", "SO3", "SO4"},
+		RoleWerewolf, RoleSorceress, RoleSeer, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Sorceress"]) == 0 || len(byRole["Seer"]) == 0 || len(byRole["Werewolf"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	sorceress, seer, werewolf := byRole["Sorceress"][0], byRole["Seer"][0], byRole["Werewolf"][0]
+	ctx.logger.Debug("Sorceress: %s, searching Seer: %s", sorceress.Name, seer.Name)
+
+	sorceress.sorceressSearchPlayer(seer.Name)
+
+	// History: the search is actor-only — the wolves do not learn it either
+	entry := "You searched " + seer.Name + " — they are the Seer"
+	if !sorceress.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: sorceress cannot see her search in history")
+		t.Errorf("Sorceress should see %q in history, got: %s", entry, sorceress.getHistoryText())
+	}
+	if werewolf.historyContains(entry) || seer.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: sorceress search visible to others")
+		t.Errorf("Only the Sorceress should see her search in history")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type SpellcasterNightData struct {
+	SpellcasterHasSilenced    bool
+	SpellcasterSelectedPlayer *Player // pending, or confirmed once silenced
+	SpellcasterResultCard     *PlayerCardData
+	SpellcasterTargetCards    []PlayerCardData
+}
+
+func buildSpellcasterNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string) SpellcasterNightData {
+	if player.RoleName != "Spellcaster" {
+		return SpellcasterNightData{}
+	}
+
+	var action GameAction
+	err := db.Get(&action, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, playerID, ActionSpellcasterApplySilence)
+
+	if err == nil && action.TargetPlayerID != nil {
+		return SpellcasterNightData{
+			SpellcasterHasSilenced:    true,
+			SpellcasterSelectedPlayer: getVisiblePlayer(db, game.ID, *action.TargetPlayerID, player, seerInvestigated),
+		}
+	}
+
+	var selectAction GameAction
+	if db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, playerID, ActionSpellcasterSelectSilence) == nil && selectAction.TargetPlayerID != nil {
+		return SpellcasterNightData{
+			SpellcasterSelectedPlayer: getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated),
+		}
+	}
+
+	return SpellcasterNightData{}
+}
+
+// silencedPlayers returns the living players the Spellcaster silenced during the night of
+// the given round, plus anyone the last Scapegoat left out and every revealed Village Idiot;
+// they sit out that day's vote.
+func silencedPlayers(db *sqlx.DB, gameID int64, round int) map[int64]bool {
+	var ids []int64
+	db.Select(&ids, `
+SELECT a.target_player_id FROM game_action a
+JOIN game_player g ON g.game_id = a.game_id AND g.player_id = a.target_player_id
+WHERE a.game_id = ? AND a.round = ? AND a.phase = 'night' AND a.action_type = ? AND g.is_alive = 1`,
+		gameID, round, ActionSpellcasterApplySilence)
+	silenced := scapegoatBarred(db, gameID, round)
+	for id := range revealedIdiots(db, gameID) {
+		silenced[id] = true
+	}
+	for _, id := range ids {
+		silenced[id] = true
+	}
+	return silenced
+}
+
+// spellbound reports whether the Spellcaster silenced the player during the night of the
+// given round. Unlike the others who sit out the vote, they may not speak that day either:
+// no day chat and no whisper.
+func spellbound(db *sqlx.DB, gameID int64, round int, playerID int64) bool {
+	var n int
+	db.Get(&n, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ? AND target_player_id = ?`,
+		gameID, round, ActionSpellcasterApplySilence, playerID)
+	return n > 0
+}
+
+func handleWSSpellcasterSelect(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSpellcasterSelect: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+	spellcaster, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSSpellcasterSelect: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if spellcaster.RoleName != "Spellcaster" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_spellcaster"))
+		return
+	}
+	if !spellcaster.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	if powerDisabled(h.db, game.ID, spellcaster.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+	var existingCount int
+	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSpellcasterApplySilence)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_silenced"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var existing GameAction
+	selectErr := h.db.Get(&existing, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSpellcasterSelectSilence)
+	if selectErr == nil && existing.TargetPlayerID != nil && *existing.TargetPlayerID == targetID {
+		// clicking the same target again deselects it
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionSpellcasterSelectSilence)
+		h.logf("Spellcaster '%s' deselected silence target", spellcaster.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionSpellcasterSelectSilence, targetID, VisibilityActor)
+		h.logf("Spellcaster '%s' selected silence target %d", spellcaster.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+func handleWSSpellcasterSilence(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSpellcasterSilence: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+
+	spellcaster, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSSpellcasterSilence: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	if spellcaster.RoleName != "Spellcaster" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_spellcaster"))
+		return
+	}
+
+	if !spellcaster.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	if powerDisabled(h.db, game.ID, spellcaster.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
+
+	var existingCount int
+	h.db.Get(&existingCount, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, ActionSpellcasterApplySilence)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_already_silenced"))
+		return
+	}
+
+	var selectAction GameAction
+	if err := h.db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSpellcasterSelectSilence); err != nil || selectAction.TargetPlayerID == nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_select_silence_first"))
+		return
+	}
+	targetID := *selectAction.TargetPlayerID
+
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_target_not_found"))
+		return
+	}
+
+	if !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionSpellcasterSelectSilence)
+
+	desc := fmt.Sprintf("Night %d: You silenced %s for the coming day", game.Round, target.Name)
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionSpellcasterApplySilence, targetID, VisibilityActor, desc, "hist_spellcaster_silence", histArgs(game.Round, target.Name))
+	if err != nil {
+		h.logError("handleWSSpellcasterSilence: db.Exec insert silence", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_silence"))
+		return
+	}
+
+	h.logf("Spellcaster '%s' silenced '%s'", spellcaster.Name, target.Name)
+	DebugLog("handleWSSpellcasterSilence", "Spellcaster '%s' silenced '%s'", spellcaster.Name, target.Name)
+	LogDBState(h.db, "after spellcaster silence")
+
+	h.resolveWerewolfVotes(game)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Spellcaster Helpers
+// ============================================================================
+
+// spellcasterSilencePlayer selects a target for the Spellcaster and clicks the Silence button.
+func (tp *TestPlayer) spellcasterSilencePlayer(targetName string) {
+	tp.selectAndConfirm("spellcaster-select-form-", targetName, "#spellcaster-silence-button")
+}
+
+// getSilencedPlayers returns the day's list of silenced players.
+func (tp *TestPlayer) getSilencedPlayers() string {
+	el, err := tp.p().Element("#silenced-players")
+	if err != nil {
+		return ""
+	}
+	text, _ := el.Text()
+	return text
+}
+
+// ============================================================================
+// Spellcaster Tests
+// ============================================================================
+
+func TestSpellcasterSilenceGatesNight(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Caster", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSpellcaster, RoleVillager, RoleVillager})
+	wolf, caster, v1, v2 := ids[0], ids[1], ids[2], ids[3]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("night should wait for the Spellcaster, but %d kill(s) are pending", n)
+	}
+
+	ctx.sendWS(caster, WSMessage{Action: "spellcaster_select", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	ctx.sendWS(caster, WSMessage{Action: "spellcaster_silence"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 1 {
+		t.Errorf("wolf kill should be pending once the Spellcaster has acted, got %d", n)
+	}
+	if h := ctx.historyFor(caster); !strings.Contains(h, "You silenced V2") {
+		t.Errorf("Spellcaster should see the silence in history, got: %q", h)
+	}
+	if h := ctx.historyFor(v2); strings.Contains(h, "silenced") {
+		t.Errorf("the silence must stay hidden overnight, target sees: %q", h)
+	}
+}
+
+func TestSilencedPlayerCannotVote(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Caster", "V1", "V2"},
+		[]string{RoleWerewolf, RoleSpellcaster, RoleVillager, RoleVillager})
+	wolf, caster, v1, v2 := ids[0], ids[1], ids[2], ids[3]
+
+	ctx.sendWS(caster, WSMessage{Action: "spellcaster_select", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(caster, WSMessage{Action: "spellcaster_silence"})
+	ctx.app.db.MustExec("UPDATE game SET status = 'day'")
+
+	ctx.sendWS(v1, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(v1, WSMessage{Action: "day_pass"})
+	if n := ctx.countActions(ActionDaySelectKill); n != 0 {
+		t.Fatalf("a silenced player's vote must be rejected, got %d vote(s)", n)
+	}
+
+	game, _ := ctx.hub().getGame()
+	buf, err := getGameComponent(ctx.hub(), v1, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "silenced-note") || strings.Contains(buf.String(), "day-pass-btn") {
+		t.Errorf("silenced player should see the silence notice instead of the vote controls (err: %v)", err)
+	}
+
+	// the three voices left decide the day without waiting for the silenced player
+	for _, id := range []int64{wolf, caster, v2} {
+		target := wolf
+		if id == wolf {
+			target = v2
+		}
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(target, 10)})
+	}
+	ctx.sendWS(caster, WSMessage{Action: "day_end_vote"})
+
+	if ctx.isPlayerAlive(wolf) {
+		t.Error("two of three eligible votes should eliminate the wolf")
+	}
+}
+
+func TestSilenceLastsOneDay(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Caster", "V1"},
+		[]string{RoleWerewolf, RoleSpellcaster, RoleVillager})
+	caster, v1 := ids[1], ids[2]
+
+	ctx.sendWS(caster, WSMessage{Action: "spellcaster_select", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(caster, WSMessage{Action: "spellcaster_silence"})
+
+	game, _ := ctx.hub().getGame()
+	if !silencedPlayers(ctx.app.db, game.ID, 1)[v1] {
+		t.Error("V1 should be silenced on day 1")
+	}
+	if silencedPlayers(ctx.app.db, game.ID, 2)[v1] {
+		t.Error("the silence should not carry over to day 2")
+	}
+}
+
+func TestSilencedPlayerCannotSpeak(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Caster", "V1", "Idiot"},
+		[]string{RoleWerewolf, RoleSpellcaster, RoleVillager, RoleVillager})
+	wolf, caster, v1, idiot := ids[0], ids[1], ids[2], ids[3]
+
+	ctx.sendWS(caster, WSMessage{Action: "spellcaster_select", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(caster, WSMessage{Action: "spellcaster_silence"})
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET status = 'day', whispers = 1 WHERE rowid = ?", game.ID)
+	// a revealed Village Idiot sits out the vote too, but the Spellcaster did not silence them
+	ctx.app.db.MustExec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility) VALUES (?, 1, 'day', ?, ?, ?, ?)`,
+		game.ID, idiot, ActionVillageIdiotRevealed, idiot, VisibilityPublic)
+
+	ctx.sendWS(v1, WSMessage{Action: "chat_send", Channel: ChatChannelDay, Message: "it was Wolf"})
+	ctx.sendWS(v1, WSMessage{Action: "whisper", TargetPlayerID: strconv.FormatInt(caster, 10), Message: "it was Wolf"})
+	if msgs := chatMessages(ctx.app.db, game.ID, ChatChannelDay); len(msgs) != 0 {
+		t.Errorf("the silenced player should not talk in the day chat, got %+v", msgs)
+	}
+	if n := ctx.countActions(ActionDayWhisper); n != 0 {
+		t.Errorf("the silenced player should not whisper, got %d", n)
+	}
+	game, _ = ctx.hub().getGame()
+	buf, err := getGameComponent(ctx.hub(), v1, game, "en")
+	if err != nil || strings.Contains(buf.String(), `id="day-chat-form"`) || strings.Contains(buf.String(), `id="whisper-form"`) {
+		t.Errorf("the silenced player should get neither the chat nor the whisper form (err: %v)", err)
+	}
+
+	ctx.sendWS(idiot, WSMessage{Action: "chat_send", Channel: ChatChannelDay, Message: "not me"})
+	ctx.sendWS(wolf, WSMessage{Action: "whisper", TargetPlayerID: strconv.FormatInt(v1, 10), Message: "shh"})
+	if msgs := chatMessages(ctx.app.db, game.ID, ChatChannelDay); len(msgs) != 1 || msgs[0].Name != "Idiot" {
+		t.Errorf("only the Spellcaster's target loses their voice, got %+v", msgs)
+	}
+	if n := ctx.countActions(ActionDayWhisper); n != 1 {
+		t.Errorf("the silenced player may still be whispered to, got %d whispers", n)
+	}
+
+	// the next day they speak again
+	ctx.app.db.MustExec("UPDATE game SET round = 2 WHERE rowid = ?", game.ID)
+	ctx.sendWS(v1, WSMessage{Action: "chat_send", Channel: ChatChannelDay, Message: "I am back"})
+	if msgs := chatMessages(ctx.app.db, game.ID, ChatChannelDay); len(msgs) != 2 {
+		t.Errorf("the silence should last one day, got %+v", msgs)
+	}
+}
+
+func TestSpellcasterSilencesInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing Spellcaster silences a player for the next day ===")
+
+	// Setup: 1 werewolf + 1 spellcaster + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"SC1", "SC2", "SC3", "SC4"},
+		RoleWerewolf, RoleSpellcaster, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Spellcaster"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) < 2 {
+		t.Fatal("Missing required roles")
+	}
+	caster, werewolf := byRole["Spellcaster"][0], byRole["Werewolf"][0]
+	silenced, victim := byRole["Villager"][0], byRole["Villager"][1]
+	ctx.logger.Debug("Spellcaster: %s, silencing: %s", caster.Name, silenced.Name)
+
+	caster.spellcasterSilencePlayer(silenced.Name)
+	entry := "You silenced " + silenced.Name + " for the coming day"
+	if !caster.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: spellcaster cannot see the silence in history")
+		t.Errorf("Spellcaster should see %q in history, got: %s", entry, caster.getHistoryText())
+	}
+
+	werewolf.voteForPlayer(victim.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	// The whole village sees who is silenced; the silenced player cannot vote
+	if list := werewolf.getSilencedPlayers(); !strings.Contains(list, silenced.Name) {
+		ctx.logger.LogDB("FAIL: silenced player not announced")
+		t.Errorf("Day should list %s as silenced, got: %q", silenced.Name, list)
+	}
+	if found, _, _ := silenced.p().Has("#silenced-note"); !found {
+		ctx.logger.LogDB("FAIL: silenced player can vote")
+		t.Errorf("Silenced player %s should see they cannot vote", silenced.Name)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
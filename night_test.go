@@ -306,6 +306,77 @@ func findPlayersByRole(players []*TestPlayer) (werewolves []*TestPlayer, village
 	return
 }
 
+// startGameWithRoles signs up one player per name, deals exactly the given roles and starts
+// the game. It does not wait for the first phase: a Thief's setup or a Mayor election may
+// come before the night.
+func startGameWithRoles(browser *TestBrowser, baseURL string, names []string, roleIDs ...string) []*TestPlayer {
+	return startGameWithSettings(browser, baseURL, names, nil, roleIDs...)
+}
+
+// startGameWithSettings is startGameWithRoles for a game with some of the host's lobby
+// settings changed first, given by the ids of their labels (see toggleLobbySetting).
+func startGameWithSettings(browser *TestBrowser, baseURL string, names, settings []string, roleIDs ...string) []*TestPlayer {
+	var players []*TestPlayer
+	for _, name := range names {
+		players = append(players, browser.signupPlayer(baseURL, name))
+	}
+	for _, labelID := range settings {
+		players[0].toggleLobbySetting(labelID)
+	}
+	for _, id := range roleIDs {
+		players[0].addRoleByID(id)
+	}
+	players[0].startGame()
+	return players
+}
+
+// waitForNightPhaseAll waits until every player has received the night.
+func waitForNightPhaseAll(ctx *TestContext, players []*TestPlayer) {
+	for _, p := range players {
+		if err := p.waitForNightPhase(); err != nil {
+			ctx.logger.Debug("Warning: timeout waiting for night phase on %s: %v", p.Name, err)
+		}
+	}
+}
+
+// waitForDayPhaseAll waits until every player has received the day.
+func waitForDayPhaseAll(ctx *TestContext, players []*TestPlayer) {
+	for _, p := range players {
+		if err := p.waitForDayPhase(); err != nil {
+			ctx.logger.Debug("Warning: timeout waiting for day phase on %s: %v", p.Name, err)
+		}
+	}
+}
+
+// playersByRole groups the players by the role on their sidebar role card.
+func playersByRole(players []*TestPlayer) map[string][]*TestPlayer {
+	byRole := make(map[string][]*TestPlayer)
+	for _, p := range players {
+		role := p.getRole()
+		byRole[role] = append(byRole[role], p)
+	}
+	return byRole
+}
+
+// waitForRole waits until the player's sidebar role card shows the given role.
+func (tp *TestPlayer) waitForRole(role string) error {
+	return tp.waitUntilCondition(
+		`() => document.querySelector('#sidebar-role-card')?.getAttribute('role-name') === '`+role+`'`,
+		"role "+role)
+}
+
+// selectAndConfirm clicks a target card in a night role's select forms, then the role's
+// confirm button.
+func (tp *TestPlayer) selectAndConfirm(formPrefix, targetName, button string) {
+	if tp.logger != nil {
+		tp.logger.Debug("[%s] %s selecting target: %s", tp.Name, formPrefix, targetName)
+	}
+	tp.clickAndWait("[id^='" + formPrefix + "'] .player-card[player-name='" + targetName + "']")
+	tp.logHTML("after " + formPrefix + " select of " + targetName)
+	tp.clickAndWait(button)
+	tp.logHTML("after " + button + " on " + targetName)
+}
+
 // ============================================================================
 // Night Phase Tests
 // ============================================================================
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// nightTimerChoices are the night lengths the lobby offers, in seconds; 0 switches the timer off.
+var nightTimerChoices = []int{0, 60, 90, 120, 180}
+
+// nightTimer counts one night down. It is keyed by game and round, so a timer left over from
+// a night that already ended never touches the next one.
+type nightTimer struct {
+	gameID   int64
+	round    int
+	deadline time.Time
+	stop     chan struct{}
+}
+
+type NightTimerChoice struct {
+	Seconds  int
+	Selected bool
+}
+
+// nightTimerSeconds returns the game's night length in seconds, 0 when the timer is off.
+func nightTimerSeconds(db *sqlx.DB, gameID int64) int {
+	var seconds int
+	db.Get(&seconds, "SELECT night_timer FROM game WHERE rowid = ?", gameID)
+	return seconds
+}
+
+func nightTimerOptions(db *sqlx.DB, gameID int64) []NightTimerChoice {
+	current := nightTimerSeconds(db, gameID)
+	options := make([]NightTimerChoice, 0, len(nightTimerChoices))
+	for _, s := range nightTimerChoices {
+		options = append(options, NightTimerChoice{Seconds: s, Selected: s == current})
+	}
+	return options
+}
+
+func formatCountdown(left time.Duration) string {
+	secs := int(left.Round(time.Second) / time.Second)
+	if secs < 0 {
+		secs = 0
+	}
+	return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+}
+
+// startNightTimer arms the countdown for the game's current night, replacing any earlier one.
+// It does nothing when the game has no night timer, or a narrator who sets the pace.
+func (h *Hub) startNightTimer(gameID int64, round int) {
+	seconds := nightTimerSeconds(h.db, gameID)
+	if seconds <= 0 || narratorModeEnabled(h.db, gameID) {
+		return
+	}
+	h.armNightTimer(gameID, round, time.Duration(seconds)*time.Second)
+}
+
+// armNightTimer starts a night countdown of the given length; a resumed game uses it to carry
+// on with the time that was left when it was paused.
+func (h *Hub) armNightTimer(gameID int64, round int, d time.Duration) {
+	t := &nightTimer{gameID: gameID, round: round, deadline: time.Now().Add(d), stop: make(chan struct{})}
+
+	h.timerMu.Lock()
+	if h.nightTimer != nil {
+		close(h.nightTimer.stop)
+	}
+	h.nightTimer = t
+	h.timerMu.Unlock()
+
+	h.logf("Night %d timer started: %s", round, formatCountdown(d))
+	h.wg.Add(1)
+	go h.runNightTimer(t)
+}
+
+// runNightTimer pushes the countdown every second and resolves the night when it runs out.
+// It stops on its own once the night ends some other way.
+func (h *Hub) runNightTimer(t *nightTimer) {
+	defer h.wg.Done()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			game, err := h.getGame()
+			if err != nil || game.ID != t.gameID || game.Status != "night" || game.Round != t.round {
+				return
+			}
+			left := time.Until(t.deadline)
+			if left <= 0 {
+				h.moveMu.Lock()
+				h.expireNight(t.gameID, t.round)
+				h.moveMu.Unlock()
+				return
+			}
+			h.pushNightCountdown(left)
+		}
+	}
+}
+
+// nightTimeLeft returns the countdown shown on the night screen, "" when the night has no timer.
+func (h *Hub) nightTimeLeft(game *Game) string {
+	h.timerMu.Lock()
+	defer h.timerMu.Unlock()
+	t := h.nightTimer
+	if t == nil || t.gameID != game.ID || t.round != game.Round {
+		return ""
+	}
+	return formatCountdown(time.Until(t.deadline))
+}
+
+// pushNightCountdown sends every client the updated countdown as an out-of-band swap, so the
+// clock ticks without re-rendering the whole night.
+func (h *Hub) pushNightCountdown(left time.Duration) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, client := range h.clients {
+		lang := client.lang
+		if l, ok := h.playerLang[client.playerID]; ok {
+			lang = l
+		}
+		msg := fmt.Sprintf(`<span id="night-timer" class="night-timer" hx-swap-oob="true">%s</span>`, T(lang, "night_timer_left", formatCountdown(left)))
+		h.queue(client, hubMsg{data: []byte(msg)})
+	}
+}
+
+// expireNight ends a night whose timer ran out.
+func (h *Hub) expireNight(gameID int64, round int) {
+	h.endNight(gameID, round, false)
+}
+
+// endNight ends the night early, because its timer ran out or the host moved on: every
+// living player still owing an action has it skipped, the night resolves with whatever was
+// chosen so far (the pack's votes count as cast, without End Vote), missing surveys are
+// submitted empty and the day begins.
+func (h *Hub) endNight(gameID int64, round int, byHost bool) {
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("endNight: getGame", err)
+		return
+	}
+	if game.ID != gameID || game.Status != "night" || game.Round != round {
+		return
+	}
+
+	// every step ready means the night was already resolved by its last action
+	resolved := nightReady(h, game)
+
+	players, err := getPlayersByGameId(h.db, game.ID)
+	if err != nil {
+		h.logError("endNight: getPlayersByGameId", err)
+		return
+	}
+	desc, key := "Night %d: Time ran out before you acted", "hist_night_timed_out"
+	if byHost {
+		desc, key = "Night %d: The host ended the night before you acted", "hist_night_ended_by_host"
+	}
+	for _, p := range players {
+		if !p.IsAlive || playerDoneWithNightAction(h.db, game.ID, game.Round, p) {
+			continue
+		}
+		h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description, description_key, description_args) VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?)`,
+			game.ID, game.Round, p.PlayerID, ActionNightTimedOut, VisibilityActor, fmt.Sprintf(desc, game.Round), key, histArgs(game.Round))
+		h.logf("Night %d ended early: '%s' (%s) did not act", game.Round, p.Name, p.RoleName)
+	}
+	if !resolved {
+		h.resolveNight(game)
+	}
+
+	for _, p := range players {
+		if p.IsAlive {
+			h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, '')`,
+				game.ID, game.Round, p.PlayerID, ActionNightSurveyApplySuspect, VisibilityResolved)
+		}
+	}
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND action_type=?`,
+		game.ID, game.Round, ActionNightSurveySelectSuspect)
+
+	h.logf("Night %d ended early", game.Round)
+	h.applyDawn(game)
+	h.triggerBroadcast()
+}
+
+// handleWSSetNightTimer picks the night length in the lobby; it applies to every night of the game.
+func handleWSSetNightTimer(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSetNightTimer: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+
+	seconds, err := strconv.Atoi(msg.Seconds)
+	valid := false
+	for _, s := range nightTimerChoices {
+		valid = valid || (err == nil && s == seconds)
+	}
+	if !valid {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_night_timer"))
+		return
+	}
+
+	if _, err := h.db.Exec("UPDATE game SET night_timer = ? WHERE rowid = ?", seconds, game.ID); err != nil {
+		h.logError("handleWSSetNightTimer: db.Exec", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_night_timer"))
+		return
+	}
+	h.logf("Night timer set to %ds", seconds)
+	h.triggerBroadcast()
+}
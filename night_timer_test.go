@@ -0,0 +1,110 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Night Timer Tests
+// ============================================================================
+
+func TestSetNightTimerInLobby(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"P1", "P2"}, []string{RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(ids[0], WSMessage{Action: "set_night_timer", Seconds: "90"})
+	if s := nightTimerSeconds(ctx.app.db, game.ID); s != 90 {
+		t.Fatalf("the night timer should be 90s, got %d", s)
+	}
+	ctx.sendWS(ids[0], WSMessage{Action: "set_night_timer", Seconds: "45"})
+	if s := nightTimerSeconds(ctx.app.db, game.ID); s != 90 {
+		t.Errorf("a length the lobby does not offer should be rejected, got %d", s)
+	}
+}
+
+func TestNightTimerExpirySkipsMissingActions(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf1", "Wolf2", "Seer", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleWerewolf, RoleSeer, RoleVillager, RoleVillager, RoleVillager})
+	wolf1, wolf2, seer, v1 := ids[0], ids[1], ids[2], ids[3]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET night_timer = 60 WHERE rowid = ?", game.ID)
+
+	ctx.hub().startNightTimer(game.ID, 1)
+	buf, err := getGameComponent(ctx.hub(), v1, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="night-timer"`) {
+		t.Fatalf("the night screen should show the countdown (err: %v)", err)
+	}
+
+	// the pack agrees but never presses End Vote, and the Seer never acts
+	ctx.sendWS(wolf1, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf2, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.hub().expireNight(game.ID, 1)
+
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Fatalf("the night should resolve when the timer runs out, got %q", status)
+	}
+	if ctx.isPlayerAlive(v1) {
+		t.Error("the pack's majority should still land without End Vote")
+	}
+	if !strings.Contains(ctx.historyFor(seer), "Time ran out") {
+		t.Errorf("the Seer should learn their action was skipped, got %q", ctx.historyFor(seer))
+	}
+	if strings.Contains(ctx.historyFor(ids[4]), "Time ran out") {
+		t.Error("players without a night action should not be marked as timed out")
+	}
+
+	// a timer firing for a night that already ended changes nothing
+	ctx.hub().expireNight(game.ID, 1)
+	if status, round, _ := ctx.gameState(); status != "day" || round != 1 {
+		t.Errorf("a stale timer should be ignored, got %s %d", status, round)
+	}
+}
+
+func TestNightTimerCountsDownInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the night countdown set in the lobby ===")
+
+	var players []*TestPlayer
+	for _, name := range []string{"NT1", "NT2", "NT3"} {
+		players = append(players, browser.signupPlayer(ctx.baseURL, name))
+	}
+	host := players[0]
+	host.toggleLobbySetting("night-timer-60")
+	host.addRoleByID(RoleWerewolf)
+	host.addRoleByID(RoleVillager)
+	host.addRoleByID(RoleVillager)
+	host.startGame()
+	waitForNightPhaseAll(ctx, players)
+
+	timer, err := players[1].p().Element("#night-timer")
+	if err != nil {
+		ctx.logger.LogDB("FAIL: no night countdown")
+		t.Fatalf("the night screen should show the countdown: %v", err)
+	}
+	first, _ := timer.Text()
+	if !strings.HasSuffix(first, "left") {
+		t.Errorf("the countdown should say how much time is left, got %q", first)
+	}
+	if err := players[1].waitUntilCondition(`() => { const el = document.querySelector('#night-timer'); return el && el.textContent.trim() !== '`+first+`'; }`, "countdown ticks"); err != nil {
+		t.Errorf("the countdown should tick down: %v", err)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
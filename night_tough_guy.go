@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// woundToughGuy absorbs the pack's kill when it lands on a Tough Guy: instead of a pending
+// kill, a wound is recorded with an empty description until dawn, like night kills, and the
+// Tough Guy dies when the following day ends.
+func (h *Hub) woundToughGuy(tx *sqlx.Tx, game *Game, victim int64) (bool, error) {
+	if getRoleName(h.db, game.ID, victim) != "Tough Guy" || powerDisabled(h.db, game.ID, "Tough Guy") {
+		return false, nil
+	}
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+		game.ID, game.Round, victim, ActionToughGuyWounded, victim, VisibilityActor); err != nil {
+		return false, err
+	}
+	h.logf("Tough Guy '%s' survives the attack but is mortally wounded", h.playerName(victim))
+	return true, nil
+}
+
+// revealToughGuyWounds fills in tonight's pending wounds at dawn, so only the Tough Guy
+// learns that they were attacked.
+func revealToughGuyWounds(d *dawn, game *Game) error {
+	var woundedIDs []int64
+	d.tx.Select(&woundedIDs, `SELECT target_player_id FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND description=''`,
+		game.ID, game.Round, ActionToughGuyWounded)
+	for _, id := range woundedIDs {
+		desc := fmt.Sprintf("Night %d: The werewolves attacked you — you survived, but will not live past the end of the day", game.Round)
+		if _, err := d.tx.Exec(`UPDATE game_action SET description=?, description_key=?, description_args=? WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND target_player_id=?`,
+			desc, "hist_tough_guy_wounded", histArgs(game.Round), game.ID, game.Round, ActionToughGuyWounded, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isToughGuyWounded reports whether the player took a wolf attack last night and will die as today ends.
+func isToughGuyWounded(db *sqlx.DB, gameID int64, round int, playerID int64) bool {
+	var n int
+	db.Get(&n, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=? AND target_player_id=?`,
+		gameID, round, ActionToughGuyWounded, playerID)
+	return n > 0
+}
+
+// applyToughGuyDeaths kills every Tough Guy wounded last night as the day ends and runs the
+// usual day death chain. It returns true when the day must not end yet: the game is over,
+// or a Hunter died with them and still has to shoot.
+func (h *Hub) applyToughGuyDeaths(game *Game) bool {
+	var woundedIDs []int64
+	h.db.Select(&woundedIDs, `
+SELECT a.target_player_id FROM game_action a
+JOIN game_player g ON g.game_id = a.game_id AND g.player_id = a.target_player_id
+WHERE a.game_id = ? AND a.round = ? AND a.phase = 'night' AND a.action_type = ? AND g.is_alive = 1`,
+		game.ID, game.Round, ActionToughGuyWounded)
+	if len(woundedIDs) == 0 {
+		return false
+	}
+
+	for _, id := range woundedIDs {
+		if _, err := h.db.Exec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, id); err != nil {
+			h.logError("applyToughGuyDeaths: kill player", err)
+			continue
+		}
+		name := h.playerName(id)
+		desc := fmt.Sprintf("Day %d: %s (Tough Guy) succumbed to their wounds", game.Round, name)
+		h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args) VALUES (?, ?, 'day', ?, ?, ?, ?, ?, ?, ?)`,
+			game.ID, game.Round, id, ActionToughGuyDied, id, VisibilityPublic, desc, "hist_tough_guy_died", histArgs(game.Round, name))
+		h.logf("Tough Guy '%s' succumbed to their wounds", name)
+	}
+
+	heartbroken := h.applyHeartbreaks(game, "day", woundedIDs)
+	h.inheritDoppelgangerRoles(game, "day")
+	h.promoteApprenticeSeer(game, "day")
+	h.awakenWildChildren(game, "day")
+
+	for _, id := range heartbroken {
+		if getRoleName(h.db, game.ID, id) == "Hunter" && !powerDisabled(h.db, game.ID, "Hunter") {
+			h.logf("Hunter '%s' died of heartbreak at day's end — waiting for revenge shot", h.playerName(id))
+			h.triggerBroadcast()
+			return true
+		}
+	}
+
+	return h.checkWinConditions(game)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Tough Guy Tests
+// ============================================================================
+
+func TestToughGuySurvivesNightDiesAtDayEnd(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Tough", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleToughGuy, RoleVillager, RoleVillager, RoleVillager})
+	wolf, tough := ids[0], ids[1]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(tough, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if !ctx.isPlayerAlive(tough) {
+		t.Fatal("the Tough Guy should survive the night")
+	}
+	if h := ctx.historyFor(tough); !strings.Contains(h, "will not live past the end of the day") {
+		t.Errorf("the Tough Guy should learn of the wound, got: %q", h)
+	}
+	if h := ctx.historyFor(ids[2]); strings.Contains(h, "attacked") {
+		t.Errorf("the wound must stay private, villager sees: %q", h)
+	}
+
+	game, _ := ctx.hub().getGame()
+	buf, err := getGameComponent(ctx.hub(), tough, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "tough-guy-wounded") {
+		t.Errorf("the day view should show the pending death (err: %v)", err)
+	}
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_pass"})
+	}
+	ctx.sendWS(wolf, WSMessage{Action: "day_end_vote"})
+
+	if ctx.isPlayerAlive(tough) {
+		t.Error("the Tough Guy should die as the day ends")
+	}
+	if h := ctx.historyFor(ids[2]); !strings.Contains(h, "Tough (Tough Guy) succumbed to their wounds") {
+		t.Errorf("the delayed death should be public, got: %q", h)
+	}
+	game, _ = ctx.hub().getGame()
+	if game.Status != "night" || game.Round != 2 {
+		t.Errorf("expected night 2 after the delayed death, got %s %d", game.Status, game.Round)
+	}
+}
+
+func TestToughGuyDelayedDeathCanEndGame(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Tough", "V1"},
+		[]string{RoleWerewolf, RoleToughGuy, RoleVillager})
+	tough := ids[1]
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE player_id = ?", ids[2])
+	ctx.app.db.MustExec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description)
+		SELECT rowid, 1, 'night', ?, ?, ?, ?, 'wounded' FROM game`, tough, ActionToughGuyWounded, tough, VisibilityActor)
+
+	game, _ := ctx.hub().getGame()
+	ctx.hub().transitionToNight(game)
+
+	if _, _, winner := ctx.gameState(); winner != "werewolves" {
+		t.Errorf("the last villager dying at day's end should hand the wolves the win, got %q", winner)
+	}
+}
+
+func TestToughGuySurvivesUntilDuskInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Tough Guy survives the night and dies at the end of the day ===")
+
+	// Setup: 1 werewolf + 1 tough guy + 3 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"TG1", "TG2", "TG3", "TG4", "TG5"},
+		RoleWerewolf, RoleToughGuy, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Tough Guy"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	toughGuy, werewolf, villager := byRole["Tough Guy"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+
+	werewolf.voteForPlayer(toughGuy.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if !villager.hasNoDeathMessage() {
+		ctx.logger.LogDB("FAIL: tough guy died at dawn")
+		t.Errorf("The Tough Guy should survive the night, got: %s", villager.getDeathAnnouncement())
+	}
+	if !toughGuy.historyContains("you survived, but will not live past the end of the day") {
+		ctx.logger.LogDB("FAIL: tough guy not told of the wound")
+		t.Errorf("Tough Guy should see the wound in history, got: %s", toughGuy.getHistoryText())
+	}
+
+	// The village passes; the wound takes the Tough Guy at the end of the day
+	passDayForAll(players)
+	waitForNightPhaseAll(ctx, players)
+
+	entry := toughGuy.Name + " (Tough Guy) succumbed to their wounds"
+	if !villager.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: tough guy did not die at the end of the day")
+		t.Errorf("Everyone should see %q in history, got: %s", entry, villager.getHistoryText())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -21,10 +21,28 @@ type WerewolfNightData struct {
 	WolfEndVoted2      bool
 	WolfTargetCards    []PlayerCardData
 	WolfTargetCards2   []PlayerCardData
+	WolvesSick         bool // the pack killed the Diseased last night and cannot hunt tonight
+	WolfChat           []ChatMessage
+}
+
+// wolfCubDiedLastRound reports whether a Wolf Cub was killed during the previous round,
+// which gives the pack a second kill tonight.
+func wolfCubDiedLastRound(db *sqlx.DB, gameID int64, round int) bool {
+	var n int
+	db.Get(&n, `
+SELECT COUNT(*) FROM game_action ga
+JOIN game_player gp ON ga.target_player_id = gp.player_id AND gp.game_id = ga.game_id
+JOIN role r ON gp.role_id = r.rowid
+WHERE ga.game_id = ? AND ga.round = ?
+AND ga.action_type IN (?, ?, ?, ?, ?, ?)
+AND r.name = 'Wolf Cub'`,
+		gameID, round-1, ActionWerewolfSelectKill, ActionDayApplyKill, ActionHunterApplyKill, ActionWitchApplyKill,
+		ActionSerialKillerApplyKill, ActionWhiteWolfApplyKill)
+	return n > 0
 }
 
 func buildWerewolfNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string, aliveTargets []Player) WerewolfNightData {
-	if player.Team != "werewolf" {
+	if !inWolfPack(player) {
 		return WerewolfNightData{}
 	}
 
@@ -66,19 +84,11 @@ WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
 		}
 	}
 
+	wolvesSick := wolvesSkipNight(db, game.ID, game.Round)
 	wolfCubDoubleKill := false
 	var currentVotePlayer2 *Player
-	if game.Round > 1 {
-		var wolfCubDeathCount int
-		db.Get(&wolfCubDeathCount, `
-SELECT COUNT(*) FROM game_action ga
-JOIN game_player gp ON ga.target_player_id = gp.player_id AND gp.game_id = ga.game_id
-JOIN role r ON gp.role_id = r.rowid
-WHERE ga.game_id = ? AND ga.round = ?
-AND ga.action_type IN (?, ?, ?, ?)
-AND r.name = 'Wolf Cub'`,
-			game.ID, game.Round-1, ActionWerewolfSelectKill, ActionDayApplyKill, ActionHunterApplyKill, ActionWitchApplyKill)
-		wolfCubDoubleKill = wolfCubDeathCount > 0
+	if game.Round > 1 && !wolvesSick {
+		wolfCubDoubleKill = wolfCubDiedLastRound(db, game.ID, game.Round)
 
 		if wolfCubDoubleKill {
 			var vote2Action GameAction
@@ -96,7 +106,7 @@ WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND
 	var werewolfCount int
 	db.Get(&werewolfCount, `
 SELECT COUNT(*) FROM game_player gp JOIN role r ON gp.role_id = r.rowid
-WHERE gp.game_id = ? AND gp.is_alive = 1 AND r.team = 'werewolf'`, game.ID)
+WHERE gp.game_id = ? AND gp.is_alive = 1 AND `+wolfPackSQL, game.ID)
 
 	var voted1 int
 	db.Get(&voted1, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
@@ -121,6 +131,8 @@ WHERE gp.game_id = ? AND gp.is_alive = 1 AND r.team = 'werewolf'`, game.ID)
 	}
 
 	return WerewolfNightData{
+		WolfChat:           chatMessages(db, game.ID, ChatChannelWerewolf),
+		WolvesSick:         wolvesSick,
 		WerewolfVoteCounts: werewolfVoteCounts,
 		VotersByTarget:     votersByTarget,
 		PassVoters:         passVoters,
@@ -156,7 +168,7 @@ func handleWSWerewolfVote(client *Client, msg WSMessage) {
 		return
 	}
 
-	if voter.Team != "werewolf" {
+	if !inWolfPack(voter) {
 		h.sendErrorToast(client.playerID, T(lang, "err_only_werewolves_vote"))
 		return
 	}
@@ -166,6 +178,11 @@ func handleWSWerewolfVote(client *Client, msg WSMessage) {
 		return
 	}
 
+	if wolvesSkipNight(h.db, game.ID, game.Round) {
+		h.sendErrorToast(client.playerID, T(lang, "err_wolves_sick"))
+		return
+	}
+
 	var endVoteCount int
 	h.db.Get(&endVoteCount, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
 		game.ID, game.Round, ActionWerewolfApplyKill)
@@ -251,7 +268,7 @@ func handleWSWerewolfVote2(client *Client, msg WSMessage) {
 		return
 	}
 
-	if voter.Team != "werewolf" {
+	if !inWolfPack(voter) {
 		h.sendErrorToast(client.playerID, T(lang, "err_only_werewolves_vote"))
 		return
 	}
@@ -261,6 +278,11 @@ func handleWSWerewolfVote2(client *Client, msg WSMessage) {
 		return
 	}
 
+	if wolvesSkipNight(h.db, game.ID, game.Round) {
+		h.sendErrorToast(client.playerID, T(lang, "err_wolves_sick"))
+		return
+	}
+
 	if game.Round <= 1 {
 		h.sendErrorToast(client.playerID, T(lang, "err_wolfcub_not_active"))
 		return
@@ -344,7 +366,7 @@ func handleWSWerewolfPass(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
 		return
 	}
-	if voter.Team != "werewolf" {
+	if !inWolfPack(voter) {
 		h.sendErrorToast(client.playerID, T(lang, "err_only_werewolves_vote"))
 		return
 	}
@@ -352,6 +374,11 @@ func handleWSWerewolfPass(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_vote"))
 		return
 	}
+
+	if wolvesSkipNight(h.db, game.ID, game.Round) {
+		h.sendErrorToast(client.playerID, T(lang, "err_wolves_sick"))
+		return
+	}
 	var endVoteCount int
 	h.db.Get(&endVoteCount, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
 		game.ID, game.Round, ActionWerewolfApplyKill)
@@ -395,7 +422,7 @@ func handleWSWerewolfPass2(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
 		return
 	}
-	if voter.Team != "werewolf" {
+	if !inWolfPack(voter) {
 		h.sendErrorToast(client.playerID, T(lang, "err_only_werewolves_vote"))
 		return
 	}
@@ -403,6 +430,11 @@ func handleWSWerewolfPass2(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_vote"))
 		return
 	}
+
+	if wolvesSkipNight(h.db, game.ID, game.Round) {
+		h.sendErrorToast(client.playerID, T(lang, "err_wolves_sick"))
+		return
+	}
 	var endVote2Count int
 	h.db.Get(&endVote2Count, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
 		game.ID, game.Round, ActionWerewolfApplyKill2)
@@ -446,10 +478,14 @@ func handleWSWerewolfEndVote(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
 		return
 	}
-	if voter.Team != "werewolf" {
+	if !inWolfPack(voter) {
 		h.sendErrorToast(client.playerID, T(lang, "err_only_werewolves_end_vote"))
 		return
 	}
+	if wolvesSkipNight(h.db, game.ID, game.Round) {
+		h.sendErrorToast(client.playerID, T(lang, "err_wolves_sick"))
+		return
+	}
 
 	var werewolves []Player
 	h.db.Select(&werewolves, `
@@ -457,7 +493,7 @@ SELECT g.rowid as id, g.player_id as player_id, p.name as name
 FROM game_player g
 JOIN player p ON g.player_id = p.rowid
 JOIN role r ON g.role_id = r.rowid
-WHERE g.game_id = ? AND g.is_alive = 1 AND r.team = 'werewolf'`, game.ID)
+WHERE g.game_id = ? AND g.is_alive = 1 AND `+wolfPackSQL+` AND `+skippedTonightSQL, game.ID, game.Round)
 
 	var totalActed int
 	h.db.Get(&totalActed, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
@@ -503,10 +539,14 @@ func handleWSWerewolfEndVote2(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
 		return
 	}
-	if voter.Team != "werewolf" {
+	if !inWolfPack(voter) {
 		h.sendErrorToast(client.playerID, T(lang, "err_only_werewolves_end_vote"))
 		return
 	}
+	if wolvesSkipNight(h.db, game.ID, game.Round) {
+		h.sendErrorToast(client.playerID, T(lang, "err_wolves_sick"))
+		return
+	}
 
 	var werewolves []Player
 	h.db.Select(&werewolves, `
@@ -514,7 +554,7 @@ SELECT g.rowid as id, g.player_id as player_id, p.name as name
 FROM game_player g
 JOIN player p ON g.player_id = p.rowid
 JOIN role r ON g.role_id = r.rowid
-WHERE g.game_id = ? AND g.is_alive = 1 AND r.team = 'werewolf'`, game.ID)
+WHERE g.game_id = ? AND g.is_alive = 1 AND `+wolfPackSQL+` AND `+skippedTonightSQL, game.ID, game.Round)
 
 	var totalActed2 int
 	h.db.Get(&totalActed2, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND round = ? AND phase = 'night' AND action_type = ?`,
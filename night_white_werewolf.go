@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type WhiteWolfNightData struct {
+	WhiteWolfCanKill     bool // an even night with a fellow wolf still alive
+	WhiteWolfDone        bool
+	WhiteWolfSelected    *Player // pending, or confirmed once the kill is set
+	WhiteWolfTargets     []Player
+	WhiteWolfTargetCards []PlayerCardData
+}
+
+// whiteWolfNight reports whether the White Werewolf may turn on the pack tonight: every second night.
+func whiteWolfNight(round int) bool {
+	return round%2 == 0
+}
+
+// whiteWolfTargets returns the living pack members the White Werewolf could kill tonight.
+func whiteWolfTargets(db *sqlx.DB, gameID, whiteWolfID int64) []int64 {
+	var ids []int64
+	db.Select(&ids, `
+SELECT g.player_id FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND g.is_alive = 1 AND g.player_id != ? AND `+wolfPackSQL, gameID, whiteWolfID)
+	return ids
+}
+
+// winTeam is the side a player wins with. The White Werewolf hunts with the pack under
+// team 'werewolf', but only ever wins alone.
+func winTeam(roleName, team string) string {
+	if roleName == "White Werewolf" {
+		return "white_werewolf"
+	}
+	return team
+}
+
+// whiteWolfOwesKill reports whether a White Werewolf still has to choose tonight: on an even
+// night with a fellow wolf alive, they must kill or spare before the night can resolve.
+func whiteWolfOwesKill(db *sqlx.DB, gameID int64, round int, playerID int64) bool {
+	if !whiteWolfNight(round) || len(whiteWolfTargets(db, gameID, playerID)) == 0 {
+		return false
+	}
+	var n int
+	db.Get(&n, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		gameID, round, playerID, ActionWhiteWolfApplyKill)
+	return n == 0
+}
+
+func buildWhiteWolfNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string) WhiteWolfNightData {
+	if player.RoleName != "White Werewolf" || !whiteWolfNight(game.Round) {
+		return WhiteWolfNightData{}
+	}
+	targetIDs := whiteWolfTargets(db, game.ID, playerID)
+	if len(targetIDs) == 0 {
+		return WhiteWolfNightData{}
+	}
+
+	d := WhiteWolfNightData{WhiteWolfCanKill: true}
+	var action GameAction
+	if db.Get(&action, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, playerID, ActionWhiteWolfApplyKill) == nil {
+		d.WhiteWolfDone = true
+		if action.TargetPlayerID != nil {
+			d.WhiteWolfSelected = getVisiblePlayer(db, game.ID, *action.TargetPlayerID, player, seerInvestigated)
+		}
+		return d
+	}
+
+	var selectAction GameAction
+	if db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, playerID, ActionWhiteWolfSelectKill) == nil && selectAction.TargetPlayerID != nil {
+		d.WhiteWolfSelected = getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated)
+	}
+
+	for _, id := range targetIDs {
+		if p := getVisiblePlayer(db, game.ID, id, player, seerInvestigated); p != nil {
+			d.WhiteWolfTargets = append(d.WhiteWolfTargets, *p)
+		}
+	}
+	return d
+}
+
+func handleWSWhiteWolfSelect(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSWhiteWolfSelect: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+	whiteWolf, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSWhiteWolfSelect: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if whiteWolf.RoleName != "White Werewolf" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_white_wolf"))
+		return
+	}
+	if !whiteWolf.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	if !whiteWolfNight(game.Round) {
+		h.sendErrorToast(client.playerID, T(lang, "err_white_wolf_not_tonight"))
+		return
+	}
+	var existingCount int
+	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionWhiteWolfApplyKill)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_white_wolf_done"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive || targetID == client.playerID || !inWolfPack(target) {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var existing GameAction
+	selectErr := h.db.Get(&existing, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionWhiteWolfSelectKill)
+	if selectErr == nil && existing.TargetPlayerID != nil && *existing.TargetPlayerID == targetID {
+		// clicking the same target again deselects it
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionWhiteWolfSelectKill)
+		h.logf("White Werewolf '%s' deselected packmate", whiteWolf.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionWhiteWolfSelectKill, targetID, VisibilityActor)
+		h.logf("White Werewolf '%s' selected packmate %d", whiteWolf.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+// handleWSWhiteWolfKill confirms tonight's choice; with spare set, the White Werewolf
+// lets the pack live and the row is recorded without a target.
+func handleWSWhiteWolfKill(client *Client, spare bool) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSWhiteWolfKill: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+
+	whiteWolf, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSWhiteWolfKill: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	if whiteWolf.RoleName != "White Werewolf" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_white_wolf"))
+		return
+	}
+
+	if !whiteWolf.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	if !whiteWolfNight(game.Round) {
+		h.sendErrorToast(client.playerID, T(lang, "err_white_wolf_not_tonight"))
+		return
+	}
+
+	var existingCount int
+	h.db.Get(&existingCount, `
+SELECT COUNT(*) FROM game_action
+WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, game.Round, client.playerID, ActionWhiteWolfApplyKill)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_white_wolf_done"))
+		return
+	}
+
+	var targetID any
+	desc := fmt.Sprintf("Night %d: You spared the pack tonight", game.Round)
+	key, args := "hist_white_wolf_spared", histArgs(game.Round)
+	if !spare {
+		var selectAction GameAction
+		if err := h.db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionWhiteWolfSelectKill); err != nil || selectAction.TargetPlayerID == nil {
+			h.sendErrorToast(client.playerID, T(lang, "err_white_wolf_select_first"))
+			return
+		}
+		target, err := getPlayerInGame(h.db, game.ID, *selectAction.TargetPlayerID)
+		if err != nil || !target.IsAlive || !inWolfPack(target) {
+			h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+			return
+		}
+		targetID = target.PlayerID
+		desc = fmt.Sprintf("Night %d: You turned on the pack and chose %s as your victim", game.Round, target.Name)
+		key, args = "hist_white_wolf_kill", histArgs(game.Round, target.Name)
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionWhiteWolfSelectKill)
+
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionWhiteWolfApplyKill, targetID, VisibilityActor, desc, key, args)
+	if err != nil {
+		h.logError("handleWSWhiteWolfKill: db.Exec insert kill", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_white_wolf"))
+		return
+	}
+
+	h.logf("White Werewolf '%s' made their choice (spare: %v)", whiteWolf.Name, spare)
+	DebugLog("handleWSWhiteWolfKill", "White Werewolf '%s' made their choice (spare: %v)", whiteWolf.Name, spare)
+	LogDBState(h.db, "after white werewolf kill")
+
+	h.resolveWerewolfVotes(game)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// White Werewolf Helpers
+// ============================================================================
+
+// whiteWolfKillPlayer selects a fellow wolf for the White Werewolf and clicks the Kill button.
+func (tp *TestPlayer) whiteWolfKillPlayer(targetName string) {
+	tp.selectAndConfirm("white-wolf-select-form-", targetName, "#white-wolf-kill-btn")
+}
+
+// ============================================================================
+// White Werewolf Tests
+// ============================================================================
+
+func TestWhiteWolfKillsPackmateOnEvenNight(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 2,
+		[]string{"Wolf", "White", "V1", "V2", "V3", "V4"},
+		[]string{RoleWerewolf, RoleWhiteWolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf, white, v1 := ids[0], ids[1], ids[2]
+
+	for _, id := range []int64{wolf, white} {
+		ctx.sendWS(id, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	}
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("the night should wait for the White Werewolf, got %d pending kills", n)
+	}
+
+	ctx.sendWS(white, WSMessage{Action: "white_wolf_select", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(white, WSMessage{Action: "white_wolf_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(white, WSMessage{Action: "white_wolf_kill"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if ctx.isPlayerAlive(wolf) || ctx.isPlayerAlive(v1) {
+		t.Error("both the packmate and the pack's victim should die at dawn")
+	}
+	if h := ctx.historyFor(white); !strings.Contains(h, "turned on the pack") {
+		t.Errorf("the White Werewolf should see the kill in history, got: %q", h)
+	}
+	if status, _, _ := ctx.gameState(); status != "day" {
+		t.Errorf("the game should continue while villagers remain, got %q", status)
+	}
+}
+
+func TestWhiteWolfCannotActOnOddNight(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "White", "V1", "V2"},
+		[]string{RoleWerewolf, RoleWhiteWolf, RoleVillager, RoleVillager})
+	wolf, white := ids[0], ids[1]
+
+	game, _ := ctx.hub().getGame()
+	player, _ := getPlayerInGame(ctx.app.db, game.ID, white)
+	if buildWhiteWolfNightData(ctx.app.db, game, white, player, nil).WhiteWolfCanKill {
+		t.Error("the White Werewolf should only turn on the pack every second night")
+	}
+	ctx.sendWS(white, WSMessage{Action: "white_wolf_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(white, WSMessage{Action: "white_wolf_kill"})
+	if n := ctx.countActions(ActionWhiteWolfApplyKill); n != 0 {
+		t.Errorf("no kill should be recorded on an odd night, got %d", n)
+	}
+}
+
+func TestWhiteWolfWinsAsSoleSurvivor(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 2,
+		[]string{"Wolf", "White", "V1"},
+		[]string{RoleWerewolf, RoleWhiteWolf, RoleVillager})
+	wolf, white, v1 := ids[0], ids[1], ids[2]
+
+	for _, id := range []int64{wolf, white} {
+		ctx.sendWS(id, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	}
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	ctx.sendWS(white, WSMessage{Action: "white_wolf_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(white, WSMessage{Action: "white_wolf_kill"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	if _, _, winner := ctx.gameState(); winner != "white_werewolf" {
+		t.Fatalf("the sole survivor should win alone, got %q", winner)
+	}
+	if playerWon("werewolves", winTeam("White Werewolf", "werewolf"), true) {
+		t.Error("the White Werewolf must not share a pack win")
+	}
+}
+
+func TestWhiteWerewolfTurnsOnPackInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing White Werewolf kills a fellow wolf on night 2 ===")
+
+	// Setup: 1 white werewolf + 1 werewolf + 4 villagers = 6 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"WW1", "WW2", "WW3", "WW4", "WW5", "WW6"},
+		RoleWhiteWolf, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["White Werewolf"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) < 3 {
+		t.Fatal("Missing required roles")
+	}
+	whiteWolf, werewolf := byRole["White Werewolf"][0], byRole["Werewolf"][0]
+	villagers := byRole["Villager"]
+
+	// Night 1: the White Werewolf hunts with the pack and has no kill of their own
+	if found, _, _ := whiteWolf.p().Has("#white-wolf-section"); found {
+		ctx.logger.LogDB("FAIL: white werewolf can kill on night 1")
+		t.Errorf("White Werewolf should only turn on the pack on even nights")
+	}
+	whiteWolf.voteForPlayer(villagers[0].Name)
+	werewolf.voteForPlayer(villagers[0].Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	// Day 1: the village passes
+	var alive []*TestPlayer
+	for _, p := range players {
+		if p != villagers[0] {
+			alive = append(alive, p)
+		}
+	}
+	passDayForAll(alive)
+	waitForNightPhaseAll(ctx, players)
+
+	// Night 2: the White Werewolf kills the other wolf, the pack kills a villager
+	whiteWolf.whiteWolfKillPlayer(werewolf.Name)
+	entry := "You turned on the pack and chose " + werewolf.Name + " as your victim"
+	if !whiteWolf.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: white werewolf cannot see the kill in history")
+		t.Errorf("White Werewolf should see %q in history, got: %s", entry, whiteWolf.getHistoryText())
+	}
+	if werewolf.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: werewolf can see the white werewolf's kill")
+		t.Errorf("The pack should not learn of the White Werewolf's kill")
+	}
+	whiteWolf.voteForPlayer(villagers[1].Name)
+	werewolf.voteForPlayer(villagers[1].Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, alive)
+
+	deaths := whiteWolf.getDeathAnnouncement()
+	if !strings.Contains(deaths, werewolf.Name) || !strings.Contains(deaths, villagers[1].Name) {
+		ctx.logger.LogDB("FAIL: white werewolf's victim did not die")
+		t.Errorf("Both %s and %s should have died, got: %s", werewolf.Name, villagers[1].Name, deaths)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
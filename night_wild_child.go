@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type WildChildNightData struct {
+	WildChildHasChosen      bool
+	WildChildSelectedPlayer *Player // pending, or the role model once chosen
+	WildChildResultCard     *PlayerCardData
+	WildChildTargetCards    []PlayerCardData
+}
+
+// roleModelOf returns the Wild Child's role model, or 0 before one is chosen.
+func roleModelOf(db *sqlx.DB, gameID, childID int64) int64 {
+	var modelID int64
+	db.Get(&modelID, `SELECT model_player_id FROM game_role_model WHERE game_id = ? AND child_player_id = ?`, gameID, childID)
+	return modelID
+}
+
+func buildWildChildNightData(db *sqlx.DB, game *Game, playerID int64, player Player, seerInvestigated map[int64]string) WildChildNightData {
+	if player.RoleName != "Wild Child" {
+		return WildChildNightData{}
+	}
+
+	if modelID := roleModelOf(db, game.ID, playerID); modelID != 0 {
+		return WildChildNightData{
+			WildChildHasChosen:      true,
+			WildChildSelectedPlayer: getVisiblePlayer(db, game.ID, modelID, player, seerInvestigated),
+		}
+	}
+
+	var selectAction GameAction
+	if db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, playerID, ActionWildChildSelectModel) == nil && selectAction.TargetPlayerID != nil {
+		return WildChildNightData{
+			WildChildSelectedPlayer: getVisiblePlayer(db, game.ID, *selectAction.TargetPlayerID, player, seerInvestigated),
+		}
+	}
+
+	return WildChildNightData{}
+}
+
+// awakenWildChildren turns every living Wild Child whose role model has died into a werewolf.
+// Like promoteApprenticeSeer it runs after every death path, so the Wild Child hunts with the
+// pack from the next night on. Only the Wild Child is told.
+func (h *Hub) awakenWildChildren(game *Game, phase string) {
+	var children []struct {
+		ChildID int64  `db:"child_player_id"`
+		ModelID int64  `db:"model_player_id"`
+		Model   string `db:"model_name"`
+	}
+	h.db.Select(&children, `
+SELECT m.child_player_id, m.model_player_id, p.name as model_name FROM game_role_model m
+JOIN game_player child ON child.game_id = m.game_id AND child.player_id = m.child_player_id
+JOIN role r ON child.role_id = r.rowid
+JOIN game_player model ON model.game_id = m.game_id AND model.player_id = m.model_player_id
+JOIN player p ON p.rowid = m.model_player_id
+WHERE m.game_id = ? AND child.is_alive = 1 AND r.name = 'Wild Child' AND model.is_alive = 0`, game.ID)
+
+	for _, c := range children {
+		if err := joinPack(h.db, game, c.ChildID); err != nil {
+			h.logError("awakenWildChildren: convert wild child", err)
+			continue
+		}
+		h.warnStaleSeers(game, c.ChildID)
+
+		histKey := "hist_wild_child_turned_night"
+		desc := fmt.Sprintf("Night %d: Your role model %s is dead — you join the werewolves", game.Round, c.Model)
+		if phase == "day" {
+			histKey = "hist_wild_child_turned_day"
+			desc = fmt.Sprintf("Day %d: Your role model %s is dead — you join the werewolves", game.Round, c.Model)
+		}
+		h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			game.ID, game.Round, phase, c.ChildID, ActionWildChildTurned, c.ModelID, VisibilityActor, desc, histKey, histArgs(game.Round, c.Model))
+
+		toastMsg := T(h.getPlayerLang(c.ChildID), "toast_wild_child_turned", c.Model)
+		h.sendToPlayer(c.ChildID, []byte(renderToast(h.templates, h.logf, "warning", toastMsg)))
+
+		h.logf("Wild Child '%s' lost their role model '%s' and is now a Werewolf", h.playerName(c.ChildID), c.Model)
+	}
+}
+
+func handleWSWildChildSelect(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSWildChildSelect: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+	child, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSWildChildSelect: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+	if child.RoleName != "Wild Child" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_wild_child"))
+		return
+	}
+	if !child.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+	if game.Round != 1 {
+		h.sendErrorToast(client.playerID, T(lang, "err_wild_child_night_1"))
+		return
+	}
+	if roleModelOf(h.db, game.ID, client.playerID) != 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_wild_child_done"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	target, err := getPlayerInGame(h.db, game.ID, targetID)
+	if err != nil || !target.IsAlive || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	var existing GameAction
+	selectErr := h.db.Get(&existing, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionWildChildSelectModel)
+	if selectErr == nil && existing.TargetPlayerID != nil && *existing.TargetPlayerID == targetID {
+		// clicking the same target again deselects it
+		h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+			game.ID, game.Round, client.playerID, ActionWildChildSelectModel)
+		h.logf("Wild Child '%s' deselected role model", child.Name)
+	} else {
+		h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description) VALUES (?, ?, 'night', ?, ?, ?, ?, '')`,
+			game.ID, game.Round, client.playerID, ActionWildChildSelectModel, targetID, VisibilityActor)
+		h.logf("Wild Child '%s' selected role model %d", child.Name, targetID)
+	}
+
+	h.triggerBroadcast()
+}
+
+func handleWSWildChildChoose(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSWildChildChoose: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "night" {
+		h.sendErrorToast(client.playerID, T(lang, "err_night_phase_act"))
+		return
+	}
+
+	child, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSWildChildChoose: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	if child.RoleName != "Wild Child" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_wild_child"))
+		return
+	}
+
+	if !child.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
+		return
+	}
+
+	if game.Round != 1 {
+		h.sendErrorToast(client.playerID, T(lang, "err_wild_child_night_1"))
+		return
+	}
+
+	if roleModelOf(h.db, game.ID, client.playerID) != 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_wild_child_done"))
+		return
+	}
+
+	var selectAction GameAction
+	if err := h.db.Get(&selectAction, `
+SELECT rowid as id, game_id, round, phase, actor_player_id, action_type, target_player_id, visibility
+FROM game_action
+WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionWildChildSelectModel); err != nil || selectAction.TargetPlayerID == nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_wild_child_select_first"))
+		return
+	}
+
+	target, err := getPlayerInGame(h.db, game.ID, *selectAction.TargetPlayerID)
+	if err != nil || !target.IsAlive {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+
+	if _, err := h.db.Exec(`INSERT INTO game_role_model (game_id, child_player_id, model_player_id) VALUES (?, ?, ?)`,
+		game.ID, client.playerID, target.PlayerID); err != nil {
+		h.logError("handleWSWildChildChoose: db.Exec insert role model", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_wild_child"))
+		return
+	}
+
+	h.db.Exec(`DELETE FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
+		game.ID, game.Round, client.playerID, ActionWildChildSelectModel)
+
+	desc := fmt.Sprintf("Night %d: You chose %s as your role model", game.Round, target.Name)
+	h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+VALUES (?, ?, 'night', ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, client.playerID, ActionWildChildApplyModel, target.PlayerID, VisibilityActor, desc, "hist_wild_child_model", histArgs(game.Round, target.Name))
+
+	h.logf("Wild Child '%s' chose '%s' as their role model", child.Name, target.Name)
+	DebugLog("handleWSWildChildChoose", "Wild Child '%s' chose '%s'", child.Name, target.Name)
+	LogDBState(h.db, "after wild child choice")
+
+	h.resolveWerewolfVotes(game)
+}
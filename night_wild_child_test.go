@@ -0,0 +1,142 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Wild Child Helpers
+// ============================================================================
+
+// wildChildChoosePlayer selects a role model for the Wild Child and clicks the Choose button.
+func (tp *TestPlayer) wildChildChoosePlayer(targetName string) {
+	tp.selectAndConfirm("wild-child-select-form-", targetName, "#wild-child-choose-button")
+}
+
+// ============================================================================
+// Wild Child Tests
+// ============================================================================
+
+func TestWildChildTurnsWhenModelKilledAtNight(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Child", "V1", "V2", "V3", "V4"},
+		[]string{RoleWerewolf, RoleWildChild, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	wolf, child, v1 := ids[0], ids[1], ids[2]
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	if n := ctx.countActions(ActionNightApplyKill); n != 0 {
+		t.Fatalf("the night should wait for the Wild Child, got %d pending kills", n)
+	}
+
+	ctx.sendWS(child, WSMessage{Action: "wild_child_select", TargetPlayerID: strconv.FormatInt(child, 10)})
+	ctx.sendWS(child, WSMessage{Action: "wild_child_choose"})
+	if n := ctx.countActions(ActionWildChildApplyModel); n != 0 {
+		t.Fatal("the Wild Child must not pick themselves")
+	}
+	ctx.sendWS(child, WSMessage{Action: "wild_child_select", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(child, WSMessage{Action: "wild_child_choose"})
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+
+	game, _ := ctx.hub().getGame()
+	player, _ := getPlayerInGame(ctx.app.db, game.ID, child)
+	if player.RoleName != "Werewolf" {
+		t.Errorf("the Wild Child should turn once the role model dies, got %q", player.RoleName)
+	}
+	if h := ctx.historyFor(child); !strings.Contains(h, "role model V1 is dead") {
+		t.Errorf("the Wild Child should be told, got: %q", h)
+	}
+	if h := ctx.historyFor(ids[3]); strings.Contains(h, "role model") {
+		t.Errorf("the turn must stay private, villager sees: %q", h)
+	}
+}
+
+func TestWildChildTurnsWhenModelLynched(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Child", "V1", "V2", "V3", "V4"},
+		[]string{RoleWerewolf, RoleWildChild, RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	child, v1, v2 := ids[1], ids[2], ids[3]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("INSERT INTO game_role_model (game_id, child_player_id, model_player_id) VALUES (?, ?, ?)", game.ID, child, v1)
+
+	// someone else dying changes nothing
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	}
+	ctx.sendWS(ids[0], WSMessage{Action: "day_end_vote"})
+	if player, _ := getPlayerInGame(ctx.app.db, game.ID, child); player.RoleName != "Wild Child" {
+		t.Fatalf("the Wild Child should stay put while the role model lives, got %q", player.RoleName)
+	}
+
+	ctx.app.db.MustExec("UPDATE game SET status = 'day', round = 2 WHERE rowid = ?", game.ID)
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	}
+	ctx.sendWS(ids[0], WSMessage{Action: "day_end_vote"})
+	if player, _ := getPlayerInGame(ctx.app.db, game.ID, child); player.RoleName != "Werewolf" {
+		t.Errorf("the Wild Child should turn as soon as the role model is lynched, got %q", player.RoleName)
+	}
+}
+
+func TestWildChildJoinsPackInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing Wild Child turns werewolf when their role model is killed ===")
+
+	// Setup: 1 werewolf + 1 wild child + 3 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"WC1", "WC2", "WC3", "WC4", "WC5"},
+		RoleWerewolf, RoleWildChild, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Wild Child"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) == 0 {
+		t.Fatal("Missing required roles")
+	}
+	child, werewolf, model := byRole["Wild Child"][0], byRole["Werewolf"][0], byRole["Villager"][0]
+	ctx.logger.Debug("Wild Child: %s, role model: %s", child.Name, model.Name)
+
+	child.wildChildChoosePlayer(model.Name)
+	entry := "You chose " + model.Name + " as your role model"
+	if !child.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: wild child cannot see the choice in history")
+		t.Errorf("Wild Child should see %q in history, got: %s", entry, child.getHistoryText())
+	}
+	if model.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: role model can see the choice in history")
+		t.Errorf("The role model should not learn they were chosen")
+	}
+
+	// The werewolf kills the role model
+	werewolf.voteForPlayer(model.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	if err := child.waitForRole("Werewolf"); err != nil {
+		ctx.logger.LogDB("FAIL: wild child did not join the pack")
+		t.Fatalf("Wild Child should be a Werewolf once their role model died, got: %s", child.getRole())
+	}
+	turned := "Your role model " + model.Name + " is dead — you join the werewolves"
+	if !child.historyContains(turned) {
+		ctx.logger.LogDB("FAIL: wild child not told of turning")
+		t.Errorf("Wild Child should see %q in history, got: %s", turned, child.getHistoryText())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -20,6 +20,7 @@ type WitchNightData struct {
 	WitchPoisonedPlayer      *Player
 	WitchDoneThisNight       bool
 	WitchHealCards           []PlayerCardData
+	WolfTargetLocked         bool // the pack has locked tonight's targets, so the heal can be decided
 	WitchPoisonCards         []PlayerCardData
 }
 
@@ -83,67 +84,14 @@ WHERE game_id = ? AND round = ? AND phase = 'night' AND actor_player_id = ? AND
 		game.ID, game.Round, playerID, ActionWitchApply)
 	d.WitchDoneThisNight = doneCount > 0
 
-	// Witch sees the wolf victim only after End Vote is pressed
-	var endVoteCount int
-	db.Get(&endVoteCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=?`,
-		game.ID, game.Round, ActionWerewolfApplyKill)
-	if endVoteCount > 0 {
-		type voteCount struct {
-			TargetPlayerID int64 `db:"target_player_id"`
-			Count          int   `db:"count"`
-		}
-		var wvotes []voteCount
-		db.Select(&wvotes, `
-SELECT ga.target_player_id, COUNT(*) as count
-FROM game_action ga
-WHERE ga.game_id = ? AND ga.round = ? AND ga.phase = 'night' AND ga.action_type = ?
-GROUP BY ga.target_player_id
-ORDER BY count DESC`,
-			game.ID, game.Round, ActionWerewolfSelectKill)
-
-		var totalWerewolves int
-		db.Get(&totalWerewolves, `
-SELECT COUNT(*) FROM game_player gp JOIN role r ON gp.role_id = r.rowid
-WHERE gp.game_id = ? AND gp.is_alive = 1 AND r.team = 'werewolf'`, game.ID)
-
-		if len(wvotes) > 0 && totalWerewolves > 0 {
-			majority := totalWerewolves/2 + 1
-			if wvotes[0].Count >= majority {
-				d.WerewolfVictimPlayer = getVisiblePlayer(db, game.ID, wvotes[0].TargetPlayerID, player, seerInvestigated)
-			}
-		}
-
-		// Wolf Cub second victim — only visible after End Vote 2 is also pressed
-		if game.Round > 1 && totalWerewolves > 0 {
-			var wolfCubDeathCount int
-			db.Get(&wolfCubDeathCount, `
-SELECT COUNT(*) FROM game_action ga
-JOIN game_player gp ON ga.target_player_id = gp.player_id AND gp.game_id = ga.game_id
-JOIN role r ON gp.role_id = r.rowid
-WHERE ga.game_id = ? AND ga.round = ?
-AND ga.action_type IN (?, ?, ?, ?)
-AND r.name = 'Wolf Cub'`,
-				game.ID, game.Round-1, ActionWerewolfSelectKill, ActionDayApplyKill, ActionHunterApplyKill, ActionWitchApplyKill)
-			if wolfCubDeathCount > 0 {
-				var endVote2Count int
-				db.Get(&endVote2Count, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=?`,
-					game.ID, game.Round, ActionWerewolfApplyKill2)
-				if endVote2Count > 0 {
-					var wvotes2 []voteCount
-					db.Select(&wvotes2, `
-SELECT ga.target_player_id, COUNT(*) as count
-FROM game_action ga
-WHERE ga.game_id = ? AND ga.round = ? AND ga.phase = 'night' AND ga.action_type = ?
-GROUP BY ga.target_player_id
-ORDER BY count DESC`,
-						game.ID, game.Round, ActionWerewolfSelectKill2)
-					majority := totalWerewolves/2 + 1
-					if len(wvotes2) > 0 && wvotes2[0].Count >= majority {
-						d.WerewolfVictimPlayer2 = getVisiblePlayer(db, game.ID, wvotes2[0].TargetPlayerID, player, seerInvestigated)
-					}
-				}
-			}
-		}
+	// the pack's targets are published to the Witch once End Vote locks them in
+	d.WolfTargetLocked = wolfTargetsLocked(db, game)
+	victim, victim2 := lockedWolfTargets(db, game)
+	if victim != 0 {
+		d.WerewolfVictimPlayer = getVisiblePlayer(db, game.ID, victim, player, seerInvestigated)
+	}
+	if victim2 != 0 {
+		d.WerewolfVictimPlayer2 = getVisiblePlayer(db, game.ID, victim2, player, seerInvestigated)
 	}
 
 	return d
@@ -176,6 +124,10 @@ func handleWSWitchSelectHeal(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
 		return
 	}
+	if powerDisabled(h.db, game.ID, witch.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
 	var appliedCount int
 	h.db.Get(&appliedCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
 		game.ID, game.Round, client.playerID, ActionWitchApply)
@@ -244,6 +196,10 @@ func handleWSWitchSelectPoison(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
 		return
 	}
+	if powerDisabled(h.db, game.ID, witch.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
 	var appliedCount int
 	h.db.Get(&appliedCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
 		game.ID, game.Round, client.playerID, ActionWitchApply)
@@ -312,6 +268,10 @@ func handleWSWitchApply(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_dead_cannot_act"))
 		return
 	}
+	if powerDisabled(h.db, game.ID, witch.RoleName) {
+		h.sendErrorToast(client.playerID, T(lang, "err_powers_lost"))
+		return
+	}
 	var appliedCount int
 	h.db.Get(&appliedCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_type=?`,
 		game.ID, game.Round, client.playerID, ActionWitchApply)
@@ -319,6 +279,14 @@ func handleWSWitchApply(client *Client, msg WSMessage) {
 		h.sendErrorToast(client.playerID, T(lang, "err_already_submitted_night"))
 		return
 	}
+	// while the heal potion is unused, the Witch cannot finish before the pack locks its targets
+	var healUsed int
+	h.db.Get(&healUsed, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND actor_player_id=? AND action_type=?`,
+		game.ID, client.playerID, ActionWitchApplyProtect)
+	if healUsed == 0 && !wolfTargetsLocked(h.db, game) {
+		h.sendErrorToast(client.playerID, T(lang, "err_werewolves_not_locked"))
+		return
+	}
 
 	var healAction GameAction
 	if err := h.db.Get(&healAction, `
@@ -328,9 +296,6 @@ WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_t
 		game.ID, game.Round, client.playerID, ActionWitchSelectProtect); err == nil && healAction.TargetPlayerID != nil {
 
 		targetID := *healAction.TargetPlayerID
-		var healUsed int
-		h.db.Get(&healUsed, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND actor_player_id=? AND action_type=?`,
-			game.ID, client.playerID, ActionWitchApplyProtect)
 		if healUsed > 0 {
 			h.sendErrorToast(client.playerID, T(lang, "err_heal_already_used"))
 			return
@@ -339,17 +304,8 @@ WHERE game_id=? AND round=? AND phase='night' AND actor_player_id=? AND action_t
 			h.sendErrorToast(client.playerID, T(lang, "err_cannot_heal_self"))
 			return
 		}
-		var endVoteCount int
-		h.db.Get(&endVoteCount, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type=?`,
-			game.ID, game.Round, ActionWerewolfApplyKill)
-		if endVoteCount == 0 {
-			h.sendErrorToast(client.playerID, T(lang, "err_werewolves_not_locked"))
-			return
-		}
-		var isVictim int
-		h.db.Get(&isVictim, `SELECT COUNT(*) FROM game_action WHERE game_id=? AND round=? AND phase='night' AND action_type IN (?,?) AND target_player_id=?`,
-			game.ID, game.Round, ActionWerewolfSelectKill, ActionWerewolfSelectKill2, targetID)
-		if isVictim == 0 {
+		victim, victim2 := lockedWolfTargets(h.db, game)
+		if targetID != victim && targetID != victim2 {
 			h.sendErrorToast(client.playerID, T(lang, "err_heal_must_target_werewolf"))
 			return
 		}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -87,7 +88,7 @@ func TestWitchHealSavesVictim(t *testing.T) {
 	)
 
 	// Witch clicks Done to apply and end night
-	witch.waitUntilCondition(`() => !!document.querySelector('#witch-apply-button')`, "witch apply button visible")
+	witch.waitUntilCondition(`() => !!document.querySelector('#witch-apply-button:not([disabled])')`, "witch apply button enabled")
 	witch.clickAndWait("#witch-apply-button")
 	// Wait for server to confirm apply (shows waiting or survey form)
 	witch.waitUntilCondition(
@@ -198,7 +199,7 @@ func TestWitchPoisonKillsPlayer(t *testing.T) {
 	)
 
 	// Witch clicks Done to apply and end night
-	witch.waitUntilCondition(`() => !!document.querySelector('#witch-apply-button')`, "witch apply button visible")
+	witch.waitUntilCondition(`() => !!document.querySelector('#witch-apply-button:not([disabled])')`, "witch apply button enabled")
 	witch.clickAndWait("#witch-apply-button")
 	// Wait for server to confirm apply (shows waiting or survey form)
 	witch.waitUntilCondition(
@@ -327,7 +328,7 @@ func TestWitchPassEndNight(t *testing.T) {
 	}
 
 	// Witch clicks Done without using any potions
-	witch.waitUntilCondition(`() => !!document.querySelector('#witch-apply-button')`, "witch apply button visible")
+	witch.waitUntilCondition(`() => !!document.querySelector('#witch-apply-button:not([disabled])')`, "witch apply button enabled")
 	witch.clickAndWait("#witch-apply-button")
 
 	submitNightSurveysForAllPlayers(players)
@@ -357,3 +358,44 @@ func TestWitchPassEndNight(t *testing.T) {
 
 	ctx.logger.Debug("=== Test passed ===")
 }
+
+func TestWitchWaitsForLockedWolfTarget(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Wolf", "Witch", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RoleWitch, RoleVillager, RoleVillager, RoleVillager})
+	wolf, witch, v1 := ids[0], ids[1], ids[2]
+	game, _ := ctx.hub().getGame()
+
+	// a vote alone is not locked in: the Witch sees no victim and cannot finish yet
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	buf, err := getGameComponent(ctx.hub(), witch, game, "en")
+	if err != nil || strings.Contains(buf.String(), "witch-heal-targets") {
+		t.Fatalf("the victim should stay hidden until End Vote (err: %v)", err)
+	}
+	ctx.sendWS(witch, WSMessage{Action: "witch_apply"})
+	if n := ctx.countActions(ActionWitchApply); n != 0 {
+		t.Fatalf("the Witch should not finish before the wolves lock their target, got %d", n)
+	}
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_end_vote"})
+	buf, err = getGameComponent(ctx.hub(), witch, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "witch-heal-targets") || !strings.Contains(buf.String(), "player-name=\"V1\"") {
+		t.Fatalf("the locked victim should be published to the Witch (err: %v)", err)
+	}
+
+	ctx.sendWS(witch, WSMessage{Action: "witch_select_heal", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	ctx.sendWS(witch, WSMessage{Action: "witch_apply"})
+	if n := ctx.countActions(ActionWitchApplyProtect); n != 1 {
+		t.Fatalf("the Witch should heal the locked victim, got %d heals", n)
+	}
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "night_survey"})
+	}
+	if !ctx.isPlayerAlive(v1) {
+		t.Error("the healed victim should survive the night")
+	}
+}
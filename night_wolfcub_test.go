@@ -213,7 +213,7 @@ func TestWitchSavesSecondVictimInWolfCubDoubleKill(t *testing.T) {
 	wolfCub.voteForPlayer(wolfCub.Name)
 	werewolf.voteForPlayer(wolfCub.Name)
 	// Witch clicks Done without using any potions
-	witch.waitUntilCondition(`() => !!document.querySelector('#witch-apply-button')`, "witch apply button visible")
+	witch.waitUntilCondition(`() => !!document.querySelector('#witch-apply-button:not([disabled])')`, "witch apply button enabled")
 	witch.clickAndWait("#witch-apply-button")
 
 	submitNightSurveysForAllPlayers(players)
@@ -267,7 +267,7 @@ func TestWitchSavesSecondVictimInWolfCubDoubleKill(t *testing.T) {
 	witch.clickAndWait("[id^='witch-select-heal-form-'] .player-card[player-name='" + victim2.Name + "']")
 
 	// Witch clicks Done to apply and end night
-	witch.waitUntilCondition(`() => !!document.querySelector('#witch-apply-button')`, "witch apply button visible")
+	witch.waitUntilCondition(`() => !!document.querySelector('#witch-apply-button:not([disabled])')`, "witch apply button enabled")
 	witch.clickAndWait("#witch-apply-button")
 
 	submitNightSurveysForAllPlayers(players)
@@ -126,7 +126,7 @@ in {
       after       = [ "network.target" ];
 
       environment = {
-        ADDR = cfg.listenAddr;
+        LISTEN = cfg.listenAddr;
         # WAL mode is important for SQLite under concurrent WebSocket load.
         DB = "file:/var/lib/werewolf/werewolf.db?cache=shared&_journal_mode=WAL";
       }
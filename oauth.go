@@ -0,0 +1,308 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// OAuth sign-in is an alternative to name + secret code, so a group that meets every week
+// keeps its accounts on any device. A provider is offered once its client id and secret are
+// configured. /auth/{provider} sends the visitor to the provider with a random state kept
+// in a cookie; /auth/{provider}/callback checks the state, trades the code for an access
+// token, reads the user's id and name and signs in the player that table player_oauth maps
+// the id to. An unknown id is linked to the signed-in player, if there is one, or gets a
+// new player named after the account; such a player has no secret code.
+
+const oauthTimeout = 10 * time.Second
+
+const oauthStateCookie = "werewolf_oauth_state"
+
+type oauthProvider struct {
+	Name         string // in the routes and player_oauth.provider
+	Label        string // on the buttons
+	AuthURL      string
+	TokenURL     string
+	UserURL      string
+	Scope        string
+	ClientID     string
+	ClientSecret string
+	// user reads the external id and a display name from the user endpoint's reply.
+	user func(body []byte) (id, name string, err error)
+}
+
+// OAuthButton is a configured provider on the start page.
+type OAuthButton struct {
+	Name   string
+	Label  string
+	Linked bool // the signed-in player already signs in with it
+}
+
+func googleUser(body []byte) (string, string, error) {
+	var u struct {
+		Sub       string `json:"sub"`
+		Name      string `json:"name"`
+		GivenName string `json:"given_name"`
+	}
+	if err := json.Unmarshal(body, &u); err != nil {
+		return "", "", err
+	}
+	if u.GivenName != "" {
+		return u.Sub, u.GivenName, nil
+	}
+	return u.Sub, u.Name, nil
+}
+
+func discordUser(body []byte) (string, string, error) {
+	var u struct {
+		ID         string `json:"id"`
+		Username   string `json:"username"`
+		GlobalName string `json:"global_name"`
+	}
+	if err := json.Unmarshal(body, &u); err != nil {
+		return "", "", err
+	}
+	if u.GlobalName != "" {
+		return u.ID, u.GlobalName, nil
+	}
+	return u.ID, u.Username, nil
+}
+
+// oauthProvidersFromConfig returns the providers whose client id and secret are set.
+func oauthProvidersFromConfig(cfg AppConfig) []*oauthProvider {
+	var providers []*oauthProvider
+	if cfg.GoogleClientID != "" && cfg.GoogleClientSecret != "" {
+		providers = append(providers, &oauthProvider{
+			Name:         "google",
+			Label:        "Google",
+			AuthURL:      "https://accounts.google.com/o/oauth2/v2/auth",
+			TokenURL:     "https://oauth2.googleapis.com/token",
+			UserURL:      "https://openidconnect.googleapis.com/v1/userinfo",
+			Scope:        "openid profile",
+			ClientID:     cfg.GoogleClientID,
+			ClientSecret: cfg.GoogleClientSecret,
+			user:         googleUser,
+		})
+	}
+	if cfg.DiscordClientID != "" && cfg.DiscordClientSecret != "" {
+		providers = append(providers, &oauthProvider{
+			Name:         "discord",
+			Label:        "Discord",
+			AuthURL:      "https://discord.com/oauth2/authorize",
+			TokenURL:     "https://discord.com/api/oauth2/token",
+			UserURL:      "https://discord.com/api/users/@me",
+			Scope:        "identify",
+			ClientID:     cfg.DiscordClientID,
+			ClientSecret: cfg.DiscordClientSecret,
+			user:         discordUser,
+		})
+	}
+	return providers
+}
+
+func (app *App) oauthProvider(name string) *oauthProvider {
+	for _, p := range app.oauthProviders {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// oauthButtons lists the providers for the start page, marking the ones playerID has linked.
+func (app *App) oauthButtons(playerID int64) []OAuthButton {
+	var linked []string
+	if playerID > 0 {
+		app.db.Select(&linked, "SELECT provider FROM player_oauth WHERE player_id = ?", playerID)
+	}
+	buttons := make([]OAuthButton, 0, len(app.oauthProviders))
+	for _, p := range app.oauthProviders {
+		b := OAuthButton{Name: p.Name, Label: p.Label}
+		for _, l := range linked {
+			b.Linked = b.Linked || l == p.Name
+		}
+		buttons = append(buttons, b)
+	}
+	return buttons
+}
+
+// oauthRedirectURL is where the provider sends the visitor back: under public_url when it
+// is set, else under the host the request came to.
+func (app *App) oauthRedirectURL(r *http.Request, p *oauthProvider) string {
+	base := strings.TrimSuffix(app.publicURL, "/")
+	if base == "" {
+		scheme := "http"
+		if isHTTPS(r) {
+			scheme = "https"
+		}
+		base = scheme + "://" + r.Host
+	}
+	return base + "/auth/" + p.Name + "/callback"
+}
+
+// handleOAuthStart sends the visitor to the provider; ?game= is where they land afterwards.
+func (app *App) handleOAuthStart(w http.ResponseWriter, r *http.Request) {
+	p := app.oauthProvider(r.PathValue("provider"))
+	if p == nil {
+		http.NotFound(w, r)
+		return
+	}
+	b := make([]byte, 16)
+	rand.Read(b)
+	state := hex.EncodeToString(b)
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    state + ":" + url.QueryEscape(r.URL.Query().Get("game")),
+		Path:     "/auth/",
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+	q := url.Values{
+		"client_id":     {p.ClientID},
+		"redirect_uri":  {app.oauthRedirectURL(r, p)},
+		"response_type": {"code"},
+		"scope":         {p.Scope},
+		"state":         {state},
+	}
+	http.Redirect(w, r, p.AuthURL+"?"+q.Encode(), http.StatusSeeOther)
+}
+
+// handleOAuthCallback signs in the player behind the external account the provider
+// confirmed, making or linking one the first time.
+func (app *App) handleOAuthCallback(w http.ResponseWriter, r *http.Request) {
+	p := app.oauthProvider(r.PathValue("provider"))
+	if p == nil {
+		http.NotFound(w, r)
+		return
+	}
+	cookie, err := r.Cookie(oauthStateCookie)
+	if err != nil {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: oauthStateCookie, Value: "", Path: "/auth/", MaxAge: -1, HttpOnly: true})
+	state, game, _ := strings.Cut(cookie.Value, ":")
+	gameName, _ := url.QueryUnescape(game)
+	if subtle.ConstantTimeCompare([]byte(state), []byte(r.URL.Query().Get("state"))) != 1 || r.URL.Query().Get("code") == "" {
+		// a wrong state or a refused consent: back to the sign-in
+		app.logf("OAuth %s: sign-in not completed (%s)", p.Name, r.URL.Query().Get("error"))
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	externalID, name, err := app.oauthUser(r, p, r.URL.Query().Get("code"))
+	if err != nil {
+		app.logf("ERROR [handleOAuthCallback: oauthUser %s]: %v", p.Name, err)
+		http.Error(w, "Sign-in failed", http.StatusBadGateway)
+		return
+	}
+	currentID, _ := getPlayerIdFromSession(app.db, r)
+	playerID, err := oauthPlayer(app.db, p.Name, externalID, name, currentID)
+	if err != nil {
+		app.logf("ERROR [handleOAuthCallback: oauthPlayer]: %v", err)
+		http.Error(w, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+	if playerID != currentID {
+		if err := setSessionCookie(app.db, w, r, playerID); err != nil {
+			app.logf("ERROR [handleOAuthCallback: setSessionCookie]: %v", err)
+			http.Error(w, "Something went wrong", http.StatusInternalServerError)
+			return
+		}
+	}
+	app.logf("Player signed in with %s: id=%d", p.Name, playerID)
+
+	target := "/"
+	if gameName != "" {
+		target = "/game/" + gameName
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+// oauthUser trades the authorization code for an access token and reads the user with it.
+func (app *App) oauthUser(r *http.Request, p *oauthProvider, code string) (id, name string, err error) {
+	client := &http.Client{Timeout: oauthTimeout}
+	resp, err := client.PostForm(p.TokenURL, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {app.oauthRedirectURL(r, p)},
+		"client_id":     {p.ClientID},
+		"client_secret": {p.ClientSecret},
+	})
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil || resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		return "", "", fmt.Errorf("token endpoint: status %d, %v", resp.StatusCode, err)
+	}
+
+	req, _ := http.NewRequest("GET", p.UserURL, nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	resp, err = client.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("user endpoint: status %d, %v", resp.StatusCode, err)
+	}
+	if id, name, err = p.user(body); err == nil && id == "" {
+		err = fmt.Errorf("user endpoint: no id")
+	}
+	return id, name, err
+}
+
+// oauthPlayer returns the player an external account signs in as. The first time the
+// account is linked to currentID when that is a player, or else to a new player with the
+// account's name (numbered when the name is taken).
+func oauthPlayer(db *sqlx.DB, provider, externalID, name string, currentID int64) (int64, error) {
+	var playerID int64
+	err := db.Get(&playerID, `
+SELECT o.player_id FROM player_oauth o JOIN player p ON p.rowid = o.player_id
+WHERE o.provider = ? AND o.external_id = ?`, provider, externalID)
+	if err != sql.ErrNoRows {
+		return playerID, err
+	}
+	db.Exec("DELETE FROM player_oauth WHERE provider = ? AND external_id = ?", provider, externalID)
+
+	if currentID > 0 {
+		playerID = currentID
+	} else {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = provider
+		}
+		candidate := name
+		for n := 2; ; n++ {
+			if _, err := getPlayerByName(db, candidate); err == sql.ErrNoRows {
+				break
+			}
+			candidate = name + " " + strconv.Itoa(n)
+		}
+		result, err := db.Exec("INSERT INTO player (name, secret_code) VALUES (?, '')", candidate)
+		if err != nil {
+			return 0, err
+		}
+		playerID, _ = result.LastInsertId()
+	}
+	_, err = db.Exec("INSERT INTO player_oauth (provider, external_id, player_id) VALUES (?, ?, ?)", provider, externalID, playerID)
+	return playerID, err
+}
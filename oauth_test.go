@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// OAuth Sign-in Tests
+// ============================================================================
+
+// fakeOAuthProvider answers the token and user endpoints for one user, who is "Alice"
+// with the id "ext-1" for the code "good".
+func fakeOAuthProvider(t *testing.T) (*oauthProvider, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if r.FormValue("code") != "good" || r.FormValue("client_secret") != "secret" {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"access_token": "tok", "token_type": "Bearer"})
+		case "/user":
+			if r.Header.Get("Authorization") != "Bearer tok" {
+				http.Error(w, "", http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"id": "ext-1", "username": "Alice"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return &oauthProvider{
+		Name: "fake", Label: "Fake",
+		AuthURL: srv.URL + "/authorize", TokenURL: srv.URL + "/token", UserURL: srv.URL + "/user",
+		Scope: "identify", ClientID: "client", ClientSecret: "secret",
+		user: discordUser,
+	}, srv.Close
+}
+
+func TestOAuthSignsInTheSamePlayerEachTime(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+	provider, stop := fakeOAuthProvider(t)
+	defer stop()
+	ctx.app.oauthProviders = []*oauthProvider{provider}
+	ctx.apiClient() // waits for the server
+	ctx.app.db.MustExec("INSERT INTO player (name, secret_code) VALUES ('Alice', '')")
+
+	// signIn goes through the provider in a fresh browser and returns where it landed.
+	signIn := func(code string) (*http.Client, string) {
+		jar, _ := cookiejar.New(nil)
+		c := &http.Client{Jar: jar, CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+		resp, err := c.Get(ctx.baseURL + "/auth/fake?game=test-game")
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+		resp.Body.Close()
+		to, _ := url.Parse(resp.Header.Get("Location"))
+		if !strings.HasPrefix(to.String(), provider.AuthURL) || to.Query().Get("redirect_uri") != ctx.baseURL+"/auth/fake/callback" {
+			t.Fatalf("the visitor should be sent to the provider, got %q", to)
+		}
+		resp, err = c.Get(ctx.baseURL + "/auth/fake/callback?code=" + code + "&state=" + to.Query().Get("state"))
+		if err != nil {
+			t.Fatalf("callback: %v", err)
+		}
+		resp.Body.Close()
+		return c, resp.Header.Get("Location")
+	}
+
+	c, landed := signIn("good")
+	if landed != "/game/test-game" {
+		t.Fatalf("the player should land in the game, got %q", landed)
+	}
+	var first int64
+	ctx.app.db.Get(&first, "SELECT player_id FROM player_oauth WHERE provider = 'fake' AND external_id = 'ext-1'")
+	if name := getPlayerName(ctx.app.db, first); name != "Alice 2" {
+		t.Errorf("the taken name should get a number, got %q", name)
+	}
+	if code := ctx.apiCall(c, "GET", "/api/v1/games", nil, nil); code != http.StatusOK {
+		t.Errorf("the player should be signed in, got %d", code)
+	}
+
+	signIn("good")
+	var count int
+	ctx.app.db.Get(&count, "SELECT COUNT(*) FROM player WHERE name LIKE 'Alice%'")
+	if count != 2 {
+		t.Errorf("signing in again should not make another player, got %d Alices", count)
+	}
+
+	if _, landed := signIn("bad"); landed != "" {
+		t.Errorf("a refused code should not sign in, got a redirect to %q", landed)
+	}
+	jar, _ := cookiejar.New(nil)
+	forged := &http.Client{Jar: jar, CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, _ := forged.Get(ctx.baseURL + "/auth/fake/callback?code=good&state=forged")
+	resp.Body.Close()
+	if resp.Header.Get("Location") != "/" || len(resp.Cookies()) > 0 {
+		t.Errorf("a callback without the state cookie should go back to the sign-in")
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// Observers are game_player rows with is_observer set: they watch the game without a card.
+// A player switches to watching in the lobby; once the game starts observers are dealt no
+// role and are not alive, so every count of the living (votes, night actions, the win
+// conditions) leaves them out. getPlayersByGameId skips them, so they never show as a
+// player card either. They see what the living village sees, or every role when the lobby
+// lets observers and the dead see everything.
+
+// getObserversByGameId returns the game's observers, shaped like getPlayersByGameId's rows.
+func getObserversByGameId(db *sqlx.DB, id int64) ([]Player, error) {
+	var observers []Player
+	err := db.Select(&observers, `
+		SELECT gp.rowid as id,
+			gp.game_id as game_id,
+			p.rowid as player_id,
+			p.name as name,
+			r.rowid as role_id,
+			r.name as role_name,
+			r.description as role_description,
+			r.team as team,
+			gp.is_alive as is_alive,
+			gp.is_observer as is_observer,
+			0 as lover,
+			0 as is_doppelganger,
+			p.profile_image_id as profile_image_id
+		FROM game_player gp
+			JOIN player p on gp.player_id = p.rowid
+			JOIN role r on gp.role_id = r.rowid
+		WHERE gp.game_id = ? AND gp.is_observer = 1
+		ORDER BY gp.rowid`, id)
+	return observers, err
+}
+
+// observerNames lists the game's observers for the lobby.
+func observerNames(db *sqlx.DB, gameID int64) []string {
+	var names []string
+	db.Select(&names, `SELECT p.name FROM game_player gp JOIN player p ON p.rowid = gp.player_id
+		WHERE gp.game_id = ? AND gp.is_observer = 1 ORDER BY gp.rowid`, gameID)
+	return names
+}
+
+// isObserver reports whether the player watches the game.
+func isObserver(db *sqlx.DB, gameID, playerID int64) bool {
+	var observer bool
+	db.Get(&observer, "SELECT is_observer FROM game_player WHERE game_id = ? AND player_id = ?", gameID, playerID)
+	return observer
+}
+
+// observersSeeAllEnabled reports whether observers and dead players see every role.
+func observersSeeAllEnabled(db *sqlx.DB, gameID int64) bool {
+	var enabled bool
+	db.Get(&enabled, "SELECT observers_see_all FROM game WHERE rowid = ?", gameID)
+	return enabled
+}
+
+// viewerReveal is how much of other players' cards the viewer sees: RevealAll for the
+// narrator, or for an observer or a dead player once the game is running and lets them see
+// everything, the game's death reveal policy otherwise.
+func viewerReveal(db *sqlx.DB, gameID int64, viewer Player) string {
+	seesAll := (viewer.IsObserver || !viewer.IsAlive) && observersSeeAllEnabled(db, gameID)
+	if seesAll || (viewer.IsObserver && isNarrator(db, gameID, viewer.PlayerID)) {
+		var started bool
+		db.Get(&started, "SELECT status != 'lobby' FROM game WHERE rowid = ?", gameID)
+		if started {
+			return RevealAll
+		}
+	}
+	return roleReveal(db, gameID)
+}
+
+// seatedPlayerCount is how many players in the game take a seat, i.e. need a role card.
+func seatedPlayerCount(db *sqlx.DB, gameID int64) int {
+	var count int
+	db.Get(&count, "SELECT COUNT(*) FROM game_player WHERE game_id = ? AND is_observer = 0", gameID)
+	return count
+}
+
+// addObserver lets a player who arrives once the game is running watch it. It reports
+// whether they were new to the game.
+func addObserver(db *sqlx.DB, gameID, playerID int64) (bool, error) {
+	result, err := db.Exec("INSERT OR IGNORE INTO game_player (game_id, player_id, is_alive, is_observer) VALUES (?, ?, 0, 1)", gameID, playerID)
+	if err != nil {
+		return false, err
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// seatObservers takes the observers out of the game's living players when it starts.
+func seatObservers(db *sqlx.DB, gameID int64) error {
+	_, err := db.Exec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND is_observer = 1", gameID)
+	return err
+}
+
+// handleWSToggleObserver switches the player between playing and watching in the lobby.
+func handleWSToggleObserver(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleObserver: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game_player SET is_observer = NOT is_observer WHERE game_id = ? AND player_id = ?", game.ID, client.playerID); err != nil {
+		h.logError("handleWSToggleObserver: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_observer"))
+		return
+	}
+	h.logf("Player %d toggled watching game %d", client.playerID, game.ID)
+	h.triggerBroadcast()
+}
+
+// handleWSToggleObserversSeeAll switches whether observers and the dead see every role;
+// kept for the next game.
+func handleWSToggleObserversSeeAll(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSToggleObserversSeeAll: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET observers_see_all = NOT observers_see_all WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSToggleObserversSeeAll: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_toggle_observers_see_all"))
+		return
+	}
+	h.logf("Observers see all toggled for game %d", game.ID)
+	h.triggerBroadcast()
+}
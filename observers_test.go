@@ -0,0 +1,208 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// ============================================================================
+// Observer Helpers
+// ============================================================================
+
+// joinRunningGame signs a new player up into the running "test-game", where they can only
+// watch. signupPlayer would wait for them in the player list, which an observer never joins.
+func (tb *TestBrowser) joinRunningGame(baseURL, name string) *TestPlayer {
+	if tb.logger != nil {
+		tb.logger.Debug("Joining running game as observer: %s", name)
+	}
+
+	page := tb.newIncognitoPage(baseURL + "?game=test-game")
+	player := &TestPlayer{
+		Name:   name,
+		Page:   page,
+		logger: tb.logger,
+		t:      tb.t,
+	}
+	player.submitAuthForm(name)
+	if el, err := page.Timeout(browserTimeout).Element("#secret-code-notice-close"); err == nil {
+		el.Click(proto.InputMouseButtonLeft, 1)
+	}
+	player.logHTML("after joining running game")
+	return player
+}
+
+// ============================================================================
+// Observer Tests
+// ============================================================================
+
+func TestObserverSitsOutTheGame(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0,
+		[]string{"P1", "P2", "P3", "Watcher"},
+		[]string{RoleVillager, RoleVillager, RoleVillager, RoleVillager})
+	watcher := ids[3]
+	game, _ := ctx.hub().getGame()
+	for _, rc := range [][2]string{{RoleWerewolf, "1"}, {RoleVillager, "2"}} {
+		ctx.app.db.MustExec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, ?)", game.ID, rc[0], rc[1])
+	}
+
+	ctx.sendWS(watcher, WSMessage{Action: "toggle_observer"})
+	buf, err := getGameComponent(ctx.hub(), ids[0], game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="lobby-observers"`) || !strings.Contains(buf.String(), `id="btn-start" >`) {
+		t.Fatalf("the lobby should list the observer and be ready with 3 roles for 3 players (err: %v)", err)
+	}
+
+	ctx.sendWS(ids[0], WSMessage{Action: "start_game"})
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("the game should start without a card for the observer, got %q", status)
+	}
+	if ctx.isPlayerAlive(watcher) {
+		t.Error("an observer should not count among the living")
+	}
+	players, _ := getPlayersByGameId(ctx.app.db, game.ID)
+	for _, p := range players {
+		if p.PlayerID == watcher {
+			t.Error("an observer should not show up as a player")
+		}
+	}
+
+	game, _ = ctx.hub().getGame()
+	buf, err = getGameComponent(ctx.hub(), watcher, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="observer-note"`) {
+		t.Errorf("the observer should get the watching view (err: %v)", err)
+	}
+}
+
+func TestObserversSeeAllRoles(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Seer", "V1"},
+		[]string{RoleWerewolf, RoleSeer, RoleVillager})
+	game, _ := ctx.hub().getGame()
+	watcher := ctx.app.db.MustExec("INSERT INTO player (name, secret_code) VALUES ('Watcher', 'x')")
+	watcherID, _ := watcher.LastInsertId()
+	ctx.app.db.MustExec("INSERT INTO game_player (game_id, player_id, is_alive, is_observer) VALUES (?, ?, 0, 1)", game.ID, watcherID)
+
+	observers, _ := getObserversByGameId(ctx.app.db, game.ID)
+	if len(observers) != 1 {
+		t.Fatalf("expected one observer, got %d", len(observers))
+	}
+	players, _ := getPlayersByGameId(ctx.app.db, game.ID)
+
+	if r := viewerReveal(ctx.app.db, game.ID, observers[0]); r != RevealFull {
+		t.Fatalf("by default an observer sees what the village sees, got %q", r)
+	}
+	if seen := applyCardVisibility(observers[0], players, nil, RevealFull)[0]; seen.RoleName != "Unknown" {
+		t.Errorf("an observer should not see a living player's role by default, got %q", seen.RoleName)
+	}
+
+	ctx.app.db.MustExec("UPDATE game SET observers_see_all = 1 WHERE rowid = ?", game.ID)
+	reveal := viewerReveal(ctx.app.db, game.ID, observers[0])
+	if seen := applyCardVisibility(observers[0], players, nil, reveal)[0]; seen.PlayerID != ids[0] || seen.RoleName != "Werewolf" {
+		t.Errorf("with see-all on the observer should see the werewolf, got %q", seen.RoleName)
+	}
+	seer, _ := getPlayerInGame(ctx.app.db, game.ID, ids[1])
+	if r := viewerReveal(ctx.app.db, game.ID, seer); r == RevealAll {
+		t.Error("a living player should never see everything")
+	}
+}
+
+func TestLateJoinerBecomesObserver(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ctx.seedGame("night", 1, []string{"Wolf", "V1", "V2"}, []string{RoleWerewolf, RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+	result := ctx.app.db.MustExec("INSERT INTO player (name, secret_code) VALUES ('Latecomer', 'x')")
+	lateID, _ := result.LastInsertId()
+
+	ctx.hub().addPlayerToLobby(lateID)
+	if !isObserver(ctx.app.db, game.ID, lateID) || ctx.isPlayerAlive(lateID) {
+		t.Fatal("a player arriving at a running game should join it as an observer")
+	}
+	players, _ := getPlayersByGameId(ctx.app.db, game.ID)
+	if len(players) != 3 {
+		t.Errorf("the late joiner should not take a seat, got %d players", len(players))
+	}
+	buf, err := getGameComponent(ctx.hub(), lateID, game, "en")
+	if err != nil || !strings.Contains(buf.String(), `id="observer-note"`) {
+		t.Errorf("the late joiner should get the watching view (err: %v)", err)
+	}
+}
+
+func TestWatchGameInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a player watching the game instead of playing ===")
+
+	var players []*TestPlayer
+	for _, name := range []string{"Host", "P2", "P3"} {
+		players = append(players, browser.signupPlayer(ctx.baseURL, name))
+	}
+	host := players[0]
+	watcher := browser.signupPlayer(ctx.baseURL, "Watcher")
+
+	watcher.toggleLobbySetting("watch-toggle")
+	if err := host.waitUntilCondition(`() => document.querySelector('#lobby-observers')?.textContent.includes('Watcher')`, "Watcher listed as observer"); err != nil {
+		ctx.logger.LogDB("FAIL: watcher not listed")
+		t.Fatalf("the lobby should list the watcher as an observer: %v", err)
+	}
+
+	for _, id := range []string{RoleWerewolf, RoleVillager, RoleVillager} {
+		host.addRoleByID(id)
+	}
+	if !host.canStartGame() {
+		t.Fatal("three roles should be enough for the three players")
+	}
+	host.startGame()
+	waitForNightPhaseAll(ctx, players)
+
+	if err := watcher.waitUntilCondition(`() => !!document.querySelector('#observer-note')`, "observer note"); err != nil {
+		ctx.logger.LogDB("FAIL: watcher not watching")
+		t.Fatalf("the watcher should get the watching view: %v", err)
+	}
+	if strings.Contains(host.getPlayerList(), "Watcher") {
+		t.Error("the watcher should not show up as a player")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
+
+func TestLateJoinerWatchesInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a player joining a running game ===")
+
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"P1", "P2", "P3"}, RoleWerewolf, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	late := browser.joinRunningGame(ctx.baseURL, "Latecomer")
+	if _, err := late.p().Element("#observer-note"); err != nil {
+		ctx.logger.LogDB("FAIL: late joiner not watching")
+		t.Fatalf("the late joiner should get the watching view: %v", err)
+	}
+	if strings.Contains(players[0].getPlayerList(), "Latecomer") {
+		t.Error("the late joiner should not take a seat")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
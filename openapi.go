@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// The OpenAPI document of the JSON API (GET /api/v1/openapi.json) is generated from apiRoutes,
+// the same table the routes are registered from, and from the Go types the handlers read and
+// write, so it cannot drift from the code. The WebSocket is described by its messages: the
+// pages' flat WSMessage, and WSEnvelope with a payload type per action (wsPayloads), both of
+// which POST .../actions also takes.
+
+// apiRoute is one endpoint of the JSON API. Request and Response are zero values of the
+// body types, nil when there is none.
+type apiRoute struct {
+	Method   string
+	Path     string
+	Summary  string
+	Handler  func(*App, http.ResponseWriter, *http.Request)
+	Request  any
+	Response any
+}
+
+// apiRoutes are registered by registerAppRoutes under /api/v1. The document itself
+// (/api/v1/openapi.json) is registered on its own: listing it here would make the table
+// refer to itself.
+var apiRoutes = []apiRoute{
+	{"POST", "/session", "Sign in or sign up; sets the session cookie", (*App).handleAPISession, apiSignin{}, apiSession{}},
+	{"POST", "/bots", "Create a bot player; the reply has its bearer token, shown only once", (*App).handleAPICreateBot, apiNewBot{}, apiBot{}},
+	{"GET", "/bots", "The signed-in player's bots", (*App).handleAPIBots, nil, []apiBot{}},
+	{"GET", "/games", "The signed-in player's games", (*App).handleAPIGames, nil, []APIGameSummary{}},
+	{"GET", "/games/{name}", "A game as the player sees it", (*App).handleAPIGame, nil, APIGame{}},
+	{"POST", "/games/{name}/join", "Join the lobby, or watch a game that has started", (*App).handleAPIJoin, nil, APIGame{}},
+	{"GET", "/games/{name}/history", "The game's history as the player sees it", (*App).handleAPIHistory, nil, []APIHistoryEntry{}},
+	{"GET", "/games/{name}/votes", "The open day vote", (*App).handleAPIVotes, nil, APIVotes{}},
+	{"POST", "/games/{name}/actions", "Send an action, like the page does over the WebSocket", (*App).handleAPIAction, WSMessage{}, APIGame{}},
+}
+
+var pathParamRe = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// openAPISchemas turns Go types into JSON schemas, collecting named structs as components.
+type openAPISchemas map[string]any
+
+func (s openAPISchemas) schemaFor(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return s.schemaFor(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		if t == reflect.TypeOf(json.RawMessage{}) {
+			return map[string]any{} // any JSON
+		}
+		return map[string]any{"type": "array", "items": s.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if _, ok := s[t.Name()]; !ok {
+			s[t.Name()] = nil // claimed, for types that refer to themselves
+			props := map[string]any{}
+			var required []string
+			for i := 0; i < t.NumField(); i++ {
+				f := t.Field(i)
+				name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+				if !f.IsExported() || name == "-" {
+					continue
+				}
+				if name == "" {
+					name = f.Name
+				}
+				props[name] = s.schemaFor(f.Type)
+				if !strings.Contains(opts, "omitempty") {
+					required = append(required, name)
+				}
+			}
+			schema := map[string]any{"type": "object", "properties": props}
+			if len(required) > 0 {
+				schema["required"] = required
+			}
+			s[t.Name()] = schema
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	}
+	return map[string]any{}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// openAPIDocument builds the document from apiRoutes.
+func openAPIDocument() map[string]any {
+	schemas := openAPISchemas{}
+	errorSchema := map[string]any{"type": "object", "properties": map[string]any{"error": map[string]any{"type": "string"}}}
+	paths := map[string]any{}
+	for _, route := range apiRoutes {
+		op := map[string]any{"summary": route.Summary}
+		var params []any
+		for _, m := range pathParamRe.FindAllStringSubmatch(route.Path, -1) {
+			params = append(params, map[string]any{"name": m[1], "in": "path", "required": true, "schema": map[string]any{"type": "string"}})
+		}
+		if params != nil {
+			op["parameters"] = params
+		}
+		if route.Request != nil {
+			op["requestBody"] = map[string]any{"required": true, "content": jsonContent(schemas.schemaFor(reflect.TypeOf(route.Request)))}
+		}
+		ok := map[string]any{"description": "OK"}
+		if route.Response != nil {
+			ok["content"] = jsonContent(schemas.schemaFor(reflect.TypeOf(route.Response)))
+		}
+		op["responses"] = map[string]any{
+			"200":     ok,
+			"default": map[string]any{"description": "An error", "content": jsonContent(errorSchema)},
+		}
+		switch route.Path {
+		case "/session":
+		case "/bots":
+			op["security"] = []any{map[string]any{"session": []any{}}}
+		default:
+			op["security"] = []any{map[string]any{"session": []any{}}, map[string]any{"bot": []any{}}}
+		}
+
+		item, _ := paths[route.Path].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[route.Path] = item
+		}
+		item[strings.ToLower(route.Method)] = op
+	}
+	schemas.schemaFor(reflect.TypeOf(WSMessage{}))
+	schemas.schemaFor(reflect.TypeOf(WSEnvelope{}))
+	schemas.schemaFor(reflect.TypeOf(WSError{}))
+	for _, payload := range wsPayloads {
+		schemas.schemaFor(reflect.TypeOf(payload))
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Werewolf API",
+			"version": buildVersion,
+			"description": "The JSON API drives the same games as the web pages. Sign in with POST /session; " +
+				"every other call needs its session cookie, or a bot's bearer token (POST /bots). " +
+				"The pages talk to a game over the WebSocket at /ws/{name}: " +
+				"they send flat WSMessage objects and receive HTML fragments. Other clients send a WSEnvelope " +
+				"(type = the action, version = 1, payload = the action's WS*Payload) and get an \"error\" envelope " +
+				"with a WSError back when it is not taken. POST /games/{name}/actions takes either form.",
+		},
+		"servers": []any{map[string]any{"url": "/api/v1"}},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"session": map[string]any{"type": "apiKey", "in": "cookie", "name": sessionCookieName},
+				"bot":     map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+func (app *App) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, openAPIDocument())
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// The host can pause a running game, say for a break at the table. While paused every move is
+// turned away, the night and day timers stand still, nobody is played for as away and a last
+// words window does not close; everyone sees the pause overlay until the host resumes.
+
+// pauseExemptActions are the WebSocket actions still taken while the game is paused.
+var pauseExemptActions = map[string]bool{
+	"pause_game":        true,
+	"resume_game":       true,
+	"abort_game":        true,
+	"substitute_player": true,
+	"issue_rejoin_code": true,
+	"chat_send":         true,
+	"toggle_mute":       true,
+	"toggle_ai":         true,
+}
+
+func gamePaused(db *sqlx.DB, gameID int64) bool {
+	var paused bool
+	db.Get(&paused, "SELECT paused FROM game WHERE rowid = ?", gameID)
+	return paused
+}
+
+// gameRunning reports whether the game is past the lobby and not over (or aborted) yet.
+func gameRunning(game *Game) bool {
+	return game.Status != "lobby" && game.Status != "finished" && game.Status != "aborted"
+}
+
+// stopTimers stops the running night or day countdown and remembers how long it had left.
+func (h *Hub) stopTimers(game *Game) {
+	h.timerMu.Lock()
+	defer h.timerMu.Unlock()
+	h.pausedLeft = 0
+	if t := h.nightTimer; t != nil {
+		if t.gameID == game.ID && t.round == game.Round && game.Status == "night" {
+			h.pausedLeft = max(time.Until(t.deadline), time.Second)
+		}
+		close(t.stop)
+		h.nightTimer = nil
+	}
+	if t := h.dayTimer; t != nil {
+		if t.gameID == game.ID && t.round == game.Round && game.Status == "day" {
+			h.pausedLeft = max(time.Until(t.deadline), time.Second)
+		}
+		close(t.stop)
+		h.dayTimer = nil
+	}
+}
+
+// restartTimers carries on with the countdown stopTimers put aside, if there was one.
+func (h *Hub) restartTimers(game *Game) {
+	h.timerMu.Lock()
+	left := h.pausedLeft
+	h.pausedLeft = 0
+	h.timerMu.Unlock()
+	if left <= 0 {
+		return
+	}
+	switch game.Status {
+	case "night":
+		h.armNightTimer(game.ID, game.Round, left)
+	case "day":
+		h.armDayTimer(game.ID, game.Round, left)
+	}
+}
+
+// handleWSPauseGame freezes the running game until the host resumes it.
+func handleWSPauseGame(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSPauseGame: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if !gameRunning(game) {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_not_running"))
+		return
+	}
+	if game.Paused {
+		return
+	}
+
+	if _, err := h.db.Exec("UPDATE game SET paused = 1 WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSPauseGame: db.Exec", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_pause"))
+		return
+	}
+	h.stopTimers(game)
+	h.logf("Game %d paused by '%s'", game.ID, h.playerName(client.playerID))
+	h.triggerBroadcast()
+}
+
+// handleWSResumeGame lifts the pause; a timer picks up where it stood.
+func handleWSResumeGame(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSResumeGame: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if !game.Paused {
+		return
+	}
+
+	if _, err := h.db.Exec("UPDATE game SET paused = 0 WHERE rowid = ?", game.ID); err != nil {
+		h.logError("handleWSResumeGame: db.Exec", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_pause"))
+		return
+	}
+	h.restartTimers(game)
+	h.logf("Game %d resumed by '%s'", game.ID, h.playerName(client.playerID))
+	h.triggerBroadcast()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Pause Tests
+// ============================================================================
+
+func TestHostPausesAndResumesGame(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host, wolf, v1 := ids[0], ids[1], ids[2]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET night_timer = 60 WHERE rowid = ?", game.ID)
+	ctx.hub().startNightTimer(game.ID, 1)
+
+	ctx.sendWS(wolf, WSMessage{Action: "pause_game"})
+	if gamePaused(ctx.app.db, game.ID) {
+		t.Fatal("only the host should pause the game")
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "pause_game"})
+	game, _ = ctx.hub().getGame()
+	if !game.Paused {
+		t.Fatal("the host should pause the game")
+	}
+	if left := ctx.hub().nightTimeLeft(game); left != "" {
+		t.Errorf("the night timer should stop while paused, got %q", left)
+	}
+	buf, err := getGameComponent(ctx.hub(), v1, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "Game paused") || strings.Contains(buf.String(), `id="btn-resume-game"`) {
+		t.Errorf("players should see the pause overlay without the resume button (err: %v)", err)
+	}
+
+	// moves are turned away while paused
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	if n := ctx.countActions(ActionWerewolfSelectKill); n != 0 {
+		t.Errorf("a paused game should take no votes, got %d", n)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "resume_game"})
+	game, _ = ctx.hub().getGame()
+	if game.Paused {
+		t.Fatal("the host should resume the game")
+	}
+	if left := ctx.hub().nightTimeLeft(game); left == "" || left == "0:00" {
+		t.Errorf("the night timer should carry on after the pause, got %q", left)
+	}
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v1, 10)})
+	if n := ctx.countActions(ActionWerewolfSelectKill); n != 1 {
+		t.Errorf("the resumed game should take the vote, got %d", n)
+	}
+}
+
+func TestPausedGameKeepsLastWordsOpen(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host := ids[0]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET last_words = 1 WHERE rowid = ?", game.ID)
+	ctx.hub().lastWordsWindow = 50 * time.Millisecond
+
+	target := strconv.FormatInt(ids[2], 10)
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: target})
+	}
+	ctx.sendWS(host, WSMessage{Action: "day_end_vote"})
+	ctx.sendWS(host, WSMessage{Action: "pause_game"})
+
+	if !ctx.hub().markAFK(ids[3]) {
+		t.Error("an away player should still be watched while the game is paused")
+	}
+	time.Sleep(200 * time.Millisecond)
+	if n := ctx.countActions(ActionDayLastWords); n != 0 {
+		t.Fatalf("the last words window should not close while paused, got %d", n)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "resume_game"})
+	deadline := time.Now().Add(2 * time.Second)
+	for status, _, _ := ctx.gameState(); status == "day" && time.Now().Before(deadline); status, _, _ = ctx.gameState() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if status, round, _ := ctx.gameState(); status != "night" || round != 2 {
+		t.Errorf("the window should close once the game goes on, got %q round %d", status, round)
+	}
+}
+
+func TestPauseAndResumeInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the host pausing and resuming the game ===")
+
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"Host", "P2", "P3"}, RoleWerewolf, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	host, guest := players[0], players[1]
+
+	host.clickAndWait("#btn-pause-game")
+	if err := guest.waitUntilCondition(`() => document.querySelector('#pause-overlay')?.hidden === false`, "pause overlay shown"); err != nil {
+		ctx.logger.LogDB("FAIL: game not paused")
+		t.Fatalf("the players should see the game paused: %v", err)
+	}
+	if has, _, _ := guest.p().Has("#btn-resume-game"); has {
+		t.Error("only the host should get the resume button")
+	}
+
+	host.clickAndWait("#btn-resume-game")
+	if err := guest.waitUntilCondition(`() => document.querySelector('#pause-overlay')?.hidden === true`, "pause overlay hidden"); err != nil {
+		ctx.logger.LogDB("FAIL: game not resumed")
+		t.Errorf("the game should carry on after the resume: %v", err)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Prince Tests
+// ============================================================================
+
+func TestPrinceSurvivesFirstLynchOnly(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Prince", "V1", "V2", "V3"},
+		[]string{RoleWerewolf, RolePrince, RoleVillager, RoleVillager, RoleVillager})
+	prince := ids[1]
+	target := strconv.FormatInt(prince, 10)
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: target})
+	}
+	ctx.sendWS(ids[2], WSMessage{Action: "day_end_vote"})
+
+	if !ctx.isPlayerAlive(prince) {
+		t.Fatal("the Prince should survive the first lynch")
+	}
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Fatalf("the day should end after the reveal, got %q", status)
+	}
+	if n := ctx.countActions(ActionPrinceRevealed); n != 1 {
+		t.Fatalf("expected one prince_revealed entry, got %d", n)
+	}
+	if h := ctx.historyFor(ids[3]); !strings.Contains(h, "revealed themselves as the Prince") {
+		t.Errorf("the reveal should be public, got: %q", h)
+	}
+
+	// second lynch sticks
+	ctx.app.db.MustExec("UPDATE game SET status = 'day', round = 2")
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: target})
+	}
+	ctx.sendWS(ids[2], WSMessage{Action: "day_end_vote"})
+	if ctx.isPlayerAlive(prince) {
+		t.Error("the Prince's protection only works once")
+	}
+}
+
+func TestPrinceSparedInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Prince reveals themselves and survives the lynch ===")
+
+	// Setup: 1 werewolf + 1 prince + 3 villagers = 5 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"PR1", "PR2", "PR3", "PR4", "PR5"},
+		RoleWerewolf, RolePrince, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Prince"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) < 3 {
+		t.Fatal("Missing required roles")
+	}
+	prince, werewolf, villagers := byRole["Prince"][0], byRole["Werewolf"][0], byRole["Villager"]
+
+	werewolf.voteForPlayer(villagers[0].Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	// The whole village votes the Prince out
+	alive := []*TestPlayer{werewolf, villagers[1], villagers[2], prince}
+	for _, p := range alive {
+		if p == prince {
+			p.dayVoteForPlayer(werewolf.Name)
+		} else {
+			p.dayVoteForPlayer(prince.Name)
+		}
+	}
+	waitForNightPhaseAll(ctx, alive)
+
+	entry := prince.Name + " was voted out but revealed themselves as the Prince and was spared"
+	if !villagers[1].historyContains(entry) {
+		ctx.logger.LogDB("FAIL: prince reveal missing from history")
+		t.Errorf("Everyone should see %q in history, got: %s", entry, villagers[1].getHistoryText())
+	}
+	if strings.Contains(prince.getGameContent(), "You are dead") {
+		ctx.logger.LogDB("FAIL: prince died")
+		t.Errorf("The Prince should have survived the lynch")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
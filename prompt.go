@@ -448,6 +448,14 @@ func buildUserPrompt(history []string, players []Player, winner string) string {
 		winnerDesc = "the werewolves — every last villager has been devoured"
 	case "lovers":
 		winnerDesc = "the lovers — the last two survivors, bound together until the end, regardless of which side they were on"
+	case "tanner":
+		winnerDesc = "the Tanner — who wanted nothing more than to be hanged, and got exactly that from the village"
+	case "serial_killer":
+		winnerDesc = "the Serial Killer — who picked off werewolves and villagers alike until nobody else was left"
+	case "piper":
+		winnerDesc = "the Piper — whose tune charmed every last survivor, wolf and villager alike"
+	case "white_werewolf":
+		winnerDesc = "the White Werewolf — who hunted with the pack, then turned on it, and stands alone over the ruins of the village"
 	}
 
 	var roster []string
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// Behind a reverse proxy (nginx, Caddy) every request comes from the proxy's address, over
+// plain HTTP and possibly for another host name; the proxy says what the client asked for
+// in X-Forwarded-For, X-Forwarded-Proto and X-Forwarded-Host. withProxyHeaders takes those
+// headers from the proxies in trusted_proxies only: for them the request gets the client's
+// address as RemoteAddr and the client's host as Host, which the WebSocket upgrade's origin
+// check, invite links and OAuth redirects rely on. From anyone else the headers are
+// dropped, so a client cannot claim another address or pretend it came over HTTPS.
+
+// defaultTrustedProxies trusts a proxy on the same host.
+const defaultTrustedProxies = "127.0.0.0/8,::1/128"
+
+var forwardedHeaders = []string{"X-Forwarded-For", "X-Forwarded-Proto", "X-Forwarded-Host"}
+
+// parseTrustedProxies reads a comma-separated list of addresses and CIDR ranges.
+func parseTrustedProxies(list string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if addr, err := netip.ParseAddr(s); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q is neither an address nor a CIDR range", s)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+func isTrustedProxy(trusted []netip.Prefix, ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP is the address the request came from, the client's behind a trusted proxy.
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// isHTTPS reports whether the client reached the server over HTTPS, directly or through a
+// trusted proxy; cookies are only marked Secure then.
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
+
+// withProxyHeaders applies the X-Forwarded-* headers of trusted proxies and drops everyone
+// else's (see above).
+func withProxyHeaders(next http.Handler, trusted []netip.Prefix) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isTrustedProxy(trusted, clientIP(r)) {
+			for _, h := range forwardedHeaders {
+				r.Header.Del(h)
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// each proxy appends the address it got the request from: the client is the last
+		// one that is not a trusted proxy itself
+		var hops []string
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			hops = append(hops, strings.Split(v, ",")...)
+		}
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if _, err := netip.ParseAddr(hop); err != nil {
+				break
+			}
+			r.RemoteAddr = net.JoinHostPort(hop, "0")
+			if !isTrustedProxy(trusted, hop) {
+				break
+			}
+		}
+		// the first proxy saw what the client asked for
+		if proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ","); proto != "" {
+			r.Header.Set("X-Forwarded-Proto", strings.ToLower(strings.TrimSpace(proto)))
+		}
+		if host, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Host"), ","); strings.TrimSpace(host) != "" {
+			r.Host = strings.TrimSpace(host)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Reverse Proxy Tests
+// ============================================================================
+
+func TestProxyHeadersOnlyFromTrustedProxies(t *testing.T) {
+	t.Parallel()
+	trusted, err := parseTrustedProxies("127.0.0.1, 10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("parseTrustedProxies: %v", err)
+	}
+	if _, err := parseTrustedProxies("nginx"); err == nil {
+		t.Errorf("a name should be refused as a trusted proxy")
+	}
+
+	for _, tc := range []struct {
+		name, peer, forwardedFor string
+		ip, host                 string
+		https                    bool
+	}{
+		{"through two trusted proxies", "127.0.0.1:4000", "203.0.113.5, 10.0.0.2", "203.0.113.5", "game.example", true},
+		{"a spoofed address before the client's", "127.0.0.1:4000", "198.51.100.1, 203.0.113.5", "203.0.113.5", "game.example", true},
+		{"straight from a client", "198.51.100.7:4000", "203.0.113.5", "198.51.100.7", "internal:8080", false},
+	} {
+		var ip, host string
+		var https bool
+		handler := withProxyHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, host, https = clientIP(r), r.Host, isHTTPS(r)
+		}), trusted)
+		r := httptest.NewRequest("GET", "http://internal:8080/", nil)
+		r.RemoteAddr = tc.peer
+		r.Header.Set("X-Forwarded-For", tc.forwardedFor)
+		r.Header.Set("X-Forwarded-Proto", "https")
+		r.Header.Set("X-Forwarded-Host", "game.example")
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+		if ip != tc.ip || host != tc.host || https != tc.https {
+			t.Errorf("%s: got %s %s https=%v, want %s %s https=%v", tc.name, ip, host, https, tc.ip, tc.host, tc.https)
+		}
+	}
+}
+
+func TestSessionCookieIsSecureBehindAnHTTPSProxy(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+	ctx.apiClient() // waits for the server
+
+	for _, proto := range []string{"https", ""} {
+		req, _ := http.NewRequest("POST", ctx.baseURL+"/api/v1/session", strings.NewReader(`{"name":"Alice`+proto+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if proto != "" {
+			req.Header.Set("X-Forwarded-Proto", proto)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("sign-in: %v", err)
+		}
+		resp.Body.Close()
+		found := false
+		for _, c := range resp.Cookies() {
+			if c.Name == sessionCookieName {
+				found = true
+				if c.Secure != (proto == "https") {
+					t.Errorf("forwarded proto %q: the session cookie's Secure is %v", proto, c.Secure)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("forwarded proto %q: the sign-in should set the session cookie", proto)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// A small QR code encoder for the invite links: byte mode, error correction level M,
+// versions 1 to 10 (up to 213 bytes), which is plenty for a URL. It follows the QR code
+// specification (ISO/IEC 18004) step by step: codewords, Reed-Solomon blocks, the fixed
+// patterns, the zigzag data placement and the mask with the lowest penalty.
+
+// qrMaxVersion is the largest symbol the encoder builds.
+const qrMaxVersion = 10
+
+// qrQuietZone is the light border around the symbol, in modules.
+const qrQuietZone = 4
+
+// Per version (index 0 = version 1) at level M: all codewords, error correction blocks and
+// error correction codewords per block.
+var (
+	qrRawCodewords = []int{26, 44, 70, 100, 134, 172, 196, 242, 292, 346}
+	qrBlocks       = []int{1, 1, 1, 2, 2, 4, 4, 4, 5, 5}
+	qrECCPerBlock  = []int{10, 16, 26, 18, 24, 16, 18, 22, 22, 26}
+)
+
+// qrAlignment are the row/column centres of the alignment patterns, per version.
+var qrAlignment = [][]int{
+	nil, {6, 18}, {6, 22}, {6, 26}, {6, 30}, {6, 34},
+	{6, 22, 38}, {6, 24, 42}, {6, 26, 46}, {6, 28, 50},
+}
+
+var errQRTooLong = errors.New("qrcode: text too long")
+
+// qrSymbol is a square of modules; dark is true for a dark module.
+type qrSymbol struct {
+	size     int
+	dark     [][]bool
+	function [][]bool // modules of the fixed patterns, which data and masks leave alone
+}
+
+// encodeQR builds the smallest symbol that holds the text.
+func encodeQR(text string) (*qrSymbol, error) {
+	data := []byte(text)
+	version := 0
+	for v := 1; v <= qrMaxVersion; v++ {
+		if qrDataCodewords(v)*8 >= 4+qrCountBits(v)+len(data)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, errQRTooLong
+	}
+
+	q := newQRSymbol(version)
+	q.drawFunctionPatterns(version)
+	q.drawCodewords(qrInterleave(version, qrDataBits(version, data)))
+
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if p := q.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		q.applyMask(mask) // masking twice undoes it
+	}
+	q.applyMask(best)
+	q.drawFormatBits(best)
+	return q, nil
+}
+
+// qrPNG renders the text's QR code as a PNG about px pixels wide, quiet zone included.
+func qrPNG(text string, px int) ([]byte, error) {
+	q, err := encodeQR(text)
+	if err != nil {
+		return nil, err
+	}
+	modules := q.size + 2*qrQuietZone
+	scale := max(px/modules, 1)
+	img := image.NewGray(image.Rect(0, 0, modules*scale, modules*scale))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if !q.dark[y][x] {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray((x+qrQuietZone)*scale+dx, (y+qrQuietZone)*scale+dy, color.Gray{})
+				}
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func qrDataCodewords(version int) int {
+	return qrRawCodewords[version-1] - qrBlocks[version-1]*qrECCPerBlock[version-1]
+}
+
+// qrCountBits is the width of the byte mode character count.
+func qrCountBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+// qrDataBits lays out the data codewords: mode, count, the bytes, terminator and padding.
+func qrDataBits(version int, data []byte) []byte {
+	var bits []bool
+	put := func(value, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, value>>i&1 == 1)
+		}
+	}
+	put(0b0100, 4)
+	put(len(data), qrCountBits(version))
+	for _, b := range data {
+		put(int(b), 8)
+	}
+	capacity := qrDataCodewords(version) * 8
+	put(0, min(4, capacity-len(bits)))
+	put(0, (8-len(bits)%8)%8)
+
+	codewords := make([]byte, 0, capacity/8)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			if bits[i+j] {
+				b |= 1 << (7 - j)
+			}
+		}
+		codewords = append(codewords, b)
+	}
+	for pad := byte(0xec); len(codewords) < capacity/8; pad ^= 0xec ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+	return codewords
+}
+
+// qrInterleave splits the data into blocks, adds each block's error correction and
+// interleaves them into the final codeword sequence.
+func qrInterleave(version int, data []byte) []byte {
+	numBlocks, ecc := qrBlocks[version-1], qrECCPerBlock[version-1]
+	raw := qrRawCodewords[version-1]
+	numShort := numBlocks - raw%numBlocks
+	shortData := raw/numBlocks - ecc
+	divisor := qrRSDivisor(ecc)
+
+	var dataBlocks, eccBlocks [][]byte
+	for i, k := 0, 0; i < numBlocks; i++ {
+		n := shortData
+		if i >= numShort {
+			n++
+		}
+		block := data[k : k+n]
+		k += n
+		dataBlocks = append(dataBlocks, block)
+		eccBlocks = append(eccBlocks, qrRSRemainder(block, divisor))
+	}
+
+	result := make([]byte, 0, raw)
+	for i := 0; i <= shortData; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < ecc; i++ {
+		for _, block := range eccBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// qrGFMul multiplies in GF(256) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func qrGFMul(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11d)
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+// qrRSDivisor is the Reed-Solomon generator polynomial of the given degree, highest
+// coefficient (always 1) left out.
+func qrRSDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = qrGFMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrGFMul(root, 0x02)
+	}
+	return result
+}
+
+// qrRSRemainder is the error correction of a block.
+func qrRSRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= qrGFMul(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+func newQRSymbol(version int) *qrSymbol {
+	size := version*4 + 17
+	q := &qrSymbol{size: size, dark: make([][]bool, size), function: make([][]bool, size)}
+	for i := range q.dark {
+		q.dark[i] = make([]bool, size)
+		q.function[i] = make([]bool, size)
+	}
+	return q
+}
+
+func (q *qrSymbol) setFunction(x, y int, dark bool) {
+	q.dark[y][x] = dark
+	q.function[y][x] = true
+}
+
+// drawFunctionPatterns draws the timing, finder and alignment patterns and reserves the
+// format and version areas.
+func (q *qrSymbol) drawFunctionPatterns(version int) {
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	for _, c := range [][2]int{{3, 3}, {q.size - 4, 3}, {3, q.size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := c[0]+dx, c[1]+dy
+				if x < 0 || x >= q.size || y < 0 || y >= q.size {
+					continue
+				}
+				dist := max(abs(dx), abs(dy))
+				q.setFunction(x, y, dist != 2 && dist != 4)
+			}
+		}
+	}
+
+	centres := qrAlignment[version-1]
+	last := len(centres) - 1
+	for i, cy := range centres {
+		for j, cx := range centres {
+			// the corners taken by the finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	q.drawFormatBits(0)
+	q.drawVersionBits(version)
+}
+
+// drawFormatBits writes both copies of the level M format information for the mask.
+func (q *qrSymbol) drawFormatBits(mask int) {
+	data := mask // level M's indicator is 00
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(i))
+	}
+	q.setFunction(8, q.size-8, true) // the dark module
+}
+
+// drawVersionBits writes the version information, which symbols from version 7 on carry.
+func (q *qrSymbol) drawVersionBits(version int) {
+	if version < 7 {
+		return
+	}
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1f25)
+	}
+	bits := version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := bits>>i&1 == 1
+		a, b := q.size-11+i%3, i/3
+		q.setFunction(a, b, dark)
+		q.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords in the zigzag of two-module columns, bottom right first.
+func (q *qrSymbol) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < q.size; vert++ {
+			y := vert
+			if upward {
+				y = q.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if q.function[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				q.dark[y][x] = codewords[i>>3]>>(7-i&7)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// applyMask flips the data modules the mask pattern selects.
+func (q *qrSymbol) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.function[y][x] {
+				continue
+			}
+			var flip bool
+			switch mask {
+			case 0:
+				flip = (x+y)%2 == 0
+			case 1:
+				flip = y%2 == 0
+			case 2:
+				flip = x%3 == 0
+			case 3:
+				flip = (x+y)%3 == 0
+			case 4:
+				flip = (x/3+y/2)%2 == 0
+			case 5:
+				flip = x*y%2+x*y%3 == 0
+			case 6:
+				flip = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				flip = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if flip {
+				q.dark[y][x] = !q.dark[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores a masked symbol by the four rules of the specification; lower is better.
+func (q *qrSymbol) penalty() int {
+	at := func(x, y int, transposed bool) bool {
+		if transposed {
+			return q.dark[x][y]
+		}
+		return q.dark[y][x]
+	}
+	finderLike := []bool{true, false, true, true, true, false, true}
+
+	result, darkCount := 0, 0
+	for _, transposed := range []bool{false, true} {
+		for y := 0; y < q.size; y++ {
+			// rule 1: runs of five or more modules of one colour
+			run := 1
+			for x := 1; x <= q.size; x++ {
+				if x < q.size && at(x, y, transposed) == at(x-1, y, transposed) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					result += run - 2
+				}
+				run = 1
+			}
+			// rule 3: a finder-like 1:1:3:1:1 pattern with four light modules on one side
+			for x := 0; x+7 <= q.size; x++ {
+				match := true
+				for k, dark := range finderLike {
+					if at(x+k, y, transposed) != dark {
+						match = false
+						break
+					}
+				}
+				if match && (q.lightRun(x-4, y, transposed) || q.lightRun(x+7, y, transposed)) {
+					result += 40
+				}
+			}
+		}
+	}
+
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.dark[y][x] {
+				darkCount++
+			}
+			// rule 2: 2x2 blocks of one colour
+			if x+1 < q.size && y+1 < q.size {
+				c := q.dark[y][x]
+				if q.dark[y][x+1] == c && q.dark[y+1][x] == c && q.dark[y+1][x+1] == c {
+					result += 3
+				}
+			}
+		}
+	}
+
+	// rule 4: the further the dark share is from half, the worse
+	total := q.size * q.size
+	k := (abs(darkCount*20-total*10) + total - 1) / total
+	result += max(k-1, 0) * 10
+	return result
+}
+
+// lightRun reports whether the four modules from x on are light; outside the symbol counts
+// as light (the quiet zone).
+func (q *qrSymbol) lightRun(x, y int, transposed bool) bool {
+	for k := x; k < x+4; k++ {
+		if k < 0 || k >= q.size {
+			continue
+		}
+		dark := q.dark[y][k]
+		if transposed {
+			dark = q.dark[k][y]
+		}
+		if dark {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
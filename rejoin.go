@@ -0,0 +1,117 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// A player who lost their secret code would be locked out of a running game, since the
+// code is only ever shown once. The host can issue a rejoin code from the sidebar for a
+// seated player who is not connected: it is shown to the host alone, who passes it on, and
+// the player signs in with it in place of the secret code. A rejoin code is for one game
+// and works once, for a short while, and only while that game runs and the player still
+// has their seat. It only opens a session; the secret code stays as it was.
+
+const rejoinCodeLifetime = 30 * time.Minute
+
+// issueRejoinCode makes a new rejoin code for the player's seat in the game, replacing any
+// earlier one, and returns the raw code; only its hash is stored.
+func issueRejoinCode(db *sqlx.DB, gameID, playerID int64) (string, error) {
+	code, err := generateSecretCode()
+	if err != nil {
+		return "", err
+	}
+	hash, err := hashSecretCode(code)
+	if err != nil {
+		return "", err
+	}
+	_, err = db.Exec("INSERT OR REPLACE INTO rejoin_code (player_id, game_id, code_hash, expires_at) VALUES (?, ?, ?, datetime('now', ?))",
+		playerID, gameID, hash, sqliteOffset(rejoinCodeLifetime))
+	return code, err
+}
+
+// redeemRejoinCode reports whether code is the player's live rejoin code, using it up if so.
+// The code is void once its game is over or the player has lost their seat.
+func redeemRejoinCode(db *sqlx.DB, playerID int64, code string) bool {
+	var hash string
+	err := db.Get(&hash, `
+SELECT rc.code_hash FROM rejoin_code rc
+JOIN game g ON g.rowid = rc.game_id
+JOIN game_player gp ON gp.game_id = rc.game_id AND gp.player_id = rc.player_id AND gp.is_observer = 0
+WHERE rc.player_id = ? AND rc.expires_at > datetime('now') AND g.status NOT IN ('lobby', 'finished', 'aborted')`, playerID)
+	if err != nil || !verifySecretCode(hash, code) {
+		return false
+	}
+	res, err := db.Exec("DELETE FROM rejoin_code WHERE player_id = ? AND code_hash = ?", playerID, hash)
+	if err != nil {
+		return false
+	}
+	// a second sign-in racing this one with the same code finds the row gone
+	n, _ := res.RowsAffected()
+	return n == 1
+}
+
+// buildRejoinChoices lists who the host can issue a rejoin code for: the seated players
+// who are not connected, while the game runs. It is empty for everyone but the host.
+func (h *Hub) buildRejoinChoices(game *Game, viewerID int64) []SeatChoice {
+	if !gameRunning(game) || gameHost(h.db, game.ID) != viewerID {
+		return nil
+	}
+	connected := map[int64]bool{}
+	for _, id := range h.connectedPlayerIDs() {
+		connected[id] = true
+	}
+	var choices []SeatChoice
+	players, _ := getPlayersByGameId(h.db, game.ID)
+	for _, p := range players {
+		if p.PlayerID != viewerID && !connected[p.PlayerID] {
+			choices = append(choices, SeatChoice{ID: p.PlayerID, Name: p.Name})
+		}
+	}
+	return choices
+}
+
+// handleWSIssueRejoinCode makes a rejoin code for a seated player of the host's game who is
+// not connected and shows it to the host.
+func handleWSIssueRejoinCode(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSIssueRejoinCode: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if !gameRunning(game) {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_not_running"))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	if err != nil || targetID == client.playerID {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	if seat, err := getPlayerInGame(h.db, game.ID, targetID); err != nil || seat.IsObserver {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	for _, id := range h.connectedPlayerIDs() {
+		if id == targetID {
+			h.sendErrorToast(client.playerID, T(lang, "err_rejoin_still_connected"))
+			return
+		}
+	}
+
+	code, err := issueRejoinCode(h.db, game.ID, targetID)
+	if err != nil {
+		h.logError("handleWSIssueRejoinCode: issueRejoinCode", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_something_wrong"))
+		return
+	}
+	name := h.playerName(targetID)
+	h.logf("Host %d issued a rejoin code for '%s' in game %d", client.playerID, name, game.ID)
+	h.sendInfoToast(client.playerID, T(lang, "rejoin_code_issued", name, code, int(rejoinCodeLifetime/time.Minute)))
+}
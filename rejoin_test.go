@@ -0,0 +1,138 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// ============================================================================
+// Rejoin Code Tests
+// ============================================================================
+
+func TestHostIssuesRejoinCode(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Lost", "Other"},
+		[]string{RoleVillager, RoleVillager, RoleWerewolf})
+	host, lost, other := ids[0], ids[1], ids[2]
+	game, _ := getOrCreateGameByName(ctx.app.db, "test-game")
+	res := ctx.app.db.MustExec("INSERT INTO player (name, secret_code) VALUES ('Watcher', ?)", seedSecretCodeHash())
+	watcher, _ := res.LastInsertId()
+	ctx.app.db.MustExec("INSERT INTO game_player (game_id, player_id, is_alive, is_observer) VALUES (?, ?, 0, 1)", game.ID, watcher)
+
+	// Other is at the table, Lost dropped out
+	h := ctx.hub()
+	conn := &Client{hub: h, conn: &websocket.Conn{}, playerID: other, send: make(chan hubMsg, 4)}
+	h.mu.Lock()
+	h.addClient(conn)
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		h.removeClient(conn)
+		h.mu.Unlock()
+	}()
+
+	codes := func() (n int) {
+		ctx.app.db.Get(&n, "SELECT COUNT(*) FROM rejoin_code")
+		return n
+	}
+	issue := func(from, target int64) {
+		ctx.sendWS(from, WSMessage{Action: "issue_rejoin_code", TargetPlayerID: strconv.FormatInt(target, 10)})
+	}
+
+	if choices := h.buildRejoinChoices(game, host); len(choices) != 1 || choices[0].ID != lost {
+		t.Errorf("the host should be offered only the seated players who are not connected, got %+v", choices)
+	}
+	issue(other, lost)
+	if codes() != 0 {
+		t.Fatalf("only the host may issue a rejoin code")
+	}
+	issue(host, other)
+	issue(host, watcher)
+	if codes() != 0 {
+		t.Fatalf("a rejoin code is only for a seated player who is not connected")
+	}
+	issue(host, lost)
+	if codes() != 1 {
+		t.Fatalf("the host should issue a rejoin code")
+	}
+
+	code, err := issueRejoinCode(ctx.app.db, game.ID, lost)
+	if err != nil {
+		t.Fatalf("issueRejoinCode: %v", err)
+	}
+	if _, _, errKey := ctx.app.signin("Other", code); errKey != "err_invalid_credentials" {
+		t.Errorf("the code should only work for the player it was issued for, got %q", errKey)
+	}
+	playerID, secretCode, errKey := ctx.app.signin("Lost", code)
+	if errKey != "" || playerID != lost || secretCode != "" {
+		t.Fatalf("the player should rejoin with the code and keep their secret code, got %d %q %q", playerID, secretCode, errKey)
+	}
+	if _, _, errKey := ctx.app.signin("Lost", code); errKey != "err_invalid_credentials" {
+		t.Errorf("the rejoin code should work only once, got %q", errKey)
+	}
+	if _, _, errKey := ctx.app.signin("Lost", "x"); errKey != "" {
+		t.Errorf("the secret code should still work, got %q", errKey)
+	}
+
+	code, _ = issueRejoinCode(ctx.app.db, game.ID, lost)
+	ctx.app.db.MustExec("UPDATE rejoin_code SET expires_at = datetime('now', '-1 minute') WHERE player_id = ?", lost)
+	if _, _, errKey := ctx.app.signin("Lost", code); errKey != "err_invalid_credentials" {
+		t.Errorf("an expired rejoin code should not work, got %q", errKey)
+	}
+
+	code, _ = issueRejoinCode(ctx.app.db, game.ID, lost)
+	ctx.app.db.MustExec("UPDATE game SET status = 'finished' WHERE rowid = ?", game.ID)
+	if _, _, errKey := ctx.app.signin("Lost", code); errKey != "err_invalid_credentials" {
+		t.Errorf("a rejoin code should not work once the game is over, got %q", errKey)
+	}
+}
+
+func TestRejoinWithCodeInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a player rejoining with a code from the host ===")
+
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"Host", "Lost", "P3"}, RoleWerewolf, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	host, lost := players[0], players[1]
+	lost.disconnect()
+	if err := host.waitUntilCondition(`() => Array.from(document.querySelectorAll('#rejoin-player option')).some(o => o.textContent.trim() === 'Lost')`, "Lost offered"); err != nil {
+		ctx.logger.LogDB("FAIL: dropped player not offered")
+		t.Fatalf("the host should be offered a rejoin code for the dropped player: %v", err)
+	}
+
+	host.submitFormWithValues("rejoin-form", map[string]string{"target_player_id": host.optionValue("#rejoin-player", "Lost")})
+	if err := host.waitUntilCondition(`() => Array.from(document.querySelectorAll('.toast-message')).some(m => m.textContent.includes('Rejoin code for Lost'))`, "rejoin code toast"); err != nil {
+		ctx.logger.LogDB("FAIL: no rejoin code")
+		t.Fatalf("the host should be shown a rejoin code: %v", err)
+	}
+	result, err := host.p().Eval(`() => Array.from(document.querySelectorAll('.toast-message')).map(m => m.textContent).find(t => t.includes('Rejoin code for Lost'))`)
+	if err != nil {
+		t.Fatalf("reading the rejoin code: %v", err)
+	}
+	_, rest, _ := strings.Cut(result.Value.String(), "Rejoin code for Lost: ")
+	code, _, _ := strings.Cut(rest, " ")
+
+	back := browser.loginPlayer(ctx.baseURL, "Lost", code)
+	if !back.isInNightPhase() {
+		ctx.logger.LogDB("FAIL: rejoined player not in the game")
+		t.Fatal("the player should be back in the running game")
+	}
+	if has, _, _ := back.p().Has("#secret-code-display"); has {
+		t.Error("a rejoin code should not replace the secret code")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,62 @@
+package main
+
+// A broadcast renders the game once for every player and observer. What all those pages
+// share (the game row, the seated players, the observers, the host, the role list) is read
+// from the database once into a renderState and every page is rendered from it, so a
+// larger table no longer costs a round of the same queries per recipient. Player names,
+// which never change, are kept in memory by each hub (playerName).
+//
+// The database stays the authority: every move is still written straight through, and a
+// renderState lives only as long as the render it was loaded for.
+
+// renderState is the game as one render (or one broadcast) sees it. It is read-only: the
+// renders take copies of the player lists (applyCardVisibility, maskDrunkSelf, ...).
+type renderState struct {
+	Game      *Game
+	Players   []Player
+	Observers []Player
+	HostID    int64
+	Roles     []Role // every role; only loaded for the lobby
+}
+
+func loadRenderState(h *Hub, game *Game) (*renderState, error) {
+	players, err := getPlayersByGameId(h.db, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	observers, err := getObserversByGameId(h.db, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	rs := &renderState{Game: game, Players: players, Observers: observers, HostID: gameHost(h.db, game.ID)}
+	if game.Status == "lobby" {
+		if rs.Roles, err = getRoles(h.db); err != nil {
+			return nil, err
+		}
+	}
+	return rs, nil
+}
+
+// playerName is getPlayerName through the hub's name cache.
+func (h *Hub) playerName(playerID int64) string {
+	h.namesMu.Lock()
+	name, ok := h.names[playerID]
+	h.namesMu.Unlock()
+	if ok {
+		return name
+	}
+	name = getPlayerName(h.db, playerID)
+	if name != "" {
+		h.namesMu.Lock()
+		h.names[playerID] = name
+		h.namesMu.Unlock()
+	}
+	return name
+}
+
+// forgetPlayerName drops a deleted player's name from the cache.
+func (h *Hub) forgetPlayerName(playerID int64) {
+	h.namesMu.Lock()
+	delete(h.names, playerID)
+	h.namesMu.Unlock()
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"html/template"
+	"net/http"
+	"strconv"
+)
+
+// A finished game can be replayed step by step (/history/{id}/replay?step=N): each step is
+// one phase of the game in the order it was played, the setup, night 1, day 1 and so on,
+// with every action recorded in it, who acted and with what role. The game is over, so the
+// replay holds nothing back: the Seer's checks, the Doctor's saves and the wolves' picks
+// are all there.
+
+// ReplayStep is one phase of a finished game.
+type ReplayStep struct {
+	Round int    `db:"round"`
+	Phase string `db:"phase"`
+}
+
+type ReplayEntry struct {
+	Actor       string
+	Role        string
+	Description string
+}
+
+type ReplayData struct {
+	Game     ArchivedGame
+	Steps    []string // the steps' labels, for the jump list
+	Step     int
+	Prev     int // -1 on the first step
+	Next     int // -1 on the last step
+	Entries  []ReplayEntry
+	StyleTag template.HTML
+	Lang     string
+}
+
+func replayStepLabel(step ReplayStep, lang string) string {
+	switch step.Phase {
+	case "setup":
+		return T(lang, "replay_setup")
+	case "night":
+		return T(lang, "night_round", step.Round)
+	case "day":
+		return T(lang, "day_round", step.Round)
+	}
+	return step.Phase
+}
+
+// replaySteps returns the phases of a game that recorded anything, in the order they were played.
+func (app *App) replaySteps(gameID int64) ([]ReplayStep, error) {
+	var steps []ReplayStep
+	err := app.db.Select(&steps, `
+		SELECT round, phase FROM game_action
+		WHERE game_id = ? AND description != ''
+		GROUP BY round, phase
+		ORDER BY MIN(rowid)`, gameID)
+	return steps, err
+}
+
+// replayEntries returns one step's actions, translated, with the actor and their role.
+func (app *App) replayEntries(gameID int64, step ReplayStep, lang string) []ReplayEntry {
+	var rows []struct {
+		Actor           string `db:"actor"`
+		Role            string `db:"role"`
+		Visibility      string `db:"visibility"`
+		Description     string `db:"description"`
+		DescriptionKey  string `db:"description_key"`
+		DescriptionArgs string `db:"description_args"`
+	}
+	app.db.Select(&rows, `
+		SELECT IFNULL(p.name, '') as actor, IFNULL(r.name, '') as role, ga.visibility,
+			ga.description, ga.description_key, ga.description_args
+		FROM game_action ga
+		LEFT JOIN player p ON p.rowid = ga.actor_player_id
+		LEFT JOIN game_player gp ON gp.game_id = ga.game_id AND gp.player_id = ga.actor_player_id
+		LEFT JOIN role r ON r.rowid = gp.role_id
+		WHERE ga.game_id = ? AND ga.round = ? AND ga.phase = ? AND ga.description != ''
+		ORDER BY ga.rowid ASC`, gameID, step.Round, step.Phase)
+	entries := make([]ReplayEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := ReplayEntry{Description: historyDescription(app.db, RevealFull, row.Description, row.DescriptionKey, row.DescriptionArgs, lang)}
+		// an override is recorded on the player it concerns, the host is the one who acted
+		if row.Visibility != VisibilityModerator {
+			entry.Actor = row.Actor
+		}
+		if entry.Actor != "" && row.Role != "" {
+			entry.Role = TOr(lang, "role_name_"+row.Role, row.Role)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (app *App) handleReplay(w http.ResponseWriter, r *http.Request) {
+	gameID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	var game ArchivedGame
+	if err := app.db.Get(&game, archivedGamesQuery+" AND g.rowid = ?", gameID); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	steps, err := app.replaySteps(gameID)
+	if err != nil {
+		app.logf("ERROR [handleReplay: replaySteps]: %v", err)
+		http.Error(w, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	lang := getLangFromCookie(r)
+	data := ReplayData{Game: game, Prev: -1, Next: -1, StyleTag: app.pageStyleTag, Lang: lang}
+	for _, step := range steps {
+		data.Steps = append(data.Steps, replayStepLabel(step, lang))
+	}
+	if len(steps) > 0 {
+		data.Step, _ = strconv.Atoi(r.URL.Query().Get("step"))
+		data.Step = max(0, min(data.Step, len(steps)-1))
+		if data.Step > 0 {
+			data.Prev = data.Step - 1
+		}
+		if data.Step < len(steps)-1 {
+			data.Next = data.Step + 1
+		}
+		data.Entries = app.replayEntries(gameID, steps[data.Step], lang)
+	}
+	app.templates.ExecuteTemplate(w, "replay.html", data)
+}
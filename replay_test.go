@@ -0,0 +1,102 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Replay Tests
+// ============================================================================
+
+func TestFinishedGamesReplayStepByStep(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Host", "Wolf", "Seer", "V1"},
+		[]string{RoleVillager, RoleWerewolf, RoleSeer, RoleVillager})
+	host, wolf, seer := ids[0], ids[1], ids[2]
+	game, _ := ctx.hub().getGame()
+	gameID := strconv.FormatInt(game.ID, 10)
+
+	replay := func(step string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/history/"+gameID+"/replay?step="+step, nil)
+		req.SetPathValue("id", gameID)
+		rec := httptest.NewRecorder()
+		ctx.app.handleReplay(rec, req)
+		return rec
+	}
+
+	ctx.sendWS(seer, WSMessage{Action: "seer_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	ctx.sendWS(seer, WSMessage{Action: "seer_investigate"})
+	if rec := replay("0"); rec.Code != http.StatusNotFound {
+		t.Fatalf("a running game should not be replayed, got %d", rec.Code)
+	}
+
+	ctx.app.db.MustExec("UPDATE game SET status = 'day' WHERE rowid = ?", game.ID)
+	ctx.sendWS(host, WSMessage{Action: "moderator_kill", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	if status, _, _ := ctx.gameState(); status != "finished" {
+		t.Fatalf("killing the last wolf should end the game, got %q", status)
+	}
+
+	rec := replay("0")
+	page := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(page, "Night 1") || !strings.Contains(page, "Seer (Seer):") || !strings.Contains(page, "investigated Wolf") {
+		t.Fatalf("the first step should show the Seer's check, got %d:\n%s", rec.Code, page)
+	}
+	if strings.Contains(page, `id="replay-prev"`) || !strings.Contains(page, `href="?step=1"`) {
+		t.Errorf("the first step should only link forward:\n%s", page)
+	}
+
+	page = replay("99").Body.String()
+	if !strings.Contains(page, "Day 1") || !strings.Contains(page, "The host killed Wolf") || strings.Contains(page, "Wolf (Werewolf):") || strings.Contains(page, `id="replay-next"`) {
+		t.Errorf("a step past the end should show the last one, the host's kill:\n%s", page)
+	}
+}
+
+func TestReplayInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing stepping through a finished game ===")
+
+	players, wolf := finishGameInBrowser(ctx, browser)
+	host := players[0]
+
+	host.navigate(ctx.baseURL + "/history")
+	host.followLink("#archive-games a[href^='/history/']")
+	host.followLink("#archive-replay")
+	step, err := host.p().Element("#replay-step")
+	if err != nil {
+		t.Fatalf("the replay should open on its first step: %v", err)
+	}
+	if text, _ := step.Text(); !strings.Contains(text, "Night 1") {
+		t.Errorf("the replay should start with the first night, got %q", text)
+	}
+
+	// step to the end: the host's kill closes the game
+	for range 10 {
+		if has, _, _ := host.p().Has("#replay-next"); !has {
+			break
+		}
+		host.followLink("#replay-next")
+	}
+	entries, err := host.p().Element("#replay-entries")
+	if err != nil {
+		t.Fatalf("the last step should list its events: %v", err)
+	}
+	if text, _ := entries.Text(); !strings.Contains(text, "The host killed "+wolf.Name) {
+		t.Errorf("the last step should show the host's kill, got %q", text)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
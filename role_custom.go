@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Night abilities a custom role can be built from.
+const (
+	CustomNightInvestigate = "investigate" // learn whether the target is a werewolf, like the Seer
+	CustomNightProtect     = "protect"     // shield the target from the werewolves, like the Doctor
+)
+
+const (
+	customRoleMaxName    = 32
+	customRoleMaxDesc    = 200
+	customRoleMaxCharges = 9
+)
+
+// RoleAbility is the JSON ability spec stored in role.ability for roles created in the lobby.
+// Built-in roles leave the column empty; a custom role always stores at least "{}". Roles are
+// revealed on death anyway, so there is no separate reveal primitive.
+type RoleAbility struct {
+	Night   string `json:"night,omitempty"`   // "", CustomNightInvestigate or CustomNightProtect
+	Charges int    `json:"charges,omitempty"` // uses of the night ability per game; 0 = every night
+	Shield  bool   `json:"shield,omitempty"`  // survives the first werewolf attack, like the Elder
+}
+
+// parseRoleAbility decodes an ability spec. An empty spec is a built-in role: ok is false.
+func parseRoleAbility(spec string) (ability RoleAbility, ok bool) {
+	if spec == "" {
+		return RoleAbility{}, false
+	}
+	if err := json.Unmarshal([]byte(spec), &ability); err != nil {
+		return RoleAbility{}, false
+	}
+	return ability, true
+}
+
+// customAbility returns the ability spec of the named role; built-in roles have none.
+func customAbility(db *sqlx.DB, roleName string) RoleAbility {
+	var spec string
+	db.Get(&spec, `SELECT ability FROM role WHERE name = ?`, roleName)
+	ability, _ := parseRoleAbility(spec)
+	return ability
+}
+
+// validate returns the translation key of the first problem with the spec, or "".
+func (a RoleAbility) validate(team string) string {
+	switch a.Night {
+	case "", CustomNightInvestigate, CustomNightProtect:
+	default:
+		return "err_custom_role_ability"
+	}
+	if a.Charges < 0 || a.Charges > customRoleMaxCharges {
+		return "err_custom_role_charges"
+	}
+	// the pack's night is spent hunting; only villagers get a custom night ability
+	if a.Night != "" && team != "villager" {
+		return "err_custom_role_wolf_night"
+	}
+	return ""
+}
+
+// handleWSCreateRole adds a role composed from the ability primitives to the role table. Like
+// the role counts it can only be changed in the lobby; the new role is offered in every
+// later game too.
+func handleWSCreateRole(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSCreateRole: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+
+	name := strings.TrimSpace(msg.RoleName)
+	desc := strings.TrimSpace(msg.RoleDesc)
+	if name == "" || len(name) > customRoleMaxName || desc == "" || len(desc) > customRoleMaxDesc {
+		h.sendErrorToast(client.playerID, T(lang, "err_custom_role_name"))
+		return
+	}
+	team := msg.RoleTeam
+	if team != "villager" && team != "werewolf" {
+		h.sendErrorToast(client.playerID, T(lang, "err_custom_role_team"))
+		return
+	}
+
+	ability := RoleAbility{Night: msg.NightAbility, Shield: msg.Shield == "on"}
+	if msg.Charges != "" {
+		if ability.Charges, err = strconv.Atoi(msg.Charges); err != nil {
+			h.sendErrorToast(client.playerID, T(lang, "err_custom_role_charges"))
+			return
+		}
+	}
+	if ability.Night == "" {
+		ability.Charges = 0
+	}
+	if key := ability.validate(team); key != "" {
+		h.sendErrorToast(client.playerID, T(lang, key))
+		return
+	}
+
+	spec, _ := json.Marshal(ability)
+	if _, err := h.db.Exec(`INSERT INTO role (name, description, team, ability, pack) VALUES (?, ?, ?, ?, ?)`,
+		name, desc, team, string(spec), PackCustom); err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			h.sendErrorToast(client.playerID, T(lang, "err_custom_role_exists"))
+			return
+		}
+		h.logError("handleWSCreateRole: db.Exec insert role", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_custom_role_failed"))
+		return
+	}
+
+	h.logf("Custom role '%s' (%s) created: %s", name, team, spec)
+	h.triggerBroadcast()
+}
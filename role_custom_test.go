@@ -0,0 +1,125 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Custom Role Tests
+// ============================================================================
+
+// insertCustomRole adds a role with the given ability spec and returns its role ID.
+func insertCustomRole(ctx *TestContext, name, team, spec string) string {
+	ctx.t.Helper()
+	res := ctx.app.db.MustExec("INSERT INTO role (name, description, team, ability) VALUES (?, ?, ?, ?)",
+		name, name+" description", team, spec)
+	id, _ := res.LastInsertId()
+	return strconv.FormatInt(id, 10)
+}
+
+func TestCreateCustomRoleInLobby(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"P1"}, []string{RoleVillager})
+	p1 := ids[0]
+
+	ctx.sendWS(p1, WSMessage{Action: "create_role", RoleName: "Night Owl", RoleDesc: "Watches over the village.",
+		RoleTeam: "villager", NightAbility: "investigate", Charges: "2", Shield: "on"})
+	var spec string
+	if err := ctx.app.db.Get(&spec, "SELECT ability FROM role WHERE name = 'Night Owl'"); err != nil {
+		t.Fatalf("the role should be stored: %v", err)
+	}
+	if want := `{"night":"investigate","charges":2,"shield":true}`; spec != want {
+		t.Errorf("ability spec = %s, want %s", spec, want)
+	}
+
+	game, _ := ctx.hub().getGame()
+	buf, err := getGameComponent(ctx.hub(), p1, game, "en")
+	if err != nil {
+		t.Fatalf("getGameComponent: %v", err)
+	}
+	if html := buf.String(); !strings.Contains(html, "Night Owl") || !strings.Contains(html, "Watches over the village.") || strings.Contains(html, "role_name_Night Owl") {
+		t.Errorf("the lobby should offer the new role by its own name and description")
+	}
+
+	var n int
+	ctx.sendWS(p1, WSMessage{Action: "create_role", RoleName: "Night Owl", RoleDesc: "Again.", RoleTeam: "villager"})
+	ctx.sendWS(p1, WSMessage{Action: "create_role", RoleName: "Seeing Wolf", RoleDesc: "Nope.", RoleTeam: "werewolf", NightAbility: "investigate"})
+	ctx.sendWS(p1, WSMessage{Action: "create_role", RoleName: "Greedy", RoleDesc: "Nope.", RoleTeam: "villager", NightAbility: "protect", Charges: "99"})
+	ctx.app.db.Get(&n, "SELECT COUNT(*) FROM role WHERE name IN ('Night Owl', 'Seeing Wolf', 'Greedy')")
+	if n != 1 {
+		t.Errorf("duplicate names, wolf night abilities and too many charges must be rejected, got %d roles", n)
+	}
+}
+
+func TestCreateCustomRoleOnlyInLobby(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1, []string{"Wolf", "V1"}, []string{RoleWerewolf, RoleVillager})
+	ctx.sendWS(ids[1], WSMessage{Action: "create_role", RoleName: "Latecomer", RoleDesc: "Too late.", RoleTeam: "villager"})
+	var n int
+	ctx.app.db.Get(&n, "SELECT COUNT(*) FROM role WHERE name = 'Latecomer'")
+	if n != 0 {
+		t.Error("roles can only be created in the lobby")
+	}
+}
+
+func TestCustomRoleInvestigatesInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a custom role built in the lobby ===")
+
+	var players []*TestPlayer
+	for _, name := range []string{"P1", "P2", "P3"} {
+		players = append(players, browser.signupPlayer(ctx.baseURL, name))
+	}
+	host := players[0]
+
+	host.submitFormWithValues("custom-role-form", map[string]string{
+		"role_name": "Night Owl", "role_desc": "Watches over the village.",
+		"role_team": "villager", "night_ability": "investigate", "charges": "0",
+	})
+	card, err := host.p().Element(".player-card[role-name='Night Owl']")
+	if err != nil {
+		ctx.logger.LogDB("FAIL: custom role not offered")
+		t.Fatalf("the lobby should offer the new role: %v", err)
+	}
+	htmlID, _ := card.Attribute("id")
+	if htmlID == nil {
+		t.Fatal("the new role's card should have an id")
+	}
+	host.addRoleByID(RoleWerewolf)
+	host.addRoleByID(RoleVillager)
+	host.addRoleByID(strings.TrimPrefix(*htmlID, "role-"))
+	host.startGame()
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Night Owl"]) != 1 || len(byRole["Werewolf"]) != 1 {
+		t.Fatalf("expected one Night Owl and one Werewolf, got %v", byRole)
+	}
+	owl, wolf := byRole["Night Owl"][0], byRole["Werewolf"][0]
+
+	owl.selectAndConfirm("custom-select-form-", wolf.Name, "#custom-act-button")
+	result, err := owl.p().Element("#custom-result-note")
+	if err != nil {
+		ctx.logger.LogDB("FAIL: no custom result")
+		t.Fatalf("the Night Owl should see what they found: %v", err)
+	}
+	if text, _ := result.Text(); !strings.Contains(text, wolf.Name+" is a werewolf!") {
+		t.Errorf("the Night Owl should find the werewolf, got %q", text)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// A player's secret code is stored only as a salted PBKDF2-SHA256 hash,
+// "pbkdf2-sha256$<iterations>$<salt>$<hash>" with base64 salt and hash. The raw code exists
+// once, when the account is made: the sign-up page shows it a single time (see
+// newSecretCodeCookie) and the JSON API returns it from the signing-up call only. Bots
+// have an empty secret_code, which matches no code, so nobody can sign in as one.
+
+const secretCodeHashPrefix = "pbkdf2-sha256$"
+
+// secretCodeIterations is the PBKDF2 work factor for new hashes; old hashes keep theirs.
+const secretCodeIterations = 600_000
+
+// hashSecretCode returns the stored form of a secret code.
+func hashSecretCode(code string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := pbkdf2.Key(sha256.New, code, salt, secretCodeIterations, sha256.Size)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%d$%s$%s", secretCodeHashPrefix, secretCodeIterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// verifySecretCode reports whether code matches the stored hash.
+func verifySecretCode(stored, code string) bool {
+	parts := strings.Split(strings.TrimPrefix(stored, secretCodeHashPrefix), "$")
+	if !strings.HasPrefix(stored, secretCodeHashPrefix) || len(parts) != 3 || code == "" {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[0])
+	if err != nil || iterations < 1 {
+		return false
+	}
+	salt, err1 := base64.RawStdEncoding.DecodeString(parts[1])
+	want, err2 := base64.RawStdEncoding.DecodeString(parts[2])
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	key, err := pbkdf2.Key(sha256.New, code, salt, iterations, len(want))
+	return err == nil && subtle.ConstantTimeCompare(key, want) == 1
+}
+
+// hashPlaintextSecretCodes hashes the codes stored in plain text before codes were hashed.
+func hashPlaintextSecretCodes(db *sqlx.DB) error {
+	var players []struct {
+		ID   int64  `db:"id"`
+		Code string `db:"secret_code"`
+	}
+	if err := db.Select(&players, "SELECT rowid AS id, secret_code FROM player WHERE secret_code != '' AND secret_code NOT LIKE 'pbkdf2-sha256$%'"); err != nil {
+		return err
+	}
+	for _, p := range players {
+		hash, err := hashSecretCode(p.Code)
+		if err != nil {
+			return err
+		}
+		if _, err := db.Exec("UPDATE player SET secret_code = ? WHERE rowid = ?", hash, p.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
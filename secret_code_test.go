@@ -0,0 +1,98 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Secret Code Tests
+// ============================================================================
+
+func TestSecretCodeHash(t *testing.T) {
+	t.Parallel()
+	hash, err := hashSecretCode("cafe0123")
+	if err != nil || !strings.HasPrefix(hash, secretCodeHashPrefix) || strings.Contains(hash, "cafe0123") {
+		t.Fatalf("the code should be stored as a hash, got %q (%v)", hash, err)
+	}
+	if other, _ := hashSecretCode("cafe0123"); other == hash {
+		t.Errorf("two hashes of one code should differ by their salt")
+	}
+	for code, want := range map[string]bool{"cafe0123": true, "cafe0124": false, "": false} {
+		if got := verifySecretCode(hash, code); got != want {
+			t.Errorf("verify %q: got %v, want %v", code, got, want)
+		}
+	}
+	for _, stored := range []string{"", "cafe0123", "pbkdf2-sha256$x$y$z"} {
+		if verifySecretCode(stored, "cafe0123") {
+			t.Errorf("%q is no hash and should match nothing", stored)
+		}
+	}
+}
+
+func TestPlaintextSecretCodesAreHashed(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ctx.app.db.MustExec("INSERT INTO player (name, secret_code) VALUES ('Old', 'cafe0123'), ('Bot', '')")
+	if err := hashPlaintextSecretCodes(ctx.app.db); err != nil {
+		t.Fatalf("hashPlaintextSecretCodes: %v", err)
+	}
+	var old, bot string
+	ctx.app.db.Get(&old, "SELECT secret_code FROM player WHERE name = 'Old'")
+	ctx.app.db.Get(&bot, "SELECT secret_code FROM player WHERE name = 'Bot'")
+	if !verifySecretCode(old, "cafe0123") || bot != "" {
+		t.Errorf("the old code should be hashed and the bot's left empty, got %q / %q", old, bot)
+	}
+	if _, _, errKey := ctx.app.signin("Old", "cafe0123"); errKey != "" {
+		t.Errorf("the player should still sign in with their old code, got %s", errKey)
+	}
+}
+
+func TestSecretCodeIsShownOnce(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	c := ctx.apiClient()
+	resp, err := c.PostForm(ctx.baseURL+"/signin", url.Values{"name": {"Alice"}, "game_name": {"test-game"}})
+	if err != nil {
+		t.Fatalf("signin: %v", err)
+	}
+	resp.Body.Close()
+
+	page := func() string {
+		resp, err := c.Get(ctx.baseURL + "/game/test-game")
+		if err != nil {
+			t.Fatalf("game page: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	first := page()
+	start := strings.Index(first, `id="secret-code-display">`)
+	if start < 0 {
+		t.Fatalf("the page after the signup should show the code")
+	}
+	code := first[start+len(`id="secret-code-display">`):]
+	code = code[:strings.Index(code, "<")]
+	if strings.Contains(page(), "secret-code-display") {
+		t.Errorf("the code should be shown only once")
+	}
+
+	var stored string
+	ctx.app.db.Get(&stored, "SELECT secret_code FROM player WHERE name = 'Alice'")
+	if stored == code || !verifySecretCode(stored, code) {
+		t.Errorf("only the code's hash should be stored, got %q for %q", stored, code)
+	}
+
+	var session apiSession
+	if status := ctx.apiCall(ctx.apiClient(), "POST", "/api/v1/session", apiSignin{Name: "Alice", SecretCode: code}, &session); status != http.StatusOK || session.SecretCode != "" {
+		t.Errorf("signing in again should work without handing out the code, got %d %+v", status, session)
+	}
+}
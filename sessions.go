@@ -0,0 +1,150 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// The start page lists the signed-in player's sessions: the browser each was made in, when
+// it started and when it was last used. Since anyone with the name and the secret code can
+// sign in from anywhere, the player can end any one of them (POST /sessions/{id}/revoke) or
+// all of them at once, this one included (POST /logout/everywhere), which also closes the
+// game pages they have open.
+
+// SessionView is one of the player's sessions on the start page.
+type SessionView struct {
+	ID         int64  `db:"id"`
+	UserAgent  string `db:"user_agent"`
+	CreatedAt  string `db:"created_at"`
+	LastSeenAt string `db:"last_seen_at"`
+	Current    bool   `db:"current"` // the session of the page showing the list
+}
+
+// SessionListData fills the "session-list" template.
+type SessionListData struct {
+	Sessions []SessionView
+	Lang     string
+}
+
+// Device names the browser and system from the user agent, empty when it is not recognised.
+func (s SessionView) Device() string {
+	ua := s.UserAgent
+	browser := ""
+	switch {
+	case strings.Contains(ua, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "Chrome/"), strings.Contains(ua, "Chromium/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "Safari/"):
+		browser = "Safari"
+	}
+	system := ""
+	switch {
+	case strings.Contains(ua, "Android"):
+		system = "Android"
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"):
+		system = "iOS"
+	case strings.Contains(ua, "Windows"):
+		system = "Windows"
+	case strings.Contains(ua, "Mac OS X"):
+		system = "macOS"
+	case strings.Contains(ua, "Linux"):
+		system = "Linux"
+	}
+	switch {
+	case browser != "" && system != "":
+		return browser + " · " + system
+	case browser != "" || system != "":
+		return browser + system
+	}
+	return ""
+}
+
+// playerSessions returns the player's live sessions, most recently used first.
+func playerSessions(db *sqlx.DB, playerID int64, r *http.Request) []SessionView {
+	current := ""
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		current = sessionTokenHash(cookie.Value)
+	}
+	sessions := []SessionView{}
+	db.Select(&sessions, `
+SELECT rowid AS id, user_agent,
+	COALESCE(strftime('%Y-%m-%d %H:%M', created_at), '') AS created_at,
+	COALESCE(strftime('%Y-%m-%d %H:%M', last_seen_at), '') AS last_seen_at,
+	token_hash = ? AS current
+FROM session WHERE player_id = ? AND expires_at > datetime('now')
+ORDER BY current DESC, last_seen_at DESC`, current, playerID)
+	return sessions
+}
+
+// handleRevokeSession ends one of the player's sessions and answers with the updated list,
+// or sends the player to the sign-in when it was this page's own.
+func (app *App) handleRevokeSession(w http.ResponseWriter, r *http.Request) {
+	playerID, err := getPlayerIdFromSession(app.db, r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	var tokenHash string
+	if err := app.db.Get(&tokenHash, "SELECT token_hash FROM session WHERE rowid = ? AND player_id = ?", id, playerID); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	app.db.Exec("DELETE FROM session WHERE rowid = ?", id)
+	app.logf("Player %d ended session %d", playerID, id)
+
+	if cookie, err := r.Cookie(sessionCookieName); err == nil && sessionTokenHash(cookie.Value) == tokenHash {
+		clearSessionCookie(w)
+		w.Header().Set("HX-Redirect", "/")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := app.templates.ExecuteTemplate(w, "session-list", SessionListData{
+		Sessions: playerSessions(app.db, playerID, r),
+		Lang:     getLangFromCookie(r),
+	}); err != nil {
+		app.logf("handleRevokeSession: ExecuteTemplate: %v", err)
+	}
+}
+
+// handleLogoutEverywhere ends every session of the player and closes their open game pages.
+func (app *App) handleLogoutEverywhere(w http.ResponseWriter, r *http.Request) {
+	playerID, err := getPlayerIdFromSession(app.db, r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	res, err := app.db.Exec("DELETE FROM session WHERE player_id = ?", playerID)
+	if err != nil {
+		app.logf("ERROR [handleLogoutEverywhere: delete sessions]: %v", err)
+		http.Error(w, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+	n, _ := res.RowsAffected()
+	app.logf("Player %d logged out everywhere (%d sessions)", playerID, n)
+
+	app.hubsMu.RLock()
+	for _, hub := range app.hubs {
+		hub.disconnectPlayer(playerID)
+	}
+	app.hubsMu.RUnlock()
+
+	clearSessionCookie(w)
+	w.Header().Set("HX-Redirect", "/")
+}
+
+func clearSessionCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Session Management Tests
+// ============================================================================
+
+func TestSessionDevice(t *testing.T) {
+	t.Parallel()
+	for ua, want := range map[string]string{
+		"Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0":                                                                  "Firefox · Linux",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1": "Safari · iOS",
+		"Go-http-client/1.1": "",
+	} {
+		if got := (SessionView{UserAgent: ua}).Device(); got != want {
+			t.Errorf("%q: got %q, want %q", ua, got, want)
+		}
+	}
+}
+
+func TestPlayerEndsTheirSessions(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	phone, laptop, other := ctx.apiClient(), ctx.apiClient(), ctx.apiClient()
+	var alice apiSession
+	ctx.apiCall(phone, "POST", "/api/v1/session", apiSignin{Name: "Alice"}, &alice)
+	ctx.apiCall(laptop, "POST", "/api/v1/session", apiSignin{Name: "Alice", SecretCode: alice.SecretCode}, nil)
+	ctx.apiCall(other, "POST", "/api/v1/session", apiSignin{Name: "Bob"}, nil)
+
+	resp, err := phone.Get(ctx.baseURL + "/")
+	if err != nil {
+		t.Fatalf("start page: %v", err)
+	}
+	page, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if n := strings.Count(string(page), `<li id="session-`); n != 2 {
+		t.Fatalf("the start page should list Alice's two sessions, got %d", n)
+	}
+
+	var laptopID, bobID int64
+	ctx.app.db.Get(&laptopID, "SELECT MAX(rowid) FROM session WHERE player_id = ?", alice.PlayerID)
+	ctx.app.db.Get(&bobID, "SELECT rowid FROM session WHERE player_id != ?", alice.PlayerID)
+	if code := ctx.apiCall(phone, "POST", "/sessions/"+strconv.FormatInt(bobID, 10)+"/revoke", nil, nil); code != http.StatusNotFound {
+		t.Errorf("Alice should not end Bob's session, got %d", code)
+	}
+	if code := ctx.apiCall(phone, "POST", "/sessions/"+strconv.FormatInt(laptopID, 10)+"/revoke", nil, nil); code != http.StatusOK {
+		t.Fatalf("Alice should end her laptop's session, got %d", code)
+	}
+	if code := ctx.apiCall(laptop, "GET", "/api/v1/games", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("the laptop should be signed out, got %d", code)
+	}
+
+	ctx.apiCall(laptop, "POST", "/api/v1/session", apiSignin{Name: "Alice", SecretCode: alice.SecretCode}, nil)
+	if code := ctx.apiCall(phone, "POST", "/logout/everywhere", nil, nil); code != http.StatusOK {
+		t.Fatalf("logging out everywhere should work, got %d", code)
+	}
+	for name, c := range map[string]*http.Client{"phone": phone, "laptop": laptop} {
+		if code := ctx.apiCall(c, "GET", "/api/v1/games", nil, nil); code != http.StatusUnauthorized {
+			t.Errorf("the %s should be signed out, got %d", name, code)
+		}
+	}
+	if code := ctx.apiCall(other, "GET", "/api/v1/games", nil, nil); code != http.StatusOK {
+		t.Errorf("Bob should stay signed in, got %d", code)
+	}
+}
+
+func TestEndSessionInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a player ending their other session ===")
+
+	phone := browser.signupPlayer(ctx.baseURL, "Alice")
+	laptop := browser.loginPlayer(ctx.baseURL, "Alice", phone.SecretCode)
+
+	phone.navigate(ctx.baseURL + "/")
+	sessionCount := func() int {
+		result, err := phone.p().Eval(`() => document.querySelectorAll('#session-list li').length`)
+		if err != nil {
+			t.Fatalf("counting sessions: %v", err)
+		}
+		return result.Value.Int()
+	}
+	if n := sessionCount(); n != 2 {
+		t.Fatalf("the start page should list Alice's two sessions, got %d", n)
+	}
+
+	// the session list sits in a collapsed <details>, so click through JS
+	phone.doWithHTMXSwap(func() {
+		if _, err := phone.p().Eval(`() => document.querySelector('#session-list li:not(:has(mark)) button').click()`); err != nil {
+			t.Fatalf("ending the other session: %v", err)
+		}
+	})
+	if n := sessionCount(); n != 1 {
+		ctx.logger.LogDB("FAIL: session not ended")
+		t.Fatalf("only this device's session should be left, got %d", n)
+	}
+
+	laptop.navigate(ctx.baseURL + "/")
+	if _, err := laptop.p().Element("#auth-name"); err != nil {
+		t.Errorf("the laptop should be signed out: %v", err)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+
+	"werewolf/engine"
+)
+
+// thiefSpareCards is how many extra role cards are dealt face down when a Thief is in the game.
+const thiefSpareCards = 2
+
+type SetupData struct {
+	IsThief      bool // this player is a Thief who still has to choose
+	MustTakeWolf bool // every spare card left is a werewolf, so keeping the Thief card is not allowed
+	SpareCards   []PlayerCardData
+	Lang         string
+}
+
+// spareRoleCount is how many cards beyond one per player the configured pool has to hold:
+// thiefSpareCards once a Thief is configured (or about to be, when adding is the Thief's role ID).
+func spareRoleCount(db *sqlx.DB, gameID int64, adding string) int {
+	if adding == RoleThief {
+		return thiefSpareCards
+	}
+	var n int
+	db.Get(&n, `
+SELECT COALESCE(SUM(c.count), 0) FROM game_role_config c
+JOIN role r ON c.role_id = r.rowid
+WHERE c.game_id = ? AND r.name = 'Thief'`, gameID)
+	if n > 0 {
+		return thiefSpareCards
+	}
+	return 0
+}
+
+// spareRoles returns the cards left face down at the start, in the order they were dealt.
+func spareRoles(db *sqlx.DB, gameID int64) []Role {
+	var roles []Role
+	db.Select(&roles, `
+SELECT r.rowid as id, r.name, r.description, r.team FROM game_spare_role s
+JOIN role r ON s.role_id = r.rowid
+WHERE s.game_id = ? ORDER BY s.rowid`, gameID)
+	return roles
+}
+
+// pendingThieves returns the Thieves who have not yet chosen between their card and a spare one.
+func pendingThieves(db *sqlx.DB, gameID int64) []int64 {
+	var ids []int64
+	db.Select(&ids, `
+SELECT g.player_id FROM game_player g
+JOIN role r ON g.role_id = r.rowid
+WHERE g.game_id = ? AND r.name = 'Thief'
+AND g.player_id NOT IN (SELECT actor_player_id FROM game_action WHERE game_id = ? AND phase = 'setup' AND action_type = ?)`,
+		gameID, gameID, ActionThiefApplyChoice)
+	return ids
+}
+
+// onlyWolvesLeft reports whether every spare card is a werewolf, in which case the Thief must take one.
+func onlyWolvesLeft(spares []Role) bool {
+	for _, r := range spares {
+		if r.Team != "werewolf" || wolfPackHelpers[r.Name] {
+			return false
+		}
+	}
+	return len(spares) > 0
+}
+
+func buildSetupData(db *sqlx.DB, game *Game, playerID int64, lang string) SetupData {
+	d := SetupData{Lang: lang}
+	isPending := false
+	for _, id := range pendingThieves(db, game.ID) {
+		if id == playerID {
+			isPending = true
+		}
+	}
+	if !isPending {
+		return d
+	}
+
+	spares := spareRoles(db, game.ID)
+	d.IsThief = true
+	d.MustTakeWolf = onlyWolvesLeft(spares)
+	for i, r := range spares {
+		d.SpareCards = append(d.SpareCards, PlayerCardData{
+			HTMLID:   fmt.Sprintf("spare-role-%d", i),
+			RoleName: r.Name,
+			RoleDesc: r.Description,
+			Team:     r.Team,
+			RoleID:   strconv.FormatInt(r.ID, 10),
+			Lang:     lang,
+		})
+	}
+	return d
+}
+
+// startFirstNight ends the setup (or the lobby, when no Thief was dealt): the Drunks get their
+// hidden role and night 1 begins, or the Mayor election first when the game has one.
+func (h *Hub) startFirstNight(gameID int64) error {
+	if err := h.assignDrunkRoles(gameID); err != nil {
+		return err
+	}
+	if mayorElectionEnabled(h.db, gameID) {
+		_, err := h.db.Exec("UPDATE game SET status = 'election', round = 0 WHERE rowid = ?", gameID)
+		return err
+	}
+	return h.beginFirstNight(gameID)
+}
+
+// beginFirstNight moves the game to night 1; the Masons learn who the others are.
+func (h *Hub) beginFirstNight(gameID int64) error {
+	next, round := engine.Next(engine.Setup, 0)
+	if _, err := h.db.Exec("UPDATE game SET status = ?, round = ? WHERE rowid = ?", next, round, gameID); err != nil {
+		return err
+	}
+	h.recordMasonReveal(gameID)
+	h.saveCheckpoint(gameID, "night")
+	h.startNightTimer(gameID, round)
+	return nil
+}
+
+// handleWSThiefChoose records a Thief's choice. With keep set the Thief stays a Thief (only
+// allowed while some spare card is not a werewolf); otherwise they swap their card for the
+// spare card with msg.RoleID. Night 1 starts once every Thief has chosen.
+func handleWSThiefChoose(client *Client, msg WSMessage, keep bool) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSThiefChoose: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+
+	if game.Status != "setup" {
+		h.sendErrorToast(client.playerID, T(lang, "err_thief_setup_only"))
+		return
+	}
+
+	thief, err := getPlayerInGame(h.db, game.ID, client.playerID)
+	if err != nil {
+		h.logError("handleWSThiefChoose: getPlayerInGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_not_in_game"))
+		return
+	}
+
+	if thief.RoleName != "Thief" {
+		h.sendErrorToast(client.playerID, T(lang, "err_only_thief"))
+		return
+	}
+
+	var existingCount int
+	h.db.Get(&existingCount, `SELECT COUNT(*) FROM game_action WHERE game_id = ? AND phase = 'setup' AND actor_player_id = ? AND action_type = ?`,
+		game.ID, client.playerID, ActionThiefApplyChoice)
+	if existingCount > 0 {
+		h.sendErrorToast(client.playerID, T(lang, "err_thief_done"))
+		return
+	}
+
+	spares := spareRoles(h.db, game.ID)
+	desc := "Before night 1: You kept the Thief card"
+	key, args := "hist_thief_kept", ""
+	if keep {
+		if onlyWolvesLeft(spares) {
+			h.sendErrorToast(client.playerID, T(lang, "err_thief_must_take_wolf"))
+			return
+		}
+	} else {
+		var taken *Role
+		for i := range spares {
+			if strconv.FormatInt(spares[i].ID, 10) == msg.RoleID {
+				taken = &spares[i]
+				break
+			}
+		}
+		if taken == nil {
+			h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+			return
+		}
+
+		// identical spare cards are interchangeable, so any one matching row goes
+		h.db.Exec(`DELETE FROM game_spare_role WHERE rowid = (SELECT rowid FROM game_spare_role WHERE game_id = ? AND role_id = ? LIMIT 1)`,
+			game.ID, taken.ID)
+		if _, err := h.db.Exec("UPDATE game_player SET role_id = ? WHERE game_id = ? AND player_id = ?", taken.ID, game.ID, client.playerID); err != nil {
+			h.logError("handleWSThiefChoose: db.Exec swap role", err)
+			h.sendErrorToast(client.playerID, T(lang, "err_failed_record_thief"))
+			return
+		}
+		desc = fmt.Sprintf("Before night 1: You stole the %s card", taken.Name)
+		key, args = "hist_thief_stole", histArgs(taken.Name)
+	}
+
+	_, err = h.db.Exec(`
+INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description, description_key, description_args)
+VALUES (?, 0, 'setup', ?, ?, ?, ?, ?, ?)`,
+		game.ID, client.playerID, ActionThiefApplyChoice, VisibilityActor, desc, key, args)
+	if err != nil {
+		h.logError("handleWSThiefChoose: db.Exec insert choice", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_record_thief"))
+		return
+	}
+
+	h.logf("Thief '%s' made their choice (keep: %v)", thief.Name, keep)
+	DebugLog("handleWSThiefChoose", "Thief '%s' made their choice (keep: %v)", thief.Name, keep)
+	LogDBState(h.db, "after thief choice")
+
+	if len(pendingThieves(h.db, game.ID)) > 0 {
+		h.triggerBroadcast()
+		return
+	}
+
+	if err := h.startFirstNight(game.ID); err != nil {
+		h.logError("handleWSThiefChoose: startFirstNight", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_start_game"))
+		return
+	}
+	h.logf("Every Thief has chosen, game status updated to 'night' (night 1)")
+	h.triggerBroadcast()
+	h.maybeSpeakStory(game.ID, T(h.storytellerLang, "tts_game_begins"))
+}
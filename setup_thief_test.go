@@ -0,0 +1,201 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Thief Helpers
+// ============================================================================
+
+// thiefTakeFirstSpare takes the first spare card offered to the Thief and returns its role.
+func (tp *TestPlayer) thiefTakeFirstSpare() string {
+	card, err := tp.p().Element("[id^='thief-take-form-'] .player-card")
+	if err != nil {
+		tp.t.Fatalf("[%s] thiefTakeFirstSpare: no spare card: %v", tp.Name, err)
+	}
+	role, _ := card.Attribute("role-name")
+	if role == nil {
+		tp.t.Fatalf("[%s] thiefTakeFirstSpare: spare card has no role-name", tp.Name)
+	}
+	if tp.logger != nil {
+		tp.logger.Debug("[%s] Thief taking the %s card", tp.Name, *role)
+	}
+	tp.clickElementAndWait(card)
+	tp.logHTML("after thief took " + *role)
+	return *role
+}
+
+// ============================================================================
+// Thief Tests
+// ============================================================================
+
+// seedSpareRoles lays the given roles face down as the Thief's spare cards.
+func seedSpareRoles(ctx *TestContext, roleIDs ...string) {
+	ctx.t.Helper()
+	game, _ := ctx.hub().getGame()
+	for _, id := range roleIDs {
+		ctx.app.db.MustExec("INSERT INTO game_spare_role (game_id, role_id) VALUES (?, ?)", game.ID, id)
+	}
+}
+
+func TestThiefStartNeedsTwoSpareCards(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0,
+		[]string{"T", "P2", "P3"},
+		[]string{RoleVillager, RoleVillager, RoleVillager})
+	game, _ := ctx.hub().getGame()
+	for _, rc := range [][2]string{{RoleThief, "1"}, {RoleWerewolf, "1"}, {RoleVillager, "1"}} {
+		ctx.app.db.MustExec("INSERT INTO game_role_config (game_id, role_id, count) VALUES (?, ?, ?)", game.ID, rc[0], rc[1])
+	}
+
+	ctx.sendWS(ids[0], WSMessage{Action: "start_game"})
+	if status, _, _ := ctx.gameState(); status != "lobby" {
+		t.Fatalf("a Thief needs two spare cards on top of one per player, got %q", status)
+	}
+
+	ctx.app.db.MustExec("UPDATE game_role_config SET count = 3 WHERE game_id = ? AND role_id = ?", game.ID, RoleVillager)
+	ctx.sendWS(ids[0], WSMessage{Action: "start_game"})
+	status, round, _ := ctx.gameState()
+	if len(spareRoles(ctx.app.db, game.ID)) != thiefSpareCards {
+		t.Errorf("expected %d spare cards to be set aside", thiefSpareCards)
+	}
+	// the Thief may have landed among the spare cards, in which case night 1 starts right away
+	if thiefDealt := len(pendingThieves(ctx.app.db, game.ID)) > 0; thiefDealt && (status != "setup" || round != 0) {
+		t.Errorf("a dealt Thief should hold the game in setup, got %q round %d", status, round)
+	} else if !thiefDealt && (status != "night" || round != 1) {
+		t.Errorf("without a dealt Thief night 1 should start, got %q round %d", status, round)
+	}
+}
+
+func TestThiefMustTakeWolfWhenBothSparesAreWolves(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("setup", 0,
+		[]string{"Thief", "V1", "V2"},
+		[]string{RoleThief, RoleVillager, RoleVillager})
+	thief := ids[0]
+	seedSpareRoles(ctx, RoleWerewolf, RoleWerewolf)
+
+	game, _ := ctx.hub().getGame()
+	buf, err := getGameComponent(ctx.hub(), thief, game, "en")
+	if err != nil {
+		t.Fatalf("getGameComponent: %v", err)
+	}
+	if html := buf.String(); !strings.Contains(html, "you must take one") || strings.Contains(html, "thief-keep-form") {
+		t.Errorf("the Thief should be told to take a werewolf, got: %s", html)
+	}
+
+	ctx.sendWS(thief, WSMessage{Action: "thief_keep"})
+	if n := ctx.countActions(ActionThiefApplyChoice); n != 0 {
+		t.Fatal("the Thief must not keep their card when both spares are werewolves")
+	}
+
+	ctx.sendWS(thief, WSMessage{Action: "thief_take", RoleID: RoleWerewolf})
+	player, _ := getPlayerInGame(ctx.app.db, game.ID, thief)
+	if player.RoleName != "Werewolf" {
+		t.Errorf("the Thief should now be a Werewolf, got %q", player.RoleName)
+	}
+	if n := len(spareRoles(ctx.app.db, game.ID)); n != 1 {
+		t.Errorf("only the taken card should leave the spares, %d left", n)
+	}
+	if h := ctx.historyFor(thief); !strings.Contains(h, "stole the Werewolf card") {
+		t.Errorf("the Thief should see the swap in history, got: %q", h)
+	}
+	if h := ctx.historyFor(ids[1]); strings.Contains(h, "stole") {
+		t.Errorf("the swap must stay private, villager sees: %q", h)
+	}
+	if status, round, _ := ctx.gameState(); status != "night" || round != 1 {
+		t.Errorf("night 1 should begin once the Thief has chosen, got %q round %d", status, round)
+	}
+}
+
+func TestThiefKeepsCard(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("setup", 0,
+		[]string{"Thief", "Wolf", "V1"},
+		[]string{RoleThief, RoleWerewolf, RoleVillager})
+	thief := ids[0]
+	seedSpareRoles(ctx, RoleWerewolf, RoleSeer)
+
+	ctx.sendWS(thief, WSMessage{Action: "thief_take", RoleID: RoleDoctor})
+	if n := ctx.countActions(ActionThiefApplyChoice); n != 0 {
+		t.Fatal("the Thief can only take one of the spare cards")
+	}
+
+	ctx.sendWS(thief, WSMessage{Action: "thief_keep"})
+	game, _ := ctx.hub().getGame()
+	player, _ := getPlayerInGame(ctx.app.db, game.ID, thief)
+	if player.RoleName != "Thief" {
+		t.Errorf("the Thief should keep their card, got %q", player.RoleName)
+	}
+	if status, _, _ := ctx.gameState(); status != "night" {
+		t.Errorf("night 1 should begin once the Thief has chosen, got %q", status)
+	}
+}
+
+func TestThiefStealsCardInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Thief swaps their card for a spare before night 1 ===")
+
+	// Setup: 3 players and 5 cards; two stay face down for the Thief
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"TH1", "TH2", "TH3"},
+		RoleThief, RoleWerewolf, RoleVillager, RoleSeer, RoleDoctor)
+
+	byRole := playersByRole(players)
+	if len(byRole["Thief"]) == 0 {
+		t.Skip("The Thief card was left face down; nobody to steal")
+	}
+	thief := byRole["Thief"][0]
+	var other *TestPlayer
+	for _, p := range players {
+		if p != thief {
+			other = p
+			break
+		}
+	}
+
+	// The others wait while the Thief chooses
+	if err := thief.waitUntilCondition(`() => !!document.querySelector("[id^='thief-take-form-']")`, "thief choice"); err != nil {
+		ctx.logger.LogDB("FAIL: thief not offered the spare cards")
+		t.Fatalf("Thief should be offered the spare cards: %v", err)
+	}
+	if found, _, _ := other.p().Has("#setup-waiting"); !found {
+		ctx.logger.LogDB("FAIL: other player not waiting for the thief")
+		t.Errorf("Other players should wait for the Thief")
+	}
+
+	stolen := thief.thiefTakeFirstSpare()
+	waitForNightPhaseAll(ctx, players)
+
+	if err := thief.waitForRole(stolen); err != nil {
+		ctx.logger.LogDB("FAIL: thief did not get the stolen card")
+		t.Fatalf("Thief should now be the %s, got: %s", stolen, thief.getRole())
+	}
+	entry := "You stole the " + stolen + " card"
+	if !thief.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: thief choice missing from history")
+		t.Errorf("Thief should see %q in history, got: %s", entry, thief.getHistoryText())
+	}
+	if other.historyContains(entry) {
+		ctx.logger.LogDB("FAIL: thief choice visible to others")
+		t.Errorf("Only the Thief should see what they stole")
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// When a game ends, endGame writes one player_game_result row per seated player: the role
+// they ended with, the team it won for (winTeam), whether they won, survived, or were lynched
+// on the first day. The stats page (/stats, /stats/{name}) adds the rows up per player.
+
+type TeamStat struct {
+	Team  string `db:"team"`
+	Games int    `db:"games"`
+	Wins  int    `db:"wins"`
+}
+
+type RoleStat struct {
+	Role  string `db:"role_name"`
+	Games int    `db:"games"`
+	Wins  int    `db:"wins"`
+}
+
+type PlayerStats struct {
+	Name            string
+	Games           int `db:"games"`
+	Wins            int `db:"wins"`
+	Survived        int `db:"survived"`
+	LynchedFirstDay int `db:"lynched_first_day"`
+	Teams           []TeamStat
+	Roles           []RoleStat
+}
+
+// SurvivalRate is the share of games the player lived to see the end of, in percent.
+func (s PlayerStats) SurvivalRate() int {
+	if s.Games == 0 {
+		return 0
+	}
+	return s.Survived * 100 / s.Games
+}
+
+type StatsPageData struct {
+	Stats    *PlayerStats // nil when the player is unknown
+	StyleTag template.HTML
+	Lang     string
+}
+
+// recordResults writes every seated player's result of a game that just ended.
+func (h *Hub) recordResults(game *Game, winner string) {
+	players, err := getPlayersByGameId(h.db, game.ID)
+	if err != nil {
+		h.logError("recordResults: getPlayersByGameId", err)
+		return
+	}
+	var lynched []int64
+	h.db.Select(&lynched, `SELECT target_player_id FROM game_action WHERE game_id = ? AND round = 1 AND phase = 'day' AND action_type = ? AND target_player_id IS NOT NULL`,
+		game.ID, ActionDayApplyKill)
+	firstDay := make(map[int64]bool, len(lynched))
+	for _, id := range lynched {
+		firstDay[id] = true
+	}
+
+	for _, p := range players {
+		if p.IsObserver {
+			continue
+		}
+		team := winTeam(p.RoleName, p.Team)
+		if _, err := h.db.Exec(`INSERT OR REPLACE INTO player_game_result (game_id, player_id, role_name, team, won, survived, lynched_first_day) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			game.ID, p.PlayerID, p.RoleName, team, playerWon(winner, team, p.IsAlive), p.IsAlive, firstDay[p.PlayerID]); err != nil {
+			h.logError("recordResults: db.Exec", err)
+		}
+	}
+}
+
+// playerStats adds up a player's results.
+func playerStats(db *sqlx.DB, playerID int64) (PlayerStats, error) {
+	var s PlayerStats
+	if err := db.Get(&s, `
+		SELECT COUNT(*) as games, IFNULL(SUM(won), 0) as wins, IFNULL(SUM(survived), 0) as survived,
+			IFNULL(SUM(lynched_first_day), 0) as lynched_first_day
+		FROM player_game_result WHERE player_id = ?`, playerID); err != nil {
+		return s, err
+	}
+	db.Select(&s.Teams, `
+		SELECT team, COUNT(*) as games, SUM(won) as wins FROM player_game_result
+		WHERE player_id = ? GROUP BY team ORDER BY games DESC, team`, playerID)
+	db.Select(&s.Roles, `
+		SELECT role_name, COUNT(*) as games, SUM(won) as wins FROM player_game_result
+		WHERE player_id = ? GROUP BY role_name ORDER BY games DESC, role_name`, playerID)
+	return s, nil
+}
+
+// handleStats shows the signed-in player's stats, or the named player's at /stats/{name}.
+func (app *App) handleStats(w http.ResponseWriter, r *http.Request) {
+	data := StatsPageData{StyleTag: app.pageStyleTag, Lang: getLangFromCookie(r)}
+
+	var player Player
+	var err error
+	if name := r.PathValue("name"); name != "" {
+		player, err = getPlayerByName(app.db, name)
+	} else {
+		var playerID int64
+		if playerID, err = getPlayerIdFromSession(app.db, r); err != nil {
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+		player.ID = playerID
+		err = app.db.Get(&player.Name, "SELECT name FROM player WHERE rowid = ?", playerID)
+	}
+
+	if err == nil {
+		stats, err := playerStats(app.db, player.ID)
+		if err != nil {
+			app.logf("ERROR [handleStats: playerStats]: %v", err)
+			http.Error(w, "Something went wrong", http.StatusInternalServerError)
+			return
+		}
+		stats.Name = player.Name
+		data.Stats = &stats
+	} else {
+		w.WriteHeader(http.StatusNotFound)
+	}
+	app.templates.ExecuteTemplate(w, "stats.html", data)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Player Stats Tests
+// ============================================================================
+
+func TestEndGameRecordsPlayerStats(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host, wolf, v1 := ids[0], ids[1], ids[2]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, v1)
+	ctx.app.db.MustExec(`INSERT INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility) VALUES (?, 1, 'day', 0, ?, ?, ?)`,
+		game.ID, ActionDayApplyKill, v1, VisibilityPublic)
+
+	ctx.sendWS(host, WSMessage{Action: "moderator_kill", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	if status, _, winner := ctx.gameState(); status != "finished" || winner != "villagers" {
+		t.Fatalf("killing the last wolf should end the game, got %q/%q", status, winner)
+	}
+
+	stats, err := playerStats(ctx.app.db, v1)
+	if err != nil || stats.Games != 1 || stats.Wins != 1 || stats.Survived != 0 || stats.LynchedFirstDay != 1 {
+		t.Errorf("V1 should have won a game, lynched on the first day, got %+v (err: %v)", stats, err)
+	}
+	stats, _ = playerStats(ctx.app.db, wolf)
+	if stats.Games != 1 || stats.Wins != 0 || len(stats.Roles) != 1 || stats.Roles[0].Role != "Werewolf" || stats.Teams[0].Team != "werewolf" {
+		t.Errorf("the wolf should have lost one game as a Werewolf, got %+v", stats)
+	}
+	stats, _ = playerStats(ctx.app.db, host)
+	if stats.SurvivalRate() != 100 {
+		t.Errorf("the host survived their only game, got %d%%", stats.SurvivalRate())
+	}
+
+	req := httptest.NewRequest("GET", "/stats/V1", nil)
+	req.SetPathValue("name", "V1")
+	rec := httptest.NewRecorder()
+	ctx.app.handleStats(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Stats for V1") || !strings.Contains(rec.Body.String(), "Village") {
+		t.Errorf("the stats page should show V1's games, got %d:\n%s", rec.Code, rec.Body.String())
+	}
+	req = httptest.NewRequest("GET", "/stats/Nobody", nil)
+	req.SetPathValue("name", "Nobody")
+	rec = httptest.NewRecorder()
+	ctx.app.handleStats(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("an unknown player should get a 404, got %d", rec.Code)
+	}
+}
+
+func TestPlayerStatsInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing a player's own stats page ===")
+
+	players, wolf := finishGameInBrowser(ctx, browser)
+
+	wolf.navigate(ctx.baseURL + "/")
+	wolf.followLink("#stats-link")
+	heading, err := wolf.p().Element("#stats-name")
+	if err != nil {
+		t.Fatalf("the stats page should open: %v", err)
+	}
+	if text, _ := heading.Text(); !strings.Contains(text, wolf.Name) {
+		t.Errorf("the stats page should be the wolf's own, got %q", text)
+	}
+	if roles, err := wolf.p().Element("#stats-roles"); err != nil {
+		t.Errorf("the stats should break the games down by role: %v", err)
+	} else if text, _ := roles.Text(); !strings.Contains(text, "Werewolf") {
+		t.Errorf("the wolf's game should count for the Werewolf, got %q", text)
+	}
+
+	_, villagers := findPlayersByRole(players)
+	wolf.navigate(ctx.baseURL + "/stats/" + villagers[0].Name)
+	if heading, err := wolf.p().Element("#stats-name"); err != nil {
+		t.Errorf("another player's stats should open too: %v", err)
+	} else if text, _ := heading.Text(); !strings.Contains(text, villagers[0].Name) {
+		t.Errorf("the page should show %s's stats, got %q", villagers[0].Name, text)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -247,6 +247,14 @@ func (h *Hub) maybeGenerateEnding(gameID int64, round int, winner string) {
 			h.maybeSpeakStory(gameID, T(h.storytellerLang, "tts_werewolves_win"))
 		case "lovers":
 			h.maybeSpeakStory(gameID, T(h.storytellerLang, "tts_lovers_win"))
+		case "tanner":
+			h.maybeSpeakStory(gameID, T(h.storytellerLang, "tts_tanner_win"))
+		case "serial_killer":
+			h.maybeSpeakStory(gameID, T(h.storytellerLang, "tts_serial_killer_win"))
+		case "piper":
+			h.maybeSpeakStory(gameID, T(h.storytellerLang, "tts_piper_win"))
+		case "white_werewolf":
+			h.maybeSpeakStory(gameID, T(h.storytellerLang, "tts_white_werewolf_win"))
 		}
 		return
 	}
@@ -321,14 +329,24 @@ func (h *Hub) maybeGenerateStory(gameID int64, round int, phase string, actorPla
 	}
 
 	go func() {
-		var descriptions []string
-		if err := h.db.Select(&descriptions, `
-			SELECT description FROM game_action
+		var rows []struct {
+			Description string `db:"description"`
+			Key         string `db:"description_key"`
+			Args        string `db:"description_args"`
+		}
+		if err := h.db.Select(&rows, `
+			SELECT description, description_key, description_args FROM game_action
 			WHERE game_id = ? AND description != '' AND visibility = ?
 			ORDER BY rowid ASC`, gameID, VisibilityPublic); err != nil {
 			h.logf("maybeGenerateStory: fetch history: %v", err)
 			return
 		}
+		// the storyteller must not reveal more of the dead than the players may see
+		reveal := roleReveal(h.db, gameID)
+		descriptions := make([]string, 0, len(rows))
+		for _, r := range rows {
+			descriptions = append(descriptions, revealedDescription(h.db, reveal, r.Description, r.Key, r.Args))
+		}
 
 		players, err := getPlayersByGameId(h.db, gameID)
 		if err != nil {
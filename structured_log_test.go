@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ============================================================================
+// Structured Logging Tests
+// ============================================================================
+
+func TestRequestIDIsEchoedAndLogged(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	handler := withRequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "teapot", http.StatusTeapot)
+	}), logger)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/stats", nil))
+	id := rec.Header().Get(requestIDHeader)
+	if len(id) != 16 {
+		t.Fatalf("a request without an id should get one, got %q", id)
+	}
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("the request should be logged as JSON, got %q", buf.String())
+	}
+	if record["request_id"] != id || record["path"] != "/stats" || record["status"] != float64(http.StatusTeapot) {
+		t.Errorf("the record should carry the request's id, path and status, got %v", record)
+	}
+
+	for sent, kept := range map[string]bool{"trace-42.a": true, "not an id": false} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(requestIDHeader, sent)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if got := rec.Header().Get(requestIDHeader) == sent; got != kept {
+			t.Errorf("%q: expected kept=%v, got %q", sent, kept, rec.Header().Get(requestIDHeader))
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// When a player drops out mid-game (their phone died) the host can hand their seat to an
+// observer: the observer takes over the game_player row with its role and life, and every
+// row that names the dropped player (actions, lovers, charms, chat, ...) follows, so the
+// seat plays on as if nothing happened. The dropped player is left watching.
+
+// SeatChoice is a player the host can pick in the substitution form.
+type SeatChoice struct {
+	ID   int64
+	Name string
+}
+
+// SubstitutionData fills the host's substitution form; Show is false for everyone else and
+// whenever there is nobody to swap.
+type SubstitutionData struct {
+	Show      bool
+	Seats     []SeatChoice // seated players without an open connection
+	Observers []SeatChoice // who could take a seat
+}
+
+// seatReferences are the columns holding a seated player's id; a substitution moves them all.
+var seatReferences = []struct{ table, column, gameColumn string }{
+	{"game_player", "player_id", "game_id"},
+	{"game_action", "actor_player_id", "game_id"},
+	{"game_action", "target_player_id", "game_id"},
+	{"game_lovers", "player1_id", "game_id"},
+	{"game_lovers", "player2_id", "game_id"},
+	{"game_charmed", "player_id", "game_id"},
+	{"game_role_model", "child_player_id", "game_id"},
+	{"game_role_model", "model_player_id", "game_id"},
+	{"game_chat", "player_id", "game_id"},
+	{"cupid_selection", "cupid_player_id", "game_id"},
+	{"cupid_selection", "first_player_id", "game_id"},
+	{"cupid_selection", "second_player_id", "game_id"},
+	{"game", "host_player_id", "rowid"},
+}
+
+func (h *Hub) buildSubstitutionData(game *Game, viewerID int64) SubstitutionData {
+	var d SubstitutionData
+	if game.Status == "lobby" || game.Status == "finished" || gameHost(h.db, game.ID) != viewerID {
+		return d
+	}
+	connected := map[int64]bool{}
+	for _, id := range h.connectedPlayerIDs() {
+		connected[id] = true
+	}
+	players, _ := getPlayersByGameId(h.db, game.ID)
+	for _, p := range players {
+		if !connected[p.PlayerID] {
+			d.Seats = append(d.Seats, SeatChoice{ID: p.PlayerID, Name: p.Name})
+		}
+	}
+	observers, _ := getObserversByGameId(h.db, game.ID)
+	for _, o := range observers {
+		d.Observers = append(d.Observers, SeatChoice{ID: o.PlayerID, Name: o.Name})
+	}
+	d.Show = len(d.Seats) > 0 && len(d.Observers) > 0
+	return d
+}
+
+// substitutePlayer moves a seat from one player to an observer in a single transaction and
+// leaves the former player as an observer.
+func substitutePlayer(db *sqlx.DB, gameID, seatID, substituteID int64) error {
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec("DELETE FROM game_player WHERE game_id = ? AND player_id = ? AND is_observer = 1", gameID, substituteID); err != nil {
+		return err
+	}
+	for _, ref := range seatReferences {
+		query := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ? AND %s = ?", ref.table, ref.column, ref.gameColumn, ref.column)
+		if _, err := tx.Exec(query, substituteID, gameID, seatID); err != nil {
+			return fmt.Errorf("move %s.%s: %w", ref.table, ref.column, err)
+		}
+	}
+	if _, err := tx.Exec("INSERT INTO game_player (game_id, player_id, is_alive, is_observer) VALUES (?, ?, 0, 1)", gameID, seatID); err != nil {
+		return err
+	}
+	// the checkpoints still name the former player, so nothing before the swap can be undone
+	if _, err := tx.Exec("DELETE FROM game_checkpoint WHERE game_id = ?", gameID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// handleWSSubstitutePlayer lets the host give a disconnected player's seat to an observer.
+func handleWSSubstitutePlayer(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSubstitutePlayer: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status == "lobby" || game.Status == "finished" {
+		h.sendErrorToast(client.playerID, T(lang, "err_substitute_not_running"))
+		return
+	}
+
+	seatID, err1 := strconv.ParseInt(msg.TargetPlayerID, 10, 64)
+	substituteID, err2 := strconv.ParseInt(msg.SubstitutePlayerID, 10, 64)
+	if err1 != nil || err2 != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	seat, err := getPlayerInGame(h.db, game.ID, seatID)
+	if err != nil || seat.IsObserver || !isObserver(h.db, game.ID, substituteID) {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_target"))
+		return
+	}
+	for _, id := range h.connectedPlayerIDs() {
+		if id == seatID {
+			h.sendErrorToast(client.playerID, T(lang, "err_substitute_still_connected"))
+			return
+		}
+	}
+
+	if err := substitutePlayer(h.db, game.ID, seatID, substituteID); err != nil {
+		h.logError("handleWSSubstitutePlayer: substitutePlayer", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_substitute"))
+		return
+	}
+
+	substituteName := h.playerName(substituteID)
+	desc := fmt.Sprintf("%s took over the seat of %s", substituteName, seat.Name)
+	h.db.Exec(`INSERT OR IGNORE INTO game_action (game_id, round, phase, actor_player_id, action_type, target_player_id, visibility, description, description_key, description_args)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, game.Round, game.Status, substituteID, ActionPlayerSubstituted, seatID, VisibilityPublic, desc, "hist_player_substituted", histArgs(substituteName, seat.Name))
+	h.logf("'%s' took over the seat of '%s' in game %d", substituteName, seat.Name, game.ID)
+	h.triggerBroadcast()
+}
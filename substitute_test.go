@@ -0,0 +1,96 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Player Substitution Tests
+// ============================================================================
+
+func TestHostHandsDroppedSeatToObserver(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("night", 1,
+		[]string{"Host", "Wolf", "Seer", "V1"},
+		[]string{RoleVillager, RoleWerewolf, RoleSeer, RoleVillager})
+	host, seer := ids[0], ids[2]
+	game, _ := ctx.hub().getGame()
+	result := ctx.app.db.MustExec("INSERT INTO player (name, secret_code) VALUES ('Sub', 'x')")
+	sub, _ := result.LastInsertId()
+	ctx.hub().addPlayerToLobby(sub)
+
+	// the Seer looked at the wolf before their phone died
+	ctx.sendWS(seer, WSMessage{Action: "seer_select", TargetPlayerID: strconv.FormatInt(ids[1], 10)})
+	ctx.sendWS(seer, WSMessage{Action: "seer_investigate", TargetPlayerID: strconv.FormatInt(ids[1], 10)})
+
+	if d := ctx.hub().buildSubstitutionData(game, host); !d.Show || len(d.Observers) != 1 {
+		t.Fatalf("the host should be offered the substitution form, got %+v", d)
+	}
+	if d := ctx.hub().buildSubstitutionData(game, seer); d.Show {
+		t.Error("only the host should see the substitution form")
+	}
+
+	ctx.sendWS(ids[3], WSMessage{Action: "substitute_player", TargetPlayerID: strconv.FormatInt(seer, 10), SubstitutePlayerID: strconv.FormatInt(sub, 10)})
+	if isObserver(ctx.app.db, game.ID, seer) {
+		t.Fatal("only the host should hand over seats")
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "substitute_player", TargetPlayerID: strconv.FormatInt(seer, 10), SubstitutePlayerID: strconv.FormatInt(sub, 10)})
+	player, err := getPlayerInGame(ctx.app.db, game.ID, sub)
+	if err != nil || player.RoleName != "Seer" || !player.IsAlive || player.IsObserver {
+		t.Fatalf("the substitute should hold the Seer's seat, got %+v (err: %v)", player, err)
+	}
+	if !isObserver(ctx.app.db, game.ID, seer) || ctx.isPlayerAlive(seer) {
+		t.Error("the dropped player should be left watching")
+	}
+	var investigations int
+	ctx.app.db.Get(&investigations, "SELECT COUNT(*) FROM game_action WHERE game_id = ? AND actor_player_id = ? AND action_type = ?", game.ID, sub, ActionSeerApplyInvestigate)
+	if investigations != 1 {
+		t.Errorf("the seat's night action should follow it, got %d", investigations)
+	}
+	if !strings.Contains(ctx.historyFor(ids[3]), "Sub took over the seat of Seer") {
+		t.Errorf("the table should be told about the substitution, got %q", ctx.historyFor(ids[3]))
+	}
+}
+
+func TestSubstituteInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the host handing a dropped seat to an observer ===")
+
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"Host", "P2", "P3", "P4"},
+		RoleWerewolf, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	host, dropped := players[0], players[3]
+	sub := browser.joinRunningGame(ctx.baseURL, "Sub")
+
+	dropped.disconnect()
+	if err := host.waitUntilCondition(`() => !!document.querySelector('#substitute-form')`, "substitution form"); err != nil {
+		ctx.logger.LogDB("FAIL: no substitution form")
+		t.Fatalf("the host should be offered the dropped seat: %v", err)
+	}
+
+	host.submitFormWithValues("substitute-form", map[string]string{
+		"target_player_id":     host.optionValue("#substitute-seat", "P4"),
+		"substitute_player_id": host.optionValue("#substitute-observer", "Sub"),
+	})
+	if err := sub.waitUntilCondition(`() => !!document.querySelector('#sidebar-role-card') && !document.querySelector('#observer-note')`, "Sub seated"); err != nil {
+		ctx.logger.LogDB("FAIL: substitute not seated")
+		t.Fatalf("the substitute should take over the seat: %v", err)
+	}
+	if !host.historyContains("Sub took over the seat of P4") {
+		t.Errorf("the table should be told about the substitution, got %q", host.getHistoryText())
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
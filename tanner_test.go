@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+// ============================================================================
+// Tanner Tests
+// ============================================================================
+
+func TestTannerWinsWhenLynched(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Wolf", "Tanner", "V1", "V2"},
+		[]string{RoleWerewolf, RoleTanner, RoleVillager, RoleVillager})
+	tanner := strconv.FormatInt(ids[1], 10)
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: tanner})
+	}
+	ctx.sendWS(ids[2], WSMessage{Action: "day_end_vote"})
+
+	status, _, winner := ctx.gameState()
+	if status != "finished" || winner != "tanner" {
+		t.Fatalf("lynching the Tanner should end the game with winner tanner, got status %q winner %q", status, winner)
+	}
+
+	game, _ := ctx.hub().getGame()
+	players, err := getPlayersByGameId(ctx.app.db, game.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range players {
+		won := playerWon(winner, p.Team, p.IsAlive)
+		if won != (p.RoleName == "Tanner") {
+			t.Errorf("%s (%s): won=%v, only the Tanner should win", p.Name, p.RoleName, won)
+		}
+	}
+}
+
+func TestTannerReadsAsVillagerToSeer(t *testing.T) {
+	t.Parallel()
+
+	seer := Player{PlayerID: 1, RoleName: "Seer", Team: "villager", IsAlive: true}
+	tanner := Player{PlayerID: 2, RoleName: "Tanner", Team: "tanner", IsAlive: true}
+
+	out := applyCardVisibility(seer, []Player{tanner}, map[int64]string{2: "tanner"}, RevealFull)
+	if out[0].RoleName != "Villager" || out[0].Team != "villager" {
+		t.Errorf("investigated Tanner should look like a villager, got %q/%q", out[0].RoleName, out[0].Team)
+	}
+}
+
+func TestTannerWinsInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the Tanner wins alone when the village lynches them ===")
+
+	// Setup: 1 werewolf + 1 tanner + 2 villagers = 4 players
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"TA1", "TA2", "TA3", "TA4"},
+		RoleWerewolf, RoleTanner, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+
+	byRole := playersByRole(players)
+	if len(byRole["Tanner"]) == 0 || len(byRole["Werewolf"]) == 0 || len(byRole["Villager"]) < 2 {
+		t.Fatal("Missing required roles")
+	}
+	tanner, werewolf := byRole["Tanner"][0], byRole["Werewolf"][0]
+	victim, villager := byRole["Villager"][0], byRole["Villager"][1]
+
+	werewolf.voteForPlayer(victim.Name)
+	submitNightSurveysForAllPlayers(players)
+	waitForDayPhaseAll(ctx, players)
+
+	// Everyone left votes the Tanner out
+	werewolf.dayVoteForPlayer(tanner.Name)
+	tanner.dayVoteForPlayer(werewolf.Name)
+	villager.dayVoteForPlayer(tanner.Name)
+
+	if !villager.isGameFinished() {
+		ctx.logger.LogDB("FAIL: game not finished after lynching the tanner")
+		t.Fatalf("Game should end once the Tanner is lynched. Content: %s", villager.getGameContent())
+	}
+	if winner := villager.getWinner(); winner != "tanner" {
+		ctx.logger.LogDB("FAIL: wrong winner")
+		t.Errorf("The Tanner should win, got: %s", winner)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// With -tls-cert and -tls-key the server speaks HTTPS itself, no reverse proxy needed. The
+// files are read again once they change on disk, so a certificate renewed by an ACME client
+// (certbot, lego) is picked up without a restart.
+
+// certCheckInterval is how often the handshakes look whether the files changed; a renewal is
+// served at most this late.
+const certCheckInterval = time.Minute
+
+// certReloader hands out the certificate from certFile and keyFile, reloading it after either
+// file was modified.
+type certReloader struct {
+	certFile, keyFile string
+
+	now func() time.Time // time.Now; tests move the clock themselves
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time // the later of the two files' modification times when cert was loaded
+	checked time.Time // when the files were last looked at
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, now: time.Now}
+	if _, err := r.certificate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// certificate returns the current certificate, loading it when the files changed. The files
+// are looked at once per certCheckInterval, not on every handshake. A renewal that cannot be
+// loaded keeps the old certificate in use.
+func (r *certReloader) certificate() (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if r.cert != nil && now.Sub(r.checked) < certCheckInterval {
+		return r.cert, nil
+	}
+	r.checked = now
+	modTime, err := r.filesModified()
+	if r.cert != nil && (err != nil || !modTime.After(r.modTime)) {
+		return r.cert, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		if r.cert != nil {
+			return r.cert, nil
+		}
+		return nil, fmt.Errorf("load certificate: %w", err)
+	}
+	r.cert, r.modTime = &cert, modTime
+	return r.cert, nil
+}
+
+func (r *certReloader) filesModified() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// tlsConfig builds the server's TLS settings from the configured files; nil when TLS is off.
+func tlsConfig(cfg AppConfig) (*tls.Config, error) {
+	if cfg.TLSCert == "" && cfg.TLSKey == "" {
+		return nil, nil
+	}
+	if cfg.TLSCert == "" || cfg.TLSKey == "" {
+		return nil, fmt.Errorf("-tls-cert and -tls-key go together")
+	}
+	reloader, err := newCertReloader(cfg.TLSCert, cfg.TLSKey)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return reloader.certificate()
+		},
+	}, nil
+}
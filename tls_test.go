@@ -0,0 +1,101 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// TLS Tests
+// ============================================================================
+
+// writeTestCert writes a self-signed certificate for name and its key, dated modTime.
+func writeTestCert(t *testing.T, certFile, keyFile, name string, modTime time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	os.Chtimes(certFile, modTime, modTime)
+	os.Chtimes(keyFile, modTime, modTime)
+}
+
+func TestRenewedCertificateIsReloaded(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	start := time.Now().Add(-time.Hour)
+	writeTestCert(t, certFile, keyFile, "old.example", start)
+
+	conf, err := tlsConfig(AppConfig{TLSCert: certFile, TLSKey: keyFile})
+	if err != nil {
+		t.Fatalf("tlsConfig: %v", err)
+	}
+	if cert, err := conf.GetCertificate(nil); err != nil || cert == nil {
+		t.Fatalf("GetCertificate: %v", err)
+	}
+
+	r, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("newCertReloader: %v", err)
+	}
+	clock := time.Now()
+	r.now = func() time.Time { return clock }
+	served := func() string {
+		cert, err := r.certificate()
+		if err != nil {
+			t.Fatalf("certificate: %v", err)
+		}
+		leaf, _ := x509.ParseCertificate(cert.Certificate[0])
+		return leaf.Subject.CommonName
+	}
+	if name := served(); name != "old.example" {
+		t.Fatalf("the configured certificate should be served, got %q", name)
+	}
+
+	writeTestCert(t, certFile, keyFile, "new.example", start.Add(time.Minute))
+	if name := served(); name != "old.example" {
+		t.Errorf("the files should be looked at once per interval, got %q right after the renewal", name)
+	}
+	clock = clock.Add(certCheckInterval)
+	if name := served(); name != "new.example" {
+		t.Errorf("the renewed certificate should be served, got %q", name)
+	}
+
+	os.WriteFile(certFile, []byte("half written"), 0600)
+	os.Chtimes(certFile, start.Add(2*time.Minute), start.Add(2*time.Minute))
+	clock = clock.Add(certCheckInterval)
+	if name := served(); name != "new.example" {
+		t.Errorf("a broken renewal should keep the last certificate, got %q", name)
+	}
+
+	if _, err := tlsConfig(AppConfig{TLSCert: certFile}); err == nil {
+		t.Errorf("a certificate without its key should be refused")
+	}
+	if conf, err := tlsConfig(AppConfig{}); conf != nil || err != nil {
+		t.Errorf("without files TLS should be off, got %v, %v", conf, err)
+	}
+}
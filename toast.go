@@ -31,3 +31,10 @@ func (h *Hub) sendErrorToast(playerID int64, message string) {
 		h.sendToPlayer(playerID, []byte(html))
 	}
 }
+
+func (h *Hub) sendInfoToast(playerID int64, message string) {
+	html := renderToast(h.templates, h.logf, "info", message)
+	if html != "" {
+		h.sendToPlayer(playerID, []byte(html))
+	}
+}
@@ -11,48 +11,200 @@ var translations = map[string]map[string]string{
 		"lang_name": "English",
 
 		// Index page
-		"brand_name":              "Werewolf",
-		"page_title_index":        "Werewolf - Sign In",
-		"page_title_game":         "Werewolf - Lobby",
-		"join_game_heading":       "Join Game",
-		"game_name_label":         "Game Name",
-		"game_name_placeholder":   "Enter game name",
-		"btn_join":                "Join Game",
-		"btn_logout":              "Logout",
-		"your_games_heading":      "Your Games",
-		"game_status_lobby":       "Waiting for players",
-		"you_won":                 "you won",
-		"you_lost":                "you lost",
-		"signin_heading":          "Sign In",
-		"name_placeholder":        "Enter your name",
-		"name_label":              "Name",
-		"secret_code_label":       "Secret Code",
-		"secret_code_placeholder": "Your secret code",
-		"btn_login":               "Login",
-		"btn_signin_continue":     "Continue",
+		"brand_name":                      "Werewolf",
+		"page_title_index":                "Werewolf - Sign In",
+		"page_title_game":                 "Werewolf - Lobby",
+		"join_game_heading":               "Join Game",
+		"game_name_label":                 "Game Name",
+		"game_name_placeholder":           "Enter game name",
+		"btn_join":                        "Join Game",
+		"btn_logout":                      "Logout",
+		"btn_delete_account":              "Delete my account",
+		"delete_account_confirm":          "Delete your account? Your name is removed from every past game and your statistics are lost. This cannot be undone.",
+		"sessions_heading":                "Signed-in devices (%d)",
+		"session_this_device":             "this device",
+		"session_unknown_device":          "Unknown device",
+		"session_times":                   "signed in %s, last used %s",
+		"btn_session_revoke":              "Sign out",
+		"btn_logout_everywhere":           "Log out everywhere",
+		"logout_everywhere_confirm":       "Sign out on every device, this one included? Open games are closed there too.",
+		"err_account_in_game":             "Finish your running game before deleting your account",
+		"your_games_heading":              "Your Games",
+		"archive_link":                    "Past games",
+		"archive_title":                   "Past games",
+		"archive_home":                    "← Back",
+		"archive_back":                    "← All past games",
+		"archive_finished":                "Finished",
+		"archive_game":                    "Game",
+		"archive_winner":                  "Winner",
+		"archive_players":                 "Players:",
+		"archive_empty":                   "No game has finished yet.",
+		"archive_no_events":               "Nothing was recorded for this game.",
+		"archive_export":                  "Download the game as JSON",
+		"archive_replay":                  "Replay the game step by step",
+		"replay_title":                    "Replay",
+		"replay_back":                     "← Back to the game",
+		"replay_setup":                    "Setup",
+		"replay_prev":                     "← Previous",
+		"replay_next":                     "Next →",
+		"replay_jump":                     "All steps",
+		"stats_link":                      "Your stats",
+		"stats_title":                     "Player stats",
+		"stats_heading":                   "Stats for %s",
+		"stats_games":                     "Games",
+		"stats_wins":                      "Wins",
+		"stats_survival":                  "Survived to the end",
+		"stats_lynched_first_day":         "Lynched on the first day",
+		"stats_by_team":                   "By team",
+		"stats_by_role":                   "By role",
+		"stats_team":                      "Team",
+		"stats_role":                      "Role",
+		"stats_none":                      "No finished games yet.",
+		"stats_unknown_player":            "There is no player of that name.",
+		"stats_team_villager":             "Village",
+		"stats_team_werewolf":             "Werewolves",
+		"stats_team_white_werewolf":       "White Werewolf",
+		"stats_team_tanner":               "Tanner",
+		"stats_team_serial_killer":        "Serial Killer",
+		"stats_team_piper":                "Piper",
+		"leaderboard_link":                "Leaderboard",
+		"leaderboard_title":               "Leaderboard",
+		"leaderboard_role_title":          "Leaderboard: %s",
+		"leaderboard_all_roles":           "All roles",
+		"leaderboard_min_games":           "Win rates rank players with at least %d games.",
+		"leaderboard_win_rate_title":      "Best win rate",
+		"leaderboard_most_games_title":    "Most games played",
+		"leaderboard_wolf_win_rate_title": "Best werewolves",
+		"leaderboard_player":              "Player",
+		"leaderboard_win_rate":            "Win rate",
+		"leaderboard_empty":               "Nobody on this board yet.",
+		"game_status_lobby":               "Waiting for players",
+		"you_won":                         "you won",
+		"you_lost":                        "you lost",
+		"signin_heading":                  "Sign In",
+		"name_placeholder":                "Enter your name",
+		"name_label":                      "Name",
+		"secret_code_label":               "Secret Code",
+		"secret_code_placeholder":         "Your secret code",
+		"secret_code_notice_heading":      "Your Secret Code",
+		"secret_code_notice_text":         "Write this code down: you need it with your name to sign in again, and it will not be shown again.",
+		"secret_code_notice_close":        "Got it",
+		"oauth_or":                        "or",
+		"oauth_signin":                    "Sign in with %s",
+		"oauth_link":                      "Sign in with %s from now on",
+		"oauth_linked":                    "Signs in with %s",
+		"btn_login":                       "Login",
+		"btn_signin_continue":             "Continue",
 
 		// Sidebar
-		"sidebar_players": "Players",
-		"ai_features":     "AI features",
-		"narrator_label":  "Narrator",
-		"code_label":      "Code",
-		"night_round":     "Night %d",
-		"day_round":       "Day %d",
+		"sidebar_players":           "Players",
+		"substitute_heading":        "Replace a dropped player",
+		"substitute_seat_label":     "Seat:",
+		"substitute_observer_label": "Taken over by:",
+		"btn_substitute":            "Hand over the seat",
+		"rejoin_heading":            "Lost secret code",
+		"rejoin_text":               "Issue a one-time rejoin code and pass it on: the player signs in with it once instead of their secret code to get back into this game.",
+		"rejoin_player_label":       "Player:",
+		"btn_issue_rejoin_code":     "Issue rejoin code",
+		"rejoin_code_issued":        "Rejoin code for %s: %s (valid for %d minutes, works once)",
+		"btn_pause_game":            "Pause the game",
+		"btn_resume_game":           "Resume",
+		"btn_wake_village":          "Wake the village",
+		"btn_close_vote":            "Close the vote",
+		"btn_undo_resolution":       "Undo last resolution",
+		"btn_abort_game":            "Abort the game",
+		"abort_confirm":             "End this game without a winner and go back to the lobby?",
+		"undo_confirm":              "Take the game back to before its last resolution?",
+		"moderator_heading":         "Override the game",
+		"moderator_player_label":    "Player:",
+		"moderator_override_label":  "Override:",
+		"moderator_kill":            "Kill",
+		"moderator_revive":          "Revive",
+		"moderator_set_role":        "Change role to",
+		"moderator_role_label":      "Role:",
+		"btn_moderator_override":    "Apply",
+		"btn_force_phase":           "Force the phase on",
+		"force_phase_confirm":       "End the night, or close today's vote, right now?",
+		"ai_features":               "AI features",
+		"narrator_label":            "Narrator",
+		"night_round":               "Night %d",
+		"day_round":                 "Day %d",
 
 		// Lobby
-		"players_label":     "Players:",
-		"roles_label":       "Roles:",
-		"ready_to_start":    "Ready to start!",
-		"need_more_players": "Need %d more players",
-		"need_more_roles":   "Need %d more roles",
-		"configure_roles":   "Configure roles below",
-		"roles_heading":     "Roles",
-		"roles_desc":        "Select which roles and how many of each to include in the game.",
-		"btn_start_game":    "Start Game",
+		"players_label":           "Players:",
+		"roles_label":             "Roles:",
+		"ready_to_start":          "Ready to start!",
+		"need_more_players":       "Need %d more players",
+		"need_more_roles":         "Need %d more roles",
+		"configure_roles":         "Configure roles below",
+		"roles_heading":           "Roles",
+		"roles_desc":              "Select which roles and how many of each to include in the game.",
+		"packs_label":             "Packs:",
+		"night_timer_label":       "Night timer:",
+		"nominations_label":       "Day votes need a nomination and a second",
+		"trials_label":            "The day vote puts its front-runner on trial",
+		"secret_votes_label":      "Day votes stay secret until the day is over",
+		"runoffs_label":           "A tied day vote goes to a runoff",
+		"mayor_election_label":    "The game opens with a Mayor election",
+		"last_words_label":        "Lynched and shot players get last words",
+		"observers_see_all_label": "Observers and the dead see every role",
+		"narrator_mode_label":     "Narrator mode: the host narrates instead of playing and moves the game on by hand",
+		"graveyard_talk_label":    "Graveyard talk: dead players may still speak in the day chat",
+		"whispers_label":          "Whispers: each living player may send one private message a day",
+		"chat_filter_label":       "Chat filter: mask rude words in every chat",
+		"webhook_heading":         "Webhook",
+		"webhook_hint":            "The game posts JSON to this URL when it starts, when a player dies and when it is over. Leave it empty for none.",
+		"btn_save_webhook":        "Save webhook",
+		"observers_label":         "Watching:",
+		"watch_label":             "Watch instead of playing",
+		"night_timer_off":         "Off",
+		"night_timer_seconds":     "%ds",
+		"day_timer_label":         "Day timer:",
+		"day_timer_minutes":       "%d min",
+		"day_timer_left":          "%s left",
+		"role_reveal_label":       "Dead players' roles:",
+		"role_reveal_full":        "Revealed",
+		"role_reveal_team":        "Team only",
+		"role_reveal_none":        "Hidden",
+		"night_timer_left":        "%s left",
+		"pack_base":               "Base",
+		"pack_daybreak":           "Daybreak",
+		"pack_bonus":              "Bonus",
+		"pack_custom":             "Custom",
+		"btn_start_game":          "Start Game",
+		"host_label":              "Host:",
+		"host_you":                "(you)",
+		"host_only_note":          "Waiting for %s to set up and start the game",
+		"transfer_host_label":     "Hand the lobby to:",
+		"btn_transfer_host":       "Make host",
+		"mute_player_label":       "Chat of:",
+		"btn_toggle_mute":         "Mute / unmute",
+		"btn_suggest_setup":       "Suggest roles for this many players",
+		"presets_heading":         "Role presets",
+		"btn_load_preset":         "Load",
+		"btn_save_preset":         "Save current roles",
+		"preset_name_placeholder": "Preset name, e.g. Classic 8p",
+
+		// Lobby: custom roles
+		"custom_role_heading":      "Create a custom role",
+		"custom_role_name":         "Name",
+		"custom_role_desc":         "Description",
+		"custom_role_team":         "Team",
+		"custom_team_villager":     "Village",
+		"custom_team_werewolf":     "Werewolves",
+		"custom_role_night":        "Night ability",
+		"custom_night_none":        "None",
+		"custom_night_investigate": "Investigate a player (like the Seer)",
+		"custom_night_protect":     "Protect a player (like the Doctor)",
+		"custom_role_charges":      "Uses per game (0 = every night)",
+		"custom_role_shield":       "Survives the first werewolf attack (like the Elder)",
+		"btn_create_role":          "✨ Create role",
 
 		// Night general
 		"waiting_for_players": "Waiting for %d more player(s)...",
 		"you_are_dead_night":  "You are dead. The village sleeps around you.",
+		"you_are_watching":    "You are watching this game.",
+		"powers_lost_night":   "The village lynched its Elder. Your power is gone — you sleep through the night.",
 		"village_sleeps":      "The village sleeps...",
 		"close_eyes":          "Close your eyes and wait for morning.",
 		"storyteller_asking":  "The storyteller is asking you",
@@ -63,16 +215,61 @@ var translations = map[string]map[string]string{
 		"btn_continue":        "Continue →",
 
 		// Night: Werewolf
-		"werewolf_title":       "Werewolf: Choose a Victim",
-		"vote_locked_waiting":  "Vote locked in. Waiting for night to end...",
-		"werewolf_select_desc": "Select a player to kill, or pass. When all werewolves have acted, end the vote.",
-		"btn_pass":             "Pass",
-		"btn_end_vote":         "End Vote",
-		"vote_pass":            "Pass",
-		"wolf_cub_title":       "Wolf Cub's Revenge — Second Victim",
-		"vote2_locked":         "Second vote locked in. Waiting for night to end...",
-		"wolf_cub_desc":        "The Wolf Cub was slain. Choose a second player to kill tonight, or pass.",
-		"btn_end_second_vote":  "End Second Vote",
+		"werewolf_title":             "Werewolf: Choose a Victim",
+		"vote_locked_waiting":        "Vote locked in. Waiting for night to end...",
+		"werewolf_select_desc":       "Select a player to kill, or pass. When all werewolves have acted, end the vote.",
+		"wolves_sick_desc":           "Last night's victim was Diseased. The pack is sick and cannot hunt tonight.",
+		"btn_pass":                   "Pass",
+		"btn_nominate":               "Nominate",
+		"btn_second":                 "Second",
+		"btn_open_vote":              "Open the vote",
+		"btn_rest_case":              "Rest my case",
+		"btn_last_words":             "Say my last words",
+		"btn_whisper":                "Whisper",
+		"btn_guilty":                 "Guilty",
+		"btn_innocent":               "Innocent",
+		"btn_end_vote":               "End Vote",
+		"wolf_chat_title":            "Pack chat",
+		"wolf_chat_empty":            "Nobody has spoken yet. Only the pack can read this.",
+		"wolf_chat_night":            "N%d",
+		"wolf_chat_placeholder":      "Whisper to the pack...",
+		"lobby_chat_title":           "Lobby chat",
+		"lobby_chat_empty":           "Nobody has said anything yet.",
+		"lobby_chat_placeholder":     "Say something to the table...",
+		"day_chat_title":             "Village chat",
+		"day_chat_empty":             "Nobody has spoken yet today.",
+		"day_chat_day":               "D%d",
+		"day_chat_placeholder":       "Say something to the village...",
+		"day_chat_closed":            "You cannot speak in the village chat.",
+		"graveyard_chat_title":       "Graveyard",
+		"graveyard_chat_empty":       "The graveyard is quiet.",
+		"graveyard_chat_round":       "R%d",
+		"graveyard_chat_placeholder": "Only the dead can hear you...",
+		"lovers_chat_title":          "Whispers with %s",
+		"lovers_chat_round":          "R%d",
+		"lovers_chat_empty":          "Nothing whispered yet.",
+		"lovers_chat_placeholder":    "Only your lover can hear you...",
+		"invite_heading":             "Invite players",
+		"invite_note":                "Share this link or let them scan the code; it opens the sign-in with this game already filled in.",
+		"invite_link":                "Invite link",
+		"btn_copy_invite":            "Copy link",
+		"invite_qr_alt":              "QR code of the invite link",
+		"btn_chat_send":              "Send",
+		"vote_pass":                  "Pass",
+		"wolf_cub_title":             "Wolf Cub's Revenge — Second Victim",
+		"vote2_locked":               "Second vote locked in. Waiting for night to end...",
+		"wolf_cub_desc":              "The Wolf Cub was slain. Choose a second player to kill tonight, or pass.",
+		"btn_end_second_vote":        "End Second Vote",
+		"alpha_bite_desc":            "Once per game you may bite tonight's victim: they join the pack instead of dying.",
+		"alpha_bite_armed":           "🩸 Tonight's victim will be bitten and join the pack.",
+		"btn_alpha_bite":             "🩸 Bite instead of kill",
+		"btn_alpha_unbite":           "Kill as usual",
+		"white_wolf_title":           "White Werewolf: Turn on the Pack",
+		"white_wolf_desc":            "Tonight you may secretly kill one of your fellow werewolves — or spare them.",
+		"white_wolf_result":          "%s will not survive the night.",
+		"white_wolf_spared":          "You spared the pack tonight.",
+		"btn_white_wolf_kill":        "🐺 Kill packmate",
+		"btn_white_wolf_spare":       "Spare the pack",
 
 		// Night: Seer
 		"seer_title":        "Seer: Your Investigation",
@@ -80,6 +277,48 @@ var translations = map[string]map[string]string{
 		"seer_choose":       "Choose a player to investigate, then confirm your choice.",
 		"btn_investigate":   "🔮 Investigate",
 
+		// Night: Aura Seer
+		"aura_seer_title":           "Aura Seer: Read an Aura",
+		"aura_seer_choose":          "Choose a player, then confirm. You will learn whether they have a special power — any role other than a plain Villager.",
+		"aura_seer_result_power":    "%s has a special power.",
+		"aura_seer_result_no_power": "%s has no special power.",
+		"btn_aura_seer_investigate": "✨ Read Aura",
+
+		// Night: Fox
+		"fox_title":          "Fox: Sniff Around",
+		"fox_choose":         "Choose a player, then confirm. You will learn whether a werewolf is among them and their two neighbors at the table. If there is none, you lose your power.",
+		"fox_result_wolf":    "A werewolf is among them.",
+		"fox_result_no_wolf": "No werewolf among them — your nose has gone cold.",
+		"fox_lost_power":     "Your nose has gone cold. You can no longer sniff out werewolves.",
+		"btn_fox_sniff":      "🦊 Sniff",
+
+		// Night: Spellcaster
+		"spellcaster_title":       "Spellcaster: Cast Silence",
+		"spellcaster_choose":      "Choose a player to silence, then confirm. They will not be able to vote tomorrow.",
+		"spellcaster_result":      "%s is silenced for the coming day.",
+		"btn_spellcaster_silence": "🤫 Silence",
+
+		// Night: Serial Killer
+		"serial_killer_title":    "Serial Killer: Your Victim",
+		"serial_killer_choose":   "Choose a player to kill tonight, then confirm. The werewolves hunt on their own.",
+		"serial_killer_result":   "%s will not see the morning.",
+		"btn_serial_killer_kill": "🔪 Kill",
+
+		// Night: Piper
+		"piper_title":          "Piper: Play Your Tune",
+		"piper_choose":         "Choose %d player(s) to charm, then confirm. Click a card again to deselect.",
+		"piper_chosen_two":     "Chosen: %s and %s. Click a card to deselect.",
+		"piper_none_left":      "Everyone else is already charmed — confirm to end your turn.",
+		"piper_done":           "Your tune has been played tonight.",
+		"piper_charmed_so_far": "Charmed so far",
+		"btn_piper_charm":      "🎶 Charm",
+
+		// Night: Sorceress
+		"sorceress_title":           "Sorceress: Find the Seer",
+		"sorceress_already_done":    "You have already searched tonight.",
+		"sorceress_choose":          "Choose a player to search, then confirm. You will learn whether they are the Seer.",
+		"btn_sorceress_investigate": "🔮 Search",
+
 		// Night: Doctor
 		"doctor_title":       "Doctor: Your Protection",
 		"doctor_protecting":  "You are protecting %s tonight.",
@@ -87,10 +326,18 @@ var translations = map[string]map[string]string{
 		"btn_doctor_protect": "🩺 Protect",
 
 		// Night: Guard
-		"guard_title":       "Guard: Your Protection",
-		"guard_protecting":  "You are protecting %s tonight.",
-		"guard_choose":      "Choose a player to protect, then confirm. You cannot protect yourself or the same player twice in a row.",
-		"btn_guard_protect": "🛡️ Protect",
+		"guard_title":        "Guard: Your Protection",
+		"guard_protecting":   "You are protecting %s tonight.",
+		"guard_choose":       "Choose a player to protect, then confirm. You cannot protect yourself or the same player twice in a row.",
+		"btn_guard_protect":  "🛡️ Protect",
+		"btn_skip_night":     "Skip tonight",
+		"night_skipped_desc": "You chose to do nothing tonight.",
+
+		// Night: Bodyguard
+		"bodyguard_title":     "Bodyguard: Your Ward",
+		"bodyguard_guarding":  "You are standing guard over %s tonight. If the wolves come for them, you die instead.",
+		"bodyguard_choose":    "Choose a player to guard, then confirm. If the werewolves attack them, you take the hit and die in their place.",
+		"btn_bodyguard_guard": "🛡️ Stand Guard",
 
 		// Night: Witch
 		"witch_title":         "Witch: Your Potions",
@@ -100,6 +347,7 @@ var translations = map[string]map[string]string{
 		"heal_potion":         "🧪 Heal Potion",
 		"witch_targeting":     "The werewolves are targeting (click to save, click again to deselect):",
 		"witch_no_target":     "The werewolves have not chosen a target yet...",
+		"witch_no_victim":     "The werewolves are not attacking anyone tonight.",
 		"heal_potion_used":    "Your heal potion has been used.",
 		"poison_potion":       "☠️ Poison Potion",
 		"witch_poison_choose": "Choose a player to poison (click to select, click again to deselect):",
@@ -111,6 +359,11 @@ var translations = map[string]map[string]string{
 		"mason_know_these": "You know these confirmed villagers:",
 		"mason_alone":      "You are the only Mason.",
 
+		// Night: Minion
+		"minion_title":      "Minion: Your Masters",
+		"minion_know_these": "These are the werewolves. They do not know you:",
+		"minion_no_wolves":  "No werewolves are left alive.",
+
 		// Night: Cupid
 		"cupid_title":      "Cupid: Link Two Lovers",
 		"cupid_linked":     "You have linked %s and %s as lovers.",
@@ -121,167 +374,474 @@ var translations = map[string]map[string]string{
 
 		// Night: Doppelganger
 		"doppelganger_title":      "Doppelganger: Choose Your Identity",
-		"doppelganger_became":     "You have become %s (copied from %s).",
+		"doppelganger_chose":      "You take %s's role when they die.",
 		"doppelganger_selected":   "Selected: %s. Click to deselect or confirm below.",
-		"doppelganger_choose":     "Choose a player. You will secretly become their role for the rest of the game.",
-		"btn_doppelganger_become": "🎭 Become",
+		"doppelganger_choose":     "Choose a player. When they die, you secretly take their role for the rest of the game.",
+		"btn_doppelganger_become": "🎭 Choose",
+
+		// Night: Wild Child
+		"wild_child_title":      "Wild Child: Your Role Model",
+		"wild_child_choose":     "Choose a player as your role model, then confirm. If they die, you become a werewolf.",
+		"wild_child_result":     "%s is your role model. Keep them alive.",
+		"btn_wild_child_choose": "🧒 Choose role model",
+
+		// Night: custom roles
+		"custom_investigate_choose": "Choose a player to investigate, then confirm.",
+		"custom_protect_choose":     "Choose a player to protect tonight, then confirm.",
+		"custom_uses_left":          "Uses left: %d",
+		"custom_no_uses":            "Your ability is used up. You sleep through the night.",
+		"custom_saw_wolf":           "%s is a werewolf!",
+		"custom_saw_villager":       "%s is not a werewolf.",
+		"custom_protecting":         "You are protecting %s tonight.",
+		"btn_custom_protect":        "🛡️ Protect",
 
 		// Day phase
-		"no_deaths_last_night":   "The village awakens. No one died last night.",
-		"hunter_shot_killed":     "🏹 The Hunter's last shot killed %s!",
-		"hunter_victim_was":      "They were a %s.",
-		"hunter_last_shot":       "Your Last Shot",
-		"hunter_eliminated_desc": "You have been eliminated! Choose a player to take down with you, then confirm.",
-		"btn_hunter_shoot":       "🏹 Shoot",
-		"hunter_choosing":        "The Hunter is choosing their final target...",
-		"vote_to_eliminate":      "Vote to Eliminate",
-		"choose_to_eliminate":    "Choose a player to eliminate, or pass. Majority vote required.",
-		"dead_cannot_vote":       "You are dead and cannot vote.",
-		"card_alive":             "Alive",
-		"card_dead":              "Dead",
-		"card_unknown":           "Unknown",
+		"no_deaths_last_night":         "The village awakens. No one died last night.",
+		"tough_guy_wounded_note":       "The werewolves attacked you last night. You shrugged it off for now — but you will die when this day ends.",
+		"hunter_shot_killed":           "🏹 The Hunter's last shot killed %s!",
+		"hunter_victim_was":            "They were a %s.",
+		"hunter_last_shot":             "Your Last Shot",
+		"hunter_eliminated_desc":       "You have been eliminated! Choose a player to take down with you, then confirm.",
+		"btn_hunter_shoot":             "🏹 Shoot",
+		"hunter_choosing":              "The Hunter is choosing their final target...",
+		"vote_to_eliminate":            "Vote to Eliminate",
+		"nominations_stage_desc":       "Nominate a player and second someone else's nomination. Only seconded nominees can be voted on once the vote opens.",
+		"ballot_stage_desc":            "The nominations are closed. Vote on a seconded nominee or pass.",
+		"nominee_line":                 "%s, nominated by %s",
+		"nominee_seconded":             "Seconded:",
+		"no_nominations":               "No one has been nominated yet.",
+		"trial_title":                  "%s is on trial",
+		"trial_defend_desc":            "The village has put you on trial. Say what you have to say in your defense — or nothing at all.",
+		"trial_defense_placeholder":    "Your defense…",
+		"trial_waiting_defense":        "Waiting for %s to speak in their defense…",
+		"trial_no_defense":             "%s said nothing in their defense.",
+		"last_words_title":             "Last words",
+		"last_words_desc":              "You are out of the game. Leave the village one last message, or send nothing to go quietly.",
+		"last_words_placeholder":       "Your last words…",
+		"whisper_title":                "Whispers",
+		"whisper_line":                 "%s to %s:",
+		"whisper_placeholder":          "Your whisper…",
+		"whisper_used":                 "You have used today's whisper.",
+		"last_words_silent":            "%s left without a word.",
+		"last_words_waiting":           "Waiting for the last words of",
+		"last_words_dusk_note":         "The vote is over. Night falls once the last words are said.",
+		"trial_verdict_progress":       "Verdicts cast: %d/%d",
+		"trial_found_guilty":           "%s was found guilty.",
+		"secret_votes_note":            "Votes are secret: everyone's vote is revealed once the day is over.",
+		"runoff_note":                  "The vote was tied. Runoff: vote again, on the tied players only.",
+		"elected_mayor_note":           "%s is Mayor: their vote breaks a tie.",
+		"election_title":               "Mayor election",
+		"election_desc":                "Before the first night, vote for a Mayor. The Mayor's vote breaks ties in the day votes for the rest of the game. A tied election is decided by lot.",
+		"election_progress":            "Votes cast: %d/%d",
+		"day_vote_recap_title":         "How the village voted yesterday",
+		"choose_to_eliminate":          "Choose a player to eliminate, or pass. Majority vote required.",
+		"mayor_vote_note":              "As Mayor, your vote counts twice — everyone can see the ×2 on your vote.",
+		"silenced_players":             "Silenced today",
+		"silenced_cannot_vote":         "You have been silenced by the Spellcaster and cannot vote or speak today.",
+		"scapegoat_barred_cannot_vote": "The Scapegoat left you out — you cannot vote today.",
+		"village_idiot_cannot_vote":    "Everyone knows you are the Village Idiot — you cannot vote anymore.",
+		"scapegoat_title":              "Scapegoat: Tomorrow's Voters",
+		"scapegoat_choose":             "The village blamed you for its tie. Choose who may vote tomorrow, then confirm.",
+		"scapegoat_choosing":           "The Scapegoat is choosing who may vote tomorrow...",
+		"btn_scapegoat_confirm":        "🐐 Confirm voters",
+
+		// Setup: Thief
+		"thief_title":          "Thief: The Spare Cards",
+		"thief_choose":         "Two cards were left over. Take one of them, or keep your Thief card.",
+		"thief_must_take_wolf": "Both spare cards are werewolves — you must take one of them.",
+		"thief_choosing":       "The Thief is looking at the spare cards...",
+		"btn_thief_keep":       "🃏 Keep the Thief card",
+		"priest_title":         "Priest: Holy Water",
+		"priest_choose":        "Once per game you may throw holy water at a player. A werewolf burns and dies — anyone else is unharmed, and you die instead.",
+		"btn_priest_throw":     "💧 Throw Holy Water",
+		"dead_cannot_vote":     "You are dead and cannot vote.",
+		"card_alive":           "Alive",
+		"card_dead":            "Dead",
+		"card_unknown":         "Unknown",
 
 		// Role names and descriptions (for player cards)
-		"role_name_Villager":     "Villager",
-		"role_name_Werewolf":     "Werewolf",
-		"role_name_Seer":         "Seer",
-		"role_name_Doctor":       "Doctor",
-		"role_name_Witch":        "Witch",
-		"role_name_Hunter":       "Hunter",
-		"role_name_Cupid":        "Cupid",
-		"role_name_Guard":        "Guard",
-		"role_name_Mason":        "Mason",
-		"role_name_Wolf Cub":     "Wolf Cub",
-		"role_name_Doppelganger": "Doppelganger",
-		"role_name_Joker":        "Joker",
-		"role_desc_Villager":     "No special powers — votes by deduction.",
-		"role_desc_Werewolf":     "Knows other werewolves, kills nightly.",
-		"role_desc_Seer":         "Investigates a player's role each night.",
-		"role_desc_Doctor":       "Protects one player each night from attack.",
-		"role_desc_Witch":        "One heal potion, one poison potion to use.",
-		"role_desc_Hunter":       "Shoots one player when eliminated.",
-		"role_desc_Cupid":        "Picks two lovers on night one.",
-		"role_desc_Guard":        "Protects one player nightly, no repeats.",
-		"role_desc_Mason":        "Knows the other masons.",
-		"role_desc_Wolf Cub":     "If killed, werewolves kill two next night.",
-		"role_desc_Doppelganger": "Copies another player's role on night one.",
-		"role_desc_Joker":        "Secretly assigned a random role at start.",
+		"role_name_Villager":        "Villager",
+		"role_name_Werewolf":        "Werewolf",
+		"role_name_Seer":            "Seer",
+		"role_name_Doctor":          "Doctor",
+		"role_name_Witch":           "Witch",
+		"role_name_Hunter":          "Hunter",
+		"role_name_Cupid":           "Cupid",
+		"role_name_Guard":           "Guard",
+		"role_name_Mason":           "Mason",
+		"role_name_Wolf Cub":        "Wolf Cub",
+		"role_name_Doppelganger":    "Doppelganger",
+		"role_name_Joker":           "Joker",
+		"role_name_Sorceress":       "Sorceress",
+		"role_name_Alpha Werewolf":  "Alpha Werewolf",
+		"role_name_Minion":          "Minion",
+		"role_name_Tanner":          "Tanner",
+		"role_name_Bodyguard":       "Bodyguard",
+		"role_name_Apprentice Seer": "Apprentice Seer",
+		"role_name_Aura Seer":       "Aura Seer",
+		"role_name_Lycan":           "Lycan",
+		"role_name_Prince":          "Prince",
+		"role_name_Mayor":           "Mayor",
+		"role_name_Priest":          "Priest",
+		"role_name_Spellcaster":     "Spellcaster",
+		"role_name_Drunk":           "Drunk",
+		"role_name_Tough Guy":       "Tough Guy",
+		"role_name_Diseased":        "Diseased",
+		"role_name_Cursed":          "Cursed",
+		"role_name_Serial Killer":   "Serial Killer",
+		"role_name_Piper":           "Piper",
+		"role_name_White Werewolf":  "White Werewolf",
+		"role_name_Fox":             "Fox",
+		"role_name_Elder":           "Elder",
+		"role_name_Scapegoat":       "Scapegoat",
+		"role_name_Thief":           "Thief",
+		"role_name_Wild Child":      "Wild Child",
+		"role_name_Village Idiot":   "Village Idiot",
+		"role_desc_Villager":        "No special powers — votes by deduction.",
+		"role_desc_Werewolf":        "Knows other werewolves, kills nightly.",
+		"role_desc_Seer":            "Investigates a player's role each night.",
+		"role_desc_Doctor":          "Protects one player each night from attack.",
+		"role_desc_Witch":           "One heal potion, one poison potion to use.",
+		"role_desc_Hunter":          "Shoots one player when eliminated.",
+		"role_desc_Cupid":           "Picks two lovers on night one.",
+		"role_desc_Guard":           "Protects one player nightly, no repeats.",
+		"role_desc_Mason":           "Knows the other masons.",
+		"role_desc_Wolf Cub":        "If killed, werewolves kill two next night.",
+		"role_desc_Doppelganger":    "Picks another player on night one and takes their role when they die.",
+		"role_desc_Joker":           "Secretly assigned a random role at start.",
+		"role_desc_Sorceress":       "Hunts for the Seer; wolf-aligned, unknown to wolves.",
+		"role_desc_Alpha Werewolf":  "Once per game, turns the victim into a wolf.",
+		"role_desc_Minion":          "Knows the wolves and serves them in secret.",
+		"role_desc_Tanner":          "Wins alone if the village lynches them.",
+		"role_desc_Bodyguard":       "Dies in place of the player they guard.",
+		"role_desc_Apprentice Seer": "Becomes the Seer when the Seer dies.",
+		"role_desc_Aura Seer":       "Senses whether a player has a power.",
+		"role_desc_Lycan":           "A villager the Seer sees as a wolf.",
+		"role_desc_Prince":          "Survives the first lynch by revealing.",
+		"role_desc_Mayor":           "Their day vote counts twice.",
+		"role_desc_Priest":          "Holy water once: kills a wolf, or the Priest.",
+		"role_desc_Spellcaster":     "Silences one player each night.",
+		"role_desc_Drunk":           "Thinks they're a Villager until night 3.",
+		"role_desc_Tough Guy":       "Survives a wolf attack for one more day.",
+		"role_desc_Diseased":        "If eaten, wolves skip their next hunt.",
+		"role_desc_Cursed":          "Turns werewolf if the wolves attack.",
+		"role_desc_Serial Killer":   "Kills nightly; wins as the last one alive.",
+		"role_desc_Piper":           "Charms two nightly; wins once all are charmed.",
+		"role_desc_White Werewolf":  "Hunts with the pack, wins only alone.",
+		"role_desc_Fox":             "Sniffs out wolves among three neighbors.",
+		"role_desc_Elder":           "Survives one wolf attack; lynching costs the village its powers.",
+		"role_desc_Scapegoat":       "Dies on a tied vote; picks tomorrow's voters.",
+		"role_desc_Thief":           "May swap for a spare card before night 1.",
+		"role_desc_Wild Child":      "Turns werewolf if their role model dies.",
+		"role_desc_Village Idiot":   "Survives being voted out once, but loses their vote.",
 
 		// Finished screen
-		"victors":            "Victors",
-		"the_fallen":         "The Fallen",
-		"btn_play_again":     "Play Again",
-		"villagers_win_alt":  "Villagers win",
-		"lovers_win_alt":     "Lovers win",
-		"werewolves_win_alt": "Werewolves win",
+		"victors":                "Victors",
+		"the_fallen":             "The Fallen",
+		"btn_play_again":         "Play Again",
+		"villagers_win_alt":      "Villagers win",
+		"lovers_win_alt":         "Lovers win",
+		"werewolves_win_alt":     "Werewolves win",
+		"tanner_win_alt":         "Tanner wins",
+		"serial_killer_win_alt":  "Serial Killer wins",
+		"piper_win_alt":          "Piper wins",
+		"white_werewolf_win_alt": "White Werewolf wins",
 
 		// Error/toast messages
-		"err_name_required":               "Name is required",
-		"err_name_taken":                  "Name already taken. Use login with secret code if this is you.",
-		"err_something_wrong":             "Something went wrong",
-		"err_invalid_credentials":         "Invalid name or secret code",
-		"err_failed_get_game":             "Failed to get game",
-		"err_game_already_started":        "Cannot update roles: game already started",
-		"err_game_started":                "Game already started",
-		"err_game_in_progress":            "This game is already in progress — you can't join it now.",
-		"err_failed_get_players":          "Failed to get players",
-		"err_failed_get_roles":            "Failed to get role configuration",
-		"err_role_count_mismatch":         "Role count must match player count",
-		"err_failed_assign_joker":         "Failed to assign Joker role",
-		"err_failed_assign_roles":         "Failed to assign roles",
-		"err_failed_start_game":           "Failed to start game",
-		"err_game_not_finished":           "Game is not finished yet",
-		"err_failed_role_config":          "Failed to get role config",
-		"err_failed_create_game":          "Failed to create new game",
-		"err_only_werewolves_vote":        "Only werewolves can vote at night",
-		"err_only_werewolves_end_vote":    "Only werewolves can end the vote",
-		"err_werewolves_not_done":         "Not all werewolves have voted yet (%d/%d)",
-		"err_werewolves_not_done_second":  "Not all werewolves have voted for the second kill yet (%d/%d)",
-		"err_werewolves_not_locked":       "Werewolves have not locked in their vote yet",
-		"err_heal_must_target_werewolf":   "You can only heal a werewolf target",
-		"toast_seer_not_werewolf":         "🔮 %s is not a werewolf.",
-		"toast_seer_is_werewolf":          "🔮 %s is a werewolf!",
-		"toast_wolves_chosen":             "🐺 The werewolves have made their choice...",
-		"err_night_phase_act":             "Can only act during night phase",
-		"err_night_phase_protect":         "Can only protect during night phase",
-		"err_night_phase_investigate":     "Can only investigate during night phase",
-		"err_cupid_night1_only":           "Cupid can only act on Night 1",
-		"err_doppelganger_night1_only":    "Doppelganger can only act on Night 1",
-		"err_not_in_game":                 "You are not in this game",
-		"err_dead_cannot_act":             "Dead players cannot act",
-		"err_dead_cannot_vote":            "Dead players cannot vote",
-		"err_dead_cannot_end_vote":        "Dead players cannot end the vote",
-		"err_invalid_target":              "Invalid target",
-		"err_target_not_found":            "Target not found",
-		"err_failed_record_vote":          "Failed to record vote",
-		"err_failed_record_pass":          "Failed to record pass",
-		"err_failed_clear_vote":           "Failed to clear vote",
-		"err_night_vote_only":             "Voting only allowed during night phase",
-		"err_day_vote_only":               "Voting only allowed during day phase",
-		"err_night_survey_only":           "Survey only available during night phase",
-		"err_cannot_target_dead":          "Cannot target a dead player",
-		"err_cannot_vote_dead":            "Cannot vote for a dead player",
-		"err_already_protected":           "You have already protected someone this night",
-		"err_select_protect_first":        "Select a player to protect first",
-		"err_cannot_protect_dead":         "Cannot protect a dead player",
-		"err_failed_record_protection":    "Failed to record protection",
-		"err_only_doctor_select":          "Only the Doctor can select a protection target",
-		"err_only_doctor_protect":         "Only the Doctor can protect players",
-		"err_only_guard_select":           "Only the Guard can select a protection target",
-		"err_only_guard_protect":          "Only the Guard can protect players",
-		"err_guard_no_self":               "Guard cannot protect themselves",
-		"err_guard_no_repeat":             "Cannot protect the same player two nights in a row",
-		"err_only_seer_select":            "Only the Seer can select an investigation target",
-		"err_only_seer_investigate":       "Only the Seer can investigate",
-		"err_already_investigated":        "You have already investigated this night",
-		"err_select_investigate_first":    "Select a player to investigate first",
-		"err_cannot_investigate_dead":     "Cannot investigate a dead player",
-		"err_failed_record_investigation": "Failed to record investigation",
-		"err_only_witch_select_heal":      "Only the Witch can select a heal target",
-		"err_only_witch_select_poison":    "Only the Witch can select a poison target",
-		"err_only_witch_apply":            "Only the Witch can apply actions",
-		"err_already_submitted_night":     "You have already submitted your actions for this night",
-		"err_heal_already_used":           "Your heal potion has already been used",
-		"err_poison_already_used":         "Your poison potion has already been used",
-		"err_cannot_heal_self":            "You cannot heal yourself",
-		"err_failed_commit_heal":          "Failed to commit heal",
-		"err_poison_target_invalid":       "Poison target is no longer valid",
-		"err_failed_commit_poison":        "Failed to commit poison",
-		"err_failed_record_witch_action":  "Failed to record witch action",
-		"err_cupid_only_living":           "Only the living Cupid can link lovers",
-		"err_cupid_already_linked":        "You have already linked the lovers",
-		"err_failed_clear_choice":         "Failed to clear choice",
-		"err_lovers_must_differ":          "The two lovers must be different players",
-		"err_failed_record_choice":        "Failed to record choice",
-		"err_choose_two_lovers_first":     "Choose two lovers before linking them",
-		"err_first_lover_invalid":         "First lover is invalid",
-		"err_second_lover_invalid":        "Second lover is invalid",
-		"err_failed_link_lovers":          "Failed to link lovers",
-		"toast_cupid_linked":              "💞 Cupid has linked you! Your lover is %s.",
-		"err_doppelganger_only_living":    "Only the living Doppelganger can copy a role",
-		"err_doppelganger_already_chosen": "You have already chosen a role to copy",
-		"err_cannot_copy_self":            "You cannot copy yourself",
-		"err_select_copy_first":           "Select a player to copy first",
-		"err_failed_apply_role_change":    "Failed to apply role change",
-		"err_failed_record_copy":          "Failed to record copy",
-		"toast_doppelganger_became":       "🎭 You are now a %s!",
-		"toast_seer_outdated_reading":     "⚠️ %s (whom you investigated) has become a werewolf — your earlier reading is outdated!",
-		"err_vote_locked":                 "The vote has already been locked in",
-		"err_wolfcub_not_active":          "Wolf Cub double kill not active",
-		"err_vote2_locked":                "The second vote has already been locked in",
-		"err_failed_record_vote2":         "Failed to record second vote",
-		"err_must_be_alive_survey":        "You must be alive to submit the survey",
-		"err_failed_record_survey":        "Failed to record survey",
-		"err_players_not_done":            "Not all players have voted yet (%d/%d)",
-		"err_hunter_revenge_inactive":     "Hunter revenge not active",
-		"err_hunter_only_select":          "Only the Hunter can select a target",
-		"err_hunter_revenge_only_dead":    "Hunter revenge is only available when eliminated",
-		"err_already_shot":                "You have already taken your revenge shot",
-		"err_hunter_only_shoot":           "Only the Hunter can take a revenge shot",
-		"err_select_shoot_first":          "Select a player to shoot first",
-		"err_cannot_shoot_dead":           "Cannot shoot a dead player",
-		"err_failed_kill_target":          "Failed to kill target",
-		"err_failed_toggle_ai":            "Failed to toggle AI features",
+		"err_name_required":                   "Name is required",
+		"err_name_taken":                      "Name already taken. Use login with secret code if this is you.",
+		"err_something_wrong":                 "Something went wrong",
+		"err_invalid_credentials":             "Invalid name or secret code",
+		"err_failed_get_game":                 "Failed to get game",
+		"err_game_already_started":            "Cannot update roles: game already started",
+		"err_game_started":                    "Game already started",
+		"join_as_observer_note":               "This game is already in progress — you will join as an observer.",
+		"err_failed_get_players":              "Failed to get players",
+		"err_failed_get_roles":                "Failed to get role configuration",
+		"err_role_count_mismatch":             "Role count must match player count",
+		"err_failed_assign_joker":             "Failed to assign Joker role",
+		"err_failed_assign_roles":             "Failed to assign roles",
+		"err_failed_start_game":               "Failed to start game",
+		"err_game_not_finished":               "Game is not finished yet",
+		"err_failed_role_config":              "Failed to get role config",
+		"err_failed_create_game":              "Failed to create new game",
+		"err_only_werewolves_vote":            "Only werewolves can vote at night",
+		"err_only_werewolves_end_vote":        "Only werewolves can end the vote",
+		"err_werewolves_not_done":             "Not all werewolves have voted yet (%d/%d)",
+		"err_werewolves_not_done_second":      "Not all werewolves have voted for the second kill yet (%d/%d)",
+		"err_werewolves_not_locked":           "Werewolves have not locked in their vote yet",
+		"err_heal_must_target_werewolf":       "You can only heal a werewolf target",
+		"toast_seer_not_werewolf":             "🔮 %s is not a werewolf.",
+		"toast_seer_is_werewolf":              "🔮 %s is a werewolf!",
+		"toast_sorceress_found_seer":          "🔮 %s is the Seer!",
+		"toast_sorceress_not_seer":            "🔮 %s is not the Seer.",
+		"toast_aura_seer_power":               "✨ %s has a special power.",
+		"toast_aura_seer_no_power":            "✨ %s is a plain Villager.",
+		"toast_fox_wolf":                      "🦊 A werewolf is among %s.",
+		"toast_fox_no_wolf":                   "🦊 No werewolf among %s — you lose your power.",
+		"toast_wolves_chosen":                 "🐺 The werewolves have made their choice...",
+		"err_night_phase_act":                 "Can only act during night phase",
+		"err_night_phase_protect":             "Can only protect during night phase",
+		"err_night_phase_investigate":         "Can only investigate during night phase",
+		"err_cupid_night1_only":               "Cupid can only act on Night 1",
+		"err_doppelganger_night1_only":        "Doppelganger can only act on Night 1",
+		"err_not_in_game":                     "You are not in this game",
+		"err_dead_cannot_act":                 "Dead players cannot act",
+		"err_dead_cannot_vote":                "Dead players cannot vote",
+		"err_dead_cannot_end_vote":            "Dead players cannot end the vote",
+		"err_invalid_target":                  "Invalid target",
+		"err_target_not_found":                "Target not found",
+		"err_failed_record_vote":              "Failed to record vote",
+		"err_failed_record_pass":              "Failed to record pass",
+		"err_failed_clear_vote":               "Failed to clear vote",
+		"err_night_vote_only":                 "Voting only allowed during night phase",
+		"err_day_vote_only":                   "Voting only allowed during day phase",
+		"err_night_survey_only":               "Survey only available during night phase",
+		"err_cannot_target_dead":              "Cannot target a dead player",
+		"err_cannot_vote_dead":                "Cannot vote for a dead player",
+		"err_already_protected":               "You have already protected someone this night",
+		"err_select_protect_first":            "Select a player to protect first",
+		"err_cannot_protect_dead":             "Cannot protect a dead player",
+		"err_failed_record_protection":        "Failed to record protection",
+		"err_only_doctor_select":              "Only the Doctor can select a protection target",
+		"err_only_doctor_protect":             "Only the Doctor can protect players",
+		"err_only_guard_select":               "Only the Guard can select a protection target",
+		"err_only_guard_protect":              "Only the Guard can protect players",
+		"err_guard_no_self":                   "Guard cannot protect themselves",
+		"err_guard_no_repeat":                 "Cannot protect the same player two nights in a row",
+		"err_only_bodyguard_select":           "Only the Bodyguard can select a ward",
+		"err_only_bodyguard_guard":            "Only the Bodyguard can stand guard",
+		"err_bodyguard_no_self":               "Bodyguard cannot guard themselves",
+		"err_only_seer_select":                "Only the Seer can select an investigation target",
+		"err_only_seer_investigate":           "Only the Seer can investigate",
+		"err_only_sorceress_select":           "Only the Sorceress can select a search target",
+		"err_only_sorceress_investigate":      "Only the Sorceress can search",
+		"err_only_aura_seer_select":           "Only the Aura Seer can select a target",
+		"err_only_aura_seer_investigate":      "Only the Aura Seer can read auras",
+		"err_already_investigated":            "You have already investigated this night",
+		"err_select_investigate_first":        "Select a player to investigate first",
+		"err_cannot_investigate_dead":         "Cannot investigate a dead player",
+		"err_failed_record_investigation":     "Failed to record investigation",
+		"err_only_witch_select_heal":          "Only the Witch can select a heal target",
+		"err_only_witch_select_poison":        "Only the Witch can select a poison target",
+		"err_only_witch_apply":                "Only the Witch can apply actions",
+		"err_already_submitted_night":         "You have already submitted your actions for this night",
+		"err_cannot_skip":                     "Your role cannot skip this action",
+		"err_failed_record_skip":              "Failed to record your skip",
+		"err_heal_already_used":               "Your heal potion has already been used",
+		"err_poison_already_used":             "Your poison potion has already been used",
+		"err_cannot_heal_self":                "You cannot heal yourself",
+		"err_failed_commit_heal":              "Failed to commit heal",
+		"err_poison_target_invalid":           "Poison target is no longer valid",
+		"err_failed_commit_poison":            "Failed to commit poison",
+		"err_failed_record_witch_action":      "Failed to record witch action",
+		"err_cupid_only_living":               "Only the living Cupid can link lovers",
+		"err_cupid_already_linked":            "You have already linked the lovers",
+		"err_failed_clear_choice":             "Failed to clear choice",
+		"err_lovers_must_differ":              "The two lovers must be different players",
+		"err_failed_record_choice":            "Failed to record choice",
+		"err_choose_two_lovers_first":         "Choose two lovers before linking them",
+		"err_first_lover_invalid":             "First lover is invalid",
+		"err_second_lover_invalid":            "Second lover is invalid",
+		"err_failed_link_lovers":              "Failed to link lovers",
+		"toast_cupid_linked":                  "💞 Cupid has linked you! Your lover is %s.",
+		"err_doppelganger_only_living":        "Only the living Doppelganger can copy a role",
+		"err_doppelganger_already_chosen":     "You have already chosen a role to copy",
+		"err_cannot_copy_self":                "You cannot copy yourself",
+		"err_select_copy_first":               "Select a player to copy first",
+		"err_failed_apply_role_change":        "Failed to apply role change",
+		"err_failed_record_copy":              "Failed to record copy",
+		"toast_doppelganger_became":           "🎭 You are now a %s!",
+		"toast_seer_outdated_reading":         "⚠️ %s (whom you investigated) has become a werewolf — your earlier reading is outdated!",
+		"toast_apprentice_promoted":           "🔮 The Seer is dead — their sight passes to you. From the next night on you can investigate.",
+		"toast_drunk_sobered":                 "🍺 The fog lifts — you are really the %s!",
+		"err_vote_locked":                     "The vote has already been locked in",
+		"err_wolves_sick":                     "The pack is sick and cannot hunt tonight",
+		"err_only_alpha_bite":                 "Only the Alpha Werewolf can bite",
+		"err_alpha_bite_used":                 "You have already used your bite",
+		"toast_alpha_bitten":                  "🩸 You were bitten in the night. You are now a Werewolf!",
+		"toast_cursed_turned":                 "🌑 The werewolves attacked you and your curse awoke. You are now a Werewolf!",
+		"toast_wild_child_turned":             "🐺 Your role model %s is dead. You are now a Werewolf!",
+		"toast_piper_charmed":                 "🎶 The Piper's tune has charmed you.",
+		"err_wolfcub_not_active":              "Wolf Cub double kill not active",
+		"err_vote2_locked":                    "The second vote has already been locked in",
+		"err_failed_record_vote2":             "Failed to record second vote",
+		"err_must_be_alive_survey":            "You must be alive to submit the survey",
+		"err_failed_record_survey":            "Failed to record survey",
+		"err_players_not_done":                "Not all players have voted yet (%d/%d)",
+		"err_nominations_off":                 "This game does not use nominations",
+		"err_nominations_closed":              "The nominations are already closed",
+		"err_already_nominated":               "That player has already been nominated",
+		"err_nominated_today":                 "You have already nominated someone today",
+		"err_not_nominated":                   "That player has not been nominated",
+		"err_second_own_nomination":           "You cannot second your own nomination",
+		"err_seconded_today":                  "You have already seconded a nomination today",
+		"err_ballot_empty":                    "No nomination has been seconded yet",
+		"err_failed_record_nomination":        "Failed to record the nomination",
+		"err_vote_not_open":                   "The vote is not open yet — nominate and second first",
+		"err_not_on_ballot":                   "You can only vote for a seconded nominee",
+		"err_failed_toggle_nominations":       "Failed to switch nominations",
+		"err_failed_toggle_trials":            "Failed to switch trials",
+		"err_failed_toggle_secret_votes":      "Failed to switch secret votes",
+		"err_failed_toggle_runoffs":           "Failed to switch runoffs",
+		"err_not_in_runoff":                   "Only the tied players can be voted on in the runoff",
+		"err_failed_toggle_mayor_election":    "Failed to switch the Mayor election",
+		"err_not_election":                    "There is no Mayor election right now",
+		"err_vote_closed_trial":               "The vote is over — a trial is under way",
+		"err_vote_closed_last_words":          "The vote is over — the night waits for last words",
+		"err_last_words_too_long":             "Your last words are too long",
+		"err_no_last_words_due":               "You have no last words to say",
+		"err_cannot_whisper":                  "You cannot whisper now",
+		"err_already_whispered":               "You have already whispered today",
+		"err_failed_toggle_last_words":        "Failed to switch last words",
+		"err_host_only":                       "Only the host can change the lobby",
+		"err_failed_transfer_host":            "Failed to hand over the lobby",
+		"err_game_paused":                     "The game is paused",
+		"err_narrator_only":                   "Only the narrator can move the game on",
+		"err_narrator_must_watch":             "In narrator mode the host watches instead of playing",
+		"err_narrator_closes_vote":            "The narrator closes the vote",
+		"err_nothing_to_advance":              "There is nothing to move on right now",
+		"err_day_waits_on_player":             "The day is still waiting on a player",
+		"err_failed_toggle_narrator_mode":     "Failed to switch narrator mode",
+		"err_failed_toggle_graveyard_talk":    "Failed to switch graveyard talk",
+		"err_failed_toggle_whispers":          "Failed to switch whispers",
+		"err_failed_toggle_chat_filter":       "Failed to switch the chat filter",
+		"err_invalid_webhook":                 "The webhook needs an http or https URL",
+		"err_failed_set_webhook":              "Failed to save the webhook",
+		"err_invalid_message":                 "That message could not be read",
+		"err_unknown_action":                  "Unknown action",
+		"err_unsupported_version":             "Unsupported message version",
+		"err_invalid_payload":                 "The action's fields are missing or invalid",
+		"err_failed_toggle_mute":              "Failed to mute the player",
+		"err_game_not_running":                "The game is not running",
+		"err_failed_pause":                    "Failed to pause or resume the game",
+		"err_nothing_to_undo":                 "There is nothing to undo",
+		"err_failed_undo":                     "Failed to undo the last resolution",
+		"err_failed_abort":                    "Failed to abort the game",
+		"game_aborted_note":                   "The host ended the game; you are back in the lobby",
+		"game_paused_heading":                 "Game paused",
+		"game_paused_note":                    "The host has paused the game. Timers are stopped until it goes on.",
+		"narrator_heading":                    "Narrator",
+		"narrator_dead":                       "dead",
+		"narrator_waiting":                    "waiting",
+		"narrator_done":                       "done",
+		"narrator_passed":                     "passed",
+		"narrator_picked":                     "picked %s",
+		"narrator_script_heading":             "Script for night %d",
+		"script_sleep":                        "Night falls. Everyone, close your eyes.",
+		"script_cupid":                        "Cupid, wake up and choose two lovers. Cupid, go back to sleep.",
+		"script_lovers":                       "Lovers, wake up and look at each other. Lovers, go back to sleep.",
+		"script_doppelganger":                 "Doppelganger, wake up and choose whose role you will take. Doppelganger, go back to sleep.",
+		"script_wild_child":                   "Wild Child, wake up and choose your role model. Wild Child, go back to sleep.",
+		"script_masons":                       "Masons, wake up and look at each other. Masons, go back to sleep.",
+		"script_guard":                        "Guard, wake up and choose someone to protect. Guard, go back to sleep.",
+		"script_bodyguard":                    "Bodyguard, wake up and choose someone to guard. Bodyguard, go back to sleep.",
+		"script_seer":                         "Seer, wake up and choose someone to look at. Seer, go back to sleep.",
+		"script_aura_seer":                    "Aura Seer, wake up and choose someone to read. Aura Seer, go back to sleep.",
+		"script_fox":                          "Fox, wake up and sniff out three neighbours. Fox, go back to sleep.",
+		"script_sorceress":                    "Sorceress, wake up and look for the Seer. Sorceress, go back to sleep.",
+		"script_spellcaster":                  "Spellcaster, wake up and choose someone to silence. Spellcaster, go back to sleep.",
+		"script_wolves":                       "Werewolves, wake up and choose your victim. Werewolves, go back to sleep.",
+		"script_white_werewolf":               "White Werewolf, wake up. You may turn on the pack tonight. White Werewolf, go back to sleep.",
+		"script_serial_killer":                "Serial Killer, wake up and choose your victim. Serial Killer, go back to sleep.",
+		"script_piper":                        "Piper, wake up and charm two players. Piper, go back to sleep.",
+		"script_witch":                        "Witch, wake up. Will you use a potion tonight? Witch, go back to sleep.",
+		"script_doctor":                       "Doctor, wake up and choose someone to heal. Doctor, go back to sleep.",
+		"script_custom":                       "%s, wake up and use your power. %s, go back to sleep.",
+		"script_wake":                         "Everyone, wake up.",
+		"err_preset_name":                     "A preset needs a name of at most 32 characters",
+		"err_preset_empty":                    "Pick some roles before saving a preset",
+		"err_failed_save_preset":              "Failed to save the preset",
+		"err_unknown_preset":                  "There is no preset with that name",
+		"err_substitute_not_running":          "Seats can only be handed over while the game is running",
+		"err_failed_override":                 "Failed to apply the override",
+		"err_invalid_role":                    "Invalid role",
+		"err_substitute_still_connected":      "That player is still connected",
+		"err_rejoin_still_connected":          "That player is still connected and needs no rejoin code",
+		"err_failed_substitute":               "Failed to hand over the seat",
+		"err_failed_toggle_observer":          "Failed to switch between playing and watching",
+		"err_failed_toggle_observers_see_all": "Failed to switch what observers see",
+		"err_suggest_needs_players":           "A setup can be suggested once at least 2 players are in the lobby",
+		"err_no_defense_due":                  "No defense is due right now",
+		"err_only_accused_defends":            "Only the accused can give the defense",
+		"err_defense_too_long":                "The defense is too long",
+		"err_failed_record_defense":           "Failed to record the defense",
+		"err_no_verdict_due":                  "No verdict is being voted on right now",
+		"err_cannot_judge":                    "You cannot vote on this verdict",
+		"err_already_judged":                  "You have already cast your verdict",
+		"err_hunter_revenge_inactive":         "Hunter revenge not active",
+		"err_only_priest":                     "Only the Priest can throw holy water",
+		"err_priest_day_only":                 "Holy water can only be thrown during the day",
+		"err_priest_water_used":               "You have already used your holy water",
+		"err_priest_select_first":             "Select a target for the holy water first",
+		"err_only_spellcaster":                "Only the Spellcaster can cast silence",
+		"err_already_silenced":                "You have already silenced someone tonight",
+		"err_select_silence_first":            "Select a player to silence first",
+		"err_failed_record_silence":           "Failed to record silence",
+		"err_silenced_cannot_vote":            "You have been silenced and cannot vote today",
+		"err_silenced_cannot_speak":           "You have been silenced and cannot speak today",
+		"err_idiot_cannot_vote":               "As the revealed Village Idiot, you cannot vote anymore",
+		"err_only_serial_killer":              "Only the Serial Killer can kill",
+		"err_serial_killer_done":              "You have already chosen your victim tonight",
+		"err_serial_killer_select_first":      "Select a victim first",
+		"err_failed_record_serial_kill":       "Failed to record kill",
+		"err_only_piper":                      "Only the Piper can charm",
+		"err_piper_already_charmed":           "You have already played your tune tonight",
+		"err_piper_choose_first":              "Choose the players to charm first",
+		"err_failed_record_charm":             "Failed to record charm",
+		"err_only_white_wolf":                 "Only the White Werewolf can turn on the pack",
+		"err_white_wolf_not_tonight":          "You can only turn on the pack every second night",
+		"err_white_wolf_done":                 "You have already made your choice tonight",
+		"err_white_wolf_select_first":         "Select a fellow werewolf first",
+		"err_failed_record_white_wolf":        "Failed to record your choice",
+		"err_only_fox":                        "Only the Fox can sniff",
+		"err_fox_done":                        "You have already sniffed tonight",
+		"err_fox_lost_power":                  "Your nose has gone cold",
+		"err_fox_select_first":                "Select a player first",
+		"err_powers_lost":                     "The village lynched its Elder — your power is gone",
+		"err_only_scapegoat":                  "Only the blamed Scapegoat can choose tomorrow's voters",
+		"err_scapegoat_inactive":              "There is no Scapegoat choice to make",
+		"err_scapegoat_choose_first":          "Choose at least one voter first",
+		"err_failed_record_scapegoat":         "Failed to record your choice",
+		"err_only_thief":                      "Only the Thief can take a spare card",
+		"err_thief_setup_only":                "The spare cards can only be taken before the first night",
+		"err_thief_done":                      "You have already made your choice",
+		"err_thief_must_take_wolf":            "Both spare cards are werewolves — you must take one",
+		"err_failed_record_thief":             "Failed to record your choice",
+		"err_only_wild_child":                 "Only the Wild Child can choose a role model",
+		"err_wild_child_night_1":              "The role model can only be chosen on the first night",
+		"err_wild_child_done":                 "You have already chosen your role model",
+		"err_wild_child_select_first":         "Select a role model first",
+		"err_failed_record_wild_child":        "Failed to record your role model",
+		"err_custom_role_name":                "A custom role needs a name (up to 32 characters) and a description (up to 200)",
+		"err_custom_role_team":                "Choose the village or the werewolves as the team",
+		"err_custom_role_ability":             "Unknown night ability",
+		"err_custom_role_charges":             "Uses per game must be between 0 and 9",
+		"err_custom_role_wolf_night":          "Only village roles can have a night ability",
+		"err_custom_role_exists":              "A role with that name already exists",
+		"err_custom_role_failed":              "Failed to save the role",
+		"err_no_custom_ability":               "Your role has no night ability",
+		"err_custom_ability_done":             "You cannot use your ability again tonight",
+		"err_custom_select_first":             "Select a player first",
+		"err_unknown_pack":                    "This pack cannot be switched off",
+		"err_invalid_night_timer":             "That night length is not available",
+		"err_invalid_day_timer":               "That day length is not available",
+		"err_invalid_role_reveal":             "That reveal setting is not available",
+		"err_chat_not_allowed":                "You cannot write in this chat right now",
+		"err_chat_too_long":                   "That message is too long",
+		"err_chat_muted":                      "The host has muted your chat",
+		"err_chat_filtered":                   "That message was blocked by the chat filter",
+		"chat_muted_notice":                   "The host has muted your chat",
+		"err_chat_failed":                     "Failed to send the message",
+		"err_failed_toggle_pack":              "Failed to switch the pack",
+		"err_hunter_only_select":              "Only the Hunter can select a target",
+		"err_hunter_revenge_only_dead":        "Hunter revenge is only available when eliminated",
+		"err_already_shot":                    "You have already taken your revenge shot",
+		"err_hunter_only_shoot":               "Only the Hunter can take a revenge shot",
+		"err_select_shoot_first":              "Select a player to shoot first",
+		"err_cannot_shoot_dead":               "Cannot shoot a dead player",
+		"err_failed_kill_target":              "Failed to kill target",
+		"err_failed_toggle_ai":                "Failed to toggle AI features",
 
 		// Night survey labels
 		"survey_prefix":   "Night %v: %s — %s",
@@ -290,84 +850,313 @@ var translations = map[string]map[string]string{
 		"survey_notes":    "Notes",
 
 		// History bar and entries
-		"hist_heading":          "History",
-		"hist_wolf_vote":        "Night %s: %s voted to kill %s",
-		"hist_wolf_vote_cub":    "Night %s: %s voted to kill %s (Wolf Cub revenge)",
-		"hist_wolf_pass":        "Night %s: %s passed",
-		"hist_wolf_pass_2":      "Night %s: %s passed (second kill)",
-		"hist_found_dead":       "Night %s: %s (%s) was found dead",
-		"hist_protected":        "Night %s: You protected %s",
-		"hist_seer_wolf":        "Night %s: You investigated %s — they are a werewolf",
-		"hist_seer_not_wolf":    "Night %s: You investigated %s — they are not a werewolf",
-		"hist_witch_heal":       "Night %s: You saved %s with your heal potion",
-		"hist_witch_poison":     "Night %s: You poisoned %s",
-		"hist_witch_confirmed":  "Night %s: Witch %s confirmed her actions",
-		"hist_cupid_lover":      "Night 1: Your lover is %s",
-		"hist_doppelganger":     "Night 1: You secretly became a %s (copied from %s)",
-		"hist_heartbreak_night": "Night %s: %s died of heartbreak after their lover %s was killed",
-		"hist_heartbreak_day":   "Day %s: %s died of heartbreak after their lover %s was killed",
-		"hist_day_vote":         "Day %s: %s voted to eliminate %s",
-		"hist_day_pass":         "Day %s: %s passed",
-		"hist_eliminated":       "Day %s: %s (%s) was eliminated by the village",
-		"hist_hunter_shot":      "Day %s: Hunter %s shot %s",
+		"hist_heading":                   "History",
+		"hist_wolf_vote":                 "Night %s: %s voted to kill %s",
+		"hist_wolf_vote_cub":             "Night %s: %s voted to kill %s (Wolf Cub revenge)",
+		"hist_wolf_pass":                 "Night %s: %s passed",
+		"hist_wolf_pass_2":               "Night %s: %s passed (second kill)",
+		"hist_alpha_bite":                "Night %s: The Alpha bit %s, who joins the pack",
+		"hist_alpha_bitten":              "Night %s: You were bitten and turned into a werewolf",
+		"hist_cursed_turned":             "Night %s: The attack awakened %s's curse — they join the pack",
+		"hist_found_dead":                "Night %s: %s (%s) was found dead",
+		"hist_found_dead_hidden":         "Night %s: %s was found dead",
+		"hist_protected":                 "Night %s: You protected %s",
+		"hist_night_timed_out":           "Night %s: Time ran out before you acted",
+		"hist_night_ended_by_host":       "Night %s: The host ended the night before you acted",
+		"hist_night_afk":                 "Night %s: You were away too long — your night action was skipped",
+		"hist_night_skipped":             "Night %s: You skipped your action",
+		"hist_bodyguard_guarded":         "Night %s: You stood guard over %s",
+		"hist_apprentice_promoted_night": "Night %s: The Seer is gone — you inherit their sight",
+		"hist_apprentice_promoted_day":   "Day %s: The Seer is gone — you inherit their sight",
+		"hist_drunk_sobered":             "Night %s: The fog lifts — you are really the %s",
+		"hist_tough_guy_wounded":         "Night %s: The werewolves attacked you — you survived, but will not live past the end of the day",
+		"hist_elder_survived":            "Night %s: The werewolves attacked you — you withstood it, but will not survive another attack",
+		"hist_elder_lynched":             "Day %s: The village lynched its Elder %s — every villager loses their powers",
+		"hist_scapegoat_blamed":          "Day %s: The vote was tied — the Scapegoat %s took the blame and was eliminated",
+		"hist_scapegoat_choice":          "Day %s: You ruled that only %s may vote tomorrow",
+		"hist_thief_stole":               "Before night 1: You stole the %s card",
+		"hist_thief_kept":                "Before night 1: You kept the Thief card",
+		"hist_wild_child_model":          "Night %s: You chose %s as your role model",
+		"hist_wild_child_turned_night":   "Night %s: Your role model %s is dead — you join the werewolves",
+		"hist_wild_child_turned_day":     "Day %s: Your role model %s is dead — you join the werewolves",
+		"hist_tough_guy_died":            "Day %s: %s (Tough Guy) succumbed to their wounds",
+		"hist_seer_wolf":                 "Night %s: You investigated %s — they are a werewolf",
+		"hist_seer_not_wolf":             "Night %s: You investigated %s — they are not a werewolf",
+		"hist_sorceress_seer":            "Night %s: You searched %s — they are the Seer",
+		"hist_sorceress_not_seer":        "Night %s: You searched %s — they are not the Seer",
+		"hist_aura_seer_power":           "Night %s: You read the aura of %s — they have a special power",
+		"hist_aura_seer_no_power":        "Night %s: You read the aura of %s — they have no special power",
+		"hist_witch_heal":                "Night %s: You saved %s with your heal potion",
+		"hist_witch_poison":              "Night %s: You poisoned %s",
+		"hist_witch_confirmed":           "Night %s: Witch %s confirmed her actions",
+		"hist_cupid_lover":               "Night 1: Your lover is %s",
+		"hist_masons_revealed":           "Night 1: The Masons are %s",
+		"hist_doppelganger":              "Night 1: You secretly became a %s (copied from %s)",
+		"hist_doppelganger_model":        "Night 1: You chose %s — you take their role when they die",
+		"hist_doppelganger_heir_night":   "Night %s: %s is dead — you take their role: %s",
+		"hist_doppelganger_heir_day":     "Day %s: %s is dead — you take their role: %s",
+		"hist_heartbreak_night":          "Night %s: %s died of heartbreak after their lover %s was killed",
+		"hist_heartbreak_day":            "Day %s: %s died of heartbreak after their lover %s was killed",
+		"hist_day_vote":                  "Day %s: %s voted to eliminate %s",
+		"hist_day_pass":                  "Day %s: %s passed",
+		"hist_day_nominate":              "Day %s: %s nominated %s",
+		"hist_day_second":                "Day %s: %s seconded the nomination of %s",
+		"hist_day_open_vote":             "Day %s: %s closed the nominations and opened the vote",
+		"hist_day_trial":                 "Day %s: %s was put on trial",
+		"hist_day_defense":               "Day %s: %s's defense: %s",
+		"hist_day_defense_silent":        "Day %s: %s rested their case without a word",
+		"hist_day_verdict_guilty":        "Day %s: %s found %s guilty",
+		"hist_day_verdict_innocent":      "Day %s: %s found %s innocent",
+		"hist_day_acquitted":             "Day %s: %s was acquitted",
+		"hist_day_runoff":                "Day %s: %s is tied for the most votes and goes into a runoff",
+		"hist_day_runoff_vote":           "Day %s: %s voted to eliminate %s in the runoff",
+		"hist_mayor_vote":                "Before night 1: %s voted for %s as Mayor",
+		"hist_mayor_elected":             "Before night 1: %s was elected Mayor",
+		"hist_last_words":                "Day %s: %s's last words: %s",
+		"hist_whisper":                   "Day %s: %s whispered to %s: %s",
+		"hist_last_words_silent":         "Day %s: %s left without last words",
+		"hist_eliminated":                "Day %s: %s (%s) was eliminated by the village",
+		"hist_eliminated_hidden":         "Day %s: %s was eliminated by the village",
+		"hist_day_timed_out":             "Day %s: Time ran out and the vote was closed",
+		"hist_day_closed_by_host":        "Day %s: The host closed the vote",
+		"hist_player_substituted":        "%s took over the seat of %s",
+		"hist_undo_day":                  "Day %s: The host took back the last resolution",
+		"hist_moderator_kill":            "Round %s: The host killed %s",
+		"hist_moderator_revive":          "Round %s: The host revived %s",
+		"hist_moderator_set_role":        "Round %s: The host made %s the %s",
+		"hist_moderator_force_night":     "Night %s: The host forced the day to begin",
+		"hist_moderator_force_day":       "Day %s: The host forced the vote to close",
+		"hist_undo_night":                "Night %s: The host took back the last resolution; the night starts over",
+		"hist_prince_revealed":           "Day %s: %s was voted out but revealed themselves as the Prince and was spared",
+		"hist_village_idiot_revealed":    "Day %s: %s was voted out but turned out to be the Village Idiot — spared, but without a vote from now on",
+		"hist_priest_wolf":               "Day %s: Priest %s threw holy water at %s, who burned — a werewolf",
+		"hist_priest_backfire":           "Day %s: Priest %s threw holy water at %s, who was unharmed — the Priest died",
+		"hist_spellcaster_silence":       "Night %s: You silenced %s for the coming day",
+		"hist_serial_killer_kill":        "Night %s: You chose %s as your victim",
+		"hist_piper_charm":               "Night %s: You charmed %s and %s",
+		"hist_piper_charm_one":           "Night %s: You charmed %s",
+		"hist_piper_charmed":             "Night %s: The Piper's tune has charmed you",
+		"hist_white_wolf_kill":           "Night %s: You turned on the pack and chose %s as your victim",
+		"hist_white_wolf_spared":         "Night %s: You spared the pack tonight",
+		"hist_fox_wolf":                  "Night %s: You sniffed around %s — a werewolf is among them",
+		"hist_fox_no_wolf":               "Night %s: You sniffed around %s — no werewolf among them, and your nose has gone cold",
+		"hist_hunter_shot":               "Day %s: Hunter %s shot %s",
 
 		// TTS narrator announcements (fixed game events)
-		"tts_game_begins":    "The game begins. Night falls upon the village.",
-		"tts_night_falls":    "Night %d falls upon the village.",
-		"tts_wolves_chosen":  "The werewolves have made their choice. Silence falls over the village.",
-		"tts_dawn_unscathed": "Dawn breaks. The village survived the night unscathed.",
-		"tts_dawn_deaths":    "Dawn breaks. The village awakens to find %s dead.",
-		"tts_join_and":       " and ",
-		"tts_villagers_win":  "The villagers have triumphed! All werewolves have been eliminated.",
-		"tts_werewolves_win": "The werewolves have won! They now rule the village.",
-		"tts_lovers_win":     "The lovers have won. They are the last ones standing, bound together forever.",
+		"tts_game_begins":            "The game begins. Night falls upon the village.",
+		"tts_night_falls":            "Night %d falls upon the village.",
+		"tts_prince_revealed":        "The noose is ready — but %s reveals the royal seal. The village cannot hang its Prince.",
+		"tts_village_idiot_revealed": "The village drags %s to the gallows — and then bursts out laughing. Nobody hangs the Village Idiot, but nobody will listen to them again either.",
+		"tts_wolves_chosen":          "The werewolves have made their choice. Silence falls over the village.",
+		"tts_dawn_unscathed":         "Dawn breaks. The village survived the night unscathed.",
+		"tts_dawn_deaths":            "Dawn breaks. The village awakens to find %s dead.",
+		"tts_join_and":               " and ",
+		"tts_villagers_win":          "The villagers have triumphed! All werewolves have been eliminated.",
+		"tts_werewolves_win":         "The werewolves have won! They now rule the village.",
+		"tts_lovers_win":             "The lovers have won. They are the last ones standing, bound together forever.",
+		"tts_tanner_win":             "The village has hanged the Tanner — exactly what they wanted. The Tanner wins alone.",
+		"tts_serial_killer_win":      "Silence settles over an empty village. The Serial Killer is the last one standing and wins alone.",
+		"tts_piper_win":              "The last notes fade away. Every soul left in the village follows the Piper's tune — the Piper wins alone.",
+		"tts_white_werewolf_win":     "The last howl belongs to the White Werewolf. Villagers and pack alike have fallen — the White Werewolf wins alone.",
 	},
 	"de": {
 		"lang_name": "Deutsch",
 
 		// Index page
-		"brand_name":              "Werwolf",
-		"page_title_index":        "Werwolf - Anmelden",
-		"page_title_game":         "Werwolf - Lobby",
-		"join_game_heading":       "Spiel beitreten",
-		"game_name_label":         "Spielname",
-		"game_name_placeholder":   "Spielname eingeben",
-		"btn_join":                "Beitreten",
-		"btn_logout":              "Abmelden",
-		"your_games_heading":      "Deine Spiele",
-		"game_status_lobby":       "Wartet auf Mitspieler",
-		"you_won":                 "du hast gewonnen",
-		"you_lost":                "du hast verloren",
-		"signin_heading":          "Anmelden",
-		"name_placeholder":        "Name eingeben",
-		"name_label":              "Name",
-		"secret_code_label":       "Geheimcode",
-		"secret_code_placeholder": "Dein Geheimcode",
-		"btn_login":               "Anmelden",
-		"btn_signin_continue":     "Weiter",
+		"brand_name":                      "Werwolf",
+		"page_title_index":                "Werwolf - Anmelden",
+		"page_title_game":                 "Werwolf - Lobby",
+		"join_game_heading":               "Spiel beitreten",
+		"game_name_label":                 "Spielname",
+		"game_name_placeholder":           "Spielname eingeben",
+		"btn_join":                        "Beitreten",
+		"btn_logout":                      "Abmelden",
+		"btn_delete_account":              "Mein Konto löschen",
+		"delete_account_confirm":          "Konto löschen? Dein Name wird aus allen vergangenen Spielen entfernt und deine Statistiken gehen verloren. Das kann nicht rückgängig gemacht werden.",
+		"sessions_heading":                "Angemeldete Geräte (%d)",
+		"session_this_device":             "dieses Gerät",
+		"session_unknown_device":          "Unbekanntes Gerät",
+		"session_times":                   "angemeldet %s, zuletzt genutzt %s",
+		"btn_session_revoke":              "Abmelden",
+		"btn_logout_everywhere":           "Überall abmelden",
+		"logout_everywhere_confirm":       "Auf allen Geräten abmelden, auch auf diesem? Offene Spiele werden dort ebenfalls geschlossen.",
+		"err_account_in_game":             "Beende dein laufendes Spiel, bevor du dein Konto löschst",
+		"your_games_heading":              "Deine Spiele",
+		"archive_link":                    "Vergangene Spiele",
+		"archive_title":                   "Vergangene Spiele",
+		"archive_home":                    "← Zurück",
+		"archive_back":                    "← Alle vergangenen Spiele",
+		"archive_finished":                "Beendet",
+		"archive_game":                    "Spiel",
+		"archive_winner":                  "Sieger",
+		"archive_players":                 "Spieler:",
+		"archive_empty":                   "Noch ist kein Spiel zu Ende gegangen.",
+		"archive_no_events":               "Für dieses Spiel wurde nichts aufgezeichnet.",
+		"archive_export":                  "Spiel als JSON herunterladen",
+		"archive_replay":                  "Spiel Schritt für Schritt nachspielen",
+		"replay_title":                    "Wiederholung",
+		"replay_back":                     "← Zurück zum Spiel",
+		"replay_setup":                    "Vorbereitung",
+		"replay_prev":                     "← Zurück",
+		"replay_next":                     "Weiter →",
+		"replay_jump":                     "Alle Schritte",
+		"stats_link":                      "Deine Statistik",
+		"stats_title":                     "Spielerstatistik",
+		"stats_heading":                   "Statistik von %s",
+		"stats_games":                     "Spiele",
+		"stats_wins":                      "Siege",
+		"stats_survival":                  "Bis zum Ende überlebt",
+		"stats_lynched_first_day":         "Am ersten Tag gelyncht",
+		"stats_by_team":                   "Nach Team",
+		"stats_by_role":                   "Nach Rolle",
+		"stats_team":                      "Team",
+		"stats_role":                      "Rolle",
+		"stats_none":                      "Noch keine beendeten Spiele.",
+		"stats_unknown_player":            "Einen Spieler dieses Namens gibt es nicht.",
+		"stats_team_villager":             "Dorf",
+		"stats_team_werewolf":             "Werwölfe",
+		"stats_team_white_werewolf":       "Weißer Werwolf",
+		"stats_team_tanner":               "Gerber",
+		"stats_team_serial_killer":        "Serienmörder",
+		"stats_team_piper":                "Rattenfänger",
+		"leaderboard_link":                "Bestenliste",
+		"leaderboard_title":               "Bestenliste",
+		"leaderboard_role_title":          "Bestenliste: %s",
+		"leaderboard_all_roles":           "Alle Rollen",
+		"leaderboard_min_games":           "In die Siegquote kommt, wer mindestens %d Spiele hat.",
+		"leaderboard_win_rate_title":      "Beste Siegquote",
+		"leaderboard_most_games_title":    "Meiste Spiele",
+		"leaderboard_wolf_win_rate_title": "Beste Werwölfe",
+		"leaderboard_player":              "Spieler",
+		"leaderboard_win_rate":            "Siegquote",
+		"leaderboard_empty":               "Noch niemand auf dieser Liste.",
+		"game_status_lobby":               "Wartet auf Mitspieler",
+		"you_won":                         "du hast gewonnen",
+		"you_lost":                        "du hast verloren",
+		"signin_heading":                  "Anmelden",
+		"name_placeholder":                "Name eingeben",
+		"name_label":                      "Name",
+		"secret_code_label":               "Geheimcode",
+		"secret_code_placeholder":         "Dein Geheimcode",
+		"secret_code_notice_heading":      "Dein Geheimcode",
+		"secret_code_notice_text":         "Schreib dir diesen Code auf: Mit ihm und deinem Namen meldest du dich wieder an, und er wird nicht noch einmal angezeigt.",
+		"secret_code_notice_close":        "Verstanden",
+		"oauth_or":                        "oder",
+		"oauth_signin":                    "Mit %s anmelden",
+		"oauth_link":                      "Ab jetzt mit %s anmelden",
+		"oauth_linked":                    "Meldet sich mit %s an",
+		"btn_login":                       "Anmelden",
+		"btn_signin_continue":             "Weiter",
 
 		// Sidebar
-		"sidebar_players": "Spieler",
-		"ai_features":     "KI-Funktionen",
-		"narrator_label":  "Erzähler",
-		"code_label":      "Code",
-		"night_round":     "Nacht %d",
-		"day_round":       "Tag %d",
+		"sidebar_players":           "Spieler",
+		"substitute_heading":        "Ausgefallene Spieler ersetzen",
+		"substitute_seat_label":     "Platz:",
+		"substitute_observer_label": "Übernommen von:",
+		"btn_substitute":            "Platz übergeben",
+		"rejoin_heading":            "Geheimcode verloren",
+		"rejoin_text":               "Erstelle einen einmaligen Wiedereinstiegscode und gib ihn weiter: Damit meldet sich die Person einmal statt mit dem Geheimcode an und kommt zurück in dieses Spiel.",
+		"rejoin_player_label":       "Person:",
+		"btn_issue_rejoin_code":     "Wiedereinstiegscode erstellen",
+		"rejoin_code_issued":        "Wiedereinstiegscode für %s: %s (%d Minuten gültig, nur einmal verwendbar)",
+		"btn_pause_game":            "Spiel pausieren",
+		"btn_resume_game":           "Weiterspielen",
+		"btn_wake_village":          "Dorf aufwecken",
+		"btn_close_vote":            "Abstimmung schließen",
+		"btn_undo_resolution":       "Letzte Auflösung zurücknehmen",
+		"btn_abort_game":            "Spiel abbrechen",
+		"abort_confirm":             "Dieses Spiel ohne Sieger beenden und zurück in die Lobby?",
+		"undo_confirm":              "Das Spiel auf den Stand vor der letzten Auflösung zurücksetzen?",
+		"moderator_heading":         "Ins Spiel eingreifen",
+		"moderator_player_label":    "Spieler:",
+		"moderator_override_label":  "Eingriff:",
+		"moderator_kill":            "Töten",
+		"moderator_revive":          "Wiederbeleben",
+		"moderator_set_role":        "Rolle ändern in",
+		"moderator_role_label":      "Rolle:",
+		"btn_moderator_override":    "Anwenden",
+		"btn_force_phase":           "Phase erzwingen",
+		"force_phase_confirm":       "Die Nacht beenden oder die heutige Abstimmung jetzt schließen?",
+		"ai_features":               "KI-Funktionen",
+		"narrator_label":            "Erzähler",
+		"night_round":               "Nacht %d",
+		"day_round":                 "Tag %d",
 
 		// Lobby
-		"players_label":     "Spieler:",
-		"roles_label":       "Rollen:",
-		"ready_to_start":    "Alles bereit!",
-		"need_more_players": "Es fehlen noch %d Spieler",
-		"need_more_roles":   "Es fehlen noch %d Rollen",
-		"configure_roles":   "Rollen unten festlegen",
-		"roles_heading":     "Rollen",
-		"roles_desc":        "Lege fest, welche Rollen mitspielen.",
-		"btn_start_game":    "Spiel starten",
+		"players_label":           "Spieler:",
+		"roles_label":             "Rollen:",
+		"ready_to_start":          "Alles bereit!",
+		"need_more_players":       "Es fehlen noch %d Spieler",
+		"need_more_roles":         "Es fehlen noch %d Rollen",
+		"configure_roles":         "Rollen unten festlegen",
+		"roles_heading":           "Rollen",
+		"roles_desc":              "Lege fest, welche Rollen mitspielen.",
+		"packs_label":             "Pakete:",
+		"night_timer_label":       "Nacht-Timer:",
+		"nominations_label":       "Abstimmungen brauchen Nominierung und Unterstützung",
+		"trials_label":            "Die Abstimmung stellt den Spitzenreiter vor Gericht",
+		"secret_votes_label":      "Abstimmungen bleiben bis zum Ende des Tages geheim",
+		"runoffs_label":           "Bei Gleichstand gibt es eine Stichwahl",
+		"mayor_election_label":    "Das Spiel beginnt mit einer Bürgermeisterwahl",
+		"last_words_label":        "Gelynchte und Erschossene haben letzte Worte",
+		"observers_see_all_label": "Zuschauer und Tote sehen alle Rollen",
+		"narrator_mode_label":     "Erzählermodus: Der Host erzählt statt mitzuspielen und bringt das Spiel von Hand voran",
+		"graveyard_talk_label":    "Friedhofsgespräche: Tote dürfen im Tages-Chat weiterreden",
+		"whispers_label":          "Flüstern: Jeder Lebende darf pro Tag eine private Nachricht senden",
+		"chat_filter_label":       "Chat-Filter: Schimpfwörter in allen Chats ausblenden",
+		"webhook_heading":         "Webhook",
+		"webhook_hint":            "Das Spiel sendet JSON an diese URL, wenn es beginnt, wenn jemand stirbt und wenn es vorbei ist. Leer lassen für keinen Webhook.",
+		"btn_save_webhook":        "Webhook speichern",
+		"observers_label":         "Zuschauer:",
+		"watch_label":             "Zuschauen statt mitspielen",
+		"night_timer_off":         "Aus",
+		"night_timer_seconds":     "%ds",
+		"day_timer_label":         "Tag-Timer:",
+		"day_timer_minutes":       "%d Min.",
+		"day_timer_left":          "noch %s",
+		"role_reveal_label":       "Rollen der Toten:",
+		"role_reveal_full":        "Aufgedeckt",
+		"role_reveal_team":        "Nur das Team",
+		"role_reveal_none":        "Verdeckt",
+		"night_timer_left":        "noch %s",
+		"pack_base":               "Basis",
+		"pack_daybreak":           "Daybreak",
+		"pack_bonus":              "Bonus",
+		"pack_custom":             "Eigene",
+		"btn_start_game":          "Spiel starten",
+		"host_label":              "Spielleitung:",
+		"host_you":                "(du)",
+		"host_only_note":          "Warte darauf, dass %s das Spiel einrichtet und startet",
+		"transfer_host_label":     "Lobby übergeben an:",
+		"btn_transfer_host":       "Zur Spielleitung machen",
+		"mute_player_label":       "Chat von:",
+		"btn_toggle_mute":         "Stummschalten / freigeben",
+		"btn_suggest_setup":       "Rollen für diese Spielerzahl vorschlagen",
+		"presets_heading":         "Rollen-Vorlagen",
+		"btn_load_preset":         "Laden",
+		"btn_save_preset":         "Aktuelle Rollen speichern",
+		"preset_name_placeholder": "Name der Vorlage, z. B. Klassisch 8 Spieler",
+
+		// Lobby: custom roles
+		"custom_role_heading":      "Eigene Rolle erstellen",
+		"custom_role_name":         "Name",
+		"custom_role_desc":         "Beschreibung",
+		"custom_role_team":         "Team",
+		"custom_team_villager":     "Dorf",
+		"custom_team_werewolf":     "Werwölfe",
+		"custom_role_night":        "Nachtfähigkeit",
+		"custom_night_none":        "Keine",
+		"custom_night_investigate": "Einen Spieler überprüfen (wie die Seherin)",
+		"custom_night_protect":     "Einen Spieler beschützen (wie der Arzt)",
+		"custom_role_charges":      "Einsätze pro Spiel (0 = jede Nacht)",
+		"custom_role_shield":       "Überlebt den ersten Werwolfangriff (wie der Dorfälteste)",
+		"btn_create_role":          "✨ Rolle erstellen",
 
 		// Night general
 		"waiting_for_players": "Warte auf %d weitere Spieler...",
 		"you_are_dead_night":  "Du bist tot. Das Dorf schläft.",
+		"you_are_watching":    "Du schaust diesem Spiel zu.",
+		"powers_lost_night":   "Das Dorf hat seinen Ältesten gelyncht. Deine Fähigkeit ist fort – du schläfst die Nacht durch.",
 		"village_sleeps":      "Das Dorf schläft...",
 		"close_eyes":          "Schließe die Augen und warte auf den Morgen.",
 		"storyteller_asking":  "Der Erzähler fragt dich",
@@ -378,16 +1167,61 @@ var translations = map[string]map[string]string{
 		"btn_continue":        "Weiter →",
 
 		// Night: Werewolf
-		"werewolf_title":       "Werwolf: Wähle ein Opfer",
-		"vote_locked_waiting":  "Du hast abgestimmt. Warte, bis die Nacht endet...",
-		"werewolf_select_desc": "Wähle dein Opfer oder passe. Sind alle Wölfe fertig, beende die Abstimmung.",
-		"btn_pass":             "Passen",
-		"btn_end_vote":         "Abstimmung beenden",
-		"vote_pass":            "Passen",
-		"wolf_cub_title":       "Rache des Wolfsjungen – zweites Opfer",
-		"vote2_locked":         "Zweite Stimme abgegeben. Warte, bis die Nacht endet...",
-		"wolf_cub_desc":        "Das Wolfsjunge wurde getötet. Wähle heute Nacht ein zweites Opfer oder passe.",
-		"btn_end_second_vote":  "Zweite Abstimmung beenden",
+		"werewolf_title":             "Werwolf: Wähle ein Opfer",
+		"vote_locked_waiting":        "Du hast abgestimmt. Warte, bis die Nacht endet...",
+		"werewolf_select_desc":       "Wähle dein Opfer oder passe. Sind alle Wölfe fertig, beende die Abstimmung.",
+		"wolves_sick_desc":           "Das letzte Opfer war krank. Das Rudel ist geschwächt und kann heute Nacht nicht jagen.",
+		"btn_pass":                   "Passen",
+		"btn_nominate":               "Nominieren",
+		"btn_second":                 "Unterstützen",
+		"btn_open_vote":              "Abstimmung eröffnen",
+		"btn_rest_case":              "Verteidigung beenden",
+		"btn_last_words":             "Letzte Worte sprechen",
+		"btn_whisper":                "Flüstern",
+		"btn_guilty":                 "Schuldig",
+		"btn_innocent":               "Unschuldig",
+		"btn_end_vote":               "Abstimmung beenden",
+		"wolf_chat_title":            "Rudel-Chat",
+		"wolf_chat_empty":            "Noch hat niemand etwas gesagt. Nur das Rudel kann das lesen.",
+		"wolf_chat_night":            "N%d",
+		"wolf_chat_placeholder":      "Flüstere dem Rudel zu...",
+		"lobby_chat_title":           "Lobby-Chat",
+		"lobby_chat_empty":           "Noch hat niemand etwas gesagt.",
+		"lobby_chat_placeholder":     "Sag etwas in die Runde...",
+		"day_chat_title":             "Dorf-Chat",
+		"day_chat_empty":             "Heute hat noch niemand gesprochen.",
+		"day_chat_day":               "T%d",
+		"day_chat_placeholder":       "Sag etwas zum Dorf...",
+		"day_chat_closed":            "Du kannst im Dorf-Chat nicht sprechen.",
+		"graveyard_chat_title":       "Friedhof",
+		"graveyard_chat_empty":       "Auf dem Friedhof ist es still.",
+		"graveyard_chat_round":       "R%d",
+		"graveyard_chat_placeholder": "Nur die Toten hören dich...",
+		"lovers_chat_title":          "Geflüster mit %s",
+		"lovers_chat_round":          "R%d",
+		"lovers_chat_empty":          "Noch nichts geflüstert.",
+		"lovers_chat_placeholder":    "Nur dein verliebtes Gegenüber hört dich...",
+		"invite_heading":             "Spieler einladen",
+		"invite_note":                "Teile diesen Link oder lass den Code scannen; er öffnet die Anmeldung mit diesem Spiel schon eingetragen.",
+		"invite_link":                "Einladungslink",
+		"btn_copy_invite":            "Link kopieren",
+		"invite_qr_alt":              "QR-Code des Einladungslinks",
+		"btn_chat_send":              "Senden",
+		"vote_pass":                  "Passen",
+		"wolf_cub_title":             "Rache des Wolfsjungen – zweites Opfer",
+		"vote2_locked":               "Zweite Stimme abgegeben. Warte, bis die Nacht endet...",
+		"wolf_cub_desc":              "Das Wolfsjunge wurde getötet. Wähle heute Nacht ein zweites Opfer oder passe.",
+		"btn_end_second_vote":        "Zweite Abstimmung beenden",
+		"alpha_bite_desc":            "Einmal pro Spiel kannst du das heutige Opfer beißen: Es wird zum Werwolf, statt zu sterben.",
+		"alpha_bite_armed":           "🩸 Das heutige Opfer wird gebissen und schließt sich dem Rudel an.",
+		"btn_alpha_bite":             "🩸 Beißen statt töten",
+		"btn_alpha_unbite":           "Wie üblich töten",
+		"white_wolf_title":           "Weißer Werwolf: Verrat am Rudel",
+		"white_wolf_desc":            "Heute Nacht darfst du heimlich einen anderen Werwolf töten – oder das Rudel verschonen.",
+		"white_wolf_result":          "%s wird die Nacht nicht überleben.",
+		"white_wolf_spared":          "Du hast das Rudel heute Nacht verschont.",
+		"btn_white_wolf_kill":        "🐺 Rudelmitglied töten",
+		"btn_white_wolf_spare":       "Rudel verschonen",
 
 		// Night: Seer
 		"seer_title":        "Seherin: Sieh jemandes wahre natur.",
@@ -395,6 +1229,48 @@ var translations = map[string]map[string]string{
 		"seer_choose":       "Wen willst du heute Nacht beobachten?",
 		"btn_investigate":   "🔮 Sehen",
 
+		// Night: Aura Seer
+		"aura_seer_title":           "Aura-Seherin: Lies eine Aura",
+		"aura_seer_choose":          "Wähle einen Spieler und bestätige. Du erfährst, ob er eine besondere Fähigkeit hat – also mehr ist als ein einfacher Dorfbewohner.",
+		"aura_seer_result_power":    "%s hat eine besondere Fähigkeit.",
+		"aura_seer_result_no_power": "%s hat keine besondere Fähigkeit.",
+		"btn_aura_seer_investigate": "✨ Aura lesen",
+
+		// Night: Fox
+		"fox_title":          "Fuchs: Herumschnüffeln",
+		"fox_choose":         "Wähle einen Spieler und bestätige. Du erfährst, ob unter ihm und seinen beiden Tischnachbarn ein Werwolf ist. Ist keiner dabei, verlierst du deine Fähigkeit.",
+		"fox_result_wolf":    "Unter ihnen ist ein Werwolf.",
+		"fox_result_no_wolf": "Kein Werwolf unter ihnen – deine Nase hat versagt.",
+		"fox_lost_power":     "Deine Nase hat versagt. Du kannst keine Werwölfe mehr erschnüffeln.",
+		"btn_fox_sniff":      "🦊 Schnüffeln",
+
+		// Night: Spellcaster
+		"spellcaster_title":       "Zauberin: Schweigebann",
+		"spellcaster_choose":      "Wähle einen Spieler und bestätige. Er kann morgen nicht abstimmen.",
+		"spellcaster_result":      "%s ist für den kommenden Tag zum Schweigen gebracht.",
+		"btn_spellcaster_silence": "🤫 Verstummen lassen",
+
+		// Night: Serial Killer
+		"serial_killer_title":    "Serienmörder: Dein Opfer",
+		"serial_killer_choose":   "Wähle einen Spieler, den du heute Nacht tötest, und bestätige. Die Werwölfe jagen unabhängig von dir.",
+		"serial_killer_result":   "%s wird den Morgen nicht erleben.",
+		"btn_serial_killer_kill": "🔪 Töten",
+
+		// Night: Piper
+		"piper_title":          "Rattenfänger: Spiel deine Melodie",
+		"piper_choose":         "Wähle %d Spieler zum Verzaubern und bestätige. Ein erneuter Klick hebt die Wahl auf.",
+		"piper_chosen_two":     "Gewählt: %s und %s. Klicke eine Karte, um die Wahl aufzuheben.",
+		"piper_none_left":      "Alle anderen sind schon verzaubert – bestätige, um deinen Zug zu beenden.",
+		"piper_done":           "Deine Melodie ist für heute Nacht gespielt.",
+		"piper_charmed_so_far": "Bisher verzaubert",
+		"btn_piper_charm":      "🎶 Verzaubern",
+
+		// Night: Sorceress
+		"sorceress_title":           "Zauberin: Finde die Seherin",
+		"sorceress_already_done":    "Du hast heute Nacht schon gesucht.",
+		"sorceress_choose":          "Wähle einen Spieler und bestätige. Du erfährst, ob er die Seherin ist.",
+		"btn_sorceress_investigate": "🔮 Suchen",
+
 		// Night: Doctor
 		"doctor_title":       "Doktor: Heile einen Spieler",
 		"doctor_protecting":  "Du heilst heute Nacht %s.",
@@ -402,10 +1278,18 @@ var translations = map[string]map[string]string{
 		"btn_doctor_protect": "🩺 Heilen",
 
 		// Night: Guard
-		"guard_title":       "Wächter: Dein Schutz",
-		"guard_protecting":  "Du beschützt heute Nacht %s.",
-		"guard_choose":      "Wen willst du heute Nacht beschützen?",
-		"btn_guard_protect": "🛡️ Beschützen",
+		"guard_title":        "Wächter: Dein Schutz",
+		"guard_protecting":   "Du beschützt heute Nacht %s.",
+		"guard_choose":       "Wen willst du heute Nacht beschützen?",
+		"btn_guard_protect":  "🛡️ Beschützen",
+		"btn_skip_night":     "Diese Nacht aussetzen",
+		"night_skipped_desc": "Du hast beschlossen, heute Nacht nichts zu tun.",
+
+		// Night: Bodyguard
+		"bodyguard_title":     "Leibwächter: Dein Schützling",
+		"bodyguard_guarding":  "Du wachst heute Nacht über %s. Greifen die Wölfe an, stirbst du stattdessen.",
+		"bodyguard_choose":    "Über wen willst du wachen? Greifen die Werwölfe diese Person an, stirbst du an ihrer Stelle.",
+		"btn_bodyguard_guard": "🛡️ Wache halten",
 
 		// Night: Witch
 		"witch_title":         "Hexe: Deine Tränke",
@@ -415,6 +1299,7 @@ var translations = map[string]map[string]string{
 		"heal_potion":         "🧪 Heiltrank",
 		"witch_targeting":     "Die Werwölfe greifen ihr Opfer an. Rette es mit deinem Heiltrank:",
 		"witch_no_target":     "Die Werwölfe haben noch kein Opfer gewählt...",
+		"witch_no_victim":     "Die Werwölfe greifen heute Nacht niemanden an.",
 		"heal_potion_used":    "Dein Heiltrank ist verbraucht.",
 		"poison_potion":       "☠️ Gifttrank",
 		"witch_poison_choose": "Wen möchtest du vergiften?",
@@ -426,6 +1311,11 @@ var translations = map[string]map[string]string{
 		"mason_know_these": "Diesen Dorfbewohnern kannst du vertrauen:",
 		"mason_alone":      "Du bist der einzige Freimaurer.",
 
+		// Night: Minion
+		"minion_title":      "Diener: Deine Herren",
+		"minion_know_these": "Das sind die Werwölfe. Sie kennen dich nicht:",
+		"minion_no_wolves":  "Es lebt kein Werwolf mehr.",
+
 		// Night: Cupid
 		"cupid_title":      "Amor: Wähle zwei Liebende",
 		"cupid_linked":     "Du hast %s und %s als Liebende verbunden.",
@@ -436,167 +1326,474 @@ var translations = map[string]map[string]string{
 
 		// Night: Doppelganger
 		"doppelganger_title":      "Doppelgänger: Wähle deine Identität",
-		"doppelganger_became":     "Deine neue Rolle: %s (kopiert von %s).",
+		"doppelganger_chose":      "Du übernimmst die Rolle von %s, sobald er oder sie stirbt.",
 		"doppelganger_selected":   "Du hast %s gewählt. Du kannst deine Wahl noch ändern.",
-		"doppelganger_choose":     "Wähle einen Spieler. Du wirst heimlich seine Rolle für den Rest des Spiels annehmen.",
-		"btn_doppelganger_become": "🎭 Werden",
+		"doppelganger_choose":     "Wähle einen Spieler. Stirbt er oder sie, übernimmst du heimlich seine Rolle für den Rest des Spiels.",
+		"btn_doppelganger_become": "🎭 Wählen",
+
+		// Night: Wild Child
+		"wild_child_title":      "Wildes Kind: Dein Vorbild",
+		"wild_child_choose":     "Wähle einen Spieler als dein Vorbild und bestätige. Stirbt er, wirst du zum Werwolf.",
+		"wild_child_result":     "%s ist dein Vorbild. Halte es am Leben.",
+		"btn_wild_child_choose": "🧒 Vorbild wählen",
+
+		// Night: custom roles
+		"custom_investigate_choose": "Wähle einen Spieler zum Überprüfen und bestätige.",
+		"custom_protect_choose":     "Wähle einen Spieler, den du heute Nacht beschützt, und bestätige.",
+		"custom_uses_left":          "Verbleibende Einsätze: %d",
+		"custom_no_uses":            "Deine Fähigkeit ist aufgebraucht. Du schläfst die Nacht durch.",
+		"custom_saw_wolf":           "%s ist ein Werwolf!",
+		"custom_saw_villager":       "%s ist kein Werwolf.",
+		"custom_protecting":         "Du beschützt heute Nacht %s.",
+		"btn_custom_protect":        "🛡️ Beschützen",
 
 		// Day phase
-		"no_deaths_last_night":   "Das Dorf erwacht. In der letzten Nacht ist niemand gestorben.",
-		"hunter_shot_killed":     "🏹 Der letzte Schuss des Jägers tötete %s!",
-		"hunter_victim_was":      "Die Rolle: %s.",
-		"hunter_last_shot":       "Dein letzter Schuss",
-		"hunter_eliminated_desc": "Es hat dich erwischt! Wen nimmst du mit in den Tod?",
-		"btn_hunter_shoot":       "🏹 Schießen",
-		"hunter_choosing":        "Der Jäger wählt sein letztes Ziel...",
-		"vote_to_eliminate":      "Wer muss sterben?",
-		"choose_to_eliminate":    "Für wen stimmst du? Oder passe – es braucht eine Mehrheit.",
-		"dead_cannot_vote":       "Du bist tot und kannst nicht abstimmen.",
-		"card_alive":             "Am Leben",
-		"card_dead":              "Tot",
-		"card_unknown":           "Unbekannt",
+		"no_deaths_last_night":         "Das Dorf erwacht. In der letzten Nacht ist niemand gestorben.",
+		"tough_guy_wounded_note":       "Die Werwölfe haben dich letzte Nacht angegriffen. Noch hältst du durch – doch am Ende dieses Tages stirbst du.",
+		"hunter_shot_killed":           "🏹 Der letzte Schuss des Jägers tötete %s!",
+		"hunter_victim_was":            "Die Rolle: %s.",
+		"hunter_last_shot":             "Dein letzter Schuss",
+		"hunter_eliminated_desc":       "Es hat dich erwischt! Wen nimmst du mit in den Tod?",
+		"btn_hunter_shoot":             "🏹 Schießen",
+		"hunter_choosing":              "Der Jäger wählt sein letztes Ziel...",
+		"vote_to_eliminate":            "Wer muss sterben?",
+		"nominations_stage_desc":       "Nominiere einen Spieler und unterstütze die Nominierung eines anderen. Sobald die Abstimmung eröffnet ist, kann nur über unterstützte Nominierte abgestimmt werden.",
+		"ballot_stage_desc":            "Die Nominierungen sind geschlossen. Stimme über einen unterstützten Nominierten ab oder passe.",
+		"nominee_line":                 "%s, nominiert von %s",
+		"nominee_seconded":             "Unterstützt:",
+		"no_nominations":               "Noch wurde niemand nominiert.",
+		"trial_title":                  "%s steht vor Gericht",
+		"trial_defend_desc":            "Das Dorf hat dich vor Gericht gestellt. Sag, was du zu deiner Verteidigung zu sagen hast — oder schweig.",
+		"trial_defense_placeholder":    "Deine Verteidigung…",
+		"trial_waiting_defense":        "Warte auf die Verteidigung von %s…",
+		"trial_no_defense":             "%s hat zur Verteidigung nichts gesagt.",
+		"last_words_title":             "Letzte Worte",
+		"last_words_desc":              "Du bist aus dem Spiel. Hinterlasse dem Dorf eine letzte Nachricht, oder sende nichts, um still zu gehen.",
+		"last_words_placeholder":       "Deine letzten Worte…",
+		"whisper_title":                "Geflüster",
+		"whisper_line":                 "%s an %s:",
+		"whisper_placeholder":          "Dein Geflüster…",
+		"whisper_used":                 "Du hast heute schon geflüstert.",
+		"last_words_silent":            "%s ging ohne ein Wort.",
+		"last_words_waiting":           "Es fehlen noch die letzten Worte von",
+		"last_words_dusk_note":         "Die Abstimmung ist vorbei. Die Nacht bricht herein, sobald die letzten Worte gesprochen sind.",
+		"trial_verdict_progress":       "Abgegebene Urteile: %d/%d",
+		"trial_found_guilty":           "%s wurde schuldig gesprochen.",
+		"secret_votes_note":            "Die Abstimmung ist geheim: Alle Stimmen werden aufgedeckt, sobald der Tag vorbei ist.",
+		"runoff_note":                  "Die Abstimmung endete unentschieden. Stichwahl: Stimmt erneut ab, nur über die Gleichplatzierten.",
+		"elected_mayor_note":           "%s ist Bürgermeister: Diese Stimme entscheidet bei Gleichstand.",
+		"election_title":               "Bürgermeisterwahl",
+		"election_desc":                "Wählt vor der ersten Nacht einen Bürgermeister. Seine Stimme entscheidet für den Rest des Spiels bei Gleichstand in den Tagesabstimmungen. Bei Stimmengleichheit entscheidet das Los.",
+		"election_progress":            "Abgegebene Stimmen: %d/%d",
+		"day_vote_recap_title":         "So hat das Dorf gestern abgestimmt",
+		"choose_to_eliminate":          "Für wen stimmst du? Oder passe – es braucht eine Mehrheit.",
+		"mayor_vote_note":              "Als Bürgermeister zählt deine Stimme doppelt – alle sehen das ×2 an deiner Stimme.",
+		"silenced_players":             "Heute zum Schweigen gebracht",
+		"silenced_cannot_vote":         "Die Zauberin hat dich zum Schweigen gebracht – du kannst heute weder abstimmen noch sprechen.",
+		"scapegoat_barred_cannot_vote": "Der Sündenbock hat dich ausgeschlossen – du kannst heute nicht abstimmen.",
+		"village_idiot_cannot_vote":    "Alle wissen, dass du der Dorfdepp bist – du kannst nicht mehr abstimmen.",
+		"scapegoat_title":              "Sündenbock: Die Wähler von morgen",
+		"scapegoat_choose":             "Das Dorf hat dir die Schuld am Gleichstand gegeben. Wähle, wer morgen abstimmen darf, und bestätige.",
+		"scapegoat_choosing":           "Der Sündenbock wählt, wer morgen abstimmen darf...",
+		"btn_scapegoat_confirm":        "🐐 Wähler bestätigen",
+
+		// Setup: Thief
+		"thief_title":          "Dieb: Die übrigen Karten",
+		"thief_choose":         "Zwei Karten sind übrig geblieben. Nimm eine davon oder behalte deine Diebeskarte.",
+		"thief_must_take_wolf": "Beide übrigen Karten sind Werwölfe – du musst eine davon nehmen.",
+		"thief_choosing":       "Der Dieb sieht sich die übrigen Karten an...",
+		"btn_thief_keep":       "🃏 Diebeskarte behalten",
+		"priest_title":         "Priester: Weihwasser",
+		"priest_choose":        "Einmal pro Spiel darfst du einen Spieler mit Weihwasser bespritzen. Ein Werwolf verbrennt und stirbt – jeder andere bleibt unversehrt, und du stirbst stattdessen.",
+		"btn_priest_throw":     "💧 Weihwasser werfen",
+		"dead_cannot_vote":     "Du bist tot und kannst nicht abstimmen.",
+		"card_alive":           "Am Leben",
+		"card_dead":            "Tot",
+		"card_unknown":         "Unbekannt",
 
 		// Role names and descriptions (for player cards)
-		"role_name_Villager":     "Dorfbewohner",
-		"role_name_Werewolf":     "Werwolf",
-		"role_name_Seer":         "Seherin",
-		"role_name_Doctor":       "Doktor",
-		"role_name_Witch":        "Hexe",
-		"role_name_Hunter":       "Jäger",
-		"role_name_Cupid":        "Amor",
-		"role_name_Guard":        "Wächter",
-		"role_name_Mason":        "Freimaurer",
-		"role_name_Wolf Cub":     "Wolfsjunges",
-		"role_name_Doppelganger": "Doppelgänger",
-		"role_name_Joker":        "Joker",
-		"role_desc_Villager":     "Nur Verstand zählt, keine Sonderkraft.",
-		"role_desc_Werewolf":     "Jagt nachts an der Seite der Wölfe.",
-		"role_desc_Seer":         "Erkennt nachts die wahre Natur eines Spielers.",
-		"role_desc_Doctor":       "Bewahrt nachts einen Spieler vor dem Biss.",
-		"role_desc_Witch":        "Braut einen Heil- und einen Gifttrank.",
-		"role_desc_Hunter":       "Reißt im Sterben einen Mitspieler mit.",
-		"role_desc_Cupid":        "Verbindet in der ersten Nacht zwei Herzen.",
-		"role_desc_Guard":        "Wacht jede Nacht über einen Spieler.",
-		"role_desc_Mason":        "Kennt die Brüder seines Bundes.",
-		"role_desc_Wolf Cub":     "Stirbt er, tötet das Rudel doppelt.",
-		"role_desc_Doppelganger": "Übernimmt in Nacht eins eine fremde Rolle.",
-		"role_desc_Joker":        "Eine vom Zufall bestimmte, geheime Rolle.",
+		"role_name_Villager":        "Dorfbewohner",
+		"role_name_Werewolf":        "Werwolf",
+		"role_name_Seer":            "Seherin",
+		"role_name_Doctor":          "Doktor",
+		"role_name_Witch":           "Hexe",
+		"role_name_Hunter":          "Jäger",
+		"role_name_Cupid":           "Amor",
+		"role_name_Guard":           "Wächter",
+		"role_name_Mason":           "Freimaurer",
+		"role_name_Wolf Cub":        "Wolfsjunges",
+		"role_name_Doppelganger":    "Doppelgänger",
+		"role_name_Joker":           "Joker",
+		"role_name_Sorceress":       "Zauberin",
+		"role_name_Alpha Werewolf":  "Urwolf",
+		"role_name_Minion":          "Diener",
+		"role_name_Tanner":          "Gerber",
+		"role_name_Bodyguard":       "Leibwächter",
+		"role_name_Apprentice Seer": "Seherlehrling",
+		"role_name_Aura Seer":       "Aura-Seherin",
+		"role_name_Lycan":           "Lykanthrop",
+		"role_name_Prince":          "Prinz",
+		"role_name_Mayor":           "Bürgermeister",
+		"role_name_Priest":          "Priester",
+		"role_name_Spellcaster":     "Zauberin",
+		"role_name_Drunk":           "Trunkenbold",
+		"role_name_Tough Guy":       "Harter Kerl",
+		"role_name_Diseased":        "Aussätziger",
+		"role_name_Cursed":          "Verfluchter",
+		"role_name_Serial Killer":   "Serienmörder",
+		"role_name_Piper":           "Rattenfänger",
+		"role_name_White Werewolf":  "Weißer Werwolf",
+		"role_name_Fox":             "Fuchs",
+		"role_name_Elder":           "Dorfältester",
+		"role_name_Scapegoat":       "Sündenbock",
+		"role_name_Thief":           "Dieb",
+		"role_name_Wild Child":      "Wildes Kind",
+		"role_name_Village Idiot":   "Dorfdepp",
+		"role_desc_Villager":        "Nur Verstand zählt, keine Sonderkraft.",
+		"role_desc_Werewolf":        "Jagt nachts an der Seite der Wölfe.",
+		"role_desc_Seer":            "Erkennt nachts die wahre Natur eines Spielers.",
+		"role_desc_Doctor":          "Bewahrt nachts einen Spieler vor dem Biss.",
+		"role_desc_Witch":           "Braut einen Heil- und einen Gifttrank.",
+		"role_desc_Hunter":          "Reißt im Sterben einen Mitspieler mit.",
+		"role_desc_Cupid":           "Verbindet in der ersten Nacht zwei Herzen.",
+		"role_desc_Guard":           "Wacht jede Nacht über einen Spieler.",
+		"role_desc_Mason":           "Kennt die Brüder seines Bundes.",
+		"role_desc_Wolf Cub":        "Stirbt er, tötet das Rudel doppelt.",
+		"role_desc_Doppelganger":    "Wählt in Nacht eins einen Spieler und übernimmt seine Rolle, wenn er stirbt.",
+		"role_desc_Joker":           "Eine vom Zufall bestimmte, geheime Rolle.",
+		"role_desc_Sorceress":       "Sucht die Seherin; hilft heimlich den Wölfen.",
+		"role_desc_Alpha Werewolf":  "Macht einmal pro Spiel das Opfer zum Wolf.",
+		"role_desc_Minion":          "Kennt die Wölfe und dient ihnen heimlich.",
+		"role_desc_Tanner":          "Gewinnt allein, wenn das Dorf ihn hängt.",
+		"role_desc_Bodyguard":       "Stirbt anstelle seines Schützlings.",
+		"role_desc_Apprentice Seer": "Wird zur Seherin, wenn diese stirbt.",
+		"role_desc_Aura Seer":       "Spürt, ob ein Spieler eine Fähigkeit hat.",
+		"role_desc_Lycan":           "Dorfbewohner, den die Seherin als Wolf sieht.",
+		"role_desc_Prince":          "Übersteht die erste Hinrichtung.",
+		"role_desc_Mayor":           "Seine Stimme zählt am Tag doppelt.",
+		"role_desc_Priest":          "Einmal Weihwasser: tötet Wolf oder sich selbst.",
+		"role_desc_Spellcaster":     "Bringt jede Nacht einen zum Schweigen.",
+		"role_desc_Drunk":           "Hält sich bis Nacht 3 für einen Dorfbewohner.",
+		"role_desc_Tough Guy":       "Überlebt einen Wolfsangriff noch einen Tag.",
+		"role_desc_Diseased":        "Gefressen: Wölfe setzen eine Jagd aus.",
+		"role_desc_Cursed":          "Wird Werwolf, wenn Wölfe angreifen.",
+		"role_desc_Serial Killer":   "Tötet nachts; gewinnt als Letzter am Leben.",
+		"role_desc_Piper":           "Verzaubert zwei pro Nacht; gewinnt, wenn alle verzaubert sind.",
+		"role_desc_White Werewolf":  "Jagt mit dem Rudel, gewinnt nur allein.",
+		"role_desc_Fox":             "Wittert Wölfe unter drei Nachbarn.",
+		"role_desc_Elder":           "Übersteht einen Wolfsangriff; sein Lynchen kostet das Dorf alle Fähigkeiten.",
+		"role_desc_Scapegoat":       "Stirbt bei Gleichstand; wählt die Wähler von morgen.",
+		"role_desc_Thief":           "Darf vor Nacht 1 eine übrige Karte nehmen.",
+		"role_desc_Wild Child":      "Wird zum Werwolf, wenn sein Vorbild stirbt.",
+		"role_desc_Village Idiot":   "Überlebt eine Verurteilung, verliert aber seine Stimme.",
 
 		// Finished screen
-		"victors":            "Sieger",
-		"the_fallen":         "Die Gefallenen",
-		"btn_play_again":     "Nochmal spielen",
-		"villagers_win_alt":  "Dorfbewohner gewinnen",
-		"lovers_win_alt":     "Liebende gewinnen",
-		"werewolves_win_alt": "Werwölfe gewinnen",
+		"victors":                "Sieger",
+		"the_fallen":             "Die Gefallenen",
+		"btn_play_again":         "Nochmal spielen",
+		"villagers_win_alt":      "Dorfbewohner gewinnen",
+		"lovers_win_alt":         "Liebende gewinnen",
+		"werewolves_win_alt":     "Werwölfe gewinnen",
+		"tanner_win_alt":         "Der Gerber gewinnt",
+		"serial_killer_win_alt":  "Der Serienmörder gewinnt",
+		"piper_win_alt":          "Der Rattenfänger gewinnt",
+		"white_werewolf_win_alt": "Der Weiße Werwolf gewinnt",
 
 		// Error/toast messages
-		"err_name_required":               "Name ist erforderlich",
-		"err_name_taken":                  "Name bereits vergeben. Wenn das du bist, melde dich mit deinem Geheimcode an.",
-		"err_something_wrong":             "Etwas ist schiefgelaufen",
-		"err_invalid_credentials":         "Ungültiger Name oder Geheimcode",
-		"err_failed_get_game":             "Spiel konnte nicht geladen werden",
-		"err_game_already_started":        "Rollen können nicht geändert werden: Spiel bereits gestartet",
-		"err_game_started":                "Spiel bereits gestartet",
-		"err_game_in_progress":            "Dieses Spiel läuft bereits — du kannst jetzt nicht mehr beitreten.",
-		"err_failed_get_players":          "Spieler konnten nicht geladen werden",
-		"err_failed_get_roles":            "Rollenkonfiguration konnte nicht geladen werden",
-		"err_role_count_mismatch":         "Rollenanzahl muss Spieleranzahl entsprechen",
-		"err_failed_assign_joker":         "Joker-Rolle konnte nicht zugewiesen werden",
-		"err_failed_assign_roles":         "Rollen konnten nicht zugewiesen werden",
-		"err_failed_start_game":           "Spiel konnte nicht gestartet werden",
-		"err_game_not_finished":           "Das Spiel ist noch nicht beendet",
-		"err_failed_role_config":          "Rollenkonfiguration konnte nicht geladen werden",
-		"err_failed_create_game":          "Neues Spiel konnte nicht erstellt werden",
-		"err_only_werewolves_vote":        "Nur Werwölfe können nachts abstimmen",
-		"err_only_werewolves_end_vote":    "Nur Werwölfe können die Abstimmung beenden",
-		"err_werewolves_not_done":         "Noch nicht alle Werwölfe haben abgestimmt (%d/%d)",
-		"err_werewolves_not_done_second":  "Noch nicht alle Werwölfe haben über das zweite Opfer abgestimmt (%d/%d)",
-		"err_werewolves_not_locked":       "Die Werwölfe haben ihre Abstimmung noch nicht abgeschlossen",
-		"err_heal_must_target_werewolf":   "Du kannst nur das Opfer der Werwölfe heilen",
-		"toast_seer_not_werewolf":         "🔮 %s ist kein Werwolf.",
-		"toast_seer_is_werewolf":          "🔮 %s ist ein Werwolf!",
-		"toast_wolves_chosen":             "🐺 Die Werwölfe haben ihre Wahl getroffen...",
-		"err_night_phase_act":             "Du kannst nur in der Nacht handeln",
-		"err_night_phase_protect":         "Du kannst nur in der Nacht schützen",
-		"err_night_phase_investigate":     "Du kannst nur nachts sehen",
-		"err_cupid_night1_only":           "Amor kann nur in der ersten Nacht handeln",
-		"err_doppelganger_night1_only":    "Der Doppelgänger kann nur in der ersten Nacht handeln",
-		"err_not_in_game":                 "Du bist nicht in diesem Spiel",
-		"err_dead_cannot_act":             "Tote Spieler können nicht handeln",
-		"err_dead_cannot_vote":            "Tote Spieler können nicht abstimmen",
-		"err_dead_cannot_end_vote":        "Tote Spieler können die Abstimmung nicht beenden",
-		"err_invalid_target":              "Ungültiges Ziel",
-		"err_target_not_found":            "Ziel nicht gefunden",
-		"err_failed_record_vote":          "Stimme konnte nicht gespeichert werden",
-		"err_failed_record_pass":          "Passen konnte nicht gespeichert werden",
-		"err_failed_clear_vote":           "Stimme konnte nicht zurückgenommen werden",
-		"err_night_vote_only":             "Abstimmen ist nur nachts möglich",
-		"err_day_vote_only":               "Abstimmen ist nur tagsüber möglich",
-		"err_night_survey_only":           "Die Befragung ist nur nachts verfügbar",
-		"err_cannot_target_dead":          "Du kannst kein totes Ziel wählen",
-		"err_cannot_vote_dead":            "Du kannst nicht für einen toten Spieler stimmen",
-		"err_already_protected":           "Du hast diese Nacht schon jemanden beschützt",
-		"err_select_protect_first":        "Wähle zuerst einen Spieler zum Beschützen",
-		"err_cannot_protect_dead":         "Du kannst keinen toten Spieler beschützen",
-		"err_failed_record_protection":    "Schutz konnte nicht gespeichert werden",
-		"err_only_doctor_select":          "Nur der Doktor kann ein Heilziel wählen",
-		"err_only_doctor_protect":         "Nur der Doktor kann Spieler heilen",
-		"err_only_guard_select":           "Nur der Wächter kann ein Schutzziel wählen",
-		"err_only_guard_protect":          "Nur der Wächter kann Spieler beschützen",
-		"err_guard_no_self":               "Der Wächter kann sich nicht selbst beschützen",
-		"err_guard_no_repeat":             "Du kannst nicht zwei Nächte hintereinander denselben Spieler beschützen",
-		"err_only_seer_select":            "Nur die Seherin kann ein Ziel zum Sehen wählen",
-		"err_only_seer_investigate":       "Nur die Seherin kann sehen",
-		"err_already_investigated":        "Du hast diese Nacht schon gesehen",
-		"err_select_investigate_first":    "Wähle zuerst einen Spieler zum Sehen",
-		"err_cannot_investigate_dead":     "Du kannst keinen toten Spieler beobachten",
-		"err_failed_record_investigation": "Beobachtung konnte nicht gespeichert werden",
-		"err_only_witch_select_heal":      "Nur die Hexe kann ein Heilziel wählen",
-		"err_only_witch_select_poison":    "Nur die Hexe kann ein Giftziel wählen",
-		"err_only_witch_apply":            "Nur die Hexe kann ihre Tränke einsetzen",
-		"err_already_submitted_night":     "Du hast für diese Nacht schon gehandelt",
-		"err_heal_already_used":           "Dein Heiltrank ist bereits verbraucht",
-		"err_poison_already_used":         "Dein Gifttrank ist bereits verbraucht",
-		"err_cannot_heal_self":            "Du kannst dich nicht selbst heilen",
-		"err_failed_commit_heal":          "Heilung konnte nicht gespeichert werden",
-		"err_poison_target_invalid":       "Das Giftziel ist nicht mehr gültig",
-		"err_failed_commit_poison":        "Vergiftung konnte nicht gespeichert werden",
-		"err_failed_record_witch_action":  "Hexenaktion konnte nicht gespeichert werden",
-		"err_cupid_only_living":           "Nur der lebende Amor kann Liebende verbinden",
-		"err_cupid_already_linked":        "Du hast die Liebenden bereits verbunden",
-		"err_failed_clear_choice":         "Auswahl konnte nicht zurückgenommen werden",
-		"err_lovers_must_differ":          "Die beiden Liebenden müssen unterschiedliche Spieler sein",
-		"err_failed_record_choice":        "Auswahl konnte nicht gespeichert werden",
-		"err_choose_two_lovers_first":     "Wähle zuerst zwei Liebende, bevor du sie verbindest",
-		"err_first_lover_invalid":         "Der erste Liebende ist ungültig",
-		"err_second_lover_invalid":        "Der zweite Liebende ist ungültig",
-		"err_failed_link_lovers":          "Liebende konnten nicht verbunden werden",
-		"toast_cupid_linked":              "💞 Amor hat euch verbunden! Deine große Liebe ist %s.",
-		"err_doppelganger_only_living":    "Nur der lebende Doppelgänger kann eine Rolle kopieren",
-		"err_doppelganger_already_chosen": "Du hast bereits eine Rolle zum Kopieren gewählt",
-		"err_cannot_copy_self":            "Du kannst dich nicht selbst kopieren",
-		"err_select_copy_first":           "Wähle zuerst einen Spieler zum Kopieren",
-		"err_failed_apply_role_change":    "Rollenwechsel konnte nicht angewendet werden",
-		"err_failed_record_copy":          "Kopie konnte nicht gespeichert werden",
-		"toast_doppelganger_became":       "🎭 Du bist jetzt %s!",
-		"toast_seer_outdated_reading":     "⚠️ %s, den du gesehen hast, ist jetzt ein Werwolf – deine Erkenntnis ist überholt!",
-		"err_vote_locked":                 "Die Abstimmung wurde bereits abgeschlossen",
-		"err_wolfcub_not_active":          "Die Rache des Wolfsjungen ist nicht aktiv",
-		"err_vote2_locked":                "Die zweite Abstimmung wurde bereits abgeschlossen",
-		"err_failed_record_vote2":         "Zweite Stimme konnte nicht gespeichert werden",
-		"err_must_be_alive_survey":        "Du musst am Leben sein, um die Befragung abzugeben",
-		"err_failed_record_survey":        "Befragung konnte nicht gespeichert werden",
-		"err_players_not_done":            "Noch nicht alle Spieler haben abgestimmt (%d/%d)",
-		"err_hunter_revenge_inactive":     "Die Rache des Jägers ist nicht aktiv",
-		"err_hunter_only_select":          "Nur der Jäger kann ein Ziel wählen",
-		"err_hunter_revenge_only_dead":    "Die Rache des Jägers ist nur möglich, wenn er ausgeschieden ist",
-		"err_already_shot":                "Du hast deinen Racheschuss schon abgegeben",
-		"err_hunter_only_shoot":           "Nur der Jäger kann einen Racheschuss abgeben",
-		"err_select_shoot_first":          "Wähle zuerst einen Spieler zum Erschießen",
-		"err_cannot_shoot_dead":           "Du kannst keinen toten Spieler erschießen",
-		"err_failed_kill_target":          "Ziel konnte nicht getötet werden",
-		"err_failed_toggle_ai":            "KI-Funktionen konnten nicht umgeschaltet werden",
+		"err_name_required":                   "Name ist erforderlich",
+		"err_name_taken":                      "Name bereits vergeben. Wenn das du bist, melde dich mit deinem Geheimcode an.",
+		"err_something_wrong":                 "Etwas ist schiefgelaufen",
+		"err_invalid_credentials":             "Ungültiger Name oder Geheimcode",
+		"err_failed_get_game":                 "Spiel konnte nicht geladen werden",
+		"err_game_already_started":            "Rollen können nicht geändert werden: Spiel bereits gestartet",
+		"err_game_started":                    "Spiel bereits gestartet",
+		"join_as_observer_note":               "Dieses Spiel läuft bereits — du trittst als Zuschauer bei.",
+		"err_failed_get_players":              "Spieler konnten nicht geladen werden",
+		"err_failed_get_roles":                "Rollenkonfiguration konnte nicht geladen werden",
+		"err_role_count_mismatch":             "Rollenanzahl muss Spieleranzahl entsprechen",
+		"err_failed_assign_joker":             "Joker-Rolle konnte nicht zugewiesen werden",
+		"err_failed_assign_roles":             "Rollen konnten nicht zugewiesen werden",
+		"err_failed_start_game":               "Spiel konnte nicht gestartet werden",
+		"err_game_not_finished":               "Das Spiel ist noch nicht beendet",
+		"err_failed_role_config":              "Rollenkonfiguration konnte nicht geladen werden",
+		"err_failed_create_game":              "Neues Spiel konnte nicht erstellt werden",
+		"err_only_werewolves_vote":            "Nur Werwölfe können nachts abstimmen",
+		"err_only_werewolves_end_vote":        "Nur Werwölfe können die Abstimmung beenden",
+		"err_werewolves_not_done":             "Noch nicht alle Werwölfe haben abgestimmt (%d/%d)",
+		"err_werewolves_not_done_second":      "Noch nicht alle Werwölfe haben über das zweite Opfer abgestimmt (%d/%d)",
+		"err_werewolves_not_locked":           "Die Werwölfe haben ihre Abstimmung noch nicht abgeschlossen",
+		"err_heal_must_target_werewolf":       "Du kannst nur das Opfer der Werwölfe heilen",
+		"toast_seer_not_werewolf":             "🔮 %s ist kein Werwolf.",
+		"toast_seer_is_werewolf":              "🔮 %s ist ein Werwolf!",
+		"toast_sorceress_found_seer":          "🔮 %s ist die Seherin!",
+		"toast_sorceress_not_seer":            "🔮 %s ist nicht die Seherin.",
+		"toast_aura_seer_power":               "✨ %s hat eine besondere Fähigkeit.",
+		"toast_aura_seer_no_power":            "✨ %s ist ein einfacher Dorfbewohner.",
+		"toast_fox_wolf":                      "🦊 Unter %s ist ein Werwolf.",
+		"toast_fox_no_wolf":                   "🦊 Kein Werwolf unter %s – du verlierst deine Fähigkeit.",
+		"toast_wolves_chosen":                 "🐺 Die Werwölfe haben ihre Wahl getroffen...",
+		"err_night_phase_act":                 "Du kannst nur in der Nacht handeln",
+		"err_night_phase_protect":             "Du kannst nur in der Nacht schützen",
+		"err_night_phase_investigate":         "Du kannst nur nachts sehen",
+		"err_cupid_night1_only":               "Amor kann nur in der ersten Nacht handeln",
+		"err_doppelganger_night1_only":        "Der Doppelgänger kann nur in der ersten Nacht handeln",
+		"err_not_in_game":                     "Du bist nicht in diesem Spiel",
+		"err_dead_cannot_act":                 "Tote Spieler können nicht handeln",
+		"err_dead_cannot_vote":                "Tote Spieler können nicht abstimmen",
+		"err_dead_cannot_end_vote":            "Tote Spieler können die Abstimmung nicht beenden",
+		"err_invalid_target":                  "Ungültiges Ziel",
+		"err_target_not_found":                "Ziel nicht gefunden",
+		"err_failed_record_vote":              "Stimme konnte nicht gespeichert werden",
+		"err_failed_record_pass":              "Passen konnte nicht gespeichert werden",
+		"err_failed_clear_vote":               "Stimme konnte nicht zurückgenommen werden",
+		"err_night_vote_only":                 "Abstimmen ist nur nachts möglich",
+		"err_day_vote_only":                   "Abstimmen ist nur tagsüber möglich",
+		"err_night_survey_only":               "Die Befragung ist nur nachts verfügbar",
+		"err_cannot_target_dead":              "Du kannst kein totes Ziel wählen",
+		"err_cannot_vote_dead":                "Du kannst nicht für einen toten Spieler stimmen",
+		"err_already_protected":               "Du hast diese Nacht schon jemanden beschützt",
+		"err_select_protect_first":            "Wähle zuerst einen Spieler zum Beschützen",
+		"err_cannot_protect_dead":             "Du kannst keinen toten Spieler beschützen",
+		"err_failed_record_protection":        "Schutz konnte nicht gespeichert werden",
+		"err_only_doctor_select":              "Nur der Doktor kann ein Heilziel wählen",
+		"err_only_doctor_protect":             "Nur der Doktor kann Spieler heilen",
+		"err_only_guard_select":               "Nur der Wächter kann ein Schutzziel wählen",
+		"err_only_guard_protect":              "Nur der Wächter kann Spieler beschützen",
+		"err_guard_no_self":                   "Der Wächter kann sich nicht selbst beschützen",
+		"err_guard_no_repeat":                 "Du kannst nicht zwei Nächte hintereinander denselben Spieler beschützen",
+		"err_only_bodyguard_select":           "Nur der Leibwächter kann einen Schützling wählen",
+		"err_only_bodyguard_guard":            "Nur der Leibwächter kann Wache halten",
+		"err_bodyguard_no_self":               "Der Leibwächter kann nicht über sich selbst wachen",
+		"err_only_seer_select":                "Nur die Seherin kann ein Ziel zum Sehen wählen",
+		"err_only_seer_investigate":           "Nur die Seherin kann sehen",
+		"err_only_sorceress_select":           "Nur die Zauberin kann ein Ziel wählen",
+		"err_only_sorceress_investigate":      "Nur die Zauberin kann suchen",
+		"err_only_aura_seer_select":           "Nur die Aura-Seherin kann ein Ziel wählen",
+		"err_only_aura_seer_investigate":      "Nur die Aura-Seherin kann Auren lesen",
+		"err_already_investigated":            "Du hast diese Nacht schon gesehen",
+		"err_select_investigate_first":        "Wähle zuerst einen Spieler zum Sehen",
+		"err_cannot_investigate_dead":         "Du kannst keinen toten Spieler beobachten",
+		"err_failed_record_investigation":     "Beobachtung konnte nicht gespeichert werden",
+		"err_only_witch_select_heal":          "Nur die Hexe kann ein Heilziel wählen",
+		"err_only_witch_select_poison":        "Nur die Hexe kann ein Giftziel wählen",
+		"err_only_witch_apply":                "Nur die Hexe kann ihre Tränke einsetzen",
+		"err_already_submitted_night":         "Du hast für diese Nacht schon gehandelt",
+		"err_cannot_skip":                     "Deine Rolle kann diese Aktion nicht aussetzen",
+		"err_failed_record_skip":              "Dein Aussetzen konnte nicht gespeichert werden",
+		"err_heal_already_used":               "Dein Heiltrank ist bereits verbraucht",
+		"err_poison_already_used":             "Dein Gifttrank ist bereits verbraucht",
+		"err_cannot_heal_self":                "Du kannst dich nicht selbst heilen",
+		"err_failed_commit_heal":              "Heilung konnte nicht gespeichert werden",
+		"err_poison_target_invalid":           "Das Giftziel ist nicht mehr gültig",
+		"err_failed_commit_poison":            "Vergiftung konnte nicht gespeichert werden",
+		"err_failed_record_witch_action":      "Hexenaktion konnte nicht gespeichert werden",
+		"err_cupid_only_living":               "Nur der lebende Amor kann Liebende verbinden",
+		"err_cupid_already_linked":            "Du hast die Liebenden bereits verbunden",
+		"err_failed_clear_choice":             "Auswahl konnte nicht zurückgenommen werden",
+		"err_lovers_must_differ":              "Die beiden Liebenden müssen unterschiedliche Spieler sein",
+		"err_failed_record_choice":            "Auswahl konnte nicht gespeichert werden",
+		"err_choose_two_lovers_first":         "Wähle zuerst zwei Liebende, bevor du sie verbindest",
+		"err_first_lover_invalid":             "Der erste Liebende ist ungültig",
+		"err_second_lover_invalid":            "Der zweite Liebende ist ungültig",
+		"err_failed_link_lovers":              "Liebende konnten nicht verbunden werden",
+		"toast_cupid_linked":                  "💞 Amor hat euch verbunden! Deine große Liebe ist %s.",
+		"err_doppelganger_only_living":        "Nur der lebende Doppelgänger kann eine Rolle kopieren",
+		"err_doppelganger_already_chosen":     "Du hast bereits eine Rolle zum Kopieren gewählt",
+		"err_cannot_copy_self":                "Du kannst dich nicht selbst kopieren",
+		"err_select_copy_first":               "Wähle zuerst einen Spieler zum Kopieren",
+		"err_failed_apply_role_change":        "Rollenwechsel konnte nicht angewendet werden",
+		"err_failed_record_copy":              "Kopie konnte nicht gespeichert werden",
+		"toast_doppelganger_became":           "🎭 Du bist jetzt %s!",
+		"toast_seer_outdated_reading":         "⚠️ %s, den du gesehen hast, ist jetzt ein Werwolf – deine Erkenntnis ist überholt!",
+		"toast_apprentice_promoted":           "🔮 Die Seherin ist tot – ihre Gabe geht auf dich über. Ab der nächsten Nacht kannst du Spieler durchschauen.",
+		"toast_drunk_sobered":                 "🍺 Der Nebel lichtet sich – du bist in Wahrheit %s!",
+		"err_vote_locked":                     "Die Abstimmung wurde bereits abgeschlossen",
+		"err_wolves_sick":                     "Das Rudel ist krank und kann heute Nacht nicht jagen",
+		"err_only_alpha_bite":                 "Nur der Urwolf kann beißen",
+		"err_alpha_bite_used":                 "Du hast deinen Biss bereits verwendet",
+		"toast_alpha_bitten":                  "🩸 Du wurdest in der Nacht gebissen. Du bist jetzt ein Werwolf!",
+		"toast_cursed_turned":                 "🌑 Die Werwölfe haben dich angegriffen und dein Fluch ist erwacht. Du bist jetzt ein Werwolf!",
+		"toast_wild_child_turned":             "🐺 Dein Vorbild %s ist tot. Du bist jetzt ein Werwolf!",
+		"toast_piper_charmed":                 "🎶 Die Melodie des Rattenfängers hat dich verzaubert.",
+		"err_wolfcub_not_active":              "Die Rache des Wolfsjungen ist nicht aktiv",
+		"err_vote2_locked":                    "Die zweite Abstimmung wurde bereits abgeschlossen",
+		"err_failed_record_vote2":             "Zweite Stimme konnte nicht gespeichert werden",
+		"err_must_be_alive_survey":            "Du musst am Leben sein, um die Befragung abzugeben",
+		"err_failed_record_survey":            "Befragung konnte nicht gespeichert werden",
+		"err_players_not_done":                "Noch nicht alle Spieler haben abgestimmt (%d/%d)",
+		"err_nominations_off":                 "Dieses Spiel verwendet keine Nominierungen",
+		"err_nominations_closed":              "Die Nominierungen sind bereits geschlossen",
+		"err_already_nominated":               "Dieser Spieler wurde bereits nominiert",
+		"err_nominated_today":                 "Du hast heute bereits jemanden nominiert",
+		"err_not_nominated":                   "Dieser Spieler wurde nicht nominiert",
+		"err_second_own_nomination":           "Du kannst deine eigene Nominierung nicht unterstützen",
+		"err_seconded_today":                  "Du hast heute bereits eine Nominierung unterstützt",
+		"err_ballot_empty":                    "Noch wurde keine Nominierung unterstützt",
+		"err_failed_record_nomination":        "Die Nominierung konnte nicht gespeichert werden",
+		"err_vote_not_open":                   "Die Abstimmung ist noch nicht eröffnet — erst nominieren und unterstützen",
+		"err_not_on_ballot":                   "Du kannst nur für einen unterstützten Nominierten stimmen",
+		"err_failed_toggle_nominations":       "Nominierungen konnten nicht umgeschaltet werden",
+		"err_failed_toggle_trials":            "Gerichtsverfahren konnten nicht umgeschaltet werden",
+		"err_failed_toggle_secret_votes":      "Geheime Abstimmungen konnten nicht umgeschaltet werden",
+		"err_failed_toggle_runoffs":           "Stichwahlen konnten nicht umgeschaltet werden",
+		"err_not_in_runoff":                   "In der Stichwahl kann nur über die Gleichplatzierten abgestimmt werden",
+		"err_failed_toggle_mayor_election":    "Bürgermeisterwahl konnte nicht umgeschaltet werden",
+		"err_not_election":                    "Gerade findet keine Bürgermeisterwahl statt",
+		"err_vote_closed_trial":               "Die Abstimmung ist vorbei — ein Gerichtsverfahren läuft",
+		"err_vote_closed_last_words":          "Die Abstimmung ist vorbei — die Nacht wartet auf letzte Worte",
+		"err_last_words_too_long":             "Deine letzten Worte sind zu lang",
+		"err_no_last_words_due":               "Du hast keine letzten Worte zu sprechen",
+		"err_cannot_whisper":                  "Du kannst jetzt nicht flüstern",
+		"err_already_whispered":               "Du hast heute schon geflüstert",
+		"err_failed_toggle_last_words":        "Letzte Worte konnten nicht umgeschaltet werden",
+		"err_host_only":                       "Nur die Spielleitung kann die Lobby ändern",
+		"err_failed_transfer_host":            "Die Lobby konnte nicht übergeben werden",
+		"err_game_paused":                     "Das Spiel ist pausiert",
+		"err_narrator_only":                   "Nur der Erzähler kann das Spiel voranbringen",
+		"err_narrator_must_watch":             "Im Erzählermodus schaut der Host zu, statt mitzuspielen",
+		"err_narrator_closes_vote":            "Der Erzähler schließt die Abstimmung",
+		"err_nothing_to_advance":              "Gerade gibt es nichts voranzubringen",
+		"err_day_waits_on_player":             "Der Tag wartet noch auf einen Spieler",
+		"err_failed_toggle_narrator_mode":     "Der Erzählermodus konnte nicht umgeschaltet werden",
+		"err_failed_toggle_graveyard_talk":    "Die Friedhofsgespräche konnten nicht umgeschaltet werden",
+		"err_failed_toggle_whispers":          "Das Flüstern konnte nicht umgeschaltet werden",
+		"err_failed_toggle_chat_filter":       "Der Chat-Filter konnte nicht umgeschaltet werden",
+		"err_invalid_webhook":                 "Der Webhook braucht eine http- oder https-URL",
+		"err_failed_set_webhook":              "Der Webhook konnte nicht gespeichert werden",
+		"err_invalid_message":                 "Die Nachricht konnte nicht gelesen werden",
+		"err_unknown_action":                  "Unbekannte Aktion",
+		"err_unsupported_version":             "Nicht unterstützte Nachrichtenversion",
+		"err_invalid_payload":                 "Die Felder der Aktion fehlen oder sind ungültig",
+		"err_failed_toggle_mute":              "Der Chat konnte nicht stummgeschaltet werden",
+		"err_game_not_running":                "Das Spiel läuft nicht",
+		"err_failed_pause":                    "Das Spiel konnte nicht pausiert oder fortgesetzt werden",
+		"err_nothing_to_undo":                 "Es gibt nichts zurückzunehmen",
+		"err_failed_undo":                     "Die letzte Auflösung konnte nicht zurückgenommen werden",
+		"err_failed_abort":                    "Das Spiel konnte nicht abgebrochen werden",
+		"game_aborted_note":                   "Der Host hat das Spiel beendet; ihr seid zurück in der Lobby",
+		"game_paused_heading":                 "Spiel pausiert",
+		"game_paused_note":                    "Der Host hat das Spiel pausiert. Die Timer stehen, bis es weitergeht.",
+		"narrator_heading":                    "Erzähler",
+		"narrator_dead":                       "tot",
+		"narrator_waiting":                    "wartet",
+		"narrator_done":                       "fertig",
+		"narrator_passed":                     "enthalten",
+		"narrator_picked":                     "wählte %s",
+		"narrator_script_heading":             "Ablauf der %d. Nacht",
+		"script_sleep":                        "Die Nacht bricht herein. Alle schließen die Augen.",
+		"script_cupid":                        "Amor, wach auf und wähle zwei Verliebte. Amor, schlaf wieder ein.",
+		"script_lovers":                       "Verliebte, wacht auf und seht euch an. Verliebte, schlaft wieder ein.",
+		"script_doppelganger":                 "Doppelgänger, wach auf und wähle, wessen Rolle du übernimmst. Doppelgänger, schlaf wieder ein.",
+		"script_wild_child":                   "Wildes Kind, wach auf und wähle dein Vorbild. Wildes Kind, schlaf wieder ein.",
+		"script_masons":                       "Freimaurer, wacht auf und seht euch an. Freimaurer, schlaft wieder ein.",
+		"script_guard":                        "Wächter, wach auf und wähle, wen du beschützt. Wächter, schlaf wieder ein.",
+		"script_bodyguard":                    "Leibwächter, wach auf und wähle, wen du bewachst. Leibwächter, schlaf wieder ein.",
+		"script_seer":                         "Seherin, wach auf und wähle, wen du ansiehst. Seherin, schlaf wieder ein.",
+		"script_aura_seer":                    "Aura-Seherin, wach auf und wähle, wessen Aura du liest. Aura-Seherin, schlaf wieder ein.",
+		"script_fox":                          "Fuchs, wach auf und erschnüffle drei Nachbarn. Fuchs, schlaf wieder ein.",
+		"script_sorceress":                    "Zauberin, wach auf und suche die Seherin. Zauberin, schlaf wieder ein.",
+		"script_spellcaster":                  "Zauberin mit dem Bann, wach auf und wähle, wen du zum Schweigen bringst. Zauberin, schlaf wieder ein.",
+		"script_wolves":                       "Werwölfe, wacht auf und wählt euer Opfer. Werwölfe, schlaft wieder ein.",
+		"script_white_werewolf":               "Weißer Werwolf, wach auf. Heute Nacht darfst du dich gegen das Rudel wenden. Weißer Werwolf, schlaf wieder ein.",
+		"script_serial_killer":                "Serienmörder, wach auf und wähle dein Opfer. Serienmörder, schlaf wieder ein.",
+		"script_piper":                        "Rattenfänger, wach auf und verzaubere zwei Spieler. Rattenfänger, schlaf wieder ein.",
+		"script_witch":                        "Hexe, wach auf. Setzt du heute Nacht einen Trank ein? Hexe, schlaf wieder ein.",
+		"script_doctor":                       "Doktor, wach auf und wähle, wen du heilst. Doktor, schlaf wieder ein.",
+		"script_custom":                       "%s, wach auf und setze deine Fähigkeit ein. %s, schlaf wieder ein.",
+		"script_wake":                         "Alle wachen auf.",
+		"err_preset_name":                     "Eine Vorlage braucht einen Namen mit höchstens 32 Zeichen",
+		"err_preset_empty":                    "Wähle erst Rollen, bevor du eine Vorlage speicherst",
+		"err_failed_save_preset":              "Die Vorlage konnte nicht gespeichert werden",
+		"err_unknown_preset":                  "Es gibt keine Vorlage mit diesem Namen",
+		"err_substitute_not_running":          "Plätze können nur während des Spiels übergeben werden",
+		"err_failed_override":                 "Der Eingriff konnte nicht angewendet werden",
+		"err_invalid_role":                    "Ungültige Rolle",
+		"err_substitute_still_connected":      "Diese Person ist noch verbunden",
+		"err_rejoin_still_connected":          "Diese Person ist noch verbunden und braucht keinen Wiedereinstiegscode",
+		"err_failed_substitute":               "Der Platz konnte nicht übergeben werden",
+		"err_failed_toggle_observer":          "Wechsel zwischen Mitspielen und Zuschauen fehlgeschlagen",
+		"err_failed_toggle_observers_see_all": "Die Sicht der Zuschauer konnte nicht umgeschaltet werden",
+		"err_suggest_needs_players":           "Ein Vorschlag ist erst ab 2 Spielern in der Lobby möglich",
+		"err_no_defense_due":                  "Gerade steht keine Verteidigung an",
+		"err_only_accused_defends":            "Nur der Angeklagte kann sich verteidigen",
+		"err_defense_too_long":                "Die Verteidigung ist zu lang",
+		"err_failed_record_defense":           "Die Verteidigung konnte nicht gespeichert werden",
+		"err_no_verdict_due":                  "Gerade wird über kein Urteil abgestimmt",
+		"err_cannot_judge":                    "Du kannst über dieses Urteil nicht abstimmen",
+		"err_already_judged":                  "Du hast dein Urteil bereits abgegeben",
+		"err_hunter_revenge_inactive":         "Die Rache des Jägers ist nicht aktiv",
+		"err_only_priest":                     "Nur der Priester kann Weihwasser werfen",
+		"err_priest_day_only":                 "Weihwasser kann nur am Tag geworfen werden",
+		"err_priest_water_used":               "Du hast dein Weihwasser schon benutzt",
+		"err_priest_select_first":             "Wähle zuerst ein Ziel für das Weihwasser",
+		"err_only_spellcaster":                "Nur die Zauberin kann zum Schweigen bringen",
+		"err_already_silenced":                "Du hast heute Nacht schon jemanden zum Schweigen gebracht",
+		"err_select_silence_first":            "Wähle zuerst einen Spieler aus",
+		"err_failed_record_silence":           "Schweigebann konnte nicht gespeichert werden",
+		"err_silenced_cannot_vote":            "Du wurdest zum Schweigen gebracht und kannst heute nicht abstimmen",
+		"err_silenced_cannot_speak":           "Du wurdest zum Schweigen gebracht und kannst heute nicht sprechen",
+		"err_idiot_cannot_vote":               "Als enttarnter Dorfdepp kannst du nicht mehr abstimmen",
+		"err_only_serial_killer":              "Nur der Serienmörder kann töten",
+		"err_serial_killer_done":              "Du hast dein Opfer für heute Nacht schon gewählt",
+		"err_serial_killer_select_first":      "Wähle zuerst ein Opfer aus",
+		"err_failed_record_serial_kill":       "Tötung konnte nicht gespeichert werden",
+		"err_only_piper":                      "Nur der Rattenfänger kann verzaubern",
+		"err_piper_already_charmed":           "Du hast deine Melodie heute Nacht schon gespielt",
+		"err_piper_choose_first":              "Wähle zuerst die Spieler zum Verzaubern aus",
+		"err_failed_record_charm":             "Verzauberung konnte nicht gespeichert werden",
+		"err_only_white_wolf":                 "Nur der Weiße Werwolf kann das Rudel verraten",
+		"err_white_wolf_not_tonight":          "Du kannst das Rudel nur jede zweite Nacht verraten",
+		"err_white_wolf_done":                 "Du hast deine Wahl heute Nacht schon getroffen",
+		"err_white_wolf_select_first":         "Wähle zuerst einen anderen Werwolf aus",
+		"err_failed_record_white_wolf":        "Deine Wahl konnte nicht gespeichert werden",
+		"err_only_fox":                        "Nur der Fuchs kann schnüffeln",
+		"err_fox_done":                        "Du hast heute Nacht schon geschnüffelt",
+		"err_fox_lost_power":                  "Deine Nase hat versagt",
+		"err_fox_select_first":                "Wähle zuerst einen Spieler aus",
+		"err_powers_lost":                     "Das Dorf hat seinen Ältesten gelyncht – deine Fähigkeit ist fort",
+		"err_only_scapegoat":                  "Nur der beschuldigte Sündenbock kann die Wähler von morgen bestimmen",
+		"err_scapegoat_inactive":              "Es gibt keine Wahl des Sündenbocks zu treffen",
+		"err_scapegoat_choose_first":          "Wähle zuerst mindestens einen Wähler aus",
+		"err_failed_record_scapegoat":         "Deine Wahl konnte nicht gespeichert werden",
+		"err_only_thief":                      "Nur der Dieb kann eine übrige Karte nehmen",
+		"err_thief_setup_only":                "Die übrigen Karten können nur vor der ersten Nacht genommen werden",
+		"err_thief_done":                      "Du hast deine Wahl bereits getroffen",
+		"err_thief_must_take_wolf":            "Beide übrigen Karten sind Werwölfe – du musst eine nehmen",
+		"err_failed_record_thief":             "Deine Wahl konnte nicht gespeichert werden",
+		"err_only_wild_child":                 "Nur das Wilde Kind kann ein Vorbild wählen",
+		"err_wild_child_night_1":              "Das Vorbild kann nur in der ersten Nacht gewählt werden",
+		"err_wild_child_done":                 "Du hast dein Vorbild bereits gewählt",
+		"err_wild_child_select_first":         "Wähle zuerst ein Vorbild aus",
+		"err_failed_record_wild_child":        "Dein Vorbild konnte nicht gespeichert werden",
+		"err_custom_role_name":                "Eine eigene Rolle braucht einen Namen (bis 32 Zeichen) und eine Beschreibung (bis 200)",
+		"err_custom_role_team":                "Wähle das Dorf oder die Werwölfe als Team",
+		"err_custom_role_ability":             "Unbekannte Nachtfähigkeit",
+		"err_custom_role_charges":             "Einsätze pro Spiel müssen zwischen 0 und 9 liegen",
+		"err_custom_role_wolf_night":          "Nur Dorfrollen können eine Nachtfähigkeit haben",
+		"err_custom_role_exists":              "Eine Rolle mit diesem Namen gibt es bereits",
+		"err_custom_role_failed":              "Die Rolle konnte nicht gespeichert werden",
+		"err_no_custom_ability":               "Deine Rolle hat keine Nachtfähigkeit",
+		"err_custom_ability_done":             "Du kannst deine Fähigkeit heute Nacht nicht mehr einsetzen",
+		"err_custom_select_first":             "Wähle zuerst einen Spieler",
+		"err_unknown_pack":                    "Dieses Paket kann nicht abgeschaltet werden",
+		"err_invalid_night_timer":             "Diese Nachtlänge gibt es nicht",
+		"err_invalid_day_timer":               "Diese Tageslänge gibt es nicht",
+		"err_invalid_role_reveal":             "Diese Einstellung gibt es nicht",
+		"err_chat_not_allowed":                "Du kannst gerade nicht in diesem Chat schreiben",
+		"err_chat_too_long":                   "Die Nachricht ist zu lang",
+		"err_chat_muted":                      "Die Spielleitung hat deinen Chat stummgeschaltet",
+		"err_chat_filtered":                   "Die Nachricht wurde vom Chat-Filter blockiert",
+		"chat_muted_notice":                   "Die Spielleitung hat deinen Chat stummgeschaltet",
+		"err_chat_failed":                     "Die Nachricht konnte nicht gesendet werden",
+		"err_failed_toggle_pack":              "Das Paket konnte nicht umgeschaltet werden",
+		"err_hunter_only_select":              "Nur der Jäger kann ein Ziel wählen",
+		"err_hunter_revenge_only_dead":        "Die Rache des Jägers ist nur möglich, wenn er ausgeschieden ist",
+		"err_already_shot":                    "Du hast deinen Racheschuss schon abgegeben",
+		"err_hunter_only_shoot":               "Nur der Jäger kann einen Racheschuss abgeben",
+		"err_select_shoot_first":              "Wähle zuerst einen Spieler zum Erschießen",
+		"err_cannot_shoot_dead":               "Du kannst keinen toten Spieler erschießen",
+		"err_failed_kill_target":              "Ziel konnte nicht getötet werden",
+		"err_failed_toggle_ai":                "KI-Funktionen konnten nicht umgeschaltet werden",
 
 		// Night survey labels
 		"survey_prefix":   "Nacht %v: %s — %s",
@@ -605,37 +1802,114 @@ var translations = map[string]map[string]string{
 		"survey_notes":    "Notizen",
 
 		// History bar and entries
-		"hist_heading":          "Verlauf",
-		"hist_wolf_vote":        "Nacht %s: %s stimmte dafür, %s zu töten",
-		"hist_wolf_vote_cub":    "Nacht %s: %s stimmte dafür, %s zu töten (Rache des Wolfsjungen)",
-		"hist_wolf_pass":        "Nacht %s: %s hat gepasst",
-		"hist_wolf_pass_2":      "Nacht %s: %s hat gepasst (zweites Opfer)",
-		"hist_found_dead":       "Nacht %s: %s (%s) wurde tot aufgefunden",
-		"hist_protected":        "Nacht %s: Du hast %s beschützt",
-		"hist_seer_wolf":        "Nacht %s: Du hast %s einen Werwolf gesehen.",
-		"hist_seer_not_wolf":    "Nacht %s: Du hast %s einen Dorfbewohner gesehen.",
-		"hist_witch_heal":       "Nacht %s: Du hast %s mit deinem Heiltrank gerettet",
-		"hist_witch_poison":     "Nacht %s: Du hast %s vergiftet",
-		"hist_witch_confirmed":  "Nacht %s: Hexe %s hat gehandelt",
-		"hist_cupid_lover":      "Nacht 1: Du bist in %s verliebt",
-		"hist_doppelganger":     "Nacht 1: Deine geheime Rolle: %s (kopiert von %s)",
-		"hist_heartbreak_night": "Nacht %s: %s starb aus Liebeskummer, nachdem %s getötet wurde",
-		"hist_heartbreak_day":   "Tag %s: %s starb aus Liebeskummer, nachdem %s getötet wurde",
-		"hist_day_vote":         "Tag %s: %s stimmte dafür, %s zu eliminieren",
-		"hist_day_pass":         "Tag %s: %s hat gepasst",
-		"hist_eliminated":       "Tag %s: %s (%s) wurde vom Dorf eliminiert",
-		"hist_hunter_shot":      "Tag %s: Jäger %s erschoss %s",
+		"hist_heading":                   "Verlauf",
+		"hist_wolf_vote":                 "Nacht %s: %s stimmte dafür, %s zu töten",
+		"hist_wolf_vote_cub":             "Nacht %s: %s stimmte dafür, %s zu töten (Rache des Wolfsjungen)",
+		"hist_wolf_pass":                 "Nacht %s: %s hat gepasst",
+		"hist_wolf_pass_2":               "Nacht %s: %s hat gepasst (zweites Opfer)",
+		"hist_alpha_bite":                "Nacht %s: Der Urwolf hat %s gebissen – willkommen im Rudel",
+		"hist_alpha_bitten":              "Nacht %s: Du wurdest gebissen und bist jetzt ein Werwolf",
+		"hist_cursed_turned":             "Nacht %s: Der Angriff weckte den Fluch von %s – der Verfluchte schließt sich dem Rudel an",
+		"hist_found_dead":                "Nacht %s: %s (%s) wurde tot aufgefunden",
+		"hist_found_dead_hidden":         "Nacht %s: %s wurde tot aufgefunden",
+		"hist_protected":                 "Nacht %s: Du hast %s beschützt",
+		"hist_night_timed_out":           "Nacht %s: Die Zeit lief ab, bevor du gehandelt hast",
+		"hist_night_ended_by_host":       "Nacht %s: Der Host hat die Nacht beendet, bevor du gehandelt hast",
+		"hist_night_afk":                 "Nacht %s: Du warst zu lange weg — deine Nachtaktion wurde übersprungen",
+		"hist_night_skipped":             "Nacht %s: Du hast deine Aktion ausgesetzt",
+		"hist_bodyguard_guarded":         "Nacht %s: Du hast über %s gewacht",
+		"hist_apprentice_promoted_night": "Nacht %s: Die Seherin ist fort – du erbst ihre Gabe",
+		"hist_apprentice_promoted_day":   "Tag %s: Die Seherin ist fort – du erbst ihre Gabe",
+		"hist_drunk_sobered":             "Nacht %s: Der Nebel lichtet sich – du bist in Wahrheit %s",
+		"hist_tough_guy_wounded":         "Nacht %s: Die Werwölfe haben dich angegriffen – du lebst, aber nicht über das Ende des Tages hinaus",
+		"hist_elder_survived":            "Nacht %s: Die Werwölfe haben dich angegriffen – du hast standgehalten, einen zweiten Angriff überlebst du aber nicht",
+		"hist_elder_lynched":             "Tag %s: Das Dorf hat seinen Ältesten %s gelyncht — alle Dorfbewohner verlieren ihre Fähigkeiten",
+		"hist_scapegoat_blamed":          "Tag %s: Die Abstimmung endete unentschieden — der Sündenbock %s bekam die Schuld und wurde eliminiert",
+		"hist_scapegoat_choice":          "Tag %s: Du hast bestimmt, dass morgen nur %s abstimmen dürfen",
+		"hist_thief_stole":               "Vor Nacht 1: Du hast die Karte %s gestohlen",
+		"hist_thief_kept":                "Vor Nacht 1: Du hast die Diebeskarte behalten",
+		"hist_wild_child_model":          "Nacht %s: Du hast %s als dein Vorbild gewählt",
+		"hist_wild_child_turned_night":   "Nacht %s: Dein Vorbild %s ist tot – du schließt dich den Werwölfen an",
+		"hist_wild_child_turned_day":     "Tag %s: Dein Vorbild %s ist tot – du schließt dich den Werwölfen an",
+		"hist_tough_guy_died":            "Tag %s: %s (Harter Kerl) erlag seinen Wunden",
+		"hist_seer_wolf":                 "Nacht %s: Du hast %s einen Werwolf gesehen.",
+		"hist_seer_not_wolf":             "Nacht %s: Du hast %s einen Dorfbewohner gesehen.",
+		"hist_sorceress_seer":            "Nacht %s: Du hast %s durchschaut — die Seherin",
+		"hist_sorceress_not_seer":        "Nacht %s: Du hast %s durchschaut — nicht die Seherin",
+		"hist_aura_seer_power":           "Nacht %s: Du hast die Aura von %s gelesen — eine besondere Fähigkeit",
+		"hist_aura_seer_no_power":        "Nacht %s: Du hast die Aura von %s gelesen — keine besondere Fähigkeit",
+		"hist_witch_heal":                "Nacht %s: Du hast %s mit deinem Heiltrank gerettet",
+		"hist_witch_poison":              "Nacht %s: Du hast %s vergiftet",
+		"hist_witch_confirmed":           "Nacht %s: Hexe %s hat gehandelt",
+		"hist_cupid_lover":               "Nacht 1: Du bist in %s verliebt",
+		"hist_masons_revealed":           "Nacht 1: Die Freimaurer sind %s",
+		"hist_doppelganger":              "Nacht 1: Deine geheime Rolle: %s (kopiert von %s)",
+		"hist_doppelganger_model":        "Nacht 1: Du hast %s gewählt – du übernimmst die Rolle, sobald er oder sie stirbt",
+		"hist_doppelganger_heir_night":   "Nacht %s: %s ist tot – du übernimmst die Rolle: %s",
+		"hist_doppelganger_heir_day":     "Tag %s: %s ist tot – du übernimmst die Rolle: %s",
+		"hist_heartbreak_night":          "Nacht %s: %s starb aus Liebeskummer, nachdem %s getötet wurde",
+		"hist_heartbreak_day":            "Tag %s: %s starb aus Liebeskummer, nachdem %s getötet wurde",
+		"hist_day_vote":                  "Tag %s: %s stimmte dafür, %s zu eliminieren",
+		"hist_day_pass":                  "Tag %s: %s hat gepasst",
+		"hist_day_nominate":              "Tag %s: %s hat %s nominiert",
+		"hist_day_second":                "Tag %s: %s hat die Nominierung von %s unterstützt",
+		"hist_day_open_vote":             "Tag %s: %s hat die Nominierungen geschlossen und die Abstimmung eröffnet",
+		"hist_day_trial":                 "Tag %s: %s wurde vor Gericht gestellt",
+		"hist_day_defense":               "Tag %s: Verteidigung von %s: %s",
+		"hist_day_defense_silent":        "Tag %s: %s verzichtete wortlos auf eine Verteidigung",
+		"hist_day_verdict_guilty":        "Tag %s: %s befand %s für schuldig",
+		"hist_day_verdict_innocent":      "Tag %s: %s befand %s für unschuldig",
+		"hist_day_acquitted":             "Tag %s: %s wurde freigesprochen",
+		"hist_day_runoff":                "Tag %s: %s hat gleich viele Stimmen wie andere und kommt in die Stichwahl",
+		"hist_day_runoff_vote":           "Tag %s: %s stimmte in der Stichwahl dafür, %s zu eliminieren",
+		"hist_mayor_vote":                "Vor Nacht 1: %s wählte %s zum Bürgermeister",
+		"hist_mayor_elected":             "Vor Nacht 1: %s wurde zum Bürgermeister gewählt",
+		"hist_last_words":                "Tag %s: Letzte Worte von %s: %s",
+		"hist_whisper":                   "Tag %s: %s flüsterte %s zu: %s",
+		"hist_last_words_silent":         "Tag %s: %s ging ohne letzte Worte",
+		"hist_eliminated":                "Tag %s: %s (%s) wurde vom Dorf eliminiert",
+		"hist_eliminated_hidden":         "Tag %s: %s wurde vom Dorf eliminiert",
+		"hist_day_timed_out":             "Tag %s: Die Zeit war um und die Abstimmung wurde geschlossen",
+		"hist_day_closed_by_host":        "Tag %s: Der Host hat die Abstimmung geschlossen",
+		"hist_player_substituted":        "%s hat den Platz von %s übernommen",
+		"hist_undo_day":                  "Tag %s: Der Host hat die letzte Auflösung zurückgenommen",
+		"hist_moderator_kill":            "Runde %s: Der Host hat %s getötet",
+		"hist_moderator_revive":          "Runde %s: Der Host hat %s wiederbelebt",
+		"hist_moderator_set_role":        "Runde %s: Der Host hat %s zu %s gemacht",
+		"hist_moderator_force_night":     "Nacht %s: Der Host hat den Tag anbrechen lassen",
+		"hist_moderator_force_day":       "Tag %s: Der Host hat die Abstimmung geschlossen",
+		"hist_undo_night":                "Nacht %s: Der Host hat die letzte Auflösung zurückgenommen; die Nacht beginnt von vorn",
+		"hist_prince_revealed":           "Tag %s: %s wurde verurteilt, gab sich aber als Prinz zu erkennen und wurde verschont",
+		"hist_village_idiot_revealed":    "Tag %s: %s wurde verurteilt, entpuppte sich aber als Dorfdepp – verschont, aber ab jetzt ohne Stimme",
+		"hist_priest_wolf":               "Tag %s: Priester %s bespritzte %s mit Weihwasser – ein Werwolf, der verbrannte",
+		"hist_priest_backfire":           "Tag %s: Priester %s bespritzte %s mit Weihwasser – unversehrt; der Priester starb",
+		"hist_spellcaster_silence":       "Nacht %s: Du hast %s für den kommenden Tag zum Schweigen gebracht",
+		"hist_serial_killer_kill":        "Nacht %s: Du hast %s als Opfer gewählt",
+		"hist_piper_charm":               "Nacht %s: Du hast %s und %s verzaubert",
+		"hist_piper_charm_one":           "Nacht %s: Du hast %s verzaubert",
+		"hist_piper_charmed":             "Nacht %s: Die Melodie des Rattenfängers hat dich verzaubert",
+		"hist_white_wolf_kill":           "Nacht %s: Du hast das Rudel verraten und %s als Opfer gewählt",
+		"hist_white_wolf_spared":         "Nacht %s: Du hast das Rudel heute Nacht verschont",
+		"hist_fox_wolf":                  "Nacht %s: Du hast bei %s geschnüffelt — ein Werwolf ist darunter",
+		"hist_fox_no_wolf":               "Nacht %s: Du hast bei %s geschnüffelt — kein Werwolf darunter, deine Nase hat versagt",
+		"hist_hunter_shot":               "Tag %s: Jäger %s erschoss %s",
 
 		// TTS narrator announcements (fixed game events)
-		"tts_game_begins":    "Das Spiel beginnt. Die Nacht legt sich über das Dorf.",
-		"tts_night_falls":    "Nacht %d legt sich über das Dorf.",
-		"tts_wolves_chosen":  "Die Werwölfe haben ihre Wahl getroffen. Stille legt sich über das Dorf.",
-		"tts_dawn_unscathed": "Der Morgen graut. Das Dorf hat die Nacht unversehrt überstanden.",
-		"tts_dawn_deaths":    "Der Morgen graut. Das Dorf erwacht und findet %s tot vor.",
-		"tts_join_and":       " und ",
-		"tts_villagers_win":  "Die Dorfbewohner haben triumphiert! Alle Werwölfe wurden ausgelöscht.",
-		"tts_werewolves_win": "Die Werwölfe haben gewonnen! Sie beherrschen nun das Dorf.",
-		"tts_lovers_win":     "Die Liebenden haben gewonnen. Sie sind die Letzten, für immer miteinander verbunden.",
+		"tts_game_begins":            "Das Spiel beginnt. Die Nacht legt sich über das Dorf.",
+		"tts_night_falls":            "Nacht %d legt sich über das Dorf.",
+		"tts_prince_revealed":        "Der Strick ist bereit – doch %s zeigt das königliche Siegel. Das Dorf kann seinen Prinzen nicht hängen.",
+		"tts_village_idiot_revealed": "Das Dorf zerrt %s zum Galgen – und bricht dann in Gelächter aus. Den Dorfdepp hängt niemand, aber hören wird auch keiner mehr auf ihn.",
+		"tts_wolves_chosen":          "Die Werwölfe haben ihre Wahl getroffen. Stille legt sich über das Dorf.",
+		"tts_dawn_unscathed":         "Der Morgen graut. Das Dorf hat die Nacht unversehrt überstanden.",
+		"tts_dawn_deaths":            "Der Morgen graut. Das Dorf erwacht und findet %s tot vor.",
+		"tts_join_and":               " und ",
+		"tts_villagers_win":          "Die Dorfbewohner haben triumphiert! Alle Werwölfe wurden ausgelöscht.",
+		"tts_werewolves_win":         "Die Werwölfe haben gewonnen! Sie beherrschen nun das Dorf.",
+		"tts_lovers_win":             "Die Liebenden haben gewonnen. Sie sind die Letzten, für immer miteinander verbunden.",
+		"tts_tanner_win":             "Das Dorf hat den Gerber gehängt — genau das hat er sich gewünscht. Der Gerber gewinnt allein.",
+		"tts_serial_killer_win":      "Stille legt sich über ein leeres Dorf. Der Serienmörder ist als Letzter übrig und gewinnt allein.",
+		"tts_piper_win":              "Die letzten Töne verklingen. Jede verbliebene Seele im Dorf folgt der Melodie des Rattenfängers – der Rattenfänger gewinnt allein.",
+		"tts_white_werewolf_win":     "Das letzte Heulen gehört dem Weißen Werwolf. Dorf und Rudel sind gefallen – der Weiße Werwolf gewinnt allein.",
 	},
 }
 
@@ -662,6 +1936,15 @@ func T(lang, key string, args ...interface{}) string {
 	return s
 }
 
+// TOr is T for keys that may have no translation, such as the names of roles created in
+// the lobby: a missing key yields fallback instead of the key.
+func TOr(lang, key, fallback string) string {
+	if s := T(lang, key); s != key {
+		return s
+	}
+	return fallback
+}
+
 // Falls back to Accept-Language. Returns "en" or "de".
 func getLangFromCookie(r *http.Request) string {
 	c, err := r.Cookie("lang")
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// The host can undo the most recent resolution, to fix a misclick in live play. Right before
+// something is resolved (a night begins, the day's votes are counted, a Hunter shoots) the
+// game saves a checkpoint: where the game_action log stood and the state the log cannot
+// rebuild (seats, lovers, charms, role models). Undoing trims the log back to the checkpoint
+// and restores that state; the phase is then played again from there.
+
+// checkpointTables are the per-game tables a checkpoint saves and restores whole.
+var checkpointTables = []string{"game_lovers", "game_charmed", "game_role_model", "cupid_selection"}
+
+// checkpointSeat is a seated player's state in a checkpoint.
+type checkpointSeat struct {
+	PlayerID       int64         `db:"player_id" json:"player_id"`
+	RoleID         int64         `db:"role_id" json:"role_id"`
+	IsAlive        bool          `db:"is_alive" json:"is_alive"`
+	OriginalRoleID sql.NullInt64 `db:"original_role_id" json:"original_role_id"`
+	DrunkRoleID    sql.NullInt64 `db:"drunk_role_id" json:"drunk_role_id"`
+}
+
+type checkpointState struct {
+	Seats  []checkpointSeat            `json:"seats"`
+	Tables map[string][]map[string]any `json:"tables"`
+	// the game's flags a resolution sets: the lynched Elder's and the Diseased's; nil in
+	// checkpoints saved before they were recorded, which leave them as they are
+	PowersDisabled  *bool `json:"powers_disabled,omitempty"`
+	WolvesSkipRound *int  `json:"wolves_skip_round,omitempty"`
+}
+
+type gameCheckpoint struct {
+	ID           int64          `db:"id"`
+	Kind         string         `db:"kind"` // "night", "vote" or "hunter"
+	Round        int            `db:"round"`
+	Status       string         `db:"status"`
+	Winner       sql.NullString `db:"winner"`
+	LastActionID int64          `db:"last_action_id"`
+	State        string         `db:"state"`
+}
+
+// saveCheckpoint records the game as it stands, before the resolution of the given kind.
+func (h *Hub) saveCheckpoint(gameID int64, kind string) {
+	var game struct {
+		Status          string         `db:"status"`
+		Round           int            `db:"round"`
+		Winner          sql.NullString `db:"winner"`
+		PowersDisabled  bool           `db:"powers_disabled"`
+		WolvesSkipRound int            `db:"wolves_skip_round"`
+	}
+	if err := h.db.Get(&game, "SELECT status, round, winner, powers_disabled, wolves_skip_round FROM game WHERE rowid = ?", gameID); err != nil {
+		h.logError("saveCheckpoint: get game", err)
+		return
+	}
+
+	state := checkpointState{
+		Tables:          map[string][]map[string]any{},
+		PowersDisabled:  &game.PowersDisabled,
+		WolvesSkipRound: &game.WolvesSkipRound,
+	}
+	if err := h.db.Select(&state.Seats, "SELECT player_id, role_id, is_alive, original_role_id, drunk_role_id FROM game_player WHERE game_id = ? AND is_observer = 0", gameID); err != nil {
+		h.logError("saveCheckpoint: select seats", err)
+		return
+	}
+	for _, table := range checkpointTables {
+		rows, err := h.db.Queryx("SELECT * FROM "+table+" WHERE game_id = ?", gameID)
+		if err != nil {
+			h.logError("saveCheckpoint: select "+table, err)
+			return
+		}
+		for rows.Next() {
+			row := map[string]any{}
+			if err := rows.MapScan(row); err != nil {
+				rows.Close()
+				h.logError("saveCheckpoint: scan "+table, err)
+				return
+			}
+			state.Tables[table] = append(state.Tables[table], row)
+		}
+		rows.Close()
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		h.logError("saveCheckpoint: marshal", err)
+		return
+	}
+
+	var lastActionID int64
+	h.db.Get(&lastActionID, "SELECT IFNULL(MAX(rowid), 0) FROM game_action WHERE game_id = ?", gameID)
+	if _, err := h.db.Exec(`INSERT INTO game_checkpoint (game_id, kind, round, status, winner, last_action_id, state) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		gameID, kind, game.Round, game.Status, game.Winner, lastActionID, string(data)); err != nil {
+		h.logError("saveCheckpoint: insert", err)
+	}
+}
+
+// lastResolution finds the checkpoint to go back to: the latest one whose resolution has
+// happened. A night checkpoint resolves only at dawn, so it is passed over during its night.
+func lastResolution(db *sqlx.DB, game *Game) (*gameCheckpoint, error) {
+	var checkpoints []gameCheckpoint
+	if err := db.Select(&checkpoints, `SELECT rowid as id, kind, round, status, winner, last_action_id, state FROM game_checkpoint WHERE game_id = ? ORDER BY rowid DESC LIMIT 2`, game.ID); err != nil {
+		return nil, err
+	}
+	for _, cp := range checkpoints {
+		if cp.Kind == "night" && game.Status == "night" && cp.Round == game.Round {
+			continue
+		}
+		return &cp, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+// restoreCheckpoint puts the game back to the checkpoint. A night checkpoint stays, as the
+// night starts over from it; any other is used up.
+func restoreCheckpoint(db *sqlx.DB, gameID int64, cp *gameCheckpoint) error {
+	var state checkpointState
+	if err := json.Unmarshal([]byte(cp.State), &state); err != nil {
+		return err
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec("DELETE FROM game_action WHERE game_id = ? AND rowid > ?", gameID, cp.LastActionID); err != nil {
+		return err
+	}
+	for _, s := range state.Seats {
+		if _, err := tx.Exec("UPDATE game_player SET role_id = ?, is_alive = ?, original_role_id = ?, drunk_role_id = ? WHERE game_id = ? AND player_id = ?",
+			s.RoleID, s.IsAlive, s.OriginalRoleID, s.DrunkRoleID, gameID, s.PlayerID); err != nil {
+			return err
+		}
+	}
+	for _, table := range checkpointTables {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE game_id = ?", gameID); err != nil {
+			return err
+		}
+		for _, row := range state.Tables[table] {
+			columns := make([]string, 0, len(row))
+			for column := range row {
+				columns = append(columns, column)
+			}
+			sort.Strings(columns)
+			args := make([]any, len(columns))
+			for i, column := range columns {
+				args[i] = row[column]
+			}
+			query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "))
+			if _, err := tx.Exec(query, args...); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := tx.Exec("UPDATE game SET status = ?, round = ?, winner = ? WHERE rowid = ?", cp.Status, cp.Round, cp.Winner, gameID); err != nil {
+		return err
+	}
+	if state.PowersDisabled != nil {
+		if _, err := tx.Exec("UPDATE game SET powers_disabled = ?, wolves_skip_round = ? WHERE rowid = ?", *state.PowersDisabled, *state.WolvesSkipRound, gameID); err != nil {
+			return err
+		}
+	}
+	// a game the undone resolution ended is running again: it has no end and no results yet
+	if cp.Status != "finished" {
+		if _, err := tx.Exec("UPDATE game SET finished_at = NULL WHERE rowid = ?", gameID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("DELETE FROM player_game_result WHERE game_id = ?", gameID); err != nil {
+			return err
+		}
+	}
+	used := "rowid >= ?"
+	if cp.Kind == "night" {
+		used = "rowid > ?"
+	}
+	if _, err := tx.Exec("DELETE FROM game_checkpoint WHERE game_id = ? AND "+used, gameID, cp.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// handleWSUndoResolution takes the game back to before its most recent resolution.
+func handleWSUndoResolution(client *Client) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSUndoResolution: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status == "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_not_running"))
+		return
+	}
+
+	cp, err := lastResolution(h.db, game)
+	if err != nil {
+		h.sendErrorToast(client.playerID, T(lang, "err_nothing_to_undo"))
+		return
+	}
+	if err := restoreCheckpoint(h.db, game.ID, cp); err != nil {
+		h.logError("handleWSUndoResolution: restoreCheckpoint", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_undo"))
+		return
+	}
+
+	phase, key := "day", "hist_undo_day"
+	desc := fmt.Sprintf("Day %d: The host took back the last resolution", cp.Round)
+	if cp.Status == "night" {
+		phase, key = "night", "hist_undo_night"
+		desc = fmt.Sprintf("Night %d: The host took back the last resolution; the night starts over", cp.Round)
+	}
+	h.db.Exec(`INSERT OR REPLACE INTO game_action (game_id, round, phase, actor_player_id, action_type, visibility, description, description_key, description_args) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID, cp.Round, phase, client.playerID, ActionResolutionUndone, VisibilityPublic, desc, key, histArgs(cp.Round))
+
+	switch cp.Status {
+	case "night":
+		h.startNightTimer(game.ID, cp.Round)
+	case "day":
+		h.startDayTimer(game.ID, cp.Round)
+	}
+	h.logf("'%s' undid the %s resolution of round %d", h.playerName(client.playerID), cp.Kind, cp.Round)
+	h.triggerBroadcast()
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Undo Tests
+// ============================================================================
+
+func TestHostUndoesElimination(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2", "V3"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	host, v1 := ids[0], ids[2]
+
+	target := strconv.FormatInt(v1, 10)
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: target})
+	}
+	ctx.sendWS(host, WSMessage{Action: "day_end_vote"})
+	if status, round, _ := ctx.gameState(); status != "night" || round != 2 || ctx.isPlayerAlive(v1) {
+		t.Fatalf("V1 should be eliminated and night 2 begin, got %q round %d", status, round)
+	}
+
+	ctx.sendWS(ids[1], WSMessage{Action: "undo_resolution"})
+	if ctx.isPlayerAlive(v1) {
+		t.Fatal("only the host should undo")
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "undo_resolution"})
+	if status, round, _ := ctx.gameState(); status != "day" || round != 1 {
+		t.Fatalf("the game should be back in day 1, got %q round %d", status, round)
+	}
+	if !ctx.isPlayerAlive(v1) {
+		t.Error("the eliminated player should be alive again")
+	}
+	if n := ctx.countActions(ActionDayApplyKill); n != 0 {
+		t.Errorf("the elimination should be gone from the log, got %d", n)
+	}
+	if n := ctx.countActions(ActionDaySelectKill); n != len(ids) {
+		t.Errorf("the votes before the resolution should stay, got %d", n)
+	}
+	if !strings.Contains(ctx.historyFor(v1), "The host took back the last resolution") {
+		t.Errorf("the table should be told about the undo, got %q", ctx.historyFor(v1))
+	}
+}
+
+func TestHostUndoesNight(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2", "V3"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager, RoleVillager})
+	host, wolf, v1, v2 := ids[0], ids[1], ids[2], ids[3]
+
+	// nobody is voted out, so night 2 begins with everyone alive
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_pass"})
+	}
+	ctx.sendWS(host, WSMessage{Action: "day_end_vote"})
+	if status, round, _ := ctx.gameState(); status != "night" || round != 2 {
+		t.Fatalf("night 2 should begin, got %q round %d", status, round)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "undo_resolution"})
+	if status, round, _ := ctx.gameState(); status != "day" || round != 1 {
+		t.Fatalf("an unresolved night should be passed over for the day's vote, got %q round %d", status, round)
+	}
+	ctx.sendWS(host, WSMessage{Action: "day_end_vote"})
+
+	ctx.sendWS(wolf, WSMessage{Action: "werewolf_vote", TargetPlayerID: strconv.FormatInt(v2, 10)})
+	game, _ := ctx.hub().getGame()
+	ctx.hub().expireNight(game.ID, 2)
+	if status, _, _ := ctx.gameState(); status != "day" || ctx.isPlayerAlive(v2) {
+		t.Fatalf("V2 should be killed and day 2 begin, got %q", status)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "undo_resolution"})
+	if status, round, _ := ctx.gameState(); status != "night" || round != 2 {
+		t.Fatalf("the game should be back at the start of night 2, got %q round %d", status, round)
+	}
+	if !ctx.isPlayerAlive(v2) {
+		t.Error("the night's victim should be alive again")
+	}
+	if n := ctx.countActions(ActionWerewolfSelectKill); n != 0 {
+		t.Errorf("the night should start over without the pack's vote, got %d", n)
+	}
+	if !ctx.isPlayerAlive(v1) {
+		t.Error("nobody else should be touched")
+	}
+}
+
+func TestUndoWithoutResolution(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1, []string{"Host", "Wolf", "V1"}, []string{RoleVillager, RoleWerewolf, RoleVillager})
+	ctx.sendWS(ids[0], WSMessage{Action: "undo_resolution"})
+	if status, round, _ := ctx.gameState(); status != "day" || round != 1 {
+		t.Errorf("with nothing resolved the game should stay put, got %q round %d", status, round)
+	}
+}
+
+func TestUndoingElderLynchRestoresVillagePowers(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "Elder", "Seer", "V1"},
+		[]string{RoleVillager, RoleWerewolf, RoleElder, RoleSeer, RoleVillager})
+	host, wolf, elder, seer := ids[0], ids[1], ids[2], ids[3]
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(elder, 10)})
+	}
+	ctx.sendWS(host, WSMessage{Action: "day_end_vote"})
+	game, _ := ctx.hub().getGame()
+	if !villagePowersDisabled(ctx.app.db, game.ID) {
+		t.Fatal("lynching the Elder should disable the village's powers")
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "undo_resolution"})
+	if villagePowersDisabled(ctx.app.db, game.ID) {
+		t.Fatal("undoing the lynch should give the village its powers back")
+	}
+
+	// this time nobody is lynched, and the Seer looks at the wolf that night
+	ctx.app.db.MustExec("DELETE FROM game_action WHERE action_type = ?", ActionDaySelectKill)
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_pass"})
+	}
+	ctx.sendWS(host, WSMessage{Action: "day_end_vote"})
+	if status, round, _ := ctx.gameState(); status != "night" || round != 2 {
+		t.Fatalf("night 2 should begin, got %q round %d", status, round)
+	}
+	ctx.sendWS(seer, WSMessage{Action: "seer_select", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	if n := ctx.countActions(ActionSeerSelectInvestigate); n != 1 {
+		t.Errorf("the Seer should have their power again, got %d selections", n)
+	}
+}
+
+func TestUndoingLastLynchReopensGame(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager})
+	host, wolf := ids[0], ids[1]
+
+	for _, id := range ids {
+		ctx.sendWS(id, WSMessage{Action: "day_vote", TargetPlayerID: strconv.FormatInt(wolf, 10)})
+	}
+	ctx.sendWS(host, WSMessage{Action: "day_end_vote"})
+	if status, _, winner := ctx.gameState(); status != "finished" || winner != "villagers" {
+		t.Fatalf("lynching the only wolf should end the game, got %q won by %q", status, winner)
+	}
+
+	ctx.sendWS(host, WSMessage{Action: "undo_resolution"})
+	if status, round, _ := ctx.gameState(); status != "day" || round != 1 {
+		t.Fatalf("the game should be back in day 1, got %q round %d", status, round)
+	}
+	var finished int
+	ctx.app.db.Get(&finished, "SELECT COUNT(*) FROM game WHERE finished_at IS NOT NULL")
+	if finished != 0 {
+		t.Error("a reopened game should have no end time")
+	}
+	var results int
+	ctx.app.db.Get(&results, "SELECT COUNT(*) FROM player_game_result")
+	if results != 0 {
+		t.Errorf("a reopened game should have no results yet, got %d", results)
+	}
+}
+
+func TestUndoNightInBrowser(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	browser, browserCleanup := newTestBrowserWithLogger(t, ctx.logger)
+	defer browserCleanup()
+
+	ctx.logger.Debug("=== Testing the host taking back the night ===")
+
+	players := startGameWithRoles(browser, ctx.baseURL, []string{"Host", "P2", "P3", "P4"},
+		RoleWerewolf, RoleVillager, RoleVillager, RoleVillager)
+	waitForNightPhaseAll(ctx, players)
+	werewolves, villagers := killFirstVillagerAtNight(ctx, players)
+	victim := villagers[0]
+	host := players[0]
+
+	host.acceptConfirms()
+	host.clickAndWait("#btn-undo-resolution")
+	waitForNightPhaseAll(ctx, players)
+	if !victim.isInNightPhase() {
+		ctx.logger.LogDB("FAIL: night not taken back")
+		t.Fatal("the game should be back at the start of the night")
+	}
+	if !victim.historyContains("The host took back the last resolution") {
+		t.Errorf("the table should be told about the undo, got %q", victim.getHistoryText())
+	}
+	if buttons := werewolves[0].getVoteButtons(); !slices.Contains(buttons, victim.Name) {
+		t.Errorf("the night's victim should be alive again, the pack can pick %v", buttons)
+	}
+
+	ctx.logger.Debug("=== Test passed ===")
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"io"
@@ -549,18 +550,43 @@ var sharedBrowser *rod.Browser
 
 // Role IDs in the database (based on insert order in initDB)
 const (
-	RoleVillager     = "1"
-	RoleWerewolf     = "2"
-	RoleSeer         = "3"
-	RoleDoctor       = "4"
-	RoleWitch        = "5"
-	RoleHunter       = "6"
-	RoleCupid        = "7"
-	RoleGuard        = "8"
-	RoleMason        = "9"
-	RoleWolfCub      = "10"
-	RoleDoppelganger = "11"
-	RoleJoker        = "12"
+	RoleVillager      = "1"
+	RoleWerewolf      = "2"
+	RoleSeer          = "3"
+	RoleDoctor        = "4"
+	RoleWitch         = "5"
+	RoleHunter        = "6"
+	RoleCupid         = "7"
+	RoleGuard         = "8"
+	RoleMason         = "9"
+	RoleWolfCub       = "10"
+	RoleDoppelganger  = "11"
+	RoleJoker         = "12"
+	RoleSorceress     = "13"
+	RoleAlphaWerewolf = "14"
+	RoleMinion        = "15"
+	RoleTanner        = "16"
+	RoleBodyguard     = "17"
+	RoleApprentice    = "18"
+	RoleAuraSeer      = "19"
+	RoleLycan         = "20"
+	RolePrince        = "21"
+	RoleMayor         = "22"
+	RolePriest        = "23"
+	RoleSpellcaster   = "24"
+	RoleDrunk         = "25"
+	RoleToughGuy      = "26"
+	RoleDiseased      = "27"
+	RoleCursed        = "28"
+	RoleSerialKiller  = "29"
+	RolePiper         = "30"
+	RoleWhiteWolf     = "31"
+	RoleFox           = "32"
+	RoleElder         = "33"
+	RoleScapegoat     = "34"
+	RoleThief         = "35"
+	RoleWildChild     = "36"
+	RoleVillageIdiot  = "37"
 )
 
 func getFreePort() (int, error) {
@@ -630,6 +656,7 @@ func newTestContext(t *testing.T) *TestContext {
 		},
 		"buildVersion": func() string { return buildVersion },
 		"T":            T,
+		"TOr":          TOr,
 	})
 	testTemplates, tmplErr := template.New("").Funcs(funcMap).ParseFS(templateFS, "templates/*.html")
 	if tmplErr != nil {
@@ -671,9 +698,11 @@ func newTestContext(t *testing.T) *TestContext {
 	mux.HandleFunc("/player-image/{imageID}", app.handlePlayerImage)
 	mux.Handle("/static/", http.FileServer(http.FS(staticFS)))
 
+	// like a server behind a proxy on the same host
+	trustedProxies, _ := parseTrustedProxies(defaultTrustedProxies)
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", port),
-		Handler: mux,
+		Handler: withProxyHeaders(mux, trustedProxies),
 	}
 
 	go server.ListenAndServe()
@@ -718,6 +747,103 @@ func newTestContext(t *testing.T) *TestContext {
 	return ctx
 }
 
+// ============================================================================
+// Direct Game Helpers (no browser)
+// ============================================================================
+
+// Every role has a browser test for its happy path. The helpers below are for the edge
+// cases a browser game cannot set up: roles are dealt at random there, so a test that
+// needs one exact deal, phase and round seeds the game instead and drives the WebSocket
+// handlers directly.
+
+// hub returns the test hub that owns the "test-game" game.
+func (ctx *TestContext) hub() *Hub {
+	return ctx.app.getOrCreateHub("test-game")
+}
+
+// seedSecretCodeHash is the stored form of "x", the secret code of every seeded player.
+var seedSecretCodeHash = sync.OnceValue(func() string {
+	hash, _ := hashSecretCode("x")
+	return hash
+})
+
+// seedGame puts "test-game" straight into the given phase and round with one player per
+// role, skipping the lobby. Player IDs are returned in the same order as names; each
+// player's secret code is "x".
+func (ctx *TestContext) seedGame(status string, round int, names []string, roleIDs []string) []int64 {
+	ctx.t.Helper()
+	db := ctx.app.db
+	game, err := getOrCreateGameByName(db, "test-game")
+	if err != nil {
+		ctx.t.Fatalf("seedGame: get game: %v", err)
+	}
+	db.MustExec("UPDATE game SET status = ?, round = ? WHERE rowid = ?", status, round, game.ID)
+
+	ids := make([]int64, len(names))
+	for i, name := range names {
+		res, err := db.Exec("INSERT INTO player (name, secret_code) VALUES (?, ?)", name, seedSecretCodeHash())
+		if err != nil {
+			ctx.t.Fatalf("seedGame: insert player %s: %v", name, err)
+		}
+		ids[i], _ = res.LastInsertId()
+		db.MustExec("INSERT INTO game_player (game_id, player_id, role_id) VALUES (?, ?, ?)", game.ID, ids[i], roleIDs[i])
+	}
+	return ids
+}
+
+// sendWS routes a WS message from playerID through handleWSMessage, as if the browser sent it.
+func (ctx *TestContext) sendWS(playerID int64, msg WSMessage) {
+	ctx.t.Helper()
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		ctx.t.Fatalf("sendWS: marshal: %v", err)
+	}
+	handleWSMessage(&Client{hub: ctx.hub(), playerID: playerID}, raw)
+}
+
+// gameState returns the current status, round and winner ("" while running) of "test-game".
+func (ctx *TestContext) gameState() (status string, round int, winner string) {
+	ctx.t.Helper()
+	game, err := getOrCreateGameByName(ctx.app.db, "test-game")
+	if err != nil {
+		ctx.t.Fatalf("gameState: %v", err)
+	}
+	if game.Winner != nil {
+		winner = *game.Winner
+	}
+	return game.Status, game.Round, winner
+}
+
+// isPlayerAlive reports whether playerID is alive in "test-game".
+func (ctx *TestContext) isPlayerAlive(playerID int64) bool {
+	ctx.t.Helper()
+	var alive bool
+	ctx.app.db.Get(&alive, `SELECT gp.is_alive FROM game_player gp JOIN game g ON g.rowid = gp.game_id WHERE g.name = 'test-game' AND gp.player_id = ?`, playerID)
+	return alive
+}
+
+// countActions counts game_action rows of the given type in "test-game".
+func (ctx *TestContext) countActions(actionType string) int {
+	ctx.t.Helper()
+	var n int
+	ctx.app.db.Get(&n, `SELECT COUNT(*) FROM game_action ga JOIN game g ON g.rowid = ga.game_id WHERE g.name = 'test-game' AND ga.action_type = ?`, actionType)
+	return n
+}
+
+// historyFor returns the history entries playerID would see, joined by newlines.
+func (ctx *TestContext) historyFor(playerID int64) string {
+	ctx.t.Helper()
+	game, err := getOrCreateGameByName(ctx.app.db, "test-game")
+	if err != nil {
+		ctx.t.Fatalf("historyFor: %v", err)
+	}
+	var lines []string
+	for _, e := range buildHistoryEntries(ctx.app.db, playerID, game, "en") {
+		lines = append(lines, e.Description)
+	}
+	return strings.Join(lines, "\n")
+}
+
 // startTestServer starts a test server and returns the base URL and a cleanup function.
 // The cleanup function MUST be called at the end of each test iteration to properly
 // close the server and database before the next iteration starts.
@@ -895,11 +1021,18 @@ func (tb *TestBrowser) signupPlayerInGame(baseURL, name, gameName string) *TestP
 	// Fill form and submit; sidebar is rendered inline so it's present as soon as /game loads.
 	player.submitAuthForm(name)
 
-	// Sidebar is inline in game.html response — #secret-code-display is in the DOM immediately.
+	// The new account's code is shown once, inline in the game.html response: note it down
+	// and close the notice, which would cover the page.
 	p := page.Timeout(browserTimeout)
-	if _, err := p.Element("#secret-code-display"); err != nil {
+	codeEl, err := p.Element("#secret-code-display")
+	if err != nil {
 		tb.t.Fatalf("signup %q: #secret-code-display not found: %v", name, err)
 	}
+	code, _ := codeEl.Text()
+	player.SecretCode = strings.TrimSpace(code)
+	if el, err := p.Element("#secret-code-notice-close"); err == nil {
+		el.Click(proto.InputMouseButtonLeft, 1)
+	}
 
 	// Wait until this player appears in the player list. The player list is updated via
 	// WebSocket OOB swap from broadcastGameUpdate (triggered by addPlayerToLobby after
@@ -920,6 +1053,32 @@ func (tb *TestBrowser) signupPlayerInGame(baseURL, name, gameName string) *TestP
 	return player
 }
 
+// navigate opens target in the player's page and waits for it to load.
+func (tp *TestPlayer) navigate(target string) {
+	if tp.logger != nil {
+		tp.logger.Debug("[%s] Navigating to %s", tp.Name, target)
+	}
+	if err := tp.p().Navigate(target); err != nil {
+		tp.t.Fatalf("[%s] navigate %s: %v", tp.Name, target, err)
+	}
+	if err := tp.p().WaitLoad(); err != nil {
+		tp.t.Fatalf("[%s] navigate %s: page did not load: %v", tp.Name, target, err)
+	}
+}
+
+// followLink clicks a link, or a button that redirects, and waits for the page it leads to.
+func (tp *TestPlayer) followLink(selector string) {
+	el, err := tp.p().Element(selector)
+	if err != nil {
+		tp.t.Fatalf("[%s] followLink: %s not found: %v", tp.Name, selector, err)
+	}
+	wait := tp.p().WaitNavigation(proto.PageLifecycleEventNameLoad)
+	if err := el.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		tp.t.Fatalf("[%s] followLink %s: %v", tp.Name, selector, err)
+	}
+	wait()
+}
+
 // reload reloads the page and waits for it to load
 func (tp *TestPlayer) reload() {
 	if err := tp.p().Reload(); err == nil {
@@ -985,6 +1144,14 @@ func (tp *TestPlayer) doWithWSWaitTimeout(action func(), timeout time.Duration)
 	tp.logHTML("after doWithWSWait")
 }
 
+// acceptConfirms answers the page's confirm() prompts with OK, for the host's buttons that
+// ask first (abort, undo, force phase); rod would otherwise wait on the dialog.
+func (tp *TestPlayer) acceptConfirms() {
+	if _, err := tp.p().Eval(`() => { window.confirm = () => true }`); err != nil {
+		tp.t.Fatalf("[%s] acceptConfirms: %v", tp.Name, err)
+	}
+}
+
 // clickAndWait clicks an element and waits for WebSocket response
 // This performs the entire operation atomically in JavaScript to avoid stale element issues
 func (tp *TestPlayer) clickAndWait(selector string) {
@@ -1063,6 +1230,47 @@ func (tp *TestPlayer) clickElementAndWait(element *rod.Element) {
 	})
 }
 
+// submitFormWithValues fills in a ws-send form's fields by name, submits it and waits for
+// the WebSocket response. It goes through JS because several lobby forms sit in collapsed
+// <details>; a checkbox is ticked by the value "on".
+func (tp *TestPlayer) submitFormWithValues(formID string, values map[string]string) {
+	if tp.logger != nil {
+		tp.logger.Debug("[%s] Submitting #%s with %v", tp.Name, formID, values)
+	}
+	tp.doWithWSWait(func() {
+		_, err := tp.p().Eval(`(formID, values) => {
+			const form = document.getElementById(formID);
+			if (!form) throw new Error('form not found: ' + formID);
+			for (const [name, value] of Object.entries(values)) {
+				const field = form.elements[name];
+				if (!field) throw new Error('field not found: ' + name);
+				if (field.type === 'checkbox') field.checked = value === 'on';
+				else field.value = value;
+			}
+			form.requestSubmit();
+		}`, formID, values)
+		if err != nil {
+			tp.t.Fatalf("[%s] submitFormWithValues #%s: %v", tp.Name, formID, err)
+		}
+	})
+}
+
+// optionValue returns the value of the option shown as text in a select, for
+// submitFormWithValues; player pickers show names but send ids.
+func (tp *TestPlayer) optionValue(selector, text string) string {
+	result, err := tp.p().Eval(`(selector, text) => {
+		const select = document.querySelector(selector);
+		if (!select) throw new Error('select not found: ' + selector);
+		const option = Array.from(select.options).find(o => o.textContent.trim() === text);
+		if (!option) throw new Error('no option ' + text + ' in ' + selector);
+		return option.value;
+	}`, selector, text)
+	if err != nil {
+		tp.t.Fatalf("[%s] optionValue %s %q: %v", tp.Name, selector, text, err)
+	}
+	return result.Value.String()
+}
+
 // waitUntilCondition waits for a DOM condition to become true by listening to WebSocket messages
 // This handles cases where multiple WebSocket messages arrive (e.g., vote confirmation + phase transition)
 func (tp *TestPlayer) waitUntilCondition(checkJS string, description string) error {
@@ -1195,18 +1403,12 @@ func (tp *TestPlayer) submitAuthForm(name string) {
 	wait()
 }
 
-// getSecretCode reads the secret code from the game page
+// getSecretCode returns the secret code the page showed once after the signup
 func (tp *TestPlayer) getSecretCode() string {
-	el, err := tp.p().Element("code")
-	if err != nil {
-		return ""
-	}
-	text, _ := el.Text()
-	code := strings.TrimSpace(text)
 	if tp.logger != nil {
-		tp.logger.Debug("[%s] Got secret code: %s", tp.Name, code)
+		tp.logger.Debug("[%s] Got secret code: %s", tp.Name, tp.SecretCode)
 	}
-	return code
+	return tp.SecretCode
 }
 
 // getPlayerList returns the player names in the sidebar player list, newline-separated.
@@ -1366,8 +1568,8 @@ func (tb *TestBrowser) loginPlayerInGame(baseURL, name, secretCode, gameName str
 	}
 
 	// Wait for sidebar to load — confirms page loaded + HTMX sidebar request completed
-	if _, err := p.Element("#secret-code-display"); err != nil {
-		tb.t.Fatalf("login %q: #secret-code-display not found: %v", name, err)
+	if _, err := p.Element("#player-id"); err != nil {
+		tb.t.Fatalf("login %q: #player-id not found: %v", name, err)
 	}
 
 	// Wait until this player appears in the player list (WS registration confirmed).
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// The host can give a game a webhook URL in the lobby. The game then POSTs a JSON event to it
+// when it starts, when a player dies and when it is over, for integrations like posting the
+// result to a group chat. Deaths happen in many places, so the events are not sent from them:
+// every broadcast compares the game with what the hub saw at the previous one
+// (notifyWebhook). A dead player's role is only sent as far as the game's role reveal goes.
+// The URL is only shown to the host, it often carries a token. Since any host can set one,
+// the server only posts to public addresses: it must not be a way to reach the machines
+// next to it (webhookDialControl).
+
+const (
+	WebhookGameStarted  = "game_started"
+	WebhookPlayerDied   = "player_died"
+	WebhookGameFinished = "game_finished"
+)
+
+const webhookTimeout = 5 * time.Second
+
+type WebhookEvent struct {
+	Event  string `json:"event"`
+	Game   string `json:"game"`
+	Round  int    `json:"round"`
+	Player string `json:"player,omitempty"`
+	Role   string `json:"role,omitempty"`
+	Winner string `json:"winner,omitempty"`
+	Time   string `json:"time"`
+}
+
+// webhookState is what the hub saw of its game at the last broadcast.
+type webhookState struct {
+	gameID int64
+	status string
+	alive  map[int64]bool
+}
+
+func webhookURL(db *sqlx.DB, gameID int64) string {
+	var u string
+	db.Get(&u, "SELECT webhook_url FROM game WHERE rowid = ?", gameID)
+	return u
+}
+
+// validWebhookURL accepts an absolute http(s) URL, or nothing to switch the webhook off. A
+// host given as an address must be a public one; a name is checked when it is dialled.
+func validWebhookURL(raw string) bool {
+	if raw == "" {
+		return true
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return false
+	}
+	if ip, err := netip.ParseAddr(u.Hostname()); err == nil {
+		return publicAddr(ip)
+	}
+	return !strings.EqualFold(u.Hostname(), "localhost")
+}
+
+// publicAddr reports whether ip is outside the server's own networks: not loopback,
+// private, link-local or unspecified.
+func publicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsUnspecified()
+}
+
+// webhookDialControl refuses a webhook connection to an address that is not public. It
+// runs on the address actually dialled, after the name was resolved and for every
+// redirect, so a public name that resolves to an internal address is refused too.
+func webhookDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !publicAddr(ip) {
+		return fmt.Errorf("webhook address %s is not public", ip)
+	}
+	return nil
+}
+
+// webhookClient is what every hub posts its webhook events with. It ignores the proxy
+// settings, a proxy would dial the address in its place.
+var webhookClient = &http.Client{
+	Timeout: webhookTimeout,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: webhookTimeout, Control: webhookDialControl}).DialContext,
+		TLSHandshakeTimeout: webhookTimeout,
+	},
+}
+
+// notifyWebhook sends the events since the last broadcast. The first look at a game only
+// records it, so a restarted server does not announce what happened before.
+func (h *Hub) notifyWebhook(game *Game, players []Player) {
+	seen := webhookState{gameID: game.ID, status: game.Status, alive: make(map[int64]bool, len(players))}
+	for _, p := range players {
+		seen.alive[p.PlayerID] = p.IsAlive
+	}
+	prev := h.webhookSeen
+	h.webhookSeen = &seen
+	if prev == nil || prev.gameID != game.ID {
+		return
+	}
+	target := webhookURL(h.db, game.ID)
+	if target == "" {
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if prev.status == "lobby" && game.Status != "lobby" {
+		h.postWebhook(target, WebhookEvent{Event: WebhookGameStarted, Game: game.Name, Round: game.Round, Time: now})
+	}
+	if game.Status != "lobby" {
+		reveal := roleReveal(h.db, game.ID)
+		for _, p := range players {
+			if p.IsAlive || !prev.alive[p.PlayerID] {
+				continue
+			}
+			e := WebhookEvent{Event: WebhookPlayerDied, Game: game.Name, Round: game.Round, Player: p.Name, Time: now}
+			switch {
+			case reveal == RevealFull, game.Status == "finished":
+				e.Role = p.RoleName
+			case reveal == RevealTeam:
+				e.Role = teamCardName(p.RoleName, p.Team)
+			}
+			h.postWebhook(target, e)
+		}
+	}
+	if prev.status != "finished" && game.Status == "finished" && game.Winner != nil {
+		h.postWebhook(target, WebhookEvent{Event: WebhookGameFinished, Game: game.Name, Round: game.Round, Winner: *game.Winner, Time: now})
+	}
+}
+
+// postWebhook sends one event in the background; a failed delivery is only logged.
+func (h *Hub) postWebhook(target string, e WebhookEvent) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		h.logError("postWebhook: json.Marshal", err)
+		return
+	}
+	go func() {
+		resp, err := h.webhookClient.Post(target, "application/json", bytes.NewReader(body))
+		if err != nil {
+			h.logf("Webhook %s for game '%s' failed: %v", e.Event, e.Game, err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			h.logf("Webhook %s for game '%s' got status %d", e.Event, e.Game, resp.StatusCode)
+		}
+	}()
+}
+
+// handleWSSetWebhook sets or clears the game's webhook URL in the lobby.
+func handleWSSetWebhook(client *Client, msg WSMessage) {
+	h := client.hub
+	lang := h.getPlayerLang(client.playerID)
+	game, err := h.getGame()
+	if err != nil {
+		h.logError("handleWSSetWebhook: getOrCreateCurrentGame", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_get_game"))
+		return
+	}
+	if game.Status != "lobby" {
+		h.sendErrorToast(client.playerID, T(lang, "err_game_already_started"))
+		return
+	}
+	target := strings.TrimSpace(msg.WebhookURL)
+	if !validWebhookURL(target) {
+		h.sendErrorToast(client.playerID, T(lang, "err_invalid_webhook"))
+		return
+	}
+	if _, err := h.db.Exec("UPDATE game SET webhook_url = ? WHERE rowid = ?", target, game.ID); err != nil {
+		h.logError("handleWSSetWebhook: update", err)
+		h.sendErrorToast(client.playerID, T(lang, "err_failed_set_webhook"))
+		return
+	}
+	h.logf("Webhook URL updated for game %d", game.ID)
+	h.triggerBroadcast()
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Webhook Tests
+// ============================================================================
+
+func TestWebhookIsSetByTheHost(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("lobby", 0, []string{"Host", "P1"}, []string{RoleVillager, RoleVillager})
+	host, p1 := ids[0], ids[1]
+	game, _ := ctx.hub().getGame()
+
+	ctx.sendWS(p1, WSMessage{Action: "set_webhook", WebhookURL: "https://example.com/hook"})
+	if u := webhookURL(ctx.app.db, game.ID); u != "" {
+		t.Fatalf("only the host should set the webhook, got %q", u)
+	}
+	ctx.sendWS(host, WSMessage{Action: "set_webhook", WebhookURL: "ftp://example.com/hook"})
+	if u := webhookURL(ctx.app.db, game.ID); u != "" {
+		t.Fatalf("only http(s) URLs should be taken, got %q", u)
+	}
+	ctx.sendWS(host, WSMessage{Action: "set_webhook", WebhookURL: "http://127.0.0.1:6379/"})
+	if u := webhookURL(ctx.app.db, game.ID); u != "" {
+		t.Fatalf("an internal address should not be taken, got %q", u)
+	}
+	ctx.sendWS(host, WSMessage{Action: "set_webhook", WebhookURL: " https://example.com/hook "})
+	if u := webhookURL(ctx.app.db, game.ID); u != "https://example.com/hook" {
+		t.Fatalf("the host should set the webhook, got %q", u)
+	}
+
+	buf, err := getGameComponent(ctx.hub(), host, game, "en")
+	if err != nil || !strings.Contains(buf.String(), "https://example.com/hook") {
+		t.Errorf("the host should see the webhook URL (err: %v)", err)
+	}
+	buf, err = getGameComponent(ctx.hub(), p1, game, "en")
+	if err != nil || strings.Contains(buf.String(), "https://example.com/hook") {
+		t.Errorf("the URL should be kept from the other players (err: %v)", err)
+	}
+}
+
+func TestWebhookPostsGameEvents(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	events := make(chan WebhookEvent, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e WebhookEvent
+		json.NewDecoder(r.Body).Decode(&e)
+		events <- e
+	}))
+	defer receiver.Close()
+	next := func() WebhookEvent {
+		t.Helper()
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("the webhook should have been called")
+			return WebhookEvent{}
+		}
+	}
+
+	ids := ctx.seedGame("lobby", 0,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	wolf, v1 := ids[1], ids[2]
+	game, _ := ctx.hub().getGame()
+	ctx.app.db.MustExec("UPDATE game SET webhook_url = ? WHERE rowid = ?", receiver.URL, game.ID)
+
+	// a hub of its own, without the broadcast worker, so the test drives every look at the game
+	h := newHub(ctx.app.db, ctx.app.templates, nil, nil, "test-game")
+	h.webhookClient = receiver.Client() // the receiver listens on loopback, which webhookClient refuses
+	look := func() {
+		game, _ := h.getGame()
+		players, _ := getPlayersByGameId(ctx.app.db, game.ID)
+		h.notifyWebhook(game, players)
+	}
+
+	look()
+	ctx.app.db.MustExec("UPDATE game SET status = 'night', round = 1 WHERE rowid = ?", game.ID)
+	look()
+	if e := next(); e.Event != WebhookGameStarted || e.Game != "test-game" {
+		t.Fatalf("the start should be posted, got %+v", e)
+	}
+
+	ctx.app.db.MustExec("UPDATE game SET role_reveal = ? WHERE rowid = ?", RevealNone, game.ID)
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, v1)
+	look()
+	if e := next(); e.Event != WebhookPlayerDied || e.Player != "V1" || e.Role != "" {
+		t.Fatalf("V1's death should be posted without the hidden role, got %+v", e)
+	}
+	look()
+	select {
+	case e := <-events:
+		t.Fatalf("nothing new happened, got %+v", e)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	ctx.app.db.MustExec("UPDATE game_player SET is_alive = 0 WHERE game_id = ? AND player_id = ?", game.ID, wolf)
+	ctx.app.db.MustExec("UPDATE game SET status = 'finished', winner = 'villagers' WHERE rowid = ?", game.ID)
+	look()
+	got := map[string]WebhookEvent{}
+	for i := 0; i < 2; i++ {
+		e := next()
+		got[e.Event] = e
+	}
+	if e := got[WebhookPlayerDied]; e.Player != "Wolf" || e.Role != "Werewolf" {
+		t.Errorf("the wolf's death should be posted with the role once the game is over, got %+v", e)
+	}
+	if e := got[WebhookGameFinished]; e.Winner != "villagers" {
+		t.Errorf("the end should be posted with the winner, got %+v", e)
+	}
+}
+
+func TestWebhookOnlyDialsPublicAddresses(t *testing.T) {
+	t.Parallel()
+
+	for _, raw := range []string{"http://localhost/hook", "http://127.0.0.1/hook", "http://10.0.0.5/hook",
+		"http://192.168.1.1/hook", "http://169.254.169.254/latest", "http://[::1]/hook", "http://0.0.0.0/hook"} {
+		if validWebhookURL(raw) {
+			t.Errorf("%s should not be taken as a webhook", raw)
+		}
+	}
+	if !validWebhookURL("https://hooks.example.com/abc") || !validWebhookURL("https://93.184.215.14/hook") {
+		t.Error("a public URL should be taken")
+	}
+
+	for _, address := range []string{"127.0.0.1:80", "10.1.2.3:443", "172.16.0.1:80", "192.168.0.10:80",
+		"169.254.169.254:80", "[::1]:80", "[fe80::1]:80", "[fd00::1]:80", "[::ffff:127.0.0.1]:80", "0.0.0.0:80"} {
+		if err := webhookDialControl("tcp", address, nil); err == nil {
+			t.Errorf("dialling %s should be refused", address)
+		}
+	}
+	if err := webhookDialControl("tcp", "93.184.215.14:443", nil); err != nil {
+		t.Errorf("dialling a public address should be allowed: %v", err)
+	}
+
+	// a name is only checked once it is resolved
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the webhook should not reach a loopback receiver")
+	}))
+	defer receiver.Close()
+	resp, err := webhookClient.Post(strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1), "application/json", strings.NewReader("{}"))
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("posting to a name that resolves to loopback should fail")
+	}
+}
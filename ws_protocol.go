@@ -0,0 +1,397 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// The WebSocket takes messages in two forms. The pages send what htmx makes of a form: a
+// flat object with "action" and the form's fields as strings, read straight into a
+// WSMessage. Other clients send a versioned envelope, {"type": "seer_select", "version": 1,
+// "payload": {"target_player_id": 4}}, whose payload is decoded into the action's typed
+// payload struct (wsPayloads) and checked before it becomes the WSMessage the handlers read.
+// A message that cannot be taken is answered, not dropped: an envelope with a WSError
+// payload for envelope clients, an error toast for the pages.
+
+const wsProtocolVersion = 1
+
+// WSEnvelope is a versioned WebSocket message; Type is the action, or "error" in a reply.
+type WSEnvelope struct {
+	Type    string          `json:"type"`
+	Version int             `json:"version"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// WSError is the payload of an "error" envelope.
+type WSError struct {
+	Code    string `json:"code"` // invalid_message, unsupported_version, unknown_action, invalid_payload
+	Message string `json:"message"`
+}
+
+// wsDecodeError is why a message was not taken; Key is the translation of the reason.
+type wsDecodeError struct {
+	Code string
+	Key  string
+}
+
+func (e *wsDecodeError) Error() string { return e.Code }
+
+// wsPayload is an action's typed payload. fill copies it into the message the handlers
+// read and reports false when a field the action needs is missing.
+type wsPayload interface {
+	fill(msg *WSMessage) bool
+}
+
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+type WSEmptyPayload struct{}
+
+func (p *WSEmptyPayload) fill(msg *WSMessage) bool { return true }
+
+type WSTargetPayload struct {
+	TargetPlayerID int64 `json:"target_player_id"`
+}
+
+func (p *WSTargetPayload) fill(msg *WSMessage) bool {
+	msg.TargetPlayerID = formatID(p.TargetPlayerID)
+	return p.TargetPlayerID > 0
+}
+
+// WSVerdictPayload judges the accused: guilty names them, innocent leaves the target out.
+type WSVerdictPayload struct {
+	TargetPlayerID int64 `json:"target_player_id,omitempty"`
+}
+
+func (p *WSVerdictPayload) fill(msg *WSMessage) bool {
+	msg.TargetPlayerID = formatID(p.TargetPlayerID)
+	return p.TargetPlayerID >= 0
+}
+
+type WSRoleCountPayload struct {
+	RoleID int64 `json:"role_id"`
+	Delta  int   `json:"delta"` // 1 or -1
+}
+
+func (p *WSRoleCountPayload) fill(msg *WSMessage) bool {
+	msg.RoleID, msg.Delta = formatID(p.RoleID), strconv.Itoa(p.Delta)
+	return p.RoleID > 0 && (p.Delta == 1 || p.Delta == -1)
+}
+
+type WSCreateRolePayload struct {
+	RoleName     string `json:"role_name"`
+	RoleDesc     string `json:"role_desc"`
+	RoleTeam     string `json:"role_team"`
+	NightAbility string `json:"night_ability,omitempty"`
+	Charges      int    `json:"charges,omitempty"`
+	Shield       bool   `json:"shield,omitempty"`
+}
+
+func (p *WSCreateRolePayload) fill(msg *WSMessage) bool {
+	msg.RoleName, msg.RoleDesc, msg.RoleTeam, msg.NightAbility = p.RoleName, p.RoleDesc, p.RoleTeam, p.NightAbility
+	if p.Charges != 0 {
+		msg.Charges = strconv.Itoa(p.Charges)
+	}
+	if p.Shield {
+		msg.Shield = "on"
+	}
+	return p.RoleName != "" && p.RoleDesc != "" && p.RoleTeam != ""
+}
+
+type WSPackPayload struct {
+	Pack string `json:"pack"`
+}
+
+func (p *WSPackPayload) fill(msg *WSMessage) bool {
+	msg.Pack = p.Pack
+	return p.Pack != ""
+}
+
+type WSPresetPayload struct {
+	Preset string `json:"preset"`
+}
+
+func (p *WSPresetPayload) fill(msg *WSMessage) bool {
+	msg.Preset = p.Preset
+	return p.Preset != ""
+}
+
+type WSWebhookPayload struct {
+	WebhookURL string `json:"webhook_url,omitempty"` // empty removes the webhook
+}
+
+func (p *WSWebhookPayload) fill(msg *WSMessage) bool {
+	msg.WebhookURL = p.WebhookURL
+	return true
+}
+
+type WSWhisperPayload struct {
+	TargetPlayerID int64  `json:"target_player_id"`
+	Message        string `json:"message"`
+}
+
+func (p *WSWhisperPayload) fill(msg *WSMessage) bool {
+	msg.TargetPlayerID, msg.Message = formatID(p.TargetPlayerID), p.Message
+	return p.TargetPlayerID > 0 && p.Message != ""
+}
+
+type WSSubstitutePayload struct {
+	TargetPlayerID     int64 `json:"target_player_id"`
+	SubstitutePlayerID int64 `json:"substitute_player_id"`
+}
+
+func (p *WSSubstitutePayload) fill(msg *WSMessage) bool {
+	msg.TargetPlayerID, msg.SubstitutePlayerID = formatID(p.TargetPlayerID), formatID(p.SubstitutePlayerID)
+	return p.TargetPlayerID > 0 && p.SubstitutePlayerID > 0
+}
+
+// WSModeratorPayload is a host override; RoleID is only read by moderator_set_role.
+type WSModeratorPayload struct {
+	TargetPlayerID int64 `json:"target_player_id"`
+	RoleID         int64 `json:"role_id,omitempty"`
+}
+
+func (p *WSModeratorPayload) fill(msg *WSMessage) bool {
+	msg.TargetPlayerID, msg.RoleID = formatID(p.TargetPlayerID), formatID(p.RoleID)
+	return p.TargetPlayerID > 0 && p.RoleID >= 0
+}
+
+type WSChatPayload struct {
+	Channel string `json:"channel"`
+	Message string `json:"message"`
+}
+
+func (p *WSChatPayload) fill(msg *WSMessage) bool {
+	msg.Channel, msg.Message = p.Channel, p.Message
+	return p.Channel != "" && p.Message != ""
+}
+
+type WSSecondsPayload struct {
+	Seconds int `json:"seconds"` // 0 switches the timer off
+}
+
+func (p *WSSecondsPayload) fill(msg *WSMessage) bool {
+	msg.Seconds = strconv.Itoa(p.Seconds)
+	return p.Seconds >= 0
+}
+
+type WSRevealPayload struct {
+	Reveal string `json:"reveal"`
+}
+
+func (p *WSRevealPayload) fill(msg *WSMessage) bool {
+	msg.Reveal = p.Reveal
+	return p.Reveal != ""
+}
+
+type WSTextPayload struct {
+	Message string `json:"message,omitempty"`
+}
+
+func (p *WSTextPayload) fill(msg *WSMessage) bool {
+	msg.Message = p.Message
+	return true
+}
+
+type WSRolePayload struct {
+	RoleID int64 `json:"role_id"`
+}
+
+func (p *WSRolePayload) fill(msg *WSMessage) bool {
+	msg.RoleID = formatID(p.RoleID)
+	return p.RoleID > 0
+}
+
+type WSSurveyPayload struct {
+	DeathTheory string `json:"death_theory,omitempty"`
+	Notes       string `json:"notes,omitempty"`
+}
+
+func (p *WSSurveyPayload) fill(msg *WSMessage) bool {
+	msg.DeathTheory, msg.Notes = p.DeathTheory, p.Notes
+	return true
+}
+
+// wsPayloads lists every action handleWSMessage takes with the zero value of its payload.
+// A new action goes here as well as into the switch.
+var wsPayloads = map[string]wsPayload{
+	// lobby
+	"update_role":              &WSRoleCountPayload{},
+	"create_role":              &WSCreateRolePayload{},
+	"toggle_pack":              &WSPackPayload{},
+	"start_game":               &WSEmptyPayload{},
+	"transfer_host":            &WSTargetPayload{},
+	"save_preset":              &WSPresetPayload{},
+	"load_preset":              &WSPresetPayload{},
+	"suggest_setup":            &WSEmptyPayload{},
+	"toggle_observer":          &WSEmptyPayload{},
+	"toggle_observers_see_all": &WSEmptyPayload{},
+	"toggle_narrator_mode":     &WSEmptyPayload{},
+	"toggle_graveyard_talk":    &WSEmptyPayload{},
+	"toggle_whispers":          &WSEmptyPayload{},
+	"toggle_chat_filter":       &WSEmptyPayload{},
+	"set_webhook":              &WSWebhookPayload{},
+	"set_night_timer":          &WSSecondsPayload{},
+	"set_day_timer":            &WSSecondsPayload{},
+	"set_role_reveal":          &WSRevealPayload{},
+	"toggle_nominations":       &WSEmptyPayload{},
+	"toggle_trials":            &WSEmptyPayload{},
+	"toggle_secret_votes":      &WSEmptyPayload{},
+	"toggle_runoffs":           &WSEmptyPayload{},
+	"toggle_mayor_election":    &WSEmptyPayload{},
+	"toggle_last_words":        &WSEmptyPayload{},
+	"toggle_ai":                &WSEmptyPayload{},
+	"new_game":                 &WSEmptyPayload{},
+
+	// the host and the narrator
+	"toggle_mute":           &WSTargetPayload{},
+	"advance_phase":         &WSEmptyPayload{},
+	"substitute_player":     &WSSubstitutePayload{},
+	"issue_rejoin_code":     &WSTargetPayload{},
+	"pause_game":            &WSEmptyPayload{},
+	"resume_game":           &WSEmptyPayload{},
+	"undo_resolution":       &WSEmptyPayload{},
+	"abort_game":            &WSEmptyPayload{},
+	"moderator_kill":        &WSModeratorPayload{},
+	"moderator_revive":      &WSModeratorPayload{},
+	"moderator_set_role":    &WSModeratorPayload{},
+	"moderator_force_phase": &WSEmptyPayload{},
+
+	// chat
+	"chat_send": &WSChatPayload{},
+	"whisper":   &WSWhisperPayload{},
+
+	// setup and night
+	"thief_take":            &WSRolePayload{},
+	"thief_keep":            &WSRolePayload{},
+	"werewolf_vote":         &WSTargetPayload{},
+	"werewolf_vote_2":       &WSTargetPayload{},
+	"werewolf_pass":         &WSEmptyPayload{},
+	"werewolf_pass_2":       &WSEmptyPayload{},
+	"werewolf_end_vote":     &WSEmptyPayload{},
+	"werewolf_end_vote_2":   &WSEmptyPayload{},
+	"alpha_bite":            &WSEmptyPayload{},
+	"seer_select":           &WSTargetPayload{},
+	"seer_investigate":      &WSEmptyPayload{},
+	"seer_skip":             &WSEmptyPayload{},
+	"aura_seer_select":      &WSTargetPayload{},
+	"aura_seer_investigate": &WSEmptyPayload{},
+	"spellcaster_select":    &WSTargetPayload{},
+	"spellcaster_silence":   &WSEmptyPayload{},
+	"fox_select":            &WSTargetPayload{},
+	"fox_sniff":             &WSEmptyPayload{},
+	"serial_killer_select":  &WSTargetPayload{},
+	"serial_killer_kill":    &WSEmptyPayload{},
+	"piper_choose":          &WSTargetPayload{},
+	"piper_charm":           &WSEmptyPayload{},
+	"white_wolf_select":     &WSTargetPayload{},
+	"white_wolf_kill":       &WSEmptyPayload{},
+	"white_wolf_spare":      &WSEmptyPayload{},
+	"sorceress_select":      &WSTargetPayload{},
+	"sorceress_investigate": &WSEmptyPayload{},
+	"doctor_select":         &WSTargetPayload{},
+	"doctor_protect":        &WSEmptyPayload{},
+	"doctor_skip":           &WSEmptyPayload{},
+	"guard_select":          &WSTargetPayload{},
+	"guard_protect":         &WSEmptyPayload{},
+	"guard_skip":            &WSEmptyPayload{},
+	"bodyguard_select":      &WSTargetPayload{},
+	"bodyguard_guard":       &WSEmptyPayload{},
+	"witch_select_heal":     &WSTargetPayload{},
+	"witch_select_poison":   &WSTargetPayload{},
+	"witch_apply":           &WSEmptyPayload{},
+	"cupid_choose":          &WSTargetPayload{},
+	"cupid_link":            &WSEmptyPayload{},
+	"doppelganger_select":   &WSTargetPayload{},
+	"doppelganger_copy":     &WSEmptyPayload{},
+	"wild_child_select":     &WSTargetPayload{},
+	"wild_child_choose":     &WSEmptyPayload{},
+	"custom_select":         &WSTargetPayload{},
+	"custom_act":            &WSEmptyPayload{},
+	"night_survey_suspect":  &WSTargetPayload{},
+	"night_survey":          &WSSurveyPayload{},
+
+	// day
+	"mayor_vote":        &WSTargetPayload{},
+	"last_words":        &WSTextPayload{},
+	"day_vote":          &WSTargetPayload{},
+	"day_pass":          &WSEmptyPayload{},
+	"day_nominate":      &WSTargetPayload{},
+	"day_second":        &WSTargetPayload{},
+	"day_open_vote":     &WSEmptyPayload{},
+	"day_defense":       &WSTextPayload{},
+	"day_verdict":       &WSVerdictPayload{},
+	"priest_select":     &WSTargetPayload{},
+	"priest_throw":      &WSEmptyPayload{},
+	"day_end_vote":      &WSEmptyPayload{},
+	"scapegoat_toggle":  &WSTargetPayload{},
+	"scapegoat_confirm": &WSEmptyPayload{},
+	"hunter_select":     &WSTargetPayload{},
+	"hunter_revenge":    &WSEmptyPayload{},
+}
+
+// decodeWSMessage reads either form of message. enveloped reports which one it was, so the
+// caller can answer in kind.
+func decodeWSMessage(raw []byte) (msg WSMessage, enveloped bool, decodeErr *wsDecodeError) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return msg, false, &wsDecodeError{"invalid_message", "err_invalid_message"}
+	}
+	if _, ok := probe["type"]; !ok {
+		// the pages' flat form; htmx adds its HEADERS, which nothing reads
+		if err := json.Unmarshal(raw, &msg); err != nil || msg.Action == "" {
+			return msg, false, &wsDecodeError{"invalid_message", "err_invalid_message"}
+		}
+		if wsPayloads[msg.Action] == nil {
+			return msg, false, &wsDecodeError{"unknown_action", "err_unknown_action"}
+		}
+		return msg, false, nil
+	}
+
+	var env WSEnvelope
+	if err := json.Unmarshal(raw, &env); err != nil || env.Type == "" {
+		return msg, true, &wsDecodeError{"invalid_message", "err_invalid_message"}
+	}
+	if env.Version != wsProtocolVersion {
+		return msg, true, &wsDecodeError{"unsupported_version", "err_unsupported_version"}
+	}
+	zero := wsPayloads[env.Type]
+	if zero == nil {
+		return msg, true, &wsDecodeError{"unknown_action", "err_unknown_action"}
+	}
+	payload := reflect.New(reflect.TypeOf(zero).Elem()).Interface().(wsPayload)
+	if len(env.Payload) > 0 && string(env.Payload) != "null" {
+		dec := json.NewDecoder(bytes.NewReader(env.Payload))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(payload); err != nil {
+			return msg, true, &wsDecodeError{"invalid_payload", "err_invalid_payload"}
+		}
+	}
+	msg.Action = env.Type
+	if !payload.fill(&msg) {
+		return msg, true, &wsDecodeError{"invalid_payload", "err_invalid_payload"}
+	}
+	return msg, true, nil
+}
+
+// sendWSError answers a message that was not taken: an error envelope, or a toast on the page.
+func (h *Hub) sendWSError(playerID int64, enveloped bool, decodeErr *wsDecodeError) {
+	lang := h.getPlayerLang(playerID)
+	if !enveloped {
+		h.sendErrorToast(playerID, T(lang, decodeErr.Key))
+		return
+	}
+	payload, _ := json.Marshal(WSError{Code: decodeErr.Code, Message: T(lang, decodeErr.Key)})
+	reply, err := json.Marshal(WSEnvelope{Type: "error", Version: wsProtocolVersion, Payload: payload})
+	if err != nil {
+		h.logError("sendWSError: json.Marshal", err)
+		return
+	}
+	h.sendToPlayer(playerID, reply)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// ============================================================================
+// WebSocket Protocol Tests
+// ============================================================================
+
+func TestDecodeWSMessage(t *testing.T) {
+	t.Parallel()
+
+	msg, enveloped, decodeErr := decodeWSMessage([]byte(`{"type":"moderator_set_role","version":1,"payload":{"target_player_id":4,"role_id":2}}`))
+	if decodeErr != nil || !enveloped {
+		t.Fatalf("a valid envelope should decode, got %v", decodeErr)
+	}
+	if msg.Action != "moderator_set_role" || msg.TargetPlayerID != "4" || msg.RoleID != "2" {
+		t.Errorf("the payload should fill the message, got %+v", msg)
+	}
+
+	msg, enveloped, decodeErr = decodeWSMessage([]byte(`{"action":"seer_select","target_player_id":"4","HEADERS":{"HX-Request":"true"}}`))
+	if decodeErr != nil || enveloped || msg.TargetPlayerID != "4" {
+		t.Errorf("the pages' flat form should still decode, got %+v (%v)", msg, decodeErr)
+	}
+
+	for raw, code := range map[string]string{
+		`not json`:                           "invalid_message",
+		`{"target_player_id":"4"}`:           "invalid_message",
+		`{"action":"fly"}`:                   "unknown_action",
+		`{"type":"fly","version":1}`:         "unknown_action",
+		`{"type":"seer_skip","version":2}`:   "unsupported_version",
+		`{"type":"seer_skip"}`:               "unsupported_version",
+		`{"type":"seer_select","version":1}`: "invalid_payload",
+		`{"type":"seer_select","version":1,"payload":{"target_player_id":"4"}}`:     "invalid_payload",
+		`{"type":"seer_select","version":1,"payload":{"target_player_id":4,"x":1}}`: "invalid_payload",
+		`{"type":"update_role","version":1,"payload":{"role_id":3,"delta":5}}`:      "invalid_payload",
+	} {
+		if _, _, decodeErr := decodeWSMessage([]byte(raw)); decodeErr == nil || decodeErr.Code != code {
+			t.Errorf("%s: expected %s, got %v", raw, code, decodeErr)
+		}
+	}
+}
+
+func TestWSPayloadsCoverTheActionSets(t *testing.T) {
+	t.Parallel()
+	for _, set := range []map[string]bool{hostOnlyActions, pauseExemptActions} {
+		for action := range set {
+			if wsPayloads[action] == nil {
+				t.Errorf("%s has no payload in wsPayloads", action)
+			}
+		}
+	}
+}
+
+func TestWSEnvelopeDrivesTheGame(t *testing.T) {
+	t.Parallel()
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ids := ctx.seedGame("day", 1,
+		[]string{"Host", "Wolf", "V1", "V2"},
+		[]string{RoleVillager, RoleWerewolf, RoleVillager, RoleVillager})
+	host, v1 := ids[0], ids[2]
+
+	// a client the hub can answer
+	h := ctx.hub()
+	client := &Client{hub: h, conn: &websocket.Conn{}, playerID: host, send: make(chan hubMsg, 8)}
+	h.mu.Lock()
+	h.addClient(client)
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		h.removeClient(client)
+		h.mu.Unlock()
+	}()
+
+	handleWSMessage(client, []byte(fmt.Sprintf(`{"type":"moderator_kill","version":1,"payload":{"target_player_id":%d}}`, v1)))
+	if ctx.isPlayerAlive(v1) {
+		t.Fatal("the envelope should reach the game")
+	}
+	for len(client.send) > 0 {
+		<-client.send
+	}
+
+	handleWSMessage(client, []byte(`{"type":"moderator_kill","version":1,"payload":{}}`))
+	select {
+	case reply := <-client.send:
+		var env WSEnvelope
+		var wsErr WSError
+		if err := json.Unmarshal(reply.data, &env); err != nil || env.Type != "error" || env.Version != wsProtocolVersion {
+			t.Fatalf("an envelope client should get an error envelope, got %s", reply.data)
+		}
+		if json.Unmarshal(env.Payload, &wsErr); wsErr.Code != "invalid_payload" || wsErr.Message == "" {
+			t.Errorf("the error should say why, got %s", env.Payload)
+		}
+	default:
+		t.Fatal("a message that was not taken should be answered")
+	}
+
+	handleWSMessage(client, []byte(`{"action":"fly"}`))
+	select {
+	case reply := <-client.send:
+		if !strings.Contains(string(reply.data), T("en", "err_unknown_action")) {
+			t.Errorf("the page should get an error toast, got %s", reply.data)
+		}
+	default:
+		t.Fatal("an unknown action should be answered, not dropped")
+	}
+}